@@ -10,21 +10,64 @@ import (
 	"os"
 	"sync"
 
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/aicmd"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/bundle"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/cachecmd"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/compare"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/configcmd"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/convert"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/cost"
 	"github.com/Nexlayer/nexlayer-cli/pkg/commands/deploy"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/destroy"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/dev"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/doctor"
 	"github.com/Nexlayer/nexlayer-cli/pkg/commands/domain"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/drift"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/execcmd"
 	"github.com/Nexlayer/nexlayer-cli/pkg/commands/feedback"
 	"github.com/Nexlayer/nexlayer-cli/pkg/commands/info"
 	"github.com/Nexlayer/nexlayer-cli/pkg/commands/initcmd"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/lint"
 	"github.com/Nexlayer/nexlayer-cli/pkg/commands/list"
 	"github.com/Nexlayer/nexlayer-cli/pkg/commands/login"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/logs"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/migrate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/monitor"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/plugincmd"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/portforward"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/promote"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/quota"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/regions"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/rollback"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/scale"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/schemacmd"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/secrets"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/seed"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/serve"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/snapshot"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/testcmd"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/traffic"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/tunnel"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/upgrade"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/validate"
 	"github.com/Nexlayer/nexlayer-cli/pkg/commands/version"
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/volume"
 	"github.com/Nexlayer/nexlayer-cli/pkg/commands/watch"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/profile"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/update"
 	"github.com/Nexlayer/nexlayer-cli/pkg/errors"
 	"github.com/Nexlayer/nexlayer-cli/pkg/observability"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	pkgversion "github.com/Nexlayer/nexlayer-cli/pkg/version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 )
 
 // Package cmd provides the command-line interface for the Nexlayer CLI.
@@ -38,12 +81,22 @@ var (
 	rootCmd *cobra.Command
 	// jsonOutput toggles JSON-formatted output for errors and responses.
 	jsonOutput bool
+	// outputFormat selects text, json or yaml output for command results.
+	outputFormat string
+	// offlineMode refuses network requests, for air-gapped machines.
+	offlineMode bool
+	// profileName selects the profile of ~/.nexlayer/config.yaml to use.
+	profileName string
+	// insecure turns off the verification of the API's TLS certificate.
+	insecure bool
+	// caCert is a PEM file of extra certificate authorities to trust.
+	caCert string
 )
 
 // init initializes the logger, sets default config values, and creates the root command.
 func init() {
-	// Enable colors for Windows terminals.
-	os.Setenv("TERM", "xterm-256color")
+	// Fall back to plain output on consoles without ANSI support (e.g. legacy cmd.exe).
+	ui.ConfigureConsole()
 
 	// Initialize logger.
 	logger = observability.NewLogger(
@@ -72,14 +125,39 @@ func NewRootCommand() *cobra.Command {
 		Use:   "nexlayer",
 		Short: "Nexlayer CLI - Deploy applications with ease",
 		Long:  `Nexlayer CLI – Deploy Full-Stack Applications in Seconds ⚡️`,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			// Load configuration only when needed.
 			if cmd.Name() != "help" {
 				lazyInitConfig()
 			}
 
+			if offlineMode {
+				offline.Enable()
+			}
+
+			// Some commands declare their own --json, which shadows ours
+			format := outputFormat
+			if asJSON, _ := cmd.Flags().GetBool("json"); asJSON || jsonOutput {
+				format = ui.OutputJSON
+			}
+			if err := ui.SetOutputFormat(format); err != nil {
+				return err
+			}
+			if ui.Structured() {
+				// Keep request traces out of the results
+				apiClient.SetDebugOutput(os.Stderr)
+			}
+
+			if err := applyProfile(apiClient, profileName, insecure, caCert); err != nil {
+				return err
+			}
+
 			// Set a background context.
 			cmd.SetContext(context.Background())
+			return nil
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			printUpdateNotice(cmd)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Check if version flag is set
@@ -98,24 +176,69 @@ func NewRootCommand() *cobra.Command {
 	}
 
 	// Add global flags
-	cmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output response in JSON format")
+	cmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output response in JSON format (same as --output json)")
+	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", ui.OutputText, "Format of the results: text, json or yaml; other output goes to stderr")
+	cmd.PersistentFlags().BoolVar(&offlineMode, "offline", false, "Work without network access (also NEXLAYER_OFFLINE=1)")
+	cmd.PersistentFlags().StringVar(&profileName, "profile", "", "Profile of ~/.nexlayer/config.yaml to use (also NEXLAYER_PROFILE)")
+	cmd.PersistentFlags().BoolVar(&insecure, "insecure", false, "Skip the verification of the API's TLS certificate")
+	cmd.PersistentFlags().StringVar(&caCert, "ca-cert", "", "PEM file of certificate authorities to trust, besides the system ones")
 	cmd.Flags().Bool("version", false, "Print version information")
 
-	// Disable auto-generation of completion command
+	// Replace cobra's completion command with ours
 	cmd.CompletionOptions.DisableDefaultCmd = true
 
 	// Register commands in desired order
 	cmd.AddCommand(
 		initcmd.NewCommand(),
 		deploy.NewCommand(apiClient),
+		destroy.NewCommand(apiClient),
+		validate.NewCommand(),
+		lint.NewCommand(),
+		schemacmd.NewCommand(),
+		dev.NewCommand(),
+		tunnel.NewCommand(apiClient),
+		portforward.NewCommand(apiClient),
 		list.NewListCommand(apiClient),
 		info.NewInfoCommand(apiClient),
+		logs.NewCommand(apiClient),
+		execcmd.NewCommand(apiClient),
+		compare.NewCommand(apiClient),
+		drift.NewCommand(apiClient),
 		domain.NewDomainCommand(apiClient),
+		volume.NewCommand(apiClient),
+		snapshot.NewCommand(apiClient),
+		rollback.NewHistoryCommand(),
+		rollback.NewCommand(apiClient),
+		secrets.NewCommand(apiClient),
+		promote.NewCommand(apiClient),
+		migrate.NewCommand(apiClient),
+		seed.NewCommand(apiClient),
+		testcmd.NewCommand(apiClient),
+		traffic.NewCommand(apiClient),
+		scale.NewCommand(apiClient),
+		regions.NewCommand(apiClient),
 		login.NewLoginCommand(apiClient),
-		watch.NewCommand(),
+		watch.NewCommand(apiClient),
+		monitor.NewCommand(apiClient),
 		feedback.NewFeedbackCommand(apiClient),
+		template.NewTemplateCommand(apiClient),
+		cost.NewCommand(apiClient),
+		quota.NewCommand(apiClient),
+		configcmd.NewCommand(apiClient),
+		bundle.NewExportCommand(apiClient),
+		bundle.NewImportCommand(apiClient),
+		bundle.NewBundleCommand(),
+		convert.NewConvertCommand(),
+		serve.NewCommand(apiClient),
+		doctor.NewCommand(apiClient),
+		aicmd.NewCommand(apiClient),
+		cachecmd.NewCommand(),
+		plugincmd.NewCommand(),
+		completion.NewCommand(),
+		upgrade.NewCommand(),
 		version.NewCommand(),
 	)
+	plugincmd.AddPluginCommands(cmd)
 
 	// Disable suggestions and help command
 	cmd.DisableSuggestions = true
@@ -125,13 +248,58 @@ func NewRootCommand() *cobra.Command {
 	cmd.SetUsageTemplate(`Core Commands:
   init        Initialize a new project (auto-detects type)
   deploy      Deploy an application (uses nexlayer.yaml if present)
+  destroy     Remove the deployments of an application
+  validate    Check a deployment file without deploying it
+  lint        Check a deployment file for errors and bad practices
+  schema      Export the JSON Schema of nexlayer.yaml or migrate old files
+  dev         Run the application locally with Docker
+  tunnel      Route a deployed pod's traffic to a local process
+  port-forward Reach a port of a deployed pod from this machine
   list        List active deployments
   info        Get deployment details <namespace> <appID>
+  logs        Show or stream the logs of a deployment
+  exec        Run a command or open a shell in a running pod
+  compare     Compare two live deployments
+  drift       Detect changes made outside nexlayer.yaml
   domain      Manage custom domains
+  volume      Snapshot and restore pod volumes
+  snapshot    Snapshot and restore a deployment with its data
+  history     List the deployed revisions of an application
+  rollback    Redeploy a previous revision of an application
+  secrets     Manage the secret values of an application
+  promote     Promote the release of one environment to another
+  migrate     Run database migrations
+  seed        Load seed data into databases
+  test        Run synthetic HTTP checks against a deployment
+  traffic     Split traffic between stable and canary versions
+  scale       Change the number of replicas of a running pod
+  regions     Show where an application runs
   login       Authenticate with Nexlayer
-  watch       Monitor project changes and update configuration
+  watch       Monitor project changes, or a deployment with 'watch dashboard'
+  monitor     Check a deployment and send notifications
   feedback    Send CLI feedback
+  template    Publish, search and pull deployment templates
+  cost        Estimate or report the monthly cost of a deployment
+  quota       Show plan limits and current usage
+  config      Generate nexlayer.yaml from a live deployment
+  export      Export a deployment to a bundle, Kubernetes, Helm or Compose
+  import      Recreate a deployment from a bundle
+  bundle      Package an application for air-gapped deployment
+  convert     Convert Compose, Kubernetes, Helm or Procfile projects to nexlayer.yaml
+  serve       Serve a local REST API for validate, convert, deploy and status
+  doctor      Diagnose problems with your Nexlayer setup
+  ai          Diagnose deployments and generate configurations with AI
+  cache       Manage the cached AI and detection results
+  plugin      Create, install, update, list and remove plugins
+  completion  Generate the shell completion script
+  upgrade     Upgrade the CLI to the latest release
   version     Print the version number of Nexlayer CLI
+{{- $plugins := false}}{{range .Commands}}{{if index .Annotations "nexlayer-plugin"}}{{$plugins = true}}{{end}}{{end}}
+{{- if $plugins}}
+
+Plugin Commands:{{range .Commands}}{{if index .Annotations "nexlayer-plugin"}}
+  {{rpad .Name 11}} {{.Short}}{{end}}{{end}}
+{{- end}}
 
 Flags:
   -h, --help         Show help for commands
@@ -139,6 +307,11 @@ Flags:
 
 Global Flags:
   --json          Output response in JSON format
+  -o, --output    Format of the results: text, json or yaml
+  --offline       Work without network access (also NEXLAYER_OFFLINE=1)
+  --profile       Profile of ~/.nexlayer/config.yaml to use (also NEXLAYER_PROFILE)
+  --ca-cert       PEM file of certificate authorities to trust
+  --insecure      Skip the verification of the API's TLS certificate
 
 For more details:
   {{.CommandPath}} [command] --help
@@ -173,6 +346,61 @@ func Execute() {
 	}
 }
 
+// printUpdateNotice tells the user on stderr when a newer release is available.
+// It stays quiet for machine-readable output, offline, in CI and when stdout
+// is not a terminal, and for commands that already deal with versions. It
+// also removes the binary a Windows upgrade left behind.
+func printUpdateNotice(cmd *cobra.Command) {
+	switch cmd.Name() {
+	case "upgrade", "version", "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return
+	}
+	update.RemoveReplaced()
+	// Scripts and CI jobs neither see the notice nor should wait on GitHub
+	if ui.Structured() || offline.Enabled() || os.Getenv("CI") != "" || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return
+	}
+	current := pkgversion.GetVersion()
+	latest := update.NewUpdater().Notice(cmd.Context(), current, update.DefaultChannel(current))
+	if latest != "" {
+		fmt.Fprintf(os.Stderr, "\n%s A new version of Nexlayer CLI is available: %s (current %s)\n", ui.Symbols().Info, latest, current)
+		fmt.Fprintln(os.Stderr, "  Run 'nexlayer upgrade' to install it")
+	}
+}
+
+// applyProfile points the API client, and the configuration the other
+// commands read, at the selected profile. The TLS flags take precedence over
+// the settings of the profile.
+func applyProfile(client *api.Client, name string, insecure bool, caCert string) error {
+	profiles, err := profile.Load()
+	if err != nil {
+		return err
+	}
+	p, err := profiles.Active(name)
+	if err != nil {
+		return err
+	}
+	token := p.ResolveToken()
+	client.SetBaseURL(p.URL)
+	client.SetToken(token)
+	client.SetAppID(p.AppID)
+	if caCert == "" {
+		caCert = p.CACert
+	}
+	if err := client.SetTLS(insecure || p.Insecure, caCert); err != nil {
+		return err
+	}
+	if insecure || p.Insecure {
+		fmt.Fprintf(os.Stderr, "%s TLS certificate verification is off for %s\n", ui.Symbols().Warning, p.URL)
+	}
+	config.SetAPIURL(p.URL)
+	config.SetToken(token)
+	if p.LLM != nil {
+		llm.Configure(*p.LLM)
+	}
+	return nil
+}
+
 // lazyInitConfig loads configuration files and environment variables.
 func lazyInitConfig() {
 	configOnce.Do(func() {
@@ -9,9 +9,12 @@
 package main
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/Nexlayer/nexlayer-cli/cmd"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
 )
 
 // main is the entry point of the Nexlayer CLI.
@@ -19,6 +22,10 @@ import (
 // configuration loading, and command execution.
 func main() {
 	if err := cmd.NewRootCommand().Execute(); err != nil {
+		// API errors of a known kind come with how to fix them
+		if hint := api.Remediation(err); hint != "" {
+			fmt.Fprintf(os.Stderr, "%s %s\n", ui.Symbols().Info, hint)
+		}
 		os.Exit(1)
 	}
 }
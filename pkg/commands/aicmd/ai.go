@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package aicmd implements the ai command, which answers with the LLM of the
+// active profile or, without one, the built-in analysis
+package aicmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/ai"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
+	"github.com/Nexlayer/nexlayer-cli/pkg/knowledge"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the ai command
+func NewCommand(client api.APIClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ai",
+		Short: "Diagnose deployments and generate configurations with AI",
+		Long: `Use a large language model to work with deployments.
+
+The model is the one of the active profile, set with
+'nexlayer config set-profile --llm-provider', or NEXLAYER_LLM_PROVIDER.
+Without one, or when it cannot be reached, ai diagnose falls back to the
+built-in analysis and ai dockerfile to the built-in Dockerfiles; ai generate
+needs a model.
+
+Examples:
+  nexlayer ai diagnose my-app
+  nexlayer ai generate "a FastAPI app with Postgres and Redis"
+  nexlayer ai dockerfile ./api`,
+	}
+
+	cmd.AddCommand(
+		newDiagnoseCommand(client),
+		newGenerateCommand(),
+		newDockerfileCommand(),
+	)
+
+	return cmd
+}
+
+// newEnhancer returns an enhancer asking the LLM of the active profile, and
+// a name for it in messages
+func newEnhancer() (*ai.Enhancer, string) {
+	provider, err := llm.Default()
+	if err != nil {
+		if !errors.Is(err, llm.ErrNotConfigured) {
+			ui.RenderWarning(err.Error() + "; using the built-in analysis")
+		}
+		return ai.NewEnhancer(nil, nil), "the built-in analysis"
+	}
+
+	metadataDir := ""
+	if home, err := os.UserHomeDir(); err == nil {
+		metadataDir = filepath.Join(home, ".nexlayer", "metadata")
+	}
+	enricher := knowledge.NewLLMEnricher(knowledge.NewGraph(), metadataDir).WithProvider(provider)
+	return ai.NewEnhancer(enricher, nil), provider.Name()
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package aicmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/ai"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/history"
+	corelogs "github.com/Nexlayer/nexlayer-cli/pkg/core/logs"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newDiagnoseCommand creates the diagnose subcommand
+func newDiagnoseCommand(client api.APIClient) *cobra.Command {
+	var file, namespace string
+	var tail int
+
+	cmd := &cobra.Command{
+		Use:   "diagnose [app]",
+		Short: "Find the probable root causes of a failing deployment",
+		Long: `Find out why a deployment failed, keeps crashing or its URL answers 502.
+
+The status of the pods, their recent logs, the configuration deployed and a
+request to the application URL are gathered and analyzed, and the probable
+root causes are listed, most likely first, with concrete fixes. With an LLM
+in the active profile the model ranks the causes; the configuration is sent
+without its secrets, but the logs are sent as they are.
+
+The deployment is the last one of the application recorded by
+'nexlayer deploy' on this machine, or the one in --namespace. The
+application defaults to the one named in --file.
+
+Examples:
+  nexlayer ai diagnose
+  nexlayer ai diagnose my-app
+  nexlayer ai diagnose --namespace my-app-ns --tail 500
+  nexlayer ai diagnose my-app --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ev, target, err := gatherEvidence(cmd, client, args, file, namespace, tail)
+			if err != nil {
+				return err
+			}
+
+			enhancer, model := newEnhancer()
+			defer enhancer.Shutdown(context.Background())
+			if !ui.Structured() {
+				fmt.Fprintf(cmd.OutOrStdout(), "🔎 Diagnosing %s with %s...\n", target, model)
+			}
+			diagnosis, err := enhancer.Diagnose(cmd.Context(), *ev)
+			if err != nil {
+				return err
+			}
+			if ui.Structured() {
+				if diagnosis.Causes == nil {
+					diagnosis.Causes = []ai.Cause{}
+				}
+				return ui.WriteOutput(diagnosis)
+			}
+			return render(cmd, target, diagnosis)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration naming the application")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace of the deployment, instead of the last one recorded")
+	cmd.Flags().IntVarP(&tail, "tail", "n", 200, "Number of log lines to analyze from the end of the logs")
+
+	return cmd
+}
+
+// gatherEvidence collects the status, logs and configuration of the
+// deployment to diagnose, and names it for messages. What cannot be
+// fetched is left out with a warning.
+func gatherEvidence(cmd *cobra.Command, client api.APIClient, args []string, file, namespace string, tail int) (*ai.Evidence, string, error) {
+	ev := &ai.Evidence{}
+	var rev *history.Revision
+	if namespace == "" || len(args) > 0 {
+		app, err := deployment.ResolveApp(args, file)
+		if err != nil {
+			return nil, "", err
+		}
+		revisions, err := history.List(app)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(revisions) == 0 && namespace == "" {
+			return nil, "", fmt.Errorf("no deployments of %s recorded on this machine; use --namespace", app)
+		}
+		if len(revisions) > 0 {
+			rev = &revisions[0]
+			if namespace == "" {
+				namespace = rev.Namespace
+			}
+			ev.URL = rev.URL
+			if ev.Config, err = rev.Parse(); err != nil {
+				ui.RenderWarning(err.Error())
+			}
+		}
+	}
+	if ev.Config == nil {
+		if config, _, err := deployment.Load(file); err == nil {
+			ev.Config = config
+		}
+	}
+	if namespace == "" {
+		// A deployment that was rejected never got a namespace
+		ev.Error = fmt.Sprintf("revision %d of %s was not started", rev.ID, rev.Application)
+		return ev, rev.Application, nil
+	}
+
+	ctx := cmd.Context()
+	info, err := client.GetDeploymentInfo(ctx, namespace)
+	switch {
+	case err != nil:
+		ui.RenderWarning(fmt.Sprintf("Could not get the status of %s: %v", namespace, err))
+		ev.Error = err.Error()
+	default:
+		ev.Deployment = &info.Data
+		if info.Data.URL != "" {
+			ev.URL = info.Data.URL
+		}
+	}
+	if rev != nil && rev.Status == history.StatusFailed && ev.Error == "" {
+		ev.Error = fmt.Sprintf("revision %d of %s failed", rev.ID, rev.Application)
+	}
+
+	raw, err := client.GetLogs(ctx, namespace, "", false, tail)
+	if err != nil {
+		ui.RenderWarning(fmt.Sprintf("Could not get the logs of %s: %v", namespace, err))
+	}
+	ev.Logs = make([]apischema.LogLine, 0, len(raw))
+	for _, r := range raw {
+		ev.Logs = append(ev.Logs, corelogs.ParseLine(r))
+	}
+
+	if ev.URL != "" && !offline.Enabled() {
+		reachCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+		defer cancel()
+		if err := deployment.Reachable(reachCtx, ev.URL); err != nil {
+			ev.URLError = err.Error()
+		}
+	}
+	return ev, namespace, nil
+}
+
+// render prints the causes of a diagnosis, most likely first
+func render(cmd *cobra.Command, target string, d *ai.Diagnosis) error {
+	out := cmd.OutOrStdout()
+	if d.Fallback != "" {
+		ui.RenderWarning(fmt.Sprintf("The model did not answer (%s); showing the built-in analysis", d.Fallback))
+	}
+	if len(d.Causes) == 0 {
+		fmt.Fprintf(out, "\n%s Found no problems in the status, logs and configuration of %s\n", ui.Symbols().Success, target)
+		return nil
+	}
+
+	ranked := "the built-in analysis"
+	if d.Model != "" {
+		ranked = d.Model
+	}
+	fmt.Fprintf(out, "\nProbable root causes, most likely first (%s):\n", ranked)
+	for i, c := range d.Causes {
+		summary := c.Summary
+		if c.Pod != "" && !strings.HasPrefix(summary, c.Pod+" ") {
+			summary = c.Pod + ": " + summary
+		}
+		fmt.Fprintf(out, "\n%d. %s\n", i+1, summary)
+		if c.Evidence != "" {
+			fmt.Fprintf(out, "   Evidence: %s\n", c.Evidence)
+		}
+		for _, fix := range c.Fixes {
+			fmt.Fprintf(out, "   %s %s\n", ui.Symbols().Bullet, fix)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package aicmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/ai"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/build"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// buildOutputLines bounds the output of a failed build shown to the model
+const buildOutputLines = 20
+
+// DockerfileResult is the outcome of ai dockerfile
+type DockerfileResult struct {
+	File  string `json:"file"`
+	Pod   string `json:"pod,omitempty"`   // pod of --file pointed at the image
+	Image string `json:"image,omitempty"` // image the pod runs
+	Valid bool   `json:"valid"`
+	*ai.GeneratedDockerfile
+}
+
+// newDockerfileCommand creates the dockerfile subcommand
+func newDockerfileCommand() *cobra.Command {
+	var file, podName string
+	var port, attempts int
+	var noBuild, force bool
+
+	cmd := &cobra.Command{
+		Use:   "dockerfile [directory]",
+		Short: "Write a multi-stage Dockerfile for a Node.js, Python or Go project",
+		Long: `Write the Dockerfile of a Node.js, Python or Go project that has none, tuned
+to the framework it uses, e.g. Next.js, Express, FastAPI, Django or Gin.
+
+The LLM of the active profile improves on the built-in Dockerfile of the
+framework, which is used as it is without one. When docker is available the
+Dockerfile is built to check it, and the model is asked to fix what fails,
+up to --attempts times; --no-build skips the build.
+
+The pod of nexlayer.yaml running the project is then pointed at the image
+built from the Dockerfile, for 'nexlayer deploy --watch-files' to build and
+push. The pod is the one named by --pod, or the first one served at a path.
+
+Examples:
+  nexlayer ai dockerfile
+  nexlayer ai dockerfile ./api --pod api
+  nexlayer ai dockerfile --port 8000 --no-build`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			dockerfile := filepath.Join(dir, schema.DefaultDockerfile)
+			if _, err := os.Stat(dockerfile); err == nil && !force {
+				return fmt.Errorf("%s already exists; use --force to overwrite it", dockerfile)
+			}
+			if !cmd.Flags().Changed("file") {
+				file = filepath.Join(dir, file)
+			}
+
+			// The pod running the project tells the port and names the image
+			var config *schema.NexlayerYAML
+			var pod *schema.Pod
+			if _, err := os.Stat(file); err == nil {
+				if config, _, err = deployment.Load(file); err != nil {
+					return err
+				}
+				if pod, err = projectPod(config, podName); err != nil {
+					return err
+				}
+				if port == 0 && len(pod.ServicePorts) > 0 {
+					port = pod.ServicePorts[0].TargetPort
+				}
+			} else if podName != "" {
+				return fmt.Errorf("--pod needs %s; create it with 'nexlayer init'", file)
+			}
+
+			project, err := ai.DescribeProject(dir, port)
+			if err != nil {
+				return err
+			}
+			tag := DockerfileTag(dir, config, pod)
+			generated, err := WriteDockerfile(cmd.Context(), cmd.OutOrStdout(), project, DockerfileOptions{
+				Tag:      tag,
+				Attempts: attempts,
+				NoBuild:  noBuild,
+				Force:    force,
+			})
+			if err != nil {
+				return err
+			}
+
+			result := DockerfileResult{File: dockerfile, Valid: generated.Valid(), GeneratedDockerfile: generated}
+			if pod != nil {
+				if result.Image, err = pointPod(file, dir, pod.Name, tag); err != nil {
+					return err
+				}
+				result.Pod = pod.Name
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(result)
+			}
+			out := cmd.OutOrStdout()
+			if pod != nil {
+				fmt.Fprintf(out, "%s Pod %s of %s now runs %s, built from %s\n", ui.Symbols().Success, pod.Name, file, result.Image, dir)
+				if config.Application.RegistryLogin == nil {
+					fmt.Fprintf(out, "%s Set application.registryLogin so 'nexlayer deploy --watch-files' can push it\n", ui.Symbols().Bullet)
+				}
+			} else {
+				fmt.Fprintf(out, "%s Run 'nexlayer init' to create nexlayer.yaml with a pod built from it\n", ui.Symbols().Bullet)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration whose pod runs the project (default nexlayer.yaml in the directory)")
+	cmd.Flags().StringVar(&podName, "pod", "", "Pod of --file running the project")
+	cmd.Flags().IntVar(&port, "port", 0, "Port the server listens on (default the target port of the pod, or the usual one of the framework)")
+	cmd.Flags().IntVar(&attempts, "attempts", ai.DefaultGenerateAttempts, "Dockerfiles the model may write before one builds")
+	cmd.Flags().BoolVar(&noBuild, "no-build", false, "Do not build the Dockerfile to check it")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the Dockerfile, and write it even when it does not build")
+
+	return cmd
+}
+
+// DockerfileOptions tunes WriteDockerfile
+type DockerfileOptions struct {
+	Tag      string // the image is built with it to check the Dockerfile
+	Attempts int    // ai.DefaultGenerateAttempts when 0
+	NoBuild  bool   // skip the build
+	Force    bool   // write the Dockerfile even when it does not pass
+}
+
+// WriteDockerfile writes the Dockerfile of a project with the LLM of the
+// active profile, or the built-in one without, and builds it to check it
+// when docker is available. Used by ai dockerfile and nexlayer init.
+func WriteDockerfile(ctx context.Context, out io.Writer, project *ai.Project, opts DockerfileOptions) (*ai.GeneratedDockerfile, error) {
+	if ui.Structured() {
+		out = io.Discard
+	}
+	provider, err := llm.Default()
+	name := "the built-in Dockerfile"
+	switch {
+	case err == nil:
+		name = provider.Name()
+	case !errors.Is(err, llm.ErrNotConfigured):
+		ui.RenderWarning(err.Error() + "; using the built-in Dockerfile")
+		fallthrough
+	default:
+		provider = nil
+	}
+
+	var dopts ai.DockerfileOptions
+	dopts.MaxAttempts = opts.Attempts
+	if !opts.NoBuild {
+		var output bytes.Buffer
+		builder, err := build.NewBuilder(project.Dir, &output)
+		if err != nil {
+			ui.RenderWarning("docker is not available; the Dockerfile is not built to check it")
+		} else {
+			dopts.Build = func(ctx context.Context, content []byte) error {
+				fmt.Fprintf(out, "🔨 Building %s to check it...\n", opts.Tag)
+				output.Reset()
+				return buildDockerfile(ctx, builder, &output, opts.Tag, project.Dir, content)
+			}
+		}
+	}
+
+	framework := project.Framework
+	if framework == "" {
+		framework = project.Language
+	}
+	fmt.Fprintf(out, "🐳 Writing a Dockerfile for the %s project with %s...\n", framework, name)
+	generated, err := ai.GenerateDockerfile(ctx, provider, project, dopts, func(a ai.Attempt) {
+		if len(a.Problems) == 0 {
+			return
+		}
+		fmt.Fprintf(out, "%s Attempt %d does not pass:\n", ui.Symbols().Warning, a.Number)
+		for _, p := range a.Problems {
+			fmt.Fprintf(out, "  %s %s\n", ui.Symbols().Bullet, strings.ReplaceAll(p, "\n", "\n    "))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !generated.Valid() && !opts.Force {
+		return nil, fmt.Errorf("no Dockerfile passed in %d attempts (rerun with --force to write it anyway)", len(generated.Attempts))
+	}
+
+	path := filepath.Join(project.Dir, schema.DefaultDockerfile)
+	if err := os.WriteFile(path, generated.Content, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	switch {
+	case !generated.Valid():
+		fmt.Fprintf(out, "%s Wrote %s with %d problems left; fix them before deploying\n", ui.Symbols().Warning, path, len(generated.Problems))
+	case generated.Built:
+		fmt.Fprintf(out, "%s Wrote %s, which builds %s\n", ui.Symbols().Success, path, opts.Tag)
+	default:
+		fmt.Fprintf(out, "%s Wrote %s\n", ui.Symbols().Success, path)
+	}
+	return generated, nil
+}
+
+// buildDockerfile builds a Dockerfile not yet written to the project, and
+// returns the end of the build output when it fails
+func buildDockerfile(ctx context.Context, builder *build.Builder, output *bytes.Buffer, tag, dir string, content []byte) error {
+	f, err := os.CreateTemp("", "Dockerfile.nexlayer-*")
+	if err != nil {
+		return fmt.Errorf("failed to create a temporary Dockerfile: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write a temporary Dockerfile: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write a temporary Dockerfile: %w", err)
+	}
+
+	if err := builder.Build(ctx, tag, f.Name(), dir); err != nil {
+		lines := strings.Split(strings.TrimRight(output.String(), "\n"), "\n")
+		return fmt.Errorf("%w:\n%s", err, strings.Join(lines[max(0, len(lines)-buildOutputLines):], "\n"))
+	}
+	return nil
+}
+
+// DockerfileTag is the local tag of the image of a project, named after its
+// pod, or its directory without one
+func DockerfileTag(dir string, config *schema.NexlayerYAML, pod *schema.Pod) string {
+	name := ""
+	if pod != nil {
+		name = pod.Name
+		if config.Application.Name != "" {
+			name = config.Application.Name + "-" + pod.Name
+		}
+	} else if abs, err := filepath.Abs(dir); err == nil {
+		name = invalidNameChars.ReplaceAllString(strings.ToLower(filepath.Base(abs)), "-")
+	}
+	name = strings.Trim(name, "-")
+	if name == "" {
+		name = "app"
+	}
+	return name + ":latest"
+}
+
+// projectPod returns the pod named, or the first one served at a path
+func projectPod(config *schema.NexlayerYAML, name string) (*schema.Pod, error) {
+	pods := config.Application.Pods
+	for i := range pods {
+		if name != "" && pods[i].Name == name {
+			return &pods[i], nil
+		}
+	}
+	if name != "" {
+		return nil, fmt.Errorf("pod %s not found", name)
+	}
+	for i := range pods {
+		if pods[i].Path != "" {
+			return &pods[i], nil
+		}
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("the application has no pods; use --pod")
+	}
+	return &pods[0], nil
+}
+
+// pointPod sets the image and build of a pod in file to the Dockerfile in
+// dir, and returns the image
+func pointPod(file, dir, pod, tag string) (string, error) {
+	base, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", file, err)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	buildContext, err := filepath.Rel(base, abs)
+	if err != nil {
+		buildContext = abs
+	}
+	if buildContext != "." && !filepath.IsAbs(buildContext) {
+		buildContext = "./" + filepath.ToSlash(buildContext)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	image := fmt.Sprintf("%s/%s", schema.RegistryPlaceholder, tag)
+	updated, err := schema.SetPodBuild(data, pod, image, schema.ImageBuild{Context: buildContext})
+	if err != nil {
+		return "", fmt.Errorf("failed to update %s: %w", file, err)
+	}
+	if err := os.WriteFile(file, updated, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return image, nil
+}
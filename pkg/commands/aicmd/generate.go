@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package aicmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/ai"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// invalidNameChars matches what application names cannot contain
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// GenerateResult is the outcome of ai generate
+type GenerateResult struct {
+	File        string   `json:"file"`
+	Application string   `json:"application,omitempty"`
+	Pods        []string `json:"pods,omitempty"`
+	Valid       bool     `json:"valid"`
+	*ai.Generated
+}
+
+// newGenerateCommand creates the generate subcommand
+func newGenerateCommand() *cobra.Command {
+	var file, name string
+	var attempts int
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "generate <description>",
+		Short: "Write nexlayer.yaml from a description of the application",
+		Long: `Write the nexlayer.yaml of an application described in plain words, with the
+LLM of the active profile. The built-in templates closest to the description
+guide the model. The configuration is validated, and the model is asked to
+fix the problems found until it is valid, up to --attempts times; it is only
+written once valid, unless --force is given.
+
+The application is named after the directory of --file unless --name is
+given. Images of the application are written <% REGISTRY %>/<name>:latest
+and secrets as <% NAME %> placeholders, to fill in before deploying.
+
+Examples:
+  nexlayer ai generate "a FastAPI app with Postgres and Redis"
+  nexlayer ai generate "Next.js frontend, Express API and MongoDB" --name shop
+  nexlayer ai generate "a Go API with Redis" -f deploy/nexlayer.yaml --attempts 5`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := os.Stat(file); err == nil && !force {
+				return fmt.Errorf("%s already exists; use --force to overwrite it", file)
+			}
+			if name == "" {
+				abs, err := filepath.Abs(filepath.Dir(file))
+				if err != nil {
+					return fmt.Errorf("failed to resolve %s: %w", file, err)
+				}
+				name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(filepath.Base(abs)), "-"), "-")
+			}
+			provider, err := llm.Default()
+			if errors.Is(err, llm.ErrNotConfigured) {
+				return fmt.Errorf("ai generate needs an LLM; select one with 'nexlayer config set-profile <profile> --llm-provider openai', or start from a built-in template with 'nexlayer init --template'")
+			}
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if !ui.Structured() {
+				fmt.Fprintf(out, "✨ Generating %s with %s...\n", file, provider.Name())
+			}
+			generated, err := ai.Generate(cmd.Context(), provider, ai.GenerateRequest{
+				Description: strings.Join(args, " "),
+				AppName:     name,
+				MaxAttempts: attempts,
+			}, func(a ai.Attempt) {
+				if ui.Structured() || len(a.Problems) == 0 {
+					return
+				}
+				fmt.Fprintf(out, "%s Attempt %d is not valid:\n", ui.Symbols().Warning, a.Number)
+				for _, p := range a.Problems {
+					fmt.Fprintf(out, "  %s %s\n", ui.Symbols().Bullet, p)
+				}
+			})
+			if err != nil {
+				return err
+			}
+			if !generated.Valid() && !force {
+				return fmt.Errorf("%s did not write a valid configuration in %d attempts (rerun with --force to write it anyway):\n  - %s",
+					generated.Model, len(generated.Attempts), strings.Join(generated.Problems, "\n  - "))
+			}
+
+			if dir := filepath.Dir(file); dir != "." {
+				if err := os.MkdirAll(dir, 0755); err != nil {
+					return fmt.Errorf("failed to create %s: %w", dir, err)
+				}
+			}
+			if err := os.WriteFile(file, schema.WithSchemaHeader(generated.Content), 0644); err != nil {
+				return fmt.Errorf("failed to write configuration: %w", err)
+			}
+
+			result := GenerateResult{File: file, Valid: generated.Valid(), Generated: generated}
+			if generated.Config != nil {
+				result.Application = generated.Config.Application.Name
+				for _, pod := range generated.Config.Application.Pods {
+					result.Pods = append(result.Pods, pod.Name)
+				}
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(result)
+			}
+			if !result.Valid {
+				fmt.Fprintf(out, "%s Wrote %s with %d problems left; fix them before deploying\n", ui.Symbols().Warning, file, len(generated.Problems))
+				return nil
+			}
+			fmt.Fprintf(out, "%s Created %s with pods %s\n", ui.Symbols().Success, file, strings.Join(result.Pods, ", "))
+			fmt.Fprintf(out, "%s Review it, fill in the <%% %%> placeholders, then run nexlayer deploy\n", ui.Symbols().Bullet)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "File to write the configuration to")
+	cmd.Flags().StringVar(&name, "name", "", "Name of the application (default the directory of --file)")
+	cmd.Flags().IntVar(&attempts, "attempts", ai.DefaultGenerateAttempts, "Configurations the model may write before one is valid")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite --file, and write the configuration even when it is not valid")
+
+	return cmd
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/compose"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newComposeCommand creates the export compose command
+func newComposeCommand() *cobra.Command {
+	var file, output string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "compose",
+		Short: "Export the application as a docker-compose.yml",
+		Long: `Render nexlayer.yaml as a Docker Compose file, to run the application locally
+with docker compose up.
+
+Each pod becomes a service with its ports published on the host and its
+volumes as named volumes. Pods stay reachable as <pod>.pod, and a pod whose
+vars reference another is started after it. Images built from source keep
+their build, secrets and config files become configs and static sites are
+served by nginx. Migrations run as a one-off service before their pod.
+Services declared under application.services are expanded into pods first.
+
+Values the platform fills in, such as <% DB_PASSWORD %>, are read from .env or
+the environment as ${DB_PASSWORD}.
+
+Examples:
+  nexlayer export compose && docker compose up
+  nexlayer export compose -f deploy/nexlayer.yaml --out-file -`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, file, err := loadExportConfig(file)
+			if err != nil {
+				return err
+			}
+
+			// Paths of nexlayer.yaml are relative to its directory, compose
+			// paths to the directory of the compose file
+			outDir := "."
+			if output != "-" {
+				outDir = filepath.Dir(output)
+			}
+			projectDir, err := relativeDir(outDir, filepath.Dir(file))
+			if err != nil {
+				return err
+			}
+
+			result, err := compose.Export(config, compose.ExportOptions{ProjectDir: projectDir})
+			if err != nil {
+				return err
+			}
+			data, err := result.YAML()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if output == "-" {
+				if _, err := out.Write(data); err != nil {
+					return err
+				}
+				// Keep stdout a clean file when the project is written to it
+				out = cmd.ErrOrStderr()
+			} else {
+				if _, err := os.Stat(output); err == nil && !force {
+					return fmt.Errorf("%s already exists; use --force to overwrite it", output)
+				}
+				if err := os.WriteFile(output, data, 0644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", output, err)
+				}
+				fmt.Fprintf(out, "%s Wrote %s with %d services\n", ui.Symbols().Success, output, len(result.Project.Services))
+			}
+			for _, note := range result.Notes {
+				fmt.Fprintf(out, "%s %s\n", ui.Symbols().Warning, note)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Configuration to export (default nexlayer.yaml)")
+	cmd.Flags().StringVar(&output, "out-file", "docker-compose.yml", "File to write, or - for stdout")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the output file")
+
+	return cmd
+}
+
+// relativeDir returns dir relative to base
+func relativeDir(base, dir string) (string, error) {
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(absBase, absDir)
+}
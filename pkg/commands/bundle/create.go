@@ -0,0 +1,200 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/build"
+	corebundle "github.com/Nexlayer/nexlayer-cli/pkg/core/bundle"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/Nexlayer/nexlayer-cli/pkg/version"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewBundleCommand creates a new bundle command
+func NewBundleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Package an application for air-gapped deployment",
+		Long: `Package the configuration and images of an application into a single archive
+that can be carried to a connected bastion and deployed from there with
+'nexlayer import'.
+
+Bundles work with --offline: creating one needs docker but no network access
+unless --pull is given.`,
+	}
+	cmd.AddCommand(newCreateCommand())
+	return cmd
+}
+
+func newCreateCommand() *cobra.Command {
+	var file, output string
+	var pull, noImages bool
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a bundle of the configuration and its images",
+		Long: `Create a bundle from nexlayer.yaml holding the configuration, with config files
+and envFrom imports inlined, and the image of every pod saved with docker.
+Images must be present locally; --pull fetches them first.
+
+Secret data, sensitive vars and registry tokens are not bundled. They are
+replaced by references such as ${DB_POSTGRES_PASSWORD} which 'nexlayer import'
+fills in from the environment or a --secrets file. On import the images are
+loaded with docker and pushed to their registry before deploying.
+
+Examples:
+  nexlayer bundle create --out-file shop.tar.gz
+  nexlayer bundle create --pull
+  nexlayer import shop.tar.gz --secrets prod.env     # on the bastion`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, _, err := deployment.Load(file)
+			if err != nil {
+				return err
+			}
+			baseDir := filepath.Dir(file)
+			if err := schema.ResolveConfigFiles(config, baseDir); err != nil {
+				return err
+			}
+			if err := schema.ResolveEnvFrom(config, baseDir); err != nil {
+				return err
+			}
+			if output == "" {
+				output = config.Application.Name + "-bundle.tar.gz"
+			}
+
+			out := cmd.OutOrStdout()
+			manifest := corebundle.Manifest{
+				FormatVersion: corebundle.FormatVersion,
+				Name:          config.Application.Name,
+				URL:           config.Application.URL,
+				Metadata: corebundle.Metadata{
+					ExportedAt: time.Now().UTC(),
+					CLIVersion: version.GetVersion(),
+				},
+			}
+			files := make(map[string]string)
+
+			for _, pod := range config.Application.Pods {
+				if pod.IsStatic() {
+					ui.RenderWarning(fmt.Sprintf("Static pod %s is not bundled; its site is built and uploaded by 'nexlayer deploy'", pod.Name))
+				}
+			}
+			if !noImages {
+				tmp, err := os.MkdirTemp("", "nexlayer-bundle-*")
+				if err != nil {
+					return fmt.Errorf("failed to create temporary directory: %w", err)
+				}
+				defer os.RemoveAll(tmp)
+
+				builder, err := build.NewBuilder(baseDir, cmd.ErrOrStderr())
+				if err != nil {
+					return err
+				}
+				ctx := cmd.Context()
+				for i, image := range bundledImages(config) {
+					ref := schema.ResolveRegistry(config, image)
+					if strings.Contains(ref, schema.RegistryPlaceholder) {
+						return fmt.Errorf("cannot bundle %s without application.registryLogin", image)
+					}
+					if pull {
+						if err := builder.Pull(ctx, ref); err != nil {
+							return err
+						}
+					} else if !builder.Exists(ctx, ref) {
+						return fmt.Errorf("image %s is not present locally; pull or build it, or rerun with --pull", ref)
+					}
+
+					img := corebundle.Image{Image: image, Ref: ref, File: imageFile(i, ref)}
+					fmt.Fprintf(out, "Saving %s\n", ref)
+					files[img.File] = filepath.Join(tmp, path.Base(img.File))
+					if err := builder.Save(ctx, ref, files[img.File]); err != nil {
+						return err
+					}
+					manifest.Images = append(manifest.Images, img)
+				}
+			}
+
+			manifest.References = corebundle.Extract(config)
+			content, err := yaml.Marshal(config)
+			if err != nil {
+				return fmt.Errorf("failed to encode configuration: %w", err)
+			}
+			b := &corebundle.Bundle{Manifest: manifest, Config: content, ImageFiles: files}
+			if err := corebundle.Write(output, b); err != nil {
+				return err
+			}
+
+			size := ""
+			if info, err := os.Stat(output); err == nil {
+				size = fmt.Sprintf(" (%.1f MB)", float64(info.Size())/(1<<20))
+			}
+			fmt.Fprintf(out, "%s Bundled %s to %s%s\n", ui.Symbols().Success, config.Application.Name, output, size)
+			fmt.Fprintf(out, "  %s %d pods, %d images\n", ui.Symbols().Bullet, len(config.Application.Pods), len(manifest.Images))
+			if refs := manifest.References; len(refs) > 0 {
+				fmt.Fprintln(out, "\nSecret values were not bundled. Provide them on import:")
+				for _, r := range refs {
+					fmt.Fprintf(out, "  %s %s\n", ui.Symbols().Bullet, r.Ref)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration to bundle")
+	cmd.Flags().StringVar(&output, "out-file", "", "File to write the bundle to (default <app>-bundle.tar.gz)")
+	cmd.Flags().BoolVar(&pull, "pull", false, "Pull the images before saving them")
+	cmd.Flags().BoolVar(&noImages, "no-images", false, "Bundle only the configuration")
+
+	return cmd
+}
+
+// bundledImages returns the images the pods run, including canaries and the
+// images of seed and migration jobs, each once and in order
+func bundledImages(config *schema.NexlayerYAML) []string {
+	var images []string
+	seen := make(map[string]bool)
+	add := func(image string) {
+		if image != "" && !seen[image] {
+			seen[image] = true
+			images = append(images, image)
+		}
+	}
+	for _, pod := range config.Application.Pods {
+		if pod.IsStatic() {
+			continue
+		}
+		add(pod.Image)
+		if pod.Canary != nil {
+			add(pod.Canary.Image)
+		}
+		if pod.Seed != nil {
+			add(pod.Seed.Image)
+		}
+	}
+	if m := config.Application.Migrations; m != nil {
+		add(m.Image)
+	}
+	return images
+}
+
+// imageFile names the archive of the i-th image inside the bundle
+func imageFile(i int, ref string) string {
+	name := path.Base(ref)
+	if j := strings.IndexAny(name, ":@"); j > 0 {
+		name = name[:j]
+	}
+	return fmt.Sprintf("%s/%02d-%s.tar", corebundle.ImagesDir, i+1, name)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	corebundle "github.com/Nexlayer/nexlayer-cli/pkg/core/bundle"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/Nexlayer/nexlayer-cli/pkg/version"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewExportCommand creates a new export command
+func NewExportCommand(client api.APIClient) *cobra.Command {
+	var configFile, output string
+
+	cmd := &cobra.Command{
+		Use:   "export <namespace>",
+		Short: "Export a deployment to a bundle",
+		Long: `Export a deployment into a bundle for backup or migration.
+
+The bundle holds the deployment configuration, its custom domain and metadata
+such as the source environment and export time. Images are taken from the
+running pods; ports, vars and volumes from --file, which defaults to
+nexlayer.yaml when present.
+
+Secret data, sensitive vars and registry tokens are not exported. They are
+replaced by references such as ${DB_POSTGRES_PASSWORD} which 'nexlayer import'
+fills in from the environment or a --secrets file.
+
+To run the application on another Kubernetes cluster instead, see
+'nexlayer export k8s' or 'nexlayer export helm', or to run it locally with
+Docker, 'nexlayer export compose'. A namespace named like one of these is
+exported after --, e.g. 'nexlayer export -- helm'. The flags of each
+subcommand are its own: -f and --out-file of a bundle export do not apply to them.
+
+Examples:
+  nexlayer export my-app-ns --out-file my-app.tar.gz
+  nexlayer export my-app-ns -f deploy/nexlayer.yaml --out-file backup.tar.gz`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := args[0]
+			if configFile == "" {
+				if _, err := os.Stat("nexlayer.yaml"); err == nil {
+					configFile = "nexlayer.yaml"
+				}
+			}
+			var base *schema.NexlayerYAML
+			if configFile != "" {
+				data, err := os.ReadFile(configFile)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %w", configFile, err)
+				}
+				base = &schema.NexlayerYAML{}
+				if err := yaml.Unmarshal(data, base); err != nil {
+					return fmt.Errorf("failed to parse %s: %w", configFile, err)
+				}
+			}
+
+			info, err := client.GetDeploymentInfo(cmd.Context(), namespace)
+			if err != nil {
+				return fmt.Errorf("failed to get deployment info: %w", err)
+			}
+			dep := info.Data
+			if len(dep.PodStatuses) == 0 {
+				return fmt.Errorf("deployment %s has no pods to export", namespace)
+			}
+
+			snapshot := tmpl.Snapshot(dep, base)
+			if snapshot.Application.Name == "" {
+				snapshot.Application.Name = namespace
+			}
+			refs := corebundle.Extract(snapshot)
+			content, err := yaml.Marshal(snapshot)
+			if err != nil {
+				return fmt.Errorf("failed to encode configuration: %w", err)
+			}
+
+			manifest := corebundle.Manifest{
+				FormatVersion: corebundle.FormatVersion,
+				Name:          snapshot.Application.Name,
+				Namespace:     namespace,
+				URL:           dep.URL,
+				References:    refs,
+				Metadata: corebundle.Metadata{
+					ExportedAt:   time.Now().UTC(),
+					CLIVersion:   version.GetVersion(),
+					SourceAPI:    strings.TrimSpace(config.GetAPIURL()),
+					TemplateName: dep.TemplateName,
+					Version:      dep.Version,
+					Status:       dep.Status,
+				},
+			}
+			if dep.CustomDomain != "" {
+				manifest.Domains = []string{dep.CustomDomain}
+			}
+
+			if err := corebundle.Write(output, &corebundle.Bundle{Manifest: manifest, Config: content}); err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s Exported %s to %s\n", ui.Symbols().Success, namespace, output)
+			fmt.Fprintf(out, "  %s %d pods\n", ui.Symbols().Bullet, len(snapshot.Application.Pods))
+			for _, d := range manifest.Domains {
+				fmt.Fprintf(out, "  %s domain %s\n", ui.Symbols().Bullet, d)
+			}
+			if len(refs) > 0 {
+				fmt.Fprintln(out, "\nSecret values were not exported. Provide them on import:")
+				for _, r := range refs {
+					fmt.Fprintf(out, "  %s %s\n", ui.Symbols().Bullet, r.Ref)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&configFile, "file", "f", "", "Configuration the deployment was created from")
+	cmd.Flags().StringVar(&output, "out-file", "bundle.tar.gz", "File to write the bundle to")
+	cmd.AddCommand(newK8sCommand())
+	cmd.AddCommand(newHelmCommand())
+	cmd.AddCommand(newComposeCommand())
+
+	return cmd
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package bundle
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/build"
+	corebundle "github.com/Nexlayer/nexlayer-cli/pkg/core/bundle"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewImportCommand creates a new import command
+func NewImportCommand(client api.APIClient) *cobra.Command {
+	var name, secretsFile string
+	var skipDomains, skipImages, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "import <bundle> [applicationID]",
+		Short: "Recreate a deployment from a bundle",
+		Long: `Recreate a deployment exported with 'nexlayer export'.
+
+The deployment is created in the organization and environment of the current
+login, so a bundle can be moved between organizations or from staging to
+production. Secret references are resolved from --secrets (a KEY=VALUE file)
+and then from environment variables; the import stops if any is missing.
+
+Custom domains recorded in the bundle are attached unless --skip-domains is
+given. DNS records must be pointed at the new deployment afterwards.
+
+Images carried by a bundle from 'nexlayer bundle create' are loaded with docker
+and pushed to their registry before deploying, unless --skip-images is given.
+
+Examples:
+  nexlayer import my-app.tar.gz
+  nexlayer import my-app.tar.gz my-app --secrets prod.env
+  nexlayer import backup.tar.gz --name my-app-restore --skip-domains`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := corebundle.Read(args[0])
+			if err != nil {
+				return err
+			}
+			appID := ""
+			if len(args) > 1 {
+				appID = args[1]
+			}
+
+			var config schema.NexlayerYAML
+			if err := yaml.Unmarshal(b.Config, &config); err != nil {
+				return fmt.Errorf("failed to parse bundled configuration: %w", err)
+			}
+			if name != "" {
+				config.Application.Name = name
+			}
+
+			values := map[string]string{}
+			if secretsFile != "" {
+				if values, err = corebundle.LoadEnvFile(secretsFile); err != nil {
+					return err
+				}
+			}
+			missing := corebundle.Apply(&config, b.Manifest.References, func(ref string) (string, bool) {
+				if v, ok := values[ref]; ok {
+					return v, true
+				}
+				return os.LookupEnv(ref)
+			})
+			if len(missing) > 0 {
+				return fmt.Errorf("missing secret values for %s\nSet them as environment variables or pass --secrets <file>", strings.Join(missing, ", "))
+			}
+
+			var problems []string
+			for _, e := range schema.Validate(&config) {
+				if e.Severity == schema.ValidationErrorSeverityError {
+					problems = append(problems, fmt.Sprintf("%s: %s", e.Field, e.Message))
+				}
+			}
+			if len(problems) > 0 {
+				return fmt.Errorf("bundled configuration is invalid:\n  %s", strings.Join(problems, "\n  "))
+			}
+
+			domains := b.Manifest.Domains
+			if skipDomains {
+				domains = nil
+			}
+
+			out := cmd.OutOrStdout()
+			if b.Manifest.Namespace != "" {
+				fmt.Fprintf(out, "Importing %s (exported from %s on %s)\n", config.Application.Name,
+					b.Manifest.Namespace, b.Manifest.Metadata.ExportedAt.Format("2006-01-02"))
+			} else {
+				fmt.Fprintf(out, "Importing %s (bundled on %s)\n", config.Application.Name,
+					b.Manifest.Metadata.ExportedAt.Format("2006-01-02"))
+			}
+			fmt.Fprintf(out, "  %s %d pods, %d secret references\n", ui.Symbols().Bullet,
+				len(config.Application.Pods), len(b.Manifest.References))
+			if n := len(b.Manifest.Images); n > 0 && !skipImages {
+				fmt.Fprintf(out, "  %s %d images to load\n", ui.Symbols().Bullet, n)
+			}
+			for _, d := range domains {
+				fmt.Fprintf(out, "  %s domain %s\n", ui.Symbols().Bullet, d)
+			}
+			if dryRun {
+				fmt.Fprintf(out, "%s Bundle is ready to import (dry run, nothing deployed)\n", ui.Symbols().Success)
+				return nil
+			}
+
+			if err := offline.Check("nexlayer import"); err != nil {
+				return err
+			}
+			if len(b.Manifest.Images) > 0 && !skipImages {
+				if err := pushImages(cmd, args[0], b.Manifest, &config); err != nil {
+					return err
+				}
+			}
+
+			content, err := yaml.Marshal(&config)
+			if err != nil {
+				return fmt.Errorf("failed to encode configuration: %w", err)
+			}
+			tmp, err := os.CreateTemp("", "nexlayer-import-*.yaml")
+			if err != nil {
+				return fmt.Errorf("failed to create temporary file: %w", err)
+			}
+			defer os.Remove(tmp.Name())
+			if _, err := tmp.Write(content); err != nil {
+				tmp.Close()
+				return fmt.Errorf("failed to write temporary file: %w", err)
+			}
+			if err := tmp.Close(); err != nil {
+				return fmt.Errorf("failed to write temporary file: %w", err)
+			}
+
+			resp, err := client.StartDeployment(cmd.Context(), appID, tmp.Name())
+			if err != nil {
+				return fmt.Errorf("failed to start deployment: %w", err)
+			}
+			fmt.Fprintf(out, "%s Deployment started in namespace %s\n", ui.Symbols().Success, resp.Data.Namespace)
+			if resp.Data.URL != "" {
+				fmt.Fprintf(out, "  %s URL: %s\n", ui.Symbols().Bullet, resp.Data.URL)
+			}
+
+			target := appID
+			if target == "" {
+				target = resp.Data.Namespace
+			}
+			for _, d := range domains {
+				if _, err := client.SaveCustomDomain(cmd.Context(), target, d); err != nil {
+					ui.RenderWarning(fmt.Sprintf("Could not attach domain %s: %v", d, err))
+					continue
+				}
+				fmt.Fprintf(out, "%s Attached domain %s; point its DNS at %s\n", ui.Symbols().Success, d, resp.Data.URL)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Application name to use instead of the exported one")
+	cmd.Flags().StringVar(&secretsFile, "secrets", "", "KEY=VALUE file with secret values")
+	cmd.Flags().BoolVar(&skipDomains, "skip-domains", false, "Do not attach the bundled custom domains")
+	cmd.Flags().BoolVar(&skipImages, "skip-images", false, "Do not load and push the bundled images")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check the bundle and secrets without deploying")
+
+	return cmd
+}
+
+// pushImages loads the images carried by a bundle into docker and pushes
+// those of the application's private registry, which the platform cannot pull
+// from anywhere else
+func pushImages(cmd *cobra.Command, path string, manifest corebundle.Manifest, config *schema.NexlayerYAML) error {
+	builder, err := build.NewBuilder("", cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	return corebundle.ReadImages(path, manifest, func(img corebundle.Image, r io.Reader) error {
+		fmt.Fprintf(cmd.OutOrStdout(), "Loading %s\n", img.Ref)
+		if err := builder.Load(ctx, r); err != nil {
+			return err
+		}
+		if !private(config, img) {
+			return nil
+		}
+		return builder.Push(ctx, img.Ref)
+	})
+}
+
+// private reports whether an image belongs to the registry of registryLogin
+func private(config *schema.NexlayerYAML, img corebundle.Image) bool {
+	if strings.Contains(img.Image, schema.RegistryPlaceholder) {
+		return true
+	}
+	login := config.Application.RegistryLogin
+	return login != nil && login.Registry != "" && strings.HasPrefix(img.Ref, strings.TrimSuffix(login.Registry, "/")+"/")
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/k8s"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newK8sCommand creates the export k8s command
+func newK8sCommand() *cobra.Command {
+	var file, output string
+	var opts k8s.Options
+
+	cmd := &cobra.Command{
+		Use:   "k8s",
+		Short: "Export the application as Kubernetes manifests or a Helm chart",
+		Long: `Render nexlayer.yaml as standard Kubernetes manifests, to run the application
+on any cluster.
+
+Each pod becomes a Deployment and a Service, volumes become
+PersistentVolumeClaims, secrets and config files become Secrets and ConfigMaps,
+and pods with a path are routed by an Ingress on the host of application.url.
+Migrations become a Job. Services declared under application.services are
+expanded into pods first.
+
+Values the platform fills in, such as <% DB_PASSWORD %>, are read from the
+<app>-secrets Secret, which is written with empty values to fill in.
+
+Manifests are written to stdout, or one file each to --out-file. With --helm, a
+chart is written to --out-file instead, with images and secrets as values.
+
+Examples:
+  nexlayer export k8s | kubectl apply -f -
+  nexlayer export k8s --out-file k8s/ --namespace my-app --ingress-class nginx
+  nexlayer export helm --out-file chart/ && helm install my-app chart/`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportK8s(cmd, file, output, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Configuration to export (default nexlayer.yaml)")
+	cmd.Flags().StringVar(&output, "out-file", "", "Directory to write to (default stdout, or <app>-chart with --helm)")
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", "", "Namespace to set on the manifests")
+	cmd.Flags().StringVar(&opts.StorageClass, "storage-class", "", "Storage class of the volume claims")
+	cmd.Flags().StringVar(&opts.IngressClass, "ingress-class", "", "Ingress class of the ingress")
+	cmd.Flags().BoolVar(&opts.Helm, "helm", false, "Write a Helm chart instead of manifests, as export helm does")
+
+	return cmd
+}
+
+// newHelmCommand creates the export helm command
+func newHelmCommand() *cobra.Command {
+	var file, output string
+	opts := k8s.Options{Helm: true}
+
+	cmd := &cobra.Command{
+		Use:   "helm",
+		Short: "Export the application as a Helm chart",
+		Long: `Render nexlayer.yaml as a Helm chart, to install the application on any
+cluster or manage it with GitOps tools such as Argo CD or Flux.
+
+The templates are the manifests of 'nexlayer export k8s'. The images, and the
+values the platform fills in such as <% DB_PASSWORD %>, are chart values, set
+with --set or a values file on install. Migrations run as a pre-install and
+pre-upgrade hook.
+
+Examples:
+  nexlayer export helm && helm install my-app my-app-chart/
+  nexlayer export helm --out-file deploy/chart --namespace my-app --storage-class gp3`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportK8s(cmd, file, output, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Configuration to export (default nexlayer.yaml)")
+	cmd.Flags().StringVar(&output, "out-file", "", "Directory to write the chart to (default <app>-chart)")
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", "", "Namespace to set on the manifests")
+	cmd.Flags().StringVar(&opts.StorageClass, "storage-class", "", "Storage class of the volume claims")
+	cmd.Flags().StringVar(&opts.IngressClass, "ingress-class", "", "Ingress class of the ingress")
+
+	return cmd
+}
+
+// exportK8s renders a configuration as manifests, written to stdout or a
+// directory, or as a chart
+func exportK8s(cmd *cobra.Command, file, output string, opts k8s.Options) error {
+	config, file, err := loadExportConfig(file)
+	if err != nil {
+		return err
+	}
+	result, err := k8s.Render(config, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Helm && output == "" {
+		output = result.App + "-chart"
+	}
+	var files []string
+	switch {
+	case opts.Helm:
+		files, err = k8s.WriteChart(output, result)
+	case output != "":
+		files, err = k8s.WriteDir(output, result)
+	default:
+		err = k8s.WriteStream(cmd.OutOrStdout(), result)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Keep stdout a clean stream when the manifests are written to it
+	out := cmd.OutOrStdout()
+	if output == "" {
+		out = cmd.ErrOrStderr()
+	} else {
+		fmt.Fprintf(out, "%s Wrote %d files to %s\n", ui.Symbols().Success, len(files), output)
+	}
+	for _, note := range result.Notes {
+		fmt.Fprintf(out, "%s %s\n", ui.Symbols().Warning, note)
+	}
+	return nil
+}
+
+// loadExportConfig loads the configuration to export, nexlayer.yaml by
+// default, with its config files and envFrom resolved and its services
+// expanded. It returns the file it was loaded from.
+func loadExportConfig(file string) (*schema.NexlayerYAML, string, error) {
+	if file == "" {
+		for _, name := range []string{"nexlayer.yaml", "nexlayer.yml", "deployment.yaml", "deployment.yml"} {
+			if _, err := os.Stat(name); err == nil {
+				file = name
+				break
+			}
+		}
+		if file == "" {
+			return nil, "", fmt.Errorf("no nexlayer.yaml found in current directory; specify one with --file")
+		}
+	}
+
+	config, _, err := deployment.Load(file)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := schema.ResolveConfigFiles(config, filepath.Dir(file)); err != nil {
+		return nil, "", err
+	}
+	if err := schema.ResolveEnvFrom(config, filepath.Dir(file)); err != nil {
+		return nil, "", err
+	}
+	return config, file, nil
+}
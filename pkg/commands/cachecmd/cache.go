@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package cachecmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/cache"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the cache command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the cached AI and detection results",
+	}
+	cmd.AddCommand(newClearCommand())
+	return cmd
+}
+
+// newClearCommand creates the cache clear command
+func newClearCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear [kind...]",
+		Short: "Remove cached AI and detection results",
+		Long: `Remove the results cached under ~/.nexlayer/cache, or NEXLAYER_CACHE_DIR.
+
+The answers of LLMs are cached so that repeated runs of init, convert and ai
+do not query the model again, and the project types detected by init so
+that it does not detect them again. Results are keyed by everything they
+depend on, so any change to a configuration or project misses the cache,
+and expire after a day; NEXLAYER_LLM_CACHE_TTL sets how long answers are
+kept. Clear the cache to ask again anyway, e.g. after a model was updated.
+
+Kinds: ` + strings.Join(cache.Kinds, ", ") + ` (default all)
+
+Examples:
+  nexlayer cache clear
+  nexlayer cache clear llm`,
+		ValidArgs: cache.Kinds,
+		Args:      cobra.OnlyValidArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			removed, err := cache.Clear(args...)
+			if err != nil {
+				return err
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(removed)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Removed %d cached results (%s)\n", ui.Symbols().Success, removed.Entries, formatBytes(removed.Bytes))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// formatBytes prints a size in the largest unit it has
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package compare
+
+import (
+	"fmt"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	corecompare "github.com/Nexlayer/nexlayer-cli/pkg/core/compare"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates a new compare command
+func NewCommand(client api.APIClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compare <namespace1> <namespace2>",
+		Short: "Compare two live deployments",
+		Long: `Show what differs between two live deployments, such as staging and
+production: images, environment variables, custom domains and the number of
+running replicas per pod.
+
+Values of sensitive variables (passwords, tokens, keys) are never printed;
+only the fact that they differ is reported.
+
+Examples:
+  nexlayer compare my-app-staging my-app-prod
+  nexlayer compare my-app-staging my-app-prod --json`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.Namespaces(client, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			left, err := client.GetDeploymentInfo(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get deployment info for %s: %w", args[0], err)
+			}
+			right, err := client.GetDeploymentInfo(cmd.Context(), args[1])
+			if err != nil {
+				return fmt.Errorf("failed to get deployment info for %s: %w", args[1], err)
+			}
+
+			diffs := corecompare.Deployments(left.Data, right.Data)
+
+			out := cmd.OutOrStdout()
+			if ui.Structured() {
+				if diffs == nil {
+					diffs = []corecompare.Difference{}
+				}
+				return ui.WriteOutput(diffs)
+			}
+
+			if len(diffs) == 0 {
+				fmt.Fprintf(out, "%s %s and %s are identical\n", ui.Symbols().Success, args[0], args[1])
+				return nil
+			}
+
+			fmt.Fprintf(out, "%d differences between %s and %s\n", len(diffs), args[0], args[1])
+			table := ui.NewTable()
+			table.AddHeader("POD", "FIELD", args[0], args[1])
+			for _, d := range diffs {
+				pod := d.Pod
+				if pod == "" {
+					pod = corecompare.Missing
+				}
+				table.AddRow(pod, d.Field, d.Left, d.Right)
+			}
+			return table.Render()
+		},
+	}
+
+	return cmd
+}
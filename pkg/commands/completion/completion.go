@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package completion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	"github.com/spf13/cobra"
+)
+
+// timeout bounds the API call made while completing, so that a slow or
+// unreachable API does not hang the shell
+const timeout = 3 * time.Second
+
+var shells = []string{"bash", "zsh", "fish", "powershell"}
+
+// NewCommand creates a new completion command
+func NewCommand() *cobra.Command {
+	var noDescriptions bool
+
+	cmd := &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate the shell completion script",
+		Long: `Generate the script completing nexlayer commands, flags and arguments in your
+shell. Namespaces and applications are completed from your live deployments.
+The shell defaults to that of $SHELL.
+
+Bash (needs the bash-completion package):
+  source <(nexlayer completion bash)
+  # or, for every session:
+  nexlayer completion bash > /etc/bash_completion.d/nexlayer
+
+Zsh:
+  nexlayer completion zsh > "${fpath[1]}/_nexlayer"
+  # completion must be enabled, e.g. with 'autoload -U compinit; compinit'
+
+Fish:
+  nexlayer completion fish > ~/.config/fish/completions/nexlayer.fish
+
+PowerShell:
+  nexlayer completion powershell | Out-String | Invoke-Expression
+  # add the line to your $PROFILE for every session`,
+		ValidArgs: shells,
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shell := filepath.Base(os.Getenv("SHELL"))
+			if len(args) > 0 {
+				shell = args[0]
+			}
+			root, out := cmd.Root(), cmd.OutOrStdout()
+			switch shell {
+			case "bash":
+				return root.GenBashCompletionV2(out, !noDescriptions)
+			case "zsh":
+				if noDescriptions {
+					return root.GenZshCompletionNoDesc(out)
+				}
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, !noDescriptions)
+			case "powershell", "pwsh":
+				if noDescriptions {
+					return root.GenPowerShellCompletion(out)
+				}
+				return root.GenPowerShellCompletionWithDesc(out)
+			case ".", "":
+				return fmt.Errorf("no shell given and $SHELL is not set; use one of %s", strings.Join(shells, ", "))
+			}
+			return fmt.Errorf("unsupported shell %q; use one of %s", shell, strings.Join(shells, ", "))
+		},
+	}
+
+	cmd.Flags().BoolVar(&noDescriptions, "no-descriptions", false, "Complete without describing each suggestion")
+
+	return cmd
+}
+
+// Namespaces completes the first n arguments of a command with the namespaces
+// of the live deployments, described by their status and URL
+func Namespaces(client api.APIClient, n int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return deployments(cmd, client, func(d apischema.Deployment) (string, string) {
+			return d.Namespace, strings.TrimSpace(d.Status + " " + d.URL)
+		}, args), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// Applications completes the first argument of a command with the names of
+// the applications that have live deployments
+func Applications(client api.APIClient) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return deployments(cmd, client, func(d apischema.Deployment) (string, string) {
+			return d.TemplateName, ""
+		}, args), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// deployments lists the live deployments and turns each into a completion,
+// skipping empty and repeated values and those already given. Nothing is
+// completed offline or when the API cannot be reached.
+func deployments(cmd *cobra.Command, client api.APIClient, complete func(apischema.Deployment) (string, string), given []string) []string {
+	// The flags of the completed command are parsed after the root has run
+	if off, _ := cmd.Flags().GetBool("offline"); off || offline.Enabled() {
+		return nil
+	}
+	// Request traces go to standard output, where they would be taken for
+	// completions
+	if c, ok := client.(interface{ SetDebugOutput(io.Writer) }); ok {
+		c.SetDebugOutput(nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	resp, err := client.ListDeployments(ctx)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("listing deployments: %v", err), false)
+		return nil
+	}
+
+	seen := make(map[string]bool, len(given))
+	for _, arg := range given {
+		seen[arg] = true
+	}
+	var completions []string
+	for _, d := range resp.Data {
+		value, desc := complete(d)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		if desc != "" {
+			value += "\t" + desc
+		}
+		completions = append(completions, value)
+	}
+	sort.Strings(completions)
+	return completions
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package configcmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewCommand creates a new config command
+func NewCommand(client api.APIClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the deployment configuration and CLI profiles",
+		Long: `Work with the nexlayer.yaml of a deployment, and with the profiles of
+~/.nexlayer/config.yaml that point the CLI at staging, production or a
+self-hosted installation.`,
+	}
+
+	cmd.AddCommand(newPullCommand(client))
+	cmd.AddCommand(newListProfilesCommand())
+	cmd.AddCommand(newUseProfileCommand())
+	cmd.AddCommand(newSetProfileCommand())
+	return cmd
+}
+
+// newPullCommand creates the pull subcommand
+func newPullCommand(client api.APIClient) *cobra.Command {
+	var output string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "pull <namespace>",
+		Short: "Generate nexlayer.yaml from a live deployment",
+		Long: `Reconstruct a nexlayer.yaml from a running deployment, for example one
+created in the web wizard, so it can be kept in git and deployed from the CLI.
+
+Pods, images, ports and vars are taken from the deployment. Values of sensitive
+vars such as passwords and tokens are masked as [REDACTED] and must be filled in
+before deploying.
+
+Examples:
+  nexlayer config pull my-app-ns
+  nexlayer config pull my-app-ns --out-file deploy/nexlayer.yaml --force
+  nexlayer config pull my-app-ns --out-file -`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := args[0]
+			if output != "-" && !force {
+				if _, err := os.Stat(output); err == nil {
+					return fmt.Errorf("%s already exists; use --force to overwrite it", output)
+				}
+			}
+
+			info, err := client.GetDeploymentInfo(cmd.Context(), namespace)
+			if err != nil {
+				return fmt.Errorf("failed to get deployment info: %w", err)
+			}
+			if len(info.Data.PodStatuses) == 0 {
+				return fmt.Errorf("deployment %s has no pods", namespace)
+			}
+
+			config := tmpl.Snapshot(info.Data, nil)
+			config.Application.Name = info.Data.TemplateName
+			if config.Application.Name == "" {
+				config.Application.Name = namespace
+			}
+			config.Application.URL = info.Data.CustomDomain
+
+			var masked []string
+			for _, pod := range config.Application.Pods {
+				for _, v := range pod.Vars {
+					if schema.IsSensitiveKey(v.Key) {
+						masked = append(masked, fmt.Sprintf("%s.%s", pod.Name, v.Key))
+					}
+				}
+			}
+			schema.Redact(config)
+
+			content, err := yaml.Marshal(config)
+			if err != nil {
+				return fmt.Errorf("failed to encode configuration: %w", err)
+			}
+			content = schema.WithSchemaHeader(content)
+			if output == "-" {
+				_, err := cmd.OutOrStdout().Write(content)
+				return err
+			}
+			if err := os.WriteFile(output, content, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output, err)
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s Wrote %s from %s (%d pods)\n", ui.Symbols().Success, output, namespace, len(config.Application.Pods))
+			if len(masked) > 0 {
+				fmt.Fprintf(out, "\n%s Fill in the masked values before deploying:\n", ui.Symbols().Warning)
+				for _, m := range masked {
+					fmt.Fprintf(out, "  %s %s\n", ui.Symbols().Bullet, m)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "out-file", "nexlayer.yaml", "File to write, or - for stdout")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing file")
+
+	return cmd
+}
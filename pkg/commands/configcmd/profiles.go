@@ -0,0 +1,269 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package configcmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/dns"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/profile"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newListProfilesCommand creates the list-profiles subcommand
+func newListProfilesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list-profiles",
+		Short: "List the CLI profiles",
+		Long: `List the profiles of ~/.nexlayer/config.yaml with their API URL, default
+application ID, TLS verification and DNS provider. The profile in use is
+marked active. Until the file exists, the built-in staging and production
+profiles are listed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := profile.Load()
+			if err != nil {
+				return err
+			}
+			active := ""
+			if p, err := profiles.Active(activeFlag(cmd)); err == nil {
+				active = p.Name
+			}
+
+			if ui.Structured() {
+				type entry struct {
+					profile.Profile
+					Token  bool `json:"token"`
+					Active bool `json:"active"`
+				}
+				list := make([]entry, 0, len(profiles.Profiles))
+				for _, name := range profiles.Names() {
+					p := profiles.Profiles[name]
+					p.Name = name
+					list = append(list, entry{Profile: p, Token: p.Token != "", Active: name == active})
+				}
+				return ui.WriteOutput(list)
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("PROFILE", "URL", "APP ID", "TOKEN", "TLS", "DNS", "LLM", "ACTIVE")
+			for _, name := range profiles.Names() {
+				p := profiles.Profiles[name]
+				mark, token, appID := "", "login", p.AppID
+				if name == active {
+					mark = "*"
+				}
+				if p.Token != "" {
+					token = "set"
+				}
+				if appID == "" {
+					appID = "-"
+				}
+				tls := "verify"
+				switch {
+				case p.Insecure:
+					tls = "insecure"
+				case p.CACert != "":
+					tls = "custom CA"
+				}
+				dnsProvider := "-"
+				if p.DNS != nil {
+					dnsProvider = p.DNS.Provider
+				}
+				llmProvider := "-"
+				if p.LLM != nil {
+					llmProvider = p.LLM.Provider
+				}
+				table.AddRow(name, p.URL, appID, token, tls, dnsProvider, llmProvider, mark)
+			}
+			return table.Render()
+		},
+	}
+}
+
+// newUseProfileCommand creates the use-profile subcommand
+func newUseProfileCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-profile <name>",
+		Short: "Switch the CLI to another profile",
+		Long: `Make a profile of ~/.nexlayer/config.yaml the current one, used by every
+command unless --profile or NEXLAYER_PROFILE selects another.
+
+Examples:
+  nexlayer config use-profile production
+  nexlayer deploy --profile staging      # one command only`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := profile.Load()
+			if err != nil {
+				return err
+			}
+			p, err := profiles.Get(args[0])
+			if err != nil {
+				return err
+			}
+			profiles.Current = p.Name
+			if err := profile.Save(profiles); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Using profile %s (%s)\n", ui.Symbols().Success, p.Name, p.URL)
+			if env := os.Getenv(profile.EnvVar); env != "" && env != p.Name {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s=%s overrides it in this shell\n", ui.Symbols().Warning, profile.EnvVar, env)
+			}
+			return nil
+		},
+	}
+}
+
+// newSetProfileCommand creates the set-profile subcommand
+func newSetProfileCommand() *cobra.Command {
+	var url, token, appID, caCert, dnsProvider, dnsZone, llmProvider, llmModel string
+	var use, insecure bool
+
+	cmd := &cobra.Command{
+		Use:   "set-profile <name>",
+		Short: "Create or update a CLI profile",
+		Long: `Create a profile of ~/.nexlayer/config.yaml, or update the settings given of
+an existing one. A profile without a token uses the token saved by
+'nexlayer login'; a token in NEXLAYER_TOKEN always takes precedence.
+
+With --dns-provider, 'nexlayer domain set' creates the CNAME records of
+custom domains at Cloudflare, Route53 or Google Cloud DNS. The credentials
+are read from the environment variables of the provider's own tools
+(CLOUDFLARE_API_TOKEN; AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY;
+GOOGLE_APPLICATION_CREDENTIALS), or from the dns section of the profile:
+
+  dns:
+    provider: cloudflare
+    apiToken: ...
+
+With --llm-provider, the AI analysis of 'nexlayer convert compose' asks
+OpenAI, Anthropic, Azure OpenAI or a local Ollama server instead of the
+built-in heuristics, which still answer when the provider cannot be reached.
+The API key is read from OPENAI_API_KEY, ANTHROPIC_API_KEY or
+AZURE_OPENAI_API_KEY, or from the llm section of the profile, which also
+sets the token budgets:
+
+  llm:
+    provider: azure
+    baseURL: https://my-resource.openai.azure.com
+    deployment: gpt-4o-mini
+    maxInputTokens: 8000
+    maxOutputTokens: 1024
+    budget: 50000
+
+Examples:
+  nexlayer config set-profile self-hosted --url https://nexlayer.internal.example.com --use
+  nexlayer config set-profile production --app-id app_123
+  nexlayer config set-profile internal --url https://nexlayer.corp.example --ca-cert ./corp-ca.pem
+  nexlayer config set-profile production --dns-provider route53
+  nexlayer config set-profile dev --llm-provider ollama --llm-model llama3.1`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			profiles, err := profile.Load()
+			if err != nil {
+				return err
+			}
+			p, exists := profiles.Profiles[name]
+			if cmd.Flags().Changed("url") {
+				p.URL = strings.TrimSuffix(url, "/")
+			}
+			if cmd.Flags().Changed("token") {
+				p.Token = token
+			}
+			if cmd.Flags().Changed("app-id") {
+				p.AppID = appID
+			}
+			if cmd.Flags().Changed("ca-cert") {
+				p.CACert = caCert
+				if caCert != "" {
+					abs, err := filepath.Abs(caCert)
+					if err != nil {
+						return err
+					}
+					p.CACert = abs
+				}
+			}
+			if cmd.Flags().Changed("insecure") {
+				p.Insecure = insecure
+			}
+			if cmd.Flags().Changed("dns-provider") {
+				p.DNS = nil
+				if dnsProvider != "" {
+					if !dns.KnownProvider(dnsProvider) {
+						return fmt.Errorf("unknown DNS provider %q; use %s, %s or %s", dnsProvider, dns.ProviderCloudflare, dns.ProviderRoute53, dns.ProviderGoogle)
+					}
+					p.DNS = &dns.ProviderConfig{Provider: strings.ToLower(dnsProvider)}
+				}
+			}
+			if cmd.Flags().Changed("dns-zone") {
+				if p.DNS == nil {
+					return fmt.Errorf("profile %s has no DNS provider; set one with --dns-provider", name)
+				}
+				p.DNS.Zone = dnsZone
+			}
+			if cmd.Flags().Changed("llm-provider") {
+				p.LLM = nil
+				if llmProvider != "" {
+					if !llm.KnownProvider(llmProvider) {
+						return fmt.Errorf("unknown LLM provider %q; use %s, %s, %s or %s", llmProvider, llm.ProviderOpenAI, llm.ProviderAnthropic, llm.ProviderOllama, llm.ProviderAzure)
+					}
+					p.LLM = &llm.Config{Provider: strings.ToLower(llmProvider)}
+				}
+			}
+			if cmd.Flags().Changed("llm-model") {
+				if p.LLM == nil {
+					return fmt.Errorf("profile %s has no LLM provider; set one with --llm-provider", name)
+				}
+				p.LLM.Model = llmModel
+			}
+			if p.URL == "" {
+				return fmt.Errorf("profile %s needs a --url", name)
+			}
+			profiles.Profiles[name] = p
+			if use {
+				profiles.Current = name
+			}
+			if err := profile.Save(profiles); err != nil {
+				return err
+			}
+
+			verb := "Created"
+			if exists {
+				verb = "Updated"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s profile %s\n", ui.Symbols().Success, verb, name)
+			if use {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s Using profile %s\n", ui.Symbols().Bullet, name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&url, "url", "", "Base URL of the Nexlayer API")
+	cmd.Flags().StringVar(&token, "token", "", "API token of the profile (default the token saved by nexlayer login)")
+	cmd.Flags().StringVar(&appID, "app-id", "", "Application ID deployments default to")
+	cmd.Flags().StringVar(&caCert, "ca-cert", "", "PEM file of certificate authorities to trust, besides the system ones (empty to unset)")
+	cmd.Flags().BoolVar(&insecure, "insecure", false, "Skip the verification of the API's TLS certificate; not recommended")
+	cmd.Flags().StringVar(&dnsProvider, "dns-provider", "", "DNS provider creating the records of custom domains: cloudflare, route53 or google (empty to unset)")
+	cmd.Flags().StringVar(&dnsZone, "dns-zone", "", "DNS zone holding the records, found from each domain when empty")
+	cmd.Flags().StringVar(&llmProvider, "llm-provider", "", "LLM answering the AI analysis: openai, anthropic, ollama or azure (empty to unset)")
+	cmd.Flags().StringVar(&llmModel, "llm-model", "", "Model of the LLM provider, its default when empty")
+	cmd.Flags().BoolVar(&use, "use", false, "Switch to the profile")
+
+	return cmd
+}
+
+// activeFlag returns the profile selected with --profile, if any
+func activeFlag(cmd *cobra.Command) string {
+	name, _ := cmd.Flags().GetString("profile")
+	return name
+}
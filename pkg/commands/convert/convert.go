@@ -0,0 +1,299 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package convert
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/compose"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/heroku"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/k8s"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// invalidNameChars matches what application names cannot contain
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// options are the flags shared by the convert commands
+type options struct {
+	force    bool
+	appName  string
+	registry string
+	output   string
+}
+
+// NewConvertCommand creates a new convert command
+func NewConvertCommand() *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Convert Docker Compose, Kubernetes, Helm or Procfile projects to nexlayer.yaml",
+		Long: `Convert the configuration of another platform into a nexlayer.yaml, the same way
+'nexlayer init' does when it finds one, but for an explicit file and without
+detecting the rest of the project.
+
+What does not translate is listed after the conversion. The result is
+validated; --force writes it even when it is invalid, and overwrites an
+existing output file.
+
+Examples:
+  nexlayer convert compose docker-compose.yml
+  nexlayer convert k8s k8s/ --app-name shop
+  nexlayer convert helm ./chart --values prod-values.yaml --set replicaCount=1 --out-file -
+  nexlayer convert procfile . --registry ghcr.io/acme`,
+	}
+
+	cmd.PersistentFlags().BoolVar(&opts.force, "force", false, "Write the result even when invalid and overwrite the output file")
+	cmd.PersistentFlags().StringVar(&opts.appName, "app-name", "", "Name of the application (default the project directory)")
+	cmd.PersistentFlags().StringVar(&opts.registry, "registry", "", "Registry of images built from source, replacing <% REGISTRY %>")
+	cmd.PersistentFlags().StringVar(&opts.output, "out-file", "nexlayer.yaml", "File to write, or - for stdout")
+
+	cmd.AddCommand(newComposeCommand(opts))
+	cmd.AddCommand(newK8sCommand(opts))
+	cmd.AddCommand(newHelmCommand(opts))
+	cmd.AddCommand(newProcfileCommand(opts))
+	return cmd
+}
+
+func newComposeCommand(opts *options) *cobra.Command {
+	var buildImages bool
+	var registryUsername string
+	var files, profiles []string
+
+	cmd := &cobra.Command{
+		Use:   "compose [path]",
+		Short: "Convert a Docker Compose file",
+		Long: `Convert a Docker Compose file, or the preferred one of a directory, such as
+docker-compose.yml or compose.yaml. Each service becomes a pod.
+
+Like docker compose, the file is merged with its override file, such as
+docker-compose.override.yml, or with the files given by repeating --file.
+Services of profiles are kept only when --profile or COMPOSE_PROFILES
+activates one of them.
+
+Services built from source keep their build and, without an image of their
+own, are pushed to <% REGISTRY %>/<app>/<service>:latest. With --registry, a
+registryLogin for it is added, whose token is read from the REGISTRY_TOKEN
+variable. --build builds and pushes these images with Docker right away; log
+in to the registry with docker login first.
+
+Examples:
+  nexlayer convert compose
+  nexlayer convert compose -f docker-compose.yml -f docker-compose.prod.yml --profile worker
+  nexlayer convert compose --registry ghcr.io/acme --registry-username bob --build`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := "."
+			var overrides []string
+			switch {
+			case len(files) > 0 && len(args) > 0:
+				return fmt.Errorf("give the compose file either as an argument or with --file")
+			case len(files) > 0:
+				file, overrides = files[0], files[1:]
+			case len(args) > 0:
+				file = args[0]
+			}
+			if info, err := os.Stat(file); err != nil {
+				return err
+			} else if info.IsDir() {
+				if file, err = compose.Find(file); err != nil {
+					return err
+				}
+			}
+			dir := filepath.Dir(file)
+			config, err := compose.Convert(file, compose.ConvertOptions{
+				ProjectDir:       dir,
+				ApplicationName:  opts.name(dir),
+				ForceConversion:  opts.force,
+				ComposeFileName:  filepath.Base(file),
+				RegistryURL:      opts.registry,
+				RegistryUsername: registryUsername,
+				BuildImages:      buildImages,
+				OverrideFiles:    overrides,
+				Profiles:         profiles,
+			})
+			if err != nil {
+				return err
+			}
+			return opts.write(cmd, config, nil)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&files, "file", "f", nil, "Compose file, repeated to merge overrides in order")
+	cmd.Flags().StringArrayVar(&profiles, "profile", nil, "Profile to activate (default COMPOSE_PROFILES)")
+	cmd.Flags().BoolVar(&buildImages, "build", false, "Build and push the images of services built from source (needs --registry)")
+	cmd.Flags().StringVar(&registryUsername, "registry-username", "", "Username of the registryLogin added for images built from source")
+	return cmd
+}
+
+func newK8sCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "k8s <path>",
+		Short: "Convert Kubernetes manifests",
+		Long: `Convert Kubernetes manifests, from a file or the .yaml and .yml files of a
+directory. Each container of a Deployment, StatefulSet, DaemonSet or Pod
+becomes a pod with the ports of the Services selecting it. Claims become
+volumes, mounted ConfigMaps become config files and Ingress paths route to
+pods. Values taken from Secrets become placeholders to set as secrets.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := k8s.ReadManifests(args[0])
+			if err != nil {
+				return err
+			}
+			result, err := k8s.Convert(data, opts.appName)
+			if err != nil {
+				return err
+			}
+			if result.Config.Application.Name == "" {
+				result.Config.Application.Name = opts.name(args[0])
+			}
+			return opts.write(cmd, result.Config, result.Notes)
+		},
+	}
+}
+
+func newHelmCommand(opts *options) *cobra.Command {
+	var release string
+	var values, set []string
+
+	cmd := &cobra.Command{
+		Use:   "helm <chart>",
+		Short: "Convert a Helm chart",
+		Long: `Convert a Helm chart by rendering it with 'helm template', which must be
+installed, and converting the manifests as 'nexlayer convert k8s' does.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := opts.name(args[0])
+			if release == "" {
+				release = name
+			}
+			data, err := k8s.Template(cmd.Context(), args[0], release, values, set)
+			if err != nil {
+				return err
+			}
+			result, err := k8s.Convert(data, name)
+			if err != nil {
+				return err
+			}
+			return opts.write(cmd, result.Config, result.Notes)
+		},
+	}
+
+	cmd.Flags().StringVar(&release, "release", "", "Release name to render the chart with (default the application name)")
+	cmd.Flags().StringSliceVar(&values, "values", nil, "Values files to render the chart with")
+	cmd.Flags().StringArrayVar(&set, "set", nil, "Set a chart value (key=value, repeatable)")
+
+	return cmd
+}
+
+func newProcfileCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:     "procfile [dir]",
+		Aliases: []string{"heroku"},
+		Short:   "Convert a Heroku Procfile and app.json",
+		Long: `Convert the Procfile and optional app.json of a Heroku project. Process types
+become pods, the release process becomes the migrations and add-ons become
+services.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			result, err := heroku.Convert(dir, opts.appName)
+			if err != nil {
+				return err
+			}
+			return opts.write(cmd, result.Config, result.Notes)
+		},
+	}
+}
+
+// name returns --app-name, or a name taken from the directory of path
+func (o *options) name(path string) string {
+	if o.appName != "" {
+		return o.appName
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(abs); err == nil && !info.IsDir() {
+		abs = filepath.Dir(abs)
+	}
+	return strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(filepath.Base(abs)), "-"), "-")
+}
+
+// write applies --registry, validates the configuration and writes it to
+// --out-file, reporting the notes of the conversion
+func (o *options) write(cmd *cobra.Command, config *schema.NexlayerYAML, notes []string) error {
+	if o.registry != "" {
+		applyRegistry(config, o.registry)
+	}
+
+	var problems []string
+	for _, e := range schema.Validate(config) {
+		problems = append(problems, e.Error())
+	}
+	if len(problems) > 0 && !o.force {
+		return fmt.Errorf("converted configuration is invalid (rerun with --force to write it anyway):\n  - %s", strings.Join(problems, "\n  - "))
+	}
+
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	content = schema.WithSchemaHeader(content)
+
+	// Keep stdout a clean stream when the configuration is written to it
+	out := cmd.OutOrStdout()
+	if o.output == "-" {
+		if _, err := out.Write(content); err != nil {
+			return err
+		}
+		out = cmd.ErrOrStderr()
+	} else {
+		if _, err := os.Stat(o.output); err == nil && !o.force {
+			return fmt.Errorf("%s already exists; rerun with --force to overwrite it or choose another --out-file", o.output)
+		}
+		if err := os.WriteFile(o.output, content, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", o.output, err)
+		}
+		fmt.Fprintf(out, "%s Converted %s to %s with %d pods\n", ui.Symbols().Success, config.Application.Name, o.output, len(config.Application.Pods))
+	}
+
+	for _, note := range notes {
+		fmt.Fprintf(out, "%s %s\n", ui.Symbols().Warning, note)
+	}
+	for _, p := range problems {
+		fmt.Fprintf(out, "%s %s\n", ui.Symbols().Error, p)
+	}
+	return nil
+}
+
+// applyRegistry replaces the registry placeholder in images with registry
+// and names an image in it for pods that have none. Images are left to
+// registryLogin when the conversion added one.
+func applyRegistry(config *schema.NexlayerYAML, registry string) {
+	registry = strings.TrimSuffix(registry, "/")
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		if pod.Image == "" && !pod.IsStatic() {
+			pod.Image = registry + "/" + pod.Name + ":latest"
+		}
+		if config.Application.RegistryLogin == nil {
+			pod.Image = strings.ReplaceAll(pod.Image, schema.RegistryPlaceholder, registry)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package cost
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	corecost "github.com/Nexlayer/nexlayer-cli/pkg/core/cost"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewCommand creates a new cost command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var refresh bool
+	var pricingURL, since string
+
+	cmd := &cobra.Command{
+		Use:   "cost [file|app]",
+		Short: "Estimate the monthly cost of a deployment",
+		Long: `Estimate the monthly cost of a nexlayer.yaml, per pod and in total.
+
+Given a running application instead of a file, or with --since, report what
+it actually cost over the period from platform usage, per pod and per volume.
+Idle pods and oversized volumes are flagged with suggested savings.
+
+Pod resources default to a profile based on the pod type and can be
+overridden with annotations:
+  cost.nexlayer.io/cpu: "500m"
+  cost.nexlayer.io/memory: "1Gi"
+  cost.nexlayer.io/replicas: "2"
+
+GPUs requested with resources.gpu are priced per GPU type.
+
+Examples:
+  nexlayer cost
+  nexlayer cost deployment.yaml --refresh
+  nexlayer cost my-app-ns --since 30d`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) > 0 && !isConfigFile(args[0])) || cmd.Flags().Changed("since") {
+				return runReport(cmd, client, args, since, refresh, pricingURL)
+			}
+
+			file := "nexlayer.yaml"
+			if len(args) > 0 {
+				file = args[0]
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			var config schema.NexlayerYAML
+			if err := yaml.Unmarshal(data, &config); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			if _, err := schema.ExpandServices(&config); err != nil {
+				return err
+			}
+
+			table, err := LoadPricing(cmd, refresh, pricingURL)
+			if err != nil {
+				return err
+			}
+			est, err := corecost.EstimateConfig(&config, table)
+			if err != nil {
+				return err
+			}
+
+			return PrintEstimate(cmd.OutOrStdout(), est, ui.Structured())
+		},
+	}
+
+	AddPricingFlags(cmd, &refresh, &pricingURL)
+	cmd.Flags().StringVar(&since, "since", "30d", "Period of the usage report, e.g. 7d, 2w or 12h")
+	return cmd
+}
+
+// AddPricingFlags registers the flags that control where pricing comes from
+func AddPricingFlags(cmd *cobra.Command, refresh *bool, pricingURL *string) {
+	cmd.Flags().BoolVar(refresh, "refresh", false, "Download the latest pricing table before estimating")
+	cmd.Flags().StringVar(pricingURL, "pricing-url", "", "Pricing endpoint (default: <api-url>/pricing)")
+}
+
+// LoadPricing returns the pricing table, refreshing it first when requested.
+// A failed refresh falls back to the cached table with a warning.
+func LoadPricing(cmd *cobra.Command, refresh bool, pricingURL string) (*corecost.PricingTable, error) {
+	if !refresh {
+		return corecost.LoadPricing(), nil
+	}
+	if pricingURL == "" {
+		pricingURL = strings.TrimSuffix(config.GetAPIURL(), "/") + "/pricing"
+	}
+	table, err := corecost.RefreshPricing(cmd.Context(), pricingURL)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s Could not refresh pricing, using cached prices: %v\n", ui.Symbols().Warning, err)
+		return corecost.LoadPricing(), nil
+	}
+	return table, nil
+}
+
+// PrintEstimate writes an estimate as a table or, when structured, in the selected output format
+func PrintEstimate(w io.Writer, est *corecost.Estimate, structured bool) error {
+	if structured {
+		return ui.WriteOutput(est)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "POD\tCPU\tMEMORY\tGPU\tREPLICAS\tSTORAGE\tMONTHLY")
+	for _, p := range est.Pods {
+		gpu := "-"
+		if p.GPUs > 0 {
+			gpu = fmt.Sprintf("%dx %s", p.GPUs, p.GPUType)
+		}
+		fmt.Fprintf(tw, "%s\t%.2f\t%.1fGi\t%s\t%d\t%.0fGi\t%.2f %s\n",
+			p.Name, p.Resources.CPU, p.Resources.MemoryGB, gpu, p.Replicas, p.StorageGB, p.Monthly, est.Currency)
+	}
+	if est.Regions > 1 {
+		fmt.Fprintf(tw, "REGIONS\t\t\t\t\t\tx%d\n", est.Regions)
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t\t\t\t\t%.2f %s\n", est.Total, est.Currency)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(w, "\nEstimates assume continuous usage over a 730-hour month.")
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package cost
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	corecost "github.com/Nexlayer/nexlayer-cli/pkg/core/cost"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// runReport prices the usage of a running application
+func runReport(cmd *cobra.Command, client api.APIClient, args []string, since string, refresh bool, pricingURL string) error {
+	period, err := parseSince(since)
+	if err != nil {
+		return err
+	}
+	namespace, err := deployment.ResolveNamespace(args)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetUsage(cmd.Context(), namespace, period)
+	if err != nil {
+		return fmt.Errorf("failed to get usage: %w", err)
+	}
+	table, err := LoadPricing(cmd, refresh, pricingURL)
+	if err != nil {
+		return err
+	}
+	report, err := corecost.BuildReport(resp.Data, table)
+	if err != nil {
+		return err
+	}
+
+	return PrintReport(cmd.OutOrStdout(), report, ui.Structured())
+}
+
+// PrintReport writes a usage report as tables or, when structured, in the selected output format
+func PrintReport(w io.Writer, r *corecost.Report, structured bool) error {
+	if structured {
+		return ui.WriteOutput(r)
+	}
+
+	fmt.Fprintf(w, "Spend of %s over the last %s\n\n", r.Namespace, formatHours(r.Hours))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "POD\tHOURS\tCPU USE\tREQUESTS\tSPEND\t")
+	for _, p := range r.Pods {
+		flag := ""
+		if p.Idle {
+			flag = "idle"
+		}
+		fmt.Fprintf(tw, "%s\t%.0f\t%.2f/%.2f\t%d\t%.2f %s\t%s\n", p.Name, p.Hours, p.AvgCPU, p.CPU, p.Requests, p.Spend, r.Currency, flag)
+	}
+	if len(r.Volumes) > 0 {
+		fmt.Fprintln(tw, "\t\t\t\t\t")
+		fmt.Fprintln(tw, "VOLUME\tSIZE\tUSED\t\tSPEND\t")
+		for _, v := range r.Volumes {
+			flag := ""
+			if v.Oversized {
+				flag = "oversized"
+			}
+			fmt.Fprintf(tw, "%s/%s\t%.0fGi\t%.1fGi\t\t%.2f %s\t%s\n", v.Pod, v.Name, v.SizeGB, v.UsedGB, v.Spend, r.Currency, flag)
+		}
+	}
+	fmt.Fprintln(tw, "\t\t\t\t\t")
+	fmt.Fprintf(tw, "TOTAL\t\t\t\t%.2f %s\t\n", r.Total, r.Currency)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Suggestions) > 0 {
+		fmt.Fprintln(w, "\nSuggestions:")
+		for _, s := range r.Suggestions {
+			fmt.Fprintf(w, "  • %s %s (saves about %.2f %s/month)\n", s.Target, s.Message, s.MonthlySavings, r.Currency)
+		}
+		fmt.Fprintf(w, "\nPotential savings: %.2f %s/month\n", r.MonthlySavings(), r.Currency)
+	}
+	return nil
+}
+
+// parseSince parses a period such as 30d, 2w or any Go duration
+func parseSince(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v < 1 {
+				return 0, fmt.Errorf("invalid period %q; use e.g. 30d, 2w or 12h", s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < time.Hour {
+		return 0, fmt.Errorf("invalid period %q; use e.g. 30d, 2w or 12h", s)
+	}
+	return d, nil
+}
+
+// formatHours renders a period in days when it spans whole days
+func formatHours(h float64) string {
+	if h >= 24 && int(h)%24 == 0 {
+		return fmt.Sprintf("%dd", int(h)/24)
+	}
+	return fmt.Sprintf("%.0fh", h)
+}
+
+// isConfigFile reports whether arg names a configuration rather than an app
+func isConfigFile(arg string) bool {
+	switch strings.ToLower(filepath.Ext(arg)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	info, err := os.Stat(arg)
+	return err == nil && !info.IsDir()
+}
@@ -6,18 +6,28 @@ package deploy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
 	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/build"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/history"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/migrate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plugin"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/policy"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/quota"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/seed"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/validate"
 	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
 // Add at the top with other style variables
@@ -47,28 +57,11 @@ var (
 			Foreground(lipgloss.Color("#ff0000"))
 )
 
-// findDeploymentFile looks for a deployment file in the current directory
-func findDeploymentFile() (string, error) {
-	// List of possible deployment file names
-	possibleFiles := []string{
-		"deployment.yaml",
-		"deployment.yml",
-		"nexlayer.yaml",
-		"nexlayer.yml",
-	}
-
-	for _, file := range possibleFiles {
-		if _, err := os.Stat(file); err == nil {
-			return file, nil
-		}
-	}
-
-	return "", fmt.Errorf("no deployment file found in current directory\nExpected one of: %v\nCreate a deployment file or specify one with --file", possibleFiles)
-}
-
 // NewCommand creates a new deploy command
 func NewCommand(apiClient api.APIClient) *cobra.Command {
-	var yamlFile string
+	var yamlFile, env, overrideReason, watchScope string
+	var watchFiles, showChanges, yes bool
+	var debounce, waitTimeout time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "deploy [applicationID]",
@@ -85,12 +78,58 @@ Arguments:
 Example:
   nexlayer deploy                    # Deploy using deployment.yaml in current directory
   nexlayer deploy myapp             # Deploy specific application
-  nexlayer deploy -f custom.yaml    # Deploy using custom file`,
+  nexlayer deploy -f custom.yaml    # Deploy using custom file
+  nexlayer deploy --override-freeze "hotfix for checkout outage"  # Deploy during a freeze
+  nexlayer deploy --watch-files      # Rebuild and redeploy on every change
+  nexlayer deploy --plan             # Review the changes before deploying
+  nexlayer deploy --env prod         # Deploy nexlayer.yaml with nexlayer.prod.yaml merged in
+
+With --watch-files the command keeps running after the deployment and redeploys
+once files stop changing for --debounce. Pods with a build section are rebuilt
+with docker and pushed when their build context changes, and deployed pinned to
+the pushed digest:
+
+  pods:
+    - name: api
+      image: <% REGISTRY %>/api:dev
+      build:
+        context: ./api            # relative to nexlayer.yaml
+        dockerfile: Dockerfile    # optional, relative to the context
+
+Use --watch-scope config to redeploy only when the deployment file changes.
+
+With --env the overlay of the environment, named after the deployment file
+(nexlayer.prod.yaml for --env prod), is merged into it. Maps are merged key by
+key and a null removes a key; pods, ports, volumes and secrets are merged by
+name and vars by key, and an item with "$patch: delete" removes its namesake.
+Other values of the overlay replace those of the file:
+
+  # nexlayer.prod.yaml
+  application:
+    pods:
+      - name: api
+        image: <% REGISTRY %>/api:1.4.2
+        vars:
+          - key: LOG_LEVEL
+            value: warn
+      - name: mailcatcher
+        $patch: delete
+
+With --plan the file is first compared with the live deployment, and the pods
+added and removed and the images, ports, env vars and volumes changed are
+listed. Nothing is deployed when nothing changed; otherwise the deployment
+starts once confirmed, or right away with --yes. Volumes, and vars of pods the
+API does not report them for, are compared with the revision deployed last.
+
+After starting, the command waits up to --wait-timeout for every pod to be
+ready and, when a pod has a path, for the application URL to answer. It exits
+non-zero with the failing pods, their last exits and recent logs when the
+deployment fails or never becomes reachable.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// If no file specified, try to find one
 			if yamlFile == "" {
-				file, err := findDeploymentFile()
+				file, err := deployment.FindFile()
 				if err != nil {
 					return err
 				}
@@ -104,40 +143,101 @@ Example:
 				appID = args[0]
 			}
 
-			return runDeploy(apiClient, yamlFile, appID)
+			if showChanges {
+				if watchFiles {
+					return fmt.Errorf("--plan cannot be used with --watch-files")
+				}
+				proceed, err := showPlan(cmd.Context(), apiClient, yamlFile, env, yes)
+				if err != nil || !proceed {
+					return err
+				}
+			}
+
+			if watchFiles {
+				if watchScope != scopeSource && watchScope != scopeConfig {
+					return fmt.Errorf("invalid --watch-scope %q: use %s or %s", watchScope, scopeSource, scopeConfig)
+				}
+				return runWatch(cmd, apiClient, yamlFile, env, appID, overrideReason, watchScope, debounce, waitTimeout)
+			}
+			return runDeploy(apiClient, yamlFile, env, appID, overrideReason, waitTimeout, nil)
 		},
 	}
 
 	cmd.Flags().StringVarP(&yamlFile, "file", "f", "", "Path to deployment YAML file")
+	cmd.Flags().StringVar(&env, "env", "", "Environment whose overlay, e.g. nexlayer.prod.yaml, is merged into the deployment file")
+	cmd.Flags().StringVar(&overrideReason, "override-freeze", "", "Deploy despite the deploy policy; the reason is recorded in the audit log")
+	cmd.Flags().BoolVar(&watchFiles, "watch-files", false, "Keep watching the project and redeploy on changes")
+	cmd.Flags().StringVar(&watchScope, "watch-scope", scopeSource, "What --watch-files watches: source (the project, rebuilding changed images) or config (only the deployment file)")
+	cmd.Flags().DurationVar(&debounce, "debounce", build.DefaultDebounce, "How long files must stop changing before --watch-files redeploys")
+	cmd.Flags().BoolVar(&showChanges, "plan", false, "Show what would change in the live deployment and ask before deploying")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Deploy the --plan changes without asking for confirmation")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", defaultWaitTimeout, "How long to wait for the deployment to become healthy and reachable")
 	return cmd
 }
 
-// runDeploy handles the deployment process
-func runDeploy(client api.APIClient, yamlFile string, appID string) error {
+// defaultWaitTimeout is how long deploy waits for a deployment to be healthy
+const defaultWaitTimeout = 5 * time.Minute
+
+// quotaTimeout bounds the advisory quota check before a deployment
+const quotaTimeout = 5 * time.Second
+
+// logTail is how many log lines a failed deployment shows
+const logTail = 50
+
+// runDeploy handles the deployment process. images replaces the image of
+// pods by name, e.g. with images just built by --watch-files. It fails when
+// the deployment is not healthy and reachable within waitTimeout.
+func runDeploy(client api.APIClient, yamlFile string, env string, appID string, overrideReason string, waitTimeout time.Duration, images map[string]string) (err error) {
+	result := &Result{Environment: env, Status: resultFailed}
+	defer func() { err = writeResult(result, err) }()
+
 	ui.RenderTitleWithBorder("Deploying Application")
 
-	// Read and parse the YAML file
-	yamlData, err := os.ReadFile(yamlFile)
+	// Parse the file, expand the services shorthand into pods and normalize
+	// container paths written on Windows hosts and port protocols
+	config, normalized, err := deployment.LoadEnv(yamlFile, env)
 	if err != nil {
-		return fmt.Errorf("failed to read deployment file: %w", err)
-	}
-
-	var config schema.NexlayerYAML
-	if err := yaml.Unmarshal(yamlData, &config); err != nil {
-		return fmt.Errorf("failed to parse deployment file: %w\nEnsure the file is valid YAML and follows the Nexlayer schema", err)
+		return err
 	}
+	result.Application = config.Application.Name
 
 	// Validate the configuration
-	validator := NewValidator(&config)
+	validator := validate.NewValidator(config).WithBaseDir(filepath.Dir(yamlFile))
 	if err := validator.Validate(); err != nil {
 		ui.RenderError("Validation failed")
 		fmt.Println(err)
+		result.ValidationErrors = validator.Errors()
 		return fmt.Errorf("deployment aborted due to validation errors")
 	}
+	if v, err := policy.Enforce(policy.ActionDeploy, config, overrideReason); err != nil {
+		return err
+	} else if v != nil {
+		ui.RenderWarning(fmt.Sprintf("Overriding deploy policy: %s (recorded in %s)", v, policy.AuditLog))
+	}
+	for i := range config.Application.Pods {
+		if image, ok := images[config.Application.Pods[i].Name]; ok {
+			config.Application.Pods[i].Image = image
+			normalized = true
+		}
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if hookErr := runHooks(plugin.HookOnFailure, yamlFile, config, result.Namespace, result.URL, err.Error()); hookErr != nil {
+			ui.RenderWarning(hookErr.Error())
+		}
+	}()
+	if err := runHooks(plugin.HookPreDeploy, yamlFile, config, "", "", ""); err != nil {
+		return fmt.Errorf("deployment aborted: %w", err)
+	}
 
 	// Show deployment summary before proceeding
 	fmt.Println("\n📋 Deployment Summary:")
 	fmt.Printf("• Application: %s\n", config.Application.Name)
+	if env != "" {
+		fmt.Printf("• Environment: %s (%s)\n", env, schema.OverlayFile(yamlFile, env))
+	}
 	if appID != "" {
 		fmt.Printf("• Application ID: %s\n", appID)
 	} else {
@@ -145,16 +245,85 @@ func runDeploy(client api.APIClient, yamlFile string, appID string) error {
 	}
 	fmt.Printf("• Pods: %d\n", len(config.Application.Pods))
 	for _, pod := range config.Application.Pods {
+		if pod.Static != nil {
+			fmt.Printf("  - %s (static site from %s)\n", pod.Name, pod.Static.Dir)
+			continue
+		}
 		fmt.Printf("  - %s (%s)\n", pod.Name, pod.Image)
 	}
+	if r := config.Application.Regions; r != nil {
+		fmt.Printf("• Regions: %s (primary)", r.Primary)
+		for _, name := range r.Replicas {
+			fmt.Printf(", %s", name)
+		}
+		fmt.Println()
+	}
+	if m := config.Application.Migrations; m != nil {
+		fmt.Printf("• Migrations: %s (%s)\n", m.Command, m.Policy())
+	}
+	for _, pod := range seed.Pods(config, "") {
+		fmt.Printf("• Seed: %s (%s)\n", pod.Name, pod.Seed.Policy())
+	}
 
 	// Start deployment
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
+	// Build and upload static sites, which sets the image of their pods
+	if err := deployment.UploadStaticSites(ctx, client, config, filepath.Dir(yamlFile), os.Stdout); err != nil {
+		return err
+	}
+
+	// Inline config files and envFrom imports; the config checksum restarts
+	// pods when only config changed
+	submitFile, cleanup, err := deployment.PrepareSubmitFile(config, yamlFile, normalized)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	for _, pod := range config.Application.Pods {
+		if sum, ok := pod.Annotations[schema.ConfigChecksumAnnotation]; ok {
+			fmt.Printf("  - %s config files: %d (checksum %s)\n", pod.Name, len(pod.ConfigFiles), sum)
+		}
+	}
+
+	// Run migrations once per release; a failure blocks the rollout
+	if m := config.Application.Migrations; m != nil && m.Policy() == schema.MigrationsBeforeDeploy {
+		fmt.Println("\n🗃  Running migrations...")
+		migrateCtx, cancelMigrate := context.WithTimeout(context.Background(), migrate.DefaultTimeout)
+		_, err := migrate.Apply(migrateCtx, client, config, false, os.Stdout)
+		cancelMigrate()
+		if err != nil {
+			ui.RenderError("Migrations failed")
+			notifyFailure(config.Application.Name, fmt.Sprintf("migrations failed, deployment aborted: %v", err))
+			return fmt.Errorf("deployment aborted: %w", err)
+		}
+	}
+
+	if err := checkSecrets(ctx, client, config); err != nil {
+		return err
+	}
+
+	// Warn about plan limits; the API has the final say, so a quota that
+	// cannot be read does not stop the deployment
+	qctx, cancel := context.WithTimeout(ctx, quotaTimeout)
+	q, err := client.GetQuota(qctx)
+	cancel()
+	switch {
+	case errors.Is(err, api.ErrNotFound), errors.Is(err, api.ErrUnsupported):
+		ui.RenderWarning("Quota unavailable: the API does not report plan limits, deploying without checking them")
+	case err != nil:
+		ui.RenderWarning(fmt.Sprintf("Quota unavailable, deploying without checking plan limits: %v", err))
+	default:
+		for _, w := range quota.Check(q.Data, config, appID == "") {
+			ui.RenderWarning(fmt.Sprintf("Quota: %s", w))
+		}
+	}
+
 	fmt.Println("\n🚀 Starting deployment...")
-	resp, err := client.StartDeployment(ctx, appID, yamlFile)
+	resp, err := client.StartDeployment(ctx, appID, submitFile)
 	if err != nil {
+		notifyFailure(config.Application.Name, fmt.Sprintf("deployment could not start: %v", err))
 		return fmt.Errorf("failed to start deployment: %w", err)
 	}
 
@@ -166,6 +335,20 @@ func runDeploy(client api.APIClient, yamlFile string, appID string) error {
 	fmt.Printf("• Namespace: %s\n", resp.Data.Namespace)
 	fmt.Printf("🚀 URL: %s\n", resp.Data.URL)
 
+	last := deployment.Last{Namespace: resp.Data.Namespace, URL: resp.Data.URL, File: yamlFile, StartedAt: time.Now().UTC()}
+	if err := deployment.SaveLast(last); err != nil {
+		ui.RenderWarning(fmt.Sprintf("Could not record the deployment: %v", err))
+	}
+	source := yamlFile
+	if env != "" {
+		source += " + " + schema.OverlayFile(yamlFile, env)
+	}
+	rev := recordRevision(config.Application.Name, resp.Data.Namespace, resp.Data.URL, source, submitFile)
+	result.URL = resp.Data.URL
+	if rev != nil {
+		result.Revision = rev.ID
+	}
+
 	// Use application name as namespace if not provided
 	if resp.Data.Namespace == "" {
 		// First try to use the application name as a fallback
@@ -195,85 +378,85 @@ func runDeploy(client api.APIClient, yamlFile string, appID string) error {
 		ui.RenderWarning(fmt.Sprintf("Namespace contained invalid characters. Using sanitized namespace '%s'", resp.Data.Namespace))
 	}
 
-	// Create context with timeout for status polling
-	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Minute)
+	result.Namespace = resp.Data.Namespace
+
+	// Create context with timeout for status polling and the reachability check
+	ctx, cancel = context.WithTimeout(context.Background(), waitTimeout)
 	defer cancel()
 
 	// Poll for deployment status with exponential backoff
 	fmt.Println("\nWaiting for deployment to stabilize...")
-	backoff := 2 * time.Second
-	maxBackoff := 10 * time.Second
 	spinner := ui.NewSpinner("Checking deployment status")
 	spinner.Start()
-
-	for {
-		select {
-		case <-ctx.Done():
-			spinner.Stop()
-			ui.RenderWarning("Deployment status check timed out after 5 minutes")
-			fmt.Printf("The deployment is still in progress. Check status with: nexlayer info %s\n", resp.Data.Namespace)
-			return nil
-		case <-time.After(backoff):
-			// Debug logging for namespace
-			fmt.Printf("DEBUG: Using namespace '%s' for status check\n", resp.Data.Namespace)
-
-			info, err := client.GetDeploymentInfo(ctx, resp.Data.Namespace)
-			if err != nil {
-				spinner.Stop()
-				return fmt.Errorf("error checking status: %w", err)
-			}
-
-			// Print deployment details
-			spinner.Stop()
-			fmt.Printf("Status: %s\n", formatPodStatus(info.Data.Status))
-
-			// Check if deployment has reached a stable state
-			if isDeploymentStable(info.Data) {
-				// Normalize status to lowercase for consistent comparison
-				status := strings.ToLower(info.Data.Status)
-
-				if status == "running" || status == "completed" {
-					ui.RenderSuccess(fmt.Sprintf("Deployment is %s!", info.Data.Status))
-					fmt.Printf("You can access your application at: %s\n", resp.Data.URL)
-					printNextSteps(info.Data)
-					return nil
-				} else {
-					// Deployment is stable but failed
-					ui.RenderError("Deployment failed")
-					printTroubleshootingSteps(info.Data)
-					return fmt.Errorf("deployment failed. Check logs for details")
-				}
-			}
-
-			spinner = ui.NewSpinner(fmt.Sprintf("Waiting for pods to be ready (%s)", info.Data.Status))
+	final, err := deployment.Wait(ctx, client, resp.Data.Namespace, 2*time.Second, 10*time.Second, func(d apischema.Deployment) {
+		spinner.Stop()
+		fmt.Printf("Status: %s\n", formatPodStatus(d.Status))
+		if !deployment.IsStable(d) {
+			spinner = ui.NewSpinner(fmt.Sprintf("Waiting for pods to be ready (%s)", d.Status))
 			spinner.Start()
-
-			// Increase backoff time exponentially, but cap it
-			backoff = time.Duration(float64(backoff) * 1.5)
-			if backoff > maxBackoff {
-				backoff = maxBackoff
-			}
 		}
+	})
+	spinner.Stop()
+	if errors.Is(err, context.DeadlineExceeded) {
+		ui.RenderError(fmt.Sprintf("Deployment did not become healthy within %s", waitTimeout))
+		if final == nil {
+			final = &apischema.Deployment{Namespace: resp.Data.Namespace}
+		}
+		setRevisionStatus(rev, history.StatusFailed)
+		reportFailure(client, *final, appID)
+		notifyFailure(config.Application.Name, fmt.Sprintf("deployment %s did not become healthy within %s", resp.Data.Namespace, waitTimeout))
+		return fmt.Errorf("deployment %s did not become healthy within %s", resp.Data.Namespace, waitTimeout)
+	}
+	if err != nil {
+		return err
 	}
-}
-
-// isDeploymentStable checks if the deployment has reached a stable state
-func isDeploymentStable(deployment apischema.Deployment) bool {
-	// Normalize status to lowercase for consistent comparison
-	status := strings.ToLower(deployment.Status)
 
-	if status == "running" || status == "failed" || status == "completed" {
-		return true
+	if !deployment.Succeeded(*final) {
+		ui.RenderError("Deployment failed")
+		setRevisionStatus(rev, history.StatusFailed)
+		reportFailure(client, *final, appID)
+		notifyFailure(config.Application.Name, fmt.Sprintf("deployment %s failed with status %s", final.Namespace, final.Status))
+		return fmt.Errorf("deployment failed. Check logs for details")
 	}
 
-	// Check if all pods are ready
-	for _, pod := range deployment.PodStatuses {
-		if !pod.Ready {
-			return false
+	// Pods can be ready while the route to them is not; check the URL answers
+	if resp.Data.URL != "" && deployment.ServesURL(config) {
+		spinner = ui.NewSpinner(fmt.Sprintf("Checking %s is reachable", resp.Data.URL))
+		spinner.Start()
+		err := deployment.WaitReachable(ctx, resp.Data.URL, 3*time.Second)
+		spinner.Stop()
+		if err != nil {
+			ui.RenderError(fmt.Sprintf("Deployment is running but %s is not reachable", resp.Data.URL))
+			fmt.Printf("Last attempt: %v\n", err)
+			setRevisionStatus(rev, history.StatusFailed)
+			reportFailure(client, *final, appID)
+			notifyFailure(config.Application.Name, fmt.Sprintf("deployment %s is running but %s is not reachable", final.Namespace, resp.Data.URL))
+			return fmt.Errorf("deployment %s never became reachable at %s", final.Namespace, resp.Data.URL)
 		}
 	}
-
-	return true
+	setRevisionStatus(rev, history.StatusHealthy)
+	result.Status = final.Status
+	ui.RenderSuccess(fmt.Sprintf("Deployment is %s!", final.Status))
+
+	// Seed databases on first boot; the platform skips pods already seeded
+	if pods := seed.Pods(config, schema.SeedFirstBoot); len(pods) > 0 {
+		fmt.Println("\n🌱 Loading seed data...")
+		seedCtx, cancelSeed := context.WithTimeout(context.Background(), migrate.DefaultTimeout)
+		defer cancelSeed()
+		for _, pod := range pods {
+			if _, err := seed.Apply(seedCtx, client, config, pod, filepath.Dir(yamlFile), false, os.Stdout); err != nil {
+				ui.RenderError(fmt.Sprintf("Seeding %s failed", pod.Name))
+				notifyFailure(config.Application.Name, fmt.Sprintf("deployment is up but seeding %s failed: %v", pod.Name, err))
+				return fmt.Errorf("deployment is up but seeding %s failed: %w\nRetry with: nexlayer seed --pod %s", pod.Name, err, pod.Name)
+			}
+		}
+	}
+	fmt.Printf("You can access your application at: %s\n", resp.Data.URL)
+	if err := runHooks(plugin.HookPostDeploy, yamlFile, config, final.Namespace, resp.Data.URL, ""); err != nil {
+		ui.RenderWarning(err.Error())
+	}
+	printNextSteps(*final)
+	return nil
 }
 
 // formatPodStatus returns a colored status string
@@ -308,23 +491,69 @@ func printNextSteps(deployment apischema.Deployment) {
 	fmt.Printf("4. Check status: nexlayer info %s\n", deployment.Namespace)
 }
 
+// reportFailure prints the state of the pods that are not ready, the recent
+// logs of the deployment and the troubleshooting steps
+func reportFailure(client api.APIClient, d apischema.Deployment, appID string) {
+	var pending []apischema.PodStatus
+	for _, pod := range d.PodStatuses {
+		if !pod.Ready {
+			pending = append(pending, pod)
+		}
+	}
+	if len(pending) > 0 {
+		fmt.Println("\n❌ Pods not ready:")
+		for _, pod := range pending {
+			state := pod.Status
+			if pod.Waiting != "" {
+				state = pod.Waiting
+			}
+			line := fmt.Sprintf("• %s: %s", pod.Name, state)
+			if pod.Restarts > 0 {
+				line += fmt.Sprintf(", %d restarts", pod.Restarts)
+			}
+			if t := pod.LastExit; t != nil {
+				line += fmt.Sprintf(", last exit %d", t.ExitCode)
+				if t.Reason != "" {
+					line += " (" + t.Reason + ")"
+				}
+			}
+			fmt.Println(line)
+		}
+	}
+
+	if d.Namespace != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		logs, err := client.GetLogs(ctx, d.Namespace, appID, false, logTail)
+		cancel()
+		switch {
+		case err != nil:
+			ui.RenderWarning(fmt.Sprintf("Could not fetch logs: %v", err))
+		case len(logs) > 0:
+			fmt.Printf("\n📜 Last %d log lines:\n", len(logs))
+			for _, line := range logs {
+				fmt.Printf("  %s\n", line)
+			}
+		}
+	}
+
+	printTroubleshootingSteps(d)
+}
+
 // printTroubleshootingSteps prints helpful debugging steps when deployment fails
-func printTroubleshootingSteps(deployment apischema.Deployment) {
+func printTroubleshootingSteps(d apischema.Deployment) {
+	if insights := deployment.Diagnose(d, time.Now()); len(insights) > 0 {
+		fmt.Println("\n🩺 Pod Insights:")
+		for _, in := range insights {
+			fmt.Printf("• %s\n  %s\n", in.Message, in.Hint)
+		}
+	}
+
 	fmt.Println("\n🔍 Troubleshooting Steps:")
-	fmt.Printf("1. Check pod logs: nexlayer logs %s\n", deployment.Namespace)
-	fmt.Printf("2. View detailed status: nexlayer info %s --verbose\n", deployment.Namespace)
+	fmt.Printf("1. Check pod logs: nexlayer logs %s\n", d.Namespace)
+	fmt.Printf("2. View detailed status: nexlayer info %s --verbose\n", d.Namespace)
 	fmt.Println("3. Common issues:")
 	fmt.Println("   - Image pull errors: Check image name and registry credentials")
 	fmt.Println("   - Resource limits: Ensure pods have sufficient CPU/memory")
 	fmt.Println("   - Port conflicts: Verify service port configurations")
 	fmt.Println("4. For more help: https://docs.nexlayer.io/troubleshooting")
 }
-
-// ValidateDeployConfig validates a deployment configuration
-// This function is exported for use by other packages
-func ValidateDeployConfig(yamlConfig *schema.NexlayerYAML) error {
-	if errs := schema.Validate(yamlConfig); len(errs) > 0 {
-		return fmt.Errorf("validation failed: %v", errs)
-	}
-	return nil
-}
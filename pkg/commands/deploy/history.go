@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deploy
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/history"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+)
+
+// recordRevision records the submitted configuration in the history of the
+// application so it can be rolled back to. Failing to record it does not
+// fail the deployment.
+func recordRevision(app, namespace, url, source, submitFile string) *history.Revision {
+	content, err := os.ReadFile(submitFile)
+	if err == nil {
+		rev := &history.Revision{
+			Application: app,
+			Namespace:   namespace,
+			URL:         url,
+			Source:      source,
+			Config:      string(content),
+		}
+		if err = history.Record(rev); err == nil {
+			return rev
+		}
+	}
+	ui.RenderWarning(fmt.Sprintf("Could not record the revision in the deployment history: %v", err))
+	return nil
+}
+
+// setRevisionStatus records the outcome of a recorded revision
+func setRevisionStatus(rev *history.Revision, status string) {
+	if rev == nil {
+		return
+	}
+	if err := history.SetStatus(rev, status); err != nil {
+		ui.RenderWarning(fmt.Sprintf("Could not record the outcome of revision %d: %v", rev.ID, err))
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deploy
+
+import (
+	"context"
+	"os"
+
+	coreconfig "github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/hooks"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plugin"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// runHooks runs the hooks of event for a deployment; namespace and url are
+// set once it started, reason when it failed
+func runHooks(event, yamlFile string, config *schema.NexlayerYAML, namespace, url, reason string) error {
+	return hooks.Run(context.Background(), hooks.Event{
+		Name:        event,
+		File:        yamlFile,
+		Config:      config,
+		Namespace:   namespace,
+		URL:         url,
+		Error:       reason,
+		Environment: plugin.Environment{APIURL: coreconfig.GetAPIURL(), Token: coreconfig.GetToken()},
+	}, os.Stdout)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/notify"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+)
+
+// notifyFailure routes a failed deployment to the notifiers of the matching
+// rules. Problems with the notifiers are reported but do not change the
+// outcome of the deployment.
+func notifyFailure(app, message string) {
+	cfg, err := notify.Load()
+	if err != nil {
+		ui.RenderWarning(fmt.Sprintf("Could not send notifications: %v", err))
+		return
+	}
+	engine, err := notify.NewEngine(cfg)
+	if err != nil || engine.Empty() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	sent, err := engine.Evaluate(ctx, notify.Event{Kind: notify.EventFailure, App: app, Message: message})
+	if err != nil {
+		ui.RenderWarning(fmt.Sprintf("Could not send notifications: %v", err))
+	}
+	if len(sent) > 0 {
+		fmt.Printf("Notified %s\n", strings.Join(sent, ", "))
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/history"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plan"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/manifoldco/promptui"
+)
+
+// showPlan prints what deploying yamlFile, with the overlay of env, would change in the live deployment
+// and asks for confirmation unless yes is set. It reports whether to deploy,
+// which is false when nothing would change.
+func showPlan(ctx context.Context, client api.APIClient, yamlFile, env string, yes bool) (bool, error) {
+	config, _, err := deployment.LoadEnv(yamlFile, env)
+	if err != nil {
+		return false, err
+	}
+	if err := schema.ResolveEnvFrom(config, filepath.Dir(yamlFile)); err != nil {
+		return false, err
+	}
+
+	// The latest revision tells where the application runs and what was
+	// deployed; without one, fall back to the last deployment of the project
+	var namespace string
+	var last *schema.NexlayerYAML
+	if revisions, err := history.List(config.Application.Name); err == nil && len(revisions) > 0 {
+		namespace = revisions[0].Namespace
+		if last, err = revisions[0].Parse(); err != nil {
+			ui.RenderWarning(err.Error())
+		}
+	} else if l, err := deployment.LoadLast(); err == nil && l != nil {
+		namespace = l.Namespace
+	}
+
+	var live apischema.Deployment
+	if namespace != "" {
+		info, err := client.GetDeploymentInfo(ctx, namespace)
+		if err != nil {
+			return false, fmt.Errorf("failed to get the live deployment %s: %w", namespace, err)
+		}
+		live = info.Data
+	}
+
+	changes := plan.Compute(config, live, last)
+	if namespace == "" {
+		fmt.Printf("\n📋 Plan: %s is not deployed yet\n", config.Application.Name)
+	} else {
+		fmt.Printf("\n📋 Plan: %s (namespace %s)\n", config.Application.Name, namespace)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("%s No changes; the live deployment matches %s\n", ui.Symbols().Success, yamlFile)
+		return false, nil
+	}
+	for _, c := range changes {
+		fmt.Printf("  %s\n", c)
+	}
+	fmt.Printf("\n%s\n", plan.Summary(changes))
+
+	if !yes {
+		prompt := promptui.Prompt{Label: "Deploy these changes", IsConfirm: true}
+		if result, err := prompt.Run(); err != nil || strings.ToLower(result) != "y" {
+			return false, fmt.Errorf("deployment cancelled")
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deploy
+
+import (
+	"errors"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/validate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+)
+
+// resultFailed is the status of a deployment that did not become healthy,
+// or did not start
+const resultFailed = "failed"
+
+// Result is what deploy writes with --output json or yaml
+type Result struct {
+	Application      string                     `json:"application,omitempty"`
+	Environment      string                     `json:"environment,omitempty"`
+	Namespace        string                     `json:"namespace,omitempty"`
+	URL              string                     `json:"url,omitempty"`
+	Status           string                     `json:"status"`
+	Revision         int                        `json:"revision,omitempty"`
+	Error            string                     `json:"error,omitempty"`
+	ErrorCode        string                     `json:"errorCode,omitempty"`
+	ValidationErrors []validate.ValidationError `json:"validationErrors,omitempty"`
+}
+
+// writeResult writes the result of a deployment that ended with err, when
+// structured output is selected
+func writeResult(result *Result, err error) error {
+	if !ui.Structured() {
+		return err
+	}
+	if err != nil {
+		result.Status = resultFailed
+		result.Error = err.Error()
+		var apiErr *api.Error
+		if errors.As(err, &apiErr) {
+			result.ErrorCode = apiErr.Code
+		}
+	}
+	if werr := ui.WriteOutput(result); werr != nil && err == nil {
+		return werr
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// checkSecrets stops a deployment whose vars refer to stored secrets that are
+// not set, which the platform would otherwise fail to resolve mid-rollout
+func checkSecrets(ctx context.Context, client api.APIClient, config *schema.NexlayerYAML) error {
+	refs := schema.SecretRefs(config)
+	if len(refs) == 0 {
+		return nil
+	}
+	resp, err := client.ListSecrets(ctx, config.Application.Name)
+	if err != nil {
+		return fmt.Errorf("failed to check the secrets the configuration refers to: %w", err)
+	}
+	stored := make(map[string]bool)
+	for _, s := range resp.Data {
+		stored[s.Name] = true
+	}
+	var missing []string
+	for _, name := range refs {
+		if !stored[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("secrets not set for %s: %s\nSet them with: nexlayer secrets set <name> --app-name %s",
+			config.Application.Name, strings.Join(missing, ", "), config.Application.Name)
+	}
+	return nil
+}
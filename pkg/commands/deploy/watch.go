@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/build"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/static"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// Scopes of --watch-files
+const (
+	scopeSource = "source" // the project, rebuilding images whose context changed
+	scopeConfig = "config" // only the deployment file
+)
+
+// rebuilder keeps the images built by --watch-files
+type rebuilder struct {
+	builder *build.Builder
+	baseDir string
+	images  map[string]string // pinned image by pod
+	sources map[string]string // image of the pod in the file when it was built
+}
+
+// rebuild builds and pushes the images of pods. A pod whose image in the
+// deployment file changed since it was last built is no longer overridden.
+func (r *rebuilder) rebuild(ctx context.Context, config *schema.NexlayerYAML, pods []schema.Pod) error {
+	for _, pod := range config.Application.Pods {
+		if src, ok := r.sources[pod.Name]; ok && (src != pod.Image || pod.Build == nil) {
+			delete(r.images, pod.Name)
+			delete(r.sources, pod.Name)
+		}
+	}
+	if len(pods) == 0 {
+		return nil
+	}
+	if r.builder == nil {
+		b, err := build.NewBuilder(r.baseDir, os.Stdout)
+		if err != nil {
+			return err
+		}
+		r.builder = b
+	}
+	for _, pod := range pods {
+		fmt.Printf("\n🔨 Building %s from %s\n", pod.Name, pod.Build.Context)
+		image, err := r.builder.Image(ctx, config, pod)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📦 Pushed %s\n", image)
+		r.images[pod.Name] = image
+		r.sources[pod.Name] = pod.Image
+	}
+	return nil
+}
+
+// runWatch deploys, then keeps redeploying when files change, rebuilding the
+// images whose build context changed, until interrupted. Failed builds and
+// deployments are reported and the next change is waited for.
+func runWatch(cmd *cobra.Command, client api.APIClient, yamlFile, env, appID, overrideReason, scope string, debounce, waitTimeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	baseDir := filepath.Dir(yamlFile)
+	r := &rebuilder{baseDir: baseDir, images: make(map[string]string), sources: make(map[string]string)}
+	opts := build.WatchOptions{Root: baseDir, Debounce: debounce}
+
+	config, _, err := deployment.LoadEnv(yamlFile, env)
+	if err != nil {
+		return err
+	}
+	if scope == scopeConfig {
+		opts.Files = []string{yamlFile}
+		if env != "" {
+			opts.Files = append(opts.Files, schema.OverlayFile(yamlFile, env))
+		}
+	} else {
+		// Static sites are rebuilt on each deploy; their output is no change
+		for _, pod := range config.Application.Pods {
+			if pod.Static != nil {
+				opts.Ignore = append(opts.Ignore, static.Dir(*pod.Static, baseDir))
+			}
+		}
+		if err := r.rebuild(ctx, config, build.Pods(config)); err != nil {
+			return err
+		}
+	}
+
+	redeploy := func() {
+		if err := runDeploy(client, yamlFile, env, appID, overrideReason, waitTimeout, r.images); err != nil {
+			ui.RenderError(err.Error())
+		}
+	}
+	redeploy()
+
+	fmt.Printf("\n👀 Watching %s for changes (scope: %s). Press Ctrl+C to stop.\n", baseDir, scope)
+	err = build.Watch(ctx, opts, func(paths []string) {
+		fmt.Printf("\n🔄 %d file(s) changed, redeploying\n", len(paths))
+		for _, p := range paths {
+			fmt.Printf("  - %s\n", p)
+		}
+		config, _, err := deployment.LoadEnv(yamlFile, env)
+		if err != nil {
+			ui.RenderError(err.Error())
+			return
+		}
+		if scope == scopeSource {
+			if err := r.rebuild(ctx, config, build.Affected(config, baseDir, paths)); err != nil {
+				ui.RenderError(fmt.Sprintf("%v; keeping the running release", err))
+				return
+			}
+		}
+		redeploy()
+		fmt.Printf("\n👀 Watching for changes. Press Ctrl+C to stop.\n")
+	})
+	fmt.Println("\nStopped watching")
+	return err
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package destroy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Result is a deployment removed by destroy
+type Result struct {
+	Namespace   string `json:"namespace"`
+	VolumesKept bool   `json:"volumesKept"`
+}
+
+// NewCommand creates the destroy command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var namespace string
+	var force, keepVolumes bool
+
+	cmd := &cobra.Command{
+		Use:   "destroy <app>",
+		Short: "Remove the deployments of an application",
+		Long: `Remove every live deployment of an application, or only the one in --namespace,
+with its pods, routes and volumes. This cannot be undone.
+
+You are asked to type the name of the application to confirm; --force skips
+the confirmation, as needed when not running in a terminal. With
+--keep-volumes the volumes are kept, and a later deployment of the
+application reattaches them.
+
+Examples:
+  nexlayer destroy my-app
+  nexlayer destroy my-app --namespace my-app-staging
+  nexlayer destroy my-app --keep-volumes --force`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Applications(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app := args[0]
+			ctx := cmd.Context()
+
+			resp, err := client.ListDeployments(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list deployments: %w", err)
+			}
+			var namespaces []string
+			for _, d := range resp.Data {
+				if d.TemplateName != app {
+					continue
+				}
+				if namespace == "" || d.Namespace == namespace {
+					namespaces = append(namespaces, d.Namespace)
+				}
+			}
+			if len(namespaces) == 0 {
+				if namespace != "" {
+					return fmt.Errorf("%s has no deployment in namespace %s; see 'nexlayer list'", app, namespace)
+				}
+				return fmt.Errorf("%s has no live deployments; see 'nexlayer list'", app)
+			}
+
+			if !force {
+				if err := confirm(cmd, app, namespaces, keepVolumes); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			results := []Result{}
+			for _, ns := range namespaces {
+				if err := client.DeleteDeployment(ctx, ns, keepVolumes); err != nil {
+					return fmt.Errorf("failed to destroy %s: %w", ns, err)
+				}
+				results = append(results, Result{Namespace: ns, VolumesKept: keepVolumes})
+				if !ui.Structured() {
+					fmt.Fprintf(out, "%s Destroyed %s\n", ui.Symbols().Success, ns)
+				}
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(results)
+			}
+			if keepVolumes {
+				fmt.Fprintf(out, "Volumes were kept; deploy %s again to reattach them\n", app)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "Destroy only the deployment in this namespace")
+	cmd.Flags().BoolVar(&force, "force", false, "Destroy without asking for confirmation")
+	cmd.Flags().BoolVar(&keepVolumes, "keep-volumes", false, "Keep the volumes of the deployments")
+
+	return cmd
+}
+
+// confirm lists what is about to be destroyed and asks for the name of the
+// application, refusing when there is no terminal to ask in
+func confirm(cmd *cobra.Command, app string, namespaces []string, keepVolumes bool) error {
+	if ui.Structured() || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("refusing to destroy %s without confirmation; rerun with --force", app)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "This will destroy the following deployments of %s:\n", app)
+	for _, ns := range namespaces {
+		fmt.Fprintf(out, "  %s %s\n", ui.Symbols().Bullet, ns)
+	}
+	if keepVolumes {
+		fmt.Fprintln(out, "Their volumes will be kept.")
+	} else {
+		ui.RenderWarning("Their volumes and all data in them will be deleted")
+	}
+
+	prompt := promptui.Prompt{Label: fmt.Sprintf("Type %s to confirm", app)}
+	if result, err := prompt.Run(); err != nil || strings.TrimSpace(result) != app {
+		return fmt.Errorf("destroy cancelled")
+	}
+	return nil
+}
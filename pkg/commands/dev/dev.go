@@ -0,0 +1,152 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package dev
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	coredev "github.com/Nexlayer/nexlayer-cli/pkg/core/dev"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/static"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// workDir holds files generated for local runs, such as mounted secrets
+var workDir = filepath.Join(".nexlayer", "dev")
+
+// NewCommand creates a new dev command
+func NewCommand() *cobra.Command {
+	var file string
+	var detach, printOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Run the application locally with Docker",
+		Long: `Run the pods declared in nexlayer.yaml on this machine with Docker, so the
+same file drives local development and cloud deploys.
+
+  • Pods share a private network and reach each other as <pod>.pod
+  • servicePorts are published on localhost (port -> targetPort)
+  • vars are passed through, with <% URL %> pointing at localhost
+  • volumes become named Docker volumes that survive restarts
+  • secrets and configFiles are written under .nexlayer/dev and mounted read-only
+
+Logs of all pods are streamed until Ctrl+C, which stops the pods.
+
+Examples:
+  nexlayer dev
+  nexlayer dev --detach
+  nexlayer dev down`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := loadPlan(file)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if printOnly {
+				fmt.Fprintf(out, "docker network create %s\n", plan.Network)
+				for _, c := range plan.Containers {
+					fmt.Fprintf(out, "docker run %s\n", strings.Join(c.Args, " "))
+				}
+				return nil
+			}
+
+			docker, err := coredev.NewDocker()
+			if err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			if err := docker.Up(ctx, plan, out); err != nil {
+				docker.Down(context.Background(), plan.App)
+				return err
+			}
+			fmt.Fprintf(out, "\n%s %s is running at %s\n", ui.Symbols().Success, plan.App, plan.URL)
+			for _, c := range plan.Containers {
+				for _, p := range c.Ports {
+					fmt.Fprintf(out, "  %s %-12s localhost:%s\n", ui.Symbols().Bullet, c.Pod, strings.SplitN(p, ":", 2)[0])
+				}
+			}
+			if detach {
+				fmt.Fprintln(out, "\nStop it with 'nexlayer dev down'")
+				return nil
+			}
+
+			fmt.Fprintln(out, "\nPress Ctrl+C to stop")
+			docker.Logs(ctx, plan, out)
+			fmt.Fprintln(out, "\nStopping pods...")
+			return docker.Down(context.Background(), plan.App)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Path to deployment YAML file")
+	cmd.Flags().BoolVarP(&detach, "detach", "d", false, "Start the pods in the background")
+	cmd.Flags().BoolVar(&printOnly, "print", false, "Print the docker commands instead of running them")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "down",
+		Short: "Stop pods started with nexlayer dev",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := loadPlan(file)
+			if err != nil {
+				return err
+			}
+			docker, err := coredev.NewDocker()
+			if err != nil {
+				return err
+			}
+			if err := docker.Down(cmd.Context(), plan.App); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Stopped %s\n", ui.Symbols().Success, plan.App)
+			return nil
+		},
+	})
+
+	return cmd
+}
+
+// loadPlan reads a configuration file and translates it into containers
+func loadPlan(file string) (*coredev.Plan, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var config schema.NexlayerYAML
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if _, err := schema.ExpandServices(&config); err != nil {
+		return nil, err
+	}
+	if err := schema.ResolveConfigFiles(&config, filepath.Dir(file)); err != nil {
+		return nil, err
+	}
+	if err := schema.ResolveEnvFrom(&config, filepath.Dir(file)); err != nil {
+		return nil, err
+	}
+	for _, pod := range config.Application.Pods {
+		if pod.Static != nil {
+			dir, err := filepath.Abs(static.Dir(*pod.Static, filepath.Dir(file)))
+			if err != nil {
+				return nil, err
+			}
+			pod.Static.Dir = dir
+		}
+	}
+	return coredev.NewPlan(&config, workDir)
+}
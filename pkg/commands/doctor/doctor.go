@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package doctor
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/auth"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	coredoctor "github.com/Nexlayer/nexlayer-cli/pkg/core/doctor"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates a new doctor command. The API is reached through the
+// transport of client, so that its TLS settings apply.
+func NewCommand(client api.APIClient) *cobra.Command {
+	var file string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose problems with your Nexlayer setup",
+		Long: `Check that the CLI can work in this environment and suggest fixes.
+
+Checks:
+  config    the CLI config file parses
+  project   nexlayer.yaml in the current directory is valid
+  api       the Nexlayer API is reachable
+  token     the API token is set and accepted
+  docker    Docker is installed and its daemon is running
+  dns       the custom domain in nexlayer.yaml resolves
+  plugins   installed plugins are executable
+
+Exits non-zero when any check fails.
+
+Examples:
+  nexlayer doctor
+  nexlayer doctor --file deploy/nexlayer.yaml --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			env := environment(file, timeout)
+			if c, ok := client.(interface{ Transport() http.RoundTripper }); ok {
+				env.Transport = c.Transport()
+			}
+			results := coredoctor.Run(cmd.Context(), env, coredoctor.DefaultChecks())
+
+			out := cmd.OutOrStdout()
+			if ui.Structured() {
+				if err := ui.WriteOutput(results); err != nil {
+					return fmt.Errorf("failed to encode results: %w", err)
+				}
+			} else {
+				for _, r := range results {
+					fmt.Fprintf(out, "%s %-8s %s\n", symbol(r.Status), r.Check, r.Message)
+					if r.Fix != "" && r.Status >= coredoctor.StatusWarn {
+						fmt.Fprintf(out, "  %-8s   fix: %s\n", "", r.Fix)
+					}
+				}
+			}
+
+			if coredoctor.Failed(results) {
+				return fmt.Errorf("one or more checks failed")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Project configuration to check")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Second, "Time allowed for each check")
+
+	return cmd
+}
+
+// environment collects the settings the checks inspect
+func environment(file string, timeout time.Duration) *coredoctor.Environment {
+	token := config.GetToken()
+	if token == "" {
+		token = auth.FromEnv()
+	}
+	cliConfig, _ := config.GetConfigFile()
+	return &coredoctor.Environment{
+		APIURL:     strings.TrimSpace(config.GetAPIURL()),
+		Token:      token,
+		CLIConfig:  cliConfig,
+		ConfigFile: file,
+		Timeout:    timeout,
+	}
+}
+
+// symbol returns the glyph shown for a status
+func symbol(s coredoctor.Status) string {
+	switch s {
+	case coredoctor.StatusPass:
+		return ui.Symbols().Success
+	case coredoctor.StatusWarn:
+		return ui.Symbols().Warning
+	case coredoctor.StatusFail:
+		return ui.Symbols().Error
+	default:
+		return ui.Symbols().Bullet
+	}
+}
@@ -5,10 +5,21 @@
 package domain
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/dns"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/profile"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
@@ -23,23 +34,47 @@ Key Features:
   • Map custom domains to your applications
   • Automatic SSL certificate provisioning
   • DNS validation and health checks
-  • Zero-downtime domain updates`,
+  • Zero-downtime domain updates
+
+Examples:
+  nexlayer domain set my-app --domain example.com
+  nexlayer domain list my-app
+  nexlayer domain status my-app --wait
+  nexlayer domain remove my-app example.com`,
 	}
 
-	// Add set subcommand
 	cmd.AddCommand(newSetCommand(client))
+	cmd.AddCommand(newListCommand(client))
+	cmd.AddCommand(newRemoveCommand(client))
+	cmd.AddCommand(newStatusCommand(client))
 
 	return cmd
 }
 
+// setResult is what domain set writes with --output json or yaml
+type setResult struct {
+	Application string    `json:"application"`
+	Domain      string    `json:"domain"`
+	Record      dnsRecord `json:"dnsRecord"`
+	DNSProvider string    `json:"dnsProvider,omitempty"` // provider the record was created at
+}
+
+// dnsRecord is the DNS record pointing a custom domain to the application
+type dnsRecord struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target string `json:"target"`
+}
+
 // newSetCommand creates the set subcommand
 func newSetCommand(client api.APIClient) *cobra.Command {
-	var customDomain string
+	var customDomains []string
+	var noDNS bool
 
 	cmd := &cobra.Command{
 		Use:   "set <applicationID>",
-		Short: "Configure custom domain for an application",
-		Long: `Configure a custom domain for your Nexlayer application.
+		Short: "Configure custom domains for an application",
+		Long: `Configure one or more custom domains for your Nexlayer application.
 
 The domain will be automatically configured with:
   • SSL/TLS certificate provisioning
@@ -47,67 +82,387 @@ The domain will be automatically configured with:
   • Health monitoring
   • Zero-downtime updates
 
+Repeat --domain to serve the application at several domains. A wildcard
+domain, *.example.com, serves it at every subdomain of its parent.
+
+When the CLI profile configures a DNS provider (Cloudflare, Route53 or Google
+Cloud DNS), the CNAME records are created there too; otherwise add them
+yourself. See 'nexlayer config set-profile --help'.
+
 Examples:
   nexlayer domain set my-app --domain example.com
-  nexlayer domain set api-backend --domain api.mycompany.com`,
+  nexlayer domain set api-backend --domain api.mycompany.com
+  nexlayer domain set my-app --domain example.com --domain www.example.com
+  nexlayer domain set my-app --domain '*.preview.example.com'
+  nexlayer domain set my-app --domain example.com --no-dns`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			applicationID := args[0]
+			out := cmd.OutOrStdout()
 
-			// Validate domain
-			if err := ValidateDomain(customDomain); err != nil {
+			domains, err := normalizeDomains(customDomains)
+			if err != nil {
 				return err
 			}
 
-			// Show progress
-			fmt.Fprintf(cmd.OutOrStdout(), "🔄 Configuring domain %s for application %s...\n", customDomain, applicationID)
-
-			// Call API to save custom domain
-			if _, err := client.SaveCustomDomain(cmd.Context(), applicationID, customDomain); err != nil {
-				return fmt.Errorf("failed to save custom domain: %w", err)
-			}
-
 			// Get the application URL from the deployment info to verify it exists
 			deployInfo, err := client.GetDeploymentInfo(cmd.Context(), applicationID)
 			if err != nil {
 				return fmt.Errorf("failed to get deployment info: %w", err)
 			}
+			target := dns.Host(deployInfo.Data.URL)
+
+			var provider dns.Provider
+			if !noDNS {
+				provider = dnsProvider(cmd)
+			}
+
+			results := make([]setResult, 0, len(domains))
+			for _, d := range domains {
+				if !ui.Structured() {
+					fmt.Fprintf(out, "🔄 Configuring domain %s for application %s...\n", d, applicationID)
+				}
+				if _, err := client.SaveCustomDomain(cmd.Context(), applicationID, d); err != nil {
+					return fmt.Errorf("failed to save custom domain %s: %w", d, err)
+				}
+
+				result := setResult{
+					Application: applicationID,
+					Domain:      d,
+					Record:      dnsRecord{Type: "CNAME", Name: d, Target: target},
+				}
+				if provider != nil {
+					if err := provider.UpsertCNAME(cmd.Context(), d, target); err != nil {
+						ui.RenderWarning(fmt.Sprintf("Could not create the DNS record of %s in %s: %v", d, provider.Name(), err))
+					} else {
+						result.DNSProvider = provider.Name()
+					}
+				}
+				results = append(results, result)
+			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "\n✨ Custom domain configured successfully!\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "\nNext Steps:\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "1. Add the following DNS record to your domain:\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "   CNAME %s -> %s\n", customDomain, deployInfo.Data.URL)
-			fmt.Fprintf(cmd.OutOrStdout(), "2. Wait for DNS propagation (may take up to 24 hours)\n")
-			fmt.Fprintf(cmd.OutOrStdout(), "3. Your domain will be automatically validated and SSL certificate provisioned\n")
+			if ui.Structured() {
+				return ui.WriteOutput(results)
+			}
+
+			fmt.Fprintf(out, "\n✨ Custom domains configured successfully!\n")
+			fmt.Fprintf(out, "\nNext Steps:\n")
+			fmt.Fprintf(out, "1. DNS records pointing your domains to the application:\n")
+			for _, r := range results {
+				if r.DNSProvider != "" {
+					fmt.Fprintf(out, "   %s CNAME %s -> %s (created in %s)\n", ui.Symbols().Success, r.Domain, target, r.DNSProvider)
+				} else {
+					fmt.Fprintf(out, "   %s CNAME %s -> %s (add it to your domain)\n", ui.Symbols().Bullet, r.Domain, target)
+				}
+			}
+			fmt.Fprintf(out, "2. Wait for DNS propagation (may take up to 24 hours); follow it with 'nexlayer domain status %s --wait'\n", applicationID)
+			fmt.Fprintf(out, "3. Your domains will be automatically validated and SSL certificates provisioned\n")
 
 			return nil
 		},
 	}
 
-	cmd.Flags().StringVar(&customDomain, "domain", "", "Custom domain to configure (required)")
+	cmd.Flags().StringArrayVar(&customDomains, "domain", nil, "Custom domain to configure; repeat for several (required)")
 	cmd.MarkFlagRequired("domain")
+	cmd.Flags().BoolVar(&noDNS, "no-dns", false, "Do not create the DNS records at the DNS provider of the profile")
 
 	return cmd
 }
 
-// ValidateDomain checks if a domain name is valid using the centralized validation system
-func ValidateDomain(domain string) error {
-	if domain == "" {
-		return fmt.Errorf("domain cannot be empty")
+// normalizeDomains lower-cases and validates domains, dropping repeats
+func normalizeDomains(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	var domains []string
+	for _, name := range names {
+		d := dns.Host(name)
+		if err := schema.ValidateDomain(d); err != nil {
+			return nil, err
+		}
+		if !seen[d] {
+			seen[d] = true
+			domains = append(domains, d)
+		}
+	}
+	return domains, nil
+}
+
+// dnsProvider returns the DNS provider of the active profile, or nil when it
+// has none or it cannot be used, leaving the records to the user
+func dnsProvider(cmd *cobra.Command) dns.Provider {
+	profiles, err := profile.Load()
+	if err != nil {
+		return nil
+	}
+	name, _ := cmd.Flags().GetString("profile")
+	p, err := profiles.Active(name)
+	if err != nil || p.DNS == nil {
+		return nil
+	}
+
+	provider, err := dns.NewProvider(*p.DNS)
+	if err != nil {
+		ui.RenderWarning(fmt.Sprintf("Could not create the DNS records: %v", err))
+		return nil
+	}
+	return provider
+}
+
+// newListCommand creates the list subcommand
+func newListCommand(client api.APIClient) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list <applicationID>",
+		Aliases: []string{"ls"},
+		Short:   "List the custom domains of an application",
+		Long: `List the custom domains of an application, the host their CNAME record must
+point to and the state of their SSL certificates.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domains, err := listDomains(cmd.Context(), client, args[0])
+			if err != nil {
+				return err
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(domains)
+			}
+			if len(domains) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No custom domains for %s; add one with 'nexlayer domain set'\n", args[0])
+				return nil
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("DOMAIN", "TARGET", "SSL", "EXPIRES")
+			for _, d := range domains {
+				table.AddRow(d.Domain, d.Target, d.SSLStatus, formatTime(d.SSLExpiresAt))
+			}
+			return table.Render()
+		},
+	}
+}
+
+// newRemoveCommand creates the remove subcommand
+func newRemoveCommand(client api.APIClient) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:     "remove <applicationID> <domain>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a custom domain from an application",
+		Long: `Detach a custom domain from an application and revoke its certificate. The
+application stays reachable at its Nexlayer URL. Delete the DNS record
+afterwards, or point it elsewhere.
+
+Examples:
+  nexlayer domain remove my-app example.com
+  nexlayer domain remove my-app api.mycompany.com --yes`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationID, customDomain := args[0], dns.Host(args[1])
+
+			if !yes {
+				prompt := promptui.Prompt{Label: fmt.Sprintf("Remove %s from %s", customDomain, applicationID), IsConfirm: true}
+				if result, err := prompt.Run(); err != nil || strings.ToLower(result) != "y" {
+					return fmt.Errorf("removal cancelled")
+				}
+			}
+			if err := client.RemoveCustomDomain(cmd.Context(), applicationID, customDomain); err != nil {
+				return err
+			}
+
+			if ui.Structured() {
+				return ui.WriteOutput(struct {
+					Application string `json:"application"`
+					Domain      string `json:"domain"`
+				}{Application: applicationID, Domain: customDomain})
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Removed %s from %s\n", ui.Symbols().Success, customDomain, applicationID)
+			fmt.Fprintf(cmd.OutOrStdout(), "Remember to delete its DNS record\n")
+			return nil
+		},
 	}
 
-	// Create a minimal YAML with just the domain to validate
-	yaml := &schema.NexlayerYAML{
-		Application: schema.Application{
-			Name: "temp",
-			URL:  domain,
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Remove without asking for confirmation")
+
+	return cmd
+}
+
+// domainStatus is the DNS and certificate state of a custom domain
+type domainStatus struct {
+	Domain       string     `json:"domain"`
+	DNS          dns.Record `json:"dns"`
+	SSLStatus    string     `json:"sslStatus"`
+	SSLError     string     `json:"sslError,omitempty"`
+	SSLExpiresAt time.Time  `json:"sslExpiresAt,omitempty"`
+	Ready        bool       `json:"ready"`
+}
+
+// newStatusCommand creates the status subcommand
+func newStatusCommand(client api.APIClient) *cobra.Command {
+	var wait bool
+	var interval, timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "status <applicationID> [domain]",
+		Short: "Check the DNS and SSL certificate of custom domains",
+		Long: `Look up the DNS records of the custom domains of an application, or of one of
+them, from this machine and show whether they point to the application yet,
+along with the state of their SSL certificates.
+
+A domain is ready once its CNAME record points to its target and its
+certificate is issued. Apex domains, which cannot have a CNAME record, are
+ready when they resolve to the same addresses as the target. With --wait the
+check is repeated until every domain is ready, to follow DNS propagation.
+
+Examples:
+  nexlayer domain status my-app
+  nexlayer domain status my-app example.com --wait --timeout 1h`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			applicationID := args[0]
+			ctx := cmd.Context()
+			if wait {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			out := cmd.OutOrStdout()
+			for {
+				statuses, err := checkDomains(ctx, client, applicationID, args[1:])
+				if err != nil {
+					return err
+				}
+				if !wait || allReady(statuses) {
+					if ui.Structured() {
+						return ui.WriteOutput(statuses)
+					}
+					printStatuses(out, statuses)
+					return nil
+				}
+
+				if !ui.Structured() {
+					printStatuses(out, statuses)
+					fmt.Fprintf(out, "Checking again in %s...\n\n", interval)
+				}
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("custom domains of %s not ready within %s", applicationID, timeout)
+				case <-time.After(interval):
+				}
+			}
 		},
 	}
 
-	// Validate the domain URL format
-	if err := schema.Validate(yaml); err != nil {
-		return fmt.Errorf("invalid domain format: %v", err)
+	cmd.Flags().BoolVar(&wait, "wait", false, "Check again until every domain is ready")
+	cmd.Flags().DurationVar(&interval, "interval", 30*time.Second, "Time between checks with --wait")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "How long to wait with --wait")
+
+	return cmd
+}
+
+// listDomains returns the custom domains of an application sorted by name
+func listDomains(ctx context.Context, client api.APIClient, applicationID string) ([]apischema.CustomDomain, error) {
+	resp, err := client.ListCustomDomains(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	domains := resp.Data
+	if domains == nil {
+		domains = []apischema.CustomDomain{}
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i].Domain < domains[j].Domain })
+	return domains, nil
+}
+
+// checkDomains looks up the custom domains of an application, or only those
+// named, and combines their DNS records with their certificate state
+func checkDomains(ctx context.Context, client api.APIClient, applicationID string, only []string) ([]domainStatus, error) {
+	domains, err := listDomains(ctx, client, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(only) > 0 {
+		name := dns.Host(only[0])
+		var found []apischema.CustomDomain
+		for _, d := range domains {
+			if dns.Host(d.Domain) == name {
+				found = append(found, d)
+			}
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("%s is not a custom domain of %s; see 'nexlayer domain list %s'", name, applicationID, applicationID)
+		}
+		domains = found
+	}
+
+	var resolver net.Resolver
+	statuses := make([]domainStatus, 0, len(domains))
+	for _, d := range domains {
+		s := domainStatus{
+			Domain:       d.Domain,
+			DNS:          dns.Check(ctx, &resolver, d.Domain, d.Target),
+			SSLStatus:    d.SSLStatus,
+			SSLError:     d.SSLError,
+			SSLExpiresAt: d.SSLExpiresAt,
+		}
+		if s.SSLStatus == "" {
+			s.SSLStatus = "pending"
+		}
+		s.Ready = s.DNS.PointsTo && s.SSLStatus == "issued"
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// allReady reports whether every domain is ready
+func allReady(statuses []domainStatus) bool {
+	for _, s := range statuses {
+		if !s.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// printStatuses describes the DNS and certificate of each domain, with what
+// to fix when they are not ready
+func printStatuses(out io.Writer, statuses []domainStatus) {
+	sym := ui.Symbols()
+	if len(statuses) == 0 {
+		fmt.Fprintln(out, "No custom domains; add one with 'nexlayer domain set'")
+		return
+	}
+	for _, s := range statuses {
+		fmt.Fprintln(out, s.Domain)
+
+		rec := s.DNS
+		switch {
+		case rec.PointsTo && rec.CNAME != "":
+			fmt.Fprintf(out, "  %s DNS  CNAME -> %s\n", sym.Success, rec.CNAME)
+		case rec.PointsTo:
+			fmt.Fprintf(out, "  %s DNS  resolves to %s like %s\n", sym.Success, strings.Join(rec.Addresses, ", "), rec.Target)
+		case rec.Error != "":
+			fmt.Fprintf(out, "  %s DNS  %s; add the record CNAME %s -> %s\n", sym.Error, rec.Error, s.Domain, rec.Target)
+		case rec.CNAME != "":
+			fmt.Fprintf(out, "  %s DNS  CNAME -> %s, expected %s\n", sym.Warning, rec.CNAME, rec.Target)
+		default:
+			fmt.Fprintf(out, "  %s DNS  resolves to %s, not %s yet\n", sym.Warning, strings.Join(rec.Addresses, ", "), rec.Target)
+		}
+
+		switch s.SSLStatus {
+		case "issued":
+			fmt.Fprintf(out, "  %s SSL  issued, expires %s\n", sym.Success, formatTime(s.SSLExpiresAt))
+		case "failed":
+			fmt.Fprintf(out, "  %s SSL  failed: %s\n", sym.Error, s.SSLError)
+		default:
+			fmt.Fprintf(out, "  %s SSL  %s; issued once DNS points to %s\n", sym.Warning, s.SSLStatus, rec.Target)
+		}
 	}
+}
 
-	return nil
+// formatTime formats a time in the local zone, or "-" when it is not set
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
 }
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package drift
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	corecompare "github.com/Nexlayer/nexlayer-cli/pkg/core/compare"
+	coredrift "github.com/Nexlayer/nexlayer-cli/pkg/core/drift"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewCommand creates a new drift command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var file, appID string
+	var reconcile, exitCode bool
+
+	cmd := &cobra.Command{
+		Use:   "drift <namespace>",
+		Short: "Detect changes made outside nexlayer.yaml",
+		Long: `Compare nexlayer.yaml with the live deployment and report changes made out
+of band, such as edits in the dashboard or manual scaling: images, vars, the
+custom domain and the number of replicas per pod.
+
+With --reconcile the file is deployed again so the deployment matches it.
+
+Examples:
+  nexlayer drift my-app-ns
+  nexlayer drift my-app-ns --reconcile
+  nexlayer drift my-app-ns --exit-code    # exit 2 when drift is found, for CI`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace := args[0]
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			var config schema.NexlayerYAML
+			if err := yaml.Unmarshal(data, &config); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			if _, err := schema.ExpandServices(&config); err != nil {
+				return err
+			}
+			if err := schema.ResolveEnvFrom(&config, filepath.Dir(file)); err != nil {
+				return err
+			}
+
+			info, err := client.GetDeploymentInfo(cmd.Context(), namespace)
+			if err != nil {
+				return fmt.Errorf("failed to get deployment info: %w", err)
+			}
+			diffs := coredrift.Detect(&config, info.Data)
+
+			out := cmd.OutOrStdout()
+			if ui.Structured() {
+				if diffs == nil {
+					diffs = []corecompare.Difference{}
+				}
+				if err := ui.WriteOutput(diffs); err != nil {
+					return err
+				}
+			} else if len(diffs) == 0 {
+				fmt.Fprintf(out, "%s %s matches %s\n", ui.Symbols().Success, namespace, file)
+			} else {
+				fmt.Fprintf(out, "%s %d changes in %s are not in %s\n", ui.Symbols().Warning, len(diffs), namespace, file)
+				table := ui.NewTable()
+				table.AddHeader("POD", "FIELD", "FILE", "LIVE")
+				for _, d := range diffs {
+					pod := d.Pod
+					if pod == "" {
+						pod = corecompare.Missing
+					}
+					table.AddRow(pod, d.Field, d.Left, d.Right)
+				}
+				if err := table.Render(); err != nil {
+					return err
+				}
+			}
+
+			if len(diffs) == 0 {
+				return nil
+			}
+			if reconcile {
+				fmt.Fprintf(out, "Re-applying %s...\n", file)
+				resp, err := client.StartDeployment(cmd.Context(), appID, file)
+				if err != nil {
+					return fmt.Errorf("failed to reconcile: %w", err)
+				}
+				fmt.Fprintf(out, "%s Deployment started in namespace %s\n", ui.Symbols().Success, resp.Data.Namespace)
+				return nil
+			}
+			if exitCode {
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration the deployment was created from")
+	cmd.Flags().BoolVar(&reconcile, "reconcile", false, "Deploy the file again to remove the drift")
+	cmd.Flags().StringVar(&appID, "app-id", "", "Application ID to deploy to with --reconcile")
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit with status 2 when drift is found")
+
+	return cmd
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package execcmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// DefaultCommand is run when no command is given
+var DefaultCommand = []string{"sh"}
+
+// NewCommand creates the exec command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var container string
+	var noTTY bool
+
+	cmd := &cobra.Command{
+		Use:   "exec <namespace> <pod> [-- command...]",
+		Short: "Run a command or open a shell in a running pod",
+		Long: `Run a command in a pod of a live deployment, or open a shell when no
+command is given. Standard input is sent to the command and its output shown
+here; nexlayer exits with the exit code of the command.
+
+When run from a terminal the command gets one too, so that shells and tools
+such as top work as they would locally; --no-tty turns it off, e.g. to pipe
+data in or out. --container runs the command in a sidecar of the pod.
+
+Examples:
+  nexlayer exec my-app-ns web
+  nexlayer exec my-app-ns web -- sh
+  nexlayer exec my-app-ns db -- psql -U postgres
+  nexlayer exec my-app-ns api --container logs -- cat /etc/fluent-bit/fluent-bit.conf
+  nexlayer exec my-app-ns db --no-tty -- pg_dump -U postgres shop > shop.sql`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			command := args[2:]
+			if len(command) == 0 {
+				command = DefaultCommand
+			}
+			req := apischema.ExecRequest{Pod: args[1], Container: container, Command: command}
+			code, err := run(cmd, client, args[0], req, !noTTY)
+			if err != nil {
+				return err
+			}
+			if code != 0 {
+				os.Exit(code)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&container, "container", "c", "", "Sidecar of the pod to run the command in")
+	cmd.Flags().BoolVarP(&noTTY, "no-tty", "T", false, "Do not allocate a terminal, even when run from one")
+
+	return cmd
+}
+
+// run runs the command until it exits and returns its exit code. The local
+// terminal is in raw mode meanwhile, so that keys such as Ctrl-C reach the
+// command instead of stopping nexlayer.
+func run(cmd *cobra.Command, client api.APIClient, namespace string, req apischema.ExecRequest, tty bool) (int, error) {
+	in, out := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	req.TTY = tty && term.IsTerminal(in) && term.IsTerminal(out)
+	if req.TTY {
+		req.Width, req.Height, _ = term.GetSize(out)
+	}
+
+	session, err := client.Exec(cmd.Context(), namespace, req)
+	if err != nil {
+		return 0, err
+	}
+	defer session.Close()
+
+	if req.TTY {
+		state, err := term.MakeRaw(in)
+		if err != nil {
+			return 0, fmt.Errorf("failed to set up the terminal: %w", err)
+		}
+		defer term.Restore(in, state)
+		stop := watchResize(session, out)
+		defer stop()
+	}
+
+	go func() {
+		if _, err := io.Copy(session, cmd.InOrStdin()); err == nil {
+			session.CloseStdin()
+		}
+	}()
+	return session.Wait(os.Stdout, os.Stderr)
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package execcmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"golang.org/x/term"
+)
+
+// watchResize passes the size of the terminal on to the session whenever it
+// changes, until stop is called
+func watchResize(session *api.ExecSession, fd int) (stop func()) {
+	changed := make(chan os.Signal, 1)
+	signal.Notify(changed, syscall.SIGWINCH)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-changed:
+				if width, height, err := term.GetSize(fd); err == nil {
+					session.Resize(width, height)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(changed)
+		close(done)
+	}
+}
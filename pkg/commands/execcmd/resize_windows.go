@@ -0,0 +1,15 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+
+package execcmd
+
+import "github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+
+// watchResize does nothing: Windows consoles do not signal size changes, so
+// the command keeps the size the terminal had when it started
+func watchResize(session *api.ExecSession, fd int) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package feedback
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/history"
+	coreschema "github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/validate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+	"gopkg.in/yaml.v3"
+)
+
+// collectDiagnostics gathers the outcome of the last deployment and the
+// validation errors of the configuration file. Whatever cannot be collected
+// is left out rather than failing the feedback.
+func collectDiagnostics(ctx context.Context, client api.APIClient, file string) *schema.FeedbackDiagnostics {
+	diag := &schema.FeedbackDiagnostics{}
+
+	var app string
+	if data, err := os.ReadFile(file); err == nil {
+		var config coreschema.NexlayerYAML
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			diag.ValidationErrors = []string{anonymize(err.Error())}
+		} else {
+			app = config.Application.Name
+			validator := validate.NewValidator(&config).WithBaseDir(filepath.Dir(file))
+			validator.Validate()
+			for _, e := range validator.Errors() {
+				diag.ValidationErrors = append(diag.ValidationErrors, anonymize(e.Error()))
+			}
+		}
+	}
+
+	if last, err := deployment.LoadLast(); err == nil && last != nil {
+		diag.LastDeployment = lastDeployment(ctx, client, app, last)
+	}
+	return diag
+}
+
+// lastDeployment reports how the last deployment went, from the local
+// history and the platform
+func lastDeployment(ctx context.Context, client api.APIClient, app string, last *deployment.Last) *schema.DeploymentDiagnostics {
+	d := &schema.DeploymentDiagnostics{Namespace: last.Namespace, StartedAt: last.StartedAt}
+	if app != "" {
+		if revisions, err := history.List(app); err == nil {
+			for _, r := range revisions {
+				if r.Namespace == last.Namespace {
+					d.Revision = r.ID
+					d.Recorded = r.Status
+					break
+				}
+			}
+		}
+	}
+
+	resp, err := client.GetDeploymentInfo(ctx, last.Namespace)
+	if err != nil {
+		d.Error = anonymize(err.Error())
+		return d
+	}
+	d.Status = resp.Data.Status
+	for _, pod := range resp.Data.PodStatuses {
+		d.Pods = append(d.Pods, schema.PodDiagnostics{
+			Name:     pod.Name,
+			Status:   pod.Status,
+			Ready:    pod.Ready,
+			Restarts: pod.Restarts,
+			Waiting:  pod.Waiting,
+			LastExit: pod.LastExit,
+		})
+	}
+	return d
+}
+
+// anonymize replaces the home and working directories in a message, which
+// would give away the user and project names
+func anonymize(s string) string {
+	if wd, err := os.Getwd(); err == nil && len(wd) > 1 {
+		s = strings.ReplaceAll(s, wd, ".")
+	}
+	if home, err := system.HomeDir(); err == nil && len(home) > 1 {
+		s = strings.ReplaceAll(s, home, "~")
+	}
+	return s
+}
@@ -6,26 +6,63 @@ package feedback
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"runtime"
 
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	coreschema "github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/version"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
+// options holds the flags shared by feedback and feedback send
+type options struct {
+	message      string
+	category     string
+	attachConfig bool
+	file         string
+	noContext    bool
+	diagnostics  bool
+	dryRun       bool
+}
+
 // NewFeedbackCommand creates a new feedback command
 func NewFeedbackCommand(client api.ClientAPI) *cobra.Command {
+	opts := &options{}
+
 	cmd := &cobra.Command{
 		Use:   "feedback",
 		Short: "Send feedback about your Nexlayer experience",
 		Long: `Send feedback about your experience with the Nexlayer platform.
 Your feedback helps us improve the platform and build better features.
 
+The CLI version, operating system and the last deployment started from this
+directory are attached unless --no-context is given. With --attach-config the
+nexlayer.yaml is attached too, with credentials, secrets and sensitive vars
+redacted.
+
+With --diagnostics an anonymized diagnostic bundle is attached to help triage
+deployments that fail silently: the outcome of the last deployment and the
+state of its pods, the validation errors of the configuration file and the
+redacted configuration. The images, vars and URL of the deployment and local
+paths are left out; use --dry-run to see exactly what would be sent.
+
 Examples:
-  • Report bugs or issues
-  • Suggest new features
-  • Share your success stories
-  • Request improvements`,
+  nexlayer feedback --category bug --message "Deploy hangs at pending" --attach-config
+  nexlayer feedback --category bug --message "Pods never become ready" --diagnostics --dry-run
+  nexlayer feedback --category feature --message "Support for cron pods"
+  nexlayer feedback --category docs --message "The volumes page is outdated"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFeedback(cmd, cmd.Context(), client, opts)
+		},
 	}
+	addFlags(cmd, opts)
 
 	sendCmd := &cobra.Command{
 		Use:   "send",
@@ -40,26 +77,111 @@ Examples:
   nexlayer feedback send --message "Would like to see support for custom domains"`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			msg, _ := cmd.Flags().GetString("message")
-			return runFeedback(cmd, cmd.Context(), client, msg)
+			return runFeedback(cmd, cmd.Context(), client, opts)
 		},
 	}
-
-	sendCmd.Flags().String("message", "", "Your feedback message (required)")
-	sendCmd.MarkFlagRequired("message")
+	addFlags(sendCmd, opts)
 
 	cmd.AddCommand(sendCmd)
 	return cmd
 }
 
-func runFeedback(cmd *cobra.Command, ctx context.Context, client api.APIClient, text string) error {
-	fmt.Fprintln(cmd.OutOrStdout(), "📝 Sending feedback to Nexlayer team...")
+// addFlags registers the feedback flags on a command
+func addFlags(cmd *cobra.Command, opts *options) {
+	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "Your feedback message (required)")
+	cmd.Flags().StringVarP(&opts.category, "category", "c", schema.FeedbackCategoryOther, "Category: bug, feature, docs or other")
+	cmd.Flags().BoolVar(&opts.attachConfig, "attach-config", false, "Attach the redacted configuration file")
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "nexlayer.yaml", "Configuration file to attach")
+	cmd.Flags().BoolVar(&opts.noContext, "no-context", false, "Do not attach CLI version, OS or deployment ID")
+	cmd.Flags().BoolVar(&opts.diagnostics, "diagnostics", false, "Attach an anonymized diagnostic bundle of the last deployment and configuration")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the feedback that would be sent without sending it")
+	cmd.MarkFlagRequired("message")
+}
+
+func runFeedback(cmd *cobra.Command, ctx context.Context, client api.APIClient, opts *options) error {
+	feedback, err := buildFeedback(opts)
+	if err != nil {
+		return err
+	}
+	if opts.diagnostics {
+		feedback.Diagnostics = collectDiagnostics(ctx, client, opts.file)
+	}
+
+	out := cmd.OutOrStdout()
+	if opts.dryRun {
+		data, err := json.MarshalIndent(feedback, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode feedback: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	fmt.Fprintln(out, "📝 Sending feedback to Nexlayer team...")
+	if feedback.DeploymentID != "" {
+		fmt.Fprintf(out, "• Attaching deployment %s\n", feedback.DeploymentID)
+	}
+	if feedback.Config != "" {
+		fmt.Fprintf(out, "• Attaching %s (redacted)\n", opts.file)
+	}
+	if feedback.Diagnostics != nil {
+		fmt.Fprintln(out, "• Attaching diagnostics")
+	}
 
-	if err := client.SendFeedback(ctx, text); err != nil {
+	if err := client.SendFeedback(ctx, feedback); err != nil {
 		return fmt.Errorf("failed to send feedback: %w", err)
 	}
 
-	fmt.Fprintln(cmd.OutOrStdout(), "\n✨ Thank you for your feedback!")
-	fmt.Fprintln(cmd.OutOrStdout(), "Your input helps us improve the Nexlayer platform.")
+	fmt.Fprintln(out, "\n✨ Thank you for your feedback!")
+	fmt.Fprintln(out, "Your input helps us improve the Nexlayer platform.")
 	return nil
 }
+
+// buildFeedback assembles the request from the flags and the local context
+func buildFeedback(opts *options) (schema.Feedback, error) {
+	feedback := schema.Feedback{Text: opts.message, Category: opts.category}
+	switch opts.category {
+	case schema.FeedbackCategoryBug, schema.FeedbackCategoryFeature, schema.FeedbackCategoryDocs, schema.FeedbackCategoryOther:
+	default:
+		return feedback, fmt.Errorf("invalid category %q: must be bug, feature, docs or other", opts.category)
+	}
+	if opts.diagnostics && opts.noContext {
+		return feedback, fmt.Errorf("--diagnostics cannot be used with --no-context")
+	}
+
+	if !opts.noContext {
+		feedback.CLIVersion = version.GetVersion()
+		feedback.OS = runtime.GOOS + "/" + runtime.GOARCH
+		if last, err := deployment.LoadLast(); err == nil && last != nil {
+			feedback.DeploymentID = last.Namespace
+		}
+	}
+
+	if opts.attachConfig || opts.diagnostics {
+		config, err := redactedConfig(opts.file)
+		// The diagnostics carry the file's errors when it cannot be read
+		if err != nil && opts.attachConfig {
+			return feedback, err
+		}
+		feedback.Config = config
+	}
+	return feedback, nil
+}
+
+// redactedConfig reads a configuration file and strips its secrets
+func redactedConfig(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var config coreschema.NexlayerYAML
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	coreschema.Redact(&config)
+	redacted, err := yaml.Marshal(&config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s: %w", file, err)
+	}
+	return string(redacted), nil
+}
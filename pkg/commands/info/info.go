@@ -5,13 +5,14 @@
 package info
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
 	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -47,6 +48,7 @@ func NewInfoCommand(client api.APIClient) *cobra.Command {
 The command shows:
   • Deployment status and health
   • Pod statuses and readiness
+  • Restarts, exit codes and out-of-memory kills, with hints
   • Resource usage and limits
   • Environment variables
   • Volume mounts
@@ -60,7 +62,8 @@ Examples:
   nexlayer info my-namespace
   nexlayer info my-namespace my-app
   nexlayer info production api-backend --verbose`,
-		Args: cobra.MinimumNArgs(1),
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			namespace := args[0]
 
@@ -100,8 +103,8 @@ Examples:
 			}
 
 			// Check JSON output flag
-			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
-				return json.NewEncoder(os.Stdout).Encode(resp)
+			if ui.Structured() {
+				return ui.WriteOutput(resp)
 			}
 
 			// Print deployment overview
@@ -118,19 +121,33 @@ Examples:
 			if len(resp.Data.PodStatuses) > 0 {
 				fmt.Fprintf(cmd.OutOrStdout(), "\n%s\n", sectionStyle.Render("Pod Status"))
 				table := ui.NewTable()
-				table.AddHeader("NAME", "STATUS", "READY", "RESTARTS", "AGE")
+				table.AddHeader("NAME", "STATUS", "READY", "RESTARTS", "LAST EXIT", "AGE")
 				for _, pod := range resp.Data.PodStatuses {
+					status := pod.Status
+					if pod.Waiting != "" {
+						status = pod.Waiting
+					}
 					table.AddRow(
 						pod.Name,
-						formatStatus(pod.Status),
+						formatStatus(status),
 						formatBool(pod.Ready),
 						fmt.Sprintf("%d", pod.Restarts),
+						formatExit(pod.LastExit),
 						formatAge(pod.CreatedAt),
 					)
 				}
 				table.Render()
 			}
 
+			// Point out crash loops, OOM kills and frequent restarts
+			if insights := deployment.Diagnose(resp.Data, time.Now()); len(insights) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "\n%s\n", sectionStyle.Render("Insights"))
+				for _, in := range insights {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", ui.Symbols().Warning, in.Message)
+					fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", in.Hint)
+				}
+			}
+
 			// Check verbose flag for additional details
 			verbose, _ := cmd.Flags().GetBool("verbose")
 			if verbose {
@@ -150,6 +167,9 @@ Examples:
 						fmt.Fprintf(cmd.OutOrStdout(), "  Status:    %s\n", formatStatus(pod.Status))
 						fmt.Fprintf(cmd.OutOrStdout(), "  Ready:     %s\n", formatBool(pod.Ready))
 						fmt.Fprintf(cmd.OutOrStdout(), "  Restarts:  %d\n", pod.Restarts)
+						if pod.LastExit != nil {
+							fmt.Fprintf(cmd.OutOrStdout(), "  Last Exit: %s at %s\n", formatExit(pod.LastExit), formatTime(pod.LastExit.FinishedAt))
+						}
 						fmt.Fprintf(cmd.OutOrStdout(), "  Image:     %s\n", pod.Image)
 						fmt.Fprintf(cmd.OutOrStdout(), "  Created:   %s\n", formatTime(pod.CreatedAt))
 						fmt.Fprintf(cmd.OutOrStdout(), "\n")
@@ -207,6 +227,18 @@ func formatBool(b bool) string {
 	return failedStyle.Render("No")
 }
 
+// formatExit returns the exit code and reason of a container's last run
+func formatExit(t *apischema.Termination) string {
+	switch {
+	case t == nil:
+		return "-"
+	case t.Reason != "":
+		return fmt.Sprintf("%d (%s)", t.ExitCode, t.Reason)
+	default:
+		return fmt.Sprint(t.ExitCode)
+	}
+}
+
 // formatTime formats a time.Time into a human-readable string
 func formatTime(t time.Time) string {
 	if t.IsZero() {
@@ -221,15 +253,5 @@ func formatAge(t time.Time) string {
 		return "N/A"
 	}
 
-	d := time.Since(t)
-	switch {
-	case d < time.Minute:
-		return fmt.Sprintf("%ds", int(d.Seconds()))
-	case d < time.Hour:
-		return fmt.Sprintf("%dm", int(d.Minutes()))
-	case d < 24*time.Hour:
-		return fmt.Sprintf("%dh", int(d.Hours()))
-	default:
-		return fmt.Sprintf("%dd", int(d.Hours()/24))
-	}
+	return deployment.FormatSince(time.Since(t))
 }
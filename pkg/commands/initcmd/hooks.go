@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package initcmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/hooks"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plugin"
+)
+
+// runHooks runs the hooks of event for the nexlayer.yaml of dir. Pre-init
+// runs the hooks block of the file init replaces, if any and readable;
+// post-init is skipped when no file was written, e.g. when the wizard was
+// cancelled.
+func runHooks(ctx context.Context, event, dir string) error {
+	ev := hooks.Event{
+		Name:        event,
+		File:        filepath.Join(dir, "nexlayer.yaml"),
+		Environment: plugin.Environment{APIURL: config.GetAPIURL(), Token: config.GetToken()},
+	}
+	if _, err := os.Stat(ev.File); err != nil {
+		if event != plugin.HookPreInit {
+			return nil
+		}
+	} else if ev.Config, _, err = deployment.Load(ev.File); err != nil && event != plugin.HookPreInit {
+		return err
+	}
+	return hooks.Run(ctx, ev, os.Stdout)
+}
+
+// stripHooks removes application.hooks from the nexlayer.yaml content of a
+// template and reports whether there was one; the rest of the document,
+// comments included, is kept
+func stripHooks(content []byte) ([]byte, bool, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, false, fmt.Errorf("failed to parse the template: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return content, false, nil
+	}
+	app := mappingValue(doc.Content[0], "application")
+	if app == nil || app.Kind != yaml.MappingNode {
+		return content, false, nil
+	}
+	for i := 0; i+1 < len(app.Content); i += 2 {
+		if app.Content[i].Value != "hooks" {
+			continue
+		}
+		app.Content = append(app.Content[:i], app.Content[i+2:]...)
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return nil, false, err
+		}
+		return buf.Bytes(), true, nil
+	}
+	return content, false, nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
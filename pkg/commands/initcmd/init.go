@@ -6,29 +6,37 @@ package initcmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 	"gopkg.in/yaml.v3"
 
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/aicmd"
+	templatecmd "github.com/Nexlayer/nexlayer-cli/pkg/commands/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/ai"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/cache"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/compose"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/heroku"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/k8s"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/types"
 	"github.com/Nexlayer/nexlayer-cli/pkg/detection"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui/components"
 )
 
 const (
-	cacheDir         = ".nexlayer"
-	cacheFile        = "detection-cache.json"
+	detectionTTL     = 24 * time.Hour // detected project types are reused for a day
+	maxHashedFile    = 256 << 10      // larger files are keyed by size and time
 	podRefPattern    = `([a-z][a-z0-9-]*).pod`
 	urlRefPattern    = `<% URL %>`
 	envVarRefPattern = `<%\s*([A-Z_][A-Z0-9_]*)\s*%>`
@@ -47,12 +55,6 @@ var (
 			Foreground(lipgloss.Color("#ffff00"))
 )
 
-// detectionCache represents cached project detection results
-type detectionCache struct {
-	ProjectInfo *types.ProjectInfo `json:"project_info"`
-	Timestamp   time.Time          `json:"timestamp"`
-}
-
 // NewCommand creates a new init command
 func NewCommand() *cobra.Command {
 	var (
@@ -63,6 +65,13 @@ func NewCommand() *cobra.Command {
 		podImage    string
 		podPort     int
 		podPath     string
+		template    string
+		registry    string
+		setValues   []string
+		allowHooks  bool
+		fromK8s     string
+		fromHelm    string
+		valuesFiles []string
 	)
 
 	cmd := &cobra.Command{
@@ -78,12 +87,38 @@ Examples:
   # Initialize with a custom name
   nexlayer init --name my-app
 
-  # Interactive mode
+  # Review the detected stack in a full-screen wizard: add or remove pods,
+  # edit ports, toggle databases and preview nexlayer.yaml before writing it
   nexlayer init --interactive
 
   # Force re-detection (ignore cache)
   nexlayer init --force
 
+  # Start from a built-in template ('nexlayer templates list' shows them)
+  nexlayer init --template pern --name shop --set dbPasswordVar=SHOP_DB_PASSWORD
+
+  # Start from a template in your organization's catalog
+  nexlayer init --template acme/payment-service
+
+  # Keep the hooks block of a template you trust; without --allow-hooks it
+  # is removed, so its commands do not run on init or deploy
+  nexlayer init --template acme/payment-service --allow-hooks
+
+  # Convert the Deployments, Services, ConfigMaps, Secrets and claims of
+  # Kubernetes manifests, a file or a directory
+  nexlayer init --from-k8s ./manifests
+
+  # Render a Helm chart, a directory or repo/chart, with helm template and
+  # convert its manifests
+  nexlayer init --from-helm bitnami/wordpress --values prod-values.yaml --set replicaCount=1
+
+--values and --set give the values of the template parameters with
+--template, and of the chart with --from-helm, which needs helm installed.
+
+Heroku projects (a Procfile, optionally with app.json) are converted: process
+types become pods, the release process becomes the migrations, config vars
+become vars and add-ons such as heroku-postgresql become services.
+
 Required Fields in nexlayer.yaml:
   - application.name: The name of the application
   - pods[].name: The pod name (e.g., "web" or "api")
@@ -96,7 +131,7 @@ Optional Fields (included when needed):
   - vars: For environment variables (AI, database configs)
   - registryLogin: For private images (registry, username, password)`,
 		Args: cobra.MaximumNArgs(1),
-		RunE: func(_ *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) error {
 			// Get target directory
 			dir := "."
 			if len(args) > 0 {
@@ -115,18 +150,59 @@ Optional Fields (included when needed):
 				PodPath:     podPath,
 			}
 
-			return runInitCommand(opts)
+			if fromHelm != "" {
+				// Fail before the hooks run when the chart cannot be rendered
+				if _, err := k8s.LookHelm(); err != nil {
+					return err
+				}
+			}
+			if err := runHooks(cmd.Context(), schema.HookPreInit, dir); err != nil {
+				return fmt.Errorf("init aborted: %w", err)
+			}
+			var err error
+			switch {
+			case template != "":
+				if len(valuesFiles) > 1 {
+					return fmt.Errorf("--template takes a single --values file")
+				}
+				valuesFile := ""
+				if len(valuesFiles) == 1 {
+					valuesFile = valuesFiles[0]
+				}
+				err = runTemplateInit(cmd.Context(), dir, template, registry, appName, valuesFile, setValues, allowHooks)
+			case fromK8s != "":
+				err = runK8sInit(opts, fromK8s)
+			case fromHelm != "":
+				err = runHelmInit(cmd.Context(), opts, fromHelm, valuesFiles, setValues)
+			default:
+				err = runInitCommand(opts)
+			}
+			if err != nil {
+				return err
+			}
+			if err := runHooks(cmd.Context(), schema.HookPostInit, dir); err != nil {
+				ui.RenderWarning(err.Error())
+			}
+			return nil
 		},
 	}
 
 	// Add flags
-	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Enable interactive mode")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Review and edit the configuration in a full-screen wizard")
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Force re-detection (ignore cache)")
 	cmd.Flags().StringVar(&appName, "name", "", "Application name (default: directory name)")
 	cmd.Flags().StringVar(&podName, "pod-name", "", "Main pod name (default: based on project type)")
 	cmd.Flags().StringVar(&podImage, "pod-image", "", "Main pod image (default: based on project type)")
 	cmd.Flags().IntVar(&podPort, "pod-port", 0, "Main pod port (default: based on project type)")
 	cmd.Flags().StringVar(&podPath, "pod-path", "", "Main pod path (default: / for web/api pods)")
+	cmd.Flags().StringVar(&template, "template", "", "Create nexlayer.yaml from a template (name[@version] or org/name)")
+	cmd.Flags().StringVar(&registry, "registry", "", "Template registry directory or URL (with --template)")
+	cmd.Flags().StringArrayVar(&setValues, "set", nil, "Set a template parameter or chart value (key=value, repeatable, with --template or --from-helm)")
+	cmd.Flags().BoolVar(&allowHooks, "allow-hooks", false, "Keep and run the hooks block of the template (with --template)")
+	cmd.Flags().StringVar(&fromK8s, "from-k8s", "", "Convert Kubernetes manifests (a file or directory) instead of detecting the project")
+	cmd.Flags().StringVar(&fromHelm, "from-helm", "", "Render a Helm chart (directory or repo/chart) with helm template and convert it")
+	cmd.Flags().StringSliceVar(&valuesFiles, "values", nil, "Values file of the template parameters or of the chart (with --template or --from-helm)")
+	cmd.MarkFlagsMutuallyExclusive("template", "from-k8s", "from-helm")
 
 	return cmd
 }
@@ -143,11 +219,114 @@ type InitOptions struct {
 	PodPath     string
 }
 
+// runTemplateInit writes nexlayer.yaml from a registry or built-in template
+// instead of detecting the project. The application name defaults to the
+// directory name. The hooks block of the template is removed unless
+// allowHooks is set, since post-init would otherwise run its commands right
+// away.
+func runTemplateInit(ctx context.Context, dir, ref, registry, appName, valuesFile string, setValues []string, allowHooks bool) error {
+	name, version, err := tmpl.ParseRef(ref)
+	if err != nil {
+		return err
+	}
+	reg, err := tmpl.OpenRegistryFor(registry, name)
+	if err != nil {
+		return err
+	}
+	t, err := reg.Pull(ctx, name, version)
+	if err != nil {
+		return fmt.Errorf("failed to pull template: %w", err)
+	}
+
+	if appName == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", dir, err)
+		}
+		appName = filepath.Base(abs)
+	}
+	var notes io.Writer = os.Stdout
+	if ui.Structured() {
+		notes = io.Discard
+	}
+	resolved, err := templatecmd.Resolve(ctx, reg, t, notes)
+	if err != nil {
+		return err
+	}
+	setValues = append([]string{"appName=" + appName}, setValues...)
+	if t.Metadata.Version == tmpl.BuiltinVersion {
+		if setValues, err = builtinParams(t.Metadata.Name, setValues); err != nil {
+			return err
+		}
+	}
+	content, err := templatecmd.Instantiate(resolved, valuesFile, setValues)
+	if err != nil {
+		return err
+	}
+	if !allowHooks {
+		var stripped bool
+		if content, stripped, err = stripHooks(content); err != nil {
+			return err
+		}
+		if stripped && !ui.Structured() {
+			fmt.Println(warningStyle.Render(fmt.Sprintf("⚠️  Removed the hooks of %s; pass --allow-hooks to keep and run them", t.Metadata.Name)))
+		}
+	}
+
+	path := filepath.Join(dir, "nexlayer.yaml")
+	if err := writeConfigFile(path, content); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	if !ui.Structured() {
+		fmt.Println(successStyle.Render(fmt.Sprintf("%s Created %s from %s@%s", ui.Symbols().Success, path, t.Metadata.Name, t.Metadata.Version)))
+	}
+	result := Result{File: path, Application: appName, Template: t.Metadata.Name + "@" + t.Metadata.Version}
+	var config schema.NexlayerYAML
+	if err := yaml.Unmarshal(content, &config); err == nil {
+		for _, pod := range config.Application.Pods {
+			result.Pods = append(result.Pods, pod.Name)
+		}
+	}
+	return writeResult(result)
+}
+
+// builtinParams completes the values of a built-in template's parameters:
+// each one not set is prompted for on a terminal, with its default filled in,
+// and takes its default otherwise
+func builtinParams(name string, setValues []string) ([]string, error) {
+	builtin, _ := tmpl.FindBuiltin(name)
+	values, err := tmpl.ParseSetFlags(nil, setValues)
+	if err != nil {
+		return nil, err
+	}
+	prompt := !ui.Structured() && term.IsTerminal(int(os.Stdin.Fd()))
+	for _, p := range builtin.Params {
+		if _, ok := values[p.Name]; ok {
+			continue
+		}
+		value := p.Default
+		if prompt {
+			if value, err = components.NewPrompt(p.Description).WithDefault(p.Default).Run(); err != nil {
+				return nil, fmt.Errorf("missing value for template parameter %s: %w", p.Name, err)
+			}
+		}
+		setValues = append(setValues, p.Name+"="+value)
+	}
+	return setValues, nil
+}
+
 // runInitCommand handles the execution of the init command
 func runInitCommand(opts *InitOptions) error {
 	// Show welcome message
 	fmt.Println(infoStyle.Render("🚀 Initializing Nexlayer project..."))
 
+	// A monorepo gets a pod for each of its projects
+	if ws, err := detection.DetectWorkspace(opts.Directory); err != nil {
+		fmt.Println(warningStyle.Render(fmt.Sprintf("⚠️  Could not read the workspace definitions: %v", err)))
+	} else if ws != nil && len(ws.Projects) > 1 {
+		return runWorkspaceInit(ws, opts)
+	}
+
 	// Try to load from cache first
 	var info *types.ProjectInfo
 	if !opts.Force {
@@ -158,23 +337,37 @@ func runInitCommand(opts *InitOptions) error {
 	if info == nil {
 		var err error
 		info, err = detectProjectParallel(opts.Directory)
-		if err != nil && opts.Interactive {
-			// If detection fails in interactive mode, prompt user
-			info, err = promptForProjectType(opts.Directory)
-		}
-		if err != nil {
+		switch {
+		case err == nil:
+			// Save to cache
+			if err := saveToCache(opts.Directory, info); err != nil {
+				if !ui.Structured() {
+					fmt.Println(warningStyle.Render("⚠️  Warning: Failed to cache detection results"))
+				}
+			}
+		case opts.Interactive:
+			// The wizard lets the user pick the stack
+			abs, _ := filepath.Abs(opts.Directory)
+			info = &types.ProjectInfo{Type: types.TypeUnknown, Name: filepath.Base(abs), Port: 8080}
+		default:
 			return fmt.Errorf("failed to detect project type: %w", err)
 		}
-
-		// Save to cache
-		if err := saveToCache(opts.Directory, info); err != nil {
-			fmt.Println(warningStyle.Render("⚠️  Warning: Failed to cache detection results"))
-		}
 	}
 
+	// Read what the project uses, to add its databases, caches and workers
+	detection.AddDependencies(info, opts.Directory)
+
 	// Apply user overrides
-	if err := applyUserOverrides(info, opts); err != nil {
-		return fmt.Errorf("failed to apply overrides: %w", err)
+	applyUserOverrides(info, opts)
+
+	// Let the user review and change the configuration before writing it
+	if opts.Interactive {
+		if !ui.Structured() && term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd())) {
+			return runInteractiveInit(info, opts)
+		}
+		if !ui.Structured() {
+			fmt.Println(warningStyle.Render("⚠️  --interactive needs a terminal; writing the detected configuration"))
+		}
 	}
 
 	// Generate configuration
@@ -182,41 +375,150 @@ func runInitCommand(opts *InitOptions) error {
 	if err != nil {
 		return fmt.Errorf("failed to generate configuration: %w", err)
 	}
+	offerDockerfile(info, opts, config)
+	return saveConfiguration(opts, config, string(info.Type))
+}
+
+// offerDockerfile offers to write the Dockerfile of a Node.js, Python or Go
+// project that has none, and points the main pod at the image built from it
+func offerDockerfile(info *types.ProjectInfo, opts *InitOptions, config *schema.NexlayerYAML) {
+	if opts.PodImage != "" || info.HasDocker || !needsDockerfile(info.Type) || len(config.Application.Pods) == 0 || ui.Structured() {
+		return
+	}
+	if _, err := os.Stat(filepath.Join(opts.Directory, schema.DefaultDockerfile)); err == nil {
+		return
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		command := "nexlayer ai dockerfile"
+		if opts.Directory != "." {
+			command += " " + opts.Directory
+		}
+		fmt.Println(infoStyle.Render(fmt.Sprintf("💡 No Dockerfile found; '%s' writes one for the project", command)))
+		return
+	}
+	ok, err := components.NewConfirm("No Dockerfile found. Write one for the project?").WithDefault(true).Run()
+	if err != nil || !ok {
+		return
+	}
+
+	pod := &config.Application.Pods[0]
+	port := 0
+	if len(pod.ServicePorts) > 0 {
+		port = pod.ServicePorts[0].TargetPort
+	}
+	project, err := ai.DescribeProject(opts.Directory, port)
+	if err != nil {
+		fmt.Println(warningStyle.Render(fmt.Sprintf("⚠️  Could not write a Dockerfile: %v", err)))
+		return
+	}
+	tag := aicmd.DockerfileTag(opts.Directory, config, pod)
+	if _, err := aicmd.WriteDockerfile(context.Background(), os.Stdout, project, aicmd.DockerfileOptions{Tag: tag}); err != nil {
+		fmt.Println(warningStyle.Render(fmt.Sprintf("⚠️  Could not write a Dockerfile: %v", err)))
+		return
+	}
+	pod.Image = schema.RegistryPlaceholder + "/" + tag
+	pod.Build = &schema.ImageBuild{Context: "."}
+	fmt.Println(warningStyle.Render("⚠️  Set application.registryLogin so 'nexlayer deploy --watch-files' can push the image built from it"))
+}
+
+// saveConfiguration validates config and writes it to nexlayer.yaml
+func saveConfiguration(opts *InitOptions, config *schema.NexlayerYAML, projectType string) error {
+	path := filepath.Join(opts.Directory, "nexlayer.yaml")
+	result := Result{File: path, Application: config.Application.Name, Type: projectType}
+	for _, pod := range config.Application.Pods {
+		result.Pods = append(result.Pods, pod.Name)
+	}
 
 	// Validate configuration
-	if err := validateConfiguration(config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+	if errs := schema.Validate(config); len(errs) > 0 {
+		result.ValidationErrors = errs
+		if err := writeResult(result); err != nil {
+			return err
+		}
+		return fmt.Errorf("configuration validation failed: %v", errs)
 	}
 
 	// Write configuration
-	if err := writeYAMLToFile(filepath.Join(opts.Directory, "nexlayer.yaml"), config); err != nil {
+	if err := writeYAMLToFile(path, config); err != nil {
 		return fmt.Errorf("failed to write configuration: %w", err)
 	}
 
-	// Print success message
-	printSuccessMessage(info, config)
-
+	if ui.Structured() {
+		return writeResult(result)
+	}
+	printSuccessMessage(projectType, config)
 	return nil
 }
 
+// Result is what init writes with --output json or yaml
+type Result struct {
+	File             string                   `json:"file"`
+	Application      string                   `json:"application"`
+	Type             string                   `json:"type,omitempty"`
+	Template         string                   `json:"template,omitempty"`
+	Pods             []string                 `json:"pods"`
+	ValidationErrors []schema.ValidationError `json:"validationErrors,omitempty"`
+}
+
+// writeResult writes the result of init when structured output is selected
+func writeResult(result Result) error {
+	if !ui.Structured() {
+		return nil
+	}
+	if result.Pods == nil {
+		result.Pods = []string{}
+	}
+	return ui.WriteOutput(result)
+}
+
 // applyUserOverrides applies user-provided overrides to the project info
-func applyUserOverrides(info *types.ProjectInfo, opts *InitOptions) error {
+func applyUserOverrides(info *types.ProjectInfo, opts *InitOptions) {
 	if opts.AppName != "" {
 		info.Name = opts.AppName
 	}
+}
 
-	// If in interactive mode, prompt for confirmation/changes
-	if opts.Interactive {
-		if err := promptForOverrides(info); err != nil {
-			return err
-		}
+// runInteractiveInit opens the wizard on the detected configuration and
+// writes what the user settles on
+func runInteractiveInit(info *types.ProjectInfo, opts *InitOptions) error {
+	draft, err := draftConfiguration(info, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate configuration: %w", err)
 	}
-
-	return nil
+	config, err := runWizard(info, filepath.Join(opts.Directory, "nexlayer.yaml"), draft)
+	if err != nil {
+		return fmt.Errorf("init wizard failed: %w", err)
+	}
+	if config == nil {
+		fmt.Println(warningStyle.Render("Cancelled; nexlayer.yaml was not written"))
+		return nil
+	}
+	return saveConfiguration(opts, config, string(info.Type))
 }
 
 // generateConfiguration creates a minimal but complete nexlayer.yaml configuration
 func generateConfiguration(info *types.ProjectInfo, opts *InitOptions) (*schema.NexlayerYAML, error) {
+	config, err := draftConfiguration(info, opts)
+	if err != nil {
+		return nil, err
+	}
+	// Write the pods of the services out, so the file shows everything that
+	// runs. Heroku add-ons stay services, as heroku.Convert writes them.
+	if info.Type != types.TypeHeroku {
+		if _, err := schema.ExpandServices(config); err != nil {
+			return nil, err
+		}
+	}
+	// Size the pods by type so that the file shows what they get
+	for i := range config.Application.Pods {
+		config.Application.Pods[i].SetDefaultResources()
+	}
+	return config, nil
+}
+
+// draftConfiguration creates the configuration of the project with its
+// databases and caches still in application.services
+func draftConfiguration(info *types.ProjectInfo, opts *InitOptions) (*schema.NexlayerYAML, error) {
 	// Check for Docker Compose first
 	if info.Type == types.TypeDockerRaw && info.HasDocker {
 		fmt.Println(infoStyle.Render("🔍 Detected Docker project, checking for Docker Compose..."))
@@ -249,6 +551,11 @@ func generateConfiguration(info *types.ProjectInfo, opts *InitOptions) (*schema.
 		}
 	}
 
+	// Convert Heroku process types, config vars and add-ons
+	if info.Type == types.TypeHeroku {
+		return convertHeroku(opts)
+	}
+
 	// Create base configuration
 	config := &schema.NexlayerYAML{
 		Application: schema.Application{
@@ -261,10 +568,11 @@ func generateConfiguration(info *types.ProjectInfo, opts *InitOptions) (*schema.
 	mainPod := generateMainPod(info, opts)
 	config.Application.Pods = append(config.Application.Pods, mainPod)
 
-	// Add database if needed
-	if hasDatabase(info) {
-		dbPod := generateDatabasePod(info)
-		config.Application.Pods = append(config.Application.Pods, dbPod)
+	// Add the backend, worker, databases and caches the dependencies call for
+	services := detectServices(info)
+	addServices(config, info, services)
+	for _, note := range services.notes {
+		fmt.Println(warningStyle.Render("⚠️  " + note))
 	}
 
 	// Add AI configurations if detected
@@ -275,6 +583,19 @@ func generateConfiguration(info *types.ProjectInfo, opts *InitOptions) (*schema.
 	return config, nil
 }
 
+// convertHeroku converts the Procfile and app.json of a Heroku project
+func convertHeroku(opts *InitOptions) (*schema.NexlayerYAML, error) {
+	fmt.Println(infoStyle.Render("🔄 Converting Heroku Procfile and app.json..."))
+	result, err := heroku.Convert(opts.Directory, opts.AppName)
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range result.Notes {
+		fmt.Println(warningStyle.Render("⚠️  " + note))
+	}
+	return result.Config, nil
+}
+
 // generateMainPod creates the main pod configuration based on project type
 func generateMainPod(info *types.ProjectInfo, opts *InitOptions) schema.Pod {
 	pod := schema.Pod{
@@ -340,29 +661,10 @@ func generateEnvironmentVars(info *types.ProjectInfo) []schema.EnvVar {
 		})
 	}
 
-	// Add service URLs based on dependencies
+	// Add service URLs based on dependencies. Databases and caches become
+	// services, which add their URLs themselves.
 	for name := range info.Dependencies {
 		switch {
-		case strings.Contains(name, "postgres"):
-			vars = append(vars, schema.EnvVar{
-				Key:   "DATABASE_URL",
-				Value: "postgresql://postgres:<% DB_PASSWORD %>@postgres.pod:5432/app",
-			})
-		case strings.Contains(name, "mongodb"):
-			vars = append(vars, schema.EnvVar{
-				Key:   "MONGODB_URI",
-				Value: "mongodb://root:<% MONGO_ROOT_PASSWORD %>@mongodb.pod:27017/app",
-			})
-		case strings.Contains(name, "mysql"):
-			vars = append(vars, schema.EnvVar{
-				Key:   "MYSQL_URL",
-				Value: "mysql://root:<% MYSQL_ROOT_PASSWORD %>@mysql.pod:3306/app",
-			})
-		case strings.Contains(name, "redis"):
-			vars = append(vars, schema.EnvVar{
-				Key:   "REDIS_URL",
-				Value: "redis://:<% REDIS_PASSWORD %>@redis.pod:6379",
-			})
 		case strings.Contains(name, "ai-model"):
 			vars = append(vars, schema.EnvVar{
 				Key:   "AI_MODEL_URL",
@@ -394,53 +696,6 @@ func generateEnvironmentVars(info *types.ProjectInfo) []schema.EnvVar {
 	return vars
 }
 
-// generateDatabasePod creates a database pod configuration
-func generateDatabasePod(info *types.ProjectInfo) schema.Pod {
-	dbType := detectDatabaseType(info)
-	dbPort := getDefaultDBPort(dbType)
-	pod := schema.Pod{
-		Name:  fmt.Sprintf("db-%s", dbType),
-		Type:  dbType,
-		Image: fmt.Sprintf("%s:latest", dbType),
-		ServicePorts: []schema.ServicePort{
-			{
-				Name:       "db",
-				Port:       dbPort,
-				TargetPort: dbPort,
-				Protocol:   "TCP",
-			},
-		},
-		Volumes: []schema.Volume{
-			{
-				Name: fmt.Sprintf("%s-data", dbType),
-				Path: getDefaultDBPath(dbType),
-				Size: "5Gi",
-			},
-		},
-	}
-
-	// Add default environment variables
-	pod.Vars = getDefaultDBVars(dbType)
-
-	// Add health check environment variables
-	pod.Vars = append(pod.Vars, schema.EnvVar{
-		Key:   "POD_NAME",
-		Value: fmt.Sprintf("%s.pod", pod.Name),
-	})
-
-	return pod
-}
-
-// validateConfiguration ensures the configuration is valid
-func validateConfiguration(config *schema.NexlayerYAML) error {
-	// Validate using schema validator
-	if errs := schema.Validate(config); len(errs) > 0 {
-		return fmt.Errorf("validation failed: %v", errs)
-	}
-
-	return nil
-}
-
 // Helper functions for default values and validation
 
 func getDefaultImage(projectType types.ProjectType) string {
@@ -460,85 +715,24 @@ func getDefaultImage(projectType types.ProjectType) string {
 	}
 }
 
-func isWebOrAPI(projectType types.ProjectType) bool {
+// needsDockerfile reports whether init offers to write the Dockerfile of a
+// project type
+func needsDockerfile(projectType types.ProjectType) bool {
 	switch projectType {
-	case types.TypeNextjs, types.TypeReact, types.TypeNode, types.TypePython, types.TypeGo:
+	case types.TypeNextjs, types.TypeReact, types.TypeNode, types.TypePython, types.TypeGo,
+		types.TypeLangchainNextjs, types.TypeOpenAINode, types.TypeLlamaPython:
 		return true
 	default:
 		return false
 	}
 }
 
-func detectDatabaseType(info *types.ProjectInfo) string {
-	for name := range info.Dependencies {
-		switch {
-		case strings.Contains(name, "pg"), strings.Contains(name, "postgres"):
-			return "postgres"
-		case strings.Contains(name, "mongodb"), strings.Contains(name, "mongoose"):
-			return "mongodb"
-		case strings.Contains(name, "mysql"):
-			return "mysql"
-		case strings.Contains(name, "redis"):
-			return "redis"
-		}
-	}
-	return "postgres" // Default to PostgreSQL
-}
-
-func getDefaultDBPort(dbType string) int {
-	switch dbType {
-	case "postgres":
-		return 5432
-	case "mongodb":
-		return 27017
-	case "mysql":
-		return 3306
-	case "redis":
-		return 6379
-	default:
-		return 5432
-	}
-}
-
-func getDefaultDBPath(dbType string) string {
-	switch dbType {
-	case "postgres":
-		return "/var/lib/postgresql/data"
-	case "mongodb":
-		return "/data/db"
-	case "mysql":
-		return "/var/lib/mysql"
-	case "redis":
-		return "/data"
-	default:
-		return "/data"
-	}
-}
-
-func getDefaultDBVars(dbType string) []schema.EnvVar {
-	switch dbType {
-	case "postgres":
-		return []schema.EnvVar{
-			{Key: "POSTGRES_USER", Value: "postgres"},
-			{Key: "POSTGRES_PASSWORD", Value: "<% DB_PASSWORD %>"},
-			{Key: "POSTGRES_DB", Value: "app"},
-		}
-	case "mongodb":
-		return []schema.EnvVar{
-			{Key: "MONGO_INITDB_ROOT_USERNAME", Value: "root"},
-			{Key: "MONGO_INITDB_ROOT_PASSWORD", Value: "<% MONGO_ROOT_PASSWORD %>"},
-		}
-	case "mysql":
-		return []schema.EnvVar{
-			{Key: "MYSQL_ROOT_PASSWORD", Value: "<% MYSQL_ROOT_PASSWORD %>"},
-			{Key: "MYSQL_DATABASE", Value: "app"},
-		}
-	case "redis":
-		return []schema.EnvVar{
-			{Key: "REDIS_PASSWORD", Value: "<% REDIS_PASSWORD %>"},
-		}
+func isWebOrAPI(projectType types.ProjectType) bool {
+	switch projectType {
+	case types.TypeNextjs, types.TypeReact, types.TypeNode, types.TypePython, types.TypeGo:
+		return true
 	default:
-		return nil
+		return false
 	}
 }
 
@@ -619,6 +813,7 @@ func selectBestProjectType(results []*types.ProjectInfo, dir string) (*types.Pro
 
 	// Prioritize by project type
 	priorityOrder := []types.ProjectType{
+		types.TypeHeroku,
 		types.TypeDockerRaw,
 		types.TypeNextjs,
 		types.TypeReact,
@@ -641,108 +836,81 @@ func selectBestProjectType(results []*types.ProjectInfo, dir string) (*types.Pro
 	return results[0], nil
 }
 
-// promptForProjectType asks the user to select a project type
-func promptForProjectType(dir string) (*types.ProjectInfo, error) {
-	prompt := promptui.Select{
-		Label: "Select your project type",
-		Items: []string{
-			"Next.js",
-			"React",
-			"Node.js",
-			"Python",
-			"Go",
-			"Docker",
-		},
-	}
-
-	_, result, err := prompt.Run()
-	if err != nil {
-		return nil, fmt.Errorf("prompt failed: %w", err)
-	}
-
-	// Convert selection to ProjectType
-	var projectType types.ProjectType
-	switch result {
-	case "Next.js":
-		projectType = types.TypeNextjs
-	case "React":
-		projectType = types.TypeReact
-	case "Node.js":
-		projectType = types.TypeNode
-	case "Python":
-		projectType = types.TypePython
-	case "Go":
-		projectType = types.TypeGo
-	case "Docker":
-		projectType = types.TypeDockerRaw
-	}
-
-	return &types.ProjectInfo{
-		Type: projectType,
-		Name: filepath.Base(dir),
-	}, nil
-}
-
-// loadFromCache attempts to load project info from cache
+// loadFromCache returns the project info detected earlier in dir, unless
+// its files changed since
 func loadFromCache(dir string) *types.ProjectInfo {
-	cachePath := filepath.Join(dir, cacheDir, cacheFile)
-	data, err := os.ReadFile(cachePath)
+	store, err := cache.Open(cache.KindDetection)
 	if err != nil {
 		return nil
 	}
-
-	var cache detectionCache
-	if err := json.Unmarshal(data, &cache); err != nil {
+	var info types.ProjectInfo
+	if !store.Get(detectionKey(dir), detectionTTL, &info) {
 		return nil
 	}
-
-	// Check if cache is still valid (24 hours)
-	if time.Since(cache.Timestamp) > 24*time.Hour {
-		return nil
-	}
-
-	return cache.ProjectInfo
+	return &info
 }
 
 // saveToCache saves project info to cache
 func saveToCache(dir string, info *types.ProjectInfo) error {
-	cachePath := filepath.Join(dir, cacheDir)
-	if err := os.MkdirAll(cachePath, 0755); err != nil {
+	store, err := cache.Open(cache.KindDetection)
+	if err != nil {
 		return err
 	}
+	return store.Put(detectionKey(dir), info)
+}
 
-	cache := detectionCache{
-		ProjectInfo: info,
-		Timestamp:   time.Now(),
-	}
-
-	data, err := json.Marshal(cache)
+// detectionKey hashes what detection reads of a project: where it is and
+// the files at its top, manifests and entry points
+func detectionKey(dir string) string {
+	abs, err := filepath.Abs(dir)
 	if err != nil {
-		return err
+		abs = dir
+	}
+	parts := []string{abs}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		parts = append(parts, e.Name())
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if info.Size() > maxHashedFile {
+			parts = append(parts, fmt.Sprintf("%d %d", info.Size(), info.ModTime().UnixNano()))
+			continue
+		}
+		if data, err := os.ReadFile(filepath.Join(dir, e.Name())); err == nil {
+			parts = append(parts, string(data))
+		}
 	}
-
-	return os.WriteFile(filepath.Join(cachePath, cacheFile), data, 0644)
+	return cache.Key(parts...)
 }
 
 // writeYAMLToFile writes the template to a YAML file
 func writeYAMLToFile(filename string, tmpl *schema.NexlayerYAML) error {
+	// Marshal configuration to YAML
+	data, err := yaml.Marshal(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to marshal YAML: %w", err)
+	}
+	return writeConfigFile(filename, data)
+}
+
+// writeConfigFile writes a configuration with the schema header, keeping the
+// file it replaces as a .bak backup
+func writeConfigFile(filename string, data []byte) error {
 	// Create backup if file exists
 	if _, err := os.Stat(filename); err == nil {
 		backupFile := filename + ".bak"
 		if err := os.Rename(filename, backupFile); err != nil {
 			return fmt.Errorf("failed to create backup: %w", err)
 		}
-		fmt.Printf("Created backup: %s\n", backupFile)
-	}
-
-	// Marshal configuration to YAML
-	data, err := yaml.Marshal(tmpl)
-	if err != nil {
-		return fmt.Errorf("failed to marshal YAML: %w", err)
+		if !ui.Structured() {
+			fmt.Printf("Created backup: %s\n", backupFile)
+		}
 	}
 
 	// Write to file
-	if err := os.WriteFile(filename, data, 0644); err != nil {
+	if err := os.WriteFile(filename, schema.WithSchemaHeader(data), 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
@@ -763,220 +931,16 @@ func addAIConfigurations(tmpl *schema.NexlayerYAML, info *types.ProjectInfo) {
 }
 
 // printSuccessMessage displays a success message with next steps
-func printSuccessMessage(info *types.ProjectInfo, config *schema.NexlayerYAML) {
+func printSuccessMessage(projectType string, config *schema.NexlayerYAML) {
 	fmt.Println(successStyle.Render("\n✨ Project initialized successfully!"))
-	fmt.Printf("Created nexlayer.yaml for %s project\n", info.Type)
+	fmt.Printf("Created nexlayer.yaml for %s project\n", projectType)
 	fmt.Printf("Application: %s\n", config.Application.Name)
 	fmt.Printf("Pods: %d\n", len(config.Application.Pods))
 
 	fmt.Println("\n📝 Next steps:")
 	fmt.Println("1. Review the generated nexlayer.yaml file")
 	fmt.Println("2. Run 'nexlayer deploy' to deploy your application")
-	fmt.Println("3. Run 'nexlayer watch' to monitor your deployment")
-}
-
-// hasDatabase checks if the project needs a database
-func hasDatabase(info *types.ProjectInfo) bool {
-	// Check dependencies for database-related packages
-	for name := range info.Dependencies {
-		switch name {
-		case "pg", "postgres", "postgresql", "sequelize", "typeorm", "prisma",
-			"mongoose", "mongodb", "mysql", "mysql2", "sqlite3", "redis":
-			return true
-		}
-	}
-	return false
-}
-
-// promptForOverrides prompts the user to confirm or modify detected settings
-func promptForOverrides(info *types.ProjectInfo) error {
-	// Confirm application name
-	prompt := promptui.Prompt{
-		Label:     fmt.Sprintf("Application name [%s]", info.Name),
-		Default:   info.Name,
-		AllowEdit: true,
-	}
-	if result, err := prompt.Run(); err != nil {
-		if err != promptui.ErrInterrupt {
-			return fmt.Errorf("prompt failed: %w", err)
-		}
-	} else if result != "" && result != info.Name {
-		info.Name = result
-	}
-
-	// Confirm project type
-	typePrompt := promptui.Select{
-		Label: "Project type",
-		Items: []string{
-			"Next.js",
-			"React",
-			"Node.js",
-			"Python",
-			"Go",
-			"Docker",
-		},
-	}
-	if _, result, err := typePrompt.Run(); err != nil {
-		if err != promptui.ErrInterrupt {
-			return fmt.Errorf("prompt failed: %w", err)
-		}
-	} else {
-		switch result {
-		case "Next.js":
-			info.Type = types.TypeNextjs
-		case "React":
-			info.Type = types.TypeReact
-		case "Node.js":
-			info.Type = types.TypeNode
-		case "Python":
-			info.Type = types.TypePython
-		case "Go":
-			info.Type = types.TypeGo
-		case "Docker":
-			info.Type = types.TypeDockerRaw
-		}
-	}
-
-	// Confirm port
-	portPrompt := promptui.Prompt{
-		Label:     fmt.Sprintf("Port [%d]", info.Port),
-		Default:   fmt.Sprintf("%d", info.Port),
-		AllowEdit: true,
-		Validate: func(input string) error {
-			if input == "" {
-				return nil
-			}
-			port, err := strconv.Atoi(input)
-			if err != nil {
-				return fmt.Errorf("port must be a number")
-			}
-			if port < 1 || port > 65535 {
-				return fmt.Errorf("port must be between 1 and 65535")
-			}
-			return nil
-		},
-	}
-	if result, err := portPrompt.Run(); err != nil {
-		if err != promptui.ErrInterrupt {
-			return fmt.Errorf("prompt failed: %w", err)
-		}
-	} else if result != "" {
-		if port, err := strconv.Atoi(result); err == nil {
-			info.Port = port
-		}
-	}
-
-	// If database dependencies are detected, confirm database type
-	if hasDatabase(info) {
-		dbPrompt := promptui.Select{
-			Label: "Database type",
-			Items: []string{
-				"PostgreSQL",
-				"MongoDB",
-				"MySQL",
-				"Redis",
-			},
-		}
-		if _, result, err := dbPrompt.Run(); err != nil {
-			if err != promptui.ErrInterrupt {
-				return fmt.Errorf("prompt failed: %w", err)
-			}
-		} else {
-			// Store the selected database type for later use
-			info.Dependencies[strings.ToLower(result)] = "latest"
-		}
-	}
-
-	// Prompt for environment variables if needed
-	if hasEnvironmentVars(info) {
-		fmt.Println(infoStyle.Render("\nEnvironment Variables:"))
-		fmt.Println("Available pod references:", strings.Join(getDefaultPodNames(info), ", "))
-		fmt.Println("Use <pod-name>.pod to reference other pods (e.g., postgres.pod:5432)")
-		fmt.Println("Use <% URL %> to reference the deployment's base URL")
-
-		for name, value := range info.Dependencies {
-			if isServiceDependency(name) {
-				prompt := promptui.Prompt{
-					Label:     fmt.Sprintf("%s URL", name),
-					Default:   getDefaultServiceURL(name, value),
-					AllowEdit: true,
-				}
-				if result, err := prompt.Run(); err != nil {
-					if err != promptui.ErrInterrupt {
-						return fmt.Errorf("prompt failed: %w", err)
-					}
-				} else if result != "" {
-					info.Dependencies[name] = result
-				}
-			}
-		}
-	}
-
-	return nil
-}
-
-// hasEnvironmentVars checks if the project needs environment variables
-func hasEnvironmentVars(info *types.ProjectInfo) bool {
-	for name := range info.Dependencies {
-		if isServiceDependency(name) {
-			return true
-		}
-	}
-	return false
-}
-
-// isServiceDependency checks if a dependency requires service connection
-func isServiceDependency(name string) bool {
-	services := []string{
-		"postgres", "mongodb", "mysql", "redis",
-		"ai-model", "vector-db", "minio",
-	}
-	for _, service := range services {
-		if strings.Contains(name, service) {
-			return true
-		}
-	}
-	return false
-}
-
-// getDefaultServiceURL returns a default URL for a service
-func getDefaultServiceURL(name, _ string) string {
-	switch {
-	case strings.Contains(name, "postgres"):
-		return "postgres.pod:5432"
-	case strings.Contains(name, "mongodb"):
-		return "mongodb.pod:27017"
-	case strings.Contains(name, "mysql"):
-		return "mysql.pod:3306"
-	case strings.Contains(name, "redis"):
-		return "redis.pod:6379"
-	case strings.Contains(name, "ai-model"):
-		return "ai-model.pod:5000"
-	case strings.Contains(name, "vector-db"):
-		return "vector-db.pod:8080"
-	case strings.Contains(name, "minio"):
-		return "minio.pod:9000"
-	default:
-		return fmt.Sprintf("%s.pod", name)
-	}
-}
-
-// getDefaultPodNames returns a list of default pod names based on project type
-func getDefaultPodNames(info *types.ProjectInfo) []string {
-	pods := []string{"web", "api"}
-
-	// Add database pods if needed
-	if hasDatabase(info) {
-		dbType := detectDatabaseType(info)
-		pods = append(pods, fmt.Sprintf("db-%s", dbType))
-	}
-
-	// Add AI-specific pods if needed
-	if info.LLMProvider != "" {
-		pods = append(pods, "ai-model", "vector-db")
-	}
-
-	return pods
+	fmt.Println("3. Run 'nexlayer watch dashboard' to monitor your deployment")
 }
 
 // tryConvertDockerCompose attempts to convert a Docker Compose file to Nexlayer YAML
@@ -984,7 +948,7 @@ func tryConvertDockerCompose(dir string, appName string) (*schema.NexlayerYAML,
 	fmt.Println(infoStyle.Render("🔄 Attempting to convert Docker Compose file..."))
 
 	// Try to detect and convert Docker Compose file
-	config, err := compose.DetectAndConvert(dir, appName)
+	config, err := compose.DetectAndConvert(dir, appName, os.Stdout)
 	if err != nil {
 		// Log the error but don't abort the entire init process
 		fmt.Println(warningStyle.Render(fmt.Sprintf("⚠️ Warning: Found Docker Compose file but couldn't convert it: %v", err)))
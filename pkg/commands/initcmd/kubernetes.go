@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package initcmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/k8s"
+)
+
+// runK8sInit writes nexlayer.yaml from Kubernetes manifests, a file or a
+// directory of them, instead of detecting the project
+func runK8sInit(opts *InitOptions, manifests string) error {
+	fmt.Println(infoStyle.Render(fmt.Sprintf("🔄 Converting Kubernetes manifests from %s...", manifests)))
+	data, err := k8s.ReadManifests(manifests)
+	if err != nil {
+		return err
+	}
+	result, err := k8s.Convert(data, opts.AppName)
+	if err != nil {
+		return err
+	}
+	return saveConverted(opts, result, "kubernetes")
+}
+
+// runHelmInit writes nexlayer.yaml from a Helm chart, a directory or a
+// repo/chart reference, rendered with helm template, the values files and the
+// --set values, and converted as Kubernetes manifests. The release is named
+// after the application.
+func runHelmInit(ctx context.Context, opts *InitOptions, chart string, values, set []string) error {
+	name := opts.AppName
+	if name == "" {
+		name = path.Base(strings.TrimSuffix(filepath.ToSlash(chart), "/"))
+	}
+	fmt.Println(infoStyle.Render(fmt.Sprintf("🔄 Rendering Helm chart %s...", chart)))
+	data, err := k8s.Template(ctx, chart, name, values, set)
+	if err != nil {
+		return err
+	}
+	result, err := k8s.Convert(data, name)
+	if err != nil {
+		return err
+	}
+	return saveConverted(opts, result, "helm")
+}
+
+// saveConverted writes a configuration converted from Kubernetes, named
+// after the directory when the manifests set no namespace
+func saveConverted(opts *InitOptions, result *k8s.Converted, source string) error {
+	config := result.Config
+	if config.Application.Name == "" {
+		abs, err := filepath.Abs(opts.Directory)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", opts.Directory, err)
+		}
+		config.Application.Name = filepath.Base(abs)
+	}
+	for _, note := range result.Notes {
+		fmt.Println(warningStyle.Render("⚠️  " + note))
+	}
+	return saveConfiguration(opts, config, source)
+}
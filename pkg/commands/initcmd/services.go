@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package initcmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/types"
+)
+
+// defaultNodePort is the port of the Node.js pods added next to a frontend
+const defaultNodePort = 3000
+
+// clientServices maps the client libraries of databases and caches, in the
+// languages init detects, to the kind of service they connect to
+var clientServices = map[string]string{
+	// PostgreSQL
+	"pg": schema.PodTypePostgres, "pg-promise": schema.PodTypePostgres, "postgres": schema.PodTypePostgres,
+	"postgresql": schema.PodTypePostgres, "psycopg": schema.PodTypePostgres, "psycopg2": schema.PodTypePostgres,
+	"psycopg2-binary": schema.PodTypePostgres, "asyncpg": schema.PodTypePostgres,
+	"github.com/lib/pq": schema.PodTypePostgres, "github.com/jackc/pgx/v5": schema.PodTypePostgres,
+	// MySQL
+	"mysql": schema.PodTypeMySQL, "mysql2": schema.PodTypeMySQL, "pymysql": schema.PodTypeMySQL,
+	"mysqlclient": schema.PodTypeMySQL, "aiomysql": schema.PodTypeMySQL,
+	"github.com/go-sql-driver/mysql": schema.PodTypeMySQL,
+	// MongoDB
+	"mongodb": schema.PodTypeMongoDB, "mongoose": schema.PodTypeMongoDB, "pymongo": schema.PodTypeMongoDB,
+	"motor": schema.PodTypeMongoDB, "go.mongodb.org/mongo-driver": schema.PodTypeMongoDB,
+	// Redis
+	"redis": schema.PodTypeRedis, "ioredis": schema.PodTypeRedis, "aioredis": schema.PodTypeRedis,
+	"github.com/redis/go-redis/v9": schema.PodTypeRedis,
+}
+
+// orm is an ORM or migration tool, with the command applying its migrations
+// when it has a conventional one
+type orm struct {
+	migrate schema.Command
+}
+
+// orms are the ORMs and migration tools init recognizes
+var orms = map[string]orm{
+	"prisma":         {migrate: schema.Command{"npx", "prisma", "migrate", "deploy"}},
+	"@prisma/client": {migrate: schema.Command{"npx", "prisma", "migrate", "deploy"}},
+	"sequelize":      {migrate: schema.Command{"npx", "sequelize-cli", "db:migrate"}},
+	"knex":           {migrate: schema.Command{"npx", "knex", "migrate:latest"}},
+	"drizzle-orm":    {migrate: schema.Command{"npx", "drizzle-kit", "migrate"}},
+	"typeorm":        {},
+	"django":         {migrate: schema.Command{"python", "manage.py", "migrate"}},
+	"alembic":        {migrate: schema.Command{"alembic", "upgrade", "head"}},
+	"sqlalchemy":     {},
+	"gorm.io/gorm":   {},
+}
+
+// queues maps job queue libraries to the broker they need
+var queues = map[string]string{
+	"bull": schema.PodTypeRedis, "bullmq": schema.PodTypeRedis, "bee-queue": schema.PodTypeRedis,
+	"celery": schema.PodTypeRedis, "rq": schema.PodTypeRedis,
+}
+
+// serverFrameworks are the Node.js server frameworks that, next to a
+// frontend, make up a separate backend
+var serverFrameworks = []string{"express", "fastify", "koa", "@nestjs/core", "hono"}
+
+// detectedServices are the parts of an application found from its
+// dependencies, besides its main pod
+type detectedServices struct {
+	kinds      []string       // backing services, such as postgres and redis
+	migrations schema.Command // applies the ORM's migrations
+	worker     schema.Command // runs the job queue worker
+	backend    schema.Command // runs the server of a frontend project
+	notes      []string       // what the user should check
+}
+
+// detectServices finds the databases, caches, job queues, ORMs and backend an
+// application uses from its dependencies and scripts
+func detectServices(info *types.ProjectInfo) detectedServices {
+	var d detectedServices
+	deps := make(map[string]bool, len(info.Dependencies))
+	for name := range info.Dependencies {
+		deps[dependencyName(name)] = true
+	}
+
+	kinds := make(map[string]bool)
+	var ormNames []string
+	for name := range deps {
+		if kind, ok := clientServices[name]; ok {
+			kinds[kind] = true
+		}
+		if _, ok := orms[name]; ok {
+			ormNames = append(ormNames, name)
+		}
+		if broker, ok := queues[name]; ok {
+			kinds[broker] = true
+		}
+	}
+
+	// An ORM without a driver talks to PostgreSQL, the most common choice
+	sort.Strings(ormNames)
+	if len(ormNames) > 0 && !kinds[schema.PodTypePostgres] && !kinds[schema.PodTypeMySQL] && !kinds[schema.PodTypeMongoDB] {
+		kinds[schema.PodTypePostgres] = true
+		d.notes = append(d.notes, fmt.Sprintf("%s uses no known driver; assuming PostgreSQL", ormNames[0]))
+	}
+	for _, name := range ormNames {
+		if m := orms[name].migrate; m != nil {
+			d.migrations = m
+			break
+		}
+	}
+
+	switch {
+	case deps["celery"]:
+		d.worker = schema.Command{"celery", "-A", info.Name, "worker", "--loglevel=info"}
+		d.notes = append(d.notes, fmt.Sprintf("The worker runs the Celery app %s; change its command if the app module differs", info.Name))
+	case deps["rq"]:
+		d.worker = schema.Command{"sh", "-c", `exec rq worker --url "$REDIS_URL"`}
+	case deps["bull"] || deps["bullmq"] || deps["bee-queue"]:
+		d.worker = npmScript(info, &d, "worker", "worker", "start:worker", "queue")
+	}
+
+	if info.Type == types.TypeReact {
+		for _, framework := range serverFrameworks {
+			if deps[framework] {
+				d.backend = npmScript(info, &d, "api", "server", "start:server", "api", "backend")
+				break
+			}
+		}
+	}
+
+	for kind := range kinds {
+		d.kinds = append(d.kinds, kind)
+	}
+	sort.Strings(d.kinds)
+	return d
+}
+
+// npmScript returns the command running the first of scripts that the
+// project defines, or the first one with a note to add it
+func npmScript(info *types.ProjectInfo, d *detectedServices, pod string, scripts ...string) schema.Command {
+	for _, script := range scripts {
+		if _, ok := info.Scripts[script]; ok {
+			return schema.Command{"npm", "run", script}
+		}
+	}
+	d.notes = append(d.notes, fmt.Sprintf("Add a %q script to package.json for the %s pod, or change its command", scripts[0], pod))
+	return schema.Command{"npm", "run", scripts[0]}
+}
+
+// dependencyName normalizes a dependency as detected, such as a line of
+// requirements.txt, to its lower-case package name
+func dependencyName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if i := strings.IndexAny(name, "[<>=~!; "); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// generateCodePod creates a pod running the application's code with another
+// command, such as its worker or backend
+func generateCodePod(name string, image string, port int, command schema.Command, vars []schema.EnvVar) schema.Pod {
+	return schema.Pod{
+		Name:    name,
+		Image:   image,
+		Command: command,
+		Vars:    append([]schema.EnvVar(nil), vars...),
+		ServicePorts: []schema.ServicePort{{
+			Name:       "http",
+			Port:       port,
+			TargetPort: port,
+			Protocol:   "TCP",
+		}},
+	}
+}
+
+// addServices adds the backend, worker, backing services and migrations
+// found in the project to config, whose first pod is the main one
+func addServices(config *schema.NexlayerYAML, info *types.ProjectInfo, services detectedServices) {
+	main := config.Application.Pods[0]
+
+	// The pod running server-side code, which the worker and migrations share
+	code := main
+	if services.backend != nil {
+		backend := generateCodePod("api", getDefaultImage(types.TypeNode), defaultNodePort, services.backend, nil)
+		backend.Type = schema.PodTypeBackend
+		backend.Path = "/api"
+		config.Application.Pods = append(config.Application.Pods, backend)
+		code = backend
+	} else if info.Type == types.TypeReact {
+		// The main pod only serves the static build
+		code = schema.Pod{Image: getDefaultImage(types.TypeNode), ServicePorts: []schema.ServicePort{{Port: defaultNodePort}}}
+	}
+
+	if services.worker != nil {
+		worker := generateCodePod("worker", code.Image, code.ServicePorts[0].Port, services.worker, code.Vars)
+		config.Application.Pods = append(config.Application.Pods, worker)
+	}
+	if services.migrations != nil {
+		if code.Name == "" {
+			config.Application.Migrations = &schema.Migrations{Image: code.Image, Command: services.migrations}
+		} else {
+			config.Application.Migrations = &schema.Migrations{Pod: code.Name, Command: services.migrations}
+		}
+	}
+
+	if len(services.kinds) > 0 {
+		config.Application.Services = make(map[string]schema.BackingService, len(services.kinds))
+		for _, kind := range services.kinds {
+			config.Application.Services[kind] = schema.BackingService{}
+		}
+	}
+}
@@ -0,0 +1,542 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package initcmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/types"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui/styles"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	wizardTitleStyle  = lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	wizardMutedStyle  = lipgloss.NewStyle().Foreground(styles.TextSecondary)
+	wizardCursorStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	wizardErrorStyle  = lipgloss.NewStyle().Foreground(styles.Error)
+	wizardOKStyle     = lipgloss.NewStyle().Foreground(styles.Success)
+)
+
+// wizardPodName matches the pod names the wizard accepts
+var wizardPodName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// wizardTypes are the stacks a pod can be switched between
+var wizardTypes = []types.ProjectType{
+	types.TypeNextjs, types.TypeReact, types.TypeNode, types.TypePython, types.TypeGo, types.TypeDockerRaw,
+}
+
+// rowKind is what a row of the wizard edits
+type rowKind int
+
+const (
+	rowAppName rowKind = iota
+	rowPodName
+	rowPodType
+	rowPodImage
+	rowPodPort
+	rowPodPath
+	rowService
+)
+
+// wizardRow is a row of the wizard: a field of the application or of a pod,
+// or a service to toggle
+type wizardRow struct {
+	kind    rowKind
+	pod     int
+	service string
+}
+
+// wizard is the bubbletea model of init --interactive. It edits a draft
+// configuration, whose services are not expanded yet, and shows the file it
+// would write next to it.
+type wizard struct {
+	info  *types.ProjectInfo
+	path  string
+	draft *schema.NexlayerYAML
+
+	cursor   int
+	editing  bool
+	input    textinput.Model
+	message  string
+	preview  []string
+	problems []string
+	scroll   int
+	width    int
+	height   int
+
+	// config is the expanded configuration, set when the user writes it
+	config *schema.NexlayerYAML
+}
+
+// newWizard creates the wizard for a draft configuration
+func newWizard(info *types.ProjectInfo, path string, draft *schema.NexlayerYAML) *wizard {
+	input := textinput.New()
+	input.Prompt = ""
+	input.CharLimit = 256
+	w := &wizard{info: info, path: path, draft: draft, input: input}
+	w.refresh()
+	return w
+}
+
+// runWizard lets the user review and change a draft configuration. It returns
+// the configuration to write, or nil when the user quits without writing.
+func runWizard(info *types.ProjectInfo, path string, draft *schema.NexlayerYAML) (*schema.NexlayerYAML, error) {
+	final, err := tea.NewProgram(newWizard(info, path, draft), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, err
+	}
+	return final.(*wizard).config, nil
+}
+
+func (w *wizard) Init() tea.Cmd {
+	return nil
+}
+
+func (w *wizard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		w.width, w.height = msg.Width, msg.Height
+		return w, nil
+	case tea.KeyMsg:
+		if w.editing {
+			return w.updateEditing(msg)
+		}
+		return w.updateBrowsing(msg)
+	}
+	return w, nil
+}
+
+// updateEditing handles keys while a field is edited
+func (w *wizard) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return w, tea.Quit
+	case "esc":
+		w.editing, w.message = false, ""
+		w.input.Blur()
+		return w, nil
+	case "enter":
+		if err := w.apply(w.rows()[w.cursor], strings.TrimSpace(w.input.Value())); err != nil {
+			w.message = err.Error()
+			return w, nil
+		}
+		w.editing, w.message = false, ""
+		w.input.Blur()
+		w.refresh()
+		return w, nil
+	}
+	var cmd tea.Cmd
+	w.input, cmd = w.input.Update(msg)
+	return w, cmd
+}
+
+// updateBrowsing handles keys while moving between rows
+func (w *wizard) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := w.rows()
+	row := rows[w.cursor]
+	w.message = ""
+	switch msg.String() {
+	case "q", "esc", "ctrl+c":
+		return w, tea.Quit
+	case "up", "k":
+		w.cursor = (w.cursor + len(rows) - 1) % len(rows)
+	case "down", "j", "tab":
+		w.cursor = (w.cursor + 1) % len(rows)
+	case "pgdown":
+		w.scroll = min(w.scroll+w.previewRows(), max(len(w.preview)-w.previewRows(), 0))
+	case "pgup":
+		w.scroll = max(w.scroll-w.previewRows(), 0)
+	case "left", "h", "right", "l":
+		if row.kind == rowPodType {
+			step := 1
+			if msg.String() == "left" || msg.String() == "h" {
+				step = len(wizardTypes) - 1
+			}
+			w.cycleType(row.pod, step)
+			w.refresh()
+		}
+	case "enter", " ":
+		switch row.kind {
+		case rowService:
+			w.toggleService(row.service)
+			w.refresh()
+		case rowPodType:
+			w.cycleType(row.pod, 1)
+			w.refresh()
+		default:
+			w.edit(row)
+			return w, textinput.Blink
+		}
+	case "a":
+		w.addPod()
+		w.refresh()
+		w.edit(w.rows()[w.cursor])
+		return w, textinput.Blink
+	case "d", "x":
+		if row.kind == rowAppName || row.kind == rowService {
+			w.message = "select a pod to remove it"
+		} else if len(w.draft.Application.Pods) == 1 {
+			w.message = "the application needs at least one pod"
+		} else {
+			w.removePod(row.pod)
+			w.refresh()
+		}
+	case "w":
+		if len(w.problems) > 0 {
+			w.message = "fix the problems listed before writing"
+			return w, nil
+		}
+		config, err := expandDraft(w.draft)
+		if err != nil {
+			w.message = err.Error()
+			return w, nil
+		}
+		w.config = config
+		return w, tea.Quit
+	}
+	return w, nil
+}
+
+// rows lists the rows of the wizard: the application name, the fields of
+// each pod and the services
+func (w *wizard) rows() []wizardRow {
+	rows := []wizardRow{{kind: rowAppName}}
+	for i := range w.draft.Application.Pods {
+		for _, kind := range []rowKind{rowPodName, rowPodType, rowPodImage, rowPodPort, rowPodPath} {
+			rows = append(rows, wizardRow{kind: kind, pod: i})
+		}
+	}
+	for _, kind := range schema.ServiceKinds() {
+		rows = append(rows, wizardRow{kind: rowService, service: kind})
+	}
+	return rows
+}
+
+// value returns the current value of a row
+func (w *wizard) value(row wizardRow) string {
+	if row.kind == rowAppName {
+		return w.draft.Application.Name
+	}
+	if row.kind == rowService {
+		return ""
+	}
+	pod := w.draft.Application.Pods[row.pod]
+	switch row.kind {
+	case rowPodName:
+		return pod.Name
+	case rowPodType:
+		return pod.Type
+	case rowPodImage:
+		return pod.Image
+	case rowPodPort:
+		if len(pod.ServicePorts) > 0 {
+			return strconv.Itoa(pod.ServicePorts[0].Port)
+		}
+	case rowPodPath:
+		return pod.Path
+	}
+	return ""
+}
+
+// edit starts editing a row
+func (w *wizard) edit(row wizardRow) {
+	w.editing = true
+	w.input.SetValue(w.value(row))
+	w.input.CursorEnd()
+	w.input.Focus()
+}
+
+// apply sets the value of a row, or reports why it is not valid
+func (w *wizard) apply(row wizardRow, value string) error {
+	if row.kind == rowAppName {
+		if value == "" {
+			return fmt.Errorf("the application needs a name")
+		}
+		w.draft.Application.Name = value
+		return nil
+	}
+
+	pod := &w.draft.Application.Pods[row.pod]
+	switch row.kind {
+	case rowPodName:
+		if !wizardPodName.MatchString(value) {
+			return fmt.Errorf("pod names start with a letter and use lowercase letters, digits and dashes")
+		}
+		for i, other := range w.draft.Application.Pods {
+			if i != row.pod && other.Name == value {
+				return fmt.Errorf("a pod named %s already exists", value)
+			}
+		}
+		if m := w.draft.Application.Migrations; m != nil && m.Pod == pod.Name {
+			m.Pod = value
+		}
+		pod.Name = value
+	case rowPodImage:
+		if value == "" {
+			return fmt.Errorf("the pod needs an image")
+		}
+		pod.Image = value
+	case rowPodPort:
+		port, err := strconv.Atoi(value)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("port must be a number between 1 and 65535")
+		}
+		if len(pod.ServicePorts) == 0 {
+			pod.ServicePorts = []schema.ServicePort{{Name: "http", Protocol: "TCP"}}
+		}
+		pod.ServicePorts[0].Port, pod.ServicePorts[0].TargetPort = port, port
+	case rowPodPath:
+		if value != "" && !strings.HasPrefix(value, "/") {
+			return fmt.Errorf("paths start with /, or are empty for pods not exposed")
+		}
+		pod.Path = value
+	}
+	return nil
+}
+
+// cycleType switches a pod to another stack, with that stack's image if the
+// pod still had the default one
+func (w *wizard) cycleType(i, step int) {
+	pod := &w.draft.Application.Pods[i]
+	current := types.ProjectType(pod.Type)
+	next := wizardTypes[0]
+	for n, t := range wizardTypes {
+		if t == current {
+			next = wizardTypes[(n+step)%len(wizardTypes)]
+			break
+		}
+	}
+	if pod.Image == "" || pod.Image == getDefaultImage(current) {
+		pod.Image = getDefaultImage(next)
+	}
+	pod.Type = string(next)
+}
+
+// toggleService adds or removes a backing service
+func (w *wizard) toggleService(kind string) {
+	services := w.draft.Application.Services
+	if _, ok := services[kind]; ok {
+		delete(services, kind)
+		return
+	}
+	if services == nil {
+		w.draft.Application.Services = make(map[string]schema.BackingService)
+	}
+	w.draft.Application.Services[kind] = schema.BackingService{}
+}
+
+// addPod adds a Node.js pod with a free name and moves to its name
+func (w *wizard) addPod() {
+	taken := make(map[string]bool)
+	for _, pod := range w.draft.Application.Pods {
+		taken[pod.Name] = true
+	}
+	name := "app"
+	for i := 2; taken[name]; i++ {
+		name = fmt.Sprintf("app-%d", i)
+	}
+	pod := generateCodePod(name, getDefaultImage(types.TypeNode), defaultNodePort, nil, nil)
+	pod.Type = string(types.TypeNode)
+	w.draft.Application.Pods = append(w.draft.Application.Pods, pod)
+	for i, row := range w.rows() {
+		if row.kind == rowPodName && row.pod == len(w.draft.Application.Pods)-1 {
+			w.cursor = i
+		}
+	}
+}
+
+// removePod removes a pod, and the migrations that ran in it
+func (w *wizard) removePod(i int) {
+	pods := w.draft.Application.Pods
+	if m := w.draft.Application.Migrations; m != nil && m.Pod == pods[i].Name {
+		w.draft.Application.Migrations = nil
+		w.message = fmt.Sprintf("removed the migrations, which ran in %s", pods[i].Name)
+	}
+	w.draft.Application.Pods = append(pods[:i], pods[i+1:]...)
+	w.cursor = min(w.cursor, len(w.rows())-1)
+}
+
+// refresh renders the configuration the draft stands for and checks it
+func (w *wizard) refresh() {
+	w.problems = nil
+	config, err := expandDraft(w.draft)
+	if err != nil {
+		w.preview = nil
+		w.problems = []string{err.Error()}
+		return
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		w.problems = []string{err.Error()}
+		return
+	}
+	w.preview = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	w.scroll = min(w.scroll, max(len(w.preview)-w.previewRows(), 0))
+	for _, e := range schema.Validate(config) {
+		w.problems = append(w.problems, e.Field+": "+e.Message)
+	}
+}
+
+// expandDraft returns a copy of a draft configuration with its services
+// expanded into pods, leaving the draft as it is
+func expandDraft(draft *schema.NexlayerYAML) (*schema.NexlayerYAML, error) {
+	config := *draft
+	config.Application.Pods = make([]schema.Pod, len(draft.Application.Pods))
+	for i, pod := range draft.Application.Pods {
+		pod.Vars = append([]schema.EnvVar(nil), pod.Vars...)
+		config.Application.Pods[i] = pod
+	}
+	if m := draft.Application.Migrations; m != nil {
+		migrations := *m
+		config.Application.Migrations = &migrations
+	}
+	if _, err := schema.ExpandServices(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (w *wizard) View() string {
+	form := w.formView()
+	preview := w.previewView()
+	if w.width >= 100 {
+		formWidth := min(56, w.width/2)
+		return lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(formWidth).MaxWidth(formWidth).Render(form),
+			lipgloss.NewStyle().MaxWidth(w.width-formWidth).Render(preview))
+	}
+	return form + "\n\n" + preview
+}
+
+// formView renders the detected stack, the rows and the keys
+func (w *wizard) formView() string {
+	var b strings.Builder
+	b.WriteString(wizardTitleStyle.Render("nexlayer init") + "  " + wizardMutedStyle.Render(w.path) + "\n")
+	b.WriteString(wizardMutedStyle.Render("Detected "+w.stack()) + "\n\n")
+
+	for i, row := range w.rows() {
+		if row.kind == rowPodName {
+			b.WriteString("\n" + wizardTitleStyle.Render("Pod") + "\n")
+		}
+		if row.kind == rowService && (i == 0 || w.rows()[i-1].kind != rowService) {
+			b.WriteString("\n" + wizardTitleStyle.Render("Services") + "\n")
+		}
+
+		marker := "  "
+		if i == w.cursor {
+			marker = wizardCursorStyle.Render("› ")
+		}
+		var line string
+		if row.kind == rowService {
+			box := "[ ]"
+			if _, ok := w.draft.Application.Services[row.service]; ok {
+				box = wizardOKStyle.Render("[x]")
+			}
+			line = box + " " + row.service
+		} else {
+			value := w.value(row)
+			if i == w.cursor && w.editing {
+				value = w.input.View()
+			} else if value == "" {
+				value = wizardMutedStyle.Render("-")
+			} else if row.kind == rowPodType {
+				value = "‹ " + value + " ›"
+			}
+			line = fmt.Sprintf("%-6s %s", rowLabel(row.kind), value)
+		}
+		b.WriteString(marker + line + "\n")
+	}
+
+	if len(w.problems) > 0 {
+		b.WriteString("\n" + wizardErrorStyle.Render(fmt.Sprintf("%d problems", len(w.problems))) + "\n")
+		for _, p := range w.problems {
+			b.WriteString(wizardErrorStyle.Render("  "+p) + "\n")
+		}
+	}
+	if w.message != "" {
+		b.WriteString("\n" + wizardErrorStyle.Render(w.message) + "\n")
+	}
+
+	keys := "↑/↓ move · enter edit or toggle · ←/→ type · a add pod · d remove pod · w write · q quit"
+	if w.editing {
+		keys = "enter apply · esc cancel"
+	}
+	b.WriteString("\n" + wizardMutedStyle.Render(keys))
+	return b.String()
+}
+
+// previewView renders the part of the file that fits
+func (w *wizard) previewView() string {
+	var b strings.Builder
+	title := "nexlayer.yaml"
+	if len(w.preview) > w.previewRows() {
+		title += fmt.Sprintf(" (lines %d-%d of %d, pgup/pgdown)", w.scroll+1, min(w.scroll+w.previewRows(), len(w.preview)), len(w.preview))
+	}
+	b.WriteString(wizardTitleStyle.Render(title) + "\n")
+	end := min(w.scroll+w.previewRows(), len(w.preview))
+	for _, line := range w.preview[min(w.scroll, end):end] {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// previewRows is how many lines of the file fit
+func (w *wizard) previewRows() int {
+	if w.height == 0 {
+		return 40
+	}
+	if w.width >= 100 {
+		return max(w.height-2, 5)
+	}
+	return max(w.height-len(w.rows())-16, 5)
+}
+
+// stack summarizes what detection found
+func (w *wizard) stack() string {
+	summary := string(w.info.Type) + " project"
+	if w.info.Type == types.TypeUnknown || w.info.Type == "" {
+		summary = "no known stack"
+	}
+	var deps []string
+	for name := range w.info.Dependencies {
+		deps = append(deps, name)
+	}
+	sort.Strings(deps)
+	if len(deps) > 6 {
+		deps = append(deps[:6], fmt.Sprintf("and %d more", len(deps)-6))
+	}
+	if len(deps) > 0 {
+		summary += " using " + strings.Join(deps, ", ")
+	}
+	return summary
+}
+
+// rowLabel names the field a row edits
+func rowLabel(kind rowKind) string {
+	switch kind {
+	case rowAppName:
+		return "App"
+	case rowPodName:
+		return "name"
+	case rowPodType:
+		return "type"
+	case rowPodImage:
+		return "image"
+	case rowPodPort:
+		return "port"
+	case rowPodPath:
+		return "path"
+	}
+	return ""
+}
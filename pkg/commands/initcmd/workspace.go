@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package initcmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/types"
+	"github.com/Nexlayer/nexlayer-cli/pkg/detection"
+)
+
+// invalidPodNameChars matches what pod names cannot contain
+var invalidPodNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// isFrontend reports whether a project of this type serves the web UI
+func isFrontend(projectType types.ProjectType) bool {
+	switch projectType {
+	case types.TypeNextjs, types.TypeReact, types.TypeLangchainNextjs:
+		return true
+	}
+	return false
+}
+
+// workspacePodName turns the directory of a workspace project into a pod
+// name that no other pod has
+func workspacePodName(dir string, used map[string]bool) string {
+	name := strings.Trim(invalidPodNameChars.ReplaceAllString(strings.ToLower(filepath.Base(dir)), "-"), "-")
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "app-" + name
+	}
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// runWorkspaceInit generates the configuration of a monorepo
+func runWorkspaceInit(ws *detection.Workspace, opts *InitOptions) error {
+	fmt.Println(infoStyle.Render(fmt.Sprintf("🔍 Detected %s workspace with %d projects", strings.Join(ws.Tools, " + "), len(ws.Projects))))
+	for _, project := range ws.Projects {
+		fmt.Printf("   %s (%s, port %d)\n", project.Dir, project.Info.Type, project.Info.Port)
+	}
+
+	config, notes, err := generateWorkspaceConfiguration(ws, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate configuration: %w", err)
+	}
+	for _, note := range notes {
+		fmt.Println(warningStyle.Render("⚠️  " + note))
+	}
+	return saveConfiguration(opts, config, "monorepo")
+}
+
+// generateWorkspaceConfiguration creates a configuration with a pod for each
+// project of a monorepo, built from its directory when it has a Dockerfile,
+// and the databases, caches, workers and migrations its dependencies call for
+func generateWorkspaceConfiguration(ws *detection.Workspace, opts *InitOptions) (*schema.NexlayerYAML, []string, error) {
+	name := opts.AppName
+	if name == "" {
+		name = filepath.Base(ws.Root)
+	}
+	config := &schema.NexlayerYAML{
+		Application: schema.Application{Name: name},
+	}
+
+	var notes []string
+	used := make(map[string]bool)
+	kinds := make(map[string]bool)
+	var built bool
+	var workers []schema.Pod
+	for _, project := range ws.Projects {
+		pod := generateMainPod(project.Info, &InitOptions{})
+		pod.Name = workspacePodName(project.Dir, used)
+		pod.Path = ""
+		if project.Dockerfile != "" {
+			pod.Image = fmt.Sprintf("%s/%s:latest", schema.RegistryPlaceholder, pod.Name)
+			pod.Build = &schema.ImageBuild{Context: "./" + project.Dir}
+			built = true
+		} else {
+			notes = append(notes, fmt.Sprintf("%s has no Dockerfile; pod %s runs the stock %s image until you add one, e.g. with 'nexlayer ai dockerfile %s -f nexlayer.yaml --pod %s'", project.Dir, pod.Name, pod.Image, project.Dir, pod.Name))
+		}
+
+		services := detectServices(project.Info)
+		for _, kind := range services.kinds {
+			kinds[kind] = true
+		}
+		if services.migrations != nil && config.Application.Migrations == nil {
+			config.Application.Migrations = &schema.Migrations{Pod: pod.Name, Command: services.migrations}
+		}
+		if services.worker != nil {
+			worker := generateCodePod(pod.Name+"-worker", pod.Image, pod.ServicePorts[0].Port, services.worker, pod.Vars)
+			workers = append(workers, worker)
+		}
+		for _, note := range services.notes {
+			notes = append(notes, project.Dir+": "+note)
+		}
+
+		config.Application.Pods = append(config.Application.Pods, pod)
+	}
+
+	// The frontend serves the root; backends are routed by path prefix, /api
+	// when there is one next to a frontend
+	pods := config.Application.Pods
+	frontend := -1
+	var backends []int
+	for i, project := range ws.Projects {
+		switch {
+		case frontend < 0 && isFrontend(project.Info.Type):
+			frontend = i
+		case isWebOrAPI(project.Info.Type) || project.Dockerfile != "":
+			backends = append(backends, i)
+		}
+	}
+	if frontend >= 0 {
+		pods[frontend].Path = "/"
+		if len(backends) == 1 {
+			pods[backends[0]].Path = "/api"
+			pods[frontend].Vars = append(pods[frontend].Vars, schema.EnvVar{Key: "API_URL", Value: "<% URL %>/api"})
+		}
+	}
+	for n, i := range backends {
+		if pods[i].Path != "" {
+			continue
+		}
+		if frontend < 0 && n == 0 {
+			pods[i].Path = "/"
+		} else {
+			pods[i].Path = "/" + pods[i].Name
+		}
+	}
+	config.Application.Pods = append(config.Application.Pods, workers...)
+
+	if built {
+		notes = append(notes, "Set application.registryLogin so 'nexlayer deploy --watch-files' can push the images it builds")
+	}
+
+	if len(kinds) > 0 {
+		config.Application.Services = make(map[string]schema.BackingService, len(kinds))
+		for kind := range kinds {
+			config.Application.Services[kind] = schema.BackingService{}
+		}
+		if _, err := schema.ExpandServices(config); err != nil {
+			return nil, nil, err
+		}
+	}
+	return config, notes, nil
+}
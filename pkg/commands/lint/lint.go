@@ -0,0 +1,169 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/lint"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/Nexlayer/nexlayer-cli/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the lint command
+func NewCommand() *cobra.Command {
+	var (
+		fix         bool
+		format      string
+		minSeverity string
+		failOn      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint [file]",
+		Short: "Check a deployment file for errors and bad practices",
+		Long: `Check a deployment file against the Nexlayer schema, the security scanner
+and best-practice rules. The file defaults to the deployment file of the
+current directory.
+
+Every issue has a severity: error, warning or info. --fix repairs the issues
+it can, such as old schema versions, unnamed ports and volumes without a size,
+keeping the comments of the file, and reports the rest.
+
+--format json and --format sarif write machine-readable reports; SARIF is
+understood by GitHub code scanning and other CI annotators. The command exits
+non-zero when an issue reaches the --fail-on severity.
+
+Examples:
+  nexlayer lint
+  nexlayer lint --fix
+  nexlayer lint nexlayer.yaml --severity warning
+  nexlayer lint --format sarif > nexlayer.sarif`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			threshold, err := lint.ParseSeverity(failOn)
+			if err != nil {
+				return err
+			}
+			shown, err := lint.ParseSeverity(minSeverity)
+			if err != nil {
+				return err
+			}
+			if format != "text" && format != "json" && format != "sarif" {
+				return fmt.Errorf("unsupported format %q (use text, json or sarif)", format)
+			}
+
+			file := ""
+			if len(args) > 0 {
+				file = args[0]
+			} else {
+				found, err := deployment.FindFile()
+				if err != nil {
+					return err
+				}
+				file = found
+			}
+			// Issues in the file are not a misuse of the command
+			cmd.SilenceUsage = true
+
+			report, err := lint.File(file)
+			if err != nil {
+				return err
+			}
+			var fixed []lint.Issue
+			if fix {
+				if fixed, err = report.Fix(); err != nil {
+					return err
+				}
+			}
+			report.Issues = filter(report.Issues, shown)
+
+			out := cmd.OutOrStdout()
+			switch {
+			case format == "sarif":
+				if err := writeJSON(out, lint.SARIF([]*lint.Report{report}, version.GetVersion())); err != nil {
+					return err
+				}
+			case format == "json":
+				if err := writeJSON(out, report); err != nil {
+					return err
+				}
+			case ui.Structured():
+				if err := ui.WriteOutput(report); err != nil {
+					return err
+				}
+			default:
+				render(out, report, fixed)
+			}
+
+			if n := report.Count(threshold); n > 0 {
+				return fmt.Errorf("found %d issues at or above %s severity in %s", n, threshold, file)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Fix the issues that can be fixed automatically, rewriting the file")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json, sarif)")
+	cmd.Flags().StringVar(&minSeverity, "severity", "info", "Only report issues of at least this severity (info, warning, error)")
+	cmd.Flags().StringVar(&failOn, "fail-on", "error", "Fail when an issue has at least this severity")
+
+	return cmd
+}
+
+// filter returns the issues at or above a severity
+func filter(issues []lint.Issue, min lint.Severity) []lint.Issue {
+	kept := []lint.Issue{}
+	for _, issue := range issues {
+		if issue.Severity >= min {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// writeJSON writes v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode lint report: %w", err)
+	}
+	return nil
+}
+
+// render prints the fixes applied and the issues left
+func render(w io.Writer, report *lint.Report, fixed []lint.Issue) {
+	for _, issue := range fixed {
+		fmt.Fprintf(w, "%s fixed %s: %s\n", ui.Symbols().Success, issue.RuleID, issue.Message)
+	}
+	if len(report.Issues) == 0 {
+		fmt.Fprintf(w, "%s No issues found in %s\n", ui.Symbols().Success, report.File)
+		return
+	}
+	fixable := 0
+	for _, issue := range report.Issues {
+		location := report.File
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s:%d", report.File, issue.Line)
+		}
+		fmt.Fprintf(w, "%s [%s] %s: %s\n", location, strings.ToUpper(issue.Severity.String()), issue.RuleID, issue.Message)
+		if issue.Suggestion != "" {
+			fmt.Fprintf(w, "    fix: %s\n", issue.Suggestion)
+		}
+		if issue.Fixable {
+			fixable++
+		}
+	}
+	if fixable > 0 {
+		fmt.Fprintf(w, "\n%d issues can be fixed with --fix\n", fixable)
+	}
+}
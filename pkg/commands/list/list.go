@@ -5,9 +5,7 @@
 package list
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
@@ -60,20 +58,9 @@ Examples:
 			// Check if an application ID was provided
 			if len(args) > 0 {
 				appID := args[0]
-				// If we have an appID, use GetDeployments which filters by appID
-				// This requires a cast to APIClientForCommands since GetDeployments is not in the main APIClient interface
-				if clientWithCommands, ok := client.(api.APIClientForCommands); ok {
-					resp, err = clientWithCommands.GetDeployments(cmd.Context(), appID)
-					if err != nil {
-						return fmt.Errorf("failed to get deployments for application %s: %w", appID, err)
-					}
-				} else {
-					// Fallback to ListDeployments if the client doesn't implement APIClientForCommands
-					fmt.Fprintf(cmd.OutOrStdout(), "Warning: Filtering by application ID not supported. Showing all deployments.\n\n")
-					resp, err = client.ListDeployments(cmd.Context())
-					if err != nil {
-						return fmt.Errorf("failed to get deployments: %w", err)
-					}
+				resp, err = client.GetDeployments(cmd.Context(), appID)
+				if err != nil {
+					return fmt.Errorf("failed to get deployments for application %s: %w", appID, err)
 				}
 			} else {
 				// Get all deployments
@@ -84,8 +71,8 @@ Examples:
 			}
 
 			// Check JSON output flag
-			if jsonOutput, _ := cmd.Flags().GetBool("json"); jsonOutput {
-				return json.NewEncoder(os.Stdout).Encode(resp)
+			if ui.Structured() {
+				return ui.WriteOutput(resp)
 			}
 
 			// Print human-readable table
@@ -6,21 +6,123 @@ package login
 
 import (
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/auth"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/profile"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
 // NewLoginCommand creates a new login command
 func NewLoginCommand(client api.APIClient) *cobra.Command {
+	var authURL, token string
+	var noBrowser bool
+
 	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Log in to Nexlayer",
-		Long:  "Log in to your Nexlayer account to access deployment features",
+		Long: `Log in to your Nexlayer account to access deployment features.
+
+A code is shown and a browser opens to approve it on the Nexlayer instance of
+the selected profile (see --profile). Once approved, the token is saved for
+that profile in ~/.nexlayer/credentials and used by every command run with
+it, so there is no need to export NEXLAYER_TOKEN. A token set in
+NEXLAYER_AUTH_TOKEN or NEXLAYER_TOKEN still takes precedence.
+
+On machines without a browser, use --no-browser and open the link on another
+device, or save an existing token with --token.
+
+Examples:
+  nexlayer login
+  nexlayer login --no-browser
+  nexlayer login --profile production
+  nexlayer login --token "$CI_NEXLAYER_TOKEN"`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return fmt.Errorf("login flow not yet implemented")
+			out := cmd.OutOrStdout()
+			path, err := auth.Path()
+			if err != nil {
+				return err
+			}
+			name, _ := cmd.Flags().GetString("profile")
+			profiles, err := profile.Load()
+			if err != nil {
+				return err
+			}
+			p, err := profiles.Active(name)
+			if err != nil {
+				return err
+			}
+			if authURL == "" {
+				authURL = p.URL
+			}
+
+			var creds *auth.Credentials
+			if token != "" {
+				creds = &auth.Credentials{Token: strings.TrimSpace(token), CreatedAt: time.Now().UTC()}
+			} else {
+				if err := offline.Check("nexlayer login"); err != nil {
+					return err
+				}
+				ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+
+				flow := auth.NewDeviceFlow(authURL)
+				if c, ok := client.(interface{ Transport() http.RoundTripper }); ok {
+					flow.HTTPClient.Transport = c.Transport()
+				}
+				code, err := flow.Start(ctx)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "Your login code: %s\n\n", code.UserCode)
+				opened := false
+				if !noBrowser {
+					opened = system.OpenBrowser(code.URL()) == nil
+				}
+				if opened {
+					fmt.Fprintf(out, "Opened %s in your browser.\nIf it did not open, visit it and enter the code.\n", code.URL())
+				} else {
+					fmt.Fprintf(out, "Visit %s and enter the code.\n", code.URL())
+				}
+				fmt.Fprintln(out, "\nWaiting for approval...")
+
+				creds, err = flow.Wait(ctx, code)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := auth.Save(p.Name, creds); err != nil {
+				return err
+			}
+			who := ""
+			if creds.Email != "" {
+				who = " as " + creds.Email
+			}
+			fmt.Fprintf(out, "%s Logged in to %s%s; token saved to %s\n", ui.Symbols().Success, p.Name, who, path)
+			for _, env := range []string{auth.EnvAuthToken, auth.EnvToken} {
+				if os.Getenv(env) != "" {
+					fmt.Fprintf(out, "%s %s is set and takes precedence over the saved token\n", ui.Symbols().Warning, env)
+				}
+			}
+			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&noBrowser, "no-browser", false, "Print the login link instead of opening a browser")
+	cmd.Flags().StringVar(&token, "token", "", "Save an existing API token instead of logging in with a browser")
+	cmd.Flags().StringVar(&authURL, "auth-url", "", "Authorization server to log in with (default: the URL of the profile)")
+	_ = cmd.Flags().MarkHidden("auth-url")
+
 	return cmd
 }
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	corelogs "github.com/Nexlayer/nexlayer-cli/pkg/core/logs"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// podColors are cycled through to tell pods apart
+var podColors = []lipgloss.Color{"#00B4D8", "#2ECC71", "#F1C40F", "#E67E22", "#9B59B6", "#E84393", "#1ABC9C", "#3498DB"}
+
+// NewCommand creates a new logs command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var appID, pod, since, grep string
+	var follow, timestamps bool
+	var tail int
+
+	cmd := &cobra.Command{
+		Use:   "logs [namespace]",
+		Short: "Show or stream the logs of a deployment",
+		Long: `Show the last lines of the logs of a deployment, or follow them as they are
+written with --follow. The lines of all pods are shown together, each prefixed
+with its pod in its own color; --pod keeps those of one pod. When the stream
+drops it is reopened after a backoff and resumes after the last line shown.
+
+--since and --grep keep the lines written since a time, or matching a regular
+expression. --tail counts lines before they are filtered. With -o json the
+lines are written as JSON, one object per line when following.
+
+The namespace defaults to the last deployment started from this directory.
+
+Examples:
+  nexlayer logs my-app-ns
+  nexlayer logs -f
+  nexlayer logs my-app-ns -f --pod api --tail 20
+  nexlayer logs my-app-ns --since 10m --grep 'error|panic'
+  nexlayer logs my-app-ns -f -o json | jq .message`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			filter := corelogs.Filter{Pod: pod}
+			if since != "" {
+				if filter.Since, err = corelogs.ParseSince(since, time.Now()); err != nil {
+					return err
+				}
+			}
+			if grep != "" {
+				if filter.Grep, err = regexp.Compile(grep); err != nil {
+					return fmt.Errorf("invalid --grep: %w", err)
+				}
+			}
+			p := &printer{out: cmd.OutOrStdout(), timestamps: timestamps}
+
+			if !follow {
+				raw, err := client.GetLogs(cmd.Context(), namespace, appID, false, tail)
+				if err != nil {
+					return err
+				}
+				lines := make([]apischema.LogLine, 0, len(raw))
+				for _, r := range raw {
+					if line := corelogs.ParseLine(r); filter.Match(line) {
+						lines = append(lines, line)
+					}
+				}
+				if ui.Structured() {
+					return ui.WriteOutput(lines)
+				}
+				for _, line := range lines {
+					p.print(line)
+				}
+				return nil
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			var writeErr error
+			err = corelogs.Follow(ctx, client, namespace, appID, tail, func(line apischema.LogLine) {
+				switch {
+				case !filter.Match(line) || writeErr != nil:
+				case ui.Structured():
+					if writeErr = ui.WriteStream(line); writeErr != nil {
+						stop()
+					}
+				default:
+					p.print(line)
+				}
+			}, func(err error, wait time.Duration) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s %v; reconnecting in %s\n", ui.Symbols().Warning, err, wait)
+			})
+			if writeErr != nil {
+				return writeErr
+			}
+			if errors.Is(err, api.ErrNotStreaming) {
+				ui.RenderWarning("The server does not stream logs; showing the lines it has")
+				return nil
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Stream new lines as they are written")
+	cmd.Flags().IntVarP(&tail, "tail", "n", 100, "Number of lines to show from the end of the logs")
+	cmd.Flags().StringVar(&pod, "pod", "", "Only show the lines of this pod")
+	cmd.Flags().StringVar(&since, "since", "", "Only show lines written since a duration ago, e.g. 10m or 1d, or an RFC 3339 time")
+	cmd.Flags().StringVar(&grep, "grep", "", "Only show lines matching this regular expression")
+	cmd.Flags().StringVar(&appID, "app", "", "Application ID of the deployment (default from your profile)")
+	cmd.Flags().BoolVar(&timestamps, "timestamps", false, "Prefix lines with the time they were written")
+
+	return cmd
+}
+
+// printer writes log lines prefixed with their pod, aligned on the longest
+// pod name seen so far
+type printer struct {
+	out        io.Writer
+	timestamps bool
+	width      int
+}
+
+func (p *printer) print(line apischema.LogLine) {
+	var prefix string
+	if p.timestamps && !line.Timestamp.IsZero() {
+		prefix = line.Timestamp.Local().Format("15:04:05.000") + " "
+	}
+	if line.Pod != "" {
+		if len(line.Pod) > p.width {
+			p.width = len(line.Pod)
+		}
+		name := line.Pod + strings.Repeat(" ", p.width-len(line.Pod))
+		prefix += lipgloss.NewStyle().Foreground(podColor(line.Pod)).Render(name+" |") + " "
+	}
+	fmt.Fprintln(p.out, prefix+line.Message)
+}
+
+// podColor picks the color of a pod, the same on every run
+func podColor(pod string) lipgloss.Color {
+	h := fnv.New32a()
+	h.Write([]byte(pod))
+	return podColors[h.Sum32()%uint32(len(podColors))]
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	coremigrate "github.com/Nexlayer/nexlayer-cli/pkg/core/migrate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewCommand creates a new migrate command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var file string
+	var force bool
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "migrate [app]",
+		Short: "Run database migrations",
+		Long: `Run the migrations declared in nexlayer.yaml on the platform and wait for them.
+
+Migrations run once per release, identified by their image and command: a
+release that already succeeded is skipped unless --force is given. Logs are
+saved under .nexlayer/migrations.
+
+  migrations:
+    pod: api                      # run with the image and vars of the api pod
+    command: [npx, prisma, migrate, deploy]
+    runPolicy: before-deploy      # or manual
+
+With runPolicy before-deploy, the default, 'nexlayer deploy' runs them first
+and a failure stops the rollout. The app defaults to application.name.
+
+Examples:
+  nexlayer migrate
+  nexlayer migrate my-app --force`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Applications(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			var config schema.NexlayerYAML
+			if err := yaml.Unmarshal(data, &config); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			if config.Application.Migrations == nil {
+				return fmt.Errorf("%s has no migrations block", file)
+			}
+			if _, err := schema.ExpandServices(&config); err != nil {
+				return err
+			}
+			if err := schema.ResolveEnvFrom(&config, filepath.Dir(file)); err != nil {
+				return err
+			}
+			if len(args) > 0 {
+				config.Application.Name = args[0]
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			jsonOutput := ui.Structured()
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				out = io.Discard
+			}
+			m, err := coremigrate.Apply(ctx, client, &config, force, out)
+			if jsonOutput && m != nil {
+				if encErr := ui.WriteOutput(m); encErr != nil {
+					return encErr
+				}
+			}
+			if err != nil {
+				return err
+			}
+			if !jsonOutput {
+				fmt.Fprintf(out, "%s Migrations of %s are up to date\n", ui.Symbols().Success, config.Application.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration declaring the migrations")
+	cmd.Flags().BoolVar(&force, "force", false, "Run the migrations even if this release already succeeded")
+	cmd.Flags().DurationVar(&timeout, "timeout", coremigrate.DefaultTimeout, "How long to wait for the migrations")
+
+	return cmd
+}
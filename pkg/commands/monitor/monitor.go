@@ -0,0 +1,205 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/notify"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/quota"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates a new monitor command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var interval time.Duration
+	var once bool
+
+	cmd := &cobra.Command{
+		Use:   "monitor [namespace]",
+		Short: "Check a deployment and send notifications",
+		Long: `Check a deployment periodically and route what is found to the notifiers of
+the matching notification rules: failed deployments, TLS certificates close to
+expiry and plan limits close to exhaustion.
+
+Rules and notifiers live in the CLI configuration (~/.config/nexlayer/config.yaml):
+
+  notifications:
+    notifiers:
+      ops: {type: slack, url: https://hooks.slack.com/services/...}
+      pager: {type: webhook, url: https://example.com/hook}
+    rules:
+      - on: failure
+        notify: [ops, pager]
+      - on: cert-expiry
+        within: 14d
+        notify: [ops]
+      - on: quota
+        above: 80
+        notify: [ops]
+
+A condition is notified once and again only after it cleared in between.
+'nexlayer deploy' notifies failed deployments with the same rules.
+
+The namespace defaults to the last deployment started from this directory.
+
+Examples:
+  nexlayer monitor
+  nexlayer monitor my-app-ns --interval 15m
+  nexlayer monitor my-app-ns --once     # e.g. from cron`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := notify.Load()
+			if err != nil {
+				return err
+			}
+			engine, err := notify.NewEngine(cfg)
+			if err != nil {
+				return err
+			}
+			if engine.Empty() {
+				ui.RenderWarning("No notification rules configured; findings are only printed")
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			for {
+				check(ctx, cmd, client, engine, namespace)
+				if once {
+					return nil
+				}
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(interval):
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Minute, "Time between checks")
+	cmd.Flags().BoolVar(&once, "once", false, "Check once and exit")
+
+	return cmd
+}
+
+// check collects the events of a deployment and evaluates them
+func check(ctx context.Context, cmd *cobra.Command, client api.APIClient, engine *notify.Engine, namespace string) {
+	out := cmd.OutOrStdout()
+	stamp := time.Now().Format("15:04:05")
+
+	var events []notify.Event
+	info, err := client.GetDeploymentInfo(ctx, namespace)
+	if err != nil {
+		fmt.Fprintf(out, "%s %s Could not get deployment: %v\n", stamp, ui.Symbols().Warning, err)
+	} else {
+		status := strings.ToLower(info.Data.Status)
+		fmt.Fprintf(out, "%s status %s\n", stamp, info.Data.Status)
+		if status == "failed" || status == "error" {
+			events = append(events, notify.Event{
+				Kind:    notify.EventFailure,
+				App:     namespace,
+				Message: fmt.Sprintf("deployment %s is %s", namespace, info.Data.Status),
+			})
+		}
+
+		if host := certHost(info.Data.CustomDomain, info.Data.URL); host != "" {
+			expires, err := certExpiry(ctx, host)
+			if err != nil {
+				fmt.Fprintf(out, "%s %s Could not check the certificate of %s: %v\n", stamp, ui.Symbols().Warning, host, err)
+			} else {
+				days := time.Until(expires).Hours() / 24
+				fmt.Fprintf(out, "%s certificate of %s expires in %.0f days\n", stamp, host, days)
+				events = append(events, notify.Event{
+					Kind:     notify.EventCertExpiry,
+					App:      namespace,
+					Subject:  host,
+					DaysLeft: days,
+					Message:  fmt.Sprintf("the certificate of %s expires in %.0f days (%s)", host, days, expires.Format("2006-01-02")),
+				})
+			}
+		}
+	}
+
+	if q, err := client.GetQuota(ctx); err != nil {
+		fmt.Fprintf(out, "%s %s Could not get quota: %v\n", stamp, ui.Symbols().Warning, err)
+	} else {
+		for _, line := range quota.Lines(q.Data) {
+			pct := line.Percent()
+			if pct < 0 {
+				continue
+			}
+			events = append(events, notify.Event{
+				Kind:    notify.EventQuota,
+				App:     namespace,
+				Subject: line.Resource,
+				Percent: pct,
+				Message: fmt.Sprintf("%s at %.0f%% of the plan limit (%s of %s)", line.Resource, pct, line.Format(line.Used), line.Format(line.Limit)),
+			})
+		}
+	}
+
+	for _, ev := range events {
+		sent, err := engine.Evaluate(ctx, ev)
+		if err != nil {
+			fmt.Fprintf(out, "%s %s %v\n", stamp, ui.Symbols().Warning, err)
+		}
+		if len(sent) > 0 {
+			fmt.Fprintf(out, "%s notified %s: %s\n", stamp, strings.Join(sent, ", "), ev.Message)
+		}
+	}
+}
+
+// certHost returns the host whose certificate is checked: the custom domain,
+// or else the host of an https URL
+func certHost(customDomain, rawURL string) string {
+	if customDomain != "" {
+		return customDomain
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// certExpiry returns when the certificate served for host expires
+func certExpiry(ctx context.Context, host string) (time.Time, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 10 * time.Second},
+		// Only the expiry is read, so that expired or otherwise invalid
+		// certificates are reported rather than failing the handshake
+		Config: &tls.Config{ServerName: host, InsecureSkipVerify: true},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, "443"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer conn.Close()
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return time.Time{}, fmt.Errorf("no certificate presented")
+	}
+	return certs[0].NotAfter, nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugincmd
+
+import (
+	"fmt"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plugin"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newInitCommand creates the init subcommand
+func newInitCommand() *cobra.Command {
+	var dir, module, description string
+
+	cmd := &cobra.Command{
+		Use:   "init <name>",
+		Short: "Generate the skeleton of a new plugin in Go",
+		Long: `Generate a working plugin in Go speaking the typed protocol: a handler
+served with plugin.Serve, a cobra command reading the deployment file, tests
+and a Makefile to build, test and install it.
+
+The plugin is written to ./nexlayer-plugin-<name> unless --dir is given, as
+the module nexlayer-plugin-<name> unless --module is given; use the path of
+its repository, e.g. github.com/org/nexlayer-plugin-foo, to publish it.
+
+Examples:
+  nexlayer plugin init foo
+  nexlayer plugin init foo --module github.com/org/nexlayer-plugin-foo
+  cd nexlayer-plugin-foo && make install && nexlayer foo`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := plugin.Skeleton{Name: args[0], Module: module, Description: description}
+			if reserved(cmd.Root())(s.Name) {
+				return fmt.Errorf("%s is a built-in command; choose another plugin name", s.Name)
+			}
+			if dir == "" {
+				dir = s.Executable()
+			}
+			files, err := plugin.Scaffold(dir, s)
+			if err != nil {
+				return err
+			}
+
+			if ui.Structured() {
+				return ui.WriteOutput(map[string]interface{}{"name": s.Name, "dir": dir, "files": files})
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s Created plugin %s in %s\n", ui.Symbols().Success, s.Name, dir)
+			for _, f := range files {
+				fmt.Fprintf(out, "  %s %s\n", ui.Symbols().Bullet, f)
+			}
+			fmt.Fprintf(out, "\nNext steps:\n  cd %s\n  make test\n  make install\n  nexlayer %s\n", dir, s.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory to write the plugin to (default ./nexlayer-plugin-<name>)")
+	cmd.Flags().StringVar(&module, "module", "", "Go module path of the plugin (default nexlayer-plugin-<name>)")
+	cmd.Flags().StringVar(&description, "description", "", "Description of the plugin")
+	return cmd
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugincmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plugin"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newKeysCommand creates the keys subcommand, managing the trust store
+func newKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "keys",
+		Aliases: []string{"key"},
+		Short:   "Manage the keys trusted to sign plugins",
+		Long: `Manage the trust store at ~/.nexlayer/plugin-keys.json, the public keys
+plugins must be signed with to be installed.
+
+Keys are minisign public keys (the .pub file minisign -G writes) or cosign
+public keys (the PEM cosign.pub of cosign generate-key-pair). A key can be
+limited to the plugins of some sources with --source, e.g. github.com/org/.
+
+Examples:
+  nexlayer plugin keys add minisign.pub --name acme --source github.com/acme/
+  nexlayer plugin keys add cosign.pub
+  nexlayer plugin keys list
+  nexlayer plugin keys remove acme`,
+	}
+
+	cmd.AddCommand(
+		newKeysAddCommand(),
+		newKeysListCommand(),
+		newKeysRemoveCommand(),
+	)
+	return cmd
+}
+
+// newKeysAddCommand creates the keys add subcommand
+func newKeysAddCommand() *cobra.Command {
+	var name string
+	var sources []string
+
+	cmd := &cobra.Command{
+		Use:   "add <public-key-file>",
+		Short: "Trust a minisign or cosign public key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", args[0], err)
+			}
+			if name == "" {
+				name = strings.ToLower(strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0])))
+			}
+			key, err := plugin.Trust(name, data, sources)
+			if err != nil {
+				return err
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(key)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Trusted %s key %s (%s)\n", ui.Symbols().Success, key.Kind, key.Name, key.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Name of the key (default: the file name)")
+	cmd.Flags().StringSliceVar(&sources, "source", nil, "Only verify plugins whose source is this path or under it (repeatable)")
+	return cmd
+}
+
+// newKeysListCommand creates the keys list subcommand
+func newKeysListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List trusted keys",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys, err := plugin.TrustedKeys()
+			if err != nil {
+				return err
+			}
+			if ui.Structured() {
+				if keys == nil {
+					keys = []plugin.Key{}
+				}
+				return ui.WriteOutput(keys)
+			}
+			if len(keys) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No trusted keys. Add one with 'nexlayer plugin keys add <public-key-file>'.")
+				return nil
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("NAME", "KIND", "ID", "SOURCES")
+			for _, k := range keys {
+				scope := strings.Join(k.Sources, ", ")
+				if scope == "" {
+					scope = "any"
+				}
+				table.AddRow(k.Name, k.Kind, k.ID, scope)
+			}
+			return table.Render()
+		},
+	}
+}
+
+// newKeysRemoveCommand creates the keys remove subcommand
+func newKeysRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   "Stop trusting a key",
+		Long: `Stop trusting a key. Plugins it signed stay installed; they are
+verified again when updated.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := plugin.Distrust(args[0]); err != nil {
+				return err
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(map[string]string{"removed": args[0]})
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Removed key %s\n", ui.Symbols().Success, args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugincmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/auth"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plugin"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// annotation marks the root commands that run a plugin, holding its path
+const annotation = "nexlayer-plugin"
+
+// NewCommand creates the plugin command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "plugin",
+		Aliases: []string{"plugins"},
+		Short:   "Create, install, update, list and remove plugins",
+		Long: `Manage plugins, executables that add commands to the CLI.
+
+Plugins are installed under ~/.nexlayer/plugins and run as nexlayer <name>,
+with every argument after the name passed through. A plugin must print its
+metadata as JSON when run with --describe:
+
+  {"name": "foo", "version": "1.2.0", "description": "Do foo with deployments"}
+
+Plugins written in Go with plugin.Serve of
+github.com/Nexlayer/nexlayer-cli/pkg/core/plugin speak the typed protocol:
+they receive the parsed nexlayer.yaml of the working directory, stream
+progress, return results that --output json and yaml render, and can hook
+into init and deploy with pre-init, post-init, pre-deploy, post-deploy and
+on-failure; a failing pre- hook aborts the command. Other plugins are run
+as plain executables, with NEXLAYER_API_URL and NEXLAYER_AUTH_TOKEN of the
+active profile in their environment.
+
+Plugins must be signed with minisign or cosign by a key of the trust store,
+see 'nexlayer plugin keys', unless installed with --allow-unsigned.
+
+Examples:
+  nexlayer plugin init foo
+  nexlayer plugin keys add org.pub --source github.com/org/
+  nexlayer plugin install github.com/org/nexlayer-plugin-foo
+  nexlayer plugin install github.com/org/nexlayer-plugin-foo@v1.2.0
+  nexlayer plugin install --allow-unsigned ./bin/nexlayer-foo
+  nexlayer plugin update
+  nexlayer plugin remove foo`,
+	}
+
+	cmd.AddCommand(
+		newInitCommand(),
+		newInstallCommand(),
+		newUpdateCommand(),
+		newListCommand(),
+		newRemoveCommand(),
+		newKeysCommand(),
+	)
+	return cmd
+}
+
+// newInstallCommand creates the install subcommand
+func newInstallCommand() *cobra.Command {
+	var allowUnsigned bool
+
+	cmd := &cobra.Command{
+		Use:   "install <source>",
+		Short: "Install a plugin from a Go module, URL or file",
+		Long: `Install a plugin, replacing any installed plugin of the same name.
+
+A Go module on GitHub is installed from the binary of its latest release, or
+the release tagged with the given version, built for this platform: an asset
+named like nexlayer-plugin-foo_linux_amd64, optionally in a .tar.gz or .zip
+archive, verified against checksums.txt when the release has one. Other
+modules, and those without such a release, are built with go install.
+
+A URL or file is the plugin executable or an archive holding it.
+
+The plugin must be signed by a key added with 'nexlayer plugin keys add':
+a minisign signature in <file>.minisig, or a cosign sign-blob signature in
+<file>.sig, next to the file or URL, or as a release asset. Plugins built
+with go install are never signed; install them, and any other unsigned
+plugin, with --allow-unsigned.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			installer := plugin.NewInstaller(reserved(cmd.Root()))
+			installer.AllowUnsigned = allowUnsigned
+			p, err := installer.Install(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(p)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Installed plugin %s %s\n", ui.Symbols().Success, p.Name, p.Version)
+			printSigner(cmd, p)
+			fmt.Fprintf(cmd.OutOrStdout(), "  Run 'nexlayer %s' to use it\n", p.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&allowUnsigned, "allow-unsigned", false, "Install the plugin even if no trusted key signed it")
+	return cmd
+}
+
+// printSigner tells who signed a plugin just installed
+func printSigner(cmd *cobra.Command, p *plugin.Plugin) {
+	if p.Signer == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s Not signed by a trusted key; installed with --allow-unsigned\n", ui.Symbols().Warning)
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "  Signed by %s\n", p.Signer)
+}
+
+// newUpdateCommand creates the update subcommand
+func newUpdateCommand() *cobra.Command {
+	var allowUnsigned bool
+
+	cmd := &cobra.Command{
+		Use:   "update [name...]",
+		Short: "Update plugins, all of them by default",
+		Long: `Install plugins again from their source. Plugins installed from a Go
+module move to its latest version; the others are fetched again from the same
+URL or file. Updates are verified like installs.`,
+		ValidArgsFunction: completeNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := args
+			if len(names) == 0 {
+				plugins, err := plugin.List()
+				if err != nil {
+					return err
+				}
+				if len(plugins) == 0 {
+					return fmt.Errorf("no plugins installed")
+				}
+				for _, p := range plugins {
+					names = append(names, p.Name)
+				}
+			}
+
+			installer := plugin.NewInstaller(reserved(cmd.Root()))
+			installer.AllowUnsigned = allowUnsigned
+			out := cmd.OutOrStdout()
+			var updated []*plugin.Plugin
+			var failed int
+			for _, name := range names {
+				old, err := plugin.Get(name)
+				if err != nil {
+					return err
+				}
+				p, err := installer.Update(cmd.Context(), name)
+				if err != nil {
+					failed++
+					fmt.Fprintf(os.Stderr, "%s %s: %v\n", ui.Symbols().Error, name, err)
+					continue
+				}
+				updated = append(updated, p)
+				if ui.Structured() {
+					continue
+				}
+				if p.Version == old.Version && p.SHA256 == old.SHA256 {
+					fmt.Fprintf(out, "%s %s is up to date (%s)\n", ui.Symbols().Success, p.Name, p.Version)
+				} else {
+					fmt.Fprintf(out, "%s Updated %s from %s to %s\n", ui.Symbols().Success, p.Name, old.Version, p.Version)
+				}
+			}
+
+			if ui.Structured() {
+				if err := ui.WriteOutput(updated); err != nil {
+					return err
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d of %d plugins failed to update", failed, len(names))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&allowUnsigned, "allow-unsigned", false, "Update plugins even if no trusted key signed them")
+	return cmd
+}
+
+// newListCommand creates the list subcommand
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List installed plugins",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plugins, err := plugin.List()
+			if err != nil {
+				return err
+			}
+			if ui.Structured() {
+				if plugins == nil {
+					plugins = []plugin.Plugin{}
+				}
+				return ui.WriteOutput(plugins)
+			}
+			if len(plugins) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No plugins installed. Install one with 'nexlayer plugin install <source>'.")
+				return nil
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("NAME", "VERSION", "DESCRIPTION", "SOURCE", "SIGNED BY")
+			for _, p := range plugins {
+				signer := p.Signer
+				if signer == "" {
+					signer = "unsigned"
+				}
+				table.AddRow(p.Name, p.Version, p.Description, p.Source, signer)
+			}
+			return table.Render()
+		},
+	}
+}
+
+// newRemoveCommand creates the remove subcommand
+func newRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "remove <name>",
+		Aliases:           []string{"rm", "uninstall"},
+		Short:             "Remove an installed plugin",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := plugin.Remove(args[0]); err != nil {
+				return err
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(map[string]string{"removed": args[0]})
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Removed plugin %s\n", ui.Symbols().Success, args[0])
+			return nil
+		},
+	}
+}
+
+// AddPluginCommands adds a command to root for every installed plugin whose
+// name is not taken by a built-in command
+func AddPluginCommands(root *cobra.Command) {
+	plugins, err := plugin.List()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s Plugins not loaded: %v\n", ui.Symbols().Warning, err)
+		return
+	}
+	taken := reserved(root)
+	for _, p := range plugins {
+		if taken(p.Name) {
+			continue
+		}
+		root.AddCommand(newPluginCommand(p))
+	}
+}
+
+// newPluginCommand creates the command running an installed plugin
+func newPluginCommand(p plugin.Plugin) *cobra.Command {
+	use := p.Usage
+	if use == "" {
+		use = p.Name
+	}
+	return &cobra.Command{
+		Use:                use,
+		Short:              p.Description,
+		Annotations:        map[string]string{annotation: p.Path},
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if p.Typed() {
+				return runTyped(cmd, p, args)
+			}
+			if err := p.Check(); err != nil {
+				return err
+			}
+			c := exec.CommandContext(cmd.Context(), p.Path, args...)
+			c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+			c.Env = append(os.Environ(),
+				"NEXLAYER_API_URL="+config.GetAPIURL(),
+				auth.EnvAuthToken+"="+config.GetToken(),
+			)
+			err := c.Run()
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			if err != nil {
+				return fmt.Errorf("failed to run plugin %s: %w (reinstall it with 'nexlayer plugin install %s')", p.Name, err, p.Source)
+			}
+			return nil
+		},
+	}
+}
+
+// reserved returns whether a name is taken by a built-in command of root
+func reserved(root *cobra.Command) func(name string) bool {
+	return func(name string) bool {
+		if name == "help" {
+			return true
+		}
+		for _, c := range root.Commands() {
+			if _, ok := c.Annotations[annotation]; ok {
+				continue
+			}
+			if c.Name() == name || c.HasAlias(name) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// completeNames completes the names of installed plugins
+func completeNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	plugins, err := plugin.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, p := range plugins {
+		names = append(names, p.Name+"\t"+p.Description)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugincmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plugin"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// defaultFile is the deployment file typed plugins receive, when present
+const defaultFile = "nexlayer.yaml"
+
+// runTyped runs a plugin speaking the typed protocol. The output flags are
+// the CLI's, which renders the result; every other argument is the plugin's.
+func runTyped(cmd *cobra.Command, p plugin.Plugin, args []string) error {
+	args, format := splitOutputFlags(args)
+	if err := ui.SetOutputFormat(format); err != nil {
+		return err
+	}
+
+	req := &plugin.RunRequest{
+		Environment: environment(),
+		Args:        args,
+	}
+	if _, err := os.Stat(defaultFile); err == nil {
+		config, _, err := deployment.Load(defaultFile)
+		if err != nil {
+			ui.RenderWarning(fmt.Sprintf("Not passing %s to %s: %v", defaultFile, p.Name, err))
+		} else {
+			req.File, req.Config = defaultFile, config
+		}
+	}
+
+	result, err := p.Run(cmd.Context(), req, printProgress)
+	if err != nil {
+		return err
+	}
+	return writeResult(result)
+}
+
+// environment returns the API URL and token plugins call the API with
+func environment() plugin.Environment {
+	return plugin.Environment{APIURL: config.GetAPIURL(), Token: config.GetToken()}
+}
+
+// printProgress shows the progress of a plugin on stderr
+func printProgress(p plugin.Progress) {
+	if p.Percent > 0 {
+		fmt.Fprintf(os.Stderr, "%s %s (%d%%)\n", ui.Symbols().Bullet, p.Message, p.Percent)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s %s\n", ui.Symbols().Bullet, p.Message)
+}
+
+// writeResult writes the result of a plugin: its data for json and yaml
+// output, its message otherwise
+func writeResult(r *plugin.Result) error {
+	if ui.Structured() {
+		if len(r.Data) > 0 {
+			return ui.WriteOutput(r.Data)
+		}
+		return ui.WriteOutput(map[string]string{"message": r.Message})
+	}
+	if r.Message != "" {
+		fmt.Println(r.Message)
+	} else if len(r.Data) > 0 {
+		return ui.WriteOutput(r.Data)
+	}
+	return nil
+}
+
+// splitOutputFlags takes --json and -o/--output out of the arguments of a
+// plugin and returns the output format they select
+func splitOutputFlags(args []string) ([]string, string) {
+	var rest []string
+	format := ui.OutputText
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return append(rest, args[i:]...), format
+		case arg == "--json":
+			format = ui.OutputJSON
+		case (arg == "-o" || arg == "--output") && i+1 < len(args):
+			format = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--output="):
+			format = strings.TrimPrefix(arg, "--output=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, format
+}
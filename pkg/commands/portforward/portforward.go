@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/forward"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the port-forward command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var address string
+
+	cmd := &cobra.Command{
+		Use:   "port-forward <namespace> <pod> [local:]port",
+		Short: "Reach a port of a deployed pod from this machine",
+		Long: `Forward a local port to a port of a deployed pod through the Nexlayer API,
+so that pods without a public endpoint, such as databases and internal
+services, can be reached with local tools while developing and debugging.
+
+The port is the targetPort of one of the pod's servicePorts. The local port
+is the same unless given before a colon; 0 picks a free one. Connections are
+forwarded until Ctrl+C is pressed.
+
+Examples:
+  nexlayer port-forward my-app-ns db 5432
+  nexlayer port-forward my-app-ns db 15432:5432
+  nexlayer port-forward my-app-ns cache 6379 --address 0.0.0.0`,
+		Args:              cobra.ExactArgs(3),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, pod := args[0], args[1]
+			local, remote, err := forward.ParsePorts(args[2])
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			out := cmd.OutOrStdout()
+			f := &forward.Forwarder{
+				Local: net.JoinHostPort(address, strconv.Itoa(local)),
+				Dial: func(ctx context.Context) (io.ReadWriteCloser, error) {
+					return client.PortForward(ctx, namespace, pod, remote)
+				},
+				OnConn: func(ev forward.Event) {
+					if ev.Err != nil {
+						fmt.Fprintf(out, "%s %s %v\n", ui.Symbols().Error, ev.Client, ev.Err)
+						return
+					}
+					fmt.Fprintf(out, "%s %s closed (%d bytes)\n", ui.Symbols().Bullet, ev.Client, ev.Bytes)
+				},
+			}
+			if err := f.Listen(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(out, "%s Forwarding %s -> %s/%s:%d\n", ui.Symbols().Success, f.Addr(), namespace, pod, remote)
+			fmt.Fprintln(out, "Press Ctrl+C to stop")
+			err = f.Serve(ctx)
+			fmt.Fprintln(out, "\nStopped forwarding")
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "127.0.0.1", "Local address to listen on")
+
+	return cmd
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package promote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/migrate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/policy"
+	corepromote "github.com/Nexlayer/nexlayer-cli/pkg/core/promote"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates a new promote command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var file, from, to, overrideReason string
+	var yes, dryRun bool
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "promote --from <env> --to <env> [applicationID]",
+		Short: "Promote the release of one environment to another",
+		Long: `Deploy exactly what runs in one environment to another: the configuration
+revision of the source deployment, with each image pinned to the digest that
+runs there, and the vars of the target environment.
+
+Environments are declared in nexlayer.yaml:
+
+  environments:
+    staging:
+      namespace: shop-staging
+    prod:
+      namespace: shop
+      appId: shop-prod            # optional, as 'nexlayer deploy <applicationID>'
+      vars:
+        LOG_LEVEL: warn           # values that differ from the source
+
+The deploy policy applies: a promotion during a freeze or outside the deploy
+windows needs --override-freeze with a reason. Migrations with runPolicy
+before-deploy run first. Each promotion is recorded in .nexlayer/promotions.log;
+list them with 'nexlayer promote history'.
+
+Examples:
+  nexlayer promote --from staging --to prod --dry-run
+  nexlayer promote --from staging --to prod --yes`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if from == "" || to == "" {
+				return fmt.Errorf("--from and --to are required")
+			}
+			base, _, err := deployment.Load(file)
+			if err != nil {
+				return err
+			}
+			if err := schema.ResolveConfigFiles(base, filepath.Dir(file)); err != nil {
+				return err
+			}
+			if err := schema.ResolveEnvFrom(base, filepath.Dir(file)); err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			plan, err := corepromote.Prepare(ctx, client, base, from, to)
+			if err != nil {
+				return err
+			}
+			appID := plan.Target.AppID
+			if len(args) > 0 {
+				appID = args[0]
+			}
+
+			out := cmd.OutOrStdout()
+			if err := printPlan(out, plan); err != nil {
+				return err
+			}
+			if dryRun {
+				fmt.Fprintf(out, "\n%s Promotion is ready (dry run, nothing deployed)\n", ui.Symbols().Success)
+				return nil
+			}
+
+			v, err := policy.Enforce(policy.ActionPromote, plan.Config, overrideReason)
+			if err != nil {
+				return err
+			}
+			reason := ""
+			if v != nil {
+				reason = strings.TrimSpace(overrideReason)
+				ui.RenderWarning(fmt.Sprintf("Overriding deploy policy: %s (recorded in %s)", v, policy.AuditLog))
+			}
+			if !yes {
+				prompt := promptui.Prompt{
+					Label:     fmt.Sprintf("Promote %s to %s (%s)", from, to, plan.Target.Namespace),
+					IsConfirm: true,
+				}
+				if result, err := prompt.Run(); err != nil || strings.ToLower(result) != "y" {
+					return fmt.Errorf("promotion cancelled")
+				}
+			}
+
+			if m := plan.Config.Application.Migrations; m != nil && m.Policy() == schema.MigrationsBeforeDeploy {
+				fmt.Fprintln(out, "\nRunning migrations...")
+				mctx, cancel := context.WithTimeout(ctx, migrate.DefaultTimeout)
+				_, err := migrate.Apply(mctx, client, plan.Config, false, out)
+				cancel()
+				if err != nil {
+					return fmt.Errorf("promotion aborted: %w", err)
+				}
+			}
+
+			submit, cleanup, err := deployment.WriteTemp(plan.Config)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+			resp, err := client.StartDeployment(ctx, appID, submit)
+			if err != nil {
+				return fmt.Errorf("failed to start deployment: %w", err)
+			}
+			namespace := resp.Data.Namespace
+			if namespace == "" {
+				namespace = plan.Target.Namespace
+			}
+			fmt.Fprintf(out, "\n%s Promoting %s to %s in namespace %s\n", ui.Symbols().Success, from, to, namespace)
+
+			entry := corepromote.Entry{
+				App:       plan.Config.Application.Name,
+				From:      from,
+				To:        to,
+				Namespace: namespace,
+				Images:    make(map[string]string),
+				Status:    deployment.StatusPending,
+				Reason:    reason,
+			}
+			for _, c := range plan.Images {
+				entry.Images[c.Pod] = c.Image
+			}
+
+			wctx, cancel := context.WithTimeout(ctx, timeout)
+			final, waitErr := deployment.Wait(wctx, client, namespace, 2*time.Second, 15*time.Second, nil)
+			cancel()
+			if final != nil {
+				entry.Status = final.Status
+			}
+			if err := corepromote.Record(entry); err != nil {
+				ui.RenderWarning(fmt.Sprintf("Could not record the promotion: %v", err))
+			}
+
+			switch {
+			case errors.Is(waitErr, context.DeadlineExceeded):
+				ui.RenderWarning(fmt.Sprintf("%s did not settle within %s; check it with 'nexlayer info %s'", namespace, timeout, namespace))
+				return nil
+			case waitErr != nil:
+				return waitErr
+			case !deployment.Succeeded(*final):
+				return fmt.Errorf("promotion to %s failed: deployment is %s", to, final.Status)
+			}
+			fmt.Fprintf(out, "%s %s now runs the release of %s\n", ui.Symbols().Success, to, from)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration declaring the environments")
+	cmd.Flags().StringVar(&from, "from", "", "Environment to take the release from")
+	cmd.Flags().StringVar(&to, "to", "", "Environment to deploy the release to")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Promote without asking for confirmation")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be promoted without deploying")
+	cmd.Flags().StringVar(&overrideReason, "override-freeze", "", "Promote despite the deploy policy; the reason is recorded in the audit log")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait for the target deployment")
+
+	cmd.AddCommand(newHistoryCommand())
+
+	return cmd
+}
+
+func newHistoryCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history",
+		Short: "List the promotions made from this project",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := corepromote.History()
+			if err != nil {
+				return err
+			}
+			if len(entries) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No promotions recorded")
+				return nil
+			}
+			table := ui.NewTable()
+			table.AddHeader("TIME", "USER", "APP", "FROM", "TO", "STATUS")
+			for i := len(entries) - 1; i >= 0; i-- {
+				e := entries[i]
+				table.AddRow(e.Time.Local().Format("2006-01-02 15:04"), e.User, e.App, e.From, e.To, e.Status)
+			}
+			return table.Render()
+		},
+	}
+}
+
+// printPlan shows the images the promotion deploys
+func printPlan(out io.Writer, plan *corepromote.Plan) error {
+	fmt.Fprintf(out, "Promoting %s to %s (%s)\n\n", plan.From, plan.To, plan.Target.Namespace)
+	table := ui.NewTable()
+	table.AddHeader("POD", "CURRENT", "PROMOTED")
+	for _, c := range plan.Images {
+		current := c.Current
+		switch {
+		case current == "":
+			current = "(new)"
+		case !c.Changed():
+			current = "(unchanged)"
+		}
+		table.AddRow(c.Pod, current, c.Image)
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+	if len(plan.Unpinned) > 0 {
+		ui.RenderWarning(fmt.Sprintf("No digest is known for %s; their tags are promoted as is and may resolve to other images",
+			strings.Join(plan.Unpinned, ", ")))
+	}
+	return nil
+}
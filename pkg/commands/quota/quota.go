@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package quota
+
+import (
+	"fmt"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	corequota "github.com/Nexlayer/nexlayer-cli/pkg/core/quota"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// warnPercent is the usage at which a resource is highlighted
+const warnPercent = 80
+
+// NewCommand creates a new quota command
+func NewCommand(client api.APIClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quota",
+		Short: "Show plan limits and current usage",
+		Long: `Show the limits of your plan and how much of each you are using:
+apps, pods, storage and bandwidth.
+
+'nexlayer deploy' also warns when a deployment would exceed these limits.
+
+Examples:
+  nexlayer quota
+  nexlayer quota --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := client.GetQuota(cmd.Context())
+			if err != nil {
+				return fmt.Errorf("failed to get quota: %w", err)
+			}
+			q := resp.Data
+			lines := corequota.Lines(q)
+
+			out := cmd.OutOrStdout()
+			if ui.Structured() {
+				return ui.WriteOutput(map[string]interface{}{"plan": q.Plan, "resources": lines})
+			}
+
+			fmt.Fprintf(out, "Plan: %s\n", q.Plan)
+			table := ui.NewTable()
+			table.AddHeader("RESOURCE", "USED", "LIMIT", "USAGE")
+			var near []string
+			for _, l := range lines {
+				limit, usage := "unlimited", "-"
+				if p := l.Percent(); p >= 0 {
+					limit, usage = l.Format(l.Limit), fmt.Sprintf("%.0f%%", p)
+					if p >= warnPercent {
+						near = append(near, l.Resource)
+					}
+				}
+				table.AddRow(l.Resource, l.Format(l.Used), limit, usage)
+			}
+			if err := table.Render(); err != nil {
+				return err
+			}
+			for _, r := range near {
+				fmt.Fprintf(out, "%s %s is at or above %d%% of your plan limit\n", ui.Symbols().Warning, r, warnPercent)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package regions
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewCommand creates a new regions command
+func NewCommand(client api.APIClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "regions",
+		Short: "Show where an application runs",
+		Long: `Inspect applications deployed to several regions. Regions are declared in
+nexlayer.yaml, with overrides for pods that differ per region:
+
+  regions:
+    primary: us-east
+    replicas: [eu-west, ap-south]
+    overrides:
+      eu-west:
+        pods:
+          api:
+            vars:
+              - key: STORAGE_BUCKET
+                value: assets-eu
+
+'nexlayer deploy' rolls the application out to every region.`,
+	}
+
+	cmd.AddCommand(newStatusCommand(client))
+	cmd.AddCommand(newShowCommand())
+
+	return cmd
+}
+
+func newStatusCommand(client api.APIClient) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [namespace]",
+		Short: "Show the status of a deployment in each region",
+		Long: `Show the status of a deployment in each of its regions.
+
+The namespace defaults to the last deployment started from this directory.`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			resp, err := client.GetRegions(cmd.Context(), namespace)
+			if err != nil {
+				return fmt.Errorf("failed to get regions: %w", err)
+			}
+			return printRegions(cmd, resp.Data)
+		},
+	}
+}
+
+func newShowCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "show <region>",
+		Short: "Print the configuration deployed to a region",
+		Long: `Print nexlayer.yaml as deployed to a region, with the region's overrides applied.
+
+Examples:
+  nexlayer regions show eu-west
+  nexlayer regions show ap-south -f deploy/nexlayer.yaml`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			var config schema.NexlayerYAML
+			if err := yaml.Unmarshal(data, &config); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			regional, err := schema.ForRegion(&config, args[0])
+			if err != nil {
+				return err
+			}
+			regional.Application.Regions = nil
+
+			if ui.Structured() {
+				return ui.WriteOutput(regional)
+			}
+			enc := yaml.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent(2)
+			if err := enc.Encode(regional); err != nil {
+				return err
+			}
+			return enc.Close()
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration declaring the regions")
+
+	return cmd
+}
+
+// printRegions renders region statuses as a table or JSON
+func printRegions(cmd *cobra.Command, regions []apischema.RegionStatus) error {
+	if ui.Structured() {
+		if regions == nil {
+			regions = []apischema.RegionStatus{}
+		}
+		return ui.WriteOutput(regions)
+	}
+	if len(regions) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "The deployment runs in a single region")
+		return nil
+	}
+
+	table := ui.NewTable()
+	table.AddHeader("REGION", "ROLE", "STATUS", "PODS", "URL")
+	for _, r := range regions {
+		role := "replica"
+		if r.Primary {
+			role = "primary"
+		}
+		table.AddRow(r.Region, role, r.Status, fmt.Sprintf("%d/%d", r.ReadyPods, r.TotalPods), r.URL)
+	}
+	return table.Render()
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/history"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/policy"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// NewHistoryCommand creates a new history command
+func NewHistoryCommand() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "history [app]",
+		Short: "List the deployed revisions of an application",
+		Long: `List the revisions of an application deployed from this machine, newest first,
+with how each deployment went. Revisions are recorded by 'nexlayer deploy' in
+~/.nexlayer/history/<app> and can be deployed again with 'nexlayer rollback'.
+
+The application defaults to the one named in --file.
+
+Examples:
+  nexlayer history
+  nexlayer history my-app --json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := deployment.ResolveApp(args, file)
+			if err != nil {
+				return err
+			}
+			revisions, err := history.List(app)
+			if err != nil {
+				return err
+			}
+			if ui.Structured() {
+				if revisions == nil {
+					revisions = []history.Revision{}
+				}
+				return ui.WriteOutput(revisions)
+			}
+			if len(revisions) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No revisions of %s recorded\n", app)
+				return nil
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("REVISION", "DEPLOYED", "STATUS", "NAMESPACE", "SOURCE")
+			for _, r := range revisions {
+				source := r.Source
+				if r.RollbackOf != 0 {
+					source = fmt.Sprintf("rollback to %d", r.RollbackOf)
+				}
+				table.AddRow(strconv.Itoa(r.ID), r.DeployedAt.Local().Format("2006-01-02 15:04"), r.Status, r.Namespace, source)
+			}
+			return table.Render()
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration naming the application")
+
+	return cmd
+}
+
+// NewCommand creates a new rollback command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var file, appName, appID, overrideReason string
+	var yes bool
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "rollback [revision]",
+		Short: "Redeploy a previous revision of an application",
+		Long: `Redeploy the configuration of a previous revision, as listed by 'nexlayer
+history', and wait for it to become healthy. Without a revision, the newest
+healthy revision before the current one is deployed. The rollback is recorded
+as a new revision.
+
+Only the configuration is rolled back; volume data is kept. Use 'nexlayer
+snapshot restore' to roll back data as well.
+
+Examples:
+  nexlayer rollback
+  nexlayer rollback 3 --yes
+  nexlayer rollback --app-name my-app`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var names []string
+			if appName != "" {
+				names = []string{appName}
+			}
+			app, err := deployment.ResolveApp(names, file)
+			if err != nil {
+				return err
+			}
+			target, err := resolveRevision(app, args)
+			if err != nil {
+				return err
+			}
+			config, err := target.Parse()
+			if err != nil {
+				return err
+			}
+			if v, err := policy.Enforce(policy.ActionRollback, config, overrideReason); err != nil {
+				return err
+			} else if v != nil {
+				ui.RenderWarning(fmt.Sprintf("Overriding deploy policy: %s (recorded in %s)", v, policy.AuditLog))
+			}
+
+			if !yes {
+				label := fmt.Sprintf("Roll %s back to revision %d (%s)?", app, target.ID, target.DeployedAt.Local().Format("2006-01-02 15:04"))
+				prompt := promptui.Prompt{Label: label, IsConfirm: true}
+				if result, err := prompt.Run(); err != nil || strings.ToLower(result) != "y" {
+					return fmt.Errorf("rollback cancelled")
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			ctx := cmd.Context()
+			submitFile, cleanup, err := deployment.WriteTemp(config)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+			resp, err := client.StartDeployment(ctx, appID, submitFile)
+			if err != nil {
+				return fmt.Errorf("failed to redeploy revision %d: %w", target.ID, err)
+			}
+			namespace := resp.Data.Namespace
+			if namespace == "" {
+				namespace = target.Namespace
+			}
+			fmt.Fprintf(out, "%s Redeploying revision %d of %s to %s\n", ui.Symbols().Success, target.ID, app, namespace)
+
+			rev := &history.Revision{
+				Application: app,
+				Namespace:   namespace,
+				URL:         resp.Data.URL,
+				Source:      target.Source,
+				RollbackOf:  target.ID,
+				Config:      target.Config,
+			}
+			if err := history.Record(rev); err != nil {
+				ui.RenderWarning(fmt.Sprintf("Could not record the rollback in the deployment history: %v", err))
+				rev = nil
+			}
+
+			wctx, cancel := context.WithTimeout(ctx, timeout)
+			dep, err := deployment.Wait(wctx, client, namespace, 2*time.Second, 15*time.Second, nil)
+			cancel()
+			switch {
+			case errors.Is(err, context.DeadlineExceeded):
+				setStatus(rev, history.StatusFailed)
+				return fmt.Errorf("deployment did not become healthy within %s; check it with 'nexlayer info %s'", timeout, namespace)
+			case err != nil:
+				return err
+			case !deployment.Succeeded(*dep):
+				setStatus(rev, history.StatusFailed)
+				return fmt.Errorf("rollback to revision %d failed with status %s; check the logs with 'nexlayer logs %s'", target.ID, dep.Status, namespace)
+			}
+			setStatus(rev, history.StatusHealthy)
+
+			fmt.Fprintf(out, "%s %s rolled back to revision %d\n", ui.Symbols().Success, app, target.ID)
+			if resp.Data.URL != "" {
+				fmt.Fprintf(out, "URL: %s\n", resp.Data.URL)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration naming the application")
+	cmd.Flags().StringVar(&appName, "app-name", "", "Application to roll back (default the one named in --file)")
+	cmd.Flags().StringVar(&appID, "app", "", "Application ID to redeploy to (default from your profile)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Roll back without asking for confirmation")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait for the redeployment to become healthy")
+	cmd.Flags().StringVar(&overrideReason, "override-freeze", "", "Roll back during a freeze or outside the deploy windows, recording this reason")
+
+	return cmd
+}
+
+// resolveRevision returns the revision argument, or the revision before the
+// current one
+func resolveRevision(app string, args []string) (*history.Revision, error) {
+	if len(args) == 0 {
+		return history.Previous(app)
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(args[0], "#"))
+	if err != nil || id < 1 {
+		return nil, fmt.Errorf("invalid revision %q; revisions are numbered as listed by 'nexlayer history'", args[0])
+	}
+	return history.Load(app, id)
+}
+
+// setStatus records the outcome of the rollback when it was recorded
+func setStatus(rev *history.Revision, status string) {
+	if rev == nil {
+		return
+	}
+	if err := history.SetStatus(rev, status); err != nil {
+		ui.RenderWarning(fmt.Sprintf("Could not record the outcome of revision %d: %v", rev.ID, err))
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package scale
+
+import (
+	"fmt"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the scale command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var replicas int
+
+	cmd := &cobra.Command{
+		Use:   "scale <namespace> <pod>",
+		Short: "Change the number of replicas of a running pod",
+		Long: `Change the number of replicas of a pod of a live deployment, without
+deploying. 0 stops the pod until it is scaled up again.
+
+The change lasts until the next deployment, which runs the replicas or the
+autoscale minimum set in nexlayer.yaml:
+
+  pods:
+    - name: api
+      replicas: 3
+    - name: worker
+      autoscale: {min: 1, max: 10, targetCPU: 70}
+
+Autoscaled pods are scaled back within their bounds as their load changes.
+
+Examples:
+  nexlayer scale my-app-ns api --replicas 5
+  nexlayer scale my-app-ns worker --replicas 0`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("replicas") {
+				return fmt.Errorf("set the number of replicas with --replicas")
+			}
+			if replicas < 0 {
+				return fmt.Errorf("replicas must not be negative, got %d", replicas)
+			}
+			namespace, pod := args[0], args[1]
+
+			resp, err := client.ScalePod(cmd.Context(), namespace, pod, replicas)
+			if err != nil {
+				return fmt.Errorf("failed to scale pod %s: %w", pod, err)
+			}
+			scale := resp.Data
+			if ui.Structured() {
+				return ui.WriteOutput(scale)
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s Scaled %s to %d replicas (%d ready)\n", ui.Symbols().Success, scale.Pod, scale.Replicas, scale.ReadyReplicas)
+			if scale.MaxReplicas > 0 {
+				fmt.Fprintf(out, "%s %s is autoscaled between %d and %d replicas and may be scaled again as its load changes\n",
+					ui.Symbols().Warning, scale.Pod, scale.MinReplicas, scale.MaxReplicas)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&replicas, "replicas", 0, "Number of replicas to run")
+
+	return cmd
+}
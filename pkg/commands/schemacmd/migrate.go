@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schemacmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+// MigrateResult is what schema migrate writes with --output json or yaml
+type MigrateResult struct {
+	File    string   `json:"file"`
+	Version int      `json:"version"`
+	Steps   []string `json:"steps"`
+	Diff    string   `json:"diff,omitempty"`
+	Written bool     `json:"written"`
+}
+
+// newMigrateCommand creates the schema migrate command
+func newMigrateCommand() *cobra.Command {
+	var write bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate [file]",
+		Short: "Upgrade a deployment file to the current schema version",
+		Long: `Upgrade a deployment file written for an older schema to the current one and
+show the changes as a diff. Nothing is written unless --write is given; the
+comments of the file are kept. The file defaults to the deployment file of
+the current directory.
+
+The version of a file is its schemaVersion. Files without one are current,
+except those in the template format, which declared pods under
+application.template with their image in tag:
+
+  version 0  application.template, pod tag, privateTag and exposeHttp
+  version 1  servicePorts as plain numbers, volume and secret mountPath
+  version 2  the current format
+
+Deploy, validate and the other commands migrate older files in memory, so
+migrating the file itself is only needed to edit it in the current format.
+
+Examples:
+  nexlayer schema migrate
+  nexlayer schema migrate nexlayer.yaml --write`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := ""
+			if len(args) > 0 {
+				file = args[0]
+			} else {
+				found, err := deployment.FindFile()
+				if err != nil {
+					return err
+				}
+				file = found
+			}
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			migrated, applied, err := schema.MigrateDocument(data)
+			if err != nil {
+				return err
+			}
+			result := MigrateResult{File: file, Version: schema.CurrentSchemaVersion, Steps: applied}
+			if result.Steps == nil {
+				result.Steps = []string{}
+			}
+			if len(applied) > 0 {
+				if result.Diff, err = unifiedDiff(file, data, migrated); err != nil {
+					return err
+				}
+				if write {
+					info, err := os.Stat(file)
+					if err != nil {
+						return err
+					}
+					if err := os.WriteFile(file, migrated, info.Mode().Perm()); err != nil {
+						return fmt.Errorf("failed to write %s: %w", file, err)
+					}
+					result.Written = true
+				}
+			}
+
+			if ui.Structured() {
+				return ui.WriteOutput(result)
+			}
+			render(cmd.OutOrStdout(), result)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&write, "write", false, "Rewrite the file instead of only showing the changes")
+
+	return cmd
+}
+
+// unifiedDiff returns the changes between the file and its migrated contents
+func unifiedDiff(file string, before, after []byte) (string, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: file,
+		ToFile:   file + " (migrated)",
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s: %w", file, err)
+	}
+	return diff, nil
+}
+
+// render prints the steps applied and the diff
+func render(w io.Writer, result MigrateResult) {
+	if len(result.Steps) == 0 {
+		fmt.Fprintf(w, "%s %s already uses schema version %d\n", ui.Symbols().Success, result.File, result.Version)
+		return
+	}
+	fmt.Fprint(w, result.Diff)
+	fmt.Fprintln(w)
+	for _, step := range result.Steps {
+		fmt.Fprintf(w, "  %s\n", step)
+	}
+	if result.Written {
+		fmt.Fprintf(w, "%s Migrated %s to schema version %d\n", ui.Symbols().Success, result.File, result.Version)
+	} else {
+		fmt.Fprintf(w, "%s %s uses an older schema; rerun with --write to apply these changes\n", ui.Symbols().Warning, result.File)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schemacmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the schema command
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Export the schema of nexlayer.yaml or migrate files to it",
+	}
+	cmd.AddCommand(newExportCommand(), newMigrateCommand())
+	return cmd
+}
+
+// newExportCommand creates the schema export command
+func newExportCommand() *cobra.Command {
+	var format, output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write the JSON Schema of nexlayer.yaml for editors",
+		Long: `Write the JSON Schema of nexlayer.yaml, which YAML plugins of VS Code,
+IntelliJ and other editors use to validate and autocomplete the file.
+
+Files written by init, convert and config start with a comment that points
+editors at the published schema:
+
+  ` + schema.LanguageServerHeader + `
+Export the schema to use it offline or pin it to the version of your CLI.
+
+Examples:
+  nexlayer schema export
+  nexlayer schema export --out-file .vscode/nexlayer.schema.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "jsonschema" {
+				return fmt.Errorf("unsupported format %q (use jsonschema)", format)
+			}
+			content, err := schema.JSONSchema()
+			if err != nil {
+				return err
+			}
+			if output == "-" {
+				_, err := cmd.OutOrStdout().Write(content)
+				return err
+			}
+			if err := os.WriteFile(output, content, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Wrote the schema to %s\n", ui.Symbols().Success, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "jsonschema", "Schema format (jsonschema)")
+	cmd.Flags().StringVar(&output, "out-file", "-", "File to write, or - for stdout")
+
+	return cmd
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package secrets
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// options are the flags shared by the secrets commands
+type options struct {
+	file    string
+	appName string
+}
+
+// NewCommand creates a new secrets command
+func NewCommand(client api.APIClient) *cobra.Command {
+	opts := &options{}
+
+	cmd := &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage the secret values of an application",
+		Long: `Store secret values for an application on the platform and refer to them from
+the vars of nexlayer.yaml instead of writing them in the file:
+
+  vars:
+    - key: DATABASE_PASSWORD
+      value: <% SECRET:DB_PASSWORD %>
+
+References are resolved by the platform when a deployment starts, so values
+never leave it; 'nexlayer deploy' stops when a referenced secret is not set.
+Running deployments keep the values they started with until redeployed.
+
+The application defaults to the one named in --file.
+
+Examples:
+  nexlayer secrets set DB_PASSWORD
+  nexlayer secrets set STRIPE_KEY --from-file stripe.key
+  echo -n "$TOKEN" | nexlayer secrets set API_TOKEN
+  nexlayer secrets list
+  nexlayer secrets rm OLD_KEY`,
+	}
+
+	cmd.PersistentFlags().StringVarP(&opts.file, "file", "f", "nexlayer.yaml", "Configuration naming the application")
+	cmd.PersistentFlags().StringVar(&opts.appName, "app-name", "", "Application whose secrets to manage (default the one named in --file)")
+
+	cmd.AddCommand(newSetCommand(client, opts))
+	cmd.AddCommand(newListCommand(client, opts))
+	cmd.AddCommand(newRemoveCommand(client, opts))
+
+	return cmd
+}
+
+func newSetCommand(client api.APIClient, opts *options) *cobra.Command {
+	var fromFile string
+
+	cmd := &cobra.Command{
+		Use:   "set <name> [value]",
+		Short: "Set the value of a secret",
+		Long: `Set the value of a secret, replacing any previous one. The value is taken from
+the argument, --from-file, standard input when it is not a terminal, or
+prompted for without echoing it. Values given as arguments end up in your
+shell history.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !schema.ValidSecretName(name) {
+				return fmt.Errorf("invalid secret name %q: use letters, digits and underscores, not starting with a digit", name)
+			}
+			app, err := opts.app()
+			if err != nil {
+				return err
+			}
+			value, err := readValue(cmd, name, args[1:], fromFile)
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				return fmt.Errorf("the value of %s is empty", name)
+			}
+			if err := client.SetSecret(cmd.Context(), app, name, value); err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s Set %s for %s\n", ui.Symbols().Success, name, app)
+			fmt.Fprintf(out, "Refer to it in vars as %s; running deployments pick it up when redeployed\n", schema.SecretRef(name))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "Read the value from a file")
+
+	return cmd
+}
+
+func newListCommand(client api.APIClient, opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the secrets of an application",
+		Long: `List the names of the secrets of an application, when each was last set and
+whether --file refers to it. Values are never shown. Secrets --file refers to
+that are not set are listed as missing.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := opts.app()
+			if err != nil {
+				return err
+			}
+			resp, err := client.ListSecrets(cmd.Context(), app)
+			if err != nil {
+				return err
+			}
+			stored := resp.Data
+			sort.Slice(stored, func(i, j int) bool { return stored[i].Name < stored[j].Name })
+			refs := opts.refs()
+
+			if ui.Structured() {
+				if stored == nil {
+					stored = []apischema.StoredSecret{}
+				}
+				return ui.WriteOutput(stored)
+			}
+
+			missing := make(map[string]bool, len(refs))
+			for name := range refs {
+				missing[name] = true
+			}
+			table := ui.NewTable()
+			table.AddHeader("NAME", "UPDATED", "REFERENCED")
+			for _, s := range stored {
+				delete(missing, s.Name)
+				table.AddRow(s.Name, s.UpdatedAt.Local().Format("2006-01-02 15:04"), yesNo(refs[s.Name]))
+			}
+			for _, name := range sortedKeys(missing) {
+				table.AddRow(name, "missing", "yes")
+			}
+			if len(stored) == 0 && len(missing) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No secrets set for %s\n", app)
+				return nil
+			}
+			return table.Render()
+		},
+	}
+}
+
+func newRemoveCommand(client api.APIClient, opts *options) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:     "rm <name>...",
+		Aliases: []string{"remove", "delete"},
+		Short:   "Remove secrets",
+		Long: `Remove secrets of an application. Running deployments keep their values, but
+deploying a configuration that still refers to a removed secret fails.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := opts.app()
+			if err != nil {
+				return err
+			}
+			refs := opts.refs()
+			for _, name := range args {
+				if refs[name] {
+					ui.RenderWarning(fmt.Sprintf("%s refers to %s; deploying it will fail until the secret is set again", opts.file, name))
+				}
+			}
+			if !yes {
+				prompt := promptui.Prompt{Label: fmt.Sprintf("Remove %s from %s", strings.Join(args, ", "), app), IsConfirm: true}
+				if result, err := prompt.Run(); err != nil || strings.ToLower(result) != "y" {
+					return fmt.Errorf("removal cancelled")
+				}
+			}
+			for _, name := range args {
+				if err := client.DeleteSecret(cmd.Context(), app, name); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s Removed %s from %s\n", ui.Symbols().Success, name, app)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Remove without asking for confirmation")
+
+	return cmd
+}
+
+// app returns --app-name or the application named in --file
+func (o *options) app() (string, error) {
+	if o.appName != "" {
+		return o.appName, nil
+	}
+	config, _, err := deployment.Load(o.file)
+	if err != nil {
+		return "", fmt.Errorf("no --app-name given: %w", err)
+	}
+	if config.Application.Name == "" {
+		return "", fmt.Errorf("no --app-name given and %s does not name an application", o.file)
+	}
+	return config.Application.Name, nil
+}
+
+// refs returns the secrets --file refers to, or none when it cannot be read
+func (o *options) refs() map[string]bool {
+	refs := make(map[string]bool)
+	config, _, err := deployment.Load(o.file)
+	if err != nil {
+		return refs
+	}
+	if o.appName != "" && config.Application.Name != o.appName {
+		return refs
+	}
+	for _, name := range schema.SecretRefs(config) {
+		refs[name] = true
+	}
+	return refs
+}
+
+// readValue returns the value of a secret from args, file, standard input
+// or a prompt
+func readValue(cmd *cobra.Command, name string, args []string, file string) (string, error) {
+	switch {
+	case len(args) > 0 && file != "":
+		return "", fmt.Errorf("give the value as an argument or with --from-file, not both")
+	case len(args) > 0:
+		return args[0], nil
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		return string(data), nil
+	}
+
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice == 0 {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", fmt.Errorf("failed to read the value from standard input: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	prompt := promptui.Prompt{Label: fmt.Sprintf("Value of %s", name), Mask: '*'}
+	value, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("no value given")
+	}
+	return value, nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package seed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	coremigrate "github.com/Nexlayer/nexlayer-cli/pkg/core/migrate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	coreseed "github.com/Nexlayer/nexlayer-cli/pkg/core/seed"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewCommand creates a new seed command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var file, pod string
+	var force bool
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "seed [app]",
+		Short: "Load seed data into databases",
+		Long: `Load the seed data declared on pods in nexlayer.yaml and wait for it.
+
+  pods:
+    - name: postgres
+      ...
+      seed:
+        files: [db/schema.sql, db/fixtures.sql]
+        runPolicy: first-boot     # or manual
+
+Files are loaded in order with the client of the database: psql, mysql,
+mongosh or redis-cli. For other pods, or to use your own tooling, set a
+command instead; it runs with the pod's image and vars and the files under
+/seed. Seeds can also be declared on application.services.
+
+Each seed runs once: the platform records its marker, seed-<pod>, and skips
+it afterwards so redeploys never seed production data again. Set seed.marker
+to a new value, or pass --force, to seed again. With runPolicy first-boot, the
+default, 'nexlayer deploy' seeds the pod once the deployment is up. The app
+defaults to application.name.
+
+Examples:
+  nexlayer seed
+  nexlayer seed my-app --pod postgres --force`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Applications(client),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			var config schema.NexlayerYAML
+			if err := yaml.Unmarshal(data, &config); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			if _, err := schema.ExpandServices(&config); err != nil {
+				return err
+			}
+			if err := schema.ResolveEnvFrom(&config, filepath.Dir(file)); err != nil {
+				return err
+			}
+			if len(args) > 0 {
+				config.Application.Name = args[0]
+			}
+
+			pods := coreseed.Pods(&config, "")
+			if pod != "" {
+				var selected []schema.Pod
+				for _, p := range pods {
+					if p.Name == pod {
+						selected = append(selected, p)
+					}
+				}
+				if len(selected) == 0 {
+					return fmt.Errorf("pod %s has no seed in %s", pod, file)
+				}
+				pods = selected
+			}
+			if len(pods) == 0 {
+				return fmt.Errorf("%s declares no seed", file)
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			jsonOutput := ui.Structured()
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				out = io.Discard
+			}
+			var runs []apischema.Migration
+			var runErr error
+			for _, p := range pods {
+				m, err := coreseed.Apply(ctx, client, &config, p, filepath.Dir(file), force, out)
+				if m != nil {
+					runs = append(runs, *m)
+				}
+				if err != nil {
+					runErr = fmt.Errorf("seeding %s: %w", p.Name, err)
+					break
+				}
+			}
+			if jsonOutput {
+				if err := ui.WriteOutput(runs); err != nil {
+					return err
+				}
+			}
+			if runErr != nil {
+				return runErr
+			}
+			if !jsonOutput {
+				fmt.Fprintf(out, "%s Seed data of %s is loaded\n", ui.Symbols().Success, config.Application.Name)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration declaring the seeds")
+	cmd.Flags().StringVar(&pod, "pod", "", "Only seed this pod")
+	cmd.Flags().BoolVar(&force, "force", false, "Seed even if the pod was already seeded")
+	cmd.Flags().DurationVar(&timeout, "timeout", coremigrate.DefaultTimeout, "How long to wait for the seeds")
+
+	return cmd
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	coreserve "github.com/Nexlayer/nexlayer-cli/pkg/core/serve"
+	"github.com/Nexlayer/nexlayer-cli/pkg/sdk"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// TokenEnv holds the token of the server when --token is not given
+const TokenEnv = "NEXLAYER_SERVE_TOKEN"
+
+// NewCommand creates a new serve command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var addr, token, dir string
+	var origins []string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a local REST API for validate, convert, deploy and status",
+		Long: `Run a local HTTP server exposing what the CLI does as a REST API, so
+playgrounds, editors and internal tools can integrate without running the CLI:
+
+  GET  /v1/health               liveness, without authentication
+  POST /v1/validate             body: nexlayer.yaml
+  POST /v1/convert?name=<app>   body: docker-compose.yml; returns nexlayer.yaml
+  POST /v1/deploy?appId=<id>    body: nexlayer.yaml
+  GET  /v1/status/{namespace}
+
+Requests must send "Authorization: Bearer <token>". The token is taken from
+--token or $` + TokenEnv + `, or generated and printed at startup. Browser
+origins allowed to call the API are listed with --allow-origin.
+
+Files that deployed configurations refer to, such as config files and static
+sites, are resolved against --dir; absolute paths and paths leaving --dir are
+refused. Static site build commands are not run, and compose files sent to
+/v1/convert may not use env_file.
+
+Examples:
+  nexlayer serve
+  nexlayer serve --addr 127.0.0.1:9000 --allow-origin http://localhost:5173
+  curl -H "Authorization: Bearer $TOKEN" --data-binary @nexlayer.yaml localhost:8787/v1/validate`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if token == "" {
+				token = os.Getenv(TokenEnv)
+			}
+			generated := token == ""
+			if generated {
+				var err error
+				if token, err = coreserve.NewToken(); err != nil {
+					return err
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			srv := &coreserve.Server{
+				Client:       sdk.NewWithAPI(client),
+				Token:        token,
+				BaseDir:      dir,
+				AllowOrigins: origins,
+				OnRequest: func(r *http.Request, status int) {
+					fmt.Fprintf(out, "%s %s %s %d\n", time.Now().Format("15:04:05"), r.Method, r.URL.Path, status)
+				},
+			}
+
+			ln, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", addr, err)
+			}
+			httpSrv := &http.Server{Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+			fmt.Fprintf(out, "%s Serving the Nexlayer API on http://%s\n", ui.Symbols().Success, ln.Addr())
+			if generated {
+				fmt.Fprintf(out, "Token: %s\n", token)
+			}
+			fmt.Fprintln(out, "Press Ctrl+C to stop")
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				sctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				httpSrv.Shutdown(sctx)
+			}()
+
+			if err := httpSrv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:8787", "Address to listen on")
+	cmd.Flags().StringVar(&token, "token", "", "Token clients must send (default $"+TokenEnv+" or generated)")
+	cmd.Flags().StringVar(&dir, "dir", ".", "Directory deployed configurations refer to files in")
+	cmd.Flags().StringSliceVar(&origins, "allow-origin", nil, "Browser origin allowed to call the API, or * (repeatable)")
+
+	return cmd
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	coresnapshot "github.com/Nexlayer/nexlayer-cli/pkg/core/snapshot"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates a new snapshot command
+func NewCommand(client api.APIClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Snapshot and restore a deployment with its data",
+		Long: `Take a snapshot of a deployment that captures the running configuration
+revision together with a snapshot of every volume, and restore both in one step
+after a bad release or a data incident.
+
+Snapshot records are kept in .nexlayer/snapshots of the project; the volume data
+stays on the platform. Records hold the configuration as deployed, including
+secret values, so keep them out of version control.
+
+The namespace defaults to the last deployment started from this directory.`,
+	}
+
+	cmd.AddCommand(newCreateCommand(client))
+	cmd.AddCommand(newListCommand(client))
+	cmd.AddCommand(newRestoreCommand(client))
+
+	return cmd
+}
+
+func newCreateCommand(client api.APIClient) *cobra.Command {
+	var file, description string
+
+	cmd := &cobra.Command{
+		Use:   "create [namespace]",
+		Short: "Snapshot the configuration and volumes of a deployment",
+		Long: `Snapshot the running configuration revision of a deployment and each of its
+volumes. Images are taken from the running pods; ports, vars and volumes from
+--file, which defaults to nexlayer.yaml when present.
+
+Examples:
+  nexlayer snapshot create
+  nexlayer snapshot create my-app-ns -m "before 2.0 release"`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			base, err := loadBase(file)
+			if err != nil {
+				return err
+			}
+
+			s, err := coresnapshot.Create(cmd.Context(), client, namespace, base, description)
+			if err != nil {
+				return err
+			}
+			if err := coresnapshot.Save(s); err != nil {
+				return err
+			}
+
+			if ui.Structured() {
+				return ui.WriteOutput(s)
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s Snapshot %s of %s created\n", ui.Symbols().Success, s.ID, namespace)
+			for _, v := range s.Volumes {
+				fmt.Fprintf(out, "  %s volume %s/%s: %s\n", ui.Symbols().Bullet, v.Pod, v.Volume, v.SnapshotID)
+			}
+			if len(s.Volumes) == 0 {
+				fmt.Fprintf(out, "  %s no volumes; only the configuration was captured\n", ui.Symbols().Bullet)
+			}
+			fmt.Fprintf(out, "Restore it with 'nexlayer snapshot restore %s %s'\n", namespace, s.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Configuration the deployment was created from")
+	cmd.Flags().StringVarP(&description, "message", "m", "", "Description of the snapshot")
+
+	return cmd
+}
+
+func newListCommand(client api.APIClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list [namespace]",
+		Aliases:           []string{"ls"},
+		Short:             "List the snapshots of a deployment",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			snapshots, err := coresnapshot.List(namespace)
+			if err != nil {
+				return err
+			}
+			if ui.Structured() {
+				if snapshots == nil {
+					snapshots = []coresnapshot.Snapshot{}
+				}
+				return ui.WriteOutput(snapshots)
+			}
+			if len(snapshots) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No snapshots of %s\n", namespace)
+				return nil
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("ID", "CREATED", "VOLUMES", "DESCRIPTION")
+			for _, s := range snapshots {
+				table.AddRow(s.ID, s.CreatedAt.Local().Format("2006-01-02 15:04"), fmt.Sprint(len(s.Volumes)), s.Description)
+			}
+			return table.Render()
+		},
+	}
+
+	return cmd
+}
+
+func newRestoreCommand(client api.APIClient) *cobra.Command {
+	var appID string
+	var yes, configOnly, volumesOnly bool
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "restore [namespace] <snapshot-id>",
+		Short: "Restore a deployment and its volumes from a snapshot",
+		Long: `Redeploy the configuration revision of a snapshot, wait for it to come up, then
+restore each volume from the snapshot. Pods mounting the volumes are restarted
+and any data written since the snapshot is lost. "latest" names the newest
+snapshot.
+
+Examples:
+  nexlayer snapshot restore latest
+  nexlayer snapshot restore my-app-ns 20250301-120000 --yes
+  nexlayer snapshot restore latest --volumes-only`,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if configOnly && volumesOnly {
+				return fmt.Errorf("--config-only and --volumes-only cannot be used together")
+			}
+			namespace, err := deployment.ResolveNamespace(args[:len(args)-1])
+			if err != nil {
+				return err
+			}
+			s, err := coresnapshot.Load(namespace, args[len(args)-1])
+			if err != nil {
+				return err
+			}
+			config, err := s.Parse()
+			if err != nil {
+				return err
+			}
+
+			if !yes {
+				label := fmt.Sprintf("Restore %s to snapshot %s (%s)? Data written since the snapshot will be lost",
+					namespace, s.ID, s.CreatedAt.Local().Format("2006-01-02 15:04"))
+				if configOnly {
+					label = fmt.Sprintf("Redeploy %s as of snapshot %s?", namespace, s.ID)
+				}
+				prompt := promptui.Prompt{Label: label, IsConfirm: true}
+				if result, err := prompt.Run(); err != nil || strings.ToLower(result) != "y" {
+					return fmt.Errorf("restore cancelled")
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			ctx := cmd.Context()
+			if !volumesOnly {
+				file, cleanup, err := deployment.WriteTemp(config)
+				if err != nil {
+					return err
+				}
+				defer cleanup()
+				resp, err := client.StartDeployment(ctx, appID, file)
+				if err != nil {
+					return fmt.Errorf("failed to redeploy snapshot configuration: %w", err)
+				}
+				if resp.Data.Namespace != "" {
+					namespace = resp.Data.Namespace
+				}
+				fmt.Fprintf(out, "%s Redeploying the configuration of %s to %s\n", ui.Symbols().Success, s.ID, namespace)
+
+				wctx, cancel := context.WithTimeout(ctx, timeout)
+				dep, err := deployment.Wait(wctx, client, namespace, 2*time.Second, 15*time.Second, nil)
+				cancel()
+				switch {
+				case errors.Is(err, context.DeadlineExceeded):
+					return fmt.Errorf("deployment did not settle within %s; volumes were not restored\nRun 'nexlayer snapshot restore %s %s --volumes-only' once it is up", timeout, namespace, s.ID)
+				case err != nil:
+					return err
+				case !deployment.Succeeded(*dep):
+					return fmt.Errorf("deployment %s, volumes were not restored", dep.Status)
+				}
+			}
+
+			if !configOnly {
+				for _, v := range s.Volumes {
+					if err := client.RestoreVolumeSnapshot(ctx, namespace, v.SnapshotID); err != nil {
+						return fmt.Errorf("failed to restore volume %s of pod %s: %w", v.Volume, v.Pod, err)
+					}
+					fmt.Fprintf(out, "%s Restoring volume %s/%s from %s\n", ui.Symbols().Success, v.Pod, v.Volume, v.SnapshotID)
+				}
+			}
+			fmt.Fprintf(out, "%s %s restored to snapshot %s\n", ui.Symbols().Success, namespace, s.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&appID, "app", "", "Application ID to redeploy to (default from your profile)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Restore without asking for confirmation")
+	cmd.Flags().BoolVar(&configOnly, "config-only", false, "Only redeploy the configuration, keep the current volume data")
+	cmd.Flags().BoolVar(&volumesOnly, "volumes-only", false, "Only restore the volumes, keep the running configuration")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "How long to wait for the redeployment before restoring volumes")
+
+	return cmd
+}
+
+// loadBase loads the configuration the deployment was created from, with
+// the files it refers to inlined so the snapshot does not depend on them
+func loadBase(file string) (*schema.NexlayerYAML, error) {
+	if file == "" {
+		if _, err := os.Stat("nexlayer.yaml"); err != nil {
+			return nil, nil
+		}
+		file = "nexlayer.yaml"
+	}
+	config, _, err := deployment.Load(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := schema.ResolveConfigFiles(config, filepath.Dir(file)); err != nil {
+		return nil, err
+	}
+	if err := schema.ResolveEnvFrom(config, filepath.Dir(file)); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// newCaptureCommand creates the capture subcommand
+func newCaptureCommand(client api.APIClient, registry *string) *cobra.Command {
+	var configFile, output string
+	var push bool
+	var meta tmpl.Metadata
+
+	cmd := &cobra.Command{
+		Use:   "capture <namespace>",
+		Short: "Create a template from a running deployment",
+		Long: `Snapshot a running deployment into a reusable, parameterized template.
+
+Images are taken from the running pods. Ports, vars and volumes are taken from
+--config when given. The application name, registry credentials, secret data and
+sensitive vars are replaced with template variables.
+
+Examples:
+  nexlayer template capture my-app-ns --config nexlayer.yaml
+  nexlayer template capture my-app-ns --push --name my-app --version 1.0.0`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var base *schema.NexlayerYAML
+			if configFile != "" {
+				data, err := os.ReadFile(configFile)
+				if err != nil {
+					return fmt.Errorf("failed to read %s: %w", configFile, err)
+				}
+				base = &schema.NexlayerYAML{}
+				if err := yaml.Unmarshal(data, base); err != nil {
+					return fmt.Errorf("failed to parse %s: %w", configFile, err)
+				}
+			}
+
+			info, err := client.GetDeploymentInfo(cmd.Context(), args[0])
+			if err != nil {
+				return fmt.Errorf("failed to get deployment info: %w", err)
+			}
+			if len(info.Data.PodStatuses) == 0 {
+				return fmt.Errorf("deployment %s has no pods to capture", args[0])
+			}
+
+			config, variables := tmpl.Capture(info.Data, base)
+			content, err := yaml.Marshal(config)
+			if err != nil {
+				return fmt.Errorf("failed to encode template: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			if push {
+				if meta.Name == "" || meta.Version == "" {
+					return fmt.Errorf("--name and --version are required with --push")
+				}
+				reg, err := tmpl.OpenRegistryFor(*registry, meta.Name)
+				if err != nil {
+					return err
+				}
+				meta.PublishedAt = time.Now().UTC()
+				if err := reg.Push(cmd.Context(), &tmpl.Template{Metadata: meta, Content: content}); err != nil {
+					return fmt.Errorf("failed to push template: %w", err)
+				}
+				fmt.Fprintf(out, "%s Published %s@%s\n", ui.Symbols().Success, meta.Name, meta.Version)
+			} else {
+				if err := os.WriteFile(output, content, 0644); err != nil {
+					return fmt.Errorf("failed to write %s: %w", output, err)
+				}
+				fmt.Fprintf(out, "%s Captured %s into %s\n", ui.Symbols().Success, args[0], output)
+			}
+
+			fmt.Fprintln(out, "\nTemplate variables:")
+			for _, v := range variables {
+				fmt.Fprintf(out, "  %s %s\n", ui.Symbols().Bullet, v)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&configFile, "config", "", "Configuration the deployment was created from")
+	cmd.Flags().StringVar(&output, "out-file", "template.yaml", "File to write the template to")
+	cmd.Flags().BoolVar(&push, "push", false, "Publish to the registry instead of writing a file")
+	cmd.Flags().StringVar(&meta.Name, "name", "", "Template name (with --push)")
+	cmd.Flags().StringVar(&meta.Version, "version", "", "Template version (with --push)")
+	cmd.Flags().StringVar(&meta.Description, "description", "", "Short description (with --push)")
+
+	return cmd
+}
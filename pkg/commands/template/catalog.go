@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newCatalogCommand creates the catalog subcommand group
+func newCatalogCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "catalog",
+		Short: "Manage organization-private template catalogs",
+		Long: `Configure private template registries for organizations.
+
+Templates named <org>/<name> are pulled from, pushed to and searched in the
+catalog configured for <org>. Requests are authenticated with the catalog's
+token, or NEXLAYER_TOKEN when none is set; the registry decides which members
+may read or publish each template.
+
+Examples:
+  nexlayer template catalog add acme https://templates.acme.internal --token $ACME_TOKEN
+  nexlayer template catalog list
+  nexlayer init --template acme/payment-service`,
+	}
+
+	cmd.AddCommand(newCatalogAddCommand())
+	cmd.AddCommand(newCatalogListCommand())
+	cmd.AddCommand(newCatalogRemoveCommand())
+
+	return cmd
+}
+
+// newCatalogAddCommand creates the catalog add subcommand
+func newCatalogAddCommand() *cobra.Command {
+	var token string
+
+	cmd := &cobra.Command{
+		Use:   "add <org> <url>",
+		Short: "Add or replace an organization catalog",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			org, url := args[0], args[1]
+			if err := tmpl.ValidateOrg(org); err != nil {
+				return err
+			}
+			if err := tmpl.ValidateCatalogURL(url); err != nil {
+				return err
+			}
+
+			catalogs, err := tmpl.LoadCatalogs()
+			if err != nil {
+				return err
+			}
+			updated := catalogs[:0]
+			for _, c := range catalogs {
+				if c.Org != org {
+					updated = append(updated, c)
+				}
+			}
+			updated = append(updated, tmpl.Catalog{Org: org, URL: url, Token: token})
+			if err := tmpl.SaveCatalogs(updated); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Templates named %s/<name> now resolve to %s\n", ui.Symbols().Success, org, url)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&token, "token", "", "Access token for the catalog (default: NEXLAYER_TOKEN)")
+
+	return cmd
+}
+
+// newCatalogListCommand creates the catalog list subcommand
+func newCatalogListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List configured organization catalogs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalogs, err := tmpl.LoadCatalogs()
+			if err != nil {
+				return err
+			}
+			if len(catalogs) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No catalogs configured")
+				return nil
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("ORG", "URL", "AUTH")
+			for _, c := range catalogs {
+				auth := "NEXLAYER_TOKEN"
+				if c.Token != "" {
+					auth = "catalog token"
+				}
+				table.AddRow(c.Org, c.URL, auth)
+			}
+			return table.Render()
+		},
+	}
+}
+
+// newCatalogRemoveCommand creates the catalog remove subcommand
+func newCatalogRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <org>",
+		Short: "Remove an organization catalog",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalogs, err := tmpl.LoadCatalogs()
+			if err != nil {
+				return err
+			}
+			updated := catalogs[:0]
+			for _, c := range catalogs {
+				if c.Org != args[0] {
+					updated = append(updated, c)
+				}
+			}
+			if len(updated) == len(catalogs) {
+				return fmt.Errorf("no catalog configured for %s", args[0])
+			}
+			if err := tmpl.SaveCatalogs(updated); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Removed catalog %s\n", ui.Symbols().Success, args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/cost"
+	corecost "github.com/Nexlayer/nexlayer-cli/pkg/core/cost"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// newCostCommand creates the cost subcommand
+func newCostCommand(registry *string) *cobra.Command {
+	var refresh bool
+	var pricingURL, valuesFile string
+	var setValues []string
+
+	cmd := &cobra.Command{
+		Use:   "cost <file|name@version>",
+		Short: "Estimate the monthly cost of a template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := loadContent(cmd.Context(), *registry, args[0])
+			if err != nil {
+				return err
+			}
+			content, err = Instantiate(content, valuesFile, setValues)
+			if err != nil {
+				return err
+			}
+			var config schema.NexlayerYAML
+			if err := yaml.Unmarshal(content, &config); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", args[0], err)
+			}
+			table, err := cost.LoadPricing(cmd, refresh, pricingURL)
+			if err != nil {
+				return err
+			}
+			est, err := corecost.EstimateConfig(&config, table)
+			if err != nil {
+				return err
+			}
+			return cost.PrintEstimate(cmd.OutOrStdout(), est, ui.Structured())
+		},
+	}
+
+	cost.AddPricingFlags(cmd, &refresh, &pricingURL)
+	cmd.Flags().StringVarP(&valuesFile, "values", "f", "", "Values file for template variables")
+	cmd.Flags().StringArrayVar(&setValues, "set", nil, "Set a template variable (key=value, repeatable)")
+	return cmd
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00"))
+	removedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+	changedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#ffff00"))
+)
+
+// newDiffCommand creates the diff subcommand
+func newDiffCommand(registry *string) *cobra.Command {
+	var format string
+	var exitCode bool
+
+	cmd := &cobra.Command{
+		Use:   "diff <old> <new>",
+		Short: "Show structural differences between two templates",
+		Long: `Compare two templates pod by pod, including images, ports, vars and volumes.
+
+Each argument is either a file path or a registry reference (name@version).
+
+Examples:
+  nexlayer template diff nexlayer.yaml web@1.2.0
+  nexlayer template diff web@1.1.0 web@1.2.0 --format json --exit-code`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			oldCfg, err := loadConfig(cmd.Context(), *registry, args[0])
+			if err != nil {
+				return err
+			}
+			newCfg, err := loadConfig(cmd.Context(), *registry, args[1])
+			if err != nil {
+				return err
+			}
+
+			changes := tmpl.Diff(oldCfg, newCfg)
+
+			switch format {
+			case "json":
+				if changes == nil {
+					changes = []tmpl.Change{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(changes); err != nil {
+					return fmt.Errorf("failed to encode diff: %w", err)
+				}
+			case "text":
+				printChanges(cmd, changes)
+			default:
+				return fmt.Errorf("unsupported format %q (use text or json)", format)
+			}
+
+			if exitCode && len(changes) > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("templates differ (%d changes)", len(changes))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit with an error when the templates differ")
+
+	return cmd
+}
+
+// printChanges renders changes as colored +/-/~ lines
+func printChanges(cmd *cobra.Command, changes []tmpl.Change) {
+	out := cmd.OutOrStdout()
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "No differences")
+		return
+	}
+	for _, c := range changes {
+		switch c.Kind {
+		case tmpl.ChangeAdded:
+			fmt.Fprintln(out, addedStyle.Render(fmt.Sprintf("+ %s: %s", c.Path, c.New)))
+		case tmpl.ChangeRemoved:
+			fmt.Fprintln(out, removedStyle.Render(fmt.Sprintf("- %s: %s", c.Path, c.Old)))
+		default:
+			fmt.Fprintln(out, changedStyle.Render(fmt.Sprintf("~ %s: %s -> %s", c.Path, c.Old, c.New)))
+		}
+	}
+}
+
+// loadConfig reads a template from a file or the registry and decodes it for inspection
+func loadConfig(ctx context.Context, registry, ref string) (*schema.NexlayerYAML, error) {
+	content, err := loadContent(ctx, registry, ref)
+	if err != nil {
+		return nil, err
+	}
+	config, err := tmpl.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ref, err)
+	}
+	return config, nil
+}
+
+// loadContent reads a template from a file, or from the registry when no such file exists
+func loadContent(ctx context.Context, registry, ref string) ([]byte, error) {
+	content, err := os.ReadFile(ref)
+	if err == nil {
+		return content, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", ref, err)
+	}
+	name, version, err := tmpl.ParseRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := tmpl.OpenRegistryFor(registry, name)
+	if err != nil {
+		return nil, err
+	}
+	t, err := reg.Pull(ctx, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", ref, err)
+	}
+	content, _, err = tmpl.Resolve(ctx, reg, t.Content)
+	return content, err
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newListCommand creates the list subcommand
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the built-in starter templates",
+		Long: `List the starter templates shipped with the CLI and the parameters each
+asks for. Start a project from one with:
+
+  nexlayer init --template pern --name shop`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			builtins := tmpl.Builtins()
+			if ui.Structured() {
+				return ui.WriteOutput(builtins)
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("NAME", "STACK", "DESCRIPTION", "PARAMETERS")
+			for _, b := range builtins {
+				params := make([]string, 0, len(b.Params))
+				for _, p := range b.Params {
+					params = append(params, p.Name)
+				}
+				table.AddRow(b.Metadata.Name, b.Metadata.Stack, b.Metadata.Description, strings.Join(params, ", "))
+			}
+			if err := table.Render(); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "\nUse one with: nexlayer init --template <name>")
+			return nil
+		},
+	}
+}
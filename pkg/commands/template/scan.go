@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/scanner"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newScanCommand creates the scan subcommand
+func newScanCommand(registry *string) *cobra.Command {
+	var format, failOn string
+
+	cmd := &cobra.Command{
+		Use:   "scan <file|name@version>",
+		Short: "Check a template for insecure settings",
+		Long: `Scan a template for privileged pods, publicly exposed databases, weak or
+hardcoded credentials, missing TLS and broadly scoped registry tokens.
+
+Examples:
+  nexlayer template scan nexlayer.yaml
+  nexlayer template scan web@1.2.0 --format json --fail-on medium`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			threshold, err := scanner.ParseSeverity(failOn)
+			if err != nil {
+				return err
+			}
+			config, err := loadConfig(cmd.Context(), *registry, args[0])
+			if err != nil {
+				return err
+			}
+
+			findings := scanner.NewSecurityScanner().Scan(config)
+
+			out := cmd.OutOrStdout()
+			switch format {
+			case "json":
+				if findings == nil {
+					findings = []scanner.Finding{}
+				}
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				enc.SetEscapeHTML(false)
+				if err := enc.Encode(findings); err != nil {
+					return fmt.Errorf("failed to encode findings: %w", err)
+				}
+			case "text":
+				if len(findings) == 0 {
+					fmt.Fprintf(out, "%s No security issues found\n", ui.Symbols().Success)
+				}
+				for _, f := range findings {
+					fmt.Fprintf(out, "[%s] %s: %s\n", strings.ToUpper(f.Severity.String()), f.Field, f.Message)
+					if f.Remediation != "" {
+						fmt.Fprintf(out, "    fix: %s\n", f.Remediation)
+					}
+				}
+			default:
+				return fmt.Errorf("unsupported format %q (use text or json)", format)
+			}
+
+			if len(findings) > 0 && scanner.MaxSeverity(findings) >= threshold {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("found %d security issues at or above %s severity", countAtLeast(findings, threshold), threshold)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	cmd.Flags().StringVar(&failOn, "fail-on", "high", "Fail when a finding has at least this severity")
+
+	return cmd
+}
+
+// countAtLeast counts findings at or above a severity
+func countAtLeast(findings []scanner.Finding, threshold scanner.Severity) int {
+	n := 0
+	for _, f := range findings {
+		if f.Severity >= threshold {
+			n++
+		}
+	}
+	return n
+}
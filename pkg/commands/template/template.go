@@ -0,0 +1,290 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui/components"
+	"github.com/spf13/cobra"
+)
+
+// NewTemplateCommand creates a new template command group
+func NewTemplateCommand(client api.APIClient) *cobra.Command {
+	var registry string
+
+	cmd := &cobra.Command{
+		Use:     "template",
+		Aliases: []string{"templates"},
+		Short:   "Publish and reuse deployment templates",
+		Long: `Share nexlayer.yaml files as versioned templates.
+
+Templates are stored in a registry, which is either a directory on disk
+(default: ~/.config/nexlayer/templates) or an HTTP registry URL.
+
+Starter templates such as pern and llm-rag ship with the CLI; "nexlayer
+templates list" shows them.
+
+Examples:
+  nexlayer templates list
+  nexlayer template push nexlayer.yaml --name nextjs-postgres --version 1.0.0
+  nexlayer template search postgres
+  nexlayer template pull nextjs-postgres@1.0.0
+  nexlayer template pull nextjs-postgres --values values.yaml --set appName=shop
+  nexlayer template pull acme/payment-service
+
+A template may start with "extends: <name>@<version>" to layer pods and
+settings over a base template from the same registry. Mappings are merged key
+by key, pods, ports, volumes and secrets by name, vars by key; the child wins.
+
+Pulled templates are migrated from their declared schemaVersion to the
+current schema. Templates written for a newer CLI are refused.
+
+Names of the form <org>/<name> are resolved against the organization's
+private catalog when one is configured with "nexlayer template catalog add".
+
+Templates may contain variables such as {{ .appName }}. Values are taken from
+--values and --set; anything still missing is prompted for.`,
+	}
+
+	cmd.PersistentFlags().StringVar(&registry, "registry", "", "Template registry directory or URL")
+
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newPushCommand(&registry))
+	cmd.AddCommand(newPullCommand(&registry))
+	cmd.AddCommand(newSearchCommand(&registry))
+	cmd.AddCommand(newDiffCommand(&registry))
+	cmd.AddCommand(newUpgradeCommand(&registry))
+	cmd.AddCommand(newCostCommand(&registry))
+	cmd.AddCommand(newScanCommand(&registry))
+	cmd.AddCommand(newCaptureCommand(client, &registry))
+	cmd.AddCommand(newCatalogCommand())
+
+	return cmd
+}
+
+// newPushCommand creates the push subcommand
+func newPushCommand(registry *string) *cobra.Command {
+	var meta tmpl.Metadata
+
+	cmd := &cobra.Command{
+		Use:   "push <file>",
+		Short: "Publish a template to the registry",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read template: %w", err)
+			}
+			if err := checkVisibility(meta.Visibility); err != nil {
+				return err
+			}
+			reg, err := tmpl.OpenRegistryFor(*registry, meta.Name)
+			if err != nil {
+				return err
+			}
+			resolved, _, err := tmpl.Resolve(cmd.Context(), reg, content)
+			if err != nil {
+				return err
+			}
+			if err := checkTemplate(resolved); err != nil {
+				return err
+			}
+
+			meta.PublishedAt = time.Now().UTC()
+			if err := reg.Push(cmd.Context(), &tmpl.Template{Metadata: meta, Content: content}); err != nil {
+				return fmt.Errorf("failed to push template: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Published %s@%s\n", ui.Symbols().Success, meta.Name, meta.Version)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&meta.Name, "name", "", "Template name (required)")
+	cmd.Flags().StringVar(&meta.Version, "version", "", "Template version (required)")
+	cmd.Flags().StringVar(&meta.Description, "description", "", "Short description")
+	cmd.Flags().StringVar(&meta.Stack, "stack", "", "Stack the template targets (e.g. nextjs, django)")
+	cmd.Flags().StringSliceVar(&meta.Keywords, "keyword", nil, "Search keywords (repeatable)")
+	cmd.Flags().StringVar(&meta.Visibility, "visibility", "", "Who can pull the template: public, org or private")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("version")
+
+	return cmd
+}
+
+// newPullCommand creates the pull subcommand
+func newPullCommand(registry *string) *cobra.Command {
+	var output, valuesFile string
+	var setValues []string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "pull <name>[@version]",
+		Short: "Use a template as the project's nexlayer.yaml",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := os.Stat(output); err == nil && !force {
+				return fmt.Errorf("%s already exists. Use --force to overwrite", output)
+			}
+
+			name, version, err := tmpl.ParseRef(args[0])
+			if err != nil {
+				return err
+			}
+			reg, err := tmpl.OpenRegistryFor(*registry, name)
+			if err != nil {
+				return err
+			}
+
+			t, err := reg.Pull(cmd.Context(), name, version)
+			if err != nil {
+				return fmt.Errorf("failed to pull template: %w", err)
+			}
+
+			resolved, err := Resolve(cmd.Context(), reg, t, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			content, err := Instantiate(resolved, valuesFile, setValues)
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(output, content, 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Wrote %s from %s@%s\n", ui.Symbols().Success, output, t.Metadata.Name, t.Metadata.Version)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "out-file", "nexlayer.yaml", "File to write the template to")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing file")
+	cmd.Flags().StringVarP(&valuesFile, "values", "f", "", "Values file for template variables")
+	cmd.Flags().StringArrayVar(&setValues, "set", nil, "Set a template variable (key=value, repeatable)")
+
+	return cmd
+}
+
+// newSearchCommand creates the search subcommand
+func newSearchCommand(registry *string) *cobra.Command {
+	var query tmpl.SearchQuery
+	var org string
+
+	cmd := &cobra.Command{
+		Use:   "search [keyword]",
+		Short: "Search templates by keyword or stack",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				query.Keyword = args[0]
+			}
+
+			reg, err := tmpl.OpenRegistryFor(*registry, org+"/")
+			if err != nil {
+				return err
+			}
+
+			results, err := reg.Search(cmd.Context(), query)
+			if err != nil {
+				return fmt.Errorf("failed to search templates: %w", err)
+			}
+			if len(results) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No templates found")
+				return nil
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("NAME", "VERSION", "STACK", "DESCRIPTION")
+			for _, m := range results {
+				table.AddRow(m.Name, m.Version, m.Stack, m.Description)
+			}
+			return table.Render()
+		},
+	}
+
+	cmd.Flags().StringVar(&query.Stack, "stack", "", "Only show templates for this stack")
+	cmd.Flags().StringVar(&org, "org", "", "Search an organization's private catalog")
+
+	return cmd
+}
+
+// Resolve composes and migrates a pulled template, reports the migrations that
+// were applied and checks the result against the current schema
+func Resolve(ctx context.Context, reg tmpl.Registry, t *tmpl.Template, out io.Writer) ([]byte, error) {
+	ref := t.Metadata.Name + "@" + t.Metadata.Version
+	content, notes, err := tmpl.Resolve(ctx, reg, t.Content)
+	if err != nil {
+		return nil, fmt.Errorf("cannot use %s: %w", ref, err)
+	}
+	for _, note := range notes {
+		fmt.Fprintf(out, "%s %s\n", ui.Symbols().Info, note)
+	}
+	if err := tmpl.CheckCompatibility(content); err != nil {
+		return nil, fmt.Errorf("cannot use %s: %w", ref, err)
+	}
+	return content, nil
+}
+
+// Instantiate fills in template variables from a values file, --set flags and,
+// for anything still missing, interactive prompts
+func Instantiate(content []byte, valuesFile string, setValues []string) ([]byte, error) {
+	values := tmpl.Values{}
+	if valuesFile != "" {
+		loaded, err := tmpl.LoadValues(valuesFile)
+		if err != nil {
+			return nil, err
+		}
+		values = loaded
+	}
+	values, err := tmpl.ParseSetFlags(values, setValues)
+	if err != nil {
+		return nil, err
+	}
+
+	missing, err := tmpl.Missing(content, values)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range missing {
+		value, err := components.NewPrompt(fmt.Sprintf("Value for %s", name)).Run()
+		if err != nil {
+			return nil, fmt.Errorf("missing value for template variable %s: %w", name, err)
+		}
+		values[name] = value
+	}
+
+	return tmpl.Render(content, values)
+}
+
+// checkVisibility validates the --visibility flag
+func checkVisibility(visibility string) error {
+	switch visibility {
+	case "", tmpl.VisibilityPublic, tmpl.VisibilityOrg, tmpl.VisibilityPrivate:
+		return nil
+	}
+	return fmt.Errorf("invalid visibility %q: must be public, org or private", visibility)
+}
+
+// checkTemplate makes sure the content is a Nexlayer configuration
+func checkTemplate(content []byte) error {
+	config, err := tmpl.Parse(content)
+	if err != nil {
+		return err
+	}
+	if config.Application.Name == "" || len(config.Application.Pods) == 0 {
+		return fmt.Errorf("template must define application.name and at least one pod")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// newUpgradeCommand creates the upgrade subcommand
+func newUpgradeCommand(registry *string) *cobra.Command {
+	var file, changelog string
+	var major, minor, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade <name>",
+		Short: "Publish the next semantic version of a template",
+		Long: `Bump a template's version, draft a changelog from the structural diff
+and validate the result against the current schema before publishing.
+
+The patch level is bumped unless --minor or --major is given.
+
+Examples:
+  nexlayer template upgrade web --file nexlayer.yaml
+  nexlayer template upgrade web --file nexlayer.yaml --minor
+  nexlayer template upgrade web --major --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level := tmpl.BumpPatch
+			switch {
+			case major && minor:
+				return fmt.Errorf("--major and --minor are mutually exclusive")
+			case major:
+				level = tmpl.BumpMajor
+			case minor:
+				level = tmpl.BumpMinor
+			}
+
+			reg, err := tmpl.OpenRegistryFor(*registry, args[0])
+			if err != nil {
+				return err
+			}
+			prev, err := reg.Pull(cmd.Context(), args[0], "")
+			if err != nil {
+				return fmt.Errorf("failed to load current version: %w", err)
+			}
+
+			content := prev.Content
+			if file != "" {
+				if content, err = os.ReadFile(file); err != nil {
+					return fmt.Errorf("failed to read template: %w", err)
+				}
+			}
+			if err := tmpl.CheckCompatibility(content); err != nil {
+				return err
+			}
+
+			version, err := tmpl.BumpVersion(prev.Metadata.Version, level)
+			if err != nil {
+				return err
+			}
+
+			if changelog == "" {
+				oldCfg, err := tmpl.Parse(prev.Content)
+				if err != nil {
+					return err
+				}
+				newCfg, err := tmpl.Parse(content)
+				if err != nil {
+					return err
+				}
+				changelog = tmpl.ChangelogStub(version, tmpl.Diff(oldCfg, newCfg))
+			}
+
+			meta := prev.Metadata
+			meta.Version = version
+			meta.Changelog = changelog
+			meta.PublishedAt = time.Now().UTC()
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s -> %s\n\n%s\n", prev.Metadata.Version, version, changelog)
+			if dryRun {
+				fmt.Fprintln(out, "Dry run: nothing was published")
+				return nil
+			}
+
+			if err := reg.Push(cmd.Context(), &tmpl.Template{Metadata: meta, Content: content}); err != nil {
+				return fmt.Errorf("failed to push template: %w", err)
+			}
+			fmt.Fprintf(out, "%s Published %s@%s\n", ui.Symbols().Success, meta.Name, meta.Version)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Updated template file (default: republish the current content)")
+	cmd.Flags().BoolVar(&minor, "minor", false, "Bump the minor version")
+	cmd.Flags().BoolVar(&major, "major", false, "Bump the major version")
+	cmd.Flags().StringVar(&changelog, "changelog", "", "Changelog entry (default: generated from the diff)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the new version and changelog without publishing")
+
+	return cmd
+}
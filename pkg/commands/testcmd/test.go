@@ -0,0 +1,162 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package testcmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/checks"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewCommand creates a new test command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var file, url string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "test [namespace]",
+		Short: "Run synthetic HTTP checks against a deployment",
+		Long: `Run the HTTP checks declared in nexlayer.yaml against a deployed application
+and exit non-zero if any fails, for use as a CI gate after deploy.
+
+  checks:
+    - name: health
+      path: /api/health
+      status: 200                 # default 200
+      latency: 300ms              # optional budget
+      body: '"status":"ok"'       # optional text the body must contain
+    - path: /login
+      method: HEAD
+      bodyRegex: ...              # optional pattern the body must match
+
+Checks run against --url, else the URL of the namespace given, else that of the
+last deployment started from this directory or application.url.
+
+Examples:
+  nexlayer test
+  nexlayer test my-app-ns
+  nexlayer deploy && nexlayer test --json > checks.json`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			var config schema.NexlayerYAML
+			if err := yaml.Unmarshal(data, &config); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", file, err)
+			}
+			if len(config.Application.Checks) == 0 {
+				return fmt.Errorf("%s declares no checks", file)
+			}
+
+			if url == "" {
+				if url, err = resolveURL(cmd, client, args, &config); err != nil {
+					return err
+				}
+			}
+			if !strings.Contains(url, "://") {
+				url = "https://" + url
+			}
+
+			results := checks.NewRunner(url, timeout).RunAll(cmd.Context(), config.Application.Checks)
+			failed := 0
+			for _, r := range results {
+				if !r.Passed() {
+					failed++
+				}
+			}
+
+			jsonOutput := ui.Structured()
+			if jsonOutput {
+				if err := ui.WriteOutput(results); err != nil {
+					return err
+				}
+			} else if err := render(cmd, url, results); err != nil {
+				return err
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d checks failed", failed, len(results))
+			}
+			if !jsonOutput {
+				fmt.Fprintf(cmd.OutOrStdout(), "\n%s All %d checks passed\n", ui.Symbols().Success, len(results))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration declaring the checks")
+	cmd.Flags().StringVar(&url, "url", "", "Base URL to run the checks against")
+	cmd.Flags().DurationVar(&timeout, "timeout", checks.DefaultTimeout, "Timeout of each request")
+
+	return cmd
+}
+
+// resolveURL finds the URL of the deployment to check
+func resolveURL(cmd *cobra.Command, client api.APIClient, args []string, config *schema.NexlayerYAML) (string, error) {
+	if len(args) > 0 {
+		info, err := client.GetDeploymentInfo(cmd.Context(), args[0])
+		if err != nil {
+			return "", fmt.Errorf("failed to get deployment info: %w", err)
+		}
+		if info.Data.URL == "" {
+			return "", fmt.Errorf("deployment %s has no URL; use --url", args[0])
+		}
+		return info.Data.URL, nil
+	}
+	if last, err := deployment.LoadLast(); err == nil && last != nil && last.URL != "" {
+		return last.URL, nil
+	}
+	if config.Application.URL != "" {
+		return config.Application.URL, nil
+	}
+	return "", fmt.Errorf("no namespace given, no previous deployment found in this directory and no application.url; use --url")
+}
+
+// render prints the results as a table followed by the reasons of failures
+func render(cmd *cobra.Command, url string, results []checks.Result) error {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Running %d checks against %s\n\n", len(results), url)
+
+	table := ui.NewTable()
+	table.AddHeader("CHECK", "STATUS", "LATENCY", "RESULT")
+	for _, r := range results {
+		status := "-"
+		if r.Status != 0 {
+			status = fmt.Sprint(r.Status)
+		}
+		result := ui.Symbols().Success + " pass"
+		if !r.Passed() {
+			result = ui.Symbols().Error + " fail"
+		}
+		table.AddRow(r.Name, status, r.Latency.Round(time.Millisecond).String(), result)
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.Passed() {
+			continue
+		}
+		fmt.Fprintf(out, "\n%s %s (%s)\n", ui.Symbols().Error, r.Name, r.URL)
+		for _, f := range r.Failures {
+			fmt.Fprintf(out, "  %s %s\n", ui.Symbols().Bullet, f)
+		}
+	}
+	return nil
+}
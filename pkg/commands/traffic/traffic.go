@@ -0,0 +1,172 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package traffic
+
+import (
+	"fmt"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates a new traffic command
+func NewCommand(client api.APIClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "traffic",
+		Short: "Split traffic between stable and canary versions",
+		Long: `Show and change how traffic is split between the stable and canary versions
+of pods. A canary is declared per pod in nexlayer.yaml:
+
+  pods:
+    - name: api
+      image: ghcr.io/acme/api:v1
+      canary:
+        image: ghcr.io/acme/api:v2
+        weight: 10            # percent of traffic
+
+Weights changed with 'traffic set' apply immediately, without a deployment, and
+'traffic rollback' sends all traffic back to the stable version.
+
+The namespace defaults to the last deployment started from this directory.`,
+	}
+
+	cmd.AddCommand(newGetCommand(client))
+	cmd.AddCommand(newSetCommand(client))
+	cmd.AddCommand(newRollbackCommand(client))
+
+	return cmd
+}
+
+func newGetCommand(client api.APIClient) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get [namespace]",
+		Short:             "Show the traffic weights of pods with a canary",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			resp, err := client.GetTraffic(cmd.Context(), namespace)
+			if err != nil {
+				return fmt.Errorf("failed to get traffic: %w", err)
+			}
+			return printSplits(cmd, namespace, resp.Data)
+		},
+	}
+}
+
+func newSetCommand(client api.APIClient) *cobra.Command {
+	var pod string
+	var stable, canary int
+
+	cmd := &cobra.Command{
+		Use:   "set [namespace]",
+		Short: "Change the traffic weights",
+		Long: `Change the percent of traffic sent to the stable and canary versions. The
+weights must add up to 100; when only one is given the other is derived.
+
+Without --pod the weights apply to every pod with a canary.
+
+Examples:
+  nexlayer traffic set --stable 90 --canary 10
+  nexlayer traffic set my-app-ns --canary 50 --pod api`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+
+			stableSet, canarySet := cmd.Flags().Changed("stable"), cmd.Flags().Changed("canary")
+			switch {
+			case !stableSet && !canarySet:
+				return fmt.Errorf("set --stable, --canary or both")
+			case !stableSet:
+				stable = 100 - canary
+			case !canarySet:
+				canary = 100 - stable
+			}
+			if stable < 0 || canary < 0 || stable+canary != 100 {
+				return fmt.Errorf("weights must be between 0 and 100 and add up to 100, got stable %d and canary %d", stable, canary)
+			}
+
+			resp, err := client.SetTraffic(cmd.Context(), namespace, pod, stable, canary)
+			if err != nil {
+				return fmt.Errorf("failed to set traffic: %w", err)
+			}
+			return printSplits(cmd, namespace, resp.Data)
+		},
+	}
+
+	cmd.Flags().StringVar(&pod, "pod", "", "Only change the weights of this pod")
+	cmd.Flags().IntVar(&stable, "stable", 0, "Percent of traffic sent to the stable version")
+	cmd.Flags().IntVar(&canary, "canary", 0, "Percent of traffic sent to the canary")
+
+	return cmd
+}
+
+func newRollbackCommand(client api.APIClient) *cobra.Command {
+	var pod string
+
+	cmd := &cobra.Command{
+		Use:   "rollback [namespace]",
+		Short: "Send all traffic to the stable version",
+		Long: `Send all traffic back to the stable version at once. The canary keeps running
+without traffic until the next deployment or 'traffic set'.
+
+Examples:
+  nexlayer traffic rollback
+  nexlayer traffic rollback my-app-ns --pod api`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			resp, err := client.SetTraffic(cmd.Context(), namespace, pod, 100, 0)
+			if err != nil {
+				return fmt.Errorf("failed to roll back traffic: %w", err)
+			}
+			return printSplits(cmd, namespace, resp.Data)
+		},
+	}
+
+	cmd.Flags().StringVar(&pod, "pod", "", "Only roll back this pod")
+
+	return cmd
+}
+
+// printSplits renders traffic splits as a table or JSON
+func printSplits(cmd *cobra.Command, namespace string, splits []apischema.TrafficSplit) error {
+	if ui.Structured() {
+		if splits == nil {
+			splits = []apischema.TrafficSplit{}
+		}
+		return ui.WriteOutput(splits)
+	}
+	if len(splits) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No pods with a canary in %s\n", namespace)
+		return nil
+	}
+
+	table := ui.NewTable()
+	table.AddHeader("POD", "STABLE", "CANARY", "WEIGHTS")
+	for _, s := range splits {
+		canary := s.CanaryImage
+		if canary == "" {
+			canary = "-"
+		}
+		table.AddRow(s.Pod, s.StableImage, canary, fmt.Sprintf("%d/%d", s.Stable, s.Canary))
+	}
+	return table.Render()
+}
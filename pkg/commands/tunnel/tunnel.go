@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package tunnel
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	coretunnel "github.com/Nexlayer/nexlayer-cli/pkg/core/tunnel"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates a new tunnel command
+func NewCommand(client api.APIClient) *cobra.Command {
+	var pod, local string
+
+	cmd := &cobra.Command{
+		Use:   "tunnel [namespace]",
+		Short: "Route a deployed pod's traffic to a local process",
+		Long: `Route the traffic of a deployed pod to a process running on this machine,
+so it can be debugged against the deployment's real dependencies.
+
+While the tunnel is open, connections to the pod (from other pods or from the
+internet) are forwarded to --local. Press Ctrl+C to close the tunnel and restore
+the deployed pod.
+
+The namespace defaults to the last deployment started from this directory.
+
+Examples:
+  nexlayer tunnel --pod backend --local 3000
+  nexlayer tunnel my-app-ns --pod api --local 127.0.0.1:8080`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			addr, err := coretunnel.LocalAddress(local)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			resp, err := client.OpenTunnel(ctx, namespace, pod)
+			if err != nil {
+				return fmt.Errorf("failed to open tunnel: %w", err)
+			}
+			t := resp.Data
+			defer func() {
+				cctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				if err := client.CloseTunnel(cctx, namespace, t.ID); err != nil {
+					ui.RenderWarning(fmt.Sprintf("Could not close tunnel %s: %v", t.ID, err))
+				}
+			}()
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s Tunnel open: %s/%s:%d -> %s\n", ui.Symbols().Success, namespace, pod, t.PodPort, addr)
+			fmt.Fprintln(out, "Press Ctrl+C to close it")
+
+			f := &coretunnel.Forwarder{
+				Endpoint: t.Endpoint,
+				ID:       t.ID,
+				Token:    t.Token,
+				Local:    addr,
+				OnConn: func(ev coretunnel.Event) {
+					if ev.Err != nil {
+						fmt.Fprintf(out, "%s %s %v\n", ui.Symbols().Error, ev.ConnID, ev.Err)
+						return
+					}
+					fmt.Fprintf(out, "%s %s closed (%d bytes)\n", ui.Symbols().Bullet, ev.ConnID, ev.Bytes)
+				},
+			}
+			if c, ok := client.(interface{ TLSConfig() *tls.Config }); ok {
+				f.TLS = c.TLSConfig()
+			}
+			err = f.Run(ctx)
+			fmt.Fprintf(out, "\nClosing tunnel, %s is serving %s again\n", pod, namespace)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&pod, "pod", "", "Deployed pod whose traffic is routed (required)")
+	cmd.Flags().StringVar(&local, "local", "", "Local port or host:port to route to (required)")
+	cmd.MarkFlagRequired("pod")
+	cmd.MarkFlagRequired("local")
+
+	return cmd
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/update"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/Nexlayer/nexlayer-cli/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates a new upgrade command
+func NewCommand() *cobra.Command {
+	var channel string
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade the CLI to the latest release",
+		Long: `Download the latest release for this platform, verify its checksum and
+the minisign signature of the release checksums, and replace the running
+binary in place. Builds without the release signing key, such as go install
+builds, do not upgrade.
+
+Channels:
+  stable    published releases only
+  beta      also includes prereleases
+
+The default channel is beta for prerelease builds and stable otherwise.
+
+Examples:
+  nexlayer upgrade
+  nexlayer upgrade --check
+  nexlayer upgrade --channel beta`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current := version.GetVersion()
+			if channel == "" {
+				channel = update.DefaultChannel(current)
+			}
+			if err := update.ValidateChannel(channel); err != nil {
+				return err
+			}
+
+			updater := update.NewUpdater()
+			release, err := updater.Latest(cmd.Context(), channel)
+			if err != nil {
+				return err
+			}
+			available := update.IsNewer(release.Version, current)
+
+			out := cmd.OutOrStdout()
+			if ui.Structured() && check {
+				return ui.WriteOutput(map[string]interface{}{
+					"current":   current,
+					"latest":    release.Version,
+					"channel":   channel,
+					"available": available,
+				})
+			}
+
+			if !available {
+				fmt.Fprintf(out, "%s Already up to date (%s, %s channel)\n", ui.Symbols().Success, current, channel)
+				return nil
+			}
+			if check {
+				fmt.Fprintf(out, "%s %s is available on the %s channel (current %s)\n", ui.Symbols().Info, release.Version, channel, current)
+				fmt.Fprintln(out, "  Run 'nexlayer upgrade' to install it")
+				return nil
+			}
+
+			exe, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("failed to locate the running binary: %w", err)
+			}
+			if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+				exe = resolved
+			}
+
+			fmt.Fprintf(out, "Downloading %s...\n", release.Version)
+			data, err := updater.Download(cmd.Context(), release)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s Signature and checksum verified\n", ui.Symbols().Success)
+			if err := update.Install(exe, data); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%s Upgraded %s to %s\n", ui.Symbols().Success, current, release.Version)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&channel, "channel", "", "Release channel: stable or beta")
+	cmd.Flags().BoolVar(&check, "check", false, "Only report whether a newer release is available")
+
+	return cmd
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package validate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/validate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// Result is what validate writes with --output json or yaml
+type Result struct {
+	File        string                     `json:"file"`
+	Environment string                     `json:"environment,omitempty"`
+	Application string                     `json:"application,omitempty"`
+	Valid       bool                       `json:"valid"`
+	Errors      []validate.ValidationError `json:"errors"`
+}
+
+// NewCommand creates a new validate command
+func NewCommand() *cobra.Command {
+	var env string
+
+	cmd := &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Check a deployment file without deploying it",
+		Long: `Check a deployment file the way deploy does, without deploying it. The file
+defaults to the deployment file of the current directory, and --env merges an
+environment overlay first.
+
+The command exits non-zero when the file is not valid.
+
+Examples:
+  nexlayer validate
+  nexlayer validate nexlayer.yaml --env prod
+  nexlayer validate -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := ""
+			if len(args) > 0 {
+				file = args[0]
+			} else {
+				found, err := deployment.FindFile()
+				if err != nil {
+					return err
+				}
+				file = found
+			}
+			// An invalid file is not a misuse of the command
+			cmd.SilenceUsage = true
+			return run(file, env)
+		},
+	}
+
+	cmd.Flags().StringVar(&env, "env", "", "Environment whose overlay, e.g. nexlayer.prod.yaml, is merged into the file")
+
+	return cmd
+}
+
+// run validates file, merged with the overlay of env
+func run(file, env string) error {
+	result := Result{File: file, Environment: env, Errors: []validate.ValidationError{}}
+	config, _, err := deployment.LoadEnv(file, env)
+	if err != nil {
+		if ui.Structured() {
+			result.Errors = append(result.Errors, validate.ValidationError{Message: err.Error()})
+			if werr := ui.WriteOutput(result); werr != nil {
+				return werr
+			}
+		}
+		return err
+	}
+	result.Application = config.Application.Name
+
+	validator := validate.NewValidator(config).WithBaseDir(filepath.Dir(file))
+	verr := validator.Validate()
+	if verr == nil {
+		result.Valid = true
+	} else {
+		result.Errors = validator.Errors()
+	}
+
+	if ui.Structured() {
+		if err := ui.WriteOutput(result); err != nil {
+			return err
+		}
+	} else if verr == nil {
+		ui.RenderSuccess(fmt.Sprintf("%s is valid", file))
+	} else {
+		ui.RenderError(fmt.Sprintf("%s is not valid", file))
+		fmt.Println(verr)
+	}
+
+	if verr != nil {
+		return fmt.Errorf("%d validation errors in %s", len(result.Errors), file)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package volume
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// NewCommand creates a new volume command
+func NewCommand(client api.APIClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "volume",
+		Short: "Snapshot and restore pod volumes",
+		Long: `Take and restore snapshots of the volumes of a deployment, typically the data
+of database pods.
+
+Scheduled snapshots are configured per volume in nexlayer.yaml:
+
+  volumes:
+    - name: pg-data
+      path: /var/lib/postgresql/data
+      size: 5Gi
+      class: ssd
+      snapshot:
+        schedule: "@daily"
+        retention: 7
+
+The namespace defaults to the last deployment started from this directory.`,
+	}
+
+	cmd.AddCommand(newSnapshotCommand(client))
+	cmd.AddCommand(newListCommand(client))
+	cmd.AddCommand(newRestoreCommand(client))
+
+	return cmd
+}
+
+func newSnapshotCommand(client api.APIClient) *cobra.Command {
+	var file, pod, volume string
+
+	cmd := &cobra.Command{
+		Use:   "snapshot [namespace]",
+		Short: "Take a snapshot of a volume now",
+		Long: `Take a snapshot of a pod's volume now.
+
+Without --pod and --volume the volume is picked from nexlayer.yaml when only one
+database pod declares volumes.
+
+Examples:
+  nexlayer volume snapshot
+  nexlayer volume snapshot my-app-ns --pod postgres --volume pg-data`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			if pod == "" || volume == "" {
+				if pod, volume, err = defaultVolume(file, pod, volume); err != nil {
+					return err
+				}
+			}
+
+			resp, err := client.CreateVolumeSnapshot(cmd.Context(), namespace, pod, volume)
+			if err != nil {
+				return fmt.Errorf("failed to create snapshot: %w", err)
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(resp.Data)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Snapshot %s of %s/%s started\n", ui.Symbols().Success, resp.Data.ID, pod, volume)
+			fmt.Fprintf(cmd.OutOrStdout(), "Restore it with 'nexlayer volume restore %s %s'\n", namespace, resp.Data.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "nexlayer.yaml", "Configuration used to pick the volume")
+	cmd.Flags().StringVar(&pod, "pod", "", "Pod that mounts the volume")
+	cmd.Flags().StringVar(&volume, "volume", "", "Volume to snapshot")
+
+	return cmd
+}
+
+func newListCommand(client api.APIClient) *cobra.Command {
+	var pod string
+
+	cmd := &cobra.Command{
+		Use:               "snapshots [namespace]",
+		Aliases:           []string{"ls"},
+		Short:             "List the volume snapshots of a deployment",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			resp, err := client.ListVolumeSnapshots(cmd.Context(), namespace)
+			if err != nil {
+				return fmt.Errorf("failed to list snapshots: %w", err)
+			}
+
+			snapshots := make([]apischema.VolumeSnapshot, 0, len(resp.Data))
+			for _, s := range resp.Data {
+				if pod == "" || s.Pod == pod {
+					snapshots = append(snapshots, s)
+				}
+			}
+			if ui.Structured() {
+				return ui.WriteOutput(snapshots)
+			}
+			if len(snapshots) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No snapshots in %s\n", namespace)
+				return nil
+			}
+
+			table := ui.NewTable()
+			table.AddHeader("ID", "POD", "VOLUME", "SIZE", "STATUS", "SOURCE", "CREATED")
+			for _, s := range snapshots {
+				source := "manual"
+				if s.Scheduled {
+					source = "schedule"
+				}
+				table.AddRow(s.ID, s.Pod, s.Volume, fmt.Sprintf("%.1f GB", s.SizeGB), s.Status, source,
+					s.CreatedAt.Local().Format("2006-01-02 15:04"))
+			}
+			return table.Render()
+		},
+	}
+
+	cmd.Flags().StringVar(&pod, "pod", "", "Only list snapshots of this pod")
+
+	return cmd
+}
+
+func newRestoreCommand(client api.APIClient) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "restore [namespace] <snapshot-id>",
+		Short: "Restore a volume from a snapshot",
+		Long: `Replace the contents of a volume with a snapshot. The pod mounting the volume
+is restarted and any data written since the snapshot is lost.
+
+Examples:
+  nexlayer volume restore snap-4f2a9c
+  nexlayer volume restore my-app-ns snap-4f2a9c --yes`,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[len(args)-1]
+			namespace, err := deployment.ResolveNamespace(args[:len(args)-1])
+			if err != nil {
+				return err
+			}
+
+			if !yes {
+				prompt := promptui.Prompt{
+					Label:     fmt.Sprintf("Restore %s in %s? Data written since the snapshot will be lost", id, namespace),
+					IsConfirm: true,
+				}
+				if result, err := prompt.Run(); err != nil || strings.ToLower(result) != "y" {
+					return fmt.Errorf("restore cancelled")
+				}
+			}
+
+			if err := client.RestoreVolumeSnapshot(cmd.Context(), namespace, id); err != nil {
+				return fmt.Errorf("failed to restore snapshot: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s Restoring %s in %s; the pod restarts when the volume is ready\n", ui.Symbols().Success, id, namespace)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Restore without asking for confirmation")
+
+	return cmd
+}
+
+// defaultVolume fills in the pod and volume from the configuration when there
+// is a single candidate, preferring database pods
+func defaultVolume(file, pod, volume string) (string, string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", "", fmt.Errorf("--pod and --volume are required without %s: %w", file, err)
+	}
+	var config schema.NexlayerYAML
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return "", "", fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if _, err := schema.ExpandServices(&config); err != nil {
+		return "", "", err
+	}
+
+	var candidates []schema.Pod
+	for _, p := range config.Application.Pods {
+		if len(p.Volumes) == 0 || (pod != "" && p.Name != pod) {
+			continue
+		}
+		if pod != "" || p.IsDatabase() {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) != 1 {
+		if pod != "" {
+			return "", "", fmt.Errorf("pod %s has no volumes in %s", pod, file)
+		}
+		return "", "", fmt.Errorf("cannot pick a volume from %s; use --pod and --volume", file)
+	}
+
+	p := candidates[0]
+	if volume == "" {
+		if len(p.Volumes) != 1 {
+			return "", "", fmt.Errorf("pod %s has %d volumes; use --volume", p.Name, len(p.Volumes))
+		}
+		volume = p.Volumes[0].Name
+	}
+	return p.Name, volume, nil
+}
@@ -0,0 +1,374 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/commands/completion"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/ui/styles"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// maxEvents is how many events the dashboard keeps
+const maxEvents = 50
+
+var (
+	sectionStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Primary)
+	mutedStyle   = lipgloss.NewStyle().Foreground(styles.TextSecondary)
+	okStyle      = lipgloss.NewStyle().Foreground(styles.Success)
+	badStyle     = lipgloss.NewStyle().Foreground(styles.Error)
+	pendingStyle = lipgloss.NewStyle().Foreground(styles.Warning)
+)
+
+// newDashboardCommand creates the watch dashboard command
+func newDashboardCommand(client api.APIClient) *cobra.Command {
+	var interval time.Duration
+	var tail int
+
+	cmd := &cobra.Command{
+		Use:     "dashboard [namespace]",
+		Aliases: []string{"status"},
+		Short:   "Show the live status of a deployment",
+		Long: `Show a live view of a deployment: its pods with their state, readiness and
+restarts, the changes seen since the view opened, and the end of its logs.
+The view refreshes every --interval; press r to refresh now and q to quit.
+
+When the output is not a terminal the view is printed once.
+
+The namespace defaults to the last deployment started from this directory.
+
+Examples:
+  nexlayer watch dashboard
+  nexlayer watch dashboard my-app-ns --interval 5s`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.Namespaces(client, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := deployment.ResolveNamespace(args)
+			if err != nil {
+				return err
+			}
+			if interval < time.Second {
+				return fmt.Errorf("--interval must be at least 1s")
+			}
+			m := &dashboard{ctx: cmd.Context(), client: client, namespace: namespace, interval: interval, tail: tail}
+
+			if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+				m.once = true
+				m.apply(m.fetch()())
+				fmt.Fprintln(cmd.OutOrStdout(), m.View())
+				return nil
+			}
+
+			// Request traces would scroll the view away
+			if c, ok := client.(interface{ SetDebugOutput(io.Writer) }); ok {
+				c.SetDebugOutput(nil)
+			}
+			_, err = tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(cmd.Context())).Run()
+			if err == tea.ErrProgramKilled {
+				return nil
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "Time between refreshes")
+	cmd.Flags().IntVarP(&tail, "tail", "n", 20, "Number of log lines to show")
+
+	return cmd
+}
+
+// refreshMsg carries the state fetched by a refresh
+type refreshMsg struct {
+	at      time.Time
+	info    *apischema.Deployment
+	logs    []string
+	infoErr error
+	logsErr error
+}
+
+// tickMsg asks for the refresh numbered sequence
+type tickMsg int
+
+// dashboard is the bubbletea model of the watch dashboard
+type dashboard struct {
+	ctx       context.Context
+	client    api.APIClient
+	namespace string
+	interval  time.Duration
+	tail      int
+
+	info     *apischema.Deployment
+	logs     []string
+	events   []deployment.Event
+	err      error
+	updated  time.Time
+	loading  bool
+	once     bool
+	sequence int
+	width    int
+	height   int
+}
+
+func (m *dashboard) Init() tea.Cmd {
+	m.loading = true
+	return m.fetch()
+}
+
+func (m *dashboard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "r":
+			if !m.loading {
+				m.loading = true
+				m.sequence++
+				return m, m.fetch()
+			}
+		}
+	case tickMsg:
+		// A manual refresh in between makes older ticks stale
+		if int(msg) == m.sequence && !m.loading {
+			m.loading = true
+			return m, m.fetch()
+		}
+	case refreshMsg:
+		m.apply(msg)
+		m.loading = false
+		seq := m.sequence
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg { return tickMsg(seq) })
+	}
+	return m, nil
+}
+
+// fetch returns a command getting the deployment and its logs
+func (m *dashboard) fetch() func() tea.Msg {
+	ctx, client, namespace, tail := m.ctx, m.client, m.namespace, m.tail
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		msg := refreshMsg{at: time.Now()}
+		resp, err := client.GetDeploymentInfo(ctx, namespace)
+		if err != nil {
+			msg.infoErr = err
+		} else {
+			msg.info = &resp.Data
+		}
+		if tail > 0 {
+			msg.logs, msg.logsErr = client.GetLogs(ctx, namespace, "", false, tail)
+		}
+		return msg
+	}
+}
+
+// apply records a refresh, deriving events from the previous status
+func (m *dashboard) apply(msg tea.Msg) {
+	r, ok := msg.(refreshMsg)
+	if !ok {
+		return
+	}
+	m.updated = r.at
+	m.err = r.infoErr
+	if r.info != nil {
+		if m.info != nil {
+			m.events = append(m.events, deployment.Events(*m.info, *r.info, r.at)...)
+			if len(m.events) > maxEvents {
+				m.events = m.events[len(m.events)-maxEvents:]
+			}
+		}
+		m.info = r.info
+	}
+	if r.logsErr != nil {
+		m.logs = []string{badStyle.Render(fmt.Sprintf("could not get logs: %v", r.logsErr))}
+	} else if r.logs != nil || r.infoErr == nil {
+		m.logs = r.logs
+	}
+}
+
+func (m *dashboard) View() string {
+	var b strings.Builder
+	title := sectionStyle.Render("nexlayer · " + m.namespace)
+	if m.info != nil {
+		title += "  " + formatState(m.info.Status)
+		if m.info.URL != "" {
+			title += "  " + mutedStyle.Render(m.info.URL)
+		}
+	}
+	b.WriteString(title + "\n")
+	switch {
+	case m.err != nil:
+		b.WriteString(badStyle.Render(fmt.Sprintf("could not get the deployment: %v", m.err)) + "\n")
+	case m.info == nil:
+		b.WriteString(mutedStyle.Render("loading...") + "\n")
+	}
+
+	if m.info != nil {
+		b.WriteString("\n" + sectionStyle.Render("Pods") + "\n")
+		if len(m.info.PodStatuses) == 0 {
+			b.WriteString(mutedStyle.Render("no pods") + "\n")
+		} else {
+			b.WriteString(m.podTable())
+		}
+	}
+
+	b.WriteString("\n" + sectionStyle.Render("Events") + "\n")
+	events := m.events
+	if n := m.eventRows(); len(events) > n {
+		events = events[len(events)-n:]
+	}
+	if len(events) == 0 {
+		b.WriteString(mutedStyle.Render("no changes since the dashboard opened") + "\n")
+	}
+	for _, e := range events {
+		line := e.Time.Local().Format("15:04:05") + " " + e.Message
+		if e.Warning {
+			line = pendingStyle.Render(line)
+		}
+		b.WriteString(m.fit(line) + "\n")
+	}
+
+	if m.tail > 0 {
+		b.WriteString("\n" + sectionStyle.Render("Logs") + "\n")
+		logs := m.logs
+		if n := m.logRows(); len(logs) > n {
+			logs = logs[len(logs)-n:]
+		}
+		if len(logs) == 0 {
+			b.WriteString(mutedStyle.Render("no logs yet") + "\n")
+		}
+		for _, line := range logs {
+			b.WriteString(m.fit(line) + "\n")
+		}
+	}
+
+	footer := "updated " + m.updated.Local().Format("15:04:05")
+	if m.updated.IsZero() {
+		footer = "updating"
+	} else if m.loading {
+		footer += ", refreshing"
+	}
+	if !m.once {
+		footer += " · r refresh · q quit"
+	}
+	b.WriteString("\n" + mutedStyle.Render(footer))
+	return b.String()
+}
+
+// podTable renders the pods with aligned columns
+func (m *dashboard) podTable() string {
+	rows := [][]string{{"NAME", "STATE", "READY", "RESTARTS", "LAST EXIT", "AGE"}}
+	for _, pod := range m.info.PodStatuses {
+		ready, exit, age := "no", "-", "-"
+		if pod.Ready {
+			ready = "yes"
+		}
+		if pod.LastExit != nil {
+			exit = fmt.Sprintf("%d", pod.LastExit.ExitCode)
+			if pod.LastExit.Reason != "" {
+				exit += " " + pod.LastExit.Reason
+			}
+		}
+		if !pod.CreatedAt.IsZero() {
+			age = deployment.FormatSince(time.Since(pod.CreatedAt))
+		}
+		state := pod.Status
+		if pod.Waiting != "" {
+			state = pod.Waiting
+		}
+		rows = append(rows, []string{pod.Name, state, ready, fmt.Sprintf("%d", pod.Restarts), exit, age})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+	var b strings.Builder
+	for r, row := range rows {
+		var cells []string
+		for i, cell := range row {
+			padded := cell + strings.Repeat(" ", widths[i]-len(cell))
+			switch {
+			case r == 0:
+				padded = mutedStyle.Render(padded)
+			case i == 1:
+				padded = formatState(padded)
+			case i == 3 && m.info.PodStatuses[r-1].Restarts >= deployment.RestartThreshold:
+				padded = pendingStyle.Render(padded)
+			}
+			cells = append(cells, padded)
+		}
+		b.WriteString(m.fit(strings.TrimRight(strings.Join(cells, "  "), " ")) + "\n")
+	}
+	return b.String()
+}
+
+// eventRows is how many events fit, a third of what the pods leave
+func (m *dashboard) eventRows() int {
+	if m.height == 0 {
+		return 10
+	}
+	return max(3, m.free()/3)
+}
+
+// logRows is how many log lines fit below the events
+func (m *dashboard) logRows() int {
+	if m.height == 0 {
+		return m.tail
+	}
+	events := min(len(m.events), m.eventRows())
+	return max(3, m.free()-max(events, 1)-2)
+}
+
+// free is the number of rows left after the header, the pods and the footer
+func (m *dashboard) free() int {
+	used := 6
+	if m.info != nil {
+		used += len(m.info.PodStatuses) + 3
+	}
+	return m.height - used
+}
+
+// fit cuts a line to the width of the terminal
+func (m *dashboard) fit(line string) string {
+	if m.width == 0 || lipgloss.Width(line) <= m.width {
+		return line
+	}
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+}
+
+// formatState colors a deployment or pod state
+func formatState(state string) string {
+	switch strings.ToLower(strings.TrimSpace(state)) {
+	case "running", "ready", "succeeded", "completed", "healthy":
+		return okStyle.Render(state)
+	case "failed", "error", "crashloopbackoff", "imagepullbackoff", "errimagepull":
+		return badStyle.Render(state)
+	default:
+		return pendingStyle.Render(state)
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -14,6 +14,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
 	"github.com/Nexlayer/nexlayer-cli/pkg/detection"
 	"github.com/charmbracelet/lipgloss"
@@ -53,7 +54,7 @@ var (
 )
 
 // NewCommand creates a new watch command
-func NewCommand() *cobra.Command {
+func NewCommand(client api.APIClient) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "watch",
 		Short: "Monitor project changes and update configuration",
@@ -61,7 +62,9 @@ func NewCommand() *cobra.Command {
 When changes are detected (new dependencies, frameworks, services, Docker images, etc.),
 the configuration will be updated to match the current project state.
 
-The command runs in the foreground. Press Ctrl+C to stop watching.`,
+The command runs in the foreground. Press Ctrl+C to stop watching.
+
+To watch a deployment rather than the project, use 'nexlayer watch dashboard'.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Find nexlayer.yaml in current directory
@@ -74,6 +77,8 @@ The command runs in the foreground. Press Ctrl+C to stop watching.`,
 		},
 	}
 
+	cmd.AddCommand(newDashboardCommand(client))
+
 	return cmd
 }
 
@@ -7,12 +7,14 @@ package ai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/cache"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
 	"github.com/Nexlayer/nexlayer-cli/pkg/detection"
 	"github.com/Nexlayer/nexlayer-cli/pkg/knowledge"
@@ -40,13 +42,21 @@ type EnhancementResult struct {
 type Enhancer struct {
 	llmEnricher     *knowledge.LLMEnricher
 	detectionMgr    *detection.DetectionManager
-	cache           sync.Map             // Cache for enhancement results
+	cache           sync.Map             // Cache for enhancement results, of cachedEnhancement
 	cacheTTL        time.Duration        // How long to cache results
 	analysisTimeout time.Duration        // Timeout for analysis operations
 	enhancementChan chan enhancementTask // Channel for background enhancements
 	wg              sync.WaitGroup       // WaitGroup for background tasks
 }
 
+// cachedEnhancement is an enhancement result and when it was made. Results
+// are only kept for the run: the answers of models they are made of are
+// cached on disk by the LLM enricher.
+type cachedEnhancement struct {
+	result *EnhancementResult
+	at     time.Time
+}
+
 type enhancementTask struct {
 	ctx          context.Context
 	config       *schema.NexlayerYAML
@@ -103,16 +113,16 @@ func (e *Enhancer) EnhanceAsync(ctx context.Context, config *schema.NexlayerYAML
 	errCh := make(chan error, 1)
 
 	// Generate a cache key from the configuration
-	cacheKey := generateCacheKey(config)
+	cacheKey := generateCacheKey(config, detectionDir)
 
 	// Check if we have a cached result
 	if cachedValue, found := e.cache.Load(cacheKey); found {
-		cachedResult := cachedValue.(*EnhancementResult)
+		cached := cachedValue.(cachedEnhancement)
 		// Check if cache is still valid
-		if time.Since(cachedResult.DetectionResult.CompletedAt) < e.cacheTTL {
+		if time.Since(cached.at) < e.cacheTTL {
 			// Return cached result
 			go func() {
-				resultCh <- cachedResult
+				resultCh <- cached.result
 				close(resultCh)
 				close(errCh)
 			}()
@@ -233,8 +243,8 @@ func (e *Enhancer) performEnhancement(ctx context.Context, config *schema.Nexlay
 	result.EnhancementTime = time.Since(startTime)
 
 	// Cache the result
-	cacheKey := generateCacheKey(config)
-	e.cache.Store(cacheKey, result)
+	cacheKey := generateCacheKey(config, detectionDir)
+	e.cache.Store(cacheKey, cachedEnhancement{result: result, at: time.Now()})
 
 	return result, nil
 }
@@ -242,7 +252,7 @@ func (e *Enhancer) performEnhancement(ctx context.Context, config *schema.Nexlay
 // analyzeDeploymentIssues uses LLM to identify deployment issues
 func (e *Enhancer) analyzeDeploymentIssues(ctx context.Context, config *schema.NexlayerYAML) ([]EnhancementIssue, error) {
 	prompt := "Analyze the following Nexlayer configuration for deployment issues. " +
-		"Focus on missing ports, improper volume configurations, and pod dependencies."
+		"Focus on missing ports, improper volume configurations, pod dependencies and missing health probes."
 
 	llmResult, err := e.llmEnricher.QueryLLM(ctx, prompt, config)
 	if err != nil {
@@ -339,7 +349,7 @@ func (e *Enhancer) performBasicAnalysis(config *schema.NexlayerYAML) *Enhancemen
 
 	// Check for missing ports
 	for _, pod := range config.Application.Pods {
-		if len(pod.ServicePorts) == 0 {
+		if len(pod.ServicePorts) == 0 && !pod.IsJob() {
 			result.Issues = append(result.Issues, EnhancementIssue{
 				Type:    "error",
 				Field:   fmt.Sprintf("pods.%s.servicePorts", pod.Name),
@@ -382,6 +392,29 @@ func (e *Enhancer) performBasicAnalysis(config *schema.NexlayerYAML) *Enhancemen
 		}
 	}
 
+	// Check serving pods for health probes
+	for _, pod := range config.Application.Pods {
+		if len(pod.ServicePorts) == 0 || pod.HasProbes() {
+			continue
+		}
+		port := pod.ServicePorts[0].TargetPort
+		suggestion := fmt.Sprintf("Add a TCP readiness probe: probes: {readiness: {tcpSocket: {port: %d}}}", port)
+		if isDatabase(pod.Image) {
+			suggestion = fmt.Sprintf("Add a readiness probe running the database's own check, or probes: {readiness: {tcpSocket: {port: %d}}}", port)
+		} else if pod.Path != "" || pod.IsStatic() {
+			suggestion = fmt.Sprintf("Add an HTTP readiness probe: probes: {readiness: {httpGet: {path: /health, port: %d}}}", port)
+		}
+		result.Issues = append(result.Issues, EnhancementIssue{
+			Type:    "suggestion",
+			Field:   fmt.Sprintf("pods.%s.probes", pod.Name),
+			Message: fmt.Sprintf("Pod '%s' has no health probes", pod.Name),
+			Suggestions: []string{
+				suggestion,
+				"Add a liveness probe so that the pod is restarted when it hangs",
+			},
+		})
+	}
+
 	return result
 }
 
@@ -459,15 +492,11 @@ func isDatabase(image string) bool {
 	return false
 }
 
-// generateCacheKey generates a cache key for a configuration
-func generateCacheKey(config *schema.NexlayerYAML) string {
-	if config == nil {
-		return "nil-config"
-	}
-
-	// Simple hash based on application name and number of pods
-	key := fmt.Sprintf("%s-%d", config.Application.Name, len(config.Application.Pods))
-	return key
+// generateCacheKey hashes a configuration and the directory detected into a
+// cache key
+func generateCacheKey(config *schema.NexlayerYAML, detectionDir string) string {
+	encoded, _ := json.Marshal(config)
+	return cache.Key(detectionDir, string(encoded))
 }
 
 // Shutdown gracefully shuts down the enhancer
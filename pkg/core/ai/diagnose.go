@@ -0,0 +1,436 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/knowledge"
+	"gopkg.in/yaml.v3"
+)
+
+// maxDiagnoseLogLines bounds the log lines sent to the model, the most
+// recent ones
+const maxDiagnoseLogLines = 200
+
+// maxCauses bounds the causes of a diagnosis
+const maxCauses = 5
+
+// Evidence is what is known about a failing deployment
+type Evidence struct {
+	Deployment *apischema.Deployment // status of the deployment, if found
+	Logs       []apischema.LogLine   // recent log lines, oldest first
+	Config     *schema.NexlayerYAML  // the deployed configuration, if found
+	URL        string                // URL of the application
+	URLError   string                // why the URL did not answer, e.g. a 502
+	Error      string                // error of the failed deployment, if known
+}
+
+// Cause is a probable root cause of a failure, with fixes
+type Cause struct {
+	Pod      string   `json:"pod,omitempty"`
+	Summary  string   `json:"summary"`
+	Evidence string   `json:"evidence,omitempty"` // log line or status pointing at it
+	Fixes    []string `json:"fixes"`
+	score    int
+}
+
+// Diagnosis lists the probable root causes of a failure, most likely first
+type Diagnosis struct {
+	Causes   []Cause `json:"causes"`
+	Source   string  `json:"source"`             // knowledge.SourceAPI, SourceCache or SourceHeuristic
+	Model    string  `json:"model,omitempty"`    // provider and model that ranked the causes
+	Fallback string  `json:"fallback,omitempty"` // why the built-in rules answered instead of the model
+}
+
+// logRule recognizes a failure in log lines
+type logRule struct {
+	pattern *regexp.Regexp
+	score   int
+	summary string // %s is the pod
+	fixes   []string
+}
+
+// logRules are the failures recognized in logs, most telling first
+var logRules = []logRule{
+	{regexp.MustCompile(`(?i)exec format error`), 90,
+		"%s's image was built for another CPU architecture",
+		[]string{"Build the image for linux/amd64: docker buildx build --platform linux/amd64 ..."}},
+	{regexp.MustCompile(`(?i)EADDRINUSE|address already in use`), 85,
+		"Two processes of %s listen on the same port",
+		[]string{"Run one server per pod, or give each process its own port and servicePorts entry"}},
+	{regexp.MustCompile(`(?i)(?:listening|running|started|serving)\b.*\b(?:127\.0\.0\.1|localhost)\b`), 80,
+		"%s listens on localhost only, so the gateway and other pods cannot reach it",
+		[]string{"Make the server listen on 0.0.0.0, e.g. --host 0.0.0.0 or HOST=0.0.0.0"}},
+	{regexp.MustCompile(`(?i)password authentication failed|access denied for user|WRONGPASS|NOAUTH|authentication failed`), 75,
+		"%s's database credentials are rejected",
+		[]string{
+			"Make the credential vars of the pod match those of the database pod",
+			"A database volume keeps the credentials it was initialized with; update them in the database or recreate the volume",
+		}},
+	{regexp.MustCompile(`(?i)ENOTFOUND|no such host|Name or service not known|could not translate host name|getaddrinfo`), 70,
+		"%s connects to a host name that does not resolve",
+		[]string{"Pods reach each other at <pod>.pod; check the host names in the vars of the pod"}},
+	{regexp.MustCompile(`(?i)(?:ECONNREFUSED|connection refused).*\b(?:127\.0\.0\.1|localhost)\b|\b(?:127\.0\.0\.1|localhost)\b.*(?:ECONNREFUSED|connection refused)`), 70,
+		"%s connects to localhost instead of the pod serving the dependency",
+		[]string{"Point the vars of the pod at <pod>.pod, e.g. DATABASE_HOST=postgres.pod"}},
+	{regexp.MustCompile(`(?i)ECONNREFUSED|connection refused`), 60,
+		"%s cannot reach a service it depends on",
+		[]string{
+			"Check that the dependency is running and its host and port in the vars of the pod",
+			"Add a readiness probe to the dependency and retry connections at startup",
+		}},
+	{regexp.MustCompile(`(?i)JavaScript heap out of memory|OutOfMemoryError|out of memory|MemoryError`), 65,
+		"%s runs out of memory",
+		[]string{"Raise the memory limit in resources, or reduce memory use, e.g. --max-old-space-size for Node or fewer workers"}},
+	{regexp.MustCompile(`(?i)no space left on device`), 65,
+		"A volume of %s is full",
+		[]string{"Increase the size of the volume, or clean up its data"}},
+	{regexp.MustCompile(`(?i)Cannot find module|ModuleNotFoundError|No module named|ImportError|cannot find package`), 60,
+		"%s's image lacks a dependency of the application",
+		[]string{"Install the dependencies in the Dockerfile, e.g. npm ci or pip install -r requirements.txt, and rebuild the image"}},
+	{regexp.MustCompile(`(?i)environment variable .*(?:not set|required|missing|undefined)|missing required (?:env|environment)|KeyError: '[A-Z_]+'`), 60,
+		"%s lacks an environment variable",
+		[]string{"Add the variable to the vars of the pod, or store it with nexlayer secrets set"}},
+	{regexp.MustCompile(`(?i)permission denied|EACCES`), 50,
+		"%s lacks the permission to open a file or port",
+		[]string{"Listen on a port above 1024, or chown the files the process writes in the Dockerfile"}},
+	{regexp.MustCompile(`(?i)^panic:|Traceback \(most recent call last\)|Unhandled (?:exception|rejection)|\bFATAL\b`), 40,
+		"%s crashes",
+		[]string{"Fix the first error in the logs of the pod: nexlayer logs --pod <pod>"}},
+}
+
+// listenPort finds the port a server announces it listens on
+var listenPort = regexp.MustCompile(`(?i)(?:listening|running|started|serving)\b.*?(?:\bport\s+|(?:localhost|[\d.]{7,15}|\]|\*|\s):)(\d{2,5})\b`)
+
+// Diagnose finds the probable root causes of a failed deployment or of an
+// application that does not answer. Built-in rules read the status, logs
+// and configuration; with a model, it ranks their findings with its own and
+// suggests fixes.
+func (e *Enhancer) Diagnose(ctx context.Context, ev Evidence) (*Diagnosis, error) {
+	causes := ruleCauses(ev, time.Now())
+	diagnosis := &Diagnosis{Causes: causes, Source: knowledge.SourceHeuristic}
+	if e.llmEnricher == nil {
+		return diagnosis, nil
+	}
+
+	result, err := e.llmEnricher.QueryLLM(ctx, diagnosePrompt(ev, causes), ev.Config)
+	if err != nil {
+		return nil, fmt.Errorf("LLM query failed: %w", err)
+	}
+	if result.Source == knowledge.SourceHeuristic {
+		diagnosis.Fallback = result.Fallback
+		return diagnosis, nil
+	}
+	parsed := parseCausesFromLLMResponse(result.Result, podNames(ev))
+	if len(parsed) == 0 {
+		diagnosis.Fallback = "the answer of " + result.Model + " listed no causes"
+		return diagnosis, nil
+	}
+	return &Diagnosis{Causes: parsed, Source: result.Source, Model: result.Model}, nil
+}
+
+// ruleCauses finds causes in the evidence with the built-in rules, most
+// likely first
+func ruleCauses(ev Evidence, now time.Time) []Cause {
+	var causes []Cause
+	add := func(c Cause) {
+		for _, existing := range causes {
+			if existing.Pod == c.Pod && existing.Summary == c.Summary {
+				return
+			}
+		}
+		causes = append(causes, c)
+	}
+
+	// Crash loops, out-of-memory kills and image pulls
+	if ev.Deployment != nil {
+		for _, in := range deployment.Diagnose(*ev.Deployment, now) {
+			add(Cause{Pod: in.Pod, Summary: in.Message, Fixes: []string{capitalize(in.Hint)}, score: 100})
+		}
+	}
+
+	// Failures in the logs, the first match of each rule per pod; a line
+	// only counts for the most telling rule it matches
+	explained := make(map[apischema.LogLine]bool)
+	for _, rule := range logRules {
+		seen := make(map[string]bool)
+		for _, line := range ev.Logs {
+			if seen[line.Pod] || explained[line] || !rule.pattern.MatchString(line.Message) {
+				continue
+			}
+			seen[line.Pod], explained[line] = true, true
+			summary := fmt.Sprintf(rule.summary, line.Pod)
+			if line.Pod == "" {
+				summary = capitalize(fmt.Sprintf(rule.summary, "the application"))
+			}
+			add(Cause{
+				Pod:      line.Pod,
+				Summary:  summary,
+				Evidence: strings.TrimSpace(line.Message),
+				Fixes:    rule.fixes,
+				score:    rule.score,
+			})
+		}
+	}
+
+	// Servers listening on another port than the one traffic is sent to
+	if ev.Config != nil {
+		for _, line := range ev.Logs {
+			pod := findPod(ev.Config, line.Pod)
+			m := listenPort.FindStringSubmatch(line.Message)
+			if pod == nil || m == nil || len(pod.ServicePorts) == 0 {
+				continue
+			}
+			port, _ := strconv.Atoi(m[1])
+			if hasTargetPort(pod, port) {
+				continue
+			}
+			add(Cause{
+				Pod:      pod.Name,
+				Summary:  fmt.Sprintf("%s listens on port %d but its servicePorts send traffic to port %d", pod.Name, port, pod.ServicePorts[0].TargetPort),
+				Evidence: strings.TrimSpace(line.Message),
+				Fixes:    []string{fmt.Sprintf("Set targetPort: %d in the servicePorts of %s, or make the server listen on %d", port, pod.Name, pod.ServicePorts[0].TargetPort)},
+				score:    95,
+			})
+		}
+	}
+
+	// The gateway cannot reach any pod
+	if ev.URLError != "" {
+		cause := Cause{Summary: "The application URL does not answer", Evidence: ev.URLError, score: 55}
+		switch {
+		case ev.Config != nil && !deployment.ServesURL(ev.Config):
+			cause.Summary = "No pod is routed at the application URL"
+			cause.Fixes = []string{"Set path: / on the pod serving the web application"}
+			cause.score = 90
+		case ev.Deployment != nil && len(deployment.FailingPods(*ev.Deployment)) > 0:
+			cause.Fixes = []string{"Fix the failing pods above; the URL answers once a routed pod is ready"}
+		case ev.Deployment != nil && !allReady(*ev.Deployment):
+			cause.Summary = "The pods serving the application URL are not ready yet"
+			cause.Fixes = []string{"Wait for the pods to become ready: nexlayer watch dashboard", "Check the readiness probes of the routed pods"}
+		default:
+			cause.Summary = "The gateway cannot reach the pod routed at the application URL"
+			cause.Fixes = []string{
+				"Check that the server listens on 0.0.0.0 at the targetPort of its servicePorts",
+				"Check the path of the routed pod and that the application serves it",
+			}
+		}
+		add(cause)
+	}
+
+	// The deployment request itself failed
+	if ev.Error != "" && len(causes) == 0 {
+		add(Cause{
+			Summary: "The deployment was rejected: " + ev.Error,
+			Fixes:   []string{"Validate the configuration: nexlayer validate", "Check the configuration for errors and bad practices: nexlayer lint"},
+			score:   50,
+		})
+	}
+
+	// Configuration errors, which matter less once pods run
+	if ev.Config != nil {
+		for _, issue := range (&Enhancer{}).performBasicAnalysis(ev.Config).Issues {
+			if issue.Type != "error" {
+				continue
+			}
+			add(Cause{Summary: issue.Message, Evidence: issue.Field, Fixes: issue.Suggestions, score: 30})
+		}
+	}
+
+	sort.SliceStable(causes, func(i, j int) bool { return causes[i].score > causes[j].score })
+	if len(causes) > maxCauses {
+		causes = causes[:maxCauses]
+	}
+	return causes
+}
+
+// diagnosePrompt asks the model for the root causes, with the evidence and
+// the findings of the built-in rules
+func diagnosePrompt(ev Evidence, causes []Cause) string {
+	var b strings.Builder
+	b.WriteString("Diagnose why this Nexlayer deployment fails or its URL does not answer. ")
+	fmt.Fprintf(&b, "List at most %d probable root causes, most likely first, one per line as:\n", maxCauses)
+	b.WriteString("- <pod>: <root cause> => <fix>; <another fix>\n")
+	b.WriteString("Leave out \"<pod>: \" when the cause is not about one pod. Base the causes on the evidence below; ")
+	b.WriteString("fixes must be concrete changes to nexlayer.yaml, the image or the application.\n")
+
+	if ev.Error != "" {
+		fmt.Fprintf(&b, "\nDeployment error: %s\n", ev.Error)
+	}
+	if d := ev.Deployment; d != nil {
+		fmt.Fprintf(&b, "\nDeployment %s: status %s, URL %s\n", d.Namespace, d.Status, d.URL)
+		for _, pod := range d.PodStatuses {
+			fmt.Fprintf(&b, "- pod %s: status %s, ready %t, %d restarts, image %s", pod.Name, pod.Status, pod.Ready, pod.Restarts, pod.Image)
+			if pod.Waiting != "" {
+				fmt.Fprintf(&b, ", waiting: %s", pod.Waiting)
+			}
+			if t := pod.LastExit; t != nil {
+				fmt.Fprintf(&b, ", last exit code %d (%s)", t.ExitCode, t.Reason)
+			}
+			b.WriteString("\n")
+		}
+	}
+	if ev.URLError != "" {
+		fmt.Fprintf(&b, "\nURL check: %s\n", ev.URLError)
+	}
+	if len(causes) > 0 {
+		b.WriteString("\nFindings of the built-in rules:\n")
+		for _, c := range causes {
+			fmt.Fprintf(&b, "- %s", c.Summary)
+			if c.Evidence != "" {
+				fmt.Fprintf(&b, " (%s)", c.Evidence)
+			}
+			b.WriteString("\n")
+		}
+	}
+	if ev.Config != nil {
+		if config, err := redactedYAML(ev.Config); err == nil {
+			fmt.Fprintf(&b, "\nnexlayer.yaml:\n%s", config)
+		}
+	}
+	if len(ev.Logs) > 0 {
+		logs := ev.Logs[max(0, len(ev.Logs)-maxDiagnoseLogLines):]
+		b.WriteString("\nRecent logs:\n")
+		for _, line := range logs {
+			if line.Pod != "" {
+				fmt.Fprintf(&b, "%s | ", line.Pod)
+			}
+			b.WriteString(line.Message)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// parseCausesFromLLMResponse parses the causes of a diagnosis from an LLM
+// response, keeping the order of the model
+func parseCausesFromLLMResponse(response string, pods map[string]bool) []Cause {
+	var causes []Cause
+	for _, line := range strings.Split(response, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "):
+			line = strings.TrimSpace(line[2:])
+		case len(line) > 2 && line[0] >= '1' && line[0] <= '9' && (line[1] == '.' || line[1] == ')'):
+			line = strings.TrimSpace(line[2:])
+		default:
+			continue
+		}
+		if line == "" || strings.EqualFold(strings.TrimSuffix(line, "."), "No issues found") {
+			continue
+		}
+
+		cause := Cause{Fixes: []string{}}
+		summary, fixes, _ := strings.Cut(line, "=>")
+		if pod, rest, ok := strings.Cut(summary, ":"); ok && pods[strings.Trim(strings.TrimSpace(pod), "`*")] {
+			cause.Pod = strings.Trim(strings.TrimSpace(pod), "`*")
+			summary = rest
+		}
+		cause.Summary = strings.TrimSpace(summary)
+		for _, fix := range strings.Split(fixes, ";") {
+			if fix = strings.TrimSpace(fix); fix != "" {
+				cause.Fixes = append(cause.Fixes, capitalize(fix))
+			}
+		}
+		if cause.Summary != "" {
+			causes = append(causes, cause)
+		}
+		if len(causes) == maxCauses {
+			break
+		}
+	}
+	return causes
+}
+
+// redactedYAML encodes a configuration without its secrets, leaving the
+// configuration as it is
+func redactedYAML(config *schema.NexlayerYAML) (string, error) {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	var copied schema.NexlayerYAML
+	if err := yaml.Unmarshal(data, &copied); err != nil {
+		return "", err
+	}
+	schema.Redact(&copied)
+	data, err = yaml.Marshal(&copied)
+	return string(data), err
+}
+
+// podNames returns the names of the pods in the evidence
+func podNames(ev Evidence) map[string]bool {
+	names := make(map[string]bool)
+	if ev.Deployment != nil {
+		for _, pod := range ev.Deployment.PodStatuses {
+			names[pod.Name] = true
+		}
+	}
+	if ev.Config != nil {
+		for _, pod := range ev.Config.Application.Pods {
+			names[pod.Name] = true
+		}
+	}
+	for _, line := range ev.Logs {
+		if line.Pod != "" {
+			names[line.Pod] = true
+		}
+	}
+	return names
+}
+
+// findPod returns the pod of a configuration running a log line's pod; the
+// platform may suffix pod names with a replica
+func findPod(config *schema.NexlayerYAML, name string) *schema.Pod {
+	if name == "" {
+		return nil
+	}
+	var found *schema.Pod
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		if pod.Name == name {
+			return pod
+		}
+		if strings.HasPrefix(name, pod.Name+"-") && (found == nil || len(pod.Name) > len(found.Name)) {
+			found = pod
+		}
+	}
+	return found
+}
+
+func hasTargetPort(pod *schema.Pod, port int) bool {
+	for _, p := range pod.ServicePorts {
+		if p.TargetPort == port || p.Port == port {
+			return true
+		}
+	}
+	return false
+}
+
+func allReady(d apischema.Deployment) bool {
+	for _, pod := range d.PodStatuses {
+		if !pod.Ready && !strings.EqualFold(pod.Status, "succeeded") {
+			return false
+		}
+	}
+	return true
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,601 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
+)
+
+// dockerfileSystemPrompt explains what makes a good Dockerfile to the model
+const dockerfileSystemPrompt = `You write production Dockerfiles for applications deployed on the Nexlayer cloud platform.
+Answer with the Dockerfile only, in a single code block, without explanations.
+
+Rules:
+- Use a multi-stage build: install the dependencies and build in a first stage, and copy only what runs into a small final stage.
+- Use official base images with a version tag, e.g. node:20-alpine, python:3.12-slim or golang:1.23-alpine.
+- Copy the dependency manifests and install them before copying the source, so that the layers are cached.
+- The server listens on 0.0.0.0 at the port given; EXPOSE it and pass it as the PORT environment variable.
+- Run as a non-root user in the final stage when the base image allows it.
+- Start the server with CMD or ENTRYPOINT in exec form.`
+
+// maxManifestBytes bounds each dependency manifest sent to the model
+const maxManifestBytes = 4000
+
+// maxListedFiles bounds the files of the project listed to the model
+const maxListedFiles = 40
+
+// Project is what a Dockerfile is written for: a Node.js, Python or Go
+// project and the framework it uses
+type Project struct {
+	Dir            string `json:"-"`
+	Language       string `json:"language"`                 // node, python or go
+	Framework      string `json:"framework,omitempty"`      // e.g. nextjs, react, express, fastapi, django, gin
+	Port           int    `json:"port"`                     // the server listens on it
+	PackageManager string `json:"packageManager,omitempty"` // npm, yarn or pnpm, for Node.js
+	Version        string `json:"version,omitempty"`        // Go version of go.mod
+	// Entry starts the server: the module:app of an ASGI or WSGI server,
+	// the script or package run, or the build output served by a static site
+	Entry string `json:"entry,omitempty"`
+
+	scripts   map[string]string // of package.json
+	lockfile  string            // of the Node.js package manager
+	hasServer bool              // the Python requirements include the server Entry needs
+	manifests []string          // found in Dir, e.g. package.json
+}
+
+// nodeFrameworks are the Node.js frameworks recognized, by package, most
+// telling first
+var nodeFrameworks = []struct{ pkg, name string }{
+	{"next", "nextjs"},
+	{"@nestjs/core", "nestjs"},
+	{"react-scripts", "react"},
+	{"vite", "react"},
+	{"express", "express"},
+	{"fastify", "fastify"},
+	{"koa", "koa"},
+}
+
+// goFrameworks are the Go web frameworks recognized, by module
+var goFrameworks = []struct{ module, name string }{
+	{"github.com/gin-gonic/gin", "gin"},
+	{"github.com/labstack/echo", "echo"},
+	{"github.com/gofiber/fiber", "fiber"},
+	{"github.com/go-chi/chi", "chi"},
+}
+
+// appAssignment finds the variable holding a FastAPI or Flask application
+var appAssignment = regexp.MustCompile(`(?m)^(\w+)\s*=\s*(?:FastAPI|Flask)\(`)
+
+// goDirective reads the Go version of go.mod
+var goDirective = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+)`)
+
+// DescribeProject finds the language, framework and entry point of the
+// project in dir. The server is expected on port, or on the usual port of
+// the framework when port is 0.
+func DescribeProject(dir string, port int) (*Project, error) {
+	p := &Project{Dir: dir, Port: port}
+	switch {
+	case exists(dir, "package.json"):
+		if err := p.describeNode(); err != nil {
+			return nil, err
+		}
+	case exists(dir, "go.mod"):
+		p.describeGo()
+	case exists(dir, "requirements.txt"), exists(dir, "pyproject.toml"), exists(dir, "setup.py"):
+		p.describePython()
+	default:
+		return nil, fmt.Errorf("no Node.js, Python or Go project found in %s", dir)
+	}
+	if p.Port == 0 {
+		p.Port = defaultPort(p.Language, p.Framework)
+	}
+	return p, nil
+}
+
+// describeNode reads package.json and the lockfile
+func (p *Project) describeNode() error {
+	p.Language, p.manifests = "node", []string{"package.json"}
+	data, err := os.ReadFile(filepath.Join(p.Dir, "package.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read package.json: %w", err)
+	}
+	var pkg struct {
+		Main            string            `json:"main"`
+		Scripts         map[string]string `json:"scripts"`
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return fmt.Errorf("invalid package.json: %w", err)
+	}
+	p.scripts = pkg.Scripts
+
+	for _, f := range nodeFrameworks {
+		_, dep := pkg.Dependencies[f.pkg]
+		_, dev := pkg.DevDependencies[f.pkg]
+		if dep || dev {
+			p.Framework = f.name
+			break
+		}
+	}
+
+	p.PackageManager, p.lockfile = "npm", ""
+	switch {
+	case exists(p.Dir, "pnpm-lock.yaml"):
+		p.PackageManager, p.lockfile = "pnpm", "pnpm-lock.yaml"
+	case exists(p.Dir, "yarn.lock"):
+		p.PackageManager, p.lockfile = "yarn", "yarn.lock"
+	case exists(p.Dir, "package-lock.json"):
+		p.lockfile = "package-lock.json"
+	}
+
+	switch {
+	case p.Framework == "react":
+		// Create React App builds to build/, Vite to dist/
+		p.Entry = "dist"
+		if _, ok := pkg.Dependencies["react-scripts"]; ok {
+			p.Entry = "build"
+		}
+		if _, ok := pkg.DevDependencies["react-scripts"]; ok {
+			p.Entry = "build"
+		}
+	case pkg.Scripts["start"] != "":
+		p.Entry = "npm start"
+	case pkg.Main != "":
+		p.Entry = pkg.Main
+	default:
+		p.Entry = firstExisting(p.Dir, "server.js", "index.js", "app.js", "dist/index.js")
+	}
+	return nil
+}
+
+// describePython reads the requirements and finds the application module
+func (p *Project) describePython() {
+	p.Language = "python"
+	var requirements strings.Builder
+	for _, name := range []string{"requirements.txt", "pyproject.toml", "setup.py"} {
+		if data, err := os.ReadFile(filepath.Join(p.Dir, name)); err == nil {
+			p.manifests = append(p.manifests, name)
+			requirements.WriteString(strings.ToLower(string(data)))
+		}
+	}
+	reqs := requirements.String()
+	for _, f := range []string{"fastapi", "django", "flask"} {
+		if strings.Contains(reqs, f) {
+			p.Framework = f
+			break
+		}
+	}
+
+	switch p.Framework {
+	case "fastapi", "flask":
+		p.hasServer = strings.Contains(reqs, pythonServer(p.Framework))
+		p.Entry = "main:app"
+		for _, file := range []string{"main.py", "app.py", "app/main.py", "src/main.py", "server.py", "wsgi.py"} {
+			data, err := os.ReadFile(filepath.Join(p.Dir, file))
+			if err != nil {
+				continue
+			}
+			if m := appAssignment.FindSubmatch(data); m != nil {
+				module := strings.ReplaceAll(strings.TrimSuffix(file, ".py"), "/", ".")
+				p.Entry = module + ":" + string(m[1])
+				break
+			}
+		}
+	case "django":
+		p.hasServer = strings.Contains(reqs, "gunicorn")
+		p.Entry = "wsgi"
+		if matches, _ := filepath.Glob(filepath.Join(p.Dir, "*", "wsgi.py")); len(matches) > 0 {
+			p.Entry = filepath.Base(filepath.Dir(matches[0])) + ".wsgi"
+		}
+	default:
+		p.Entry = firstExisting(p.Dir, "main.py", "app.py", "server.py")
+	}
+}
+
+// describeGo reads go.mod and finds the main package
+func (p *Project) describeGo() {
+	p.Language, p.manifests = "go", []string{"go.mod"}
+	data, _ := os.ReadFile(filepath.Join(p.Dir, "go.mod"))
+	if m := goDirective.FindSubmatch(data); m != nil {
+		p.Version = string(m[1])
+	}
+	for _, f := range goFrameworks {
+		if strings.Contains(string(data), f.module) {
+			p.Framework = f.name
+			break
+		}
+	}
+
+	p.Entry = "."
+	if !exists(p.Dir, "main.go") {
+		if matches, _ := filepath.Glob(filepath.Join(p.Dir, "cmd", "*", "main.go")); len(matches) > 0 {
+			p.Entry = "./cmd/" + filepath.Base(filepath.Dir(matches[0]))
+		}
+	}
+}
+
+// defaultPort is the port the servers of a framework usually listen on
+func defaultPort(language, framework string) int {
+	switch {
+	case framework == "react":
+		return 80
+	case language == "node":
+		return 3000
+	case language == "python":
+		return 8000
+	default:
+		return 8080
+	}
+}
+
+// pythonServer is the server running the applications of a Python framework
+func pythonServer(framework string) string {
+	if framework == "fastapi" {
+		return "uvicorn"
+	}
+	return "gunicorn"
+}
+
+// BuiltinDockerfile writes the multi-stage Dockerfile usual for the
+// framework of a project, without a model
+func BuiltinDockerfile(p *Project) []byte {
+	var b strings.Builder
+	switch p.Language {
+	case "node":
+		nodeDockerfile(&b, p)
+	case "python":
+		pythonDockerfile(&b, p)
+	default:
+		goDockerfile(&b, p)
+	}
+	return []byte(b.String())
+}
+
+// nodeDockerfile installs and builds in node stages, and serves a static
+// site with nginx or runs the server on node
+func nodeDockerfile(b *strings.Builder, p *Project) {
+	copyManifests := "COPY package*.json ./"
+	install := "npm install"
+	run := "npm run"
+	prune := "npm prune --omit=dev"
+	switch p.PackageManager {
+	case "pnpm":
+		copyManifests = "COPY package.json pnpm-lock.yaml ./"
+		install = "corepack enable && pnpm install --frozen-lockfile"
+		run, prune = "pnpm run", "pnpm prune --prod"
+	case "yarn":
+		copyManifests = "COPY package.json yarn.lock ./"
+		install = "corepack enable && yarn install --frozen-lockfile"
+		run, prune = "yarn run", ""
+	default:
+		if p.lockfile != "" {
+			install = "npm ci"
+		}
+	}
+	build := ""
+	if p.scripts["build"] != "" {
+		build = "RUN " + run + " build\n"
+	}
+
+	if p.Framework == "react" {
+		fmt.Fprintf(b, "FROM node:20-alpine AS build\nWORKDIR /app\n%s\nRUN %s\nCOPY . .\n%s\n", copyManifests, install, build)
+		b.WriteString("FROM nginx:1.27-alpine\n")
+		fmt.Fprintf(b, "COPY --from=build /app/%s /usr/share/nginx/html\n", p.Entry)
+		if p.Port != 80 {
+			fmt.Fprintf(b, "RUN sed -i 's/listen  *80;/listen %d;/' /etc/nginx/conf.d/default.conf\n", p.Port)
+		}
+		fmt.Fprintf(b, "EXPOSE %d\nCMD [\"nginx\", \"-g\", \"daemon off;\"]\n", p.Port)
+		return
+	}
+
+	fmt.Fprintf(b, "FROM node:20-alpine AS deps\nWORKDIR /app\n%s\nRUN %s\n\n", copyManifests, install)
+	b.WriteString("FROM node:20-alpine AS build\nWORKDIR /app\nCOPY --from=deps /app/node_modules ./node_modules\nCOPY . .\n")
+	b.WriteString(build)
+	if prune != "" {
+		fmt.Fprintf(b, "RUN %s\n", prune)
+	}
+	b.WriteString("\nFROM node:20-alpine\nWORKDIR /app\n")
+	fmt.Fprintf(b, "ENV NODE_ENV=production PORT=%d HOSTNAME=0.0.0.0\n", p.Port)
+	b.WriteString("COPY --from=build --chown=node:node /app ./\nUSER node\n")
+	fmt.Fprintf(b, "EXPOSE %d\n", p.Port)
+	if p.Entry == "npm start" || p.Entry == "" {
+		b.WriteString("CMD [\"npm\", \"start\"]\n")
+	} else {
+		fmt.Fprintf(b, "CMD [\"node\", %q]\n", p.Entry)
+	}
+}
+
+// pythonDockerfile installs the requirements in a virtual environment and
+// copies it into a slim stage
+func pythonDockerfile(b *strings.Builder, p *Project) {
+	var server string
+	if (p.Framework == "fastapi" || p.Framework == "flask" || p.Framework == "django") && !p.hasServer {
+		server = " " + pythonServer(p.Framework)
+	}
+	b.WriteString("FROM python:3.12-slim AS build\nWORKDIR /app\nRUN python -m venv /opt/venv\nENV PATH=\"/opt/venv/bin:$PATH\"\n")
+	if exists(p.Dir, "requirements.txt") {
+		fmt.Fprintf(b, "COPY requirements.txt ./\nRUN pip install --no-cache-dir -r requirements.txt%s\n\n", server)
+	} else {
+		fmt.Fprintf(b, "COPY . .\nRUN pip install --no-cache-dir .%s\n\n", server)
+	}
+
+	b.WriteString("FROM python:3.12-slim\nWORKDIR /app\n")
+	fmt.Fprintf(b, "ENV PATH=\"/opt/venv/bin:$PATH\" PYTHONUNBUFFERED=1 PORT=%d\n", p.Port)
+	b.WriteString("RUN useradd --create-home --uid 10001 app\nCOPY --from=build /opt/venv /opt/venv\nCOPY --chown=app:app . .\nUSER app\n")
+	fmt.Fprintf(b, "EXPOSE %d\n", p.Port)
+	switch p.Framework {
+	case "fastapi":
+		fmt.Fprintf(b, "CMD [\"uvicorn\", %q, \"--host\", \"0.0.0.0\", \"--port\", \"%d\"]\n", p.Entry, p.Port)
+	case "flask", "django":
+		fmt.Fprintf(b, "CMD [\"gunicorn\", \"--bind\", \"0.0.0.0:%d\", %q]\n", p.Port, p.Entry)
+	default:
+		fmt.Fprintf(b, "CMD [\"python\", %q]\n", p.Entry)
+	}
+}
+
+// goDockerfile compiles a static binary and copies it into a distroless stage
+func goDockerfile(b *strings.Builder, p *Project) {
+	version := p.Version
+	if version == "" {
+		version = "1.23"
+	}
+	fmt.Fprintf(b, "FROM golang:%s-alpine AS build\nWORKDIR /src\n", version)
+	b.WriteString("COPY go.mod go.sum* ./\nRUN go mod download\nCOPY . .\n")
+	fmt.Fprintf(b, "RUN CGO_ENABLED=0 go build -trimpath -ldflags=\"-s -w\" -o /out/server %s\n\n", p.Entry)
+	b.WriteString("FROM gcr.io/distroless/static-debian12:nonroot\nCOPY --from=build /out/server /server\n")
+	fmt.Fprintf(b, "ENV PORT=%d\nEXPOSE %d\nENTRYPOINT [\"/server\"]\n", p.Port, p.Port)
+}
+
+// DockerfileOptions tunes GenerateDockerfile
+type DockerfileOptions struct {
+	MaxAttempts int // DefaultGenerateAttempts when 0
+	// Build, when not nil, builds a Dockerfile that passed the other checks
+	// and returns why the build failed
+	Build func(ctx context.Context, dockerfile []byte) error
+}
+
+// GeneratedDockerfile is a Dockerfile written for a project
+type GeneratedDockerfile struct {
+	Content  []byte    `json:"-"`
+	Project  *Project  `json:"project"`
+	Model    string    `json:"model,omitempty"` // provider and model that wrote it, empty for the built-in one
+	Built    bool      `json:"built"`           // a local build of it succeeded
+	Attempts []Attempt `json:"attempts"`
+	Problems []string  `json:"problems,omitempty"` // left after the last attempt
+}
+
+// Valid reports whether the Dockerfile passed the checks
+func (g *GeneratedDockerfile) Valid() bool {
+	return len(g.Content) > 0 && len(g.Problems) == 0
+}
+
+// GenerateDockerfile asks a model to write a multi-stage Dockerfile tuned to
+// the framework of a project, starting from the built-in one. Each Dockerfile
+// is checked, and built when opts.Build is set, and the model is asked to fix
+// the problems found until one passes or the attempts run out. Without a
+// provider the built-in Dockerfile is checked and returned. onAttempt, when
+// not nil, is called after each attempt.
+func GenerateDockerfile(ctx context.Context, provider llm.Provider, p *Project, opts DockerfileOptions, onAttempt func(Attempt)) (*GeneratedDockerfile, error) {
+	result := &GeneratedDockerfile{Project: p}
+	attempts := opts.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultGenerateAttempts
+	}
+	if provider == nil {
+		attempts = 1
+	} else {
+		result.Model = provider.Name()
+	}
+
+	builtin := BuiltinDockerfile(p)
+	prompt := dockerfilePrompt(p, builtin)
+	for n := 1; n <= attempts; n++ {
+		if provider == nil {
+			result.Content = builtin
+		} else {
+			resp, err := provider.Complete(ctx, llm.Request{System: dockerfileSystemPrompt, Prompt: prompt}, nil)
+			if err != nil {
+				return nil, err
+			}
+			result.Content = extractCode(resp.Text)
+		}
+
+		result.Problems = checkDockerfile(result.Content, p)
+		result.Built = false
+		if len(result.Problems) == 0 && opts.Build != nil {
+			if err := opts.Build(ctx, result.Content); err != nil {
+				result.Problems = []string{fmt.Sprintf("docker build failed: %v", err)}
+			} else {
+				result.Built = true
+			}
+		}
+
+		attempt := Attempt{Number: n, Problems: result.Problems}
+		result.Attempts = append(result.Attempts, attempt)
+		if onAttempt != nil {
+			onAttempt(attempt)
+		}
+		if result.Valid() {
+			break
+		}
+		prompt = dockerfileRepairPrompt(p, builtin, result.Content, result.Problems)
+	}
+	return result, nil
+}
+
+// dockerfilePrompt describes a project to the model
+func dockerfilePrompt(p *Project, builtin []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Write the Dockerfile of this %s project.\n\n", languageName(p.Language))
+	if p.Framework != "" {
+		fmt.Fprintf(&b, "Framework: %s\n", p.Framework)
+	}
+	if p.PackageManager != "" {
+		lockfile := p.lockfile
+		if lockfile == "" {
+			lockfile = "no lockfile"
+		}
+		fmt.Fprintf(&b, "Package manager: %s (%s)\n", p.PackageManager, lockfile)
+	}
+	if p.Version != "" {
+		fmt.Fprintf(&b, "Go version: %s\n", p.Version)
+	}
+	if p.Entry != "" {
+		fmt.Fprintf(&b, "Entry point: %s\n", p.Entry)
+	}
+	fmt.Fprintf(&b, "Port: %d\n", p.Port)
+	if files := listFiles(p.Dir); len(files) > 0 {
+		fmt.Fprintf(&b, "Files: %s\n", strings.Join(files, ", "))
+	}
+	for _, name := range p.manifests {
+		data, err := os.ReadFile(filepath.Join(p.Dir, name))
+		if err != nil {
+			continue
+		}
+		if len(data) > maxManifestBytes {
+			data = append(data[:maxManifestBytes], "\n..."...)
+		}
+		fmt.Fprintf(&b, "\n%s:\n```\n%s\n```\n", name, strings.TrimRight(string(data), "\n"))
+	}
+	fmt.Fprintf(&b, "\nA generic Dockerfile for this stack, to improve on:\n```dockerfile\n%s```\n", builtin)
+	return b.String()
+}
+
+// dockerfileRepairPrompt asks to fix the problems of a Dockerfile
+func dockerfileRepairPrompt(p *Project, builtin, content []byte, problems []string) string {
+	var b strings.Builder
+	b.WriteString(dockerfilePrompt(p, builtin))
+	b.WriteString("\nThis Dockerfile was written for it but does not work:\n\n```dockerfile\n")
+	b.Write(content)
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		b.WriteString("\n")
+	}
+	b.WriteString("```\n\nFix these problems and answer with the whole corrected Dockerfile:\n")
+	for _, problem := range problems {
+		fmt.Fprintf(&b, "- %s\n", problem)
+	}
+	return b.String()
+}
+
+// checkDockerfile returns the problems of a Dockerfile that a build would not
+// catch: a single stage, or a port or start command missing
+func checkDockerfile(content []byte, p *Project) []string {
+	var instructions []string
+	exposed := map[int]bool{}
+	continued := false
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		wasContinued := continued
+		continued = strings.HasSuffix(strings.TrimSpace(line), "\\")
+		if wasContinued {
+			continue
+		}
+		instruction := strings.ToUpper(fields[0])
+		instructions = append(instructions, instruction)
+		if instruction == "EXPOSE" {
+			for _, f := range fields[1:] {
+				port, _ := strconv.Atoi(strings.Split(f, "/")[0])
+				exposed[port] = true
+			}
+		}
+	}
+	if len(instructions) == 0 {
+		return []string{"the answer contains no Dockerfile"}
+	}
+
+	var problems []string
+	stages, starts := 0, false
+	for _, instruction := range instructions {
+		switch instruction {
+		case "FROM":
+			stages++
+		case "CMD", "ENTRYPOINT":
+			starts = true
+		}
+	}
+	for _, instruction := range instructions {
+		if instruction == "FROM" {
+			break
+		}
+		if instruction != "ARG" {
+			problems = append(problems, "the first instruction must be FROM")
+			break
+		}
+	}
+	if stages < 2 {
+		problems = append(problems, "use a multi-stage build: build in a first stage and copy only what runs into the final stage")
+	}
+	if !exposed[p.Port] {
+		problems = append(problems, fmt.Sprintf("EXPOSE %d, the port the server listens on", p.Port))
+	}
+	if !starts {
+		problems = append(problems, "start the server with CMD or ENTRYPOINT")
+	}
+	return problems
+}
+
+// languageName names a language of Project in messages
+func languageName(language string) string {
+	switch language {
+	case "node":
+		return "Node.js"
+	case "python":
+		return "Python"
+	default:
+		return "Go"
+	}
+}
+
+// listFiles lists the top of a project, directories with a trailing slash
+func listFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") || name == "node_modules" || name == "__pycache__" || name == "venv" || name == "vendor" {
+			continue
+		}
+		if e.IsDir() {
+			name += "/"
+		}
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files[:min(len(files), maxListedFiles)]
+}
+
+// exists reports whether a file exists in dir
+func exists(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+// firstExisting returns the first of the files found in dir, or the first
+// one when none is
+func firstExisting(dir string, names ...string) string {
+	for _, name := range names {
+		if exists(dir, name) {
+			return name
+		}
+	}
+	return names[0]
+}
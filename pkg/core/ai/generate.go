@@ -0,0 +1,229 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+)
+
+// DefaultGenerateAttempts is how many configurations the model may write
+// before one validates
+const DefaultGenerateAttempts = 3
+
+// maxExamples bounds the built-in templates shown to the model
+const maxExamples = 2
+
+// generateSystemPrompt explains nexlayer.yaml to the model
+const generateSystemPrompt = `You write nexlayer.yaml deployment files for the Nexlayer cloud platform.
+Answer with the file only, in a single yaml code block, without explanations.
+
+Rules of nexlayer.yaml:
+- The top-level key is application, with a name (lowercase letters, digits and dashes) and a list of pods.
+- Each pod has a name (lowercase letters, digits and dashes), an image and servicePorts, a list of {name, port, targetPort}.
+- Pods reach each other at <pod>.pod, e.g. postgres.pod:5432; never use localhost between pods.
+- The pod serving the website or API has path: /; other routed pods have their own path, e.g. /api.
+- Environment variables are vars, a list of {key, value}.
+- Secrets are never written in the file: use <% NAME %> placeholders, e.g. <% DB_PASSWORD %>.
+- Images of the application are written <% REGISTRY %>/<name>:latest; services use official images with a version tag, e.g. postgres:16 or redis:7.
+- Databases keep their data in volumes, a list of {name, path, size}.
+- Servers listen on 0.0.0.0 at their targetPort.`
+
+// GenerateRequest describes the configuration to generate
+type GenerateRequest struct {
+	Description string // what the application is made of, in plain words
+	AppName     string // name of the application; the model picks one when empty
+	MaxAttempts int    // DefaultGenerateAttempts when 0
+}
+
+// Attempt is a configuration the model wrote and the problems validation
+// found in it
+type Attempt struct {
+	Number   int      `json:"number"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// Generated is a configuration generated from a description
+type Generated struct {
+	Content  []byte               `json:"-"`                  // nexlayer.yaml as written by the model
+	Config   *schema.NexlayerYAML `json:"-"`                  // parsed Content, nil when it is not valid YAML
+	Model    string               `json:"model"`              // provider and model that wrote it
+	Examples []string             `json:"examples,omitempty"` // built-in templates shown as examples
+	Attempts []Attempt            `json:"attempts"`
+	Problems []string             `json:"problems,omitempty"` // left after the last attempt
+}
+
+// Valid reports whether the configuration passed validation
+func (g *Generated) Valid() bool {
+	return g.Config != nil && len(g.Problems) == 0
+}
+
+// codeBlock matches a fenced code block of an answer
+var codeBlock = regexp.MustCompile("(?s)```[\\w-]*[ \t]*\r?\n(.*?)```")
+
+// Generate asks a model to write the nexlayer.yaml of an application from a
+// description, guided by the built-in templates closest to it. Each
+// configuration is run through schema.Validate, and the model is asked to
+// fix the problems found until one passes or the attempts run out; the last
+// configuration is returned either way, with its problems. onAttempt, when
+// not nil, is called after each attempt.
+func Generate(ctx context.Context, provider llm.Provider, req GenerateRequest, onAttempt func(Attempt)) (*Generated, error) {
+	if strings.TrimSpace(req.Description) == "" {
+		return nil, fmt.Errorf("no description of the application")
+	}
+	attempts := req.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultGenerateAttempts
+	}
+
+	examples, names := generateExamples(ctx, req.Description)
+	system := generateSystemPrompt + examples
+	result := &Generated{Model: provider.Name(), Examples: names}
+	prompt := generatePrompt(req)
+	for n := 1; n <= attempts; n++ {
+		resp, err := provider.Complete(ctx, llm.Request{System: system, Prompt: prompt}, nil)
+		if err != nil {
+			return nil, err
+		}
+		result.Content = extractCode(resp.Text)
+		result.Config, result.Problems = checkGenerated(result.Content, req.AppName)
+
+		attempt := Attempt{Number: n, Problems: result.Problems}
+		result.Attempts = append(result.Attempts, attempt)
+		if onAttempt != nil {
+			onAttempt(attempt)
+		}
+		if result.Valid() {
+			break
+		}
+		prompt = repairPrompt(req, result.Content, result.Problems)
+	}
+	return result, nil
+}
+
+// generatePrompt asks for the configuration of a description
+func generatePrompt(req GenerateRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Write the nexlayer.yaml of this application: %s\n", strings.TrimSpace(req.Description))
+	if req.AppName != "" {
+		fmt.Fprintf(&b, "Name the application %s.\n", req.AppName)
+	}
+	return b.String()
+}
+
+// repairPrompt asks to fix the problems of a configuration
+func repairPrompt(req GenerateRequest, content []byte, problems []string) string {
+	var b strings.Builder
+	b.WriteString(generatePrompt(req))
+	b.WriteString("\nThis nexlayer.yaml was written for it but is not valid:\n\n```yaml\n")
+	b.Write(content)
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		b.WriteString("\n")
+	}
+	b.WriteString("```\n\nFix these problems and answer with the whole corrected file:\n")
+	for _, p := range problems {
+		fmt.Fprintf(&b, "- %s\n", p)
+	}
+	return b.String()
+}
+
+// checkGenerated parses and validates a generated configuration, returning
+// the blocking problems found
+func checkGenerated(content []byte, appName string) (*schema.NexlayerYAML, []string) {
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return nil, []string{"the answer contains no configuration"}
+	}
+	config, _, err := deployment.Parse(content)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	var problems []string
+	if len(config.Application.Pods) == 0 {
+		problems = append(problems, "application.pods: the application has no pods")
+	}
+	if appName != "" && config.Application.Name != appName {
+		problems = append(problems, fmt.Sprintf("application.name: must be %s", appName))
+	}
+	for _, e := range schema.Validate(config) {
+		if e.Severity == schema.ValidationErrorSeverityError {
+			problems = append(problems, fmt.Sprintf("%s: %s", e.Field, e.Message))
+		}
+	}
+	return config, problems
+}
+
+// extractCode returns the file of an answer: its first code block, or the
+// whole answer when it has none
+func extractCode(answer string) []byte {
+	content := answer
+	if m := codeBlock.FindStringSubmatch(answer); m != nil {
+		content = m[1]
+	}
+	return []byte(strings.TrimSpace(content) + "\n")
+}
+
+// generateExamples renders the built-in templates sharing the most keywords
+// with a description, for the model to follow, and returns their names
+func generateExamples(ctx context.Context, description string) (string, []string) {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+	type match struct {
+		builtin template.Builtin
+		score   int
+	}
+	var matches []match
+	for _, b := range template.Builtins() {
+		score := 0
+		for _, keyword := range append(b.Metadata.Keywords, b.Metadata.Stack) {
+			for _, w := range words {
+				if w == keyword || strings.HasPrefix(w, keyword) {
+					score++
+					break
+				}
+			}
+		}
+		if score > 0 {
+			matches = append(matches, match{b, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	if len(matches) == 0 {
+		// Any example shows the model the format
+		matches = append(matches, match{builtin: template.Builtins()[0]})
+	}
+
+	var b strings.Builder
+	var names []string
+	registry := template.NewBuiltinRegistry()
+	for _, m := range matches[:min(len(matches), maxExamples)] {
+		t, err := registry.Pull(ctx, m.builtin.Metadata.Name, "")
+		if err != nil {
+			continue
+		}
+		values := template.Values{"appName": "my-app"}
+		for _, p := range m.builtin.Params {
+			if p.Default != "" {
+				values[p.Name] = p.Default
+			}
+		}
+		content, err := template.Render(t.Content, values)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "\n\nExample, %s:\n```yaml\n%s```", m.builtin.Metadata.Description, content)
+		names = append(names, m.builtin.Metadata.Name)
+	}
+	return b.String(), names
+}
@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -20,16 +21,42 @@ import (
 	"time"
 
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/auth"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
 )
 
 // ClientAPI is an interface that abstracts the methods required for API interactions.
 type ClientAPI interface {
 	StartDeployment(ctx context.Context, appID string, configPath string) (*schema.APIResponse[schema.DeploymentResponse], error)
-	SendFeedback(ctx context.Context, text string) error
+	SendFeedback(ctx context.Context, feedback schema.Feedback) error
 	SaveCustomDomain(ctx context.Context, appID string, domain string) (*schema.APIResponse[struct{}], error)
+	ListCustomDomains(ctx context.Context, appID string) (*schema.APIResponse[[]schema.CustomDomain], error)
+	RemoveCustomDomain(ctx context.Context, appID string, domain string) error
 	ListDeployments(ctx context.Context) (*schema.APIResponse[[]schema.Deployment], error)
+	GetDeployments(ctx context.Context, appID string) (*schema.APIResponse[[]schema.Deployment], error)
 	GetDeploymentInfo(ctx context.Context, namespace string) (*schema.APIResponse[schema.Deployment], error)
+	DeleteDeployment(ctx context.Context, namespace string, keepVolumes bool) error
 	GetLogs(ctx context.Context, namespace string, appID string, follow bool, tail int) ([]string, error)
+	StreamLogs(ctx context.Context, namespace string, appID string, tail int, lastEventID string, onLine func(schema.LogLine)) error
+	GetQuota(ctx context.Context) (*schema.APIResponse[schema.Quota], error)
+	OpenTunnel(ctx context.Context, namespace string, pod string) (*schema.APIResponse[schema.Tunnel], error)
+	CloseTunnel(ctx context.Context, namespace string, tunnelID string) error
+	CreateVolumeSnapshot(ctx context.Context, namespace string, pod string, volume string) (*schema.APIResponse[schema.VolumeSnapshot], error)
+	ListVolumeSnapshots(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.VolumeSnapshot], error)
+	RestoreVolumeSnapshot(ctx context.Context, namespace string, snapshotID string) error
+	UploadStaticSite(ctx context.Context, appName string, pod string, digest string, data []byte) (*schema.APIResponse[schema.StaticUpload], error)
+	RunMigration(ctx context.Context, appName string, req schema.MigrationRequest) (*schema.APIResponse[schema.Migration], error)
+	GetMigration(ctx context.Context, appName string, migrationID string) (*schema.APIResponse[schema.Migration], error)
+	GetTraffic(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.TrafficSplit], error)
+	SetTraffic(ctx context.Context, namespace string, pod string, stable int, canary int) (*schema.APIResponse[[]schema.TrafficSplit], error)
+	ScalePod(ctx context.Context, namespace string, pod string, replicas int) (*schema.APIResponse[schema.PodScale], error)
+	Exec(ctx context.Context, namespace string, req schema.ExecRequest) (*ExecSession, error)
+	PortForward(ctx context.Context, namespace string, pod string, port int) (*PortStream, error)
+	GetRegions(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.RegionStatus], error)
+	GetUsage(ctx context.Context, namespace string, since time.Duration) (*schema.APIResponse[schema.Usage], error)
+	ListSecrets(ctx context.Context, appName string) (*schema.APIResponse[[]schema.StoredSecret], error)
+	SetSecret(ctx context.Context, appName string, name string, value string) error
+	DeleteSecret(ctx context.Context, appName string, name string) error
 }
 
 // APIClient defines the interface for interacting with the Nexlayer API.
@@ -43,34 +70,139 @@ type APIClient interface {
 	// Endpoint: POST /startUserDeployment
 	StartDeployment(ctx context.Context, appID string, configPath string) (*schema.APIResponse[schema.DeploymentResponse], error)
 
-	// SendFeedback submits feedback to Nexlayer regarding deployment or application experience.
+	// SendFeedback submits feedback to Nexlayer regarding deployment or application experience,
+	// optionally with a category and context such as the CLI version and redacted configuration.
 	// Endpoint: POST /feedback
-	SendFeedback(ctx context.Context, text string) error
+	SendFeedback(ctx context.Context, feedback schema.Feedback) error
 
 	// SaveCustomDomain associates a custom domain with a specific application deployment.
 	// Endpoint: POST /saveCustomDomain/{applicationID}
 	SaveCustomDomain(ctx context.Context, appID string, domain string) (*schema.APIResponse[struct{}], error)
 
+	// ListCustomDomains retrieves the custom domains of an application with the
+	// state of their SSL certificates.
+	// Endpoint: GET /listCustomDomains/{applicationID}
+	ListCustomDomains(ctx context.Context, appID string) (*schema.APIResponse[[]schema.CustomDomain], error)
+
+	// RemoveCustomDomain detaches a custom domain from an application and revokes
+	// its certificate. The DNS record is left to the user.
+	// Endpoint: POST /removeCustomDomain/{applicationID}
+	RemoveCustomDomain(ctx context.Context, appID string, domain string) error
+
 	// ListDeployments retrieves all deployments.
 	// Endpoint: GET /listDeployments
 	ListDeployments(ctx context.Context) (*schema.APIResponse[[]schema.Deployment], error)
 
+	// GetDeployments retrieves the deployments of an application.
+	// Endpoint: GET /getDeployments/{applicationID}
+	GetDeployments(ctx context.Context, appID string) (*schema.APIResponse[[]schema.Deployment], error)
+
 	// GetDeploymentInfo retrieves detailed information about a specific deployment.
 	// Endpoint: GET /getDeploymentInfo/{namespace}
 	GetDeploymentInfo(ctx context.Context, namespace string) (*schema.APIResponse[schema.Deployment], error)
 
+	// DeleteDeployment removes a deployment with its pods and routes. Its volumes
+	// are deleted too unless keepVolumes is set, so that a later deployment to
+	// the same application can reattach them.
+	// Endpoint: DELETE /deleteDeployment/{namespace}
+	DeleteDeployment(ctx context.Context, namespace string, keepVolumes bool) error
+
 	// GetLogs retrieves logs for a specific deployment.
-	// If follow is true, streams logs in real-time.
 	// tail specifies the number of lines to return from the end of the logs.
+	// The lines are returned at once; use StreamLogs to follow them.
 	GetLogs(ctx context.Context, namespace string, appID string, follow bool, tail int) ([]string, error)
-}
 
-// APIClientForCommands interface is used for API client operations used in commands.
-type APIClientForCommands interface {
-	GetDeploymentInfo(ctx context.Context, namespace string) (*schema.APIResponse[schema.Deployment], error)
-	GetDeployments(ctx context.Context, appID string) (*schema.APIResponse[[]schema.Deployment], error)
-	SaveCustomDomain(ctx context.Context, appID string, domain string) (*schema.APIResponse[struct{}], error)
-	// Add other methods as needed
+	// StreamLogs follows the logs of a deployment over server-sent events,
+	// calling onLine for each line, until ctx is done or the stream drops.
+	// tail lines are sent first; lastEventID resumes after a line received
+	// before the stream dropped instead.
+	// Endpoint: GET /getDeploymentLogs/{namespace}?follow=true (text/event-stream)
+	StreamLogs(ctx context.Context, namespace string, appID string, tail int, lastEventID string, onLine func(schema.LogLine)) error
+
+	// GetQuota retrieves the plan limits and current usage of the account.
+	// Endpoint: GET /getQuota
+	GetQuota(ctx context.Context) (*schema.APIResponse[schema.Quota], error)
+
+	// OpenTunnel asks the platform to route a pod's traffic through a reverse tunnel.
+	// Endpoint: POST /openTunnel/{namespace}
+	OpenTunnel(ctx context.Context, namespace string, pod string) (*schema.APIResponse[schema.Tunnel], error)
+
+	// CloseTunnel restores a pod's normal routing.
+	// Endpoint: POST /closeTunnel/{namespace}/{tunnelID}
+	CloseTunnel(ctx context.Context, namespace string, tunnelID string) error
+
+	// CreateVolumeSnapshot takes a snapshot of a pod's volume.
+	// Endpoint: POST /createVolumeSnapshot/{namespace}
+	CreateVolumeSnapshot(ctx context.Context, namespace string, pod string, volume string) (*schema.APIResponse[schema.VolumeSnapshot], error)
+
+	// ListVolumeSnapshots retrieves the volume snapshots of a deployment, newest first.
+	// Endpoint: GET /listVolumeSnapshots/{namespace}
+	ListVolumeSnapshots(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.VolumeSnapshot], error)
+
+	// RestoreVolumeSnapshot replaces the contents of a volume with a snapshot and restarts its pod.
+	// Endpoint: POST /restoreVolumeSnapshot/{namespace}/{snapshotID}
+	RestoreVolumeSnapshot(ctx context.Context, namespace string, snapshotID string) error
+
+	// UploadStaticSite stores the packaged assets of a static pod as application/gzip.
+	// Uploading a package with a known digest returns the stored one.
+	// Endpoint: POST /uploadStaticSite/{applicationName}/{pod}
+	UploadStaticSite(ctx context.Context, appName string, pod string, digest string, data []byte) (*schema.APIResponse[schema.StaticUpload], error)
+
+	// RunMigration starts an application's migrations, or returns the run of
+	// the same release when it already succeeded.
+	// Endpoint: POST /runMigration/{applicationName}
+	RunMigration(ctx context.Context, appName string, req schema.MigrationRequest) (*schema.APIResponse[schema.Migration], error)
+
+	// GetMigration retrieves the status and logs of a migration run.
+	// Endpoint: GET /getMigration/{applicationName}/{migrationID}
+	GetMigration(ctx context.Context, appName string, migrationID string) (*schema.APIResponse[schema.Migration], error)
+
+	// GetTraffic retrieves the traffic weights of the pods with a canary.
+	// Endpoint: GET /getTraffic/{namespace}
+	GetTraffic(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.TrafficSplit], error)
+
+	// SetTraffic changes the traffic weights of a pod, or of every pod with a
+	// canary when pod is empty, and returns the resulting splits.
+	// Endpoint: POST /setTraffic/{namespace}
+	SetTraffic(ctx context.Context, namespace string, pod string, stable int, canary int) (*schema.APIResponse[[]schema.TrafficSplit], error)
+
+	// ScalePod changes the number of replicas of a running pod until the next
+	// deployment, and returns the pod's new scale.
+	// Endpoint: POST /scalePod/{namespace}
+	ScalePod(ctx context.Context, namespace string, pod string, replicas int) (*schema.APIResponse[schema.PodScale], error)
+
+	// Exec runs a command in a running pod, with its standard streams and
+	// exit status multiplexed over a websocket.
+	// Endpoint: GET /execPod/{namespace} (websocket)
+	Exec(ctx context.Context, namespace string, req schema.ExecRequest) (*ExecSession, error)
+
+	// PortForward opens a connection to a port of a running pod, exposed or
+	// not, carried over a websocket.
+	// Endpoint: GET /portForward/{namespace} (websocket)
+	PortForward(ctx context.Context, namespace string, pod string, port int) (*PortStream, error)
+
+	// GetRegions retrieves the status of a deployment in each of its regions.
+	// Endpoint: GET /getRegions/{namespace}
+	GetRegions(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.RegionStatus], error)
+
+	// GetUsage retrieves the resources a deployment used over the period
+	// ending now, rounded up to whole hours.
+	// Endpoint: GET /getUsage/{namespace}?hours={hours}
+	GetUsage(ctx context.Context, namespace string, since time.Duration) (*schema.APIResponse[schema.Usage], error)
+
+	// ListSecrets retrieves the names of the secrets stored for an application,
+	// without their values.
+	// Endpoint: GET /listSecrets/{applicationName}
+	ListSecrets(ctx context.Context, appName string) (*schema.APIResponse[[]schema.StoredSecret], error)
+
+	// SetSecret stores the value of a secret of an application, replacing any
+	// previous value. Running deployments keep the old value until redeployed.
+	// Endpoint: POST /setSecret/{applicationName}
+	SetSecret(ctx context.Context, appName string, name string, value string) error
+
+	// DeleteSecret removes a secret of an application.
+	// Endpoint: POST /deleteSecret/{applicationName}
+	DeleteSecret(ctx context.Context, appName string, name string) error
 }
 
 // Client represents an API client for interacting with the Nexlayer API.
@@ -80,22 +212,19 @@ type Client struct {
 	baseURL    string       // Base URL of the Nexlayer API
 	httpClient *http.Client // HTTP client for making API requests
 	token      string       // Authentication token for API requests
+	appID      string       // Application ID used when a request names none
+	debug      io.Writer    // Where requests and responses are traced
+	retry      *retryTransport
+	transport  *http.Transport
 }
 
-// Ensure Client implements APIClientForCommands
-var _ APIClientForCommands = (*Client)(nil)
-
 // Ensure Client implements ClientAPI
 var _ ClientAPI = (*Client)(nil)
 
 // handleAPIError processes API error responses and returns a formatted error
 func (c *Client) handleAPIError(resp *http.Response) error {
 	body, _ := io.ReadAll(resp.Body)
-	var errResp schema.APIError
-	if err := json.Unmarshal(body, &errResp); err != nil {
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
-	}
-	return fmt.Errorf("API error (status %d): %s", resp.StatusCode, errResp.Message)
+	return parseError(resp.StatusCode, body)
 }
 
 // NewClient creates a new Nexlayer API client.
@@ -122,6 +251,465 @@ func (c *Client) ListDeployments(ctx context.Context) (*schema.APIResponse[[]sch
 	return &result, nil
 }
 
+// GetQuota retrieves the plan limits and current usage of the account. The
+// request is not retried: the quota is advisory, and an API without the
+// endpoint answers with ErrNotFound or ErrUnsupported.
+// Endpoint: GET /getQuota
+func (c *Client) GetQuota(ctx context.Context) (*schema.APIResponse[schema.Quota], error) {
+	url := fmt.Sprintf("%s/getQuota", c.baseURL)
+	resp, err := c.get(withoutRetries(ctx), url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var result schema.APIResponse[schema.Quota]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode quota response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// OpenTunnel asks the platform to route a pod's traffic through a reverse tunnel.
+// Endpoint: POST /openTunnel/{namespace}
+func (c *Client) OpenTunnel(ctx context.Context, namespace string, pod string) (*schema.APIResponse[schema.Tunnel], error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+
+	body, err := json.Marshal(struct {
+		Pod string `json:"pod"`
+	}{Pod: pod})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/openTunnel/%s", c.baseURL, namespace)
+	resp, err := c.post(ctx, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tunnel: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result schema.APIResponse[schema.Tunnel]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode tunnel response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CloseTunnel restores a pod's normal routing.
+// Endpoint: POST /closeTunnel/{namespace}/{tunnelID}
+func (c *Client) CloseTunnel(ctx context.Context, namespace string, tunnelID string) error {
+	url := fmt.Sprintf("%s/closeTunnel/%s/%s", c.baseURL, strings.TrimSpace(namespace), tunnelID)
+	resp, err := c.post(ctx, url, []byte("{}"))
+	if err != nil {
+		return fmt.Errorf("failed to close tunnel: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// CreateVolumeSnapshot takes a snapshot of a pod's volume.
+// Endpoint: POST /createVolumeSnapshot/{namespace}
+func (c *Client) CreateVolumeSnapshot(ctx context.Context, namespace string, pod string, volume string) (*schema.APIResponse[schema.VolumeSnapshot], error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+
+	body, err := json.Marshal(struct {
+		Pod    string `json:"pod"`
+		Volume string `json:"volume"`
+	}{Pod: pod, Volume: volume})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/createVolumeSnapshot/%s", c.baseURL, namespace)
+	resp, err := c.post(ctx, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result schema.APIResponse[schema.VolumeSnapshot]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ListVolumeSnapshots retrieves the volume snapshots of a deployment, newest first.
+// Endpoint: GET /listVolumeSnapshots/{namespace}
+func (c *Client) ListVolumeSnapshots(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.VolumeSnapshot], error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+
+	url := fmt.Sprintf("%s/listVolumeSnapshots/%s", c.baseURL, namespace)
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var result schema.APIResponse[[]schema.VolumeSnapshot]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshots response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// RestoreVolumeSnapshot replaces the contents of a volume with a snapshot and restarts its pod.
+// Endpoint: POST /restoreVolumeSnapshot/{namespace}/{snapshotID}
+func (c *Client) RestoreVolumeSnapshot(ctx context.Context, namespace string, snapshotID string) error {
+	url := fmt.Sprintf("%s/restoreVolumeSnapshot/%s/%s", c.baseURL, strings.TrimSpace(namespace), snapshotID)
+	resp, err := c.post(ctx, url, []byte("{}"))
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// UploadStaticSite stores the packaged assets of a static pod.
+// Endpoint: POST /uploadStaticSite/{applicationName}/{pod}
+func (c *Client) UploadStaticSite(ctx context.Context, appName string, pod string, digest string, data []byte) (*schema.APIResponse[schema.StaticUpload], error) {
+	url := fmt.Sprintf("%s/uploadStaticSite/%s/%s", c.baseURL, strings.TrimSpace(appName), pod)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("X-Content-Digest", "sha256="+digest)
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload static site: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var result schema.APIResponse[schema.StaticUpload]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode upload response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// RunMigration starts an application's migrations.
+// Endpoint: POST /runMigration/{applicationName}
+func (c *Client) RunMigration(ctx context.Context, appName string, req schema.MigrationRequest) (*schema.APIResponse[schema.Migration], error) {
+	appName = strings.TrimSpace(appName)
+	if appName == "" || strings.Contains(appName, "/") {
+		return nil, fmt.Errorf("invalid application name %q", appName)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/runMigration/%s", c.baseURL, appName)
+	resp, err := c.post(ctx, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result schema.APIResponse[schema.Migration]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode migration response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetMigration retrieves the status and logs of a migration run.
+// Endpoint: GET /getMigration/{applicationName}/{migrationID}
+func (c *Client) GetMigration(ctx context.Context, appName string, migrationID string) (*schema.APIResponse[schema.Migration], error) {
+	url := fmt.Sprintf("%s/getMigration/%s/%s", c.baseURL, strings.TrimSpace(appName), migrationID)
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var result schema.APIResponse[schema.Migration]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode migration response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetTraffic retrieves the traffic weights of the pods with a canary.
+// Endpoint: GET /getTraffic/{namespace}
+func (c *Client) GetTraffic(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.TrafficSplit], error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+
+	url := fmt.Sprintf("%s/getTraffic/%s", c.baseURL, namespace)
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get traffic: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var result schema.APIResponse[[]schema.TrafficSplit]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode traffic response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetRegions retrieves the status of a deployment in each of its regions.
+// Endpoint: GET /getRegions/{namespace}
+func (c *Client) GetRegions(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.RegionStatus], error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+
+	url := fmt.Sprintf("%s/getRegions/%s", c.baseURL, namespace)
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var result schema.APIResponse[[]schema.RegionStatus]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode regions response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetUsage retrieves the resources a deployment used over the period ending now.
+// Endpoint: GET /getUsage/{namespace}?hours={hours}
+func (c *Client) GetUsage(ctx context.Context, namespace string, since time.Duration) (*schema.APIResponse[schema.Usage], error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+	hours := int((since + time.Hour - 1) / time.Hour)
+	if hours < 1 {
+		hours = 1
+	}
+
+	url := fmt.Sprintf("%s/getUsage/%s?hours=%d", c.baseURL, namespace, hours)
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var result schema.APIResponse[schema.Usage]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode usage response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ListSecrets retrieves the names of the secrets stored for an application.
+// Endpoint: GET /listSecrets/{applicationName}
+func (c *Client) ListSecrets(ctx context.Context, appName string) (*schema.APIResponse[[]schema.StoredSecret], error) {
+	appName = strings.TrimSpace(appName)
+	if appName == "" || strings.Contains(appName, "/") {
+		return nil, fmt.Errorf("invalid application name %q", appName)
+	}
+
+	url := fmt.Sprintf("%s/listSecrets/%s", c.baseURL, appName)
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var result schema.APIResponse[[]schema.StoredSecret]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode secrets response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// SetSecret stores the value of a secret of an application.
+// Endpoint: POST /setSecret/{applicationName}
+func (c *Client) SetSecret(ctx context.Context, appName string, name string, value string) error {
+	appName = strings.TrimSpace(appName)
+	if appName == "" || strings.Contains(appName, "/") {
+		return fmt.Errorf("invalid application name %q", appName)
+	}
+
+	body, err := json.Marshal(struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}{Name: name, Value: value})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	// Not sent with post, which traces the body holding the value
+	url := fmt.Sprintf("%s/setSecret/%s", c.baseURL, appName)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to set secret: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return c.handleAPIError(resp)
+	}
+	return nil
+}
+
+// DeleteSecret removes a secret of an application.
+// Endpoint: POST /deleteSecret/{applicationName}
+func (c *Client) DeleteSecret(ctx context.Context, appName string, name string) error {
+	appName = strings.TrimSpace(appName)
+	if appName == "" || strings.Contains(appName, "/") {
+		return fmt.Errorf("invalid application name %q", appName)
+	}
+
+	body, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/deleteSecret/%s", c.baseURL, appName)
+	resp, err := c.post(ctx, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// SetTraffic changes the traffic weights of a pod, or of every pod with a canary.
+// Endpoint: POST /setTraffic/{namespace}
+func (c *Client) SetTraffic(ctx context.Context, namespace string, pod string, stable int, canary int) (*schema.APIResponse[[]schema.TrafficSplit], error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+
+	body, err := json.Marshal(struct {
+		Pod    string `json:"pod,omitempty"`
+		Stable int    `json:"stable"`
+		Canary int    `json:"canary"`
+	}{Pod: pod, Stable: stable, Canary: canary})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/setTraffic/%s", c.baseURL, namespace)
+	resp, err := c.post(ctx, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set traffic: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result schema.APIResponse[[]schema.TrafficSplit]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode traffic response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ScalePod changes the number of replicas of a running pod.
+// Endpoint: POST /scalePod/{namespace}
+func (c *Client) ScalePod(ctx context.Context, namespace string, pod string, replicas int) (*schema.APIResponse[schema.PodScale], error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+	if pod == "" {
+		return nil, fmt.Errorf("pod is required")
+	}
+
+	body, err := json.Marshal(struct {
+		Pod      string `json:"pod"`
+		Replicas int    `json:"replicas"`
+	}{Pod: pod, Replicas: replicas})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/scalePod/%s", c.baseURL, namespace)
+	resp, err := c.post(ctx, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale pod: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result schema.APIResponse[schema.PodScale]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode scale response: %w", err)
+	}
+
+	return &result, nil
+}
+
 // GetLogs retrieves logs for a specific deployment
 func (c *Client) GetLogs(ctx context.Context, namespace string, appID string, follow bool, tail int) ([]string, error) {
 	// Validate parameters
@@ -143,6 +731,9 @@ func (c *Client) GetLogs(ctx context.Context, namespace string, appID string, fo
 
 	// Add query parameters
 	queryParams := make([]string, 0)
+	if appID == "" {
+		appID = c.appID
+	}
 	if appID != "" {
 		queryParams = append(queryParams, fmt.Sprintf("appID=%s", appID))
 	}
@@ -158,7 +749,7 @@ func (c *Client) GetLogs(ctx context.Context, namespace string, appID string, fo
 	}
 
 	// Debug: Print the URL we're requesting
-	fmt.Printf("DEBUG: Getting logs from URL: %s\n", url)
+	fmt.Fprintf(c.debug, "DEBUG: Getting logs from URL: %s\n", url)
 
 	// Make the request
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -185,17 +776,11 @@ func (c *Client) GetLogs(ctx context.Context, namespace string, appID string, fo
 	}
 
 	// Debug: Print the response status
-	fmt.Printf("DEBUG: Response status: %d\n", resp.StatusCode)
+	fmt.Fprintf(c.debug, "DEBUG: Response status: %d\n", resp.StatusCode)
 
 	// Check for errors
 	if resp.StatusCode != http.StatusOK {
-		var errResp struct {
-			Error string `json:"error"`
-		}
-		if err := json.Unmarshal(body, &errResp); err != nil {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
-		}
-		return nil, fmt.Errorf("API error: %s", errResp.Error)
+		return nil, parseError(resp.StatusCode, body)
 	}
 
 	// Parse response
@@ -216,16 +801,25 @@ func NewClient(baseURL string) *Client {
 		MaxIdleConns:       10,
 		IdleConnTimeout:    30 * time.Second,
 		DisableCompression: true,
-		TLSClientConfig:    &tls.Config{InsecureSkipVerify: strings.Contains(baseURL, "staging")},
+		TLSClientConfig:    &tls.Config{MinVersion: tls.VersionTLS12},
 	}
 
-	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout:   120 * time.Second,
-			Transport: transport,
-		},
+	c := &Client{
+		baseURL:   baseURL,
+		token:     auth.FromEnv(),
+		debug:     os.Stdout,
+		transport: transport,
+	}
+	c.retry = &retryTransport{
+		base:   &offline.Transport{Base: transport},
+		policy: retryPolicyFromEnv(),
+		trace:  func(format string, args ...interface{}) { fmt.Fprintf(c.debug, format, args...) },
+	}
+	c.httpClient = &http.Client{
+		Timeout:   120 * time.Second,
+		Transport: c.retry,
 	}
+	return c
 }
 
 // SetToken sets the authentication token for the client
@@ -233,7 +827,70 @@ func (c *Client) SetToken(token string) {
 	c.token = token
 }
 
+// SetBaseURL sets the base URL of the API
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// SetAppID sets the application ID used by requests that name none
+func (c *Client) SetAppID(appID string) {
+	c.appID = appID
+}
+
+// SetDebugOutput sets where requests and responses are traced; nil turns
+// tracing off
+func (c *Client) SetDebugOutput(w io.Writer) {
+	if w == nil {
+		w = io.Discard
+	}
+	c.debug = w
+}
+
+// SetTLS sets how the certificate of the API is verified: against the
+// system roots plus the PEM certificates of caCertFile when it is not empty,
+// or not at all when insecure is true
+func (c *Client) SetTLS(insecure bool, caCertFile string) error {
+	config := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: insecure}
+	if caCertFile != "" {
+		pem, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no PEM certificate found in %s", caCertFile)
+		}
+		config.RootCAs = pool
+	}
+	c.transport.TLSClientConfig = config
+	c.transport.CloseIdleConnections()
+	return nil
+}
+
+// Transport returns the transport requests to the API go through, with the
+// TLS settings of SetTLS, for other requests to the API's host
+func (c *Client) Transport() http.RoundTripper {
+	return &offline.Transport{Base: c.transport}
+}
+
+// TLSConfig returns a copy of the TLS settings of SetTLS, for connections to
+// the API's hosts that do not go through HTTP
+func (c *Client) TLSConfig() *tls.Config {
+	return c.transport.TLSClientConfig.Clone()
+}
+
+// SetRetryPolicy sets how requests that failed for a transient reason are
+// retried
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry.policy = policy
+}
+
 // StartDeployment starts a new deployment using a YAML configuration file.
+// The request carries an idempotency key, so that a retry after a dropped
+// connection does not start a second deployment.
 // Endpoint: POST /startUserDeployment
 func (c *Client) StartDeployment(ctx context.Context, appID string, yamlFile string) (*schema.APIResponse[schema.DeploymentResponse], error) {
 	// Read and validate YAML file
@@ -242,6 +899,10 @@ func (c *Client) StartDeployment(ctx context.Context, appID string, yamlFile str
 		return nil, fmt.Errorf("failed to read YAML file: %w", err)
 	}
 
+	if appID == "" {
+		appID = c.appID
+	}
+
 	var url string
 	if appID != "" {
 		// If appID is provided, include it in the URL
@@ -252,7 +913,7 @@ func (c *Client) StartDeployment(ctx context.Context, appID string, yamlFile str
 	}
 
 	// Debug: Print the URL we're requesting
-	fmt.Printf("DEBUG: Starting deployment at URL: %s\n", url)
+	fmt.Fprintf(c.debug, "DEBUG: Starting deployment at URL: %s\n", url)
 
 	// Create a new request with the YAML data as binary
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(yamlData))
@@ -262,6 +923,7 @@ func (c *Client) StartDeployment(ctx context.Context, appID string, yamlFile str
 
 	// Set the content type to text/x-yaml
 	req.Header.Set("Content-Type", "text/x-yaml")
+	req.Header.Set(IdempotencyKeyHeader, newIdempotencyKey())
 
 	// Add authorization if token is set
 	if c.token != "" {
@@ -282,18 +944,12 @@ func (c *Client) StartDeployment(ctx context.Context, appID string, yamlFile str
 	}
 
 	// Debug: Print the response
-	fmt.Printf("DEBUG: Response status: %d\n", resp.StatusCode)
-	fmt.Printf("DEBUG: Response body: %s\n", string(body))
+	fmt.Fprintf(c.debug, "DEBUG: Response status: %d\n", resp.StatusCode)
+	fmt.Fprintf(c.debug, "DEBUG: Response body: %s\n", string(body))
 
 	// Check for errors
 	if resp.StatusCode != http.StatusOK {
-		var errResp struct {
-			Error string `json:"error"`
-		}
-		if err := json.Unmarshal(body, &errResp); err != nil {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
-		}
-		return nil, fmt.Errorf("API error: %s", errResp.Error)
+		return nil, parseError(resp.StatusCode, body)
 	}
 
 	// Parse response
@@ -309,6 +965,9 @@ func (c *Client) StartDeployment(ctx context.Context, appID string, yamlFile str
 // Endpoint: POST /saveCustomDomain/{applicationID}
 func (c *Client) SaveCustomDomain(ctx context.Context, appID string, domain string) (*schema.APIResponse[struct{}], error) {
 	// Validate parameters
+	if appID == "" {
+		appID = c.appID
+	}
 	if appID == "" {
 		return nil, fmt.Errorf("application ID is required and cannot be empty")
 	}
@@ -331,7 +990,7 @@ func (c *Client) SaveCustomDomain(ctx context.Context, appID string, domain stri
 	url := fmt.Sprintf("%s/saveCustomDomain/%s", c.baseURL, appID)
 
 	// Debug: Print the URL we're requesting
-	fmt.Printf("DEBUG: Saving custom domain at URL: %s\n", url)
+	fmt.Fprintf(c.debug, "DEBUG: Saving custom domain at URL: %s\n", url)
 
 	// Create request body
 	reqBody := struct {
@@ -374,17 +1033,11 @@ func (c *Client) SaveCustomDomain(ctx context.Context, appID string, domain stri
 	}
 
 	// Debug: Print the response status
-	fmt.Printf("DEBUG: Response status: %d\n", resp.StatusCode)
+	fmt.Fprintf(c.debug, "DEBUG: Response status: %d\n", resp.StatusCode)
 
 	// Check for errors
 	if resp.StatusCode != http.StatusOK {
-		var errResp struct {
-			Error string `json:"error"`
-		}
-		if err := json.Unmarshal(body, &errResp); err != nil {
-			return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
-		}
-		return nil, fmt.Errorf("API error: %s", errResp.Error)
+		return nil, parseError(resp.StatusCode, body)
 	}
 
 	// Parse response
@@ -396,9 +1049,62 @@ func (c *Client) SaveCustomDomain(ctx context.Context, appID string, domain stri
 	return &apiResp, nil
 }
 
+// ListCustomDomains retrieves the custom domains of an application.
+// Endpoint: GET /listCustomDomains/{applicationID}
+func (c *Client) ListCustomDomains(ctx context.Context, appID string) (*schema.APIResponse[[]schema.CustomDomain], error) {
+	appID = strings.TrimSpace(appID)
+	if appID == "" || strings.Contains(appID, "/") {
+		return nil, fmt.Errorf("invalid application ID %q", appID)
+	}
+
+	url := fmt.Sprintf("%s/listCustomDomains/%s", c.baseURL, appID)
+	resp, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom domains: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.handleAPIError(resp)
+	}
+
+	var result schema.APIResponse[[]schema.CustomDomain]
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
+// RemoveCustomDomain detaches a custom domain from an application.
+// Endpoint: POST /removeCustomDomain/{applicationID}
+func (c *Client) RemoveCustomDomain(ctx context.Context, appID string, domain string) error {
+	appID = strings.TrimSpace(appID)
+	if appID == "" || strings.Contains(appID, "/") {
+		return fmt.Errorf("invalid application ID %q", appID)
+	}
+
+	body, err := json.Marshal(struct {
+		Domain string `json:"domain"`
+	}{Domain: strings.TrimSpace(domain)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/removeCustomDomain/%s", c.baseURL, appID)
+	resp, err := c.post(ctx, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to remove custom domain: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
 // GetDeployments retrieves all deployments associated with the specified application ID.
 // Endpoint: GET /getDeployments/{applicationID}
 func (c *Client) GetDeployments(ctx context.Context, appID string) (*schema.APIResponse[[]schema.Deployment], error) {
+	if appID == "" {
+		appID = c.appID
+	}
 	url := fmt.Sprintf("%s/getDeployments/%s", c.baseURL, appID)
 	resp, err := c.get(ctx, url)
 	if err != nil {
@@ -441,7 +1147,7 @@ func (c *Client) GetDeploymentInfo(ctx context.Context, namespace string) (*sche
 	url := fmt.Sprintf("%s/getDeploymentInfo/%s", c.baseURL, namespace)
 
 	// Debug: Print the URL we're requesting
-	fmt.Printf("DEBUG: Checking deployment status at URL: %s\n", url)
+	fmt.Fprintf(c.debug, "DEBUG: Checking deployment status at URL: %s\n", url)
 
 	resp, err := c.get(ctx, url)
 	if err != nil {
@@ -451,7 +1157,8 @@ func (c *Client) GetDeploymentInfo(ctx context.Context, namespace string) (*sche
 	// Check for non-200 responses
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		resp.Body.Close()
+		return nil, parseError(resp.StatusCode, body)
 	}
 
 	var apiResp schema.APIResponse[schema.Deployment]
@@ -462,23 +1169,43 @@ func (c *Client) GetDeploymentInfo(ctx context.Context, namespace string) (*sche
 	return &apiResp, nil
 }
 
+// DeleteDeployment removes a deployment, keeping its volumes if asked.
+// Endpoint: DELETE /deleteDeployment/{namespace}
+func (c *Client) DeleteDeployment(ctx context.Context, namespace string, keepVolumes bool) error {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return fmt.Errorf("invalid namespace %q", namespace)
+	}
+
+	url := fmt.Sprintf("%s/deleteDeployment/%s", c.baseURL, namespace)
+	if keepVolumes {
+		url += "?keepVolumes=true"
+	}
+	resp, err := c.delete(ctx, url)
+	if err != nil {
+		return fmt.Errorf("failed to delete deployment: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
 // Helper methods for making HTTP requests
 func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
 	// Check for double slashes in URL (except for http:// or https://)
 	if strings.Contains(url, "//") &&
 		!strings.Contains(url, "http://") &&
 		!strings.Contains(url, "https://") {
-		fmt.Printf("WARNING: URL contains double slashes: %s\n", url)
+		fmt.Fprintf(c.debug, "WARNING: URL contains double slashes: %s\n", url)
 		// Fix the URL by replacing multiple slashes with a single slash
 		fixedURL := strings.Replace(url, "//", "/", -1)
 		// But preserve http:// or https://
 		fixedURL = strings.Replace(fixedURL, "http:/", "http://", 1)
 		fixedURL = strings.Replace(fixedURL, "https:/", "https://", 1)
-		fmt.Printf("WARNING: Fixed URL: %s\n", fixedURL)
+		fmt.Fprintf(c.debug, "WARNING: Fixed URL: %s\n", fixedURL)
 		url = fixedURL
 	}
 
-	fmt.Printf("GET Request URL: %s\n", url)
+	fmt.Fprintf(c.debug, "GET Request URL: %s\n", url)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -488,24 +1215,40 @@ func (c *Client) get(ctx context.Context, url string) (*http.Response, error) {
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
 	}
 
-	fmt.Printf("Making GET request with headers: %v\n", req.Header)
+	fmt.Fprintf(c.debug, "Making GET request with headers: %v\n", redactHeaders(req.Header))
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 
-	fmt.Printf("Response status: %s\n", resp.Status)
+	fmt.Fprintf(c.debug, "Response status: %s\n", resp.Status)
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		resp.Body.Close()
+		return nil, parseError(resp.StatusCode, body)
 	}
 
 	return resp, nil
 }
 
+// credentialHeaders are the request headers whose values traces leave out
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key"}
+
+// redactHeaders returns a copy of h with the values of credential headers
+// replaced, for traces
+func redactHeaders(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, name := range credentialHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "[REDACTED]")
+		}
+	}
+	return redacted
+}
+
 func (c *Client) post(ctx context.Context, url string, body []byte) (*http.Response, error) {
-	fmt.Printf("POST Request URL: %s\n", url)
-	fmt.Printf("POST Request Body: %s\n", string(body))
+	fmt.Fprintf(c.debug, "POST Request URL: %s\n", url)
+	fmt.Fprintf(c.debug, "POST Request Body: %s\n", string(body))
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
 	if err != nil {
@@ -525,7 +1268,34 @@ func (c *Client) post(ctx context.Context, url string, body []byte) (*http.Respo
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		resp.Body.Close()
+		return nil, parseError(resp.StatusCode, body)
+	}
+
+	return resp, nil
+}
+
+// delete sends a DELETE request
+func (c *Client) delete(ctx context.Context, url string) (*http.Response, error) {
+	fmt.Fprintf(c.debug, "DELETE Request URL: %s\n", url)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, parseError(resp.StatusCode, body)
 	}
 
 	return resp, nil
@@ -550,7 +1320,8 @@ func (c *Client) postYAML(ctx context.Context, url string, body []byte) (*http.R
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		resp.Body.Close()
+		return nil, parseError(resp.StatusCode, body)
 	}
 
 	return resp, nil
@@ -558,11 +1329,10 @@ func (c *Client) postYAML(ctx context.Context, url string, body []byte) (*http.R
 
 // SendFeedback sends user feedback to Nexlayer.
 // The feedback text will be used to improve the service.
-func (c *Client) SendFeedback(ctx context.Context, text string) error {
+func (c *Client) SendFeedback(ctx context.Context, feedback schema.Feedback) error {
 	url := fmt.Sprintf("%s/feedback", c.baseURL)
-	fmt.Printf("Sending feedback to: %s\n", url)
+	fmt.Fprintf(c.debug, "Sending feedback to: %s\n", url)
 
-	feedback := map[string]string{"text": text}
 	body, err := json.Marshal(feedback)
 	if err != nil {
 		return fmt.Errorf("failed to marshal feedback: %w", err)
@@ -570,11 +1340,11 @@ func (c *Client) SendFeedback(ctx context.Context, text string) error {
 
 	resp, err := c.post(ctx, url, body)
 	if err != nil {
-		fmt.Printf("Error sending feedback: %v\n", err)
+		fmt.Fprintf(c.debug, "Error sending feedback: %v\n", err)
 		return fmt.Errorf("failed to send feedback: %w", err)
 	}
 	defer resp.Body.Close()
 
-	fmt.Printf("Feedback sent successfully\n")
+	fmt.Fprintf(c.debug, "Feedback sent successfully\n")
 	return nil
 }
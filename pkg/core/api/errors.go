@@ -0,0 +1,144 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Kinds of API errors, matched with errors.Is
+var (
+	ErrUnauthorized  = errors.New("not authorized")
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	ErrValidation    = errors.New("invalid request")
+	ErrNotFound      = errors.New("not found")
+	ErrUnsupported   = errors.New("not supported by the API")
+)
+
+// maxErrorBody bounds the part of a non-JSON error answer kept in the message
+const maxErrorBody = 512
+
+// Error is an error answer of the API
+type Error struct {
+	StatusCode int      `json:"statusCode"`
+	Code       string   `json:"code,omitempty"`    // Machine-readable code, when the API sends one
+	Message    string   `json:"message"`           // What the API said went wrong
+	Details    []string `json:"details,omitempty"` // Such as the fields that failed validation
+	kind       error
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if len(e.Details) > 0 {
+		msg += ": " + strings.Join(e.Details, "; ")
+	}
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, msg)
+}
+
+// Unwrap returns the kind of the error, such as ErrNotFound, or nil
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+// parseError turns an error answer into an *Error. The API answers with a
+// JSON object whose message is in "message" or "error", and may add a "code"
+// and "details", either strings or fields with their message.
+func parseError(statusCode int, body []byte) *Error {
+	e := &Error{StatusCode: statusCode}
+	var payload struct {
+		Message string          `json:"message"`
+		Error   string          `json:"error"`
+		Code    string          `json:"code"`
+		Details json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		e.Message = strings.TrimSpace(string(body))
+		if len(e.Message) > maxErrorBody {
+			e.Message = e.Message[:maxErrorBody] + "..."
+		}
+	} else {
+		e.Message, e.Code = payload.Message, payload.Code
+		switch {
+		case e.Message == "":
+			e.Message = payload.Error
+		case e.Code == "" && !strings.ContainsAny(payload.Error, " \t"):
+			// schema.APIError sends its code as "error"
+			e.Code = payload.Error
+		}
+		e.Details = parseDetails(payload.Details)
+	}
+	e.kind = errorKind(statusCode, e.Code, e.Message)
+	return e
+}
+
+// parseDetails reads the details of an error answer
+func parseDetails(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var details []string
+	if json.Unmarshal(raw, &details) == nil {
+		return details
+	}
+	var fields []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(raw, &fields) != nil {
+		return nil
+	}
+	for _, f := range fields {
+		if f.Field == "" {
+			details = append(details, f.Message)
+		} else {
+			details = append(details, f.Field+": "+f.Message)
+		}
+	}
+	return details
+}
+
+// errorKind classifies an error answer by its code, falling back to its status
+func errorKind(statusCode int, code, message string) error {
+	mentions := strings.ToLower(code + " " + message)
+	switch {
+	// A missing endpoint is not a quota problem, whatever its path says
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusNotImplemented:
+		return ErrUnsupported
+	case statusCode == http.StatusPaymentRequired,
+		statusCode != http.StatusTooManyRequests && strings.Contains(mentions, "quota"):
+		return ErrQuotaExceeded
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnprocessableEntity:
+		return ErrValidation
+	}
+	return nil
+}
+
+// Remediation returns what the user can do about err, or "" when it is not
+// an API error of a known kind
+func Remediation(err error) string {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return "Run 'nexlayer login', or check NEXLAYER_TOKEN and the profile in use with 'nexlayer config list-profiles'"
+	case errors.Is(err, ErrQuotaExceeded):
+		return "Reduce the pod count or resources in nexlayer.yaml, or remove deployments you no longer need; 'nexlayer quota' shows your limits and usage"
+	case errors.Is(err, ErrValidation):
+		return "Fix the request or nexlayer.yaml; 'nexlayer validate' checks the file before deploying"
+	case errors.Is(err, ErrNotFound):
+		return "Check the namespace or application ID; 'nexlayer list' shows your deployments"
+	}
+	return ""
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+)
+
+// Streams of an exec session. Each binary message carries one: its first
+// byte is the stream, the rest the data.
+const (
+	execStdin  = 0
+	execStdout = 1
+	execStderr = 2
+	execStatus = 3 // an ExecStatus as JSON, the last message of the server
+	execResize = 4 // the terminal size as JSON, from the CLI
+)
+
+// ExecSession is a command running in a pod. Writes go to its standard
+// input; Wait copies its output until it exits.
+type ExecSession struct {
+	conn *wsConn
+}
+
+// Exec runs a command in a pod over a websocket, in a terminal of the given
+// size when req.TTY is set.
+// Endpoint: GET /execPod/{namespace} (websocket)
+func (c *Client) Exec(ctx context.Context, namespace string, req schema.ExecRequest) (*ExecSession, error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+	if req.Pod == "" {
+		return nil, fmt.Errorf("pod is required")
+	}
+	if len(req.Command) == 0 {
+		return nil, fmt.Errorf("command is required")
+	}
+
+	query := url.Values{"pod": {req.Pod}, "command": req.Command}
+	if req.Container != "" {
+		query.Set("container", req.Container)
+	}
+	if req.TTY {
+		query.Set("tty", "true")
+		if req.Width > 0 && req.Height > 0 {
+			query.Set("width", strconv.Itoa(req.Width))
+			query.Set("height", strconv.Itoa(req.Height))
+		}
+	}
+	// Not traced, as the output of the command may be piped
+	u := fmt.Sprintf("%s/execPod/%s?%s", c.baseURL, namespace, query.Encode())
+
+	conn, err := c.dialWebSocket(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exec in pod %s: %w", req.Pod, err)
+	}
+	return &ExecSession{conn: conn}, nil
+}
+
+// Write sends p to the standard input of the command
+func (s *ExecSession) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := s.conn.WriteMessage(wsBinary, append([]byte{execStdin}, p...)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// CloseStdin ends the standard input of the command
+func (s *ExecSession) CloseStdin() error {
+	return s.conn.WriteMessage(wsBinary, []byte{execStdin})
+}
+
+// Resize tells the command its terminal changed size
+func (s *ExecSession) Resize(width, height int) error {
+	data, err := json.Marshal(struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	}{Width: width, Height: height})
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteMessage(wsBinary, append([]byte{execResize}, data...))
+}
+
+// Wait copies the output of the command to stdout and stderr until it exits,
+// and returns its exit code
+func (s *ExecSession) Wait(stdout, stderr io.Writer) (int, error) {
+	for {
+		opcode, msg, err := s.conn.ReadMessage()
+		if errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("exec session closed before the command exited")
+		}
+		if err != nil {
+			return 0, fmt.Errorf("exec session lost: %w", err)
+		}
+		if opcode != wsBinary || len(msg) == 0 {
+			continue
+		}
+
+		switch data := msg[1:]; msg[0] {
+		case execStdout:
+			if _, err := stdout.Write(data); err != nil {
+				return 0, err
+			}
+		case execStderr:
+			if _, err := stderr.Write(data); err != nil {
+				return 0, err
+			}
+		case execStatus:
+			var status schema.ExecStatus
+			if err := json.Unmarshal(data, &status); err != nil {
+				return 0, fmt.Errorf("failed to parse exit status: %w", err)
+			}
+			if status.Error != "" {
+				return status.ExitCode, fmt.Errorf("%s", status.Error)
+			}
+			return status.ExitCode, nil
+		}
+	}
+}
+
+// Close ends the session; the command is stopped if it still runs
+func (s *ExecSession) Close() error {
+	return s.conn.Close()
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
@@ -27,6 +28,14 @@ type errorHandler struct {
 	next APIClient
 }
 
+func (h *errorHandler) GetDeployments(ctx context.Context, appID string) (*schema.APIResponse[[]schema.Deployment], error) {
+	resp, err := h.next.GetDeployments(ctx, appID)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
 func (h *errorHandler) GetDeploymentInfo(ctx context.Context, namespace string) (*schema.APIResponse[schema.Deployment], error) {
 	resp, err := h.next.GetDeploymentInfo(ctx, namespace)
 	if err != nil {
@@ -67,8 +76,186 @@ func (h *errorHandler) GetLogs(ctx context.Context, namespace, appID string, fol
 	return logs, nil
 }
 
-func (h *errorHandler) SendFeedback(ctx context.Context, text string) error {
-	err := h.next.SendFeedback(ctx, text)
+func (h *errorHandler) StreamLogs(ctx context.Context, namespace, appID string, tail int, lastEventID string, onLine func(schema.LogLine)) error {
+	err := h.next.StreamLogs(ctx, namespace, appID, tail, lastEventID, onLine)
+	if err != nil && ctx.Err() == nil && err != api.ErrStreamClosed && err != api.ErrNotStreaming {
+		return h.handleError(err)
+	}
+	return err
+}
+
+func (h *errorHandler) GetQuota(ctx context.Context) (*schema.APIResponse[schema.Quota], error) {
+	resp, err := h.next.GetQuota(ctx)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) OpenTunnel(ctx context.Context, namespace, pod string) (*schema.APIResponse[schema.Tunnel], error) {
+	resp, err := h.next.OpenTunnel(ctx, namespace, pod)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) CloseTunnel(ctx context.Context, namespace, tunnelID string) error {
+	if err := h.next.CloseTunnel(ctx, namespace, tunnelID); err != nil {
+		return h.handleError(err)
+	}
+	return nil
+}
+
+func (h *errorHandler) CreateVolumeSnapshot(ctx context.Context, namespace, pod, volume string) (*schema.APIResponse[schema.VolumeSnapshot], error) {
+	resp, err := h.next.CreateVolumeSnapshot(ctx, namespace, pod, volume)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) ListVolumeSnapshots(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.VolumeSnapshot], error) {
+	resp, err := h.next.ListVolumeSnapshots(ctx, namespace)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) RestoreVolumeSnapshot(ctx context.Context, namespace, snapshotID string) error {
+	if err := h.next.RestoreVolumeSnapshot(ctx, namespace, snapshotID); err != nil {
+		return h.handleError(err)
+	}
+	return nil
+}
+
+func (h *errorHandler) UploadStaticSite(ctx context.Context, appName, pod, digest string, data []byte) (*schema.APIResponse[schema.StaticUpload], error) {
+	resp, err := h.next.UploadStaticSite(ctx, appName, pod, digest, data)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) RunMigration(ctx context.Context, appName string, req schema.MigrationRequest) (*schema.APIResponse[schema.Migration], error) {
+	resp, err := h.next.RunMigration(ctx, appName, req)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) GetMigration(ctx context.Context, appName, migrationID string) (*schema.APIResponse[schema.Migration], error) {
+	resp, err := h.next.GetMigration(ctx, appName, migrationID)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) GetTraffic(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.TrafficSplit], error) {
+	resp, err := h.next.GetTraffic(ctx, namespace)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) SetTraffic(ctx context.Context, namespace, pod string, stable, canary int) (*schema.APIResponse[[]schema.TrafficSplit], error) {
+	resp, err := h.next.SetTraffic(ctx, namespace, pod, stable, canary)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) ScalePod(ctx context.Context, namespace, pod string, replicas int) (*schema.APIResponse[schema.PodScale], error) {
+	resp, err := h.next.ScalePod(ctx, namespace, pod, replicas)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) Exec(ctx context.Context, namespace string, req schema.ExecRequest) (*api.ExecSession, error) {
+	session, err := h.next.Exec(ctx, namespace, req)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return session, nil
+}
+
+func (h *errorHandler) PortForward(ctx context.Context, namespace, pod string, port int) (*api.PortStream, error) {
+	stream, err := h.next.PortForward(ctx, namespace, pod, port)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return stream, nil
+}
+
+func (h *errorHandler) GetRegions(ctx context.Context, namespace string) (*schema.APIResponse[[]schema.RegionStatus], error) {
+	resp, err := h.next.GetRegions(ctx, namespace)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) GetUsage(ctx context.Context, namespace string, since time.Duration) (*schema.APIResponse[schema.Usage], error) {
+	resp, err := h.next.GetUsage(ctx, namespace, since)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) ListSecrets(ctx context.Context, appName string) (*schema.APIResponse[[]schema.StoredSecret], error) {
+	resp, err := h.next.ListSecrets(ctx, appName)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) SetSecret(ctx context.Context, appName, name, value string) error {
+	if err := h.next.SetSecret(ctx, appName, name, value); err != nil {
+		return h.handleError(err)
+	}
+	return nil
+}
+
+func (h *errorHandler) DeleteDeployment(ctx context.Context, namespace string, keepVolumes bool) error {
+	if err := h.next.DeleteDeployment(ctx, namespace, keepVolumes); err != nil {
+		return h.handleError(err)
+	}
+	return nil
+}
+
+func (h *errorHandler) ListCustomDomains(ctx context.Context, appID string) (*schema.APIResponse[[]schema.CustomDomain], error) {
+	resp, err := h.next.ListCustomDomains(ctx, appID)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+	return resp, nil
+}
+
+func (h *errorHandler) RemoveCustomDomain(ctx context.Context, appID, domain string) error {
+	if err := h.next.RemoveCustomDomain(ctx, appID, domain); err != nil {
+		return h.handleError(err)
+	}
+	return nil
+}
+
+func (h *errorHandler) DeleteSecret(ctx context.Context, appName, name string) error {
+	if err := h.next.DeleteSecret(ctx, appName, name); err != nil {
+		return h.handleError(err)
+	}
+	return nil
+}
+
+func (h *errorHandler) SendFeedback(ctx context.Context, feedback schema.Feedback) error {
+	err := h.next.SendFeedback(ctx, feedback)
 	if err != nil {
 		return h.handleError(err)
 	}
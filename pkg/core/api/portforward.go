@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PortStream is a connection to a port of a pod, its bytes carried in binary
+// websocket messages
+type PortStream struct {
+	conn *wsConn
+	buf  []byte
+}
+
+// PortForward connects to a port of a pod, which need not be exposed. Each
+// connection to forward opens its own stream.
+// Endpoint: GET /portForward/{namespace} (websocket)
+func (c *Client) PortForward(ctx context.Context, namespace string, pod string, port int) (*PortStream, error) {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return nil, fmt.Errorf("invalid namespace %q", namespace)
+	}
+	if pod == "" {
+		return nil, fmt.Errorf("pod is required")
+	}
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid port %d", port)
+	}
+
+	query := url.Values{"pod": {pod}, "port": {strconv.Itoa(port)}}
+	u := fmt.Sprintf("%s/portForward/%s?%s", c.baseURL, namespace, query.Encode())
+	conn, err := c.dialWebSocket(ctx, u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s:%d: %w", pod, port, err)
+	}
+	return &PortStream{conn: conn}, nil
+}
+
+// Read reads bytes sent by the pod; it returns io.EOF once the pod closed
+// the connection
+func (s *PortStream) Read(p []byte) (int, error) {
+	for len(s.buf) == 0 {
+		opcode, msg, err := s.conn.ReadMessage()
+		if err != nil {
+			return 0, err
+		}
+		if opcode == wsBinary {
+			s.buf = msg
+		}
+	}
+	n := copy(p, s.buf)
+	s.buf = s.buf[n:]
+	return n, nil
+}
+
+// Write sends bytes to the pod
+func (s *PortStream) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := s.conn.WriteMessage(wsBinary, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection to the pod
+func (s *PortStream) Close() error {
+	return s.conn.Close()
+}
+
+// Ensure PortStream can be copied to and from like a connection
+var _ io.ReadWriteCloser = (*PortStream)(nil)
@@ -0,0 +1,193 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+)
+
+// EnvRetries overrides how many times failed requests are retried; 0 turns
+// retries off
+const EnvRetries = "NEXLAYER_RETRIES"
+
+// IdempotencyKeyHeader names the request header that lets the API recognize
+// a retried POST and answer it without acting twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy sets how requests that failed for a transient reason are retried
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt
+	BaseDelay  time.Duration // Delay before the first retry, doubled for each next one
+	MaxDelay   time.Duration // Upper bound of a delay, including one asked by Retry-After
+}
+
+// DefaultRetryPolicy is the policy of new clients
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 30 * time.Second}
+
+// noRetryKey marks the context of requests that are never retried
+type noRetryKey struct{}
+
+// withoutRetries returns a context whose requests are tried once, for
+// requests whose answer is optional
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// retryPolicyFromEnv returns the default policy with the retries of EnvRetries
+func retryPolicyFromEnv() RetryPolicy {
+	policy := DefaultRetryPolicy
+	if v := strings.TrimSpace(os.Getenv(EnvRetries)); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			policy.MaxRetries = n
+		}
+	}
+	return policy
+}
+
+// retryTransport retries requests that failed for a transient reason, with
+// exponential backoff and jitter. Requests that are safe to repeat, including
+// POSTs with an idempotency key, are retried after network errors and
+// throttled or unavailable answers. Other requests are only retried when the
+// API cannot have acted on them: the connection was refused, or the request
+// was throttled.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+	trace  func(format string, args ...interface{})
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		retry, reason := t.shouldRetry(req, resp, err)
+		if !retry || attempt >= t.policy.MaxRetries {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp, time.Now()); ok {
+				delay = min(after, t.policy.MaxDelay)
+			}
+			// Reuse the connection for the next attempt
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		t.trace("Retrying %s %s in %s (retry %d of %d): %s\n", req.Method, req.URL.Redacted(), delay.Round(time.Millisecond), attempt+1, t.policy.MaxRetries, reason)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether the outcome of req is worth another attempt,
+// and why
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) (bool, string) {
+	if req.Body != nil && req.GetBody == nil || req.Context().Value(noRetryKey{}) != nil {
+		return false, ""
+	}
+	safe := isIdempotent(req)
+	if err != nil {
+		if req.Context().Err() != nil || errors.Is(err, offline.ErrOffline) {
+			return false, ""
+		}
+		return safe || isRefused(err), err.Error()
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true, resp.Status
+	case http.StatusRequestTimeout, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return safe, resp.Status
+	}
+	return false, ""
+}
+
+// backoff returns the delay before retry attempt+1: BaseDelay doubled for
+// each attempt, up to MaxDelay, of which a random half is taken off so that
+// clients failing together do not retry together
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.policy.MaxDelay
+	if attempt < 30 {
+		delay = min(t.policy.BaseDelay<<attempt, t.policy.MaxDelay)
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(mathrand.Int63n(int64(delay/2)+1))
+}
+
+// isIdempotent reports whether sending req twice has the effect of sending it
+// once
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// isRefused reports whether err happened while connecting, before anything
+// was sent
+func isRefused(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryAfter returns the delay asked by the Retry-After header of resp, in
+// seconds or as a date
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	v := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// newIdempotencyKey returns a random key for IdempotencyKeyHeader
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -18,6 +18,234 @@ type DeploymentResponse struct {
 	URL       string `json:"url"`
 }
 
+// Feedback categories accepted by the feedback endpoint
+const (
+	FeedbackCategoryBug     = "bug"
+	FeedbackCategoryFeature = "feature"
+	FeedbackCategoryDocs    = "docs"
+	FeedbackCategoryOther   = "other"
+)
+
+// Feedback is the request body of the feedback endpoint. Everything except
+// Text is optional context attached by the CLI.
+type Feedback struct {
+	Text         string `json:"text"`
+	Category     string `json:"category,omitempty"`
+	CLIVersion   string `json:"cliVersion,omitempty"`
+	OS           string `json:"os,omitempty"`
+	DeploymentID string `json:"deploymentId,omitempty"`
+	Config       string `json:"config,omitempty"`
+
+	Diagnostics *FeedbackDiagnostics `json:"diagnostics,omitempty"`
+}
+
+// FeedbackDiagnostics is the anonymized diagnostic bundle attached to
+// feedback, to help triage deployments that fail without an error
+type FeedbackDiagnostics struct {
+	LastDeployment   *DeploymentDiagnostics `json:"lastDeployment,omitempty"`
+	ValidationErrors []string               `json:"validationErrors,omitempty"`
+}
+
+// DeploymentDiagnostics is the outcome of the last deployment started from
+// the project. Images, vars and URLs are left out.
+type DeploymentDiagnostics struct {
+	Namespace string           `json:"namespace"`
+	StartedAt time.Time        `json:"startedAt"`
+	Revision  int              `json:"revision,omitempty"`
+	Recorded  string           `json:"recordedStatus,omitempty"` // outcome kept in the local history
+	Status    string           `json:"status,omitempty"`         // status reported by the platform
+	Error     string           `json:"error,omitempty"`          // why the status could not be fetched
+	Pods      []PodDiagnostics `json:"pods,omitempty"`
+}
+
+// PodDiagnostics is the state of a pod of the last deployment
+type PodDiagnostics struct {
+	Name     string       `json:"name"`
+	Status   string       `json:"status"`
+	Ready    bool         `json:"ready"`
+	Restarts int          `json:"restarts"`
+	Waiting  string       `json:"waitingReason,omitempty"`
+	LastExit *Termination `json:"lastTermination,omitempty"`
+}
+
+// Quota is the plan of the current account with its limits and usage
+type Quota struct {
+	Plan   string     `json:"plan"`
+	Limits QuotaUsage `json:"limits"`
+	Usage  QuotaUsage `json:"usage"`
+}
+
+// QuotaUsage counts the resources limited by a plan. In limits, zero means
+// unlimited.
+type QuotaUsage struct {
+	Apps        int     `json:"apps"`
+	Pods        int     `json:"pods"`
+	StorageGB   float64 `json:"storageGB"`
+	BandwidthGB float64 `json:"bandwidthGB"`
+}
+
+// Tunnel is a reverse tunnel that routes a deployed pod's traffic to the CLI.
+// The CLI authenticates to Endpoint with Token.
+type Tunnel struct {
+	ID       string `json:"id"`
+	Endpoint string `json:"endpoint"`
+	Token    string `json:"token"`
+	Pod      string `json:"pod"`
+	PodPort  int    `json:"podPort"`
+}
+
+// ExecRequest is a command to run in a running pod
+type ExecRequest struct {
+	Pod       string   `json:"pod"`
+	Container string   `json:"container,omitempty"` // a sidecar, instead of the pod's own container
+	Command   []string `json:"command"`
+	TTY       bool     `json:"tty,omitempty"`
+	Width     int      `json:"width,omitempty"`
+	Height    int      `json:"height,omitempty"`
+}
+
+// ExecStatus ends an exec session with the exit code of the command, or why
+// it could not run
+type ExecStatus struct {
+	ExitCode int    `json:"exitCode"`
+	Error    string `json:"error,omitempty"`
+}
+
+// VolumeSnapshot is a point-in-time copy of a pod's volume
+type VolumeSnapshot struct {
+	ID        string    `json:"id"`
+	Pod       string    `json:"pod"`
+	Volume    string    `json:"volume"`
+	SizeGB    float64   `json:"sizeGB"`
+	Status    string    `json:"status"`
+	Scheduled bool      `json:"scheduled"` // taken by the volume's snapshot policy
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// StaticUpload is a static site package stored by the platform
+type StaticUpload struct {
+	ID     string `json:"id"`
+	Image  string `json:"image"` // image that serves the package
+	Files  int    `json:"files"`
+	Digest string `json:"digest"`
+}
+
+// MigrationRequest asks the platform to run an application's migrations, or
+// another one-off command such as a seed. A release that already succeeded is
+// not run again unless Force is set.
+type MigrationRequest struct {
+	Release string   `json:"release"`
+	Pod     string   `json:"pod,omitempty"`
+	Image   string   `json:"image"`
+	Command []string `json:"command"`
+	Vars    []EnvVar `json:"vars,omitempty"`
+	Force   bool     `json:"force,omitempty"`
+	// Files are written into the container, path to content, before the
+	// command runs
+	Files map[string]string `json:"files,omitempty"`
+}
+
+// Migration is a run of an application's migrations
+type Migration struct {
+	ID         string    `json:"id"`
+	Release    string    `json:"release"`
+	Status     string    `json:"status"` // pending, running, succeeded, failed or skipped
+	ExitCode   int       `json:"exitCode"`
+	Logs       []string  `json:"logs,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+}
+
+// TrafficSplit is how a pod's traffic is shared between its stable and
+// canary versions, in percent
+type TrafficSplit struct {
+	Pod         string    `json:"pod"`
+	StableImage string    `json:"stableImage"`
+	CanaryImage string    `json:"canaryImage,omitempty"`
+	Stable      int       `json:"stable"`
+	Canary      int       `json:"canary"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// PodScale is the number of replicas of a pod. Autoscaled pods report the
+// bounds they scale within.
+type PodScale struct {
+	Pod           string `json:"pod"`
+	Replicas      int    `json:"replicas"`
+	ReadyReplicas int    `json:"readyReplicas"`
+	MinReplicas   int    `json:"minReplicas,omitempty"`
+	MaxReplicas   int    `json:"maxReplicas,omitempty"`
+}
+
+// RegionStatus is the state of a deployment in one of its regions
+type RegionStatus struct {
+	Region    string    `json:"region"`
+	Primary   bool      `json:"primary"`
+	Status    string    `json:"status"`
+	URL       string    `json:"url"`
+	ReadyPods int       `json:"readyPods"`
+	TotalPods int       `json:"totalPods"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// LogLine is a line of the logs of a deployment, as streamed by the platform
+type LogLine struct {
+	ID        string    `json:"id,omitempty"` // event id the stream resumes after
+	Pod       string    `json:"pod,omitempty"`
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	Message   string    `json:"message"`
+}
+
+// StoredSecret is a secret value stored for an application. The platform
+// resolves <% SECRET:NAME %> references to it when a deployment starts and
+// never returns the value.
+type StoredSecret struct {
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// CustomDomain is a custom domain of an application and the state of the
+// certificate the platform provisions for it
+type CustomDomain struct {
+	Domain       string    `json:"domain"`
+	Target       string    `json:"target"`    // host the domain's CNAME record must point to
+	SSLStatus    string    `json:"sslStatus"` // pending, issued or failed
+	SSLError     string    `json:"sslError,omitempty"`
+	SSLExpiresAt time.Time `json:"sslExpiresAt,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Usage is what a deployment consumed over a period, as metered by the platform
+type Usage struct {
+	Namespace string        `json:"namespace"`
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Pods      []PodUsage    `json:"pods"`
+	Volumes   []VolumeUsage `json:"volumes"`
+}
+
+// PodUsage is the metered usage of a pod. CPU, MemoryGB and GPUs are what
+// each replica requests; Hours counts replica hours.
+type PodUsage struct {
+	Name     string  `json:"name"`
+	Hours    float64 `json:"hours"`
+	CPU      float64 `json:"cpu"`
+	MemoryGB float64 `json:"memoryGb"`
+	GPUs     int     `json:"gpus,omitempty"`
+	GPUType  string  `json:"gpuType,omitempty"`
+	AvgCPU   float64 `json:"avgCpu"`   // vCPUs used on average
+	Requests int64   `json:"requests"` // requests served over the period
+}
+
+// VolumeUsage is the provisioned and used size of a volume
+type VolumeUsage struct {
+	Pod    string  `json:"pod"`
+	Name   string  `json:"name"`
+	SizeGB float64 `json:"sizeGb"`
+	UsedGB float64 `json:"usedGb"`
+	Class  string  `json:"class,omitempty"`
+}
+
 // Deployment represents a deployment in the system
 type Deployment struct {
 	Namespace    string      `json:"namespace"`
@@ -34,13 +262,32 @@ type Deployment struct {
 
 // PodStatus represents the status of a pod in a deployment
 type PodStatus struct {
-	Name      string    `json:"name"`
-	Type      string    `json:"type"`
-	Status    string    `json:"status"`
-	Ready     bool      `json:"ready"`
-	Restarts  int       `json:"restarts"`
-	Image     string    `json:"image"`
-	CreatedAt time.Time `json:"createdAt"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Status      string            `json:"status"`
+	Ready       bool              `json:"ready"`
+	Restarts    int               `json:"restarts"`
+	Image       string            `json:"image"`
+	ImageDigest string            `json:"imageDigest,omitempty"` // digest the image resolved to, sha256:...
+	CreatedAt   time.Time         `json:"createdAt"`
+	Ports       []Port            `json:"ports,omitempty"`
+	Vars        []EnvVar          `json:"vars,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Waiting     string            `json:"waitingReason,omitempty"` // why the container is not running, e.g. CrashLoopBackOff
+	LastExit    *Termination      `json:"lastTermination,omitempty"`
+}
+
+// Termination is how the previous run of a pod's container ended
+type Termination struct {
+	ExitCode   int       `json:"exitCode"`
+	Reason     string    `json:"reason,omitempty"` // e.g. Error, OOMKilled, Completed
+	FinishedAt time.Time `json:"finishedAt"`
+}
+
+// OOMKilled reports whether the container was killed for exceeding its memory
+func (t *Termination) OOMKilled() bool {
+	return t != nil && t.Reason == "OOMKilled"
 }
 
 // NexlayerYAML represents the structure of a Nexlayer deployment YAML file
@@ -68,6 +315,7 @@ type Port struct {
 	ContainerPort int    `json:"containerPort"`
 	ServicePort   int    `json:"servicePort"`
 	Name          string `json:"name"`
+	Protocol      string `json:"protocol,omitempty"`
 }
 
 // Pod represents a container configuration in a Nexlayer application
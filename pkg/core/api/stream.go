@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+)
+
+// Errors of StreamLogs when the stream ends while ctx is not done
+var (
+	ErrStreamClosed = errors.New("log stream closed by the server")
+	ErrNotStreaming = errors.New("the server does not stream logs")
+)
+
+// maxEventSize bounds a single server-sent event, such as a long log line
+const maxEventSize = 1 << 20
+
+// StreamLogs follows the logs of a deployment over server-sent events. Each
+// "log" event carries a LogLine as JSON, or the bare line; other events, such
+// as keep-alives, are ignored. Servers that do not stream answer with the
+// JSON array of GetLogs, whose lines are passed on before ErrNotStreaming.
+// Endpoint: GET /getDeploymentLogs/{namespace}?follow=true
+func (c *Client) StreamLogs(ctx context.Context, namespace string, appID string, tail int, lastEventID string, onLine func(schema.LogLine)) error {
+	namespace = strings.TrimSpace(namespace)
+	if namespace == "" || strings.Contains(namespace, "/") {
+		return fmt.Errorf("invalid namespace %q", namespace)
+	}
+
+	query := url.Values{"follow": {"true"}}
+	if appID == "" {
+		appID = c.appID
+	}
+	if appID != "" {
+		query.Set("appID", appID)
+	}
+	if tail > 0 && lastEventID == "" {
+		query.Set("tail", strconv.Itoa(tail))
+	}
+	u := fmt.Sprintf("%s/getDeploymentLogs/%s?%s", c.baseURL, namespace, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	// The stream stays open, so the client's request timeout does not apply
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to open log stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.handleAPIError(resp)
+	}
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		var lines []string
+		if err := json.NewDecoder(resp.Body).Decode(&lines); err != nil {
+			return fmt.Errorf("failed to parse logs response: %w", err)
+		}
+		for _, line := range lines {
+			onLine(schema.LogLine{Message: line})
+		}
+		return ErrNotStreaming
+	}
+
+	err = readEvents(resp.Body, func(e event) {
+		if e.name != "" && e.name != "log" && e.name != "message" {
+			return
+		}
+		line := schema.LogLine{Message: e.data}
+		if strings.HasPrefix(e.data, "{") {
+			if err := json.Unmarshal([]byte(e.data), &line); err != nil {
+				line = schema.LogLine{Message: e.data}
+			}
+		}
+		if e.id != "" {
+			line.ID = e.id
+		}
+		onLine(line)
+	})
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		return fmt.Errorf("log stream dropped: %w", err)
+	}
+	return ErrStreamClosed
+}
+
+// event is a server-sent event
+type event struct {
+	name string
+	id   string
+	data string
+}
+
+// readEvents parses server-sent events from r until it ends, calling onEvent
+// for each event with data
+func readEvents(r io.Reader, onEvent func(event)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	var e event
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				e.data = strings.Join(data, "\n")
+				onEvent(e)
+			}
+			e, data = event{}, nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // comment, used as keep-alive
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			e.name = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			e.id = value
+		}
+	}
+	return scanner.Err()
+}
@@ -104,16 +104,6 @@ type StartDeploymentResponse struct {
 	URL string `json:"url" validate:"required,url" example:"https://fantastic-fox-my-mern-app.alpha.nexlayer.ai"`
 }
 
-// SaveCustomDomainRequest represents the request body for saving a custom domain
-type SaveCustomDomainRequest struct {
-	Domain string `json:"domain" example:"mydomain.com"`
-}
-
-// SaveCustomDomainResponse represents the response from saving a custom domain
-type SaveCustomDomainResponse struct {
-	Message string `json:"message" example:"Custom domain saved successfully"`
-}
-
 // Deployment represents a deployment in the system
 type Deployment struct {
 	Namespace        string `json:"namespace" example:"ecstatic-frog"`
@@ -156,15 +146,6 @@ type DeployResponse struct {
 	Status string `json:"status"`
 }
 
-// Domain represents a custom domain
-type Domain struct {
-	Domain        string    `json:"domain"`
-	ApplicationID string    `json:"applicationId"`
-	Status        string    `json:"status"`
-	CreatedAt     time.Time `json:"createdAt"`
-	SSLEnabled    bool      `json:"sslEnabled"`
-}
-
 // ErrorResponse represents an API error response
 type ErrorResponse struct {
 	Message string `json:"message"`
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Opcodes of websocket frames
+const (
+	wsContinuation = 0x0
+	wsBinary       = 0x2
+	wsClose        = 0x8
+	wsPing         = 0x9
+	wsPong         = 0xa
+)
+
+// wsGUID is appended to the key of the handshake to compute its accept value
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// wsMaxMessage bounds a single message, such as a burst of command output
+const wsMaxMessage = 16 << 20
+
+// wsConn is a client websocket connection, as much of RFC 6455 as exec
+// needs: whole messages are read, pings answered, and frames written masked
+type wsConn struct {
+	rwc io.ReadWriteCloser
+	r   *bufio.Reader
+	wmu sync.Mutex
+}
+
+// dialWebSocket upgrades a GET of u to a websocket. The connection stays
+// open, so the client's request timeout does not apply.
+func (c *Client) dialWebSocket(ctx context.Context, u string) (*wsConn, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to create websocket key: %w", err)
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open websocket: %w", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		defer resp.Body.Close()
+		return nil, c.handleAPIError(resp)
+	}
+
+	sum := sha1.Sum([]byte(key + wsGUID))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		resp.Body.Close()
+		return nil, fmt.Errorf("invalid websocket handshake from the server")
+	}
+	rwc, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		resp.Body.Close()
+		return nil, fmt.Errorf("the connection cannot be upgraded to a websocket")
+	}
+	return &wsConn{rwc: rwc, r: bufio.NewReader(rwc)}, nil
+}
+
+// ReadMessage returns the next data message and its opcode. A close from the
+// server ends the connection with io.EOF.
+func (w *wsConn) ReadMessage() (byte, []byte, error) {
+	var opcode byte
+	var msg []byte
+	for {
+		fin, op, payload, err := w.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+		switch op {
+		case wsPing:
+			if err := w.writeFrame(wsPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case wsPong:
+			continue
+		case wsClose:
+			w.writeFrame(wsClose, payload)
+			return 0, nil, io.EOF
+		case wsContinuation:
+		default:
+			opcode, msg = op, nil
+		}
+		msg = append(msg, payload...)
+		if len(msg) > wsMaxMessage {
+			return 0, nil, fmt.Errorf("websocket message exceeds %d bytes", wsMaxMessage)
+		}
+		if fin {
+			return opcode, msg, nil
+		}
+	}
+}
+
+// WriteMessage sends a message in a single frame
+func (w *wsConn) WriteMessage(opcode byte, data []byte) error {
+	return w.writeFrame(opcode, data)
+}
+
+// Close closes the connection normally
+func (w *wsConn) Close() error {
+	w.writeFrame(wsClose, []byte{0x03, 0xe8}) // 1000, normal closure
+	return w.rwc.Close()
+}
+
+func (w *wsConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var head [2]byte
+	if _, err := io.ReadFull(w.r, head[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin = head[0]&0x80 != 0
+	opcode = head[0] & 0x0f
+	size := uint64(head[1] & 0x7f)
+	switch size {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(w.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		size = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(w.r, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		size = binary.BigEndian.Uint64(ext[:])
+	}
+	if size > wsMaxMessage {
+		return false, 0, nil, fmt.Errorf("websocket frame exceeds %d bytes", wsMaxMessage)
+	}
+
+	var mask [4]byte
+	masked := head[1]&0x80 != 0
+	if masked {
+		if _, err := io.ReadFull(w.r, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload = make([]byte, size)
+	if _, err := io.ReadFull(w.r, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, opcode, payload, nil
+}
+
+// writeFrame writes a final frame, masked as clients must
+func (w *wsConn) writeFrame(opcode byte, data []byte) error {
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	frame := make([]byte, 0, 14+len(data))
+	frame = append(frame, 0x80|opcode)
+	switch n := len(data); {
+	case n < 126:
+		frame = append(frame, 0x80|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range data {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	w.wmu.Lock()
+	defer w.wmu.Unlock()
+	_, err := w.rwc.Write(frame)
+	return err
+}
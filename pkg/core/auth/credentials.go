@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package auth logs the CLI in to Nexlayer with the OAuth device
+// authorization flow and keeps the resulting tokens in ~/.nexlayer/credentials,
+// one per profile, where the API client picks them up. A token in the
+// environment takes precedence over the stored ones.
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+)
+
+// Environment variables holding a token, in order of precedence
+const (
+	EnvAuthToken = "NEXLAYER_AUTH_TOKEN"
+	EnvToken     = "NEXLAYER_TOKEN"
+)
+
+// CredentialsFile is the name of the credentials file in ~/.nexlayer
+const CredentialsFile = "credentials"
+
+// Credentials are what a login stores
+type Credentials struct {
+	Token     string    `json:"token"`
+	Email     string    `json:"email,omitempty"`
+	AuthURL   string    `json:"authUrl,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+}
+
+// Expired reports whether the token has an expiry that has passed
+func (c *Credentials) Expired(now time.Time) bool {
+	return !c.ExpiresAt.IsZero() && now.After(c.ExpiresAt)
+}
+
+// LegacyProfile is the profile a credentials file written before tokens were
+// stored per profile belongs to: logins then always went to DefaultAuthURL
+const LegacyProfile = "production"
+
+// store is the content of the credentials file
+type store struct {
+	Profiles map[string]*Credentials `json:"profiles"`
+}
+
+// Path returns the path of the credentials file
+func Path() (string, error) {
+	home, err := system.HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".nexlayer", CredentialsFile), nil
+}
+
+// readStore reads the credentials file. A missing file is an empty store.
+func readStore(path string) (*store, error) {
+	s := &store{Profiles: map[string]*Credentials{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if _, legacy := raw["token"]; legacy {
+		var c Credentials
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		s.Profiles[LegacyProfile] = &c
+		return s, nil
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if s.Profiles == nil {
+		s.Profiles = map[string]*Credentials{}
+	}
+	return s, nil
+}
+
+// Load reads the credentials stored for a profile. It returns nil and no
+// error when none are stored.
+func Load(profile string) (*Credentials, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	s, err := readStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return s.Profiles[profile], nil
+}
+
+// Save stores the credentials of a profile, readable only by the user. The
+// credentials of the other profiles are kept.
+func Save(profile string, c *Credentials) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	s, err := readStore(path)
+	if err != nil {
+		return err
+	}
+	s.Profiles[profile] = c
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".credentials-*")
+	if err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+	return nil
+}
+
+// FromEnv returns the token set in NEXLAYER_AUTH_TOKEN or NEXLAYER_TOKEN,
+// if any
+func FromEnv() string {
+	for _, env := range []string{EnvAuthToken, EnvToken} {
+		if token := strings.TrimSpace(os.Getenv(env)); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// Token returns the token to authenticate with a profile: the one in the
+// environment when set, else the one stored for the profile unless it has
+// expired
+func Token(profile string) string {
+	if token := FromEnv(); token != "" {
+		return token
+	}
+	c, err := Load(profile)
+	if err != nil || c == nil || c.Expired(time.Now()) {
+		return ""
+	}
+	return c.Token
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+)
+
+// DefaultAuthURL is where the CLI logs in
+const DefaultAuthURL = "https://app.nexlayer.io"
+
+// ClientID identifies the CLI to the authorization server
+const ClientID = "nexlayer-cli"
+
+// Endpoints of the device authorization flow, relative to the auth URL
+const (
+	DeviceCodePath = "/oauth/device/code"
+	TokenPath      = "/oauth/token"
+)
+
+// grantDeviceCode is the grant type of device access token requests
+const grantDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+// defaultInterval is how often to poll when the server does not say
+const defaultInterval = 5 * time.Second
+
+// Errors of a device login that did not complete
+var (
+	ErrDenied  = errors.New("the login was denied in the browser")
+	ErrExpired = errors.New("the login code expired before it was approved")
+)
+
+// DeviceCode is a pending device login
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// URL returns the page to approve the login on, with the code filled in
+// when the server offers that
+func (d *DeviceCode) URL() string {
+	if d.VerificationURIComplete != "" {
+		return d.VerificationURIComplete
+	}
+	return d.VerificationURI
+}
+
+// tokenResponse is the answer to a token request
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	Email       string `json:"email"`
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// DeviceFlow logs in with the OAuth 2.0 device authorization grant (RFC 8628)
+type DeviceFlow struct {
+	AuthURL    string
+	HTTPClient *http.Client
+}
+
+// NewDeviceFlow creates a device flow against authURL, or DefaultAuthURL
+// when empty
+func NewDeviceFlow(authURL string) *DeviceFlow {
+	if authURL == "" {
+		authURL = DefaultAuthURL
+	}
+	return &DeviceFlow{
+		AuthURL:    strings.TrimSuffix(authURL, "/"),
+		HTTPClient: &http.Client{Timeout: 30 * time.Second, Transport: &offline.Transport{}},
+	}
+}
+
+// Start asks for a device code for the user to approve in a browser
+func (f *DeviceFlow) Start(ctx context.Context) (*DeviceCode, error) {
+	resp, err := f.post(ctx, DeviceCodePath, url.Values{"client_id": {ClientID}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start login: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start login: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to start login: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var code DeviceCode
+	if err := json.Unmarshal(body, &code); err != nil {
+		return nil, fmt.Errorf("failed to decode device code: %w", err)
+	}
+	if code.DeviceCode == "" || code.URL() == "" {
+		return nil, fmt.Errorf("the authorization server returned an incomplete device code")
+	}
+	return &code, nil
+}
+
+// Wait polls until the login is approved, denied or expired, and returns the
+// credentials of an approved login
+func (f *DeviceFlow) Wait(ctx context.Context, code *DeviceCode) (*Credentials, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	if code.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(code.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	form := url.Values{
+		"grant_type":  {grantDeviceCode},
+		"device_code": {code.DeviceCode},
+		"client_id":   {ClientID},
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrExpired
+			}
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		tok, err := f.token(ctx, form)
+		if err != nil {
+			return nil, err
+		}
+		switch tok.Error {
+		case "":
+			now := time.Now().UTC()
+			c := &Credentials{Token: tok.AccessToken, Email: tok.Email, AuthURL: f.AuthURL, CreatedAt: now}
+			if tok.ExpiresIn > 0 {
+				c.ExpiresAt = now.Add(time.Duration(tok.ExpiresIn) * time.Second)
+			}
+			return c, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return nil, ErrDenied
+		case "expired_token":
+			return nil, ErrExpired
+		default:
+			msg := tok.Error
+			if tok.Description != "" {
+				msg += ": " + tok.Description
+			}
+			return nil, fmt.Errorf("login failed: %s", msg)
+		}
+	}
+}
+
+// token makes one access token request
+func (f *DeviceFlow) token(ctx context.Context, form url.Values) (*tokenResponse, error) {
+	resp, err := f.post(ctx, TokenPath, form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll for the token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tok tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return nil, fmt.Errorf("failed to decode token response (status %d): %w", resp.StatusCode, err)
+	}
+	if tok.Error == "" && tok.AccessToken == "" {
+		return nil, fmt.Errorf("the authorization server returned no token (status %d)", resp.StatusCode)
+	}
+	return &tok, nil
+}
+
+func (f *DeviceFlow) post(ctx context.Context, path string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.AuthURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	return f.HTTPClient.Do(req)
+}
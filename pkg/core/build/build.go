@@ -0,0 +1,159 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package build builds, pushes and packages images with the docker CLI: it
+// rebuilds the images of pods built from source and watches a project for
+// the changes that need it, for nexlayer deploy --watch-files, and saves and
+// loads the images carried in bundles.
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/promote"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Builder builds and pushes images with the docker CLI
+type Builder struct {
+	path    string
+	baseDir string // build contexts are relative to it
+	out     io.Writer
+}
+
+// NewBuilder finds the docker CLI. Build and push output goes to out.
+func NewBuilder(baseDir string, out io.Writer) (*Builder, error) {
+	path, err := exec.LookPath("docker")
+	if err != nil {
+		return nil, fmt.Errorf("docker is required to build images; install it from https://docs.docker.com/get-docker/")
+	}
+	if out == nil {
+		out = io.Discard
+	}
+	return &Builder{path: path, baseDir: baseDir, out: out}, nil
+}
+
+// Image builds and pushes the image of a pod and returns the image pinned to
+// the digest pushed, so the platform pulls it even when the tag is reused
+func (b *Builder) Image(ctx context.Context, config *schema.NexlayerYAML, pod schema.Pod) (string, error) {
+	if pod.Build == nil {
+		return "", fmt.Errorf("pod %s has no build", pod.Name)
+	}
+	ref := schema.ResolveRegistry(config, pod.Image)
+	if strings.Contains(ref, schema.RegistryPlaceholder) {
+		return "", fmt.Errorf("pod %s: cannot push %s without application.registryLogin", pod.Name, pod.Image)
+	}
+
+	args := []string{"build", "-t", ref, "-f", pod.Build.DockerfilePath(b.baseDir)}
+	keys := make([]string, 0, len(pod.Build.Args))
+	for k := range pod.Build.Args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--build-arg", k+"="+pod.Build.Args[k])
+	}
+	args = append(args, pod.Build.Dir(b.baseDir))
+
+	if err := b.stream(ctx, args...); err != nil {
+		return "", fmt.Errorf("failed to build %s: %w", pod.Name, err)
+	}
+	if err := b.Push(ctx, ref); err != nil {
+		return "", err
+	}
+	digest, err := b.digest(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	return promote.PinImage(pod.Image, digest), nil
+}
+
+// Build builds an image tagged ref from a Dockerfile and a context
+// directory, without pushing it
+func (b *Builder) Build(ctx context.Context, ref, dockerfile, dir string) error {
+	return b.stream(ctx, "build", "-t", ref, "-f", dockerfile, dir)
+}
+
+// digest returns the digest the registry reported for a pushed image
+func (b *Builder) digest(ctx context.Context, ref string) (string, error) {
+	digests, err := b.output(ctx, nil, "image", "inspect", "--format", "{{range .RepoDigests}}{{println .}}{{end}}", ref)
+	if err != nil {
+		return "", err
+	}
+
+	repo := ref
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+	var fallback string
+	for _, line := range strings.Fields(digests) {
+		name, digest, ok := strings.Cut(line, "@")
+		if !ok {
+			continue
+		}
+		if name == repo {
+			return digest, nil
+		}
+		if fallback == "" {
+			fallback = digest
+		}
+	}
+	if fallback == "" {
+		return "", fmt.Errorf("no digest known for %s after push", ref)
+	}
+	return fallback, nil
+}
+
+// stream runs docker with its output going to out
+func (b *Builder) stream(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, b.path, args...)
+	cmd.Stdout, cmd.Stderr = b.out, b.out
+	return cmd.Run()
+}
+
+// Pods returns the pods built from source
+func Pods(config *schema.NexlayerYAML) []schema.Pod {
+	var pods []schema.Pod
+	for _, pod := range config.Application.Pods {
+		if pod.Build != nil {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// Affected returns the pods built from source whose build context contains
+// any of the paths
+func Affected(config *schema.NexlayerYAML, baseDir string, paths []string) []schema.Pod {
+	var pods []schema.Pod
+	for _, pod := range Pods(config) {
+		dir, err := filepath.Abs(pod.Build.Dir(baseDir))
+		if err != nil {
+			continue
+		}
+		for _, p := range paths {
+			if within(dir, p) {
+				pods = append(pods, pod)
+				break
+			}
+		}
+	}
+	return pods
+}
+
+// within reports whether path is dir or below it
+func within(dir, path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(dir, abs)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package build
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// Exists reports whether an image is present locally
+func (b *Builder) Exists(ctx context.Context, ref string) bool {
+	return exec.CommandContext(ctx, b.path, "image", "inspect", ref).Run() == nil
+}
+
+// Pull pulls an image from its registry
+func (b *Builder) Pull(ctx context.Context, ref string) error {
+	if err := b.stream(ctx, "pull", ref); err != nil {
+		return fmt.Errorf("failed to pull %s: %w", ref, err)
+	}
+	return nil
+}
+
+// Push pushes an image to its registry
+func (b *Builder) Push(ctx context.Context, ref string) error {
+	if err := b.stream(ctx, "push", ref); err != nil {
+		return fmt.Errorf("failed to push %s: %w", ref, err)
+	}
+	return nil
+}
+
+// Save writes an image as a docker save archive to path
+func (b *Builder) Save(ctx context.Context, ref, path string) error {
+	if _, err := b.output(ctx, nil, "save", "-o", path, ref); err != nil {
+		return fmt.Errorf("failed to save %s: %w", ref, err)
+	}
+	return nil
+}
+
+// Load loads the images of a docker save archive
+func (b *Builder) Load(ctx context.Context, r io.Reader) error {
+	out, err := b.output(ctx, r, "load")
+	if err != nil {
+		return fmt.Errorf("failed to load image: %w", err)
+	}
+	fmt.Fprint(b.out, out)
+	return nil
+}
+
+// output runs docker, feeding it stdin, and returns its output; errors carry
+// what docker printed on stderr
+func (b *Builder) output(ctx context.Context, stdin io.Reader, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, b.path, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("docker %s: %s", args[0], msg)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is how long files must stop changing before a batch of
+// changes is reported
+const DefaultDebounce = 2 * time.Second
+
+// WatchOptions selects the files Watch reports changes of
+type WatchOptions struct {
+	Root     string        // directory watched recursively
+	Files    []string      // when set, only these files are watched
+	Ignore   []string      // directories whose changes are ignored, e.g. build output
+	Debounce time.Duration // defaults to DefaultDebounce
+}
+
+// Watch calls onChange with the files changed under the root, once they
+// stopped changing for the debounce interval, until ctx is cancelled.
+// Changes made while onChange runs are reported in the next batch.
+func Watch(ctx context.Context, opts WatchOptions, onChange func(paths []string)) error {
+	if opts.Debounce <= 0 {
+		opts.Debounce = DefaultDebounce
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	ignored := make([]string, 0, len(opts.Ignore))
+	for _, dir := range opts.Ignore {
+		if abs, err := filepath.Abs(dir); err == nil {
+			ignored = append(ignored, abs)
+		}
+	}
+	skipDir := func(path string) bool {
+		base := filepath.Base(path)
+		if path != opts.Root && (strings.HasPrefix(base, ".") || base == "node_modules" || base == "vendor" || base == "dist" || base == "build" || base == "__pycache__") {
+			return true
+		}
+		for _, dir := range ignored {
+			if within(dir, path) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Editors replace files on save, so files are watched through their
+	// directory
+	only := make(map[string]bool)
+	for _, f := range opts.Files {
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			return err
+		}
+		only[abs] = true
+		if err := watcher.Add(filepath.Dir(abs)); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", f, err)
+		}
+	}
+	if len(only) == 0 {
+		if err := addTree(watcher, opts.Root, skipDir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", opts.Root, err)
+		}
+	}
+
+	pending := make(map[string]bool)
+	timer := time.NewTimer(opts.Debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			if len(only) > 0 {
+				abs, err := filepath.Abs(event.Name)
+				if err != nil || !only[abs] {
+					continue
+				}
+			} else {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// New directories are watched as well
+					if event.Op&fsnotify.Create != 0 && !skipDir(event.Name) {
+						_ = addTree(watcher, event.Name, skipDir)
+					}
+					continue
+				}
+				if ignoreFile(event.Name) || skipDir(filepath.Dir(event.Name)) {
+					continue
+				}
+			}
+			pending[event.Name] = true
+			timer.Reset(opts.Debounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("watch failed: %w", err)
+
+		case <-timer.C:
+			paths := make([]string, 0, len(pending))
+			for p := range pending {
+				paths = append(paths, p)
+			}
+			sort.Strings(paths)
+			pending = make(map[string]bool)
+			onChange(paths)
+		}
+	}
+}
+
+// addTree watches dir and the directories below it that skip does not exclude
+func addTree(watcher *fsnotify.Watcher, dir string, skip func(string) bool) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if skip(path) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// ignoreFile reports whether a file is an editor or temporary file
+func ignoreFile(path string) bool {
+	base := filepath.Base(path)
+	return strings.HasPrefix(base, ".") || strings.HasSuffix(base, "~") || strings.HasSuffix(base, ".swp") || strings.HasSuffix(base, ".swx") || strings.HasSuffix(base, ".tmp")
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package bundle reads and writes deployment export bundles.
+//
+// A bundle is a gzipped tarball holding the deployment configuration and a
+// manifest, and for air-gapped deployments the images of the pods as docker
+// save archives. Secret values never leave the source environment: they are
+// replaced by named references that are filled in again on import.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// FormatVersion is the bundle layout written by this CLI
+const FormatVersion = 1
+
+// Files inside a bundle
+const (
+	ManifestFile = "manifest.json"
+	ConfigFile   = "nexlayer.yaml"
+	ImagesDir    = "images"
+)
+
+// Manifest describes an exported deployment
+type Manifest struct {
+	FormatVersion int         `json:"formatVersion"`
+	Name          string      `json:"name"`
+	Namespace     string      `json:"namespace"`
+	URL           string      `json:"url,omitempty"`
+	Domains       []string    `json:"domains,omitempty"`
+	References    []Reference `json:"references,omitempty"`
+	Images        []Image     `json:"images,omitempty"`
+	Metadata      Metadata    `json:"metadata"`
+}
+
+// Image is an image carried in the bundle
+type Image struct {
+	Image string `json:"image"` // as written in the configuration
+	Ref   string `json:"ref"`   // name it was saved and is loaded under
+	File  string `json:"file"`  // docker save archive inside the bundle
+}
+
+// Metadata records where and when a bundle was created
+type Metadata struct {
+	ExportedAt   time.Time `json:"exportedAt"`
+	CLIVersion   string    `json:"cliVersion"`
+	SourceAPI    string    `json:"sourceApi,omitempty"`
+	TemplateName string    `json:"templateName,omitempty"`
+	Version      string    `json:"version,omitempty"`
+	Status       string    `json:"status,omitempty"`
+}
+
+// Bundle is a manifest together with the deployment configuration
+type Bundle struct {
+	Manifest Manifest
+	Config   []byte
+	// ImageFiles locates the archive of each image of the manifest on disk,
+	// by bundle file; it is only used by Write
+	ImageFiles map[string]string
+}
+
+// Write stores a bundle as a gzipped tarball at path. Image archives are
+// streamed from disk rather than held in memory.
+func Write(path string, b *Bundle) (err error) {
+	manifest, err := json.MarshalIndent(b.Manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("failed to write %s: %w", path, cerr)
+		}
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	modTime := b.Manifest.Metadata.ExportedAt
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{{ManifestFile, manifest}, {ConfigFile, b.Config}} {
+		hdr := &tar.Header{Name: file.name, Mode: 0600, Size: int64(len(file.data)), ModTime: modTime}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+	for _, img := range b.Manifest.Images {
+		if err := writeFile(tw, img.File, b.ImageFiles[img.File], modTime); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+// writeFile copies the file at src into the tarball as name
+func writeFile(tw *tar.Writer, name, src string, modTime time.Time) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: info.Size(), ModTime: modTime}); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Read loads a bundle written by Write
+func Read(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a bundle: %w", path, err)
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if hdr.Name != ManifestFile && hdr.Name != ConfigFile {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from %s: %w", hdr.Name, path, err)
+		}
+		files[hdr.Name] = data
+	}
+
+	for _, name := range []string{ManifestFile, ConfigFile} {
+		if _, ok := files[name]; !ok {
+			return nil, fmt.Errorf("%s is not a bundle: missing %s", path, name)
+		}
+	}
+
+	b := &Bundle{Config: files[ConfigFile]}
+	if err := json.Unmarshal(files[ManifestFile], &b.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if b.Manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("bundle format %d is newer than this CLI supports (%d); run 'nexlayer upgrade'", b.Manifest.FormatVersion, FormatVersion)
+	}
+	return b, nil
+}
+
+// ReadImages calls fn with each image of the bundle at path and a reader of
+// its docker save archive, in the order of the manifest's images
+func ReadImages(path string, manifest Manifest, fn func(img Image, r io.Reader) error) error {
+	byFile := make(map[string]Image, len(manifest.Images))
+	for _, img := range manifest.Images {
+		byFile[img.File] = img
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%s is not a bundle: %w", path, err)
+	}
+	defer gz.Close()
+
+	found := 0
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		img, ok := byFile[hdr.Name]
+		if !ok {
+			continue
+		}
+		if err := fn(img, tr); err != nil {
+			return err
+		}
+		found++
+	}
+	if found < len(byFile) {
+		return fmt.Errorf("%s is incomplete: %d of %d images missing", path, len(byFile)-found, len(byFile))
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Reference kinds
+const (
+	KindVar      = "var"
+	KindSecret   = "secret"
+	KindRegistry = "registry"
+)
+
+// Reference names a secret value that was left out of a bundle. Ref is the
+// environment variable that supplies it on import.
+type Reference struct {
+	Kind string `json:"kind"`
+	Pod  string `json:"pod,omitempty"`
+	Name string `json:"name"`
+	Ref  string `json:"ref"`
+}
+
+// Extract replaces secret data, sensitive vars and registry tokens in config
+// with ${REF} placeholders and returns the references
+func Extract(config *schema.NexlayerYAML) []Reference {
+	var refs []Reference
+	if login := config.Application.RegistryLogin; login != nil && login.PersonalAccessToken != "" {
+		r := Reference{Kind: KindRegistry, Name: "personalAccessToken", Ref: "REGISTRY_TOKEN"}
+		login.PersonalAccessToken = placeholder(r.Ref)
+		refs = append(refs, r)
+	}
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		for j := range pod.Vars {
+			if !schema.IsSensitiveKey(pod.Vars[j].Key) {
+				continue
+			}
+			r := Reference{Kind: KindVar, Pod: pod.Name, Name: pod.Vars[j].Key, Ref: envName(pod.Name, pod.Vars[j].Key)}
+			pod.Vars[j].Value = placeholder(r.Ref)
+			refs = append(refs, r)
+		}
+		for j := range pod.Secrets {
+			r := Reference{Kind: KindSecret, Pod: pod.Name, Name: pod.Secrets[j].Name, Ref: envName(pod.Name, pod.Secrets[j].Name)}
+			pod.Secrets[j].Data = placeholder(r.Ref)
+			refs = append(refs, r)
+		}
+	}
+	return refs
+}
+
+// Apply fills the referenced values back into config. lookup returns the value
+// of a reference; references it cannot resolve are returned as missing.
+func Apply(config *schema.NexlayerYAML, refs []Reference, lookup func(ref string) (string, bool)) (missing []string) {
+	for _, r := range refs {
+		value, ok := lookup(r.Ref)
+		if !ok {
+			missing = append(missing, r.Ref)
+			continue
+		}
+		switch r.Kind {
+		case KindRegistry:
+			if login := config.Application.RegistryLogin; login != nil {
+				login.PersonalAccessToken = value
+			}
+		case KindVar, KindSecret:
+			for i := range config.Application.Pods {
+				pod := &config.Application.Pods[i]
+				if pod.Name != r.Pod {
+					continue
+				}
+				if r.Kind == KindVar {
+					for j := range pod.Vars {
+						if pod.Vars[j].Key == r.Name {
+							pod.Vars[j].Value = value
+						}
+					}
+				} else {
+					for j := range pod.Secrets {
+						if pod.Secrets[j].Name == r.Name {
+							pod.Secrets[j].Data = value
+						}
+					}
+				}
+			}
+		}
+	}
+	return missing
+}
+
+// LoadEnvFile reads a KEY=VALUE file in the format of schema.ParseEnv
+func LoadEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	vars, err := schema.ParseEnv(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		values[v.Key] = v.Value
+	}
+	return values, nil
+}
+
+func placeholder(ref string) string {
+	return "${" + ref + "}"
+}
+
+// envName builds an environment variable name from a pod name and key,
+// e.g. ("db", "postgres-password") -> "DB_POSTGRES_PASSWORD"
+func envName(parts ...string) string {
+	var words []string
+	for _, part := range parts {
+		words = append(words, strings.FieldsFunc(part, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+	return strings.ToUpper(strings.Join(words, "_"))
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package cache keeps results that are slow or costly to get, such as the
+// answers of LLMs and project detection, under ~/.nexlayer/cache so that
+// later runs reuse them. Results are keyed by a hash of what they depend on,
+// so a change to any of it misses the cache, and expire after a TTL.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+)
+
+// Kinds of cached results, each kept in its own directory
+const (
+	KindLLM       = "llm"       // answers of LLMs
+	KindDetection = "detection" // project types detected by nexlayer init
+)
+
+// Kinds lists the kinds of cached results
+var Kinds = []string{KindLLM, KindDetection}
+
+// entry is a result as written to disk
+type entry struct {
+	StoredAt time.Time       `json:"storedAt"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// Store holds the cached results of a kind
+type Store struct {
+	dir string
+}
+
+// Dir returns the directory of the cache, NEXLAYER_CACHE_DIR when set
+func Dir() (string, error) {
+	if dir := os.Getenv("NEXLAYER_CACHE_DIR"); dir != "" {
+		return system.HostPath(dir), nil
+	}
+	home, err := system.HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".nexlayer", "cache"), nil
+}
+
+// Open returns the store of a kind. Its directory is created when a result
+// is first put.
+func Open(kind string) (*Store, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Store{dir: filepath.Join(dir, kind)}, nil
+}
+
+// Key hashes the parts a result depends on into a key
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Get reads the result stored under key into v and reports whether one
+// younger than ttl was found. Expired and unreadable results are removed.
+func (s *Store) Get(key string, ttl time.Duration, v interface{}) bool {
+	path := s.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil || time.Since(e.StoredAt) >= ttl || json.Unmarshal(e.Value, v) != nil {
+		os.Remove(path)
+		return false
+	}
+	return true
+}
+
+// Put stores a result under key. Results may quote configurations, so they
+// are only readable by the current user.
+func (s *Store) Put(key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cached result: %w", err)
+	}
+	data, err := json.Marshal(entry{StoredAt: time.Now().UTC(), Value: value})
+	if err != nil {
+		return fmt.Errorf("failed to encode cached result: %w", err)
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.dir, err)
+	}
+
+	// Concurrent runs may put the same key; renaming keeps each file whole
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cached result: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cached result: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cached result: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("failed to write cached result: %w", err)
+	}
+	return nil
+}
+
+// path returns the file of a key
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, key+".json")
+}
+
+// Usage counts cached results
+type Usage struct {
+	Entries int   `json:"entries"`
+	Bytes   int64 `json:"bytes"`
+}
+
+// Clear removes the cached results of the kinds given, or of all kinds when
+// none is, and returns what was removed
+func Clear(kinds ...string) (Usage, error) {
+	var removed Usage
+	dir, err := Dir()
+	if err != nil {
+		return removed, err
+	}
+	if len(kinds) == 0 {
+		kinds = Kinds
+	}
+	for _, kind := range kinds {
+		if !known(kind) {
+			return removed, fmt.Errorf("unknown kind of cached results %q (use %s)", kind, strings.Join(Kinds, ", "))
+		}
+	}
+	for _, kind := range kinds {
+		kindDir := filepath.Join(dir, kind)
+		err := filepath.WalkDir(kindDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			if info, err := d.Info(); err == nil {
+				removed.Bytes += info.Size()
+			}
+			removed.Entries++
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to read %s: %w", kindDir, err)
+		}
+		if err := os.RemoveAll(kindDir); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", kindDir, err)
+		}
+	}
+	return removed, nil
+}
+
+// known reports whether kind is one of Kinds
+func known(kind string) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
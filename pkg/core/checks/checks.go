@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package checks runs the synthetic HTTP checks declared in a nexlayer.yaml
+// against a deployed application and reports which of them failed.
+package checks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// DefaultTimeout bounds each request of a check
+const DefaultTimeout = 30 * time.Second
+
+// maxBody is how much of a response body is read for matching
+const maxBody = 1 << 20
+
+// Result is the outcome of one check
+type Result struct {
+	Name     string        `json:"name"`
+	URL      string        `json:"url"`
+	Status   int           `json:"status,omitempty"`
+	Latency  time.Duration `json:"-"`
+	Millis   int64         `json:"latencyMs"`
+	Failures []string      `json:"failures,omitempty"`
+}
+
+// Passed reports whether the check met all its expectations
+func (r Result) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Runner runs checks against a base URL
+type Runner struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewRunner returns a runner for baseURL whose requests time out after
+// timeout and do not follow redirects, so checks can expect them
+func NewRunner(baseURL string, timeout time.Duration) *Runner {
+	return &Runner{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// RunAll runs checks in order and returns their results
+func (r *Runner) RunAll(ctx context.Context, checks []schema.Check) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, c := range checks {
+		results = append(results, r.Run(ctx, c))
+	}
+	return results
+}
+
+// Run runs one check
+func (r *Runner) Run(ctx context.Context, c schema.Check) Result {
+	res := Result{Name: c.Label(), URL: r.BaseURL + c.Path}
+	fail := func(format string, args ...interface{}) Result {
+		res.Millis = res.Latency.Milliseconds()
+		res.Failures = append(res.Failures, fmt.Sprintf(format, args...))
+		return res
+	}
+
+	req, err := http.NewRequestWithContext(ctx, c.HTTPMethod(), res.URL, nil)
+	if err != nil {
+		return fail("invalid request: %v", err)
+	}
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+
+	start := time.Now()
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		res.Latency = time.Since(start)
+		return fail("request failed: %v", err)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+	resp.Body.Close()
+	res.Latency = time.Since(start)
+	res.Status = resp.StatusCode
+	if err != nil {
+		return fail("failed to read body: %v", err)
+	}
+
+	if want := c.ExpectedStatus(); resp.StatusCode != want {
+		fail("status %d, want %d", resp.StatusCode, want)
+	}
+	if budget, err := c.LatencyBudget(); err != nil {
+		fail("%v", err)
+	} else if budget > 0 && res.Latency > budget {
+		fail("took %s, budget %s", res.Latency.Round(time.Millisecond), budget)
+	}
+	if c.Body != "" && !strings.Contains(string(body), c.Body) {
+		fail("body does not contain %q", c.Body)
+	}
+	if c.BodyRegex != "" {
+		re, err := regexp.Compile(c.BodyRegex)
+		switch {
+		case err != nil:
+			fail("invalid body pattern: %v", err)
+		case !re.Match(body):
+			fail("body does not match %s", c.BodyRegex)
+		}
+	}
+	res.Millis = res.Latency.Milliseconds()
+	return res
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package compare reports differences between two live deployments.
+package compare
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Missing marks a value that one side does not have
+const Missing = "-"
+
+// Difference is a single value that differs between two deployments. Pod is
+// empty for deployment-wide fields such as the domain.
+type Difference struct {
+	Pod   string `json:"pod,omitempty"`
+	Field string `json:"field"`
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
+
+// pod is the comparable state of the pods sharing a name
+type pod struct {
+	images   []string
+	replicas int
+	vars     map[string]string
+}
+
+// Deployments compares images, env, domains and scale of two deployments.
+// Values of sensitive vars are never reported, only that they differ.
+func Deployments(left, right apischema.Deployment) []Difference {
+	var diffs []Difference
+	add := func(pod, field, l, r string) {
+		if l != r {
+			diffs = append(diffs, Difference{Pod: pod, Field: field, Left: l, Right: r})
+		}
+	}
+
+	add("", "domain", orMissing(left.CustomDomain), orMissing(right.CustomDomain))
+	add("", "version", orMissing(left.Version), orMissing(right.Version))
+
+	lpods, rpods := groupPods(left), groupPods(right)
+	for _, name := range unionKeys(lpods, rpods) {
+		l, lok := lpods[name]
+		r, rok := rpods[name]
+		if !lok || !rok {
+			add(name, "pod", present(lok), present(rok))
+			continue
+		}
+		add(name, "image", joinImages(l.images), joinImages(r.images))
+		add(name, "replicas", strconv.Itoa(l.replicas), strconv.Itoa(r.replicas))
+		for _, key := range unionKeys(l.vars, r.vars) {
+			lv, lok := l.vars[key]
+			rv, rok := r.vars[key]
+			if lok && rok && lv == rv {
+				continue
+			}
+			if !lok {
+				lv = Missing
+			}
+			if !rok {
+				rv = Missing
+			}
+			if schema.IsSensitiveKey(key) {
+				if lok {
+					lv = schema.Redacted
+				}
+				if rok {
+					rv = schema.Redacted
+				}
+			}
+			diffs = append(diffs, Difference{Pod: name, Field: "env." + key, Left: lv, Right: rv})
+		}
+	}
+	return diffs
+}
+
+// groupPods collects pod statuses by pod name; each status is one replica
+func groupPods(dep apischema.Deployment) map[string]*pod {
+	pods := make(map[string]*pod)
+	for _, status := range dep.PodStatuses {
+		p, ok := pods[status.Name]
+		if !ok {
+			p = &pod{vars: make(map[string]string)}
+			pods[status.Name] = p
+		}
+		p.replicas++
+		if status.Image != "" && !contains(p.images, status.Image) {
+			p.images = append(p.images, status.Image)
+		}
+		for _, v := range status.Vars {
+			p.vars[v.Key] = v.Value
+		}
+	}
+	return pods
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinImages(images []string) string {
+	if len(images) == 0 {
+		return Missing
+	}
+	sort.Strings(images)
+	return strings.Join(images, ", ")
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func orMissing(s string) string {
+	if s == "" {
+		return Missing
+	}
+	return s
+}
+
+func present(ok bool) string {
+	if ok {
+		return "present"
+	}
+	return Missing
+}
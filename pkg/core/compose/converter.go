@@ -7,7 +7,9 @@ package compose
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
@@ -17,12 +19,13 @@ import (
 	"strings"
 	"time"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/ai"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/build"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
 	"github.com/Nexlayer/nexlayer-cli/pkg/detection"
 	"github.com/Nexlayer/nexlayer-cli/pkg/knowledge"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
 	"github.com/Nexlayer/nexlayer-cli/pkg/vars"
 )
 
@@ -39,10 +42,17 @@ type DockerComposeService struct {
 	DependsOn     interface{}            `yaml:"depends_on,omitempty"`
 	Networks      interface{}            `yaml:"networks,omitempty"`
 	Restart       string                 `yaml:"restart,omitempty"`
+	NetworkMode   string                 `yaml:"network_mode,omitempty"`
 	Links         []string               `yaml:"links,omitempty"`
 	ExtraHosts    []string               `yaml:"extra_hosts,omitempty"`
+	Labels        interface{}            `yaml:"labels,omitempty"`
 	ExtraSettings map[string]interface{} `yaml:",inline,omitempty"`
 	Secrets       []interface{}          `yaml:"secrets,omitempty"`
+	Healthcheck   map[string]interface{} `yaml:"healthcheck,omitempty"`
+	Deploy        map[string]interface{} `yaml:"deploy,omitempty"`
+	CPUs          interface{}            `yaml:"cpus,omitempty"`
+	MemLimit      interface{}            `yaml:"mem_limit,omitempty"`
+	Scale         int                    `yaml:"scale,omitempty"`
 }
 
 // DockerComposeConfig represents the structure of a docker-compose.yml file
@@ -64,8 +74,34 @@ type ConvertOptions struct {
 	ApplicationURL  string
 	RegistryURL     string
 	UseAI           bool
+
+	// RegistryUsername is the username of the registryLogin emitted for
+	// services built from source when RegistryURL is set
+	RegistryUsername string
+	// BuildImages builds the images of services built from source with
+	// Docker and pushes them to RegistryURL
+	BuildImages bool
+	// OverrideFiles are merged over the compose file in order; when nil, its
+	// override file is, see Files
+	OverrideFiles []string
+	// Profiles are the active profiles, default COMPOSE_PROFILES
+	Profiles []string
+	// Log receives warnings and AI suggestions; nil writes warnings to the
+	// standard logger and suggestions to stdout
+	Log io.Writer
 }
 
+// logger returns the logger warnings are written to
+func (opts ConvertOptions) logger() *log.Logger {
+	if opts.Log == nil {
+		return log.Default()
+	}
+	return log.New(opts.Log, "", 0)
+}
+
+// aliasRegex matches names usable as pod aliases
+var aliasRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
 // DefaultPorts maps common images to their default ports for intelligent port assignment
 var DefaultPorts = map[string]int{
 	"postgres":   5432,
@@ -89,54 +125,492 @@ var DefaultVolumeSizes = map[string]string{
 	"default":    "1Gi",
 }
 
-// ParsePortMapping parses a Docker port mapping string like "8080:80/tcp"
+// ParsePortMapping parses a Docker port mapping string like "8080:80/udp" or
+// "127.0.0.1:8080:80". The protocol defaults to TCP.
 func ParsePortMapping(portStr, serviceName string) (int, int, string, error) {
-	protocol := "TCP"
-	portParts := strings.Split(portStr, "/")
-	if len(portParts) > 1 {
-		protocol = strings.ToUpper(portParts[1])
+	protocol := schema.ProtocolTCP
+	mapping := portStr
+	if i := strings.LastIndex(portStr, "/"); i >= 0 {
+		mapping = portStr[:i]
+		protocol = schema.NormalizeProtocol(portStr[i+1:])
+		if !schema.IsValidProtocol(protocol) {
+			return 0, 0, "", fmt.Errorf("unsupported protocol: %s", portStr[i+1:])
+		}
 	}
 
-	ports := strings.Split(portParts[0], ":")
+	ports := strings.Split(mapping, ":")
+	if len(ports) == 3 {
+		// Host IP; pods are exposed through the platform, not a host interface
+		ports = ports[1:]
+	}
 	if len(ports) == 1 {
 		port, err := strconv.Atoi(ports[0])
 		if err != nil {
-			log.Printf("Warning: Invalid port '%s' for service '%s'", ports[0], serviceName)
 			return 0, 0, "", fmt.Errorf("invalid port: %s", ports[0])
 		}
 		return port, port, protocol, nil
 	} else if len(ports) == 2 {
 		externalPort, err := strconv.Atoi(ports[0])
 		if err != nil {
-			log.Printf("Warning: Invalid external port '%s' for service '%s'", ports[0], serviceName)
 			return 0, 0, "", fmt.Errorf("invalid external port: %s", ports[0])
 		}
 		internalPort, err := strconv.Atoi(ports[1])
 		if err != nil {
-			log.Printf("Warning: Invalid internal port '%s' for service '%s'", ports[1], serviceName)
 			return 0, 0, "", fmt.Errorf("invalid internal port: %s", ports[1])
 		}
 		return externalPort, internalPort, protocol, nil
 	}
-	log.Printf("Warning: Invalid port mapping '%s' for service '%s'", portStr, serviceName)
 	return 0, 0, "", fmt.Errorf("invalid port mapping: %s", portStr)
 }
 
+// parseLongPort parses the long ports syntax of a compose service:
+// {target: 53, published: 5353, protocol: udp}
+func parseLongPort(entry map[string]interface{}, serviceName string) (int, int, string, error) {
+	target, ok := entry["target"].(int)
+	if !ok {
+		return 0, 0, "", fmt.Errorf("port entry has no target")
+	}
+	published := target
+	switch p := entry["published"].(type) {
+	case int:
+		published = p
+	case string:
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, 0, "", fmt.Errorf("invalid published port: %s", p)
+		}
+		published = n
+	}
+	protocol := schema.ProtocolTCP
+	if p, ok := entry["protocol"].(string); ok {
+		protocol = schema.NormalizeProtocol(p)
+		if !schema.IsValidProtocol(protocol) {
+			return 0, 0, "", fmt.Errorf("unsupported protocol: %s", p)
+		}
+	}
+	return published, target, protocol, nil
+}
+
+// convertCommand converts a compose command or entrypoint. The shell form is
+// split like compose does; the exec form is kept as is.
+func convertCommand(value interface{}, serviceName string, logger *log.Logger) schema.Command {
+	switch v := value.(type) {
+	case string:
+		args, err := schema.ParseCommand(v)
+		if err != nil {
+			logger.Printf("Warning: %v for service '%s'; splitting on whitespace", err, serviceName)
+			return strings.Fields(v)
+		}
+		return args
+	case []interface{}:
+		args := make(schema.Command, 0, len(v))
+		for _, part := range v {
+			args = append(args, fmt.Sprint(part))
+		}
+		return args
+	}
+	return nil
+}
+
+// convertLabels converts compose labels, in map or "key=value" list form.
+// Keys the platform would reject are skipped.
+func convertLabels(value interface{}, serviceName string, logger *log.Logger) map[string]string {
+	labels := make(map[string]string)
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if val == nil {
+				val = ""
+			}
+			labels[k] = fmt.Sprint(val)
+		}
+	case []interface{}:
+		for _, entry := range v {
+			k, val, _ := strings.Cut(fmt.Sprint(entry), "=")
+			labels[k] = val
+		}
+	}
+	for k := range labels {
+		if err := schema.CheckMetadataKey(k); err != nil {
+			logger.Printf("Warning: Skipping label of service '%s': %v", serviceName, err)
+			delete(labels, k)
+		}
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// convertBuild converts a compose build, a context or a map with the context,
+// Dockerfile and build arguments in map or "key=value" list form
+func convertBuild(value interface{}) (*schema.ImageBuild, error) {
+	build := &schema.ImageBuild{}
+	switch v := value.(type) {
+	case string:
+		build.Context = v
+	case map[string]interface{}:
+		build.Context, _ = v["context"].(string)
+		build.Dockerfile, _ = v["dockerfile"].(string)
+		switch args := v["args"].(type) {
+		case map[string]interface{}:
+			build.Args = make(map[string]string, len(args))
+			for k, val := range args {
+				if val == nil {
+					val = ""
+				}
+				build.Args[k] = fmt.Sprint(val)
+			}
+		case []interface{}:
+			build.Args = make(map[string]string, len(args))
+			for _, arg := range args {
+				k, val, _ := strings.Cut(fmt.Sprint(arg), "=")
+				build.Args[k] = val
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported build: %v", value)
+	}
+	if build.Context == "" {
+		build.Context = "."
+	}
+	if !filepath.IsAbs(build.Context) && !strings.HasPrefix(build.Context, ".") {
+		build.Context = "./" + build.Context
+	}
+	return build, nil
+}
+
+// setBuildImages names the image of pods built from source that have none
+// <% REGISTRY %>/<namespace>/<pod>:latest, where the namespace is the path of
+// registry, such as acme in ghcr.io/acme, or the application name. When
+// registry is set and such pods exist, the registryLogin the platform pulls
+// them with is added, its token read from the REGISTRY_TOKEN variable.
+func setBuildImages(config *schema.NexlayerYAML, registry, username string) {
+	host, namespace, _ := strings.Cut(strings.TrimSuffix(registry, "/"), "/")
+	if namespace == "" {
+		namespace = config.Application.Name
+	}
+	built := false
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		if pod.Build == nil {
+			continue
+		}
+		built = true
+		if pod.Image == "" {
+			pod.Image = fmt.Sprintf("%s/%s/%s:latest", schema.RegistryPlaceholder, namespace, pod.Name)
+		}
+	}
+	if !built || host == "" || config.Application.RegistryLogin != nil {
+		return
+	}
+	if username == "" {
+		username = "<% REGISTRY_USERNAME %>"
+	}
+	config.Application.RegistryLogin = &schema.RegistryLogin{
+		Registry:            host,
+		Username:            username,
+		PersonalAccessToken: "<% REGISTRY_TOKEN %>",
+	}
+}
+
+// buildImages builds the images of pods built from source with Docker and
+// pushes them to the registry of registryLogin. Build contexts are relative
+// to dir.
+func buildImages(config *schema.NexlayerYAML, dir string, logger *log.Logger) error {
+	pods := build.Pods(config)
+	if len(pods) == 0 {
+		return nil
+	}
+	if config.Application.RegistryLogin == nil {
+		return fmt.Errorf("images built from source need a registry to be pushed to, e.g. --registry ghcr.io/acme")
+	}
+	builder, err := build.NewBuilder(dir, os.Stderr)
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		logger.Printf("Building and pushing %s for service '%s'", schema.ResolveRegistry(config, pod.Image), pod.Name)
+		if _, err := builder.Image(context.Background(), config, pod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertDependsOn lists the services of a compose depends_on, in short list
+// or long map form
+func convertDependsOn(value interface{}) []string {
+	var deps []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, dep := range v {
+			deps = append(deps, fmt.Sprint(dep))
+		}
+	case map[string]interface{}:
+		for dep := range v {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// convertJob makes a pod of a one-shot service a job: one labelled
+// nexlayer.io/type job or cronjob, the latter with its nexlayer.io/schedule,
+// or one that is not restarted and publishes no ports
+func convertJob(pod *schema.Pod, service DockerComposeService, serviceName string, logger *log.Logger) {
+	switch t := pod.Annotations[schema.TypeLabel]; t {
+	case schema.PodTypeJob, schema.PodTypeCronJob:
+		pod.Type = t
+		pod.Schedule = pod.Annotations[schema.ScheduleLabel]
+		if t == schema.PodTypeCronJob && pod.Schedule == "" {
+			logger.Printf("Warning: Service '%s' is labelled a cron job but has no %s label", serviceName, schema.ScheduleLabel)
+		}
+	case "":
+		policy, _ := service.Deploy["restart_policy"].(map[string]interface{})
+		if (service.Restart == "no" || policy["condition"] == "none") && service.Ports == nil {
+			pod.Type = schema.PodTypeJob
+		}
+	}
+	if pod.IsJob() {
+		// Jobs serve nothing to route to
+		pod.Path = ""
+	}
+	delete(pod.Annotations, schema.TypeLabel)
+	delete(pod.Annotations, schema.ScheduleLabel)
+	if len(pod.Annotations) == 0 {
+		pod.Annotations = nil
+	}
+}
+
+// attachContainers moves the pods of services belonging to another, labelled
+// nexlayer.io/container-of or in its network with network_mode service:<name>,
+// into the pod of that service: one-shot ones as init containers, in the
+// order of their dependencies, the others as sidecars. The pod takes over
+// their dependencies.
+func attachContainers(pods []schema.Pod, composeConfig DockerComposeConfig, logger *log.Logger) []schema.Pod {
+	owners := make(map[string]string)
+	index := make(map[string]int, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		index[pod.Name] = i
+		owner := pod.Annotations[schema.ContainerOfLabel]
+		if mode := composeConfig.Services[pod.Name].NetworkMode; strings.HasPrefix(mode, "service:") {
+			owner = strings.TrimPrefix(mode, "service:")
+		}
+		delete(pod.Annotations, schema.ContainerOfLabel)
+		if owner != "" {
+			owners[pod.Name] = owner
+		}
+	}
+	if len(owners) == 0 {
+		return pods
+	}
+
+	for _, pod := range pods {
+		owner, ok := owners[pod.Name]
+		if !ok {
+			continue
+		}
+		i, found := index[owner]
+		if _, nested := owners[owner]; !found || nested {
+			logger.Printf("Warning: Service '%s' belongs to '%s', which is not converted to a pod; keeping it as a pod", pod.Name, owner)
+			delete(owners, pod.Name)
+			continue
+		}
+		if len(pod.Volumes) > 0 {
+			logger.Printf("Warning: Volumes of service '%s' are not converted; it becomes a container of pod '%s'", pod.Name, owner)
+		}
+		c := schema.Container{
+			Name:       strings.TrimPrefix(pod.Name, owner+"-"),
+			Image:      pod.Image,
+			Entrypoint: pod.Entrypoint,
+			Command:    pod.Command,
+			Vars:       pod.Vars,
+		}
+		p := &pods[i]
+		if pod.IsJob() {
+			p.InitContainers = append(p.InitContainers, c)
+		} else {
+			p.Sidecars = append(p.Sidecars, c)
+		}
+		p.SetDependencies(append(p.Dependencies(), pod.Dependencies()...))
+	}
+
+	kept := make([]schema.Pod, 0, len(pods)-len(owners))
+	for _, pod := range pods {
+		if _, ok := owners[pod.Name]; ok {
+			continue
+		}
+		var deps []string
+		seen := map[string]bool{pod.Name: true}
+		for _, dep := range pod.Dependencies() {
+			if _, attached := owners[dep]; !attached && !seen[dep] {
+				deps = append(deps, dep)
+			}
+			seen[dep] = true
+		}
+		pod.SetDependencies(deps)
+		if len(pod.Annotations) == 0 {
+			pod.Annotations = nil
+		}
+		kept = append(kept, pod)
+	}
+	return kept
+}
+
+// convertResources converts the limits of deploy.resources, or its
+// reservations when there are no limits, into the CPU and memory of a pod, and
+// a reserved GPU device into a GPU request
+func convertResources(service DockerComposeService, serviceName string, logger *log.Logger) *schema.Resources {
+	res, _ := service.Deploy["resources"].(map[string]interface{})
+	limits, _ := res["limits"].(map[string]interface{})
+	reservations, _ := res["reservations"].(map[string]interface{})
+	value := func(key string, fallback interface{}) string {
+		for _, m := range []map[string]interface{}{limits, reservations} {
+			if v, ok := m[key]; ok && v != nil {
+				return fmt.Sprint(v)
+			}
+		}
+		if fallback != nil {
+			return fmt.Sprint(fallback)
+		}
+		return ""
+	}
+
+	r := &schema.Resources{CPU: value("cpus", service.CPUs)}
+	if memory := value("memory", service.MemLimit); memory != "" {
+		if normalized, ok := schema.NormalizeMemory(memory); ok {
+			r.Memory = normalized
+		} else {
+			logger.Printf("Warning: Invalid memory '%s' for service '%s'", memory, serviceName)
+		}
+	}
+	if r.CPU != "" {
+		if _, err := schema.ParseCPU(r.CPU); err != nil {
+			logger.Printf("Warning: Invalid cpus '%s' for service '%s'", r.CPU, serviceName)
+			r.CPU = ""
+		}
+	}
+
+	devices, _ := reservations["devices"].([]interface{})
+	for _, d := range devices {
+		device, _ := d.(map[string]interface{})
+		capabilities, _ := device["capabilities"].([]interface{})
+		for _, c := range capabilities {
+			if fmt.Sprint(c) != "gpu" {
+				continue
+			}
+			count := 1
+			if n, ok := device["count"].(int); ok && n > 0 {
+				count = n
+			} else if device["count"] == "all" {
+				logger.Printf("Warning: Service '%s' reserves all GPUs; requesting 1", serviceName)
+			}
+			r.GPU = &schema.GPU{Count: count}
+		}
+	}
+
+	if r.CPU == "" && r.Memory == "" && r.GPU == nil {
+		return nil
+	}
+	return r
+}
+
+// convertHealthcheck converts a compose healthcheck into a probe. The test is
+// run as is in the exec form (CMD) and through a shell in the shell form
+// (CMD-SHELL or a string); disabled checks and NONE give no probe.
+func convertHealthcheck(hc map[string]interface{}, serviceName string, logger *log.Logger) *schema.Probe {
+	if hc == nil {
+		return nil
+	}
+	if disable, _ := hc["disable"].(bool); disable {
+		return nil
+	}
+
+	var command schema.Command
+	switch test := hc["test"].(type) {
+	case string:
+		command = schema.Command{"/bin/sh", "-c", test}
+	case []interface{}:
+		args := make([]string, 0, len(test))
+		for _, part := range test {
+			args = append(args, fmt.Sprint(part))
+		}
+		switch {
+		case len(args) == 0 || args[0] == "NONE":
+			return nil
+		case args[0] == "CMD":
+			command = args[1:]
+		case args[0] == "CMD-SHELL":
+			command = schema.Command{"/bin/sh", "-c", strings.Join(args[1:], " ")}
+		default:
+			command = args
+		}
+	}
+	if len(command) == 0 {
+		logger.Printf("Warning: Healthcheck of service '%s' has no test; skipping it", serviceName)
+		return nil
+	}
+
+	probe := &schema.Probe{Command: command}
+	duration := func(key string) string {
+		if d, ok := hc[key]; ok && d != nil {
+			return fmt.Sprint(d)
+		}
+		return ""
+	}
+	probe.Interval = duration("interval")
+	probe.Timeout = duration("timeout")
+	probe.StartPeriod = duration("start_period")
+	if retries, ok := hc["retries"].(int); ok {
+		probe.Retries = retries
+	}
+	return probe
+}
+
+// appendAlias adds alias to the aliases of pod unless it is the pod's own
+// name, already present or not a valid pod name
+func appendAlias(aliases []string, pod, alias string, logger *log.Logger) []string {
+	if alias == pod {
+		return aliases
+	}
+	for _, a := range aliases {
+		if a == alias {
+			return aliases
+		}
+	}
+	if !aliasRegex.MatchString(alias) {
+		logger.Printf("Warning: Skipping alias '%s' of service '%s'; aliases must be lowercase alphanumeric with hyphens", alias, pod)
+		return aliases
+	}
+	return append(aliases, alias)
+}
+
+// servicePort names the i-th port of a compose service
+func servicePort(serviceName string, i, port, targetPort int, protocol string) schema.ServicePort {
+	return schema.ServicePort{
+		Name:       fmt.Sprintf("%s-port-%d", serviceName, i+1),
+		Port:       port,
+		TargetPort: targetPort,
+		Protocol:   protocol,
+	}
+}
+
 // ParseVolumeMapping parses a Docker volume mapping string like "/host/path:/container/path:ro"
 func ParseVolumeMapping(volumeStr, serviceName string) (string, string, bool, error) {
 	readOnly := false
-	parts := strings.Split(volumeStr, ":")
+	parts := system.SplitVolumeSpec(volumeStr)
 	if len(parts) == 1 {
-		return parts[0], "/" + parts[0], readOnly, nil
+		return parts[0], system.ContainerPath("/" + strings.TrimPrefix(parts[0], "/")), readOnly, nil
 	} else if len(parts) >= 2 {
 		hostPath := parts[0]
-		containerPath := parts[1]
+		containerPath := system.ContainerPath(parts[1])
 		if len(parts) > 2 && parts[2] == "ro" {
 			readOnly = true
 		}
 		return hostPath, containerPath, readOnly, nil
 	}
-	log.Printf("Warning: Invalid volume mapping '%s' for service '%s'", volumeStr, serviceName)
 	return "", "", false, fmt.Errorf("invalid volume mapping: %s", volumeStr)
 }
 
@@ -146,6 +620,7 @@ func Convert(composeFilePath string, opts ConvertOptions) (*schema.NexlayerYAML,
 	if !opts.UseAI {
 		return convertBasic(composeFilePath, opts)
 	}
+	logger := opts.logger()
 
 	// Create a context with timeout for AI operations
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -163,7 +638,7 @@ func Convert(composeFilePath string, opts ConvertOptions) (*schema.NexlayerYAML,
 	// Initialize AI enhancement components if enabled
 	var enhancer *ai.Enhancer
 	detectionManager := initializeDetectionManager()
-	llmEnricher := initializeLLMEnricher()
+	llmEnricher := initializeLLMEnricher(logger)
 
 	if llmEnricher != nil {
 		enhancer = ai.NewEnhancer(llmEnricher, detectionManager)
@@ -179,19 +654,23 @@ func Convert(composeFilePath string, opts ConvertOptions) (*schema.NexlayerYAML,
 				applyAISuggestions(config, result)
 
 				// Display enhancement suggestions to the user
-				printEnhancementSuggestions(result)
+				out := opts.Log
+				if out == nil {
+					out = os.Stdout
+				}
+				printEnhancementSuggestions(result, out)
 
 				// Check for critical issues and warn user
 				if hasCriticalIssues(result) {
-					log.Printf("⚠️ Warning: The generated configuration has potential issues. Review the suggestions above.")
+					logger.Printf("⚠️ Warning: The generated configuration has potential issues. Review the suggestions above.")
 				} else {
-					log.Printf("✅ AI analysis complete: Configuration looks good!")
+					logger.Printf("✅ AI analysis complete: Configuration looks good!")
 				}
 			}
 		case err := <-errCh:
-			log.Printf("⚠️ AI enhancement failed: %v", err)
+			logger.Printf("⚠️ AI enhancement failed: %v", err)
 		case <-ctx.Done():
-			log.Printf("⚠️ AI enhancement timed out, proceeding with basic configuration")
+			logger.Printf("⚠️ AI enhancement timed out, proceeding with basic configuration")
 		}
 	}
 
@@ -201,18 +680,12 @@ func Convert(composeFilePath string, opts ConvertOptions) (*schema.NexlayerYAML,
 // convertBasic performs the basic Docker Compose to Nexlayer YAML conversion
 // This is the original conversion logic from before AI enhancement
 func convertBasic(composeFilePath string, opts ConvertOptions) (*schema.NexlayerYAML, error) {
-	content, err := os.ReadFile(composeFilePath)
+	logger := opts.logger()
+	composeConfig, err := Load(Files(composeFilePath, opts.OverrideFiles), opts.Profiles)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read Docker Compose file: %w", err)
-	}
-
-	var composeConfig DockerComposeConfig
-	if err := yaml.Unmarshal(content, &composeConfig); err != nil {
-		return nil, fmt.Errorf("failed to parse Docker Compose file: %w", err)
+		return nil, err
 	}
 
-	composeConfig.ConfigPath = composeFilePath
-
 	// Setup variable context for substitution
 	varCtx := vars.NewVariableContext()
 
@@ -236,8 +709,8 @@ func convertBasic(composeFilePath string, opts ConvertOptions) (*schema.Nexlayer
 	// Create a detector registry to help with project type detection
 	registry := detection.NewDetectorRegistry()
 
-	// Detect if we're running in an AI-powered IDE
-	projectInfo, err := registry.DetectProject(".")
+	// Detect if the project uses an AI-powered IDE
+	projectInfo, err := registry.DetectProject(filepath.Dir(composeFilePath))
 	if err == nil && projectInfo != nil && projectInfo.LLMProvider != "" {
 		// Add AI-specific annotations to the application
 		nexlayerConfig.Application.Annotations = map[string]string{
@@ -247,9 +720,9 @@ func convertBasic(composeFilePath string, opts ConvertOptions) (*schema.Nexlayer
 	}
 
 	for serviceName, service := range composeConfig.Services {
-		pod, err := convertServiceToPod(serviceName, service, composeConfig)
+		pod, err := convertServiceToPod(serviceName, service, composeConfig, logger)
 		if err != nil {
-			log.Printf("Error converting service '%s': %v", serviceName, err)
+			logger.Printf("Error converting service '%s': %v", serviceName, err)
 			if !opts.ForceConversion {
 				return nil, fmt.Errorf("failed to convert service '%s': %w", serviceName, err)
 			}
@@ -257,8 +730,10 @@ func convertBasic(composeFilePath string, opts ConvertOptions) (*schema.Nexlayer
 		}
 		nexlayerConfig.Application.Pods = append(nexlayerConfig.Application.Pods, *pod)
 
-		// Add this pod to the variable context
-		varCtx.AddPod(pod.Name)
+		// Add this pod and its aliases to the variable context
+		for _, name := range pod.HostNames() {
+			varCtx.AddPod(name)
+		}
 	}
 
 	// Process variable substitutions
@@ -292,16 +767,25 @@ func convertBasic(composeFilePath string, opts ConvertOptions) (*schema.Nexlayer
 		}
 	}
 
+	setBuildImages(nexlayerConfig, opts.RegistryURL, opts.RegistryUsername)
+	if opts.BuildImages {
+		if err := buildImages(nexlayerConfig, filepath.Dir(composeFilePath), logger); err != nil {
+			return nil, err
+		}
+	}
+
 	// Process traditional pod references (maintaining backward compatibility)
-	nexlayerConfig = addPodReferences(nexlayerConfig, composeConfig)
+	nexlayerConfig = addPodReferences(nexlayerConfig, composeConfig, logger)
 	nexlayerConfig = reorderPods(nexlayerConfig)
+	nexlayerConfig.Application.Pods = schema.OrderByDependencies(nexlayerConfig.Application.Pods)
+	nexlayerConfig.Application.Pods = attachContainers(nexlayerConfig.Application.Pods, composeConfig, logger)
 
 	// Validate the configuration
 	if err := validateNexlayerConfig(nexlayerConfig); err != nil {
 		if !opts.ForceConversion {
 			return nil, fmt.Errorf("generated Nexlayer YAML is invalid: %w", err)
 		}
-		log.Printf("Warning: Generated Nexlayer YAML has validation errors: %v", err)
+		logger.Printf("Warning: Generated Nexlayer YAML has validation errors: %v", err)
 	}
 
 	return nexlayerConfig, nil
@@ -322,7 +806,7 @@ func initializeDetectionManager() *detection.DetectionManager {
 }
 
 // initializeLLMEnricher creates a new LLM enricher for AI-powered analysis
-func initializeLLMEnricher() *knowledge.LLMEnricher {
+func initializeLLMEnricher(logger *log.Logger) *knowledge.LLMEnricher {
 	// Check if LLM environment variables are set
 	llmEnabled := os.Getenv("NEXLAYER_LLM_ENABLED")
 	if llmEnabled == "false" {
@@ -335,7 +819,7 @@ func initializeLLMEnricher() *knowledge.LLMEnricher {
 	// Get metadata directory
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		log.Printf("Warning: Could not determine user home directory: %v", err)
+		logger.Printf("Warning: Could not determine user home directory: %v", err)
 		return nil
 	}
 
@@ -344,10 +828,17 @@ func initializeLLMEnricher() *knowledge.LLMEnricher {
 	// Create LLM enricher
 	enricher := knowledge.NewLLMEnricher(graph, metadataDir)
 
+	// Answer with the LLM of the profile, if any, or the built-in analysis
+	if provider, err := llm.Default(); err == nil {
+		enricher.WithProvider(provider)
+	} else if !errors.Is(err, llm.ErrNotConfigured) {
+		logger.Printf("Warning: %v; using the built-in analysis", err)
+	}
+
 	// Load metadata (non-blocking)
 	go func() {
 		if err := enricher.LoadMetadata(); err != nil {
-			log.Printf("Warning: Could not load LLM metadata: %v", err)
+			logger.Printf("Warning: Could not load LLM metadata: %v", err)
 		}
 	}()
 
@@ -400,17 +891,17 @@ func applyAISuggestions(config *schema.NexlayerYAML, result *ai.EnhancementResul
 	}
 }
 
-// printEnhancementSuggestions prints AI suggestions to the user
-func printEnhancementSuggestions(result *ai.EnhancementResult) {
+// printEnhancementSuggestions writes AI suggestions to out
+func printEnhancementSuggestions(result *ai.EnhancementResult, out io.Writer) {
 	if len(result.Issues) == 0 && len(result.Suggestions) == 0 {
 		return
 	}
 
-	fmt.Println("\n🧠 AI Analysis Results:")
+	fmt.Fprintln(out, "\n🧠 AI Analysis Results:")
 
 	// Print issues
 	if len(result.Issues) > 0 {
-		fmt.Println("\n⚠️ Potential Issues:")
+		fmt.Fprintln(out, "\n⚠️ Potential Issues:")
 		for _, issue := range result.Issues {
 			var prefix string
 			switch issue.Type {
@@ -422,25 +913,25 @@ func printEnhancementSuggestions(result *ai.EnhancementResult) {
 				prefix = "ℹ️ NOTE"
 			}
 
-			fmt.Printf("%s: %s\n", prefix, issue.Message)
+			fmt.Fprintf(out, "%s: %s\n", prefix, issue.Message)
 
 			if len(issue.Suggestions) > 0 {
-				fmt.Println("  Suggestions:")
+				fmt.Fprintln(out, "  Suggestions:")
 				for _, suggestion := range issue.Suggestions {
-					fmt.Printf("  - %s\n", suggestion)
+					fmt.Fprintf(out, "  - %s\n", suggestion)
 				}
 			}
-			fmt.Println()
+			fmt.Fprintln(out)
 		}
 	}
 
 	// Print general suggestions
 	if len(result.Suggestions) > 0 {
-		fmt.Println("\n💡 Improvement Suggestions:")
+		fmt.Fprintln(out, "\n💡 Improvement Suggestions:")
 		for _, suggestion := range result.Suggestions {
-			fmt.Printf("- %s\n", suggestion)
+			fmt.Fprintf(out, "- %s\n", suggestion)
 		}
-		fmt.Println()
+		fmt.Fprintln(out)
 	}
 }
 
@@ -455,7 +946,7 @@ func hasCriticalIssues(result *ai.EnhancementResult) bool {
 }
 
 // convertServiceToPod converts a Docker Compose service to a Nexlayer pod
-func convertServiceToPod(serviceName string, service DockerComposeService, composeConfig DockerComposeConfig) (*schema.Pod, error) {
+func convertServiceToPod(serviceName string, service DockerComposeService, composeConfig DockerComposeConfig, logger *log.Logger) (*schema.Pod, error) {
 	pod := &schema.Pod{
 		Name:  serviceName,
 		Type:  "docker",
@@ -470,35 +961,47 @@ func convertServiceToPod(serviceName string, service DockerComposeService, compo
 		pod.Path = "/"
 	}
 
-	// Handle command
-	if service.Command != nil {
-		switch cmd := service.Command.(type) {
-		case string:
-			pod.Command = cmd
-		case []interface{}:
-			cmdParts := make([]string, 0, len(cmd))
-			for _, part := range cmd {
-				if strPart, ok := part.(string); ok {
-					cmdParts = append(cmdParts, strPart)
-				}
-			}
-			pod.Command = strings.Join(cmdParts, " ")
+	// Services built from source keep their build; the image is named once
+	// the application name is known, see setBuildImages
+	if service.Build != nil {
+		build, err := convertBuild(service.Build)
+		if err != nil {
+			return nil, err
 		}
+		pod.Build = build
 	}
 
-	// Handle entrypoint
-	if service.Entrypoint != nil {
-		switch entry := service.Entrypoint.(type) {
-		case string:
-			pod.Entrypoint = entry
-		case []interface{}:
-			entryParts := make([]string, 0, len(entry))
-			for _, part := range entry {
-				if strPart, ok := part.(string); ok {
-					entryParts = append(entryParts, strPart)
-				}
+	// Handle command and entrypoint; exec form is kept argument for argument
+	pod.Command = convertCommand(service.Command, serviceName, logger)
+	pod.Entrypoint = convertCommand(service.Entrypoint, serviceName, logger)
+
+	// Container labels carry arbitrary values, so they become pod annotations
+	pod.Annotations = convertLabels(service.Labels, serviceName, logger)
+	convertJob(pod, service, serviceName, logger)
+
+	// Startup order and health checks; dependencies are started first and,
+	// when they have a probe, waited for until healthy
+	pod.SetDependencies(convertDependsOn(service.DependsOn))
+	pod.Probe = convertHealthcheck(service.Healthcheck, serviceName, logger)
+
+	// Sizing from deploy.resources, or the older cpus and mem_limit
+	pod.Resources = convertResources(service, serviceName, logger)
+
+	// Replicas from deploy.replicas, or the older scale
+	if n, ok := service.Deploy["replicas"].(int); ok && n > 1 {
+		pod.Replicas = n
+	} else if service.Scale > 1 {
+		pod.Replicas = service.Scale
+	}
+
+	// Network aliases become pod aliases, reachable as <alias>.pod
+	if networks, ok := service.Networks.(map[string]interface{}); ok {
+		for _, network := range networks {
+			settings, _ := network.(map[string]interface{})
+			aliases, _ := settings["aliases"].([]interface{})
+			for _, alias := range aliases {
+				pod.Aliases = appendAlias(pod.Aliases, pod.Name, fmt.Sprint(alias), logger)
 			}
-			pod.Entrypoint = strings.Join(entryParts, " ")
 		}
 	}
 
@@ -511,19 +1014,26 @@ func convertServiceToPod(serviceName string, service DockerComposeService, compo
 				if portStr, ok := portDef.(string); ok {
 					externalPort, internalPort, protocol, err := ParsePortMapping(portStr, serviceName)
 					if err != nil {
+						logger.Printf("Warning: Skipping port '%s' of service '%s': %v", portStr, serviceName, err)
 						continue
 					}
-					pod.ServicePorts = append(pod.ServicePorts, schema.ServicePort{
-						Name:       fmt.Sprintf("%s-port-%d", serviceName, i+1),
-						Port:       externalPort,
-						TargetPort: internalPort,
-						Protocol:   protocol,
-					})
+					pod.ServicePorts = append(pod.ServicePorts, servicePort(serviceName, i, externalPort, internalPort, protocol))
+				} else if port, ok := portDef.(int); ok {
+					pod.ServicePorts = append(pod.ServicePorts, servicePort(serviceName, i, port, port, schema.ProtocolTCP))
+				} else if long, ok := portDef.(map[string]interface{}); ok {
+					externalPort, internalPort, protocol, err := parseLongPort(long, serviceName)
+					if err != nil {
+						logger.Printf("Warning: Skipping a port of service '%s': %v", serviceName, err)
+						continue
+					}
+					pod.ServicePorts = append(pod.ServicePorts, servicePort(serviceName, i, externalPort, internalPort, protocol))
 				}
 			}
 		}
 	}
-	if len(pod.ServicePorts) == 0 {
+	// Jobs and services in the network of another, which become its
+	// containers, serve no ports of their own
+	if len(pod.ServicePorts) == 0 && !pod.IsJob() && !strings.HasPrefix(service.NetworkMode, "service:") {
 		defaultPort := 80
 		for img, port := range DefaultPorts {
 			if strings.Contains(strings.ToLower(service.Image), img) {
@@ -535,9 +1045,9 @@ func convertServiceToPod(serviceName string, service DockerComposeService, compo
 			Name:       fmt.Sprintf("%s-port-1", serviceName),
 			Port:       defaultPort,
 			TargetPort: defaultPort,
-			Protocol:   "TCP",
+			Protocol:   schema.ProtocolTCP,
 		})
-		log.Printf("Warning: No ports specified for service '%s', using default port %d", serviceName, defaultPort)
+		logger.Printf("Warning: No ports specified for service '%s', using default port %d", serviceName, defaultPort)
 	}
 
 	// Handle volumes with intelligent sizing
@@ -549,6 +1059,7 @@ func convertServiceToPod(serviceName string, service DockerComposeService, compo
 				if volumeStr, ok := volumeDef.(string); ok {
 					volumeName, containerPath, readOnly, err := ParseVolumeMapping(volumeStr, serviceName)
 					if err != nil {
+						logger.Printf("Warning: Skipping volume '%s' of service '%s': %v", volumeStr, serviceName, err)
 						continue
 					}
 
@@ -612,11 +1123,14 @@ func convertServiceToPod(serviceName string, service DockerComposeService, compo
 		}
 	}
 
-	// Handle env_file
+	// Handle env_file, relative to the compose file like compose does
 	if service.EnvFile != nil {
 		envFiles := parseEnvFiles(service.EnvFile)
 		for _, envFile := range envFiles {
-			pod.Vars = append(pod.Vars, parseEnvFile(envFile)...)
+			if !filepath.IsAbs(envFile) && composeConfig.ConfigPath != "" {
+				envFile = filepath.Join(filepath.Dir(composeConfig.ConfigPath), envFile)
+			}
+			pod.Vars = append(pod.Vars, parseEnvFile(envFile, logger)...)
 		}
 	}
 
@@ -663,11 +1177,11 @@ func parseEnvFiles(envFilesDef interface{}) []string {
 }
 
 // parseEnvFile reads and parses a .env file into environment variables
-func parseEnvFile(filePath string) []schema.EnvVar {
+func parseEnvFile(filePath string, logger *log.Logger) []schema.EnvVar {
 	vars := make([]schema.EnvVar, 0)
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		log.Printf("Warning: Failed to read env file '%s': %v", filePath, err)
+		logger.Printf("Warning: Failed to read env file '%s': %v", filePath, err)
 		return vars
 	}
 
@@ -710,11 +1224,29 @@ func createSecret(secretName string) (schema.Secret, error) {
 }
 
 // addPodReferences modifies environment variables to use pod references - legacy method for compatibility
-func addPodReferences(config *schema.NexlayerYAML, composeConfig DockerComposeConfig) *schema.NexlayerYAML {
-	// Build service name to pod name map (legacy approach for compatibility)
+func addPodReferences(config *schema.NexlayerYAML, composeConfig DockerComposeConfig, logger *log.Logger) *schema.NexlayerYAML {
+	// Links such as "db:database" make a service reachable under another name
+	pods := make(map[string]*schema.Pod, len(config.Application.Pods))
+	for i := range config.Application.Pods {
+		pods[config.Application.Pods[i].Name] = &config.Application.Pods[i]
+	}
+	for _, service := range composeConfig.Services {
+		for _, link := range service.Links {
+			target, alias, ok := strings.Cut(link, ":")
+			if pod := pods[target]; ok && pod != nil {
+				if _, taken := pods[alias]; !taken {
+					pod.Aliases = appendAlias(pod.Aliases, pod.Name, alias, logger)
+				}
+			}
+		}
+	}
+
+	// Build service name and alias to pod reference map (legacy approach for compatibility)
 	serviceMap := make(map[string]string, len(config.Application.Pods))
 	for _, pod := range config.Application.Pods {
-		serviceMap[pod.Name] = pod.Name + ".pod"
+		for _, name := range pod.HostNames() {
+			serviceMap[name] = name + ".pod"
+		}
 	}
 
 	// Process each pod's environment variables for service references
@@ -766,7 +1298,7 @@ func validateNexlayerConfig(config *schema.NexlayerYAML) error {
 			return fmt.Errorf("image is required for pod '%s'", pod.Name)
 		}
 
-		if len(pod.ServicePorts) == 0 {
+		if len(pod.ServicePorts) == 0 && !pod.IsJob() {
 			return fmt.Errorf("at least one service port is required for pod '%s'", pod.Name)
 		}
 	}
@@ -824,56 +1356,67 @@ func isInfrastructurePod(podName string) bool {
 	return false
 }
 
+// FileNames are the names of Docker Compose files in order of preference
+var FileNames = []string{
+	"docker-compose.yml",
+	"docker-compose.yaml",
+	"docker-compose.dev.yml",
+	"docker-compose.prod.yml",
+	"compose.yml",
+	"compose.yaml",
+}
+
+// Find returns the preferred Docker Compose file in dir
+func Find(dir string) (string, error) {
+	for _, name := range FileNames {
+		p := filepath.Join(dir, name)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("no Docker Compose file found in %s", dir)
+}
+
 // DetectAndConvert tries to detect a Docker Compose file in the given directory
-// and convert it to a Nexlayer YAML if found
-func DetectAndConvert(dir string, appName string) (*schema.NexlayerYAML, error) {
-	fmt.Printf("🔍 Searching for Docker Compose files in directory: %s\n", dir)
+// and convert it to a Nexlayer YAML if found, reporting its progress to out
+func DetectAndConvert(dir string, appName string, out io.Writer) (*schema.NexlayerYAML, error) {
+	fmt.Fprintf(out, "🔍 Searching for Docker Compose files in directory: %s\n", dir)
 
 	opts := ConvertOptions{
 		ProjectDir:      dir,
 		ApplicationName: appName,
 	}
 
-	// List of common Compose file names in order of preference
-	composeFiles := []string{
-		"docker-compose.yml",
-		"docker-compose.yaml",
-		"docker-compose.dev.yml",
-		"docker-compose.prod.yml",
-		"compose.yml",
-		"compose.yaml",
-	}
-
-	for _, fileName := range composeFiles {
+	for _, fileName := range FileNames {
 		composePath := filepath.Join(dir, fileName)
-		fmt.Printf("🔍 Checking for compose file at: %s\n", composePath)
+		fmt.Fprintf(out, "🔍 Checking for compose file at: %s\n", composePath)
 
 		if _, err := os.Stat(composePath); err == nil {
 			// Found a Docker Compose file, convert it
-			fmt.Printf("✅ Found Docker Compose file: %s\n", composePath)
+			fmt.Fprintf(out, "✅ Found Docker Compose file: %s\n", composePath)
 
 			config, err := Convert(composePath, opts)
 			if err != nil {
-				fmt.Printf("❌ Error converting Docker Compose file: %v\n", err)
+				fmt.Fprintf(out, "❌ Error converting Docker Compose file: %v\n", err)
 				return nil, err
 			}
 
-			fmt.Printf("✅ Successfully converted Docker Compose file with %d services\n", len(config.Application.Pods))
+			fmt.Fprintf(out, "✅ Successfully converted Docker Compose file with %d services\n", len(config.Application.Pods))
 
 			// Print summary of converted pods
-			fmt.Printf("✅ Converted Docker Compose to Nexlayer YAML with %d pods:\n", len(config.Application.Pods))
+			fmt.Fprintf(out, "✅ Converted Docker Compose to Nexlayer YAML with %d pods:\n", len(config.Application.Pods))
 			for i, pod := range config.Application.Pods {
-				fmt.Printf("  - Pod %d: %s (image: %s)\n", i+1, pod.Name, pod.Image)
+				fmt.Fprintf(out, "  - Pod %d: %s (image: %s)\n", i+1, pod.Name, pod.Image)
 			}
 
 			return config, nil
 		} else {
-			fmt.Printf("❌ Compose file not found at: %s (error: %v)\n", composePath, err)
+			fmt.Fprintf(out, "❌ Compose file not found at: %s (error: %v)\n", composePath, err)
 		}
 	}
 
 	// No Docker Compose file found
-	fmt.Printf("❌ No Docker Compose file found in %s\n", dir)
+	fmt.Fprintf(out, "❌ No Docker Compose file found in %s\n", dir)
 	return nil, fmt.Errorf("no Docker Compose file found in %s", dir)
 }
 
@@ -890,27 +1433,31 @@ func ConvertToNexlayer(composeConfig DockerComposeConfig) (*schema.NexlayerYAML,
 		},
 	}
 
-	// Detect if we're running in an AI-powered IDE
-	projectInfo, err := registry.DetectProject(".")
+	// Detect if the project uses an AI-powered IDE
+	projectInfo, err := registry.DetectProject(filepath.Dir(composeConfig.ConfigPath))
 	if err == nil && projectInfo != nil && projectInfo.LLMProvider != "" {
 		// We'll add AI-specific metadata to pods after they're created
 		// This is handled in ConvertToNexlayer
 	}
 
 	// Convert services to pods
+	logger := log.Default()
 	for serviceName, service := range composeConfig.Services {
-		pod, err := convertServiceToPod(serviceName, service, composeConfig)
+		pod, err := convertServiceToPod(serviceName, service, composeConfig, logger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert service %s: %w", serviceName, err)
 		}
 		config.Application.Pods = append(config.Application.Pods, *pod)
 	}
 
-	// Sort pods to ensure deterministic output
+	// Sort pods to ensure deterministic output, dependencies first
 	sortPods(config.Application.Pods)
+	setBuildImages(config, "", "")
+	config.Application.Pods = schema.OrderByDependencies(config.Application.Pods)
+	config.Application.Pods = attachContainers(config.Application.Pods, composeConfig, logger)
 
 	// Add pod references
-	config = addPodReferences(config, composeConfig)
+	config = addPodReferences(config, composeConfig, logger)
 
 	return config, nil
 }
@@ -923,19 +1470,12 @@ func sortPods(pods []schema.Pod) {
 }
 
 func ConvertFromFile(composeFilePath string) (*schema.NexlayerYAML, error) {
-	// Read and parse Docker Compose file
-	data, err := os.ReadFile(composeFilePath)
+	// Read the Docker Compose file with its override file
+	composeConfig, err := Load(Files(composeFilePath, nil), nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read Docker Compose file: %w", err)
-	}
-
-	var composeConfig DockerComposeConfig
-	if err := yaml.Unmarshal(data, &composeConfig); err != nil {
-		return nil, fmt.Errorf("failed to parse Docker Compose file: %w", err)
+		return nil, err
 	}
 
-	composeConfig.ConfigPath = composeFilePath
-
 	// Create a detector registry to help with project type detection
 	registry := detection.NewDetectorRegistry()
 
@@ -947,27 +1487,31 @@ func ConvertFromFile(composeFilePath string) (*schema.NexlayerYAML, error) {
 		},
 	}
 
-	// Detect if we're running in an AI-powered IDE
-	projectInfo, err := registry.DetectProject(".")
+	// Detect if the project uses an AI-powered IDE
+	projectInfo, err := registry.DetectProject(filepath.Dir(composeConfig.ConfigPath))
 	if err == nil && projectInfo != nil && projectInfo.LLMProvider != "" {
 		// We'll add AI-specific metadata to pods after they're created
 		// This is handled in ConvertToNexlayer
 	}
 
 	// Convert services to pods
+	logger := log.Default()
 	for serviceName, service := range composeConfig.Services {
-		pod, err := convertServiceToPod(serviceName, service, composeConfig)
+		pod, err := convertServiceToPod(serviceName, service, composeConfig, logger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert service %s: %w", serviceName, err)
 		}
 		nexlayerConfig.Application.Pods = append(nexlayerConfig.Application.Pods, *pod)
 	}
 
-	// Sort pods to ensure deterministic output
+	// Sort pods to ensure deterministic output, dependencies first
 	sortPods(nexlayerConfig.Application.Pods)
+	setBuildImages(nexlayerConfig, "", "")
+	nexlayerConfig.Application.Pods = schema.OrderByDependencies(nexlayerConfig.Application.Pods)
+	nexlayerConfig.Application.Pods = attachContainers(nexlayerConfig.Application.Pods, composeConfig, logger)
 
 	// Add pod references
-	nexlayerConfig = addPodReferences(nexlayerConfig, composeConfig)
+	nexlayerConfig = addPodReferences(nexlayerConfig, composeConfig, logger)
 
 	return nexlayerConfig, nil
 }
@@ -0,0 +1,688 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package compose
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/dev"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// placeholderRegex matches values the platform fills in, such as <% DB_PASSWORD %>
+var placeholderRegex = regexp.MustCompile(`<%\s*([A-Za-z0-9_]+)\s*%>`)
+
+// ExportOptions tune the export
+type ExportOptions struct {
+	// ProjectDir is the directory of nexlayer.yaml relative to the compose
+	// file, which build contexts and static sites are resolved against
+	ProjectDir string
+}
+
+// Exported is a configuration exported as a Docker Compose project
+type Exported struct {
+	Project ExportedProject
+	// Env lists the variables the project reads from .env or the shell, the
+	// values the platform fills in
+	Env   []string
+	Notes []string
+}
+
+// ExportedProject is the docker-compose.yml written by Export
+type ExportedProject struct {
+	Name     string                     `yaml:"name"`
+	Services map[string]ExportedService `yaml:"services"`
+	Volumes  map[string]struct{}        `yaml:"volumes,omitempty"`
+	Configs  map[string]ExportedConfig  `yaml:"configs,omitempty"`
+}
+
+// ExportedService is a pod as a compose service
+type ExportedService struct {
+	Image       string                        `yaml:"image,omitempty"`
+	Build       *ExportedBuild                `yaml:"build,omitempty"`
+	Entrypoint  []string                      `yaml:"entrypoint,omitempty"`
+	Command     []string                      `yaml:"command,omitempty"`
+	Environment map[string]string             `yaml:"environment,omitempty"`
+	Ports       []string                      `yaml:"ports,omitempty"`
+	Volumes     []string                      `yaml:"volumes,omitempty"`
+	Configs     []ExportedConfigMount         `yaml:"configs,omitempty"`
+	Healthcheck *ExportedHealthcheck          `yaml:"healthcheck,omitempty"`
+	Deploy      *ExportedDeploy               `yaml:"deploy,omitempty"`
+	DependsOn   map[string]ExportedDependency `yaml:"depends_on,omitempty"`
+	Networks    map[string]ExportedNetwork    `yaml:"networks,omitempty"`
+	NetworkMode string                        `yaml:"network_mode,omitempty"`
+	Labels      map[string]string             `yaml:"labels,omitempty"`
+	Restart     string                        `yaml:"restart,omitempty"`
+}
+
+// ExportedBuild builds the image of a service from source
+type ExportedBuild struct {
+	Context    string            `yaml:"context"`
+	Dockerfile string            `yaml:"dockerfile,omitempty"`
+	Args       map[string]string `yaml:"args,omitempty"`
+}
+
+// ExportedHealthcheck is the probe of a pod
+type ExportedHealthcheck struct {
+	Test        []string `yaml:"test"`
+	Interval    string   `yaml:"interval,omitempty"`
+	Timeout     string   `yaml:"timeout,omitempty"`
+	Retries     int      `yaml:"retries,omitempty"`
+	StartPeriod string   `yaml:"start_period,omitempty"`
+}
+
+// ExportedDeploy sizes and replicates a service
+type ExportedDeploy struct {
+	Replicas  int                `yaml:"replicas,omitempty"`
+	Resources *ExportedResources `yaml:"resources,omitempty"`
+}
+
+// ExportedResources are the limits and GPU reservations of a service
+type ExportedResources struct {
+	Limits       *ExportedLimits       `yaml:"limits,omitempty"`
+	Reservations *ExportedReservations `yaml:"reservations,omitempty"`
+}
+
+// ExportedLimits caps the CPU and memory of a service
+type ExportedLimits struct {
+	CPUs   string `yaml:"cpus,omitempty"`
+	Memory string `yaml:"memory,omitempty"`
+}
+
+// ExportedReservations reserves devices for a service
+type ExportedReservations struct {
+	Devices []ExportedDevice `yaml:"devices"`
+}
+
+// ExportedDevice is a reserved device such as a GPU
+type ExportedDevice struct {
+	Driver       string   `yaml:"driver"`
+	Count        int      `yaml:"count"`
+	Capabilities []string `yaml:"capabilities"`
+}
+
+// ExportedConfig is a file mounted into services, with inline content
+type ExportedConfig struct {
+	Content string `yaml:"content"`
+}
+
+// ExportedConfigMount mounts a config at a path of a service
+type ExportedConfigMount struct {
+	Source string `yaml:"source"`
+	Target string `yaml:"target"`
+}
+
+// ExportedDependency is a service started before another
+type ExportedDependency struct {
+	Condition string `yaml:"condition"`
+}
+
+// ExportedNetwork attaches a service to a network under aliases
+type ExportedNetwork struct {
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// YAML encodes the project
+func (e *Exported) YAML() ([]byte, error) {
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(e.Project); err != nil {
+		return nil, fmt.Errorf("failed to encode compose file: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
+
+// exporter carries the state of one export
+type exporter struct {
+	opts    ExportOptions
+	app     schema.Application
+	result  *Exported
+	hosts   map[string]string // host name -> pod
+	hostRef *regexp.Regexp
+	url     string
+	env     map[string]bool
+}
+
+// Export turns a configuration into a Docker Compose project to run the
+// application locally. Each pod becomes a service reachable as <pod>.pod and
+// <alias>.pod, with its service ports published and its volumes as named
+// volumes; pods that another depends on or references in its vars are started
+// first, and waited for until healthy when they have a probe. Secrets
+// and config files become configs with inline content, and static sites are
+// served by nginx. Values the platform fills in, such as <% DB_PASSWORD %>,
+// are read from the environment as ${DB_PASSWORD}. Config files and envFrom
+// must have been resolved, and services expanded.
+func Export(config *schema.NexlayerYAML, opts ExportOptions) (*Exported, error) {
+	app := config.Application
+	if app.Name == "" {
+		return nil, fmt.Errorf("application name is required")
+	}
+	if len(app.Pods) == 0 {
+		return nil, fmt.Errorf("no pods to export")
+	}
+	if opts.ProjectDir == "" {
+		opts.ProjectDir = "."
+	}
+
+	e := &exporter{
+		opts: opts,
+		app:  app,
+		result: &Exported{Project: ExportedProject{
+			Name:     app.Name,
+			Services: make(map[string]ExportedService, len(app.Pods)),
+		}},
+		hosts: make(map[string]string),
+		env:   make(map[string]bool),
+	}
+	var hosts []string
+	for _, pod := range app.Pods {
+		for _, h := range pod.HostNames() {
+			e.hosts[h] = pod.Name
+			hosts = append(hosts, regexp.QuoteMeta(h))
+		}
+	}
+	e.hostRef = regexp.MustCompile(`\b(` + strings.Join(hosts, "|") + `)\.pod\b`)
+	e.url = e.localURL()
+
+	for _, pod := range app.Pods {
+		service, err := e.service(pod)
+		if err != nil {
+			return nil, err
+		}
+		e.result.Project.Services[pod.Name] = service
+	}
+	for _, pod := range app.Pods {
+		if err := e.containers(pod); err != nil {
+			return nil, err
+		}
+	}
+	if m := app.Migrations; m != nil {
+		if err := e.migrations(config, *m); err != nil {
+			return nil, err
+		}
+	}
+	if app.Regions != nil {
+		e.note("Regions are not exported; the project runs in a single place")
+	}
+
+	for name := range e.env {
+		e.result.Env = append(e.result.Env, name)
+	}
+	sort.Strings(e.result.Env)
+	if len(e.result.Env) > 0 {
+		e.note("Set %s in .env or the environment before docker compose up", strings.Join(e.result.Env, ", "))
+	}
+	return e.result, nil
+}
+
+// service translates a pod
+func (e *exporter) service(pod schema.Pod) (ExportedService, error) {
+	s := ExportedService{
+		Networks: map[string]ExportedNetwork{"default": {Aliases: e.aliases(pod)}},
+		Labels:   pod.Labels,
+	}
+	for _, sp := range pod.ServicePorts {
+		mapping := fmt.Sprintf("%d:%d", sp.Port, sp.TargetPort)
+		if schema.NormalizeProtocol(sp.Protocol) == schema.ProtocolUDP {
+			mapping += "/udp"
+		}
+		s.Ports = append(s.Ports, mapping)
+	}
+
+	if pod.IsStatic() {
+		return e.staticService(pod, s)
+	}
+
+	image, err := e.image(pod.Name, pod.Image, pod.Build)
+	if err != nil {
+		return ExportedService{}, err
+	}
+	s.Image = image
+	if pod.Build != nil {
+		s.Build = e.build(*pod.Build)
+	}
+	s.Entrypoint = escapeAll(pod.Entrypoint)
+	s.Command = escapeAll(pod.Command)
+	s.Environment = e.environment(pod.Vars)
+	s.DependsOn = e.dependencies(pod)
+	s.Healthcheck = e.healthcheck(pod)
+	s.Deploy = deploy(pod)
+
+	for _, vol := range pod.Volumes {
+		name := pod.Name + "-" + vol.Name
+		if e.result.Project.Volumes == nil {
+			e.result.Project.Volumes = make(map[string]struct{})
+		}
+		e.result.Project.Volumes[name] = struct{}{}
+		mount := name + ":" + vol.Path
+		if vol.ReadOnly {
+			mount += ":ro"
+		}
+		s.Volumes = append(s.Volumes, mount)
+	}
+	for _, secret := range pod.Secrets {
+		s.Configs = append(s.Configs, e.config(pod.Name+"-secret-"+secret.Name, path.Join(secret.Path, secret.FileName), secret.Data))
+	}
+	for _, cf := range pod.ConfigFiles {
+		s.Configs = append(s.Configs, e.config(pod.Name+"-config-"+cf.FileName, path.Join(cf.Path, cf.FileName), cf.Content))
+	}
+
+	if n, _ := pod.GPURequest(); n > 0 {
+		e.note("Pod %s requests %d GPU(s); add a device reservation to run it on a GPU host", pod.Name, n)
+	}
+	if pod.Canary != nil {
+		e.note("Pod %s has a canary; only the stable version is exported", pod.Name)
+	}
+	if pod.Autoscale != nil {
+		e.note("Pod %s is autoscaled; compose runs its minimum of %d replicas", pod.Name, pod.Autoscale.Min)
+	}
+	if pod.IsJob() {
+		e.job(pod, &s)
+	}
+	if pod.ReplicaCount() > 1 && len(s.Ports) > 0 {
+		e.note("Pod %s runs %d replicas, which cannot all publish the same host ports; remove its ports or publish ranges", pod.Name, pod.ReplicaCount())
+	}
+	return s, nil
+}
+
+// staticService serves a static pod's build output with nginx in place of the
+// platform
+func (e *exporter) staticService(pod schema.Pod, s ExportedService) (ExportedService, error) {
+	if pod.Static == nil {
+		return ExportedService{}, fmt.Errorf("pod %s: static pods need static.dir", pod.Name)
+	}
+	port := schema.StaticPort
+	if len(pod.ServicePorts) > 0 {
+		port = pod.ServicePorts[0].TargetPort
+	} else {
+		s.Ports = []string{fmt.Sprintf("%d:%d", port, port)}
+	}
+	fallback := "=404"
+	if pod.Static.SPA {
+		fallback = "/index.html"
+	}
+	conf := fmt.Sprintf(`server {
+    listen %d;
+    root /usr/share/nginx/html;
+    location / {
+        try_files $uri $uri/ %s;
+    }
+}
+`, port, fallback)
+
+	s.Image = dev.StaticImage
+	s.Volumes = []string{e.projectPath(pod.Static.Dir) + ":/usr/share/nginx/html:ro"}
+	s.Configs = []ExportedConfigMount{e.config(pod.Name+"-nginx", "/etc/nginx/conf.d/default.conf", conf)}
+	if pod.Static.Build != "" {
+		e.note("Run %q before docker compose up so %s holds the site of pod %s", pod.Static.Build, pod.Static.Dir, pod.Name)
+	}
+	return s, nil
+}
+
+// migrations runs the migrations as a one-off service, before the pod they
+// belong to
+func (e *exporter) migrations(config *schema.NexlayerYAML, m schema.Migrations) error {
+	if m.Policy() == schema.MigrationsManual {
+		e.note("Migrations run manually; run them with docker compose run")
+	}
+	name := e.app.Name + "-migrate"
+	s := ExportedService{Command: escapeAll(m.Command), Restart: "no"}
+
+	var owner *schema.Pod
+	for i := range e.app.Pods {
+		if e.app.Pods[i].Name == m.Pod {
+			owner = &e.app.Pods[i]
+		}
+	}
+	// Migrations without an image of their own run in the image of their pod
+	var err error
+	if owner != nil && m.Image == "" {
+		s.Image, err = e.image(owner.Name, owner.Image, owner.Build)
+		if owner.Build != nil {
+			s.Build = e.build(*owner.Build)
+		}
+	} else {
+		s.Image, err = e.image(name, schema.MigrationImage(config), nil)
+	}
+	if err != nil {
+		return err
+	}
+	if owner != nil {
+		s.Entrypoint = escapeAll(owner.Entrypoint)
+		s.Environment = e.environment(owner.Vars)
+		s.DependsOn = e.dependencies(*owner)
+
+		if m.Policy() != schema.MigrationsManual {
+			service := e.result.Project.Services[owner.Name]
+			if service.DependsOn == nil {
+				service.DependsOn = make(map[string]ExportedDependency)
+			}
+			service.DependsOn[name] = ExportedDependency{Condition: "service_completed_successfully"}
+			e.result.Project.Services[owner.Name] = service
+		}
+	}
+	e.result.Project.Services[name] = s
+	return nil
+}
+
+// image resolves the registry of an image. Images built from source that
+// still need a registry are named after the application instead.
+func (e *exporter) image(name, image string, build *schema.ImageBuild) (string, error) {
+	if login := e.app.RegistryLogin; login != nil {
+		image = strings.ReplaceAll(image, schema.RegistryPlaceholder, login.Registry)
+	}
+	if !placeholderRegex.MatchString(image) {
+		return image, nil
+	}
+	if build != nil {
+		return e.app.Name + "-" + name + ":latest", nil
+	}
+	return "", fmt.Errorf("pod %s: image %q has an unresolved placeholder; set registryLogin.registry", name, image)
+}
+
+// build translates the build of an image
+func (e *exporter) build(b schema.ImageBuild) *ExportedBuild {
+	return &ExportedBuild{Context: e.projectPath(b.Context), Dockerfile: b.Dockerfile, Args: b.Args}
+}
+
+// environment translates the vars of a pod. Placeholders are read from the
+// environment of docker compose, and <% URL %> is the local address.
+func (e *exporter) environment(vars []schema.EnvVar) map[string]string {
+	if len(vars) == 0 {
+		return nil
+	}
+	env := make(map[string]string, len(vars))
+	for _, v := range vars {
+		value := escape(v.Value)
+		value = strings.ReplaceAll(value, schema.URLPlaceholder, e.url)
+		value = placeholderRegex.ReplaceAllStringFunc(value, func(m string) string {
+			name := placeholderRegex.FindStringSubmatch(m)[1]
+			e.env[name] = true
+			return "${" + name + "}"
+		})
+		env[v.Key] = value
+	}
+	return env
+}
+
+// dependencies lists the pods a pod depends on and those its vars reference.
+// Pods with a probe are waited for until healthy.
+func (e *exporter) dependencies(pod schema.Pod) map[string]ExportedDependency {
+	targets := pod.Dependencies()
+	for _, v := range pod.Vars {
+		for _, m := range e.hostRef.FindAllStringSubmatch(v.Value, -1) {
+			targets = append(targets, e.hosts[m[1]])
+		}
+	}
+
+	deps := make(map[string]ExportedDependency)
+	for _, target := range targets {
+		if target == pod.Name {
+			continue
+		}
+		condition := "service_started"
+		for _, p := range e.app.Pods {
+			switch {
+			case p.Name != target:
+			case p.IsJob() && !p.IsCronJob():
+				condition = "service_completed_successfully"
+			case e.healthcheckProbe(p) != nil:
+				condition = "service_healthy"
+			}
+		}
+		deps[target] = ExportedDependency{Condition: condition}
+	}
+	if len(deps) == 0 {
+		return nil
+	}
+	return deps
+}
+
+// job runs a job pod once instead of restarting it, labelled so that convert
+// turns it back into a job. Compose has no schedules, so cron jobs run once
+// on up as well.
+func (e *exporter) job(pod schema.Pod, s *ExportedService) {
+	s.Restart = "no"
+	labels := map[string]string{schema.TypeLabel: pod.Type}
+	if pod.IsCronJob() {
+		labels[schema.ScheduleLabel] = pod.Schedule
+		e.note("Cron job %s runs once on up; compose has no schedules, so run it from cron with 'docker compose run %s'", pod.Name, pod.Name)
+	}
+	for k, v := range s.Labels {
+		labels[k] = v
+	}
+	s.Labels = labels
+}
+
+// containers adds the init containers of a pod as one-off services run one
+// after the other before it, and its sidecars as services sharing its
+// network. Both are labelled so that convert keeps them with the pod.
+func (e *exporter) containers(pod schema.Pod) error {
+	service := e.result.Project.Services[pod.Name]
+	// The first init container waits for what the pod waits for
+	after := e.dependencies(pod)
+	for _, c := range pod.InitContainers {
+		name, s, err := e.container(pod, c)
+		if err != nil {
+			return err
+		}
+		s.Restart = "no"
+		s.DependsOn = after
+		e.result.Project.Services[name] = s
+		after = map[string]ExportedDependency{name: {Condition: "service_completed_successfully"}}
+	}
+	if len(pod.InitContainers) > 0 {
+		if service.DependsOn == nil {
+			service.DependsOn = make(map[string]ExportedDependency)
+		}
+		for name, dep := range after {
+			service.DependsOn[name] = dep
+		}
+		e.result.Project.Services[pod.Name] = service
+	}
+
+	for _, c := range pod.Sidecars {
+		name, s, err := e.container(pod, c)
+		if err != nil {
+			return err
+		}
+		s.NetworkMode = "service:" + pod.Name
+		e.result.Project.Services[name] = s
+	}
+	if len(pod.Sidecars) > 0 && pod.ReplicaCount() > 1 {
+		e.note("Pod %s runs %d replicas; compose attaches its sidecars to one of them", pod.Name, pod.ReplicaCount())
+	}
+	return nil
+}
+
+// container translates an init container or sidecar into a service named
+// <pod>-<container>
+func (e *exporter) container(pod schema.Pod, c schema.Container) (string, ExportedService, error) {
+	name := pod.Name + "-" + c.Name
+	if _, ok := e.result.Project.Services[name]; ok {
+		return "", ExportedService{}, fmt.Errorf("pod %s: container %s clashes with service %s", pod.Name, c.Name, name)
+	}
+	image, err := e.image(name, c.Image, nil)
+	if err != nil {
+		return "", ExportedService{}, err
+	}
+	return name, ExportedService{
+		Image:       image,
+		Entrypoint:  escapeAll(c.Entrypoint),
+		Command:     escapeAll(c.Command),
+		Environment: e.environment(c.Vars),
+		Labels:      map[string]string{schema.ContainerOfLabel: pod.Name},
+	}, nil
+}
+
+// healthcheckProbe is the probe exported as the healthcheck of a pod:
+// compose has one, which gates dependent services like a readiness probe
+func (e *exporter) healthcheckProbe(pod schema.Pod) *schema.HealthProbe {
+	if p := pod.ReadinessProbe(); p != nil {
+		return p
+	}
+	if pod.Probes != nil {
+		return pod.Probes.Liveness
+	}
+	return nil
+}
+
+// healthcheck converts the probe of a pod. HTTP and TCP probes run wget and
+// nc in the container, which the image must provide.
+func (e *exporter) healthcheck(pod schema.Pod) *ExportedHealthcheck {
+	p := e.healthcheckProbe(pod)
+	if p == nil {
+		return nil
+	}
+	hc := &ExportedHealthcheck{
+		Interval:    p.Interval,
+		Timeout:     p.Timeout,
+		Retries:     p.Retries,
+		StartPeriod: p.StartPeriod,
+	}
+	switch {
+	case p.HTTPGet != nil:
+		hc.Test = []string{"CMD-SHELL", fmt.Sprintf("wget -q -O /dev/null http://localhost:%d%s || exit 1", p.HTTPGet.Port, escape(p.HTTPGet.Path))}
+		e.note("Pod %s checks its health over HTTP; its image needs wget for the healthcheck", pod.Name)
+	case p.TCPSocket != nil:
+		hc.Test = []string{"CMD-SHELL", fmt.Sprintf("nc -z localhost %d || exit 1", p.TCPSocket.Port)}
+		e.note("Pod %s checks its health over TCP; its image needs nc for the healthcheck", pod.Name)
+	case p.Exec != nil:
+		hc.Test = append([]string{"CMD"}, escapeAll(p.Exec.Command)...)
+	default:
+		return nil
+	}
+	if pod.Probes != nil && pod.Probes.Liveness != nil && pod.Probes.Liveness != p {
+		e.note("Pod %s has a liveness probe; compose runs one healthcheck, so only its readiness probe is exported", pod.Name)
+	}
+	if pod.Probes != nil && pod.Probes.Startup != nil {
+		e.note("Pod %s has a startup probe; set the start_period of its healthcheck instead", pod.Name)
+	}
+	return hc
+}
+
+// aliases are the names a pod is reachable by besides its service name
+func (e *exporter) aliases(pod schema.Pod) []string {
+	var aliases []string
+	for _, h := range pod.HostNames() {
+		if h != pod.Name {
+			aliases = append(aliases, h)
+		}
+		aliases = append(aliases, h+".pod")
+	}
+	return aliases
+}
+
+// config registers a file with inline content and returns its mount
+func (e *exporter) config(name, target, content string) ExportedConfigMount {
+	if e.result.Project.Configs == nil {
+		e.result.Project.Configs = make(map[string]ExportedConfig)
+	}
+	name = strings.ReplaceAll(name, ".", "-")
+	e.result.Project.Configs[name] = ExportedConfig{Content: escape(content)}
+	return ExportedConfigMount{Source: name, Target: target}
+}
+
+// projectPath resolves a path of nexlayer.yaml against the compose file
+func (e *exporter) projectPath(p string) string {
+	if filepath.IsAbs(p) {
+		return filepath.ToSlash(p)
+	}
+	joined := path.Join(filepath.ToSlash(e.opts.ProjectDir), filepath.ToSlash(p))
+	if !strings.HasPrefix(joined, ".") && !strings.HasPrefix(joined, "/") {
+		joined = "./" + joined
+	}
+	return joined
+}
+
+// localURL is the address of the pod serving the application root
+func (e *exporter) localURL() string {
+	entry := e.app.Pods[0]
+	for _, pod := range e.app.Pods {
+		if pod.Path == "/" {
+			entry = pod
+			break
+		}
+	}
+	if len(entry.ServicePorts) == 0 {
+		if entry.IsStatic() {
+			return fmt.Sprintf("http://localhost:%d", schema.StaticPort)
+		}
+		return "http://localhost"
+	}
+	return fmt.Sprintf("http://localhost:%d", entry.ServicePorts[0].Port)
+}
+
+func (e *exporter) note(format string, args ...interface{}) {
+	e.result.Notes = append(e.result.Notes, fmt.Sprintf(format, args...))
+}
+
+// escape keeps docker compose from interpolating the dollar signs of a value
+func escape(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
+
+// escapeAll escapes every argument of a command
+func escapeAll(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	escaped := make([]string, len(args))
+	for i, a := range args {
+		escaped[i] = escape(a)
+	}
+	return escaped
+}
+
+// deploy returns the replicas of a pod and its resources as compose limits,
+// with its GPUs as reserved devices
+func deploy(pod schema.Pod) *ExportedDeploy {
+	var r ExportedResources
+	if res := pod.Resources; res != nil && (res.CPU != "" || res.Memory != "") {
+		r.Limits = &ExportedLimits{Memory: composeMemory(res.Memory)}
+		if cpu, err := schema.ParseCPU(res.CPU); err == nil {
+			r.Limits.CPUs = strconv.FormatFloat(cpu, 'f', -1, 64)
+		}
+	}
+	if count, _ := pod.GPURequest(); count > 0 {
+		r.Reservations = &ExportedReservations{Devices: []ExportedDevice{
+			{Driver: "nvidia", Count: count, Capabilities: []string{"gpu"}},
+		}}
+	}
+	var d ExportedDeploy
+	if r.Limits != nil || r.Reservations != nil {
+		d.Resources = &r
+	}
+	if n := pod.ReplicaCount(); n > 1 {
+		d.Replicas = n
+	}
+	if d.Resources == nil && d.Replicas == 0 {
+		return nil
+	}
+	return &d
+}
+
+// composeMemory writes a memory quantity such as 512Mi in the units of
+// compose, 512m
+func composeMemory(memory string) string {
+	for _, unit := range []string{"Ki", "Mi", "Gi", "Ti"} {
+		if strings.HasSuffix(memory, unit) {
+			return strings.TrimSuffix(memory, unit) + strings.ToLower(unit[:1])
+		}
+	}
+	return memory
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// appendedKeys are the service sequences later files add to instead of
+// replacing, as docker compose merges them
+var appendedKeys = map[string]bool{
+	"ports": true, "expose": true, "dns": true, "dns_search": true, "tmpfs": true,
+	"links": true, "external_links": true, "extra_hosts": true, "env_file": true,
+	"secrets": true, "configs": true, "cap_add": true, "cap_drop": true,
+}
+
+// mappedKeys are the service entries written either as a mapping or as a
+// list of "key=value" or names; later files override them key by key
+var mappedKeys = map[string]bool{
+	"environment": true, "labels": true, "depends_on": true, "networks": true,
+}
+
+// Files returns the compose files to merge for a compose file: the file, then
+// the overrides given or, when overrides is nil, its override file such as
+// docker-compose.override.yml if it exists
+func Files(file string, overrides []string) []string {
+	if overrides != nil {
+		return append([]string{file}, overrides...)
+	}
+	ext := filepath.Ext(file)
+	base := strings.TrimSuffix(file, ext)
+	for _, e := range []string{ext, ".yml", ".yaml"} {
+		override := base + ".override" + e
+		if _, err := os.Stat(override); err == nil {
+			return []string{file, override}
+		}
+	}
+	return []string{file}
+}
+
+// ActiveProfiles returns profiles, or those of COMPOSE_PROFILES when none are
+// given
+func ActiveProfiles(profiles []string) []string {
+	if len(profiles) > 0 {
+		return profiles
+	}
+	var active []string
+	for _, p := range strings.Split(os.Getenv("COMPOSE_PROFILES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// Load reads and merges compose files the way docker compose -f a -f b does,
+// then drops the services of profiles that are not active. Services without
+// profiles are always kept; "*" activates every profile.
+func Load(files []string, profiles []string) (DockerComposeConfig, error) {
+	if len(files) == 0 {
+		return DockerComposeConfig{}, fmt.Errorf("no Docker Compose file given")
+	}
+	merged := map[string]interface{}{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return DockerComposeConfig{}, fmt.Errorf("failed to read Docker Compose file: %w", err)
+		}
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return DockerComposeConfig{}, fmt.Errorf("failed to parse Docker Compose file %s: %w", file, err)
+		}
+		merged = mergeProject(merged, doc)
+	}
+
+	services, _ := merged["services"].(map[string]interface{})
+	dropped := selectProfiles(services, ActiveProfiles(profiles))
+	for _, service := range services {
+		removeDependencies(service, dropped)
+	}
+
+	// Round-trip through YAML to decode the merged document into the types
+	// the converter reads
+	content, err := yaml.Marshal(merged)
+	if err != nil {
+		return DockerComposeConfig{}, fmt.Errorf("failed to merge Docker Compose files: %w", err)
+	}
+	var config DockerComposeConfig
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return DockerComposeConfig{}, fmt.Errorf("failed to parse Docker Compose file: %w", err)
+	}
+	config.ConfigPath = files[0]
+	return config, nil
+}
+
+// mergeProject merges a compose file into the files before it: services are
+// merged one by one, other top-level mappings key by key
+func mergeProject(base, override map[string]interface{}) map[string]interface{} {
+	for key, value := range override {
+		if key != "services" {
+			base[key] = mergeValue(base[key], value)
+			continue
+		}
+		services, _ := base["services"].(map[string]interface{})
+		if services == nil {
+			services = map[string]interface{}{}
+		}
+		overrides, _ := value.(map[string]interface{})
+		for name, service := range overrides {
+			existing, _ := services[name].(map[string]interface{})
+			def, _ := service.(map[string]interface{})
+			if existing == nil {
+				services[name] = def
+				continue
+			}
+			services[name] = mergeService(existing, def)
+		}
+		base["services"] = services
+	}
+	return base
+}
+
+// mergeService merges the definition of a service in a later file
+func mergeService(base, override map[string]interface{}) map[string]interface{} {
+	for key, value := range override {
+		switch {
+		case appendedKeys[key]:
+			base[key] = appendUnique(base[key], value)
+		case mappedKeys[key]:
+			base[key] = mergeValue(toMapping(base[key]), toMapping(value))
+		case key == "volumes" || key == "devices":
+			base[key] = mergeByTarget(base[key], value)
+		case key == "build":
+			base[key] = mergeValue(toBuild(base[key]), toBuild(value))
+		default:
+			base[key] = mergeValue(base[key], value)
+		}
+	}
+	return base
+}
+
+// mergeValue merges mappings recursively; anything else is replaced
+func mergeValue(base, override interface{}) interface{} {
+	b, ok1 := base.(map[string]interface{})
+	o, ok2 := override.(map[string]interface{})
+	if !ok1 || !ok2 {
+		return override
+	}
+	for k, v := range o {
+		b[k] = mergeValue(b[k], v)
+	}
+	return b
+}
+
+// appendUnique appends the entries of a later file not already present
+func appendUnique(base, override interface{}) interface{} {
+	list, _ := base.([]interface{})
+	seen := make(map[string]bool, len(list))
+	for _, v := range list {
+		seen[fmt.Sprint(v)] = true
+	}
+	more, ok := override.([]interface{})
+	if !ok {
+		return override
+	}
+	for _, v := range more {
+		if !seen[fmt.Sprint(v)] {
+			seen[fmt.Sprint(v)] = true
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// toMapping turns the list form of environment, labels, depends_on and
+// networks into a mapping
+func toMapping(value interface{}) interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+	m := make(map[string]interface{}, len(list))
+	for _, entry := range list {
+		key, val, found := strings.Cut(fmt.Sprint(entry), "=")
+		if found {
+			m[key] = val
+		} else {
+			m[key] = nil
+		}
+	}
+	return m
+}
+
+// toBuild turns the short form of build, a context, into a mapping
+func toBuild(value interface{}) interface{} {
+	if context, ok := value.(string); ok {
+		return map[string]interface{}{"context": context}
+	}
+	return value
+}
+
+// mergeByTarget merges volume or device lists: an entry of a later file
+// replaces the one mounted at the same path
+func mergeByTarget(base, override interface{}) interface{} {
+	list, _ := base.([]interface{})
+	more, ok := override.([]interface{})
+	if !ok {
+		return override
+	}
+	for _, v := range more {
+		replaced := false
+		for i, existing := range list {
+			if mountTarget(existing) == mountTarget(v) {
+				list[i] = v
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// mountTarget returns the container path of a volume or device, in short
+// "source:target[:mode]" or long form
+func mountTarget(v interface{}) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		return fmt.Sprint(m["target"])
+	}
+	parts := strings.Split(fmt.Sprint(v), ":")
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return parts[1]
+}
+
+// selectProfiles removes the services none of whose profiles are active and
+// returns their names
+func selectProfiles(services map[string]interface{}, active []string) map[string]bool {
+	enabled := make(map[string]bool, len(active))
+	for _, p := range active {
+		enabled[p] = true
+	}
+	dropped := make(map[string]bool)
+	for name, def := range services {
+		service, _ := def.(map[string]interface{})
+		profiles, _ := service["profiles"].([]interface{})
+		if len(profiles) == 0 || enabled["*"] {
+			continue
+		}
+		keep := false
+		for _, p := range profiles {
+			keep = keep || enabled[fmt.Sprint(p)]
+		}
+		if !keep {
+			dropped[name] = true
+			delete(services, name)
+		}
+	}
+	return dropped
+}
+
+// removeDependencies drops the dependencies of a service on removed services
+func removeDependencies(def interface{}, removed map[string]bool) {
+	service, _ := def.(map[string]interface{})
+	if service == nil || len(removed) == 0 {
+		return
+	}
+	switch deps := service["depends_on"].(type) {
+	case []interface{}:
+		kept := deps[:0]
+		for _, dep := range deps {
+			if !removed[fmt.Sprint(dep)] {
+				kept = append(kept, dep)
+			}
+		}
+		service["depends_on"] = kept
+	case map[string]interface{}:
+		for dep := range deps {
+			if removed[dep] {
+				delete(deps, dep)
+			}
+		}
+	}
+}
@@ -118,6 +118,14 @@ func GetConfigDir() (string, error) {
 	return manager.GetConfigDir()
 }
 
+// GetConfigFile returns the path of the configuration file in use
+func GetConfigFile() (string, error) {
+	managerMu.RLock()
+	manager := defaultManager
+	managerMu.RUnlock()
+	return manager.GetConfigFile()
+}
+
 // GetConfigProvider returns the configuration provider
 func GetConfigProvider() Provider {
 	managerMu.RLock()
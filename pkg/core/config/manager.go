@@ -135,6 +135,21 @@ func (m *Manager) GetConfigDir() (string, error) {
 	return filepath.Dir(configFile), nil
 }
 
+// GetConfigFile returns the path of the configuration file in use
+func (m *Manager) GetConfigFile() (string, error) {
+	p, ok := m.provider.(*ViperProvider)
+	if !ok {
+		return "", fmt.Errorf("provider does not support config file path")
+	}
+
+	configFile := p.ConfigFileUsed()
+	if configFile == "" {
+		return "", fmt.Errorf("no config file used")
+	}
+
+	return configFile, nil
+}
+
 // GetAPIURL returns the API URL from the configuration
 func (m *Manager) GetAPIURL() string {
 	return m.provider.GetString("nexlayer.api_url")
@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package cost
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Annotations that override the resources assumed for a pod
+const (
+	AnnotationCPU      = "cost.nexlayer.io/cpu"
+	AnnotationMemory   = "cost.nexlayer.io/memory"
+	AnnotationReplicas = "cost.nexlayer.io/replicas"
+)
+
+// PodEstimate is the monthly cost of a single pod
+type PodEstimate struct {
+	Name      string    `json:"name"`
+	Resources Resources `json:"resources"`
+	Replicas  int       `json:"replicas"`
+	GPUs      int       `json:"gpus,omitempty"`
+	GPUType   string    `json:"gpuType,omitempty"`
+	StorageGB float64   `json:"storageGb"`
+	Compute   float64   `json:"compute"`
+	Storage   float64   `json:"storage"`
+	Monthly   float64   `json:"monthly"`
+}
+
+// Estimate is the monthly cost of a deployment
+type Estimate struct {
+	Currency string        `json:"currency"`
+	Pods     []PodEstimate `json:"pods"`
+	Regions  int           `json:"regions"` // every pod runs in each region
+	Total    float64       `json:"total"`
+}
+
+// EstimateConfig prices every pod in a configuration, in each of its regions
+func EstimateConfig(config *schema.NexlayerYAML, table *PricingTable) (*Estimate, error) {
+	est := &Estimate{Currency: table.Currency, Regions: schema.RegionCount(config)}
+	for _, pod := range config.Application.Pods {
+		pe, err := estimatePod(pod, table)
+		if err != nil {
+			return nil, err
+		}
+		est.Pods = append(est.Pods, pe)
+		est.Total += pe.Monthly
+	}
+	est.Total *= float64(est.Regions)
+	return est, nil
+}
+
+// estimatePod prices one pod from its annotations, its resources or its
+// type's profile. Autoscaled pods are priced at their minimum replicas.
+func estimatePod(pod schema.Pod, table *PricingTable) (PodEstimate, error) {
+	pe := PodEstimate{Name: pod.Name, Resources: profileFor(pod, table), Replicas: pod.ReplicaCount()}
+
+	if r := pod.Resources; r != nil && r.CPU != "" {
+		cpu, err := schema.ParseCPU(r.CPU)
+		if err != nil {
+			return pe, fmt.Errorf("pod %s: %w", pod.Name, err)
+		}
+		pe.Resources.CPU = cpu
+	}
+	if r := pod.Resources; r != nil && r.Memory != "" {
+		mem, err := schema.ParseMemory(r.Memory)
+		if err != nil {
+			return pe, fmt.Errorf("pod %s: %w", pod.Name, err)
+		}
+		pe.Resources.MemoryGB = mem
+	}
+	if v, ok := pod.Annotations[AnnotationCPU]; ok {
+		cpu, err := schema.ParseCPU(v)
+		if err != nil {
+			return pe, fmt.Errorf("pod %s: %w", pod.Name, err)
+		}
+		pe.Resources.CPU = cpu
+	}
+	if v, ok := pod.Annotations[AnnotationMemory]; ok {
+		mem, err := ParseSizeGB(v)
+		if err != nil {
+			return pe, fmt.Errorf("pod %s: %w", pod.Name, err)
+		}
+		pe.Resources.MemoryGB = mem
+	}
+	if v, ok := pod.Annotations[AnnotationReplicas]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return pe, fmt.Errorf("pod %s: invalid replicas %q", pod.Name, v)
+		}
+		pe.Replicas = n
+	}
+	if pod.Canary != nil {
+		// The canary runs next to the stable replicas
+		pe.Replicas++
+	}
+
+	for _, vol := range pod.Volumes {
+		if vol.Size == "" {
+			continue
+		}
+		size, err := ParseSizeGB(vol.Size)
+		if err != nil {
+			return pe, fmt.Errorf("pod %s volume %s: %w", pod.Name, vol.Name, err)
+		}
+		pe.StorageGB += size
+		if vol.Class == schema.VolumeClassSSD {
+			pe.Storage += size * table.SSDGBMonth
+		} else {
+			pe.Storage += size * table.StorageGBMonth
+		}
+	}
+
+	hourly := pe.Resources.CPU*table.CPUHour + pe.Resources.MemoryGB*table.MemoryGBHour
+	if pe.GPUs, pe.GPUType = pod.GPURequest(); pe.GPUs > 0 {
+		price, ok := table.GPUHour[pe.GPUType]
+		if !ok {
+			return pe, fmt.Errorf("pod %s: no price for gpu type %s", pod.Name, pe.GPUType)
+		}
+		hourly += float64(pe.GPUs) * price
+	}
+	pe.Compute = hourly * HoursPerMonth * float64(pe.Replicas)
+	pe.Monthly = pe.Compute + pe.Storage
+	return pe, nil
+}
+
+// profileFor picks the resource profile for a pod's type
+func profileFor(pod schema.Pod, table *PricingTable) Resources {
+	if r, ok := table.Profiles[pod.Profile()]; ok {
+		return r
+	}
+	return table.Profiles[schema.ProfileDefault]
+}
+
+// ParseSizeGB converts sizes such as "512Mi", "10Gi", "1Ti" or "5G" to GiB
+func ParseSizeGB(v string) (float64, error) {
+	units := []struct {
+		suffix string
+		factor float64
+	}{
+		{"Ki", 1.0 / (1024 * 1024)}, {"Mi", 1.0 / 1024}, {"Gi", 1}, {"Ti", 1024},
+		{"K", 1.0 / (1024 * 1024)}, {"M", 1.0 / 1024}, {"G", 1}, {"T", 1024},
+	}
+	v = strings.TrimSpace(v)
+	for _, u := range units {
+		if strings.HasSuffix(v, u.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(v, u.suffix), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid size %q", v)
+			}
+			return n * u.factor, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid size %q, expected a unit such as Mi or Gi", v)
+}
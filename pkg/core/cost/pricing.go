@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package cost estimates the monthly cost of a Nexlayer deployment.
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/config"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// HoursPerMonth is the billing month used for estimates
+const HoursPerMonth = 730
+
+// pricingFile is the name of the cached pricing table
+const pricingFile = "pricing.json"
+
+// Resources describes the compute a pod is expected to use
+type Resources struct {
+	CPU      float64 `json:"cpu"`      // vCPUs
+	MemoryGB float64 `json:"memoryGb"` // GiB
+}
+
+// PricingTable maps resources to prices
+type PricingTable struct {
+	Currency       string               `json:"currency"`
+	CPUHour        float64              `json:"cpuHour"`
+	MemoryGBHour   float64              `json:"memoryGbHour"`
+	StorageGBMonth float64              `json:"storageGbMonth"`
+	SSDGBMonth     float64              `json:"ssdGbMonth,omitempty"` // storage class ssd
+	GPUHour        map[string]float64   `json:"gpuHour,omitempty"`    // by GPU type
+	Profiles       map[string]Resources `json:"profiles"`
+	UpdatedAt      time.Time            `json:"updatedAt"`
+}
+
+// DefaultPricing returns the built-in pricing table used when no fresher table is cached
+func DefaultPricing() *PricingTable {
+	return &PricingTable{
+		Currency:       "USD",
+		CPUHour:        0.0316,
+		MemoryGBHour:   0.0042,
+		StorageGBMonth: 0.10,
+		SSDGBMonth:     0.17,
+		GPUHour: map[string]float64{
+			schema.GPUTypeT4:   0.53,
+			schema.GPUTypeL4:   0.81,
+			schema.GPUTypeA10G: 1.21,
+			schema.GPUTypeA100: 3.67,
+			schema.GPUTypeH100: 9.80,
+		},
+		Profiles: map[string]Resources{
+			"default":  {CPU: 0.25, MemoryGB: 0.5},
+			"frontend": {CPU: 0.25, MemoryGB: 0.5},
+			"backend":  {CPU: 0.5, MemoryGB: 1},
+			"database": {CPU: 0.5, MemoryGB: 1},
+			"cache":    {CPU: 0.25, MemoryGB: 0.5},
+			"queue":    {CPU: 0.5, MemoryGB: 1},
+			"llm":      {CPU: 2, MemoryGB: 8},
+		},
+	}
+}
+
+// LoadPricing returns the cached pricing table, or the built-in one when there is none
+func LoadPricing() *PricingTable {
+	path, err := cachePath()
+	if err != nil {
+		return DefaultPricing()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return DefaultPricing()
+	}
+	var table PricingTable
+	if err := json.Unmarshal(data, &table); err != nil || table.Currency == "" {
+		return DefaultPricing()
+	}
+	withDefaults(&table)
+	return &table
+}
+
+// withDefaults fills in the parts of a table older or partial pricing
+// endpoints leave out with those of the built-in table
+func withDefaults(table *PricingTable) {
+	defaults := DefaultPricing()
+	if table.Profiles == nil {
+		table.Profiles = defaults.Profiles
+	}
+	if table.GPUHour == nil {
+		table.GPUHour = defaults.GPUHour
+	}
+	if table.SSDGBMonth == 0 {
+		table.SSDGBMonth = defaults.SSDGBMonth
+	}
+}
+
+// RefreshPricing downloads a pricing table from url and caches it
+func RefreshPricing(ctx context.Context, url string) (*PricingTable, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := (&http.Client{Timeout: 15 * time.Second, Transport: &offline.Transport{}}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pricing: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("pricing endpoint error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var table PricingTable
+	if err := json.NewDecoder(resp.Body).Decode(&table); err != nil {
+		return nil, fmt.Errorf("failed to decode pricing: %w", err)
+	}
+	if table.Currency == "" {
+		return nil, fmt.Errorf("pricing endpoint returned an empty table")
+	}
+	withDefaults(&table)
+	if table.UpdatedAt.IsZero() {
+		table.UpdatedAt = time.Now().UTC()
+	}
+
+	path, err := cachePath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pricing: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to cache pricing: %w", err)
+	}
+	return &table, nil
+}
+
+// cachePath returns the location of the cached pricing table
+func cachePath() (string, error) {
+	dir, err := config.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, pricingFile), nil
+}
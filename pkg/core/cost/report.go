@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package cost
+
+import (
+	"fmt"
+	"math"
+
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Thresholds below which pods and volumes are flagged
+const (
+	IdleCPURatio   = 0.05 // average CPU use over requested CPU of an idle pod
+	OversizedRatio = 0.25 // used over provisioned size of an oversized volume
+	minFlaggedGB   = 2    // volumes smaller than this are never flagged
+)
+
+// PodSpend is what a running pod cost over the report period
+type PodSpend struct {
+	Name     string  `json:"name"`
+	Hours    float64 `json:"hours"`
+	AvgCPU   float64 `json:"avgCpu"`
+	CPU      float64 `json:"cpu"`
+	Requests int64   `json:"requests"`
+	Spend    float64 `json:"spend"`
+	Idle     bool    `json:"idle,omitempty"`
+}
+
+// VolumeSpend is what a volume cost over the report period
+type VolumeSpend struct {
+	Pod       string  `json:"pod"`
+	Name      string  `json:"name"`
+	SizeGB    float64 `json:"sizeGb"`
+	UsedGB    float64 `json:"usedGb"`
+	Spend     float64 `json:"spend"`
+	Oversized bool    `json:"oversized,omitempty"`
+}
+
+// Suggestion is a change expected to save MonthlySavings
+type Suggestion struct {
+	Target         string  `json:"target"`
+	Message        string  `json:"message"`
+	MonthlySavings float64 `json:"monthlySavings"`
+}
+
+// Report is the spend of a running application over a period
+type Report struct {
+	Namespace   string        `json:"namespace"`
+	Currency    string        `json:"currency"`
+	Hours       float64       `json:"hours"`
+	Pods        []PodSpend    `json:"pods"`
+	Volumes     []VolumeSpend `json:"volumes"`
+	Total       float64       `json:"total"`
+	Suggestions []Suggestion  `json:"suggestions"`
+}
+
+// BuildReport prices platform usage with a pricing table and flags idle pods
+// and oversized volumes
+func BuildReport(u apischema.Usage, table *PricingTable) (*Report, error) {
+	hours := u.To.Sub(u.From).Hours()
+	if hours <= 0 {
+		return nil, fmt.Errorf("usage of %s covers no time", u.Namespace)
+	}
+	r := &Report{Namespace: u.Namespace, Currency: table.Currency, Hours: hours, Suggestions: []Suggestion{}}
+
+	for _, p := range u.Pods {
+		hourly := p.CPU*table.CPUHour + p.MemoryGB*table.MemoryGBHour
+		if p.GPUs > 0 {
+			price, ok := table.GPUHour[p.GPUType]
+			if !ok {
+				return nil, fmt.Errorf("pod %s: no price for gpu type %s", p.Name, p.GPUType)
+			}
+			hourly += float64(p.GPUs) * price
+		}
+		ps := PodSpend{
+			Name:     p.Name,
+			Hours:    p.Hours,
+			AvgCPU:   p.AvgCPU,
+			CPU:      p.CPU,
+			Requests: p.Requests,
+			Spend:    p.Hours * hourly,
+		}
+		if p.CPU > 0 && p.Requests == 0 && p.AvgCPU/p.CPU < IdleCPURatio {
+			ps.Idle = true
+			// Replicas running on average over the period, kept for a month
+			monthly := hourly * p.Hours / hours * HoursPerMonth
+			r.Suggestions = append(r.Suggestions, Suggestion{
+				Target:         "pod " + p.Name,
+				Message:        fmt.Sprintf("served no requests and used %.0f%% of its CPU; remove it or scale it down", 100*p.AvgCPU/p.CPU),
+				MonthlySavings: monthly,
+			})
+		}
+		r.Pods = append(r.Pods, ps)
+		r.Total += ps.Spend
+	}
+
+	for _, v := range u.Volumes {
+		gbMonth := table.StorageGBMonth
+		if v.Class == schema.VolumeClassSSD && table.SSDGBMonth > 0 {
+			gbMonth = table.SSDGBMonth
+		}
+		vs := VolumeSpend{
+			Pod:    v.Pod,
+			Name:   v.Name,
+			SizeGB: v.SizeGB,
+			UsedGB: v.UsedGB,
+			Spend:  v.SizeGB * gbMonth * hours / HoursPerMonth,
+		}
+		if v.SizeGB >= minFlaggedGB && v.UsedGB/v.SizeGB < OversizedRatio {
+			vs.Oversized = true
+			// Leave room to double the data
+			size := math.Max(1, math.Ceil(2*v.UsedGB))
+			r.Suggestions = append(r.Suggestions, Suggestion{
+				Target:         fmt.Sprintf("volume %s/%s", v.Pod, v.Name),
+				Message:        fmt.Sprintf("uses %.1fGi of %.0fGi; set size: %.0fGi", v.UsedGB, v.SizeGB, size),
+				MonthlySavings: (v.SizeGB - size) * gbMonth,
+			})
+		}
+		r.Volumes = append(r.Volumes, vs)
+		r.Total += vs.Spend
+	}
+
+	return r, nil
+}
+
+// MonthlySavings sums the savings of all suggestions
+func (r *Report) MonthlySavings() float64 {
+	var total float64
+	for _, s := range r.Suggestions {
+		total += s.MonthlySavings
+	}
+	return total
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package deployment holds the steps of deploying a nexlayer.yaml: loading
+// and normalizing it, uploading static sites, preparing the file submitted to
+// the platform and waiting for the deployment to settle. Progress is written
+// to the io.Writer given, never to the terminal directly.
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/static"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+	"gopkg.in/yaml.v3"
+)
+
+// Deployment statuses reported by the platform
+const (
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusPending   = "pending"
+)
+
+// Load reads and parses a deployment file, expands the services shorthand
+// and normalizes it. changed reports whether the configuration now differs
+// from the file.
+func Load(file string) (config *schema.NexlayerYAML, changed bool, err error) {
+	return LoadEnv(file, "")
+}
+
+// LoadEnv loads a deployment file like Load, with the overlay of env, such
+// as nexlayer.prod.yaml, merged into it when env is set. The merged
+// configuration always counts as changed since it differs from the file.
+func LoadEnv(file, env string) (config *schema.NexlayerYAML, changed bool, err error) {
+	data, err := schema.ReadWithOverlay(file, env)
+	if err != nil {
+		return nil, false, err
+	}
+	config, changed, err = Parse(data)
+	return config, changed || env != "", err
+}
+
+// Parse parses the contents of a deployment file, migrates it from older
+// schema versions, expands the services shorthand and normalizes it. changed
+// reports whether the configuration now differs from data.
+func Parse(data []byte) (config *schema.NexlayerYAML, changed bool, err error) {
+	data, applied, err := schema.MigrateDocument(data)
+	if err != nil {
+		return nil, false, err
+	}
+	config = &schema.NexlayerYAML{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, false, fmt.Errorf("failed to parse deployment file: %w\nEnsure the file is valid YAML and follows the Nexlayer schema", err)
+	}
+	expanded, err := schema.ExpandServices(config)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid services: %w", err)
+	}
+	return config, Normalize(config) || expanded || len(applied) > 0, nil
+}
+
+// Normalize converts volume and secret paths to POSIX form, upper-cases port
+// protocols, gives static pods their default port and probes without a port
+// the first one of their pod. It reports whether anything changed.
+func Normalize(config *schema.NexlayerYAML) bool {
+	changed := false
+	set := func(field *string, value string) {
+		if *field != value {
+			*field = value
+			changed = true
+		}
+	}
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		set(&pod.Path, system.ContainerPath(pod.Path))
+		for j := range pod.Volumes {
+			set(&pod.Volumes[j].Path, system.ContainerPath(pod.Volumes[j].Path))
+		}
+		for j := range pod.Secrets {
+			set(&pod.Secrets[j].Path, system.ContainerPath(pod.Secrets[j].Path))
+		}
+		for j := range pod.ConfigFiles {
+			set(&pod.ConfigFiles[j].Path, system.ContainerPath(pod.ConfigFiles[j].Path))
+		}
+		for j := range pod.ServicePorts {
+			if sp := &pod.ServicePorts[j]; sp.Protocol != "" {
+				set(&sp.Protocol, schema.NormalizeProtocol(sp.Protocol))
+			}
+		}
+		if pod.IsStatic() && len(pod.ServicePorts) == 0 {
+			pod.ServicePorts = []schema.ServicePort{{Name: "http", Port: schema.StaticPort, TargetPort: schema.StaticPort}}
+			changed = true
+		}
+		if pod.SetDefaultProbePorts() {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// UploadStaticSites builds and uploads the assets of each static pod, then
+// points the pod at the image serving them. Paths are relative to baseDir;
+// build output and progress go to out, which may be nil.
+func UploadStaticSites(ctx context.Context, client api.APIClient, config *schema.NexlayerYAML, baseDir string, out io.Writer) error {
+	if out == nil {
+		out = io.Discard
+	}
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		if pod.Static == nil {
+			continue
+		}
+
+		if pod.Static.Build != "" {
+			fmt.Fprintf(out, "\n🔨 Building %s: %s\n", pod.Name, pod.Static.Build)
+			if err := static.Build(ctx, *pod.Static, baseDir, out, out); err != nil {
+				return fmt.Errorf("pod %s: %w", pod.Name, err)
+			}
+		}
+		pkg, err := static.Pack(*pod.Static, baseDir)
+		if err != nil {
+			return fmt.Errorf("pod %s: %w", pod.Name, err)
+		}
+
+		fmt.Fprintf(out, "📦 Uploading %s: %d files (%.1f KB)\n", pod.Name, pkg.Files, float64(len(pkg.Data))/1024)
+		resp, err := client.UploadStaticSite(ctx, config.Application.Name, pod.Name, pkg.Digest, pkg.Data)
+		if err != nil {
+			return fmt.Errorf("failed to upload static site of pod %s: %w", pod.Name, err)
+		}
+		if resp.Data.Image == "" {
+			return fmt.Errorf("upload of pod %s returned no image to serve it", pod.Name)
+		}
+
+		pod.Image = resp.Data.Image
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[schema.StaticAssetsAnnotation] = resp.Data.ID
+	}
+	return nil
+}
+
+// PrepareSubmitFile writes the configuration, with config file sources,
+// checksums, envFrom imports and static site images inlined, to a temporary
+// file when it differs from the deployment file. It returns the file to submit
+// and a cleanup function.
+func PrepareSubmitFile(config *schema.NexlayerYAML, file string, changed bool) (string, func(), error) {
+	if !changed && !schema.HasConfigFiles(config) && !schema.HasEnvFrom(config) && !schema.HasStaticSites(config) {
+		return file, func() {}, nil
+	}
+	if err := schema.ResolveConfigFiles(config, filepath.Dir(file)); err != nil {
+		return "", nil, err
+	}
+	if err := schema.ResolveEnvFrom(config, filepath.Dir(file)); err != nil {
+		return "", nil, err
+	}
+	return WriteTemp(config)
+}
+
+// WriteTemp writes a configuration to a temporary file and returns it with
+// a function removing it
+func WriteTemp(config *schema.NexlayerYAML) (string, func(), error) {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode deployment file: %w", err)
+	}
+	tmp, err := os.CreateTemp("", "nexlayer-deploy-*.yaml")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	cleanup := func() { os.Remove(tmp.Name()) }
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	return tmp.Name(), cleanup, nil
+}
+
+// IsStable reports whether a deployment has reached a state it will not
+// leave on its own. A running deployment whose pods are not all ready yet
+// is still settling unless some pod is failing.
+func IsStable(d apischema.Deployment) bool {
+	if len(FailingPods(d)) > 0 {
+		return true
+	}
+	switch strings.ToLower(d.Status) {
+	case StatusFailed, StatusCompleted:
+		return true
+	case StatusRunning:
+		return len(d.PodStatuses) == 0 || podsReady(d)
+	}
+	return podsReady(d)
+}
+
+// Succeeded reports whether a stable deployment is up: not failed, with all
+// pods ready and none failing
+func Succeeded(d apischema.Deployment) bool {
+	if len(FailingPods(d)) > 0 {
+		return false
+	}
+	switch strings.ToLower(d.Status) {
+	case StatusCompleted:
+		return true
+	case StatusFailed:
+		return false
+	case StatusRunning:
+		return len(d.PodStatuses) == 0 || podsReady(d)
+	}
+	return podsReady(d)
+}
+
+// podsReady reports whether the deployment has pods and all are ready
+func podsReady(d apischema.Deployment) bool {
+	for _, pod := range d.PodStatuses {
+		if !pod.Ready {
+			return false
+		}
+	}
+	return len(d.PodStatuses) > 0
+}
+
+// Wait polls a deployment until it is stable or ctx is done, starting at
+// interval and backing off to maxInterval. onPoll, when set, sees every
+// state observed. It returns the last state seen.
+func Wait(ctx context.Context, client api.APIClient, namespace string, interval, maxInterval time.Duration, onPoll func(apischema.Deployment)) (*apischema.Deployment, error) {
+	var last *apischema.Deployment
+	for {
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		info, err := client.GetDeploymentInfo(ctx, namespace)
+		if err != nil {
+			return last, fmt.Errorf("error checking status: %w", err)
+		}
+		last = &info.Data
+		if onPoll != nil {
+			onPoll(info.Data)
+		}
+		if IsStable(info.Data) {
+			return last, nil
+		}
+
+		interval = time.Duration(float64(interval) * 1.5)
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deployment
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+)
+
+// Event is a change seen between two statuses of a deployment
+type Event struct {
+	Time    time.Time `json:"time"`
+	Pod     string    `json:"pod,omitempty"`
+	Message string    `json:"message"`
+	Warning bool      `json:"warning"`
+}
+
+// Events returns what changed from prev to cur, as seen at now: the status of
+// the deployment, pods that appeared or went away, readiness, restarts and
+// waiting reasons. The platform does not report events itself, so they are
+// only as fine-grained as the statuses compared.
+func Events(prev, cur apischema.Deployment, now time.Time) []Event {
+	var events []Event
+	add := func(pod string, warning bool, format string, args ...interface{}) {
+		events = append(events, Event{Time: now, Pod: pod, Message: fmt.Sprintf(format, args...), Warning: warning})
+	}
+
+	if prev.Status != cur.Status && prev.Status != "" {
+		add("", strings.EqualFold(cur.Status, StatusFailed), "deployment %s -> %s", prev.Status, cur.Status)
+	}
+
+	before := make(map[string]apischema.PodStatus)
+	for _, pod := range prev.PodStatuses {
+		before[pod.Name] = pod
+	}
+	seen := make(map[string]bool)
+	for _, pod := range cur.PodStatuses {
+		seen[pod.Name] = true
+		old, ok := before[pod.Name]
+		switch {
+		case !ok:
+			add(pod.Name, false, "%s started (%s)", pod.Name, podState(pod))
+			continue
+		case pod.Image != old.Image:
+			add(pod.Name, false, "%s now runs %s", pod.Name, pod.Image)
+		}
+		if pod.Restarts > old.Restarts {
+			msg := fmt.Sprintf("%s restarted", pod.Name)
+			if n := pod.Restarts - old.Restarts; n > 1 {
+				msg += fmt.Sprintf(" %d times", n)
+			}
+			if pod.LastExit != nil {
+				msg += fmt.Sprintf(", exit code %d", pod.LastExit.ExitCode)
+				if pod.LastExit.Reason != "" {
+					msg += " (" + pod.LastExit.Reason + ")"
+				}
+			}
+			add(pod.Name, true, "%s", msg)
+		}
+		if pod.Waiting != old.Waiting && pod.Waiting != "" {
+			add(pod.Name, true, "%s is waiting: %s", pod.Name, pod.Waiting)
+		}
+		if pod.Ready != old.Ready {
+			if pod.Ready {
+				add(pod.Name, false, "%s is ready", pod.Name)
+			} else {
+				add(pod.Name, true, "%s is no longer ready", pod.Name)
+			}
+		} else if !strings.EqualFold(pod.Status, old.Status) && pod.Waiting == "" {
+			add(pod.Name, strings.EqualFold(pod.Status, StatusFailed), "%s %s -> %s", pod.Name, old.Status, pod.Status)
+		}
+	}
+
+	var gone []string
+	for name := range before {
+		if !seen[name] {
+			gone = append(gone, name)
+		}
+	}
+	sort.Strings(gone)
+	for _, name := range gone {
+		add(name, false, "%s went away", name)
+	}
+	return events
+}
+
+// podState is the status of a pod as shown to users: its waiting reason when
+// it has one
+func podState(pod apischema.PodStatus) string {
+	if pod.Waiting != "" {
+		return pod.Waiting
+	}
+	return pod.Status
+}
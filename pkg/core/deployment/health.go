@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deployment
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+)
+
+// RestartThreshold is how many restarts make a pod worth pointing out
+const RestartThreshold = 3
+
+// Waiting reasons of containers the platform reports
+const (
+	WaitingCrashLoop = "CrashLoopBackOff"
+	WaitingImagePull = "ImagePullBackOff"
+	WaitingErrImage  = "ErrImagePull"
+)
+
+// Insight is a problem spotted in the status of a pod, with a hint at what
+// to do about it
+type Insight struct {
+	Pod     string `json:"pod"`
+	Message string `json:"message"`
+	Hint    string `json:"hint"`
+}
+
+// FailingPods returns the pods that will not become ready without a change:
+// those that failed, cannot pull their image or keep crashing
+func FailingPods(d apischema.Deployment) []apischema.PodStatus {
+	var pods []apischema.PodStatus
+	for _, pod := range d.PodStatuses {
+		switch {
+		case strings.EqualFold(pod.Status, StatusFailed),
+			pod.Waiting == WaitingImagePull, pod.Waiting == WaitingErrImage,
+			pod.Waiting == WaitingCrashLoop && pod.Restarts >= RestartThreshold:
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// Diagnose looks for crash loops, out-of-memory kills, image pull failures
+// and frequent restarts in the pods of a deployment
+func Diagnose(d apischema.Deployment, now time.Time) []Insight {
+	var insights []Insight
+	logs := fmt.Sprintf("check its logs: nexlayer logs %s", d.Namespace)
+	for _, pod := range d.PodStatuses {
+		restarts := ""
+		if pod.Restarts > 0 {
+			restarts = fmt.Sprintf("restarted %d times", pod.Restarts)
+			if pod.Restarts == 1 {
+				restarts = "restarted once"
+			}
+			if !pod.CreatedAt.IsZero() && now.After(pod.CreatedAt) {
+				restarts += " in " + FormatSince(now.Sub(pod.CreatedAt))
+			}
+		}
+
+		switch {
+		case pod.Waiting == WaitingImagePull || pod.Waiting == WaitingErrImage:
+			insights = append(insights, Insight{
+				Pod:     pod.Name,
+				Message: fmt.Sprintf("%s cannot pull %s", pod.Name, pod.Image),
+				Hint:    "check the image name and tag, and the registryLogin credentials for private images",
+			})
+		case pod.LastExit.OOMKilled():
+			msg := fmt.Sprintf("%s ran out of memory and was killed", pod.Name)
+			if restarts != "" {
+				msg += "; " + restarts
+			}
+			insights = append(insights, Insight{
+				Pod:     pod.Name,
+				Message: msg,
+				Hint:    "look for a memory leak or reduce its memory use, e.g. worker counts or cache sizes; " + logs,
+			})
+		case pod.Waiting == WaitingCrashLoop:
+			msg := fmt.Sprintf("%s is crash-looping", pod.Name)
+			if restarts != "" {
+				msg += ": " + restarts
+			}
+			if pod.LastExit != nil {
+				msg += fmt.Sprintf(", last exit code %d", pod.LastExit.ExitCode)
+			}
+			insights = append(insights, Insight{Pod: pod.Name, Message: msg, Hint: exitHint(pod.LastExit, logs)})
+		case pod.Restarts >= RestartThreshold:
+			insights = append(insights, Insight{Pod: pod.Name, Message: pod.Name + " " + restarts, Hint: exitHint(pod.LastExit, logs)})
+		}
+	}
+	return insights
+}
+
+// exitHint explains the exit code of a container's last run
+func exitHint(t *apischema.Termination, logs string) string {
+	if t == nil {
+		return logs
+	}
+	switch t.ExitCode {
+	case 126, 127:
+		return "the command could not be run; check the entrypoint and command of the pod and that the image contains them"
+	case 137:
+		return "it was killed (SIGKILL), usually for running out of memory or failing its health checks; " + logs
+	case 139:
+		return "it crashed with a segmentation fault; " + logs
+	case 143:
+		return "it was stopped (SIGTERM) and may not shut down cleanly; " + logs
+	}
+	return logs
+}
+
+// FormatSince returns a short duration such as 45s, 10m, 3h or 2d
+func FormatSince(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deployment
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lastDeploymentFile records the most recent deployment started from the
+// current project, next to init's detection cache
+var lastDeploymentFile = filepath.Join(".nexlayer", "last-deployment.json")
+
+// Last is the most recent deployment started from a project
+type Last struct {
+	Namespace string    `json:"namespace"`
+	URL       string    `json:"url"`
+	File      string    `json:"file"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// LoadLast returns the last deployment started from the current directory,
+// or nil when there is none
+func LoadLast() (*Last, error) {
+	data, err := os.ReadFile(lastDeploymentFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read last deployment: %w", err)
+	}
+	var last Last
+	if err := json.Unmarshal(data, &last); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", lastDeploymentFile, err)
+	}
+	return &last, nil
+}
+
+// ResolveNamespace returns the namespace given in args, or that of the last
+// deployment started from the current directory
+func ResolveNamespace(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if last, err := LoadLast(); err == nil && last != nil && last.Namespace != "" {
+		return last.Namespace, nil
+	}
+	return "", fmt.Errorf("no namespace given and no previous deployment found in this directory")
+}
+
+// ResolveApp returns the application given in args, or the one named in file
+func ResolveApp(args []string, file string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	config, _, err := Load(file)
+	if err != nil {
+		return "", fmt.Errorf("no application given: %w", err)
+	}
+	if config.Application.Name == "" {
+		return "", fmt.Errorf("no application given and %s does not name one", file)
+	}
+	return config.Application.Name, nil
+}
+
+// SaveLast records a started deployment
+func SaveLast(last Last) error {
+	data, err := json.MarshalIndent(last, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(lastDeploymentFile), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(lastDeploymentFile, data, 0644)
+}
+
+// FindFile looks for a deployment file in the current directory
+func FindFile() (string, error) {
+	// List of possible deployment file names
+	possibleFiles := []string{
+		"deployment.yaml",
+		"deployment.yml",
+		"nexlayer.yaml",
+		"nexlayer.yml",
+	}
+
+	for _, file := range possibleFiles {
+		if _, err := os.Stat(file); err == nil {
+			return file, nil
+		}
+	}
+
+	return "", fmt.Errorf("no deployment file found in current directory\nExpected one of: %v\nCreate a deployment file or specify one with --file", possibleFiles)
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Reachable reports whether the application at url answers. Any response
+// counts except the 502, 503 and 504 the platform's gateway returns while no
+// pod serves the route.
+func Reachable(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second, Transport: &offline.Transport{}}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return nil
+}
+
+// WaitReachable polls url every interval until it is reachable or ctx is
+// done, and returns the last error seen when it never was
+func WaitReachable(ctx context.Context, url string, interval time.Duration) error {
+	for {
+		err := Reachable(ctx, url)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(interval):
+		}
+	}
+}
+
+// ServesURL reports whether any pod is routed at the application URL
+func ServesURL(config *schema.NexlayerYAML) bool {
+	for _, pod := range config.Application.Pods {
+		if pod.Path != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package dev runs the pods of a nexlayer.yaml locally with Docker.
+package dev
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/vars"
+)
+
+// Container is a pod translated to a docker run invocation
+type Container struct {
+	Pod   string
+	Name  string
+	Image string
+	Ports []string // host:container
+	Args  []string // arguments after "docker run"
+}
+
+// Plan is everything needed to run an application locally
+type Plan struct {
+	App        string
+	Network    string
+	URL        string
+	FilesDir   string
+	Files      map[string]string // host file -> content
+	Containers []Container
+}
+
+// Label marks containers and networks created by nexlayer dev
+const Label = "io.nexlayer.dev"
+
+// StaticImage serves static pods locally
+const StaticImage = "nginx:alpine"
+
+// NewPlan translates config into containers on a private network. Each pod is
+// reachable from the others as <pod>.pod and <alias>.pod, as in the cloud.
+// Service ports are published on localhost, volumes become named Docker
+// volumes and secrets and resolved config files are written under workDir and
+// mounted read-only. Static pods serve their build output with nginx.
+func NewPlan(config *schema.NexlayerYAML, workDir string) (*Plan, error) {
+	app := dockerName(config.Application.Name)
+	if app == "" {
+		return nil, fmt.Errorf("application.name is required")
+	}
+	if len(config.Application.Pods) == 0 {
+		return nil, fmt.Errorf("no pods to run")
+	}
+
+	plan := &Plan{
+		App:      app,
+		Network:  "nexlayer-" + app,
+		FilesDir: workDir,
+		Files:    make(map[string]string),
+	}
+
+	ctx := vars.NewVariableContext()
+	for _, pod := range config.Application.Pods {
+		for _, name := range pod.HostNames() {
+			ctx.AddPod(name)
+		}
+	}
+	if login := config.Application.RegistryLogin; login != nil {
+		ctx.SetRegistry(login.Registry)
+	}
+	plan.URL = localURL(config)
+	ctx.SetURL(plan.URL)
+
+	for _, pod := range config.Application.Pods {
+		c, err := plan.container(pod, ctx)
+		if err != nil {
+			return nil, err
+		}
+		plan.Containers = append(plan.Containers, c)
+	}
+	return plan, nil
+}
+
+func (p *Plan) container(pod schema.Pod, ctx *vars.VariableContext) (Container, error) {
+	if pod.Static != nil {
+		return p.staticContainer(pod)
+	}
+
+	image, err := vars.SubstituteVariables(pod.Image, ctx)
+	if err != nil {
+		return Container{}, err
+	}
+	if strings.Contains(image, "<%") {
+		return Container{}, fmt.Errorf("pod %s: image %q has an unresolved placeholder; set registryLogin.registry", pod.Name, pod.Image)
+	}
+
+	c := Container{Pod: pod.Name, Name: fmt.Sprintf("nexlayer-%s-%s", p.App, dockerName(pod.Name)), Image: image}
+	args := p.runArgs(&c, pod)
+
+	for _, v := range pod.Vars {
+		value, err := vars.SubstituteVariables(v.Value, ctx)
+		if err != nil {
+			return Container{}, fmt.Errorf("pod %s: var %s: %w", pod.Name, v.Key, err)
+		}
+		args = append(args, "-e", v.Key+"="+value)
+	}
+
+	for _, vol := range pod.Volumes {
+		mount := fmt.Sprintf("%s-%s-%s:%s", p.Network, dockerName(pod.Name), dockerName(vol.Name), vol.Path)
+		if vol.ReadOnly {
+			mount += ":ro"
+		}
+		args = append(args, "-v", mount)
+	}
+
+	for _, s := range pod.Secrets {
+		mount, err := p.file("secrets", pod.Name, s.Path, s.FileName, s.Data)
+		if err != nil {
+			return Container{}, err
+		}
+		args = append(args, "-v", mount)
+	}
+	for _, cf := range pod.ConfigFiles {
+		mount, err := p.file("config", pod.Name, cf.Path, cf.FileName, cf.Content)
+		if err != nil {
+			return Container{}, err
+		}
+		args = append(args, "-v", mount)
+	}
+
+	if n, _ := pod.GPURequest(); n > 0 {
+		args = append(args, "--gpus", fmt.Sprint(n))
+	}
+
+	var command []string
+	if len(pod.Entrypoint) > 0 {
+		args = append(args, "--entrypoint", pod.Entrypoint[0])
+		command = append(command, pod.Entrypoint[1:]...)
+	}
+	command = append(command, pod.Command...)
+
+	args = append(args, image)
+	c.Args = append(args, command...)
+	return c, nil
+}
+
+// runArgs returns the docker run arguments shared by all pods: name, network
+// and published ports
+func (p *Plan) runArgs(c *Container, pod schema.Pod) []string {
+	args := []string{
+		"-d", "--rm",
+		"--name", c.Name,
+		"--label", Label + "=" + p.App,
+		"--network", p.Network,
+	}
+	for _, name := range pod.HostNames() {
+		args = append(args, "--network-alias", name+".pod", "--network-alias", name)
+	}
+
+	for _, sp := range pod.ServicePorts {
+		mapping := fmt.Sprintf("%d:%d", sp.Port, sp.TargetPort)
+		if schema.NormalizeProtocol(sp.Protocol) == schema.ProtocolUDP {
+			mapping += "/udp"
+		}
+		c.Ports = append(c.Ports, mapping)
+		args = append(args, "-p", "127.0.0.1:"+mapping)
+	}
+	return args
+}
+
+// staticContainer serves a static pod's build output, which must be an
+// absolute path, with nginx in place of the platform
+func (p *Plan) staticContainer(pod schema.Pod) (Container, error) {
+	if len(pod.ServicePorts) == 0 {
+		pod.ServicePorts = []schema.ServicePort{{Name: "http", Port: schema.StaticPort, TargetPort: schema.StaticPort}}
+	}
+	c := Container{Pod: pod.Name, Name: fmt.Sprintf("nexlayer-%s-%s", p.App, dockerName(pod.Name)), Image: StaticImage}
+	args := p.runArgs(&c, pod)
+
+	fallback := "=404"
+	if pod.Static.SPA {
+		fallback = "/index.html"
+	}
+	conf := fmt.Sprintf(`server {
+    listen %d;
+    root /usr/share/nginx/html;
+    location / {
+        try_files $uri $uri/ %s;
+    }
+}
+`, pod.ServicePorts[0].TargetPort, fallback)
+	mount, err := p.file("config", pod.Name, "/etc/nginx/conf.d", "default.conf", conf)
+	if err != nil {
+		return Container{}, err
+	}
+	args = append(args, "-v", mount, "-v", pod.Static.Dir+":/usr/share/nginx/html:ro")
+
+	c.Args = append(args, StaticImage)
+	return c, nil
+}
+
+// file registers a generated file and returns its read-only bind mount
+func (p *Plan) file(kind, pod, dir, name, content string) (string, error) {
+	host := filepath.Join(p.FilesDir, kind, dockerName(pod), name)
+	p.Files[host] = content
+	abs, err := filepath.Abs(host)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s:ro", abs, path.Join(dir, name)), nil
+}
+
+// WriteFiles writes the secret and config files mounted into the containers
+func (p *Plan) WriteFiles() error {
+	for file, data := range p.Files {
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(file), err)
+		}
+		if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// localURL is the address of the pod serving the application root
+func localURL(config *schema.NexlayerYAML) string {
+	pods := config.Application.Pods
+	entry := pods[0]
+	for _, pod := range pods {
+		if pod.Path == "/" {
+			entry = pod
+			break
+		}
+	}
+	if len(entry.ServicePorts) == 0 {
+		if entry.Static != nil {
+			return fmt.Sprintf("http://localhost:%d", schema.StaticPort)
+		}
+		return "http://localhost"
+	}
+	return fmt.Sprintf("http://localhost:%d", entry.ServicePorts[0].Port)
+}
+
+// dockerName lowercases a name and replaces characters Docker rejects
+func dockerName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), "-_.")
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package dev
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Docker runs plans with the docker CLI
+type Docker struct {
+	path string
+}
+
+// NewDocker finds the docker CLI
+func NewDocker() (*Docker, error) {
+	path, err := exec.LookPath("docker")
+	if err != nil {
+		return nil, fmt.Errorf("docker is required for nexlayer dev; install it from https://docs.docker.com/get-docker/")
+	}
+	return &Docker{path: path}, nil
+}
+
+// Up creates the network and starts every container of the plan. Containers
+// left over from a previous run are removed first.
+func (d *Docker) Up(ctx context.Context, plan *Plan, out io.Writer) error {
+	if err := d.Down(ctx, plan.App); err != nil {
+		return err
+	}
+	if err := plan.WriteFiles(); err != nil {
+		return err
+	}
+	if _, err := d.run(ctx, "network", "create", "--label", Label+"="+plan.App, plan.Network); err != nil {
+		return err
+	}
+	for _, c := range plan.Containers {
+		fmt.Fprintf(out, "Starting %s (%s)\n", c.Pod, c.Image)
+		if _, err := d.run(ctx, append([]string{"run"}, c.Args...)...); err != nil {
+			return fmt.Errorf("failed to start pod %s: %w", c.Pod, err)
+		}
+	}
+	return nil
+}
+
+// Down stops the containers and removes the network of an application
+func (d *Docker) Down(ctx context.Context, app string) error {
+	ids, err := d.run(ctx, "ps", "-aq", "--filter", "label="+Label+"="+app)
+	if err != nil {
+		return err
+	}
+	if ids := strings.Fields(ids); len(ids) > 0 {
+		if _, err := d.run(ctx, append([]string{"rm", "-f"}, ids...)...); err != nil {
+			return err
+		}
+	}
+	networks, err := d.run(ctx, "network", "ls", "-q", "--filter", "label="+Label+"="+app)
+	if err != nil {
+		return err
+	}
+	if ids := strings.Fields(networks); len(ids) > 0 {
+		if _, err := d.run(ctx, append([]string{"network", "rm"}, ids...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Logs streams the output of every container, prefixed with the pod name,
+// until ctx is cancelled or all containers exit
+func (d *Docker) Logs(ctx context.Context, plan *Plan, out io.Writer) error {
+	width := 0
+	for _, c := range plan.Containers {
+		if len(c.Pod) > width {
+			width = len(c.Pod)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range plan.Containers {
+		cmd := exec.CommandContext(ctx, d.path, "logs", "-f", c.Name)
+		pr, pw := io.Pipe()
+		cmd.Stdout, cmd.Stderr = pw, pw
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to follow logs of %s: %w", c.Pod, err)
+		}
+		wg.Add(1)
+		go func(pod string) {
+			defer wg.Done()
+			scanner := bufio.NewScanner(pr)
+			for scanner.Scan() {
+				mu.Lock()
+				fmt.Fprintf(out, "%-*s | %s\n", width, pod, scanner.Text())
+				mu.Unlock()
+			}
+		}(c.Pod)
+		go func() {
+			cmd.Wait()
+			pw.Close()
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// run executes a docker command and returns its standard output
+func (d *Docker) run(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, d.path, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("docker %s: %s", args[0], msg)
+	}
+	return stdout.String(), nil
+}
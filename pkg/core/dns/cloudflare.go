@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package dns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// cloudflareAPI is the base URL of the Cloudflare API
+var cloudflareAPI = "https://api.cloudflare.com/client/v4"
+
+// cloudflare manages records through the Cloudflare API with an API token
+type cloudflare struct {
+	token string
+	zone  string
+}
+
+func (p *cloudflare) Name() string { return "Cloudflare" }
+
+// UpsertCNAME creates the record unproxied, so that the platform can
+// provision the domain's certificate
+func (p *cloudflare) UpsertCNAME(ctx context.Context, name, target string) error {
+	name, target = Host(name), Host(target)
+	zone := p.zone
+	if zone == "" {
+		var err error
+		if zone, err = p.findZone(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	var existing []struct {
+		ID string `json:"id"`
+	}
+	query := url.Values{"type": {"CNAME"}, "name": {name}}
+	if err := p.do(ctx, "GET", "/zones/"+zone+"/dns_records?"+query.Encode(), nil, &existing); err != nil {
+		return err
+	}
+
+	record := map[string]interface{}{
+		"type":    "CNAME",
+		"name":    name,
+		"content": target,
+		"ttl":     recordTTL,
+		"proxied": false,
+	}
+	if len(existing) > 0 {
+		return p.do(ctx, "PUT", "/zones/"+zone+"/dns_records/"+existing[0].ID, record, nil)
+	}
+	return p.do(ctx, "POST", "/zones/"+zone+"/dns_records", record, nil)
+}
+
+// findZone returns the ID of the zone of the account a domain belongs to
+func (p *cloudflare) findZone(ctx context.Context, domain string) (string, error) {
+	for _, name := range zoneCandidates(domain) {
+		var zones []struct {
+			ID string `json:"id"`
+		}
+		if err := p.do(ctx, "GET", "/zones?"+url.Values{"name": {name}}.Encode(), nil, &zones); err != nil {
+			return "", err
+		}
+		if len(zones) > 0 {
+			return zones[0].ID, nil
+		}
+	}
+	return "", fmt.Errorf("no Cloudflare zone for %s in the account of the API token", domain)
+}
+
+// do sends a request and decodes the result of the response envelope
+func (p *cloudflare) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, cloudflareAPI+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("cloudflare: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Success bool `json:"success"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("cloudflare: unexpected response (status %d)", resp.StatusCode)
+	}
+	if !envelope.Success {
+		var messages []string
+		for _, e := range envelope.Errors {
+			messages = append(messages, e.Message)
+		}
+		return fmt.Errorf("cloudflare: %s (status %d)", strings.Join(messages, "; "), resp.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(envelope.Result, result)
+}
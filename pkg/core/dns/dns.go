@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package dns looks up the records of custom domains, to tell whether a
+// domain points at its application yet while DNS changes propagate, and
+// creates them at DNS providers.
+package dns
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Resolver does the lookups of Check; *net.Resolver is one
+type Resolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// Record is what a domain resolves to
+type Record struct {
+	Domain    string   `json:"domain"`
+	CNAME     string   `json:"cname,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+	Target    string   `json:"target"`
+	PointsTo  bool     `json:"pointsToTarget"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// wildcardProbe is the subdomain looked up for a wildcard domain
+const wildcardProbe = "nexlayer-wildcard-check"
+
+// Check resolves domain and reports whether it points at target: by its
+// CNAME, or, for apex domains which cannot have one, by resolving to the
+// same addresses as target. A wildcard domain is checked through one of the
+// subdomains it covers.
+func Check(ctx context.Context, r Resolver, domain, target string) Record {
+	domain, target = Host(domain), Host(target)
+	rec := Record{Domain: domain, Target: target}
+
+	name := domain
+	if strings.HasPrefix(name, "*.") {
+		name = wildcardProbe + name[1:]
+	}
+	addrs, err := r.LookupHost(ctx, name)
+	if err != nil || len(addrs) == 0 {
+		rec.Error = "does not resolve"
+		return rec
+	}
+	sort.Strings(addrs)
+	rec.Addresses = addrs
+
+	if cname, err := r.LookupCNAME(ctx, name); err == nil {
+		if cname = Host(cname); cname != name {
+			rec.CNAME = cname
+		}
+	}
+	if target == "" {
+		return rec
+	}
+	if rec.CNAME == target {
+		rec.PointsTo = true
+		return rec
+	}
+
+	targetAddrs, err := r.LookupHost(ctx, target)
+	if err != nil {
+		return rec
+	}
+	rec.PointsTo = sameAddresses(addrs, targetAddrs)
+	return rec
+}
+
+// Host returns the lower-case host name of a domain or URL, without the
+// trailing dot of fully qualified names
+func Host(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "://") {
+		if u, err := url.Parse(s); err == nil {
+			s = u.Hostname()
+		}
+	}
+	return strings.ToLower(strings.TrimSuffix(s, "."))
+}
+
+// sameAddresses reports whether other holds the same addresses as sorted
+func sameAddresses(sorted, other []string) bool {
+	if len(sorted) != len(other) {
+		return false
+	}
+	other = append([]string(nil), other...)
+	sort.Strings(other)
+	for i := range sorted {
+		if sorted[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package dns
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// googleDNSAPI is the base URL of the Cloud DNS API
+var googleDNSAPI = "https://dns.googleapis.com/dns/v1"
+
+// googleScope allows changing Cloud DNS records
+const googleScope = "https://www.googleapis.com/auth/ndev.clouddns.readwrite"
+
+// serviceAccount is the key file of a Google service account
+type serviceAccount struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+	signingKey   *rsa.PrivateKey
+	tokenExpires time.Time
+	token        string
+}
+
+// loadServiceAccount reads a service account key file
+func loadServiceAccount(path string) (*serviceAccount, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Google credentials: %w", err)
+	}
+	var key serviceAccount
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse Google credentials %s: %w", path, err)
+	}
+	if key.Type != "service_account" || key.ClientEmail == "" || key.TokenURI == "" {
+		return nil, fmt.Errorf("%s is not the key file of a Google service account", path)
+	}
+
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("%s holds no private key", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if err != nil || !ok {
+		return nil, fmt.Errorf("%s holds no RSA private key", path)
+	}
+	key.signingKey = rsaKey
+	return &key, nil
+}
+
+// accessToken returns an OAuth access token, exchanging a signed JWT for one
+// when the previous one is about to expire
+func (k *serviceAccount) accessToken(ctx context.Context) (string, error) {
+	now := time.Now()
+	if k.token != "" && now.Add(time.Minute).Before(k.tokenExpires) {
+		return k.token, nil
+	}
+
+	assertion, err := k.assertion(now)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", k.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("google: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Error       string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil || resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		if token.Error != "" {
+			return "", fmt.Errorf("google: failed to authenticate %s: %s", k.ClientEmail, token.Error)
+		}
+		return "", fmt.Errorf("google: failed to authenticate %s (status %d)", k.ClientEmail, resp.StatusCode)
+	}
+	k.token = token.AccessToken
+	k.tokenExpires = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	return k.token, nil
+}
+
+// assertion returns the JWT, signed with RS256, that asks for an access
+// token to Cloud DNS for an hour from now
+func (k *serviceAccount) assertion(now time.Time) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   k.ClientEmail,
+		"scope": googleScope,
+		"aud":   k.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, k.signingKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the Google token request: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// google manages records through the Cloud DNS API with a service account
+type google struct {
+	key     *serviceAccount
+	project string
+	zone    string
+}
+
+func (p *google) Name() string { return "Google Cloud DNS" }
+
+// resourceRecordSet is a record set of Cloud DNS; names end with a dot
+type resourceRecordSet struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     int      `json:"ttl"`
+	RRDatas []string `json:"rrdatas"`
+}
+
+func (p *google) UpsertCNAME(ctx context.Context, name, target string) error {
+	name, target = Host(name), Host(target)
+	zone := p.zone
+	if zone == "" {
+		var zoneName string
+		var err error
+		if zone, zoneName, err = p.findZone(ctx, name); err != nil {
+			return err
+		}
+		if zoneName == name {
+			return fmt.Errorf("%s is the apex of its managed zone, which cannot have a CNAME record", name)
+		}
+	}
+	zonePath := "/projects/" + p.project + "/managedZones/" + zone
+
+	var existing struct {
+		RRSets []resourceRecordSet `json:"rrsets"`
+	}
+	query := url.Values{"name": {name + "."}, "type": {"CNAME"}}
+	if err := p.do(ctx, "GET", zonePath+"/rrsets?"+query.Encode(), nil, &existing); err != nil {
+		return err
+	}
+
+	change := struct {
+		Additions []resourceRecordSet `json:"additions"`
+		Deletions []resourceRecordSet `json:"deletions,omitempty"`
+	}{
+		Additions: []resourceRecordSet{{Name: name + ".", Type: "CNAME", TTL: recordTTL, RRDatas: []string{target + "."}}},
+		Deletions: existing.RRSets,
+	}
+	return p.do(ctx, "POST", zonePath+"/changes", change, nil)
+}
+
+// findZone returns the name and DNS name of the public managed zone of the
+// project a domain belongs to
+func (p *google) findZone(ctx context.Context, domain string) (string, string, error) {
+	for _, name := range zoneCandidates(domain) {
+		var resp struct {
+			ManagedZones []struct {
+				Name       string `json:"name"`
+				DNSName    string `json:"dnsName"`
+				Visibility string `json:"visibility"`
+			} `json:"managedZones"`
+		}
+		query := url.Values{"dnsName": {name + "."}}
+		if err := p.do(ctx, "GET", "/projects/"+p.project+"/managedZones?"+query.Encode(), nil, &resp); err != nil {
+			return "", "", err
+		}
+		for _, z := range resp.ManagedZones {
+			if Host(z.DNSName) == name && z.Visibility != "private" {
+				return z.Name, name, nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("no Cloud DNS managed zone for %s in project %s", domain, p.project)
+}
+
+// do sends an authorized request and decodes the JSON response
+func (p *google) do(ctx context.Context, method, path string, body, result interface{}) error {
+	token, err := p.key.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, googleDNSAPI+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("google: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var e struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&e) == nil && e.Error.Message != "" {
+			return fmt.Errorf("google: %s (status %d)", e.Error.Message, resp.StatusCode)
+		}
+		return fmt.Errorf("google: unexpected response (status %d)", resp.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
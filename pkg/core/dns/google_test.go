@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package dns
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeServiceAccount writes the key file of a service account whose token
+// endpoint is tokenURI, returning its path and private key
+func writeServiceAccount(t *testing.T, tokenURI string) (string, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "example",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email": "dns@example.iam.gserviceaccount.com",
+		"token_uri":    tokenURI,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path, key
+}
+
+// TestAssertion checks the JWT against RFC 7519 and the claims Google asks
+// of service accounts, and its RS256 signature against the public key
+func TestAssertion(t *testing.T) {
+	path, key := writeServiceAccount(t, "https://oauth2.googleapis.com/token")
+	account, err := loadServiceAccount(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	jwt, err := account.assertion(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d parts, want 3", len(parts))
+	}
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		t.Fatalf("header is not unpadded base64url: %v", err)
+	}
+	if string(header) != `{"alg":"RS256","typ":"JWT"}` {
+		t.Errorf("header = %s", header)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("claims are not unpadded base64url: %v", err)
+	}
+	var claims struct {
+		Iss   string `json:"iss"`
+		Scope string `json:"scope"`
+		Aud   string `json:"aud"`
+		Iat   int64  `json:"iat"`
+		Exp   int64  `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Iss != "dns@example.iam.gserviceaccount.com" || claims.Scope != googleScope ||
+		claims.Aud != "https://oauth2.googleapis.com/token" || claims.Iat != 1700000000 || claims.Exp != 1700003600 {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("signature is not unpadded base64url: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+}
+
+// TestAccessToken checks the JWT bearer grant sent to the token endpoint and
+// that the token is reused until it is about to expire
+func TestAccessToken(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			t.Errorf("grant_type = %q", got)
+		}
+		if strings.Count(r.PostForm.Get("assertion"), ".") != 2 {
+			t.Errorf("assertion = %q", r.PostForm.Get("assertion"))
+		}
+		w.Write([]byte(`{"access_token":"ya29.token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	path, _ := writeServiceAccount(t, server.URL)
+	account, err := loadServiceAccount(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		token, err := account.accessToken(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "ya29.token" {
+			t.Errorf("token = %q", token)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("token endpoint called %d times, want 1", requests)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// DNS providers whose records can be created by the CLI
+const (
+	ProviderCloudflare = "cloudflare"
+	ProviderRoute53    = "route53"
+	ProviderGoogle     = "google" // Google Cloud DNS
+)
+
+// recordTTL is the TTL of created records, in seconds
+const recordTTL = 300
+
+// httpClient sends the requests to the providers
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// ProviderConfig selects a DNS provider and its credentials, as kept in a
+// profile of ~/.nexlayer/config.yaml. Credentials left out are read from the
+// environment variables the provider's own tools use.
+type ProviderConfig struct {
+	Provider string `yaml:"provider" json:"provider"`
+
+	// Zone holding the records: a Cloudflare zone ID, a Route53 hosted zone
+	// ID or a Cloud DNS managed zone name. Found from the domain when empty.
+	Zone string `yaml:"zone,omitempty" json:"zone,omitempty"`
+
+	// Cloudflare API token with the DNS edit permission (CLOUDFLARE_API_TOKEN)
+	APIToken string `yaml:"apiToken,omitempty" json:"-"`
+
+	// AWS access key allowed to change Route53 records (AWS_ACCESS_KEY_ID,
+	// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN)
+	AccessKeyID     string `yaml:"accessKeyID,omitempty" json:"-"`
+	SecretAccessKey string `yaml:"secretAccessKey,omitempty" json:"-"`
+	SessionToken    string `yaml:"sessionToken,omitempty" json:"-"`
+
+	// Google service account key file (GOOGLE_APPLICATION_CREDENTIALS) and
+	// project, which defaults to the key's
+	CredentialsFile string `yaml:"credentialsFile,omitempty" json:"credentialsFile,omitempty"`
+	Project         string `yaml:"project,omitempty" json:"project,omitempty"`
+}
+
+// Provider creates DNS records at a DNS provider
+type Provider interface {
+	// Name returns the name of the provider, for messages
+	Name() string
+
+	// UpsertCNAME points name at target, replacing any CNAME record name has
+	UpsertCNAME(ctx context.Context, name, target string) error
+}
+
+// NewProvider returns the provider a configuration selects
+func NewProvider(c ProviderConfig) (Provider, error) {
+	switch strings.ToLower(c.Provider) {
+	case ProviderCloudflare:
+		token := firstNonEmpty(c.APIToken, os.Getenv("CLOUDFLARE_API_TOKEN"))
+		if token == "" {
+			return nil, fmt.Errorf("no Cloudflare API token; set dns.apiToken in the profile or CLOUDFLARE_API_TOKEN")
+		}
+		return &cloudflare{token: token, zone: c.Zone}, nil
+	case ProviderRoute53:
+		p := &route53{
+			accessKeyID:     firstNonEmpty(c.AccessKeyID, os.Getenv("AWS_ACCESS_KEY_ID")),
+			secretAccessKey: firstNonEmpty(c.SecretAccessKey, os.Getenv("AWS_SECRET_ACCESS_KEY")),
+			sessionToken:    firstNonEmpty(c.SessionToken, os.Getenv("AWS_SESSION_TOKEN")),
+			zone:            strings.TrimPrefix(c.Zone, "/hostedzone/"),
+		}
+		if p.accessKeyID == "" || p.secretAccessKey == "" {
+			return nil, fmt.Errorf("no AWS credentials; set dns.accessKeyID and dns.secretAccessKey in the profile or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+		return p, nil
+	case ProviderGoogle, "gcp":
+		file := firstNonEmpty(c.CredentialsFile, os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+		if file == "" {
+			return nil, fmt.Errorf("no Google credentials; set dns.credentialsFile in the profile or GOOGLE_APPLICATION_CREDENTIALS")
+		}
+		key, err := loadServiceAccount(file)
+		if err != nil {
+			return nil, err
+		}
+		project := firstNonEmpty(c.Project, key.ProjectID)
+		if project == "" {
+			return nil, fmt.Errorf("no Google Cloud project; set dns.project in the profile")
+		}
+		return &google{key: key, project: project, zone: c.Zone}, nil
+	case "":
+		return nil, fmt.Errorf("no DNS provider configured")
+	}
+	return nil, fmt.Errorf("unknown DNS provider %q; use %s, %s or %s", c.Provider, ProviderCloudflare, ProviderRoute53, ProviderGoogle)
+}
+
+// KnownProvider reports whether NewProvider supports a provider
+func KnownProvider(name string) bool {
+	switch strings.ToLower(name) {
+	case ProviderCloudflare, ProviderRoute53, ProviderGoogle, "gcp":
+		return true
+	}
+	return false
+}
+
+// zoneCandidates returns the names that may be the zone of a domain, from
+// the domain itself, or the parent of a wildcard domain, up to the
+// second-level domain
+func zoneCandidates(domain string) []string {
+	labels := strings.Split(strings.TrimPrefix(Host(domain), "*."), ".")
+	var names []string
+	for i := 0; i+2 <= len(labels); i++ {
+		names = append(names, strings.Join(labels[i:], "."))
+	}
+	return names
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package dns
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// route53API is the base URL of the Route53 API, a global service signed for
+// us-east-1
+var route53API = "https://route53.amazonaws.com/2013-04-01"
+
+// route53 manages records through the Route53 API with an AWS access key
+type route53 struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	zone            string
+}
+
+func (p *route53) Name() string { return "Route53" }
+
+// changeBatch is the body of a ChangeResourceRecordSets request
+type changeBatch struct {
+	XMLName xml.Name `xml:"https://route53.amazonaws.com/doc/2013-04-01/ ChangeResourceRecordSetsRequest"`
+	Comment string   `xml:"ChangeBatch>Comment"`
+	Changes []change `xml:"ChangeBatch>Changes>Change"`
+}
+
+// change changes a record set of a single value
+type change struct {
+	Action string `xml:"Action"`
+	Name   string `xml:"ResourceRecordSet>Name"`
+	Type   string `xml:"ResourceRecordSet>Type"`
+	TTL    int    `xml:"ResourceRecordSet>TTL"`
+	Value  string `xml:"ResourceRecordSet>ResourceRecords>ResourceRecord>Value"`
+}
+
+func (p *route53) UpsertCNAME(ctx context.Context, name, target string) error {
+	name, target = Host(name), Host(target)
+	zone := p.zone
+	if zone == "" {
+		var zoneName string
+		var err error
+		if zone, zoneName, err = p.findZone(ctx, name); err != nil {
+			return err
+		}
+		if zoneName == name {
+			return fmt.Errorf("%s is the apex of its hosted zone, which cannot have a CNAME record; create an alias record instead", name)
+		}
+	}
+
+	batch := changeBatch{
+		Comment: "nexlayer domain set",
+		Changes: []change{{Action: "UPSERT", Name: name, Type: "CNAME", TTL: recordTTL, Value: target}},
+	}
+	body, err := xml.Marshal(batch)
+	if err != nil {
+		return err
+	}
+	return p.do(ctx, "POST", "/hostedzone/"+zone+"/rrset", nil, append([]byte(xml.Header), body...), nil)
+}
+
+// findZone returns the ID and name of the public hosted zone a domain
+// belongs to
+func (p *route53) findZone(ctx context.Context, domain string) (string, string, error) {
+	for _, name := range zoneCandidates(domain) {
+		var resp struct {
+			Zones []struct {
+				ID      string `xml:"Id"`
+				Name    string `xml:"Name"`
+				Private bool   `xml:"Config>PrivateZone"`
+			} `xml:"HostedZones>HostedZone"`
+		}
+		query := url.Values{"dnsname": {name}, "maxitems": {"10"}}
+		if err := p.do(ctx, "GET", "/hostedzonesbyname", query, nil, &resp); err != nil {
+			return "", "", err
+		}
+		for _, z := range resp.Zones {
+			if Host(z.Name) == name && !z.Private {
+				return strings.TrimPrefix(z.ID, "/hostedzone/"), name, nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("no Route53 hosted zone for %s in the AWS account", domain)
+}
+
+// do sends a request signed with Signature Version 4 and decodes the XML
+// response
+func (p *route53) do(ctx context.Context, method, path string, query url.Values, body []byte, result interface{}) error {
+	u := route53API + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/xml")
+	}
+	p.sign(req, body, time.Now().UTC())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("route53: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("route53: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var e struct {
+			Code    string `xml:"Error>Code"`
+			Message string `xml:"Error>Message"`
+		}
+		if xml.Unmarshal(data, &e) == nil && e.Message != "" {
+			return fmt.Errorf("route53: %s: %s", e.Code, e.Message)
+		}
+		return fmt.Errorf("route53: unexpected response (status %d)", resp.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+	return xml.Unmarshal(data, result)
+}
+
+// sign adds the Signature Version 4 authorization of a request
+func (p *route53) sign(req *http.Request, body []byte, now time.Time) {
+	p.signFor(req, body, now, "us-east-1", "route53")
+}
+
+// signFor signs a request for a service in a region, signing its host and
+// date headers and the session token, if any
+func (p *route53) signFor(req *http.Request, body []byte, now time.Time, region, service string) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	canonicalHeaders := "host:" + req.URL.Host + "\nx-amz-date:" + amzDate + "\n"
+	signedHeaders := "host;x-amz-date"
+	if p.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.sessionToken)
+		canonicalHeaders += "x-amz-security-token:" + p.sessionToken + "\n"
+		signedHeaders += ";x-amz-security-token"
+	}
+
+	payload := sha256.Sum256(body)
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		// Values are encoded sorted by key; none of those sent hold spaces,
+		// which would need %20 rather than +
+		req.URL.Query().Encode(),
+		canonicalHeaders,
+		signedHeaders,
+		hex.EncodeToString(payload[:]),
+	}, "\n")
+	hashed := sha256.Sum256([]byte(canonical))
+	toSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+
+	key := hmacSHA256([]byte("AWS4"+p.secretAccessKey), date)
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, toSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plugin"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// checkConfig parses the CLI configuration file, when there is one
+func checkConfig(ctx context.Context, env *Environment) Result {
+	if env.CLIConfig == "" {
+		return Result{Status: StatusSkip, Message: "no CLI config file, using defaults"}
+	}
+	data, err := os.ReadFile(env.CLIConfig)
+	if err != nil {
+		return Result{Status: StatusFail, Message: fmt.Sprintf("cannot read %s: %v", env.CLIConfig, err),
+			Fix: "check the file permissions"}
+	}
+	var settings map[string]interface{}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return Result{Status: StatusFail, Message: fmt.Sprintf("%s is not valid YAML: %v", env.CLIConfig, err),
+			Fix: fmt.Sprintf("fix or remove %s", env.CLIConfig)}
+	}
+	return Result{Status: StatusPass, Message: fmt.Sprintf("%s is valid", env.CLIConfig)}
+}
+
+// checkProject validates the project's nexlayer.yaml
+func checkProject(ctx context.Context, env *Environment) Result {
+	config, err := loadProject(env.ConfigFile)
+	if os.IsNotExist(err) {
+		return Result{Status: StatusSkip, Message: fmt.Sprintf("no %s in the current directory", env.ConfigFile),
+			Fix: "run 'nexlayer init' to create one"}
+	}
+	if err != nil {
+		return Result{Status: StatusFail, Message: err.Error(), Fix: fmt.Sprintf("fix the syntax of %s", env.ConfigFile)}
+	}
+	var problems []string
+	for _, e := range schema.Validate(config) {
+		if e.Severity == schema.ValidationErrorSeverityError {
+			problems = append(problems, fmt.Sprintf("%s: %s", e.Field, e.Message))
+		}
+	}
+	if len(problems) > 0 {
+		return Result{Status: StatusFail, Message: strings.Join(problems, "; "),
+			Fix: fmt.Sprintf("correct the fields listed above in %s", env.ConfigFile)}
+	}
+	return Result{Status: StatusPass, Message: fmt.Sprintf("%s is valid", env.ConfigFile)}
+}
+
+// checkAPI makes sure the API host resolves and answers HTTPS requests
+func checkAPI(ctx context.Context, env *Environment) Result {
+	u, err := url.Parse(env.APIURL)
+	if err != nil || u.Host == "" {
+		return Result{Status: StatusFail, Message: fmt.Sprintf("invalid API URL %q", env.APIURL),
+			Fix: "set nexlayer.api_url in the CLI config to a valid URL"}
+	}
+	if offline.Enabled() {
+		return Result{Status: StatusSkip, Message: fmt.Sprintf("offline mode, %s not contacted", u.Host)}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, env.APIURL, nil)
+	if err != nil {
+		return Result{Status: StatusFail, Message: err.Error()}
+	}
+	resp, err := env.httpClient().Do(req)
+	if err != nil {
+		return Result{Status: StatusFail, Message: fmt.Sprintf("cannot reach %s: %v", u.Host, err),
+			Fix: "check your network connection, proxy settings (HTTPS_PROXY) and firewall"}
+	}
+	resp.Body.Close()
+	return Result{Status: StatusPass, Message: fmt.Sprintf("%s is reachable", u.Host)}
+}
+
+// checkToken verifies that the configured token is accepted by the API
+func checkToken(ctx context.Context, env *Environment) Result {
+	if env.Token == "" {
+		return Result{Status: StatusFail, Message: "no API token configured",
+			Fix: "run nexlayer login, or export NEXLAYER_TOKEN=<token>"}
+	}
+	if offline.Enabled() {
+		return Result{Status: StatusSkip, Message: "offline mode, token not verified"}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(env.APIURL, "/")+"/listDeployments", nil)
+	if err != nil {
+		return Result{Status: StatusFail, Message: err.Error()}
+	}
+	req.Header.Set("Authorization", "Bearer "+env.Token)
+	resp, err := env.httpClient().Do(req)
+	if err != nil {
+		return Result{Status: StatusSkip, Message: "could not verify the token because the API is unreachable"}
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return Result{Status: StatusFail, Message: "the API rejected the token",
+			Fix: "run nexlayer login again, or create a new token in the Nexlayer dashboard and update NEXLAYER_TOKEN"}
+	case resp.StatusCode >= 500:
+		return Result{Status: StatusWarn, Message: fmt.Sprintf("the API returned status %d", resp.StatusCode),
+			Fix: "retry later; the Nexlayer API may be degraded"}
+	}
+	return Result{Status: StatusPass, Message: "token accepted"}
+}
+
+// checkDocker makes sure the docker CLI is installed and its daemon is running
+func checkDocker(ctx context.Context, env *Environment) Result {
+	path, err := exec.LookPath("docker")
+	if err != nil {
+		return Result{Status: StatusWarn, Message: "docker is not installed",
+			Fix: "install Docker to build images locally: https://docs.docker.com/get-docker/"}
+	}
+	out, err := exec.CommandContext(ctx, path, "info", "--format", "{{.ServerVersion}}").Output()
+	if err != nil {
+		fix := "start the Docker daemon"
+		if runtime.GOOS != "linux" {
+			fix = "start Docker Desktop"
+		}
+		return Result{Status: StatusWarn, Message: "docker is installed but the daemon is not running", Fix: fix}
+	}
+	return Result{Status: StatusPass, Message: "docker " + strings.TrimSpace(string(out))}
+}
+
+// checkDNS resolves the custom domain configured in nexlayer.yaml
+func checkDNS(ctx context.Context, env *Environment) Result {
+	config, err := loadProject(env.ConfigFile)
+	if err != nil || config.Application.URL == "" {
+		return Result{Status: StatusSkip, Message: "no custom domain configured"}
+	}
+	if offline.Enabled() {
+		return Result{Status: StatusSkip, Message: "offline mode, DNS not checked"}
+	}
+	host := config.Application.URL
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	var r net.Resolver
+	addrs, err := r.LookupHost(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return Result{Status: StatusFail, Message: fmt.Sprintf("%s does not resolve", host),
+			Fix: fmt.Sprintf("add a CNAME record for %s pointing at your application URL (see 'nexlayer domain set')", host)}
+	}
+	if cname, err := r.LookupCNAME(ctx, host); err == nil && cname != "" && strings.TrimSuffix(cname, ".") != host {
+		return Result{Status: StatusPass, Message: fmt.Sprintf("%s -> %s", host, strings.TrimSuffix(cname, "."))}
+	}
+	return Result{Status: StatusPass, Message: fmt.Sprintf("%s resolves to %s", host, strings.Join(addrs, ", "))}
+}
+
+// checkPlugins makes sure every installed plugin is still the executable
+// that was installed, so that a modified plugin is reported before it is run
+func checkPlugins(ctx context.Context, env *Environment) Result {
+	plugins, err := plugin.List()
+	if err != nil {
+		return Result{Status: StatusFail, Message: err.Error()}
+	}
+	if len(plugins) == 0 {
+		return Result{Status: StatusSkip, Message: "no plugins installed"}
+	}
+	var broken []string
+	for i := range plugins {
+		p := &plugins[i]
+		if err := p.Check(); err != nil {
+			broken = append(broken, p.Name)
+			continue
+		}
+		info, err := os.Stat(p.Path)
+		if err != nil || (runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0) {
+			broken = append(broken, p.Name)
+		}
+	}
+	if len(broken) > 0 {
+		return Result{Status: StatusFail, Message: fmt.Sprintf("modified or not executable: %s", strings.Join(broken, ", ")),
+			Fix: fmt.Sprintf("reinstall them with 'nexlayer plugin update %s' or remove them with 'nexlayer plugin remove'", strings.Join(broken, " "))}
+	}
+	return Result{Status: StatusPass, Message: fmt.Sprintf("%d plugin(s) installed, checksums match", len(plugins))}
+}
+
+// loadProject reads and decodes a nexlayer.yaml
+func loadProject(path string) (*schema.NexlayerYAML, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config schema.NexlayerYAML
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s is not valid YAML: %w", path, err)
+	}
+	if _, err := schema.ExpandServices(&config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &config, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package doctor diagnoses problems with the local Nexlayer CLI environment.
+package doctor
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Status is the outcome of a single check
+type Status int
+
+const (
+	StatusPass Status = iota
+	StatusSkip
+	StatusWarn
+	StatusFail
+)
+
+var statusNames = []string{"pass", "skip", "warn", "fail"}
+
+// String returns the lowercase status name
+func (s Status) String() string {
+	if s < StatusPass || s > StatusFail {
+		return "unknown"
+	}
+	return statusNames[s]
+}
+
+// MarshalText encodes the status by name
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Result is the outcome of a check together with a suggested fix
+type Result struct {
+	Check   string `json:"check"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+}
+
+// Check is a single diagnostic
+type Check struct {
+	Name string
+	Run  func(ctx context.Context, env *Environment) Result
+}
+
+// Environment is what the checks inspect
+type Environment struct {
+	APIURL     string
+	Token      string
+	CLIConfig  string // CLI config file in use, if any
+	ConfigFile string // nexlayer.yaml of the current project
+	Timeout    time.Duration
+	// Transport reaches the API with the TLS settings of the CLI;
+	// http.DefaultTransport when nil
+	Transport http.RoundTripper
+}
+
+// httpClient returns the client the checks reach the API with
+func (env *Environment) httpClient() *http.Client {
+	return &http.Client{Transport: env.Transport}
+}
+
+// DefaultChecks returns every check in the order they are reported
+func DefaultChecks() []Check {
+	return []Check{
+		{Name: "config", Run: checkConfig},
+		{Name: "project", Run: checkProject},
+		{Name: "api", Run: checkAPI},
+		{Name: "token", Run: checkToken},
+		{Name: "docker", Run: checkDocker},
+		{Name: "dns", Run: checkDNS},
+		{Name: "plugins", Run: checkPlugins},
+	}
+}
+
+// Run executes checks in order. Each check gets env.Timeout to finish.
+func Run(ctx context.Context, env *Environment, checks []Check) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, c := range checks {
+		cctx, cancel := context.WithTimeout(ctx, env.Timeout)
+		r := c.Run(cctx, env)
+		cancel()
+		r.Check = c.Name
+		results = append(results, r)
+	}
+	return results
+}
+
+// Failed reports whether any result failed
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package drift finds out-of-band changes between a configuration file and
+// the live deployment created from it.
+package drift
+
+import (
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/compare"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/vars"
+)
+
+// Detect compares config with the live deployment. Left values come from the
+// file and right values from the deployment. Each pod in the file is expected
+// to run one replica; vars are only compared for pods whose live vars the API
+// reports and images are not compared for static pods.
+func Detect(config *schema.NexlayerYAML, live apischema.Deployment) []compare.Difference {
+	return compare.Deployments(Expected(config, live), live)
+}
+
+// Expected is the deployment config describes, shaped like live so the two can
+// be compared field by field
+func Expected(config *schema.NexlayerYAML, live apischema.Deployment) apischema.Deployment {
+	ctx := vars.NewVariableContext()
+	if login := config.Application.RegistryLogin; login != nil {
+		ctx.SetRegistry(login.Registry)
+	}
+
+	reportsVars := make(map[string]bool)
+	liveImages := make(map[string]string)
+	for _, status := range live.PodStatuses {
+		if len(status.Vars) > 0 {
+			reportsVars[status.Name] = true
+		}
+		liveImages[status.Name] = status.Image
+	}
+
+	expected := apischema.Deployment{
+		Namespace:    live.Namespace,
+		Version:      live.Version,
+		CustomDomain: config.Application.URL,
+	}
+	for _, pod := range config.Application.Pods {
+		image, err := vars.SubstituteVariables(pod.Image, ctx)
+		if err != nil {
+			image = pod.Image
+		}
+		if pod.Static != nil {
+			// The platform picks the image serving a static site
+			image = liveImages[pod.Name]
+		}
+		status := apischema.PodStatus{Name: pod.Name, Type: pod.Type, Image: image}
+		if reportsVars[pod.Name] {
+			for _, v := range pod.Vars {
+				status.Vars = append(status.Vars, apischema.EnvVar{Key: v.Key, Value: v.Value})
+			}
+		}
+		expected.PodStatuses = append(expected.PodStatuses, status)
+	}
+	return expected
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package forward forwards the connections to a local port to a port of a
+// deployed pod, each through a stream of its own, so that pods without a
+// public endpoint, such as databases, can be reached during development.
+package forward
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Event describes a forwarded connection when it closes
+type Event struct {
+	Client string // address of the local client
+	Bytes  int64
+	Err    error
+}
+
+// Forwarder listens on a local address and forwards each connection through a
+// stream opened by Dial
+type Forwarder struct {
+	Local string // host:port to listen on; port 0 picks a free one
+	Dial  func(ctx context.Context) (io.ReadWriteCloser, error)
+
+	// OnConn is called when a forwarded connection closes, if set
+	OnConn func(Event)
+
+	listener net.Listener
+	active   int64
+}
+
+// Listen binds the local address, failing early when it is taken
+func (f *Forwarder) Listen() error {
+	l, err := net.Listen("tcp", f.Local)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", f.Local, err)
+	}
+	f.listener = l
+	return nil
+}
+
+// Addr returns the address listened on
+func (f *Forwarder) Addr() string {
+	if f.listener == nil {
+		return f.Local
+	}
+	return f.listener.Addr().String()
+}
+
+// Active returns the number of connections being forwarded
+func (f *Forwarder) Active() int64 {
+	return atomic.LoadInt64(&f.active)
+}
+
+// Serve accepts and forwards connections until ctx is cancelled, listening
+// first if Listen was not called
+func (f *Forwarder) Serve(ctx context.Context) error {
+	if f.listener == nil {
+		if err := f.Listen(); err != nil {
+			return err
+		}
+	}
+	defer f.listener.Close()
+	go func() {
+		<-ctx.Done()
+		f.listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.forward(ctx, conn)
+		}()
+	}
+}
+
+// forward copies between a local connection and a stream to the pod until
+// either side closes
+func (f *Forwarder) forward(ctx context.Context, local net.Conn) {
+	atomic.AddInt64(&f.active, 1)
+	defer atomic.AddInt64(&f.active, -1)
+	defer local.Close()
+
+	ev := Event{Client: local.RemoteAddr().String()}
+	defer func() {
+		if f.OnConn != nil {
+			f.OnConn(ev)
+		}
+	}()
+
+	remote, err := f.Dial(ctx)
+	if err != nil {
+		ev.Err = err
+		return
+	}
+	defer remote.Close()
+
+	var n int64
+	done := make(chan struct{}, 2)
+	go func() {
+		c, _ := io.Copy(remote, local)
+		atomic.AddInt64(&n, c)
+		done <- struct{}{}
+	}()
+	go func() {
+		c, _ := io.Copy(local, remote)
+		atomic.AddInt64(&n, c)
+		done <- struct{}{}
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+	ev.Bytes = atomic.LoadInt64(&n)
+}
+
+// ParsePorts parses the ports of a forward, "5432" or "15432:5432": the
+// local port, the same as the pod's unless given, and the pod's
+func ParsePorts(spec string) (local, remote int, err error) {
+	localSpec, remoteSpec, found := strings.Cut(spec, ":")
+	if !found {
+		remoteSpec = localSpec
+	}
+	if remote, err = strconv.Atoi(remoteSpec); err != nil || remote < 1 || remote > 65535 {
+		return 0, 0, fmt.Errorf("invalid port %q; use e.g. 5432 or 15432:5432", spec)
+	}
+	if local, err = strconv.Atoi(localSpec); err != nil || local < 0 || local > 65535 {
+		return 0, 0, fmt.Errorf("invalid port %q; use e.g. 5432 or 15432:5432", spec)
+	}
+	return local, remote, nil
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package heroku converts Heroku projects, described by a Procfile and an
+// optional app.json, into a nexlayer.yaml. Process types become pods, the
+// release process becomes the migrations, config vars become vars and
+// add-ons such as heroku-postgresql and heroku-redis become services.
+package heroku
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Files that make a directory a Heroku project
+const (
+	ProcfileName = "Procfile"
+	AppJSONName  = "app.json"
+)
+
+// DefaultPort is the port processes are told to listen on through $PORT
+const DefaultPort = 8080
+
+// ReleaseProcess is the process type Heroku runs before each release
+const ReleaseProcess = "release"
+
+// addonKinds maps add-on services to the service kinds replacing them
+var addonKinds = map[string]string{
+	"heroku-postgresql": schema.PodTypePostgres,
+	"heroku-redis":      schema.PodTypeRedis,
+	"rediscloud":        schema.PodTypeRedis,
+	"redistogo":         schema.PodTypeRedis,
+	"mongolab":          schema.PodTypeMongoDB,
+	"ormongo":           schema.PodTypeMongoDB,
+	"jawsdb":            schema.PodTypeMySQL,
+	"jawsdb-maria":      schema.PodTypeMySQL,
+	"cleardb":           schema.PodTypeMySQL,
+}
+
+// invalidNameChars matches runs of characters pod names cannot contain
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// processRegex matches Procfile lines, "<process type>: <command>"
+var processRegex = regexp.MustCompile(`^([A-Za-z0-9_-]+):\s*(.+)$`)
+
+// Process is a process type of the Procfile
+type Process struct {
+	Name    string
+	Command string
+}
+
+// AppJSON is the part of an app.json the conversion uses
+type AppJSON struct {
+	Name      string                     `json:"name"`
+	Env       map[string]json.RawMessage `json:"env"`
+	Addons    []json.RawMessage          `json:"addons"`
+	Formation map[string]struct {
+		Quantity int `json:"quantity"`
+	} `json:"formation"`
+}
+
+// envSpec is the object form of an app.json config var
+type envSpec struct {
+	Value     string `json:"value"`
+	Required  *bool  `json:"required"`
+	Generator string `json:"generator"`
+}
+
+// Result is a converted project with notes on what needs attention
+type Result struct {
+	Config *schema.NexlayerYAML
+	Notes  []string
+}
+
+// Detect reports whether dir holds a Heroku project
+func Detect(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ProcfileName))
+	return err == nil
+}
+
+// ParseProcfile parses the process types of a Procfile in file order
+func ParseProcfile(data []byte) ([]Process, error) {
+	var procs []Process
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m := processRegex.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("%s line %d: expected <process type>: <command>", ProcfileName, n)
+		}
+		if seen[m[1]] {
+			return nil, fmt.Errorf("%s line %d: process type %s is defined twice", ProcfileName, n, m[1])
+		}
+		seen[m[1]] = true
+		procs = append(procs, Process{Name: m[1], Command: strings.TrimSpace(m[2])})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(procs) == 0 {
+		return nil, fmt.Errorf("%s defines no process types", ProcfileName)
+	}
+	return procs, nil
+}
+
+// Convert converts the Heroku project in dir. appName names the application
+// when set, else the app.json name or the directory is used.
+func Convert(dir, appName string) (*Result, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ProcfileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ProcfileName, err)
+	}
+	procs, err := ParseProcfile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var app AppJSON
+	if data, err := os.ReadFile(filepath.Join(dir, AppJSONName)); err == nil {
+		if err := json.Unmarshal(data, &app); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", AppJSONName, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", AppJSONName, err)
+	}
+
+	if appName == "" {
+		appName = app.Name
+	}
+	if appName == "" {
+		abs, _ := filepath.Abs(dir)
+		appName = filepath.Base(abs)
+	}
+
+	r := &Result{Config: &schema.NexlayerYAML{Application: schema.Application{Name: podName(appName)}}}
+	image := baseImage(dir)
+	r.note("Processes run in %s; build an image containing the application and set it on each pod", image)
+
+	vars, err := r.configVars(app.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	var release *Process
+	for i, p := range procs {
+		if p.Name == ReleaseProcess {
+			release = &procs[i]
+			continue
+		}
+		pod := schema.Pod{
+			Name:         podName(p.Name),
+			Image:        image,
+			Command:      schema.Command{"sh", "-c", p.Command},
+			Vars:         append([]schema.EnvVar(nil), vars...),
+			ServicePorts: []schema.ServicePort{{Name: "http", Port: DefaultPort, TargetPort: DefaultPort}},
+		}
+		if p.Name == "web" {
+			pod.Path = "/"
+		} else {
+			r.note("Process %s is not a web process; it still gets port %d, which it may ignore", p.Name, DefaultPort)
+		}
+		if f, ok := app.Formation[p.Name]; ok && f.Quantity > 1 {
+			r.note("Process %s runs %d dynos on Heroku; pods run one replica", p.Name, f.Quantity)
+		}
+		r.Config.Application.Pods = append(r.Config.Application.Pods, pod)
+	}
+	if len(r.Config.Application.Pods) == 0 {
+		return nil, fmt.Errorf("%s defines only a %s process; add a web or worker process", ProcfileName, ReleaseProcess)
+	}
+
+	if release != nil {
+		r.Config.Application.Migrations = &schema.Migrations{
+			Pod:     r.Config.Application.Pods[0].Name,
+			Command: schema.Command{"sh", "-c", release.Command},
+		}
+	}
+
+	if err := r.addons(app.Addons); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// configVars converts the config vars of an app.json. Secrets and required
+// vars without a value become placeholders filled in by the platform.
+func (r *Result) configVars(env map[string]json.RawMessage) ([]schema.EnvVar, error) {
+	vars := []schema.EnvVar{{Key: "PORT", Value: fmt.Sprint(DefaultPort)}}
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == "PORT" {
+			continue
+		}
+		var spec envSpec
+		var value string
+		if err := json.Unmarshal(env[key], &value); err == nil {
+			spec.Value = value
+		} else if err := json.Unmarshal(env[key], &spec); err != nil {
+			return nil, fmt.Errorf("%s: env.%s: expected a string or an object", AppJSONName, key)
+		}
+
+		switch {
+		case spec.Generator == "secret":
+			vars = append(vars, schema.EnvVar{Key: key, Value: placeholder(key)})
+		case spec.Value != "":
+			vars = append(vars, schema.EnvVar{Key: key, Value: spec.Value})
+		case spec.Required == nil || *spec.Required:
+			vars = append(vars, schema.EnvVar{Key: key, Value: placeholder(key)})
+			r.note("Config var %s has no value; set %s before deploying", key, placeholder(key))
+		}
+	}
+	return vars, nil
+}
+
+// addons converts add-ons into services
+func (r *Result) addons(addons []json.RawMessage) error {
+	for _, raw := range addons {
+		var plan, as string
+		if err := json.Unmarshal(raw, &plan); err != nil {
+			var obj struct {
+				Plan string `json:"plan"`
+				As   string `json:"as"`
+			}
+			if err := json.Unmarshal(raw, &obj); err != nil {
+				return fmt.Errorf("%s: addons: expected a plan or an object with a plan", AppJSONName)
+			}
+			plan, as = obj.Plan, obj.As
+		}
+		service, _, _ := strings.Cut(plan, ":")
+		kind, ok := addonKinds[service]
+		if !ok {
+			r.note("Add-on %s has no Nexlayer equivalent; set up a replacement and its config vars", plan)
+			continue
+		}
+		if _, dup := r.Config.Application.Services[kind]; dup {
+			r.note("Add-on %s is another %s; only one is created", plan, kind)
+			continue
+		}
+		if r.Config.Application.Services == nil {
+			r.Config.Application.Services = make(map[string]schema.BackingService)
+		}
+		r.Config.Application.Services[kind] = schema.BackingService{}
+		if as != "" {
+			r.note("Add-on %s is attached as %s; its connection variable is the one of the %s service", plan, as, kind)
+		}
+	}
+	return nil
+}
+
+// note records something that needs attention after the conversion
+func (r *Result) note(format string, args ...interface{}) {
+	r.Notes = append(r.Notes, fmt.Sprintf(format, args...))
+}
+
+// baseImage picks an image for the language of the project
+func baseImage(dir string) string {
+	for _, c := range []struct{ file, image string }{
+		{"package.json", "node:20-alpine"},
+		{"requirements.txt", "python:3.12-slim"},
+		{"Pipfile", "python:3.12-slim"},
+		{"pyproject.toml", "python:3.12-slim"},
+		{"Gemfile", "ruby:3.3-slim"},
+		{"go.mod", "golang:1.23-alpine"},
+		{"composer.json", "php:8.3-apache"},
+		{"pom.xml", "eclipse-temurin:21-jre"},
+	} {
+		if _, err := os.Stat(filepath.Join(dir, c.file)); err == nil {
+			return c.image
+		}
+	}
+	return "heroku/heroku:24"
+}
+
+// podName turns a Heroku name into a valid pod name
+func podName(name string) string {
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	return strings.Trim(name, "-")
+}
+
+// placeholder is the value the platform replaces with the secret key
+func placeholder(key string) string {
+	return "<% " + key + " %>"
+}
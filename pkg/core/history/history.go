@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package history records every configuration deployed from this machine as
+// a numbered revision under ~/.nexlayer/history/<app>, with how the
+// deployment went, so a previous revision can be deployed again with
+// nexlayer rollback.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+	"gopkg.in/yaml.v3"
+)
+
+// Outcomes of a revision
+const (
+	StatusStarted = "started" // submitted, outcome unknown
+	StatusHealthy = "healthy"
+	StatusFailed  = "failed"
+)
+
+// Revision is a configuration deployed once
+type Revision struct {
+	ID          int       `json:"id"`
+	Application string    `json:"application"`
+	Namespace   string    `json:"namespace"`
+	URL         string    `json:"url,omitempty"`
+	Source      string    `json:"source,omitempty"` // file deployed, or how the revision came about
+	RollbackOf  int       `json:"rollbackOf,omitempty"`
+	Status      string    `json:"status"`
+	DeployedAt  time.Time `json:"deployedAt"`
+	Config      string    `json:"config"` // nexlayer.yaml as submitted
+}
+
+// Parse returns the configuration of the revision
+func (r *Revision) Parse() (*schema.NexlayerYAML, error) {
+	var config schema.NexlayerYAML
+	if err := yaml.Unmarshal([]byte(r.Config), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration of revision %d: %w", r.ID, err)
+	}
+	return &config, nil
+}
+
+// Dir returns the directory holding the revisions of app
+func Dir(app string) (string, error) {
+	if app == "" || strings.ContainsAny(app, `/\`) || app == "." || app == ".." {
+		return "", fmt.Errorf("invalid application name %q", app)
+	}
+	home, err := system.HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".nexlayer", "history", app), nil
+}
+
+// Record saves r as the next revision of its application and sets its ID
+func Record(r *Revision) error {
+	all, err := List(r.Application)
+	if err != nil {
+		return err
+	}
+	r.ID = 1
+	if len(all) > 0 {
+		r.ID = all[0].ID + 1
+	}
+	if r.Status == "" {
+		r.Status = StatusStarted
+	}
+	if r.DeployedAt.IsZero() {
+		r.DeployedAt = time.Now().UTC()
+	}
+	return save(r)
+}
+
+// SetStatus records the outcome of a revision
+func SetStatus(r *Revision, status string) error {
+	r.Status = status
+	return save(r)
+}
+
+// save writes a revision. Configurations may hold secret values, so
+// revisions are only readable by the current user.
+func save(r *Revision) error {
+	dir, err := Dir(r.Application)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(dir, strconv.Itoa(r.ID)+".json")
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
+
+// Load reads a revision of app
+func Load(app string, id int) (*Revision, error) {
+	dir, err := Dir(app)
+	if err != nil {
+		return nil, err
+	}
+	file := filepath.Join(dir, strconv.Itoa(id)+".json")
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("revision %d of %s not found; run 'nexlayer history'", id, app)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var r Revision
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return &r, nil
+}
+
+// List returns the revisions of app, newest first
+func List(app string) ([]Revision, error) {
+	dir, err := Dir(app)
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var all []Revision
+	for _, file := range files {
+		id, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(file), ".json"))
+		if err != nil {
+			continue
+		}
+		r, err := Load(app, id)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, *r)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID > all[j].ID })
+	return all, nil
+}
+
+// Previous returns the newest healthy revision older than the newest one
+// that deployed a different configuration, the revision a rollback returns
+// to by default
+func Previous(app string) (*Revision, error) {
+	all, err := List(app)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		return nil, fmt.Errorf("no revisions of %s recorded; revisions are recorded by nexlayer deploy", app)
+	}
+	for _, r := range all[1:] {
+		if r.Status == StatusHealthy && r.Config != all[0].Config {
+			return &r, nil
+		}
+	}
+	return nil, fmt.Errorf("no healthy revision of %s before revision %d", app, all[0].ID)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package hooks runs the lifecycle hooks of an application: the steps of the
+// hooks block of nexlayer.yaml, shell commands and plugins, followed by the
+// installed plugins subscribed to the event.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/plugin"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Event is a point of the lifecycle of an application
+type Event struct {
+	Name   string               // one of schema.HookEvents
+	File   string               // deployment file
+	Config *schema.NexlayerYAML // nil before init wrote the file
+
+	// Set once the deployment started
+	Namespace string
+	URL       string
+
+	// Why the deployment failed, for on-failure
+	Error string
+
+	// API URL and token plugins call the API with; shell commands only get
+	// the URL, so a hook cannot read the token unless the user exported it
+	Environment plugin.Environment
+}
+
+// Run runs the hooks of an event, writing their output to out. Steps run in
+// order and the first failure stops them, unless the step continues on
+// error; the subscribed plugins run after the steps. Whether a failure aborts
+// the command is up to the caller; see schema.HookAborts.
+func Run(ctx context.Context, ev Event, out io.Writer) error {
+	var hooks *schema.Hooks
+	if ev.Config != nil {
+		hooks = ev.Config.Application.Hooks
+	}
+	steps := hooks.Steps(ev.Name)
+
+	named := make(map[string]bool)
+	for _, step := range steps {
+		if step.Plugin != "" {
+			named[step.Plugin] = true
+		}
+		if err := runStep(ctx, ev, step, out); err != nil {
+			err = fmt.Errorf("%s hook %s: %w", ev.Name, step.Label(), err)
+			if !step.ContinueOnError {
+				return err
+			}
+			fmt.Fprintf(out, "  %v (continuing)\n", err)
+		}
+	}
+
+	plugins, err := plugin.List()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i := range plugins {
+		p := &plugins[i]
+		if named[p.Name] || !p.Typed() || !p.HasHook(ev.Name) {
+			continue
+		}
+		if err := runPlugin(ctx, ev, p, schema.DefaultHookTimeout, out); err != nil {
+			err = fmt.Errorf("%s hook of %s: %w", ev.Name, p.Name, err)
+			if schema.HookAborts(ev.Name) {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runStep runs a step of the hooks block
+func runStep(ctx context.Context, ev Event, step schema.HookStep, out io.Writer) error {
+	timeout, err := step.TimeoutDuration()
+	if err != nil {
+		return fmt.Errorf("invalid timeout %q: %w", step.Timeout, err)
+	}
+	if step.Plugin != "" {
+		p, err := plugin.Get(step.Plugin)
+		if err != nil {
+			return err
+		}
+		if !p.Typed() {
+			return fmt.Errorf("plugin %s does not speak the typed protocol, so it cannot run as a hook", p.Name)
+		}
+		return runPlugin(ctx, ev, p, timeout, out)
+	}
+
+	fmt.Fprintf(out, "🪝 Running %s hook: %s\n", ev.Name, step.Label())
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, step.Run)
+	cmd.Dir = filepath.Dir(ev.File)
+	cmd.Env = append(os.Environ(), ev.env()...)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		return err
+	}
+	return nil
+}
+
+// runPlugin tells a typed plugin about the event
+func runPlugin(ctx context.Context, ev Event, p *plugin.Plugin, timeout time.Duration, out io.Writer) error {
+	fmt.Fprintf(out, "🔌 Running %s hook of %s\n", ev.Name, p.Name)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := p.Hook(ctx, &plugin.HookRequest{
+		Environment: ev.Environment,
+		Event:       ev.Name,
+		File:        ev.File,
+		Config:      ev.Config,
+		Namespace:   ev.Namespace,
+		URL:         ev.URL,
+		Error:       ev.Error,
+	}, func(progress plugin.Progress) {
+		fmt.Fprintf(out, "  %s\n", progress.Message)
+	})
+	if err != nil {
+		return err
+	}
+	if result.Message != "" {
+		fmt.Fprintf(out, "  %s\n", result.Message)
+	}
+	return nil
+}
+
+// env returns the variables describing the event to shell commands
+func (ev Event) env() []string {
+	env := []string{
+		"NEXLAYER_HOOK=" + ev.Name,
+		"NEXLAYER_FILE=" + ev.File,
+		"NEXLAYER_NAMESPACE=" + ev.Namespace,
+		"NEXLAYER_URL=" + ev.URL,
+		"NEXLAYER_ERROR=" + ev.Error,
+		"NEXLAYER_API_URL=" + ev.Environment.APIURL,
+	}
+	if ev.Config != nil {
+		env = append(env, "NEXLAYER_APP="+ev.Config.Application.Name)
+	}
+	return env
+}
@@ -0,0 +1,789 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// LabelGPU is the resource name of NVIDIA GPUs in container limits
+const LabelGPU = "nvidia.com/gpu"
+
+// Kinds of workloads converted into pods
+var workloadKinds = map[string]bool{
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+	"ReplicaSet":  true,
+	"Pod":         true,
+	"Job":         true,
+	"CronJob":     true,
+}
+
+// invalidNameChars matches what pod names cannot contain
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// envKeyRegex matches the keys envFrom imports; Kubernetes skips the others
+var envKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// The subset of the Kubernetes API the conversion reads
+
+type inObject struct {
+	Kind       string            `yaml:"kind"`
+	Metadata   meta              `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+	StringData map[string]string `yaml:"stringData"`
+	Spec       yaml.Node         `yaml:"spec"`
+}
+
+type inWorkload struct {
+	Replicas             *int          `yaml:"replicas"`
+	Template             inTemplate    `yaml:"template"`
+	VolumeClaimTemplates []inClaim     `yaml:"volumeClaimTemplates"`
+	Containers           []inContainer `yaml:"containers"` // kind Pod
+	InitContainers       []inContainer `yaml:"initContainers"`
+	Volumes              []inVolume    `yaml:"volumes"`
+	Schedule             string        `yaml:"schedule"` // kind CronJob
+	JobTemplate          struct {
+		Spec struct {
+			Template inTemplate `yaml:"template"`
+		} `yaml:"spec"`
+	} `yaml:"jobTemplate"`
+}
+
+type inTemplate struct {
+	Metadata meta      `yaml:"metadata"`
+	Spec     inPodSpec `yaml:"spec"`
+}
+
+type inPodSpec struct {
+	InitContainers []inContainer `yaml:"initContainers"`
+	Containers     []inContainer `yaml:"containers"`
+	Volumes        []inVolume    `yaml:"volumes"`
+}
+
+type inContainer struct {
+	Name         string          `yaml:"name"`
+	Image        string          `yaml:"image"`
+	Command      []string        `yaml:"command"`
+	Args         []string        `yaml:"args"`
+	Ports        []containerPort `yaml:"ports"`
+	Env          []inEnv         `yaml:"env"`
+	EnvFrom      []inEnvFrom     `yaml:"envFrom"`
+	VolumeMounts []volumeMount   `yaml:"volumeMounts"`
+	Resources    struct {
+		Requests map[string]string `yaml:"requests"`
+		Limits   map[string]string `yaml:"limits"`
+	} `yaml:"resources"`
+}
+
+// inAutoscaler is the spec of a HorizontalPodAutoscaler, in the autoscaling/v1
+// or v2 format
+type inAutoscaler struct {
+	ScaleTargetRef struct {
+		Name string `yaml:"name"`
+	} `yaml:"scaleTargetRef"`
+	MinReplicas                    *int     `yaml:"minReplicas"`
+	MaxReplicas                    int      `yaml:"maxReplicas"`
+	TargetCPUUtilizationPercentage int      `yaml:"targetCPUUtilizationPercentage"`
+	Metrics                        []metric `yaml:"metrics"`
+}
+
+// autoscale converts the autoscaler, scaling on CPU only
+func (a inAutoscaler) autoscale() *schema.Autoscale {
+	out := &schema.Autoscale{Min: 1, Max: a.MaxReplicas, TargetCPU: a.TargetCPUUtilizationPercentage}
+	if a.MinReplicas != nil {
+		out.Min = *a.MinReplicas
+	}
+	for _, m := range a.Metrics {
+		if m.Type == "Resource" && m.Resource.Name == "cpu" {
+			out.TargetCPU = m.Resource.Target.AverageUtilization
+		}
+	}
+	if out.TargetCPU == schema.DefaultTargetCPU {
+		out.TargetCPU = 0
+	}
+	return out
+}
+
+type inEnv struct {
+	Name      string `yaml:"name"`
+	Value     string `yaml:"value"`
+	ValueFrom *struct {
+		SecretKeyRef    *keyRef   `yaml:"secretKeyRef"`
+		ConfigMapKeyRef *keyRef   `yaml:"configMapKeyRef"`
+		FieldRef        *struct{} `yaml:"fieldRef"`
+	} `yaml:"valueFrom"`
+}
+
+type inEnvFrom struct {
+	Prefix       string   `yaml:"prefix"`
+	ConfigMapRef *nameRef `yaml:"configMapRef"`
+	SecretRef    *nameRef `yaml:"secretRef"`
+}
+
+type inVolume struct {
+	Name                  string        `yaml:"name"`
+	PersistentVolumeClaim *claimSource  `yaml:"persistentVolumeClaim"`
+	Secret                *secretSource `yaml:"secret"`
+	ConfigMap             *nameRef      `yaml:"configMap"`
+}
+
+type inClaim struct {
+	Metadata meta `yaml:"metadata"`
+	Spec     struct {
+		Resources claimResources `yaml:"resources"`
+	} `yaml:"spec"`
+}
+
+type inService struct {
+	name     string
+	Selector map[string]string `yaml:"selector"`
+	Ports    []struct {
+		Name       string `yaml:"name"`
+		Port       int    `yaml:"port"`
+		TargetPort string `yaml:"targetPort"`
+		Protocol   string `yaml:"protocol"`
+	} `yaml:"ports"`
+}
+
+// Converted is a nexlayer.yaml converted from Kubernetes manifests, with
+// notes on what did not translate and needs attention
+type Converted struct {
+	Config *schema.NexlayerYAML
+	Notes  []string
+}
+
+func (c *Converted) note(format string, args ...interface{}) {
+	c.Notes = append(c.Notes, fmt.Sprintf(format, args...))
+}
+
+// converter holds the objects the workloads refer to
+type converter struct {
+	*Converted
+	configMaps map[string]map[string]string
+	secrets    map[string]map[string]string
+	claims     map[string]string // claim -> requested size
+	services   []inService
+	ingresses  []ingressSpec
+	autoscale  map[string]*schema.Autoscale // workload -> its autoscaling
+}
+
+// workload is a workload with its pod template
+type workload struct {
+	kind, name string
+	replicas   int
+	schedule   string
+	labels     map[string]string
+	spec       inPodSpec
+	claims     map[string]string // volume claim template -> requested size
+}
+
+// ReadManifests reads a manifest file, or the .yaml and .yml files of a
+// directory in name order, as one multi-document stream
+func ReadManifests(p string) ([]byte, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return os.ReadFile(p)
+	}
+	entries, err := os.ReadDir(p)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(p, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+		buf.WriteString("\n")
+	}
+	if buf.Len() == 0 {
+		return nil, fmt.Errorf("no .yaml or .yml files in %s", p)
+	}
+	return buf.Bytes(), nil
+}
+
+// LookHelm returns the path of the helm executable charts are rendered with,
+// or an error telling how to install it
+func LookHelm() (string, error) {
+	helm, err := exec.LookPath("helm")
+	if err != nil {
+		return "", fmt.Errorf("helm is required to render charts and was not found in PATH; install it from https://helm.sh/docs/intro/install/")
+	}
+	return helm, nil
+}
+
+// Template renders a Helm chart into manifests with helm template, passing
+// each of values as a --values file and each of set as a --set value
+func Template(ctx context.Context, chart, release string, values, set []string) ([]byte, error) {
+	helm, err := LookHelm()
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"template", release, chart}
+	for _, v := range values {
+		args = append(args, "--values", v)
+	}
+	for _, v := range set {
+		args = append(args, "--set", v)
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, helm, args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("helm template failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// Convert converts Kubernetes manifests into a nexlayer.yaml. Each container
+// of a Deployment, StatefulSet, DaemonSet, Job, CronJob or Pod becomes a pod
+// with the ports of the Services selecting it, claims become volumes, mounted
+// ConfigMaps and Secrets become config files and secrets, and Ingress paths
+// route to pods. Values taken from Secrets become placeholders filled in by
+// the platform.
+func Convert(data []byte, appName string) (*Converted, error) {
+	c := &converter{
+		Converted:  &Converted{Config: &schema.NexlayerYAML{}},
+		configMaps: make(map[string]map[string]string),
+		secrets:    make(map[string]map[string]string),
+		claims:     make(map[string]string),
+		autoscale:  make(map[string]*schema.Autoscale),
+	}
+
+	var workloads []workload
+	ignored := make(map[string]bool)
+	namespace := ""
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var obj inObject
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse manifests: %w", err)
+		}
+		if obj.Kind == "" {
+			continue
+		}
+		if namespace == "" {
+			namespace = obj.Metadata.Namespace
+		}
+
+		switch {
+		case workloadKinds[obj.Kind]:
+			w, err := decodeWorkload(obj)
+			if err != nil {
+				return nil, err
+			}
+			workloads = append(workloads, w)
+		case obj.Kind == "Service":
+			var s inService
+			if err := obj.Spec.Decode(&s); err != nil {
+				return nil, fmt.Errorf("service %s: %w", obj.Metadata.Name, err)
+			}
+			s.name = obj.Metadata.Name
+			c.services = append(c.services, s)
+		case obj.Kind == "Ingress":
+			var s ingressSpec
+			if err := obj.Spec.Decode(&s); err != nil {
+				return nil, fmt.Errorf("ingress %s: %w", obj.Metadata.Name, err)
+			}
+			c.ingresses = append(c.ingresses, s)
+		case obj.Kind == "ConfigMap":
+			c.configMaps[obj.Metadata.Name] = obj.Data
+		case obj.Kind == "Secret":
+			values := make(map[string]string)
+			for k, v := range obj.Data {
+				decoded, err := base64.StdEncoding.DecodeString(v)
+				if err != nil {
+					return nil, fmt.Errorf("secret %s: key %s is not base64: %w", obj.Metadata.Name, k, err)
+				}
+				values[k] = string(decoded)
+			}
+			for k, v := range obj.StringData {
+				values[k] = v
+			}
+			c.secrets[obj.Metadata.Name] = values
+		case obj.Kind == "PersistentVolumeClaim":
+			var claim inClaim
+			if err := obj.Spec.Decode(&claim.Spec); err != nil {
+				return nil, fmt.Errorf("claim %s: %w", obj.Metadata.Name, err)
+			}
+			c.claims[obj.Metadata.Name] = claim.Spec.Resources.Requests["storage"]
+		case obj.Kind == "HorizontalPodAutoscaler":
+			var spec inAutoscaler
+			if err := obj.Spec.Decode(&spec); err != nil {
+				return nil, fmt.Errorf("autoscaler %s: %w", obj.Metadata.Name, err)
+			}
+			c.autoscale[spec.ScaleTargetRef.Name] = spec.autoscale()
+		default:
+			ignored[obj.Kind] = true
+		}
+	}
+	if len(workloads) == 0 {
+		return nil, fmt.Errorf("no Deployments, StatefulSets, DaemonSets, Jobs or Pods found")
+	}
+	if len(ignored) > 0 {
+		kinds := make([]string, 0, len(ignored))
+		for k := range ignored {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+		c.note("Ignored objects of kind %s; the platform manages their equivalents", strings.Join(kinds, ", "))
+	}
+
+	if appName == "" {
+		appName = namespace
+	}
+	c.Config.Application.Name = podName(appName)
+	for _, w := range workloads {
+		c.convertWorkload(w)
+	}
+	c.routeIngresses()
+	return c.Converted, nil
+}
+
+// decodeWorkload reads the pod template of a workload
+func decodeWorkload(obj inObject) (workload, error) {
+	var spec inWorkload
+	if err := obj.Spec.Decode(&spec); err != nil {
+		return workload{}, fmt.Errorf("%s %s: %w", strings.ToLower(obj.Kind), obj.Metadata.Name, err)
+	}
+	w := workload{kind: obj.Kind, name: obj.Metadata.Name, replicas: 1, claims: make(map[string]string)}
+	switch obj.Kind {
+	case "Pod":
+		w.labels = obj.Metadata.Labels
+		w.spec = inPodSpec{InitContainers: spec.InitContainers, Containers: spec.Containers, Volumes: spec.Volumes}
+	case "CronJob":
+		w.labels = spec.JobTemplate.Spec.Template.Metadata.Labels
+		w.spec = spec.JobTemplate.Spec.Template.Spec
+		w.schedule = spec.Schedule
+	default:
+		w.labels = spec.Template.Metadata.Labels
+		w.spec = spec.Template.Spec
+	}
+	if spec.Replicas != nil {
+		w.replicas = *spec.Replicas
+	}
+	for _, t := range spec.VolumeClaimTemplates {
+		w.claims[t.Metadata.Name] = t.Spec.Resources.Requests["storage"]
+	}
+	return w, nil
+}
+
+// convertWorkload adds a pod for each container of a workload; its init
+// containers, and further containers exposing no ports, are kept with the
+// pod of the first one as its init containers and sidecars
+func (c *converter) convertWorkload(w workload) {
+	services := c.selecting(w.labels)
+	first := len(c.Config.Application.Pods)
+
+	for i, ctr := range w.spec.Containers {
+		name := podName(w.name)
+		if i > 0 && len(c.servicePorts(w.spec.Containers, i, services)) == 0 {
+			pod := &c.Config.Application.Pods[first]
+			pod.Sidecars = append(pod.Sidecars, c.container(pod.Name, ctr))
+			continue
+		}
+		if i > 0 {
+			name = podName(w.name + "-" + ctr.Name)
+			c.note("Container %s of %s %s becomes pod %s; it no longer shares localhost with %s", ctr.Name, w.kind, w.name, name, podName(w.name))
+		}
+		pod := schema.Pod{
+			Name:       name,
+			Image:      ctr.Image,
+			Entrypoint: ctr.Command,
+			Command:    ctr.Args,
+		}
+		switch w.kind {
+		case "Job":
+			pod.Type = schema.PodTypeJob
+		case "CronJob":
+			pod.Type, pod.Schedule = schema.PodTypeCronJob, w.schedule
+		}
+		if i == 0 {
+			for _, s := range services {
+				if alias := podName(s.name); alias != name && !contains(pod.Aliases, alias) {
+					pod.Aliases = append(pod.Aliases, alias)
+				}
+			}
+		}
+		pod.ServicePorts = c.servicePorts(w.spec.Containers, i, services)
+		if len(pod.ServicePorts) == 0 && !pod.IsJob() {
+			c.note("Pod %s exposes no ports; add servicePorts", name)
+		}
+		pod.Vars = c.vars(name, ctr)
+		c.mounts(&pod, w, ctr)
+		if i == 0 {
+			for _, ic := range w.spec.InitContainers {
+				pod.InitContainers = append(pod.InitContainers, c.container(name, ic))
+			}
+		}
+		pod.Resources = podResources(ctr)
+		if a := c.autoscale[w.name]; a != nil {
+			pod.Autoscale = a
+		} else if w.replicas > 1 {
+			pod.Replicas = w.replicas
+		}
+		c.Config.Application.Pods = append(c.Config.Application.Pods, pod)
+	}
+}
+
+// container converts an init container or sidecar of pod; it keeps its
+// image, command and environment only
+func (c *converter) container(pod string, ctr inContainer) schema.Container {
+	if len(ctr.VolumeMounts) > 0 {
+		c.note("Volume mounts of container %s of pod %s are not converted", ctr.Name, pod)
+	}
+	return schema.Container{
+		Name:       podName(ctr.Name),
+		Image:      ctr.Image,
+		Entrypoint: ctr.Command,
+		Command:    ctr.Args,
+		Vars:       c.vars(pod, ctr),
+	}
+}
+
+// selecting returns the services whose selector matches labels
+func (c *converter) selecting(labels map[string]string) []inService {
+	var services []inService
+	for _, s := range c.services {
+		if len(s.Selector) == 0 {
+			continue
+		}
+		match := true
+		for k, v := range s.Selector {
+			if labels[k] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			services = append(services, s)
+		}
+	}
+	return services
+}
+
+// servicePorts returns the ports of the i-th container: the service ports
+// targeting it, or its container ports when no service selects the workload
+func (c *converter) servicePorts(containers []inContainer, i int, services []inService) []schema.ServicePort {
+	var ports []schema.ServicePort
+	seen := make(map[int]bool)
+	add := func(name string, port, target int, protocol string) {
+		if seen[port] {
+			return
+		}
+		seen[port] = true
+		if name == "" {
+			name = "port-" + strconv.Itoa(port)
+		}
+		p := schema.ServicePort{Name: name, Port: port, TargetPort: target}
+		if protocol != "" && protocol != schema.ProtocolTCP {
+			p.Protocol = protocol
+		}
+		ports = append(ports, p)
+	}
+
+	if len(services) == 0 {
+		for _, p := range containers[i].Ports {
+			add(p.Name, p.ContainerPort, p.ContainerPort, p.Protocol)
+		}
+		return ports
+	}
+	for _, s := range services {
+		for _, sp := range s.Ports {
+			owner, target := 0, sp.Port
+			if sp.TargetPort != "" {
+				if n, err := strconv.Atoi(sp.TargetPort); err == nil {
+					target = n
+				}
+			}
+		find:
+			for j, ctr := range containers {
+				for _, cp := range ctr.Ports {
+					if cp.Name == sp.TargetPort || cp.ContainerPort == target {
+						owner, target = j, cp.ContainerPort
+						break find
+					}
+				}
+			}
+			if owner == i {
+				add(sp.Name, sp.Port, target, sp.Protocol)
+			}
+		}
+	}
+	return ports
+}
+
+// vars converts the environment of a container
+func (c *converter) vars(pod string, ctr inContainer) []schema.EnvVar {
+	var vars []schema.EnvVar
+	for _, from := range ctr.EnvFrom {
+		switch {
+		case from.ConfigMapRef != nil:
+			data, ok := c.configMaps[from.ConfigMapRef.Name]
+			if !ok {
+				c.note("Pod %s imports ConfigMap %s, which is not in the manifests", pod, from.ConfigMapRef.Name)
+			}
+			for _, k := range sortedKeys(data) {
+				if envKeyRegex.MatchString(from.Prefix + k) {
+					vars = append(vars, schema.EnvVar{Key: from.Prefix + k, Value: data[k]})
+				}
+			}
+		case from.SecretRef != nil:
+			data, ok := c.secrets[from.SecretRef.Name]
+			if !ok {
+				c.note("Pod %s imports Secret %s, which is not in the manifests", pod, from.SecretRef.Name)
+			}
+			for _, k := range sortedKeys(data) {
+				if envKeyRegex.MatchString(from.Prefix + k) {
+					vars = append(vars, schema.EnvVar{Key: from.Prefix + k, Value: placeholder(from.Prefix + k)})
+				}
+			}
+		}
+	}
+
+	for _, env := range ctr.Env {
+		v := schema.EnvVar{Key: env.Name, Value: env.Value}
+		if ref := env.ValueFrom; ref != nil {
+			switch {
+			case ref.SecretKeyRef != nil:
+				v.Value = placeholder(env.Name)
+			case ref.ConfigMapKeyRef != nil:
+				value, ok := c.configMaps[ref.ConfigMapKeyRef.Name][ref.ConfigMapKeyRef.Key]
+				if !ok {
+					c.note("Pod %s: %s refers to key %s of ConfigMap %s, which is not in the manifests", pod, env.Name, ref.ConfigMapKeyRef.Key, ref.ConfigMapKeyRef.Name)
+					continue
+				}
+				v.Value = value
+			default:
+				c.note("Pod %s: %s is taken from the pod's fields, which pods do not expose; set it by hand", pod, env.Name)
+				continue
+			}
+		}
+		if v.Value == "" {
+			continue
+		}
+		vars = append(vars, v)
+	}
+
+	for _, v := range vars {
+		if strings.HasPrefix(v.Value, "<% ") {
+			c.note("Pod %s: %s came from a Secret; set it as a secret of the application", pod, v.Key)
+		}
+	}
+	return vars
+}
+
+// mounts converts the volume mounts of a container into volumes, config
+// files and secrets
+func (c *converter) mounts(pod *schema.Pod, w workload, ctr inContainer) {
+	volumes := make(map[string]inVolume, len(w.spec.Volumes))
+	for _, v := range w.spec.Volumes {
+		volumes[v.Name] = v
+	}
+
+	for _, m := range ctr.VolumeMounts {
+		if size, ok := w.claims[m.Name]; ok {
+			pod.Volumes = append(pod.Volumes, schema.Volume{Name: volumeName(m.Name), Path: m.MountPath, Size: size, ReadOnly: m.ReadOnly})
+			continue
+		}
+		v, ok := volumes[m.Name]
+		switch {
+		case !ok:
+			c.note("Pod %s mounts volume %s, which is not declared", pod.Name, m.Name)
+		case v.PersistentVolumeClaim != nil:
+			claim := v.PersistentVolumeClaim.ClaimName
+			pod.Volumes = append(pod.Volumes, schema.Volume{Name: volumeName(claim), Path: m.MountPath, Size: c.claims[claim], ReadOnly: m.ReadOnly})
+		case v.ConfigMap != nil:
+			data, ok := c.configMaps[v.ConfigMap.Name]
+			if !ok {
+				c.note("Pod %s mounts ConfigMap %s, which is not in the manifests", pod.Name, v.ConfigMap.Name)
+				continue
+			}
+			for _, f := range mountedFiles(m, data) {
+				pod.ConfigFiles = append(pod.ConfigFiles, schema.ConfigFile{
+					Name:     podName(v.ConfigMap.Name + "-" + f.name),
+					Path:     f.dir,
+					FileName: f.name,
+					Content:  f.content,
+				})
+			}
+		case v.Secret != nil:
+			data, ok := c.secrets[v.Secret.SecretName]
+			if !ok {
+				c.note("Pod %s mounts Secret %s, which is not in the manifests", pod.Name, v.Secret.SecretName)
+				continue
+			}
+			for _, f := range mountedFiles(m, data) {
+				pod.Secrets = append(pod.Secrets, schema.Secret{
+					Name:     volumeName(v.Secret.SecretName + f.name),
+					Path:     f.dir,
+					FileName: f.name,
+					Data:     f.content,
+				})
+			}
+			c.note("Pod %s: files of Secret %s are written into nexlayer.yaml; keep it out of version control or move them to envFrom", pod.Name, v.Secret.SecretName)
+		default:
+			c.note("Pod %s: volume %s is neither a claim, a ConfigMap nor a Secret and is not converted", pod.Name, m.Name)
+		}
+	}
+}
+
+// mountedFile is a key of a ConfigMap or Secret mounted as a file
+type mountedFile struct {
+	dir, name, content string
+}
+
+// mountedFiles returns the files a mount places: the key named by its
+// subPath at the mount path, or every key inside the mount path
+func mountedFiles(m volumeMount, data map[string]string) []mountedFile {
+	if m.SubPath != "" {
+		return []mountedFile{{dir: path.Dir(m.MountPath), name: path.Base(m.MountPath), content: data[m.SubPath]}}
+	}
+	files := make([]mountedFile, 0, len(data))
+	for _, k := range sortedKeys(data) {
+		files = append(files, mountedFile{dir: m.MountPath, name: k, content: data[k]})
+	}
+	return files
+}
+
+// routeIngresses sets the path of the pods Ingress rules route to
+func (c *converter) routeIngresses() {
+	hosts := make(map[string]bool)
+	for _, ing := range c.ingresses {
+		for _, rule := range ing.Rules {
+			if rule.Host != "" {
+				hosts[rule.Host] = true
+			}
+			for _, p := range rule.HTTP.Paths {
+				pod := c.podOf(p.Backend.Service.Name)
+				if pod == nil {
+					continue
+				}
+				if pod.Path != "" && pod.Path != p.Path {
+					c.note("Pod %s is routed at %s and %s; it keeps %s", pod.Name, pod.Path, p.Path, pod.Path)
+					continue
+				}
+				pod.Path = p.Path
+				if pod.Path == "" {
+					pod.Path = "/"
+				}
+			}
+		}
+	}
+	if len(hosts) > 0 {
+		names := sortedKeys(hosts)
+		c.note("Ingress hosts %s are not converted; add a custom domain with nexlayer domain", strings.Join(names, ", "))
+	}
+}
+
+// podOf returns the pod a service name refers to
+func (c *converter) podOf(service string) *schema.Pod {
+	name := podName(service)
+	pods := c.Config.Application.Pods
+	for i := range pods {
+		if pods[i].Name == name || contains(pods[i].Aliases, name) {
+			return &pods[i]
+		}
+	}
+	return nil
+}
+
+// podName turns a Kubernetes name into a pod name
+func podName(name string) string {
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	return strings.Trim(name, "-")
+}
+
+// volumeName turns a Kubernetes name into a volume name, which must be
+// alphanumeric
+func volumeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// placeholder is the value the platform replaces with the secret key
+func placeholder(key string) string {
+	return "<% " + key + " %>"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// podResources reads the CPU, memory and GPUs of a container. Nexlayer pods
+// are guaranteed and limited to the same amount, so limits are preferred over
+// requests.
+func podResources(ctr inContainer) *schema.Resources {
+	quantity := func(name string) string {
+		if v := ctr.Resources.Limits[name]; v != "" {
+			return v
+		}
+		return ctr.Resources.Requests[name]
+	}
+	r := &schema.Resources{CPU: quantity("cpu"), Memory: quantity("memory")}
+	if memory, ok := schema.NormalizeMemory(r.Memory); ok {
+		r.Memory = memory
+	}
+	if gpu, ok := ctr.Resources.Limits[LabelGPU]; ok {
+		if n, err := strconv.Atoi(gpu); err == nil && n > 0 {
+			r.GPU = &schema.GPU{Count: n}
+		}
+	}
+	if r.CPU == "" && r.Memory == "" && r.GPU == nil {
+		return nil
+	}
+	return r
+}
@@ -0,0 +1,609 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package k8s renders an application as standard Kubernetes manifests:
+// a Deployment and Services per pod, PersistentVolumeClaims for volumes,
+// Secrets and ConfigMaps for mounted files, an Ingress for forward-facing
+// pods, Jobs and CronJobs for job pods and a Job for migrations. The
+// manifests can also be packaged as a Helm chart whose values hold the
+// images and secrets. Convert goes the other way, turning manifests, or a
+// chart rendered by helm template, into a nexlayer.yaml.
+package k8s
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Labels set on every object
+const (
+	LabelName   = "app.kubernetes.io/name"
+	LabelPartOf = "app.kubernetes.io/part-of"
+)
+
+// DefaultVolumeSize is the size of claims for volumes without size
+const DefaultVolumeSize = "1Gi"
+
+// placeholderRegex matches values the platform fills in, such as <% DB_PASSWORD %>
+var placeholderRegex = regexp.MustCompile(`<%\s*([A-Za-z0-9_]+)\s*%>`)
+
+// Options tune the rendering
+type Options struct {
+	Namespace    string // set on every object when not empty
+	StorageClass string // storage class of every claim
+	IngressClass string // class of the ingress
+	Helm         bool   // take images and secrets from chart values
+}
+
+// Manifest is one rendered object
+type Manifest struct {
+	Kind string
+	Name string
+	obj  object
+}
+
+// FileName names the file the manifest is written to
+func (m Manifest) FileName() string {
+	return strings.ToLower(m.Kind) + "-" + m.Name + ".yaml"
+}
+
+// YAML encodes the manifest
+func (m Manifest) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(m.obj); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Result holds the manifests of an application
+type Result struct {
+	App       string
+	Manifests []Manifest
+	Images    map[string]string // pod -> image
+	Secrets   []string          // keys of the application secret, sorted
+	Notes     []string          // what did not translate and needs attention
+}
+
+// SecretName names the secret holding the values the platform fills in
+func (r *Result) SecretName() string {
+	return r.App + "-secrets"
+}
+
+// renderer carries the state of one rendering
+type renderer struct {
+	opts    Options
+	app     schema.Application
+	result  *Result
+	secrets map[string]bool
+	hosts   *regexp.Regexp // <pod>.pod references
+	baseURL string
+}
+
+// Render renders the pods of a configuration. Config files and envFrom must
+// have been resolved, and services expanded.
+func Render(config *schema.NexlayerYAML, opts Options) (*Result, error) {
+	app := config.Application
+	if app.Name == "" {
+		return nil, fmt.Errorf("application name is required")
+	}
+	r := &renderer{
+		opts:    opts,
+		app:     app,
+		result:  &Result{App: app.Name, Images: make(map[string]string)},
+		secrets: make(map[string]bool),
+	}
+
+	var hosts []string
+	for _, pod := range app.Pods {
+		for _, h := range pod.HostNames() {
+			hosts = append(hosts, regexp.QuoteMeta(h))
+		}
+	}
+	if len(hosts) > 0 {
+		r.hosts = regexp.MustCompile(`\b(` + strings.Join(hosts, "|") + `)\.pod\b`)
+	}
+	if app.URL != "" && !placeholderRegex.MatchString(app.URL) {
+		r.baseURL = strings.TrimSuffix(app.URL, "/")
+	}
+
+	var pullSecrets []nameRef
+	if login := app.RegistryLogin; login != nil {
+		name := app.Name + "-registry"
+		pullSecrets = []nameRef{{Name: name}}
+		r.add("Secret", name, r.registrySecret(name, *login))
+	}
+
+	var ingress []ingressPath
+	for _, pod := range app.Pods {
+		if pod.IsStatic() {
+			r.note("Pod %s is a static site hosted by Nexlayer; serve %s from an image of your own", pod.Name, staticDir(pod))
+			continue
+		}
+		if pod.Canary != nil {
+			r.note("Pod %s has a canary; only the stable version is exported", pod.Name)
+		}
+		if err := r.pod(pod, pullSecrets); err != nil {
+			return nil, err
+		}
+		if pod.Path != "" && len(pod.ServicePorts) > 0 {
+			ingress = append(ingress, ingressPath{
+				Path:     pod.Path,
+				PathType: "Prefix",
+				Backend:  ingressBackend{Service: ingressService{Name: pod.Name, Port: portNumber{Number: pod.ServicePorts[0].Port}}},
+			})
+		}
+	}
+
+	if m := app.Migrations; m != nil {
+		r.migrations(config, *m, pullSecrets)
+	}
+	if len(ingress) > 0 {
+		r.ingress(ingress)
+	}
+	if app.Regions != nil {
+		r.note("Regions are not exported; apply the manifests to a cluster in each region")
+	}
+
+	if len(r.secrets) > 0 {
+		for key := range r.secrets {
+			r.result.Secrets = append(r.result.Secrets, key)
+		}
+		sort.Strings(r.result.Secrets)
+		data := make(map[string]string, len(r.result.Secrets))
+		for _, key := range r.result.Secrets {
+			data[key] = r.secretValue(key)
+		}
+		obj := r.object("v1", "Secret", r.result.SecretName(), "")
+		obj.Type = "Opaque"
+		obj.StringData = data
+		r.add("Secret", r.result.SecretName(), obj)
+		if !opts.Helm {
+			r.note("Fill in the values of secret %s: %s", r.result.SecretName(), strings.Join(r.result.Secrets, ", "))
+		}
+	}
+	return r.result, nil
+}
+
+// pod renders the Deployment, Services, claims and mounted files of a pod
+func (r *renderer) pod(pod schema.Pod, pullSecrets []nameRef) error {
+	labels := r.labels(pod.Name)
+	c := container{
+		Name:    pod.Name,
+		Image:   r.image(pod.Name, pod.Image),
+		Command: append([]string(nil), pod.Entrypoint...),
+		Args:    append([]string(nil), pod.Command...),
+		Env:     r.env(pod.Vars),
+	}
+	spec := podSpec{ImagePullSecrets: pullSecrets}
+
+	for _, sp := range pod.ServicePorts {
+		c.Ports = append(c.Ports, containerPort{Name: portName(sp.Name), ContainerPort: sp.TargetPort, Protocol: schema.NormalizeProtocol(sp.Protocol)})
+	}
+	if pod.Seed != nil {
+		r.note("Seed data of %s is not exported; load it once after the first install", pod.Name)
+	}
+	c.ReadinessProbe = containerProbe(pod.ReadinessProbe())
+	if pod.Probes != nil {
+		c.LivenessProbe = containerProbe(pod.Probes.Liveness)
+		c.StartupProbe = containerProbe(pod.Probes.Startup)
+	}
+	if len(pod.Dependencies()) > 0 {
+		r.note("Pod %s depends on %s; Kubernetes starts pods together, so it must retry until they are ready", pod.Name, strings.Join(pod.Dependencies(), ", "))
+	}
+	c.Resources = containerResources(pod)
+
+	for _, v := range pod.Volumes {
+		size := v.Size
+		if size == "" {
+			size = DefaultVolumeSize
+		}
+		claim := claimSpec{
+			AccessModes:      []string{"ReadWriteOnce"},
+			StorageClassName: r.opts.StorageClass,
+			Resources:        claimResources{Requests: map[string]string{"storage": size}},
+		}
+		if v.Class == schema.VolumeClassSSD && r.opts.StorageClass == "" {
+			r.note("Volume %s asks for SSD storage; set a storage class that provides it", v.Name)
+		}
+		if v.Snapshot != nil {
+			r.note("Volume %s has scheduled snapshots; set up backups in the cluster", v.Name)
+		}
+		obj := r.object("v1", "PersistentVolumeClaim", v.Name, pod.Name)
+		obj.Spec = claim
+		r.add("PersistentVolumeClaim", v.Name, obj)
+		spec.Volumes = append(spec.Volumes, volume{Name: v.Name, PersistentVolumeClaim: &claimSource{ClaimName: v.Name}})
+		c.VolumeMounts = append(c.VolumeMounts, volumeMount{Name: v.Name, MountPath: v.Path, ReadOnly: v.ReadOnly})
+	}
+
+	for _, s := range pod.Secrets {
+		name := pod.Name + "-" + strings.ToLower(s.Name)
+		obj := r.object("v1", "Secret", name, pod.Name)
+		obj.Type = "Opaque"
+		obj.StringData = map[string]string{s.FileName: s.Data}
+		r.add("Secret", name, obj)
+		spec.Volumes = append(spec.Volumes, volume{Name: name, Secret: &secretSource{SecretName: name}})
+		c.VolumeMounts = append(c.VolumeMounts, volumeMount{Name: name, MountPath: joinPath(s.Path, s.FileName), SubPath: s.FileName, ReadOnly: true})
+	}
+
+	for _, cf := range pod.ConfigFiles {
+		if cf.Source != "" {
+			return fmt.Errorf("pod %s: config file %s is not resolved", pod.Name, cf.Name)
+		}
+		name := pod.Name + "-" + strings.ToLower(cf.Name)
+		obj := r.object("v1", "ConfigMap", name, pod.Name)
+		obj.Data = map[string]string{cf.FileName: cf.Content}
+		r.add("ConfigMap", name, obj)
+		spec.Volumes = append(spec.Volumes, volume{Name: name, ConfigMap: &nameRef{Name: name}})
+		c.VolumeMounts = append(c.VolumeMounts, volumeMount{Name: name, MountPath: joinPath(cf.Path, cf.FileName), SubPath: cf.FileName, ReadOnly: true})
+	}
+
+	spec.Containers = []container{c}
+	for _, ic := range pod.InitContainers {
+		spec.InitContainers = append(spec.InitContainers, r.container(pod, ic))
+	}
+	for _, sc := range pod.Sidecars {
+		spec.Containers = append(spec.Containers, r.container(pod, sc))
+	}
+	if pod.IsJob() {
+		r.job(pod, labels, spec)
+		return nil
+	}
+	dep := r.object("apps/v1", "Deployment", pod.Name, pod.Name)
+	dep.Metadata.Annotations = pod.Annotations
+	dep.Spec = deploymentSpec{
+		Replicas: pod.ReplicaCount(),
+		Selector: selector{MatchLabels: map[string]string{LabelName: pod.Name}},
+		Template: podTemplate{Metadata: meta{Labels: labels, Annotations: pod.Annotations}, Spec: spec},
+	}
+	r.add("Deployment", pod.Name, dep)
+	if pod.Autoscale != nil {
+		r.autoscaler(pod)
+	}
+	if pod.ReplicaCount() > 1 && len(pod.Volumes) > 0 {
+		r.note("Pod %s runs several replicas with volumes; its claims need a storage class that allows ReadWriteMany", pod.Name)
+	}
+
+	if len(pod.ServicePorts) == 0 {
+		return nil
+	}
+	var ports []servicePort
+	for _, sp := range pod.ServicePorts {
+		ports = append(ports, servicePort{Name: portName(sp.Name), Port: sp.Port, TargetPort: sp.TargetPort, Protocol: schema.NormalizeProtocol(sp.Protocol)})
+	}
+	// One service per host name, so that aliases keep resolving
+	for _, host := range pod.HostNames() {
+		svc := r.object("v1", "Service", host, pod.Name)
+		svc.Spec = serviceSpec{Selector: map[string]string{LabelName: pod.Name}, Ports: ports}
+		r.add("Service", host, svc)
+	}
+	return nil
+}
+
+// container renders an init container or sidecar of a pod, whose image
+// is named <pod>-<container> among the chart values
+func (r *renderer) container(pod schema.Pod, c schema.Container) container {
+	return container{
+		Name:    c.Name,
+		Image:   r.image(pod.Name+"-"+c.Name, c.Image),
+		Command: append([]string(nil), c.Entrypoint...),
+		Args:    append([]string(nil), c.Command...),
+		Env:     r.env(c.Vars),
+	}
+}
+
+// job renders the Job of a job pod, rerun on each release like the
+// migrations, or the CronJob of a cron job
+func (r *renderer) job(pod schema.Pod, labels map[string]string, spec podSpec) {
+	spec.RestartPolicy = "Never"
+	template := jobSpec{Template: podTemplate{Metadata: meta{Labels: labels, Annotations: pod.Annotations}, Spec: spec}}
+
+	if pod.IsCronJob() {
+		obj := r.object("batch/v1", "CronJob", pod.Name, pod.Name)
+		obj.Metadata.Annotations = pod.Annotations
+		obj.Spec = cronJobSpec{
+			Schedule:          pod.Schedule,
+			ConcurrencyPolicy: "Forbid",
+			JobTemplate:       jobTemplate{Spec: template},
+		}
+		r.add("CronJob", pod.Name, obj)
+		return
+	}
+
+	obj := r.object("batch/v1", "Job", pod.Name, pod.Name)
+	if r.opts.Helm {
+		obj.Metadata.Annotations = map[string]string{
+			"helm.sh/hook":               "post-install,post-upgrade",
+			"helm.sh/hook-delete-policy": "before-hook-creation",
+		}
+	} else {
+		r.note("Job %s runs once per deployment; delete and apply it again on each release", pod.Name)
+	}
+	for k, v := range pod.Annotations {
+		if obj.Metadata.Annotations == nil {
+			obj.Metadata.Annotations = make(map[string]string)
+		}
+		obj.Metadata.Annotations[k] = v
+	}
+	obj.Spec = template
+	r.add("Job", pod.Name, obj)
+}
+
+// migrations renders the Job running the migrations
+func (r *renderer) migrations(config *schema.NexlayerYAML, m schema.Migrations, pullSecrets []nameRef) {
+	name := r.app.Name + "-migrate"
+	c := container{
+		Name:  "migrate",
+		Image: r.image(name, schema.MigrationImage(config)),
+		Args:  append([]string(nil), m.Command...),
+	}
+	for _, pod := range r.app.Pods {
+		if pod.Name == m.Pod {
+			c.Command = append([]string(nil), pod.Entrypoint...)
+			c.Env = r.env(pod.Vars)
+		}
+	}
+	job := r.object("batch/v1", "Job", name, name)
+	if r.opts.Helm {
+		job.Metadata.Annotations = map[string]string{
+			"helm.sh/hook":               "pre-install,pre-upgrade",
+			"helm.sh/hook-delete-policy": "before-hook-creation",
+		}
+	} else {
+		r.note("Job %s runs the migrations; apply it before the deployments on each release", name)
+	}
+	job.Spec = jobSpec{
+		Template: podTemplate{
+			Metadata: meta{Labels: r.labels(name)},
+			Spec:     podSpec{RestartPolicy: "Never", ImagePullSecrets: pullSecrets, Containers: []container{c}},
+		},
+	}
+	r.add("Job", name, job)
+}
+
+// autoscaler renders the HorizontalPodAutoscaler of an autoscaled pod
+func (r *renderer) autoscaler(pod schema.Pod) {
+	a := pod.Autoscale
+	obj := r.object("autoscaling/v2", "HorizontalPodAutoscaler", pod.Name, pod.Name)
+	obj.Spec = autoscalerSpec{
+		ScaleTargetRef: scaleTarget{APIVersion: "apps/v1", Kind: "Deployment", Name: pod.Name},
+		MinReplicas:    a.Min,
+		MaxReplicas:    a.Max,
+		Metrics: []metric{{
+			Type: "Resource",
+			Resource: resourceMetric{
+				Name:   "cpu",
+				Target: metricTarget{Type: "Utilization", AverageUtilization: a.Target()},
+			},
+		}},
+	}
+	r.add("HorizontalPodAutoscaler", pod.Name, obj)
+	if pod.Resources == nil || pod.Resources.CPU == "" {
+		r.note("Pod %s is autoscaled on CPU but requests none; set resources.cpu so that utilization can be measured", pod.Name)
+	}
+}
+
+// containerProbe converts a health probe, or returns nil when it is unset
+func containerProbe(p *schema.HealthProbe) *probe {
+	if p == nil {
+		return nil
+	}
+	out := &probe{
+		InitialDelaySeconds: seconds(p.StartPeriod),
+		PeriodSeconds:       seconds(p.Interval),
+		TimeoutSeconds:      seconds(p.Timeout),
+		FailureThreshold:    p.Retries,
+	}
+	switch {
+	case p.HTTPGet != nil:
+		out.HTTPGet = &httpGetAction{Path: p.HTTPGet.Path, Port: p.HTTPGet.Port}
+	case p.TCPSocket != nil:
+		out.TCPSocket = &tcpSocketAction{Port: p.TCPSocket.Port}
+	case p.Exec != nil:
+		out.Exec = &execAction{Command: append([]string(nil), p.Exec.Command...)}
+	}
+	return out
+}
+
+// seconds converts a probe duration to whole seconds, rounded up; zero when
+// unset or invalid
+func seconds(d string) int {
+	duration, err := time.ParseDuration(d)
+	if err != nil || duration <= 0 {
+		return 0
+	}
+	return int((duration + time.Second - 1) / time.Second)
+}
+
+// ingress renders the Ingress routing to forward-facing pods
+func (r *renderer) ingress(paths []ingressPath) {
+	// Longer prefixes first, so that / does not shadow the others
+	sort.SliceStable(paths, func(i, j int) bool { return len(paths[i].Path) > len(paths[j].Path) })
+	rule := ingressRule{HTTP: ingressHTTP{Paths: paths}}
+	if r.baseURL != "" {
+		if u, err := url.Parse(r.baseURL); err == nil {
+			rule.Host = u.Hostname()
+		}
+	} else {
+		r.note("The ingress matches any host; set application.url or edit its rules")
+	}
+	obj := r.object("networking.k8s.io/v1", "Ingress", r.app.Name, "")
+	obj.Spec = ingressSpec{IngressClassName: r.opts.IngressClass, Rules: []ingressRule{rule}}
+	r.add("Ingress", r.app.Name, obj)
+}
+
+// registrySecret renders the image pull secret of a private registry
+func (r *renderer) registrySecret(name string, login schema.RegistryLogin) object {
+	var data []byte
+	if r.opts.Helm {
+		// The auth field is computed from the token when the chart renders
+		data = []byte(fmt.Sprintf(`{"auths":{%q:{"username":%q,"password":"{{ .Values.registryToken }}","auth":"{{ printf "%%s:%%s" %q .Values.registryToken | b64enc }}"}}}`,
+			login.Registry, login.Username, login.Username))
+	} else {
+		if placeholderRegex.MatchString(login.PersonalAccessToken) {
+			r.note("Set the registry token in secret %s", name)
+		}
+		auth := base64.StdEncoding.EncodeToString([]byte(login.Username + ":" + login.PersonalAccessToken))
+		data, _ = json.Marshal(map[string]interface{}{
+			"auths": map[string]interface{}{
+				login.Registry: map[string]string{"username": login.Username, "password": login.PersonalAccessToken, "auth": auth},
+			},
+		})
+	}
+	obj := r.object("v1", "Secret", name, "")
+	obj.Type = "kubernetes.io/dockerconfigjson"
+	obj.StringData = map[string]string{".dockerconfigjson": string(data)}
+	return obj
+}
+
+// env converts vars. Values the platform fills in come from the application
+// secret, and <pod>.pod references become service names.
+func (r *renderer) env(vars []schema.EnvVar) []envVar {
+	var env []envVar
+	defined := make(map[string]bool)
+	for _, v := range vars {
+		value := v.Value
+		if r.hosts != nil {
+			value = r.hosts.ReplaceAllString(value, "$1")
+		}
+		if r.baseURL != "" {
+			value = strings.ReplaceAll(value, schema.URLPlaceholder, r.baseURL)
+		}
+
+		if m := placeholderRegex.FindStringSubmatch(value); m != nil && m[0] == strings.TrimSpace(value) {
+			r.secrets[m[1]] = true
+			env = append(env, envVar{Name: v.Key, ValueFrom: &envSource{SecretKeyRef: keyRef{Name: r.result.SecretName(), Key: m[1]}}})
+			defined[v.Key] = true
+			continue
+		}
+		// Placeholders within a value are defined first and referenced as
+		// $(KEY), which Kubernetes expands
+		for _, m := range placeholderRegex.FindAllStringSubmatch(value, -1) {
+			key := m[1]
+			r.secrets[key] = true
+			if !defined[key] {
+				env = append(env, envVar{Name: key, ValueFrom: &envSource{SecretKeyRef: keyRef{Name: r.result.SecretName(), Key: key}}})
+				defined[key] = true
+			}
+		}
+		value = placeholderRegex.ReplaceAllString(value, "$$($1)")
+		env = append(env, envVar{Name: v.Key, Value: value})
+		defined[v.Key] = true
+	}
+	return env
+}
+
+// image returns the image of a pod, or its chart value
+func (r *renderer) image(name, image string) string {
+	if login := r.app.RegistryLogin; login != nil {
+		image = strings.ReplaceAll(image, schema.RegistryPlaceholder, login.Registry)
+	}
+	if strings.Contains(image, schema.RegistryPlaceholder) {
+		r.note("Image %s of %s refers to the Nexlayer registry; push it to a registry the cluster can pull from", image, name)
+	}
+	r.result.Images[name] = image
+	if r.opts.Helm {
+		return fmt.Sprintf("{{ index .Values.images %q }}", name)
+	}
+	return image
+}
+
+// secretValue returns the value of a key of the application secret
+func (r *renderer) secretValue(key string) string {
+	if r.opts.Helm {
+		return fmt.Sprintf("{{ index .Values.secrets %q }}", key)
+	}
+	return ""
+}
+
+// object starts an object of the application; pod labels it with the pod
+// it belongs to
+func (r *renderer) object(apiVersion, kind, name, pod string) object {
+	labels := map[string]string{LabelPartOf: r.app.Name}
+	if pod != "" {
+		labels[LabelName] = pod
+	}
+	for k, v := range r.app.Labels {
+		labels[k] = v
+	}
+	return object{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Metadata:   meta{Name: name, Namespace: r.opts.Namespace, Labels: labels},
+	}
+}
+
+// labels returns the labels of the pods of a workload
+func (r *renderer) labels(name string) map[string]string {
+	return map[string]string{LabelName: name, LabelPartOf: r.app.Name}
+}
+
+// add appends a manifest
+func (r *renderer) add(kind, name string, obj object) {
+	r.result.Manifests = append(r.result.Manifests, Manifest{Kind: kind, Name: name, obj: obj})
+}
+
+// note records something that did not translate
+func (r *renderer) note(format string, args ...interface{}) {
+	r.result.Notes = append(r.result.Notes, fmt.Sprintf(format, args...))
+}
+
+// portName shortens a port name to the 15 characters Kubernetes allows
+func portName(name string) string {
+	name = strings.ToLower(name)
+	if len(name) > 15 {
+		name = strings.TrimRight(name[:15], "-")
+	}
+	return name
+}
+
+// joinPath joins a directory and a file name with a single slash
+func joinPath(dir, file string) string {
+	return strings.TrimSuffix(dir, "/") + "/" + file
+}
+
+// staticDir names the directory of a static site
+func staticDir(pod schema.Pod) string {
+	if pod.Static != nil && pod.Static.Dir != "" {
+		return pod.Static.Dir
+	}
+	return "its files"
+}
+
+// containerResources returns the requests and limits of a pod's container: the
+// pod is guaranteed and limited to its CPU and memory
+func containerResources(pod schema.Pod) *resources {
+	r := &resources{Requests: map[string]string{}, Limits: map[string]string{}}
+	if pod.Resources != nil && pod.Resources.CPU != "" {
+		r.Requests["cpu"], r.Limits["cpu"] = pod.Resources.CPU, pod.Resources.CPU
+	}
+	if pod.Resources != nil && pod.Resources.Memory != "" {
+		r.Requests["memory"], r.Limits["memory"] = pod.Resources.Memory, pod.Resources.Memory
+	}
+	if count, _ := pod.GPURequest(); count > 0 {
+		r.Limits[LabelGPU] = fmt.Sprint(count)
+	}
+	if len(r.Limits) == 0 {
+		return nil
+	}
+	if len(r.Requests) == 0 {
+		r.Requests = nil
+	}
+	return r
+}
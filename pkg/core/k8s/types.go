@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package k8s
+
+// The subset of the Kubernetes API the export writes, in field order
+
+type object struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   meta              `yaml:"metadata"`
+	Type       string            `yaml:"type,omitempty"`
+	StringData map[string]string `yaml:"stringData,omitempty"`
+	Data       map[string]string `yaml:"data,omitempty"`
+	Spec       interface{}       `yaml:"spec,omitempty"`
+}
+
+type meta struct {
+	Name        string            `yaml:"name,omitempty"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+type deploymentSpec struct {
+	Replicas int         `yaml:"replicas"`
+	Selector selector    `yaml:"selector"`
+	Template podTemplate `yaml:"template"`
+}
+
+type autoscalerSpec struct {
+	ScaleTargetRef scaleTarget `yaml:"scaleTargetRef"`
+	MinReplicas    int         `yaml:"minReplicas"`
+	MaxReplicas    int         `yaml:"maxReplicas"`
+	Metrics        []metric    `yaml:"metrics"`
+}
+
+type scaleTarget struct {
+	APIVersion string `yaml:"apiVersion,omitempty"`
+	Kind       string `yaml:"kind"`
+	Name       string `yaml:"name"`
+}
+
+type metric struct {
+	Type     string         `yaml:"type"`
+	Resource resourceMetric `yaml:"resource"`
+}
+
+type resourceMetric struct {
+	Name   string       `yaml:"name"`
+	Target metricTarget `yaml:"target"`
+}
+
+type metricTarget struct {
+	Type               string `yaml:"type"`
+	AverageUtilization int    `yaml:"averageUtilization,omitempty"`
+}
+
+type selector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type podTemplate struct {
+	Metadata meta    `yaml:"metadata"`
+	Spec     podSpec `yaml:"spec"`
+}
+
+type jobSpec struct {
+	BackoffLimit int         `yaml:"backoffLimit"`
+	Template     podTemplate `yaml:"template"`
+}
+
+type cronJobSpec struct {
+	Schedule          string      `yaml:"schedule"`
+	ConcurrencyPolicy string      `yaml:"concurrencyPolicy,omitempty"`
+	JobTemplate       jobTemplate `yaml:"jobTemplate"`
+}
+
+type jobTemplate struct {
+	Spec jobSpec `yaml:"spec"`
+}
+
+type podSpec struct {
+	RestartPolicy    string      `yaml:"restartPolicy,omitempty"`
+	ImagePullSecrets []nameRef   `yaml:"imagePullSecrets,omitempty"`
+	InitContainers   []container `yaml:"initContainers,omitempty"`
+	Containers       []container `yaml:"containers"`
+	Volumes          []volume    `yaml:"volumes,omitempty"`
+}
+
+type nameRef struct {
+	Name string `yaml:"name"`
+}
+
+type container struct {
+	Name           string          `yaml:"name"`
+	Image          string          `yaml:"image"`
+	Command        []string        `yaml:"command,omitempty"`
+	Args           []string        `yaml:"args,omitempty"`
+	Ports          []containerPort `yaml:"ports,omitempty"`
+	Env            []envVar        `yaml:"env,omitempty"`
+	VolumeMounts   []volumeMount   `yaml:"volumeMounts,omitempty"`
+	Resources      *resources      `yaml:"resources,omitempty"`
+	LivenessProbe  *probe          `yaml:"livenessProbe,omitempty"`
+	ReadinessProbe *probe          `yaml:"readinessProbe,omitempty"`
+	StartupProbe   *probe          `yaml:"startupProbe,omitempty"`
+}
+
+type probe struct {
+	Exec                *execAction      `yaml:"exec,omitempty"`
+	HTTPGet             *httpGetAction   `yaml:"httpGet,omitempty"`
+	TCPSocket           *tcpSocketAction `yaml:"tcpSocket,omitempty"`
+	InitialDelaySeconds int              `yaml:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       int              `yaml:"periodSeconds,omitempty"`
+	TimeoutSeconds      int              `yaml:"timeoutSeconds,omitempty"`
+	FailureThreshold    int              `yaml:"failureThreshold,omitempty"`
+}
+
+type execAction struct {
+	Command []string `yaml:"command"`
+}
+
+type httpGetAction struct {
+	Path string `yaml:"path"`
+	Port int    `yaml:"port"`
+}
+
+type tcpSocketAction struct {
+	Port int `yaml:"port"`
+}
+
+type containerPort struct {
+	Name          string `yaml:"name,omitempty"`
+	ContainerPort int    `yaml:"containerPort"`
+	Protocol      string `yaml:"protocol,omitempty"`
+}
+
+type envVar struct {
+	Name      string     `yaml:"name"`
+	Value     string     `yaml:"value,omitempty"`
+	ValueFrom *envSource `yaml:"valueFrom,omitempty"`
+}
+
+type envSource struct {
+	SecretKeyRef keyRef `yaml:"secretKeyRef"`
+}
+
+type keyRef struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+type volumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+	SubPath   string `yaml:"subPath,omitempty"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty"`
+}
+
+type volume struct {
+	Name                  string        `yaml:"name"`
+	PersistentVolumeClaim *claimSource  `yaml:"persistentVolumeClaim,omitempty"`
+	Secret                *secretSource `yaml:"secret,omitempty"`
+	ConfigMap             *nameRef      `yaml:"configMap,omitempty"`
+}
+
+type claimSource struct {
+	ClaimName string `yaml:"claimName"`
+}
+
+type secretSource struct {
+	SecretName string `yaml:"secretName"`
+}
+
+type resources struct {
+	Requests map[string]string `yaml:"requests,omitempty"`
+	Limits   map[string]string `yaml:"limits"`
+}
+
+type serviceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []servicePort     `yaml:"ports"`
+}
+
+type servicePort struct {
+	Name       string `yaml:"name"`
+	Port       int    `yaml:"port"`
+	TargetPort int    `yaml:"targetPort"`
+	Protocol   string `yaml:"protocol,omitempty"`
+}
+
+type claimSpec struct {
+	AccessModes      []string       `yaml:"accessModes"`
+	StorageClassName string         `yaml:"storageClassName,omitempty"`
+	Resources        claimResources `yaml:"resources"`
+}
+
+type claimResources struct {
+	Requests map[string]string `yaml:"requests"`
+}
+
+type ingressSpec struct {
+	IngressClassName string        `yaml:"ingressClassName,omitempty"`
+	Rules            []ingressRule `yaml:"rules"`
+}
+
+type ingressRule struct {
+	Host string      `yaml:"host,omitempty"`
+	HTTP ingressHTTP `yaml:"http"`
+}
+
+type ingressHTTP struct {
+	Paths []ingressPath `yaml:"paths"`
+}
+
+type ingressPath struct {
+	Path     string         `yaml:"path"`
+	PathType string         `yaml:"pathType"`
+	Backend  ingressBackend `yaml:"backend"`
+}
+
+type ingressBackend struct {
+	Service ingressService `yaml:"service"`
+}
+
+type ingressService struct {
+	Name string     `yaml:"name"`
+	Port portNumber `yaml:"port"`
+}
+
+type portNumber struct {
+	Number int `yaml:"number"`
+}
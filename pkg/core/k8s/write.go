@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChartVersion is the version of generated charts
+const ChartVersion = "0.1.0"
+
+// WriteStream writes the manifests as one multi-document stream, as read by
+// kubectl apply -f -
+func WriteStream(w io.Writer, r *Result) error {
+	for i, m := range r.Manifests {
+		data, err := m.YAML()
+		if err != nil {
+			return fmt.Errorf("failed to encode %s %s: %w", m.Kind, m.Name, err)
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteDir writes each manifest to its own file in dir and returns the files
+// written
+func WriteDir(dir string, r *Result) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	var files []string
+	for _, m := range r.Manifests {
+		data, err := m.YAML()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s %s: %w", m.Kind, m.Name, err)
+		}
+		file := filepath.Join(dir, m.FileName())
+		if err := os.WriteFile(file, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// WriteChart writes a Helm chart rendered with Options.Helm to dir: the
+// manifests as templates, and values holding the images and secrets
+func WriteChart(dir string, r *Result) ([]string, error) {
+	chart := map[string]string{
+		"apiVersion":  "v2",
+		"name":        r.App,
+		"description": fmt.Sprintf("%s, exported from Nexlayer", r.App),
+		"type":        "application",
+		"version":     ChartVersion,
+	}
+	chartData, err := yaml.Marshal(chart)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{"images": r.Images}
+	secrets := make(map[string]string, len(r.Secrets))
+	for _, key := range r.Secrets {
+		secrets[key] = ""
+	}
+	if len(secrets) > 0 {
+		values["secrets"] = secrets
+	}
+	for _, m := range r.Manifests {
+		if m.obj.Type == "kubernetes.io/dockerconfigjson" {
+			values["registryToken"] = ""
+		}
+	}
+	var buf bytes.Buffer
+	buf.WriteString("# Images of the pods, and values of the secrets to set at install time:\n")
+	buf.WriteString("#   helm install " + r.App + " . --set secrets.KEY=value\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(values); err != nil {
+		return nil, err
+	}
+	enc.Close()
+
+	templates := filepath.Join(dir, "templates")
+	if err := os.MkdirAll(templates, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", templates, err)
+	}
+	files := []string{filepath.Join(dir, "Chart.yaml"), filepath.Join(dir, "values.yaml")}
+	if err := os.WriteFile(files[0], chartData, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", files[0], err)
+	}
+	if err := os.WriteFile(files[1], buf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", files[1], err)
+	}
+	written, err := WriteDir(templates, r)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, written...), nil
+}
@@ -0,0 +1,263 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package lint checks a nexlayer.yaml for errors, insecure settings and
+// questionable practices, and fixes those it can in place.
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/scanner"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity ranks how serious an issue is
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+var severityNames = []string{"info", "warning", "error"}
+
+// String returns the lowercase severity name
+func (s Severity) String() string {
+	if s < SeverityInfo || s > SeverityError {
+		return "unknown"
+	}
+	return severityNames[s]
+}
+
+// MarshalText encodes the severity by name
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ParseSeverity converts a severity name into a Severity
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(n, name) {
+			return Severity(i), nil
+		}
+	}
+	return SeverityInfo, fmt.Errorf("unknown severity %q (use %s)", name, strings.Join(severityNames, ", "))
+}
+
+// Issue is a single problem found in a deployment file
+type Issue struct {
+	RuleID     string   `json:"ruleId"`
+	Severity   Severity `json:"severity"`
+	Field      string   `json:"field,omitempty"`
+	Line       int      `json:"line,omitempty"`
+	Message    string   `json:"message"`
+	Suggestion string   `json:"suggestion,omitempty"`
+	Fixable    bool     `json:"fixable"`
+
+	// fix edits the document to resolve the issue and reports whether it did
+	fix func(root *yaml.Node) bool
+	// replaces are the validation errors this issue reports in more detail
+	replaces []validate.ValidationError
+}
+
+// Report holds the issues found in a deployment file
+type Report struct {
+	File   string  `json:"file"`
+	Issues []Issue `json:"issues"`
+
+	doc yaml.Node
+}
+
+// File lints a deployment file
+func File(file string) (*Report, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployment file: %w", err)
+	}
+	report := &Report{File: file, Issues: []Issue{}}
+	if err := yaml.Unmarshal(data, &report.doc); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment file: %w", err)
+	}
+	if len(report.doc.Content) == 0 || report.doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("deployment file must be a YAML mapping")
+	}
+	root := report.doc.Content[0]
+
+	// Lint a migrated copy so that files in an older schema are checked
+	// against the current one
+	var migrated yaml.Node
+	if err := yaml.Unmarshal(data, &migrated); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment file: %w", err)
+	}
+	applied, err := schema.Migrate(migrated.Content[0])
+	if err != nil {
+		return nil, err
+	}
+	var issues []Issue
+	if len(applied) > 0 {
+		issues = append(issues, schemaVersionIssue(applied))
+	}
+
+	content, err := yaml.Marshal(migrated.Content[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deployment file: %w", err)
+	}
+	config, _, err := deployment.Parse(content)
+	if err != nil {
+		issues = append(issues, Issue{RuleID: RuleSchema, Severity: SeverityError, Message: err.Error()})
+		report.add(root, issues)
+		return report, nil
+	}
+
+	for _, rule := range rules {
+		issues = append(issues, rule.check(config)...)
+	}
+
+	replaced := make(map[string]bool)
+	for _, issue := range issues {
+		for _, verr := range issue.replaces {
+			replaced[verr.Error()] = true
+		}
+	}
+	validator := validate.NewValidator(config).WithBaseDir(filepath.Dir(file))
+	if validator.Validate() != nil {
+		for _, verr := range validator.Errors() {
+			if replaced[verr.Error()] {
+				continue
+			}
+			issues = append(issues, Issue{
+				RuleID:     RuleSchema,
+				Severity:   SeverityError,
+				Field:      verr.Field,
+				Message:    verr.Message,
+				Suggestion: strings.Join(verr.Suggestions, "; "),
+			})
+		}
+	}
+
+	for _, f := range scanner.NewSecurityScanner().Scan(config) {
+		issues = append(issues, Issue{
+			RuleID:     securityPrefix + f.RuleID,
+			Severity:   securitySeverity(f.Severity),
+			Field:      f.Field,
+			Message:    f.Message,
+			Suggestion: f.Remediation,
+		})
+	}
+
+	report.add(root, issues)
+	return report, nil
+}
+
+// add records issues with the line of their field, most severe first
+func (r *Report) add(root *yaml.Node, issues []Issue) {
+	for i := range issues {
+		if issues[i].Line == 0 {
+			issues[i].Line = lineOf(root, issues[i].Field)
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Severity > issues[j].Severity
+	})
+	r.Issues = append(r.Issues, issues...)
+}
+
+// MaxSeverity returns the highest severity among the issues, or -1 when there
+// are none
+func (r *Report) MaxSeverity() Severity {
+	max := Severity(-1)
+	for _, issue := range r.Issues {
+		if issue.Severity > max {
+			max = issue.Severity
+		}
+	}
+	return max
+}
+
+// Count returns the number of issues at or above a severity
+func (r *Report) Count(threshold Severity) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity >= threshold {
+			n++
+		}
+	}
+	return n
+}
+
+// Fix applies the fixes of fixable issues to the file, keeping its comments,
+// and returns the issues fixed. Fixed issues are removed from the report.
+func (r *Report) Fix() ([]Issue, error) {
+	root := r.doc.Content[0]
+
+	// Migrate first: the other fixes edit the current schema
+	ordered := append([]Issue(nil), r.Issues...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].RuleID == RuleSchemaVersion && ordered[j].RuleID != RuleSchemaVersion
+	})
+	var fixed []Issue
+	done := make(map[*Issue]bool)
+	for i := range ordered {
+		issue := &ordered[i]
+		if issue.fix != nil && issue.fix(root) {
+			fixed = append(fixed, *issue)
+			done[issue] = true
+		}
+	}
+	if len(fixed) == 0 {
+		return nil, nil
+	}
+
+	remaining := r.Issues[:0]
+	for i := range ordered {
+		if !done[&ordered[i]] {
+			remaining = append(remaining, ordered[i])
+		}
+	}
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].Severity > remaining[j].Severity
+	})
+	r.Issues = remaining
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&r.doc); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", r.File, err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", r.File, err)
+	}
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(r.File); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(r.File, buf.Bytes(), mode); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", r.File, err)
+	}
+	return fixed, nil
+}
+
+// securitySeverity maps the severity of a security finding to an issue
+// severity
+func securitySeverity(s scanner.Severity) Severity {
+	switch {
+	case s >= scanner.SeverityHigh:
+		return SeverityError
+	case s == scanner.SeverityMedium:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mappingNode returns the value node for key in a mapping, or nil
+func mappingNode(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequenceItem returns the item at index in a sequence, or nil
+func sequenceItem(s *yaml.Node, index int) *yaml.Node {
+	if s == nil || s.Kind != yaml.SequenceNode || index < 0 || index >= len(s.Content) {
+		return nil
+	}
+	return s.Content[index]
+}
+
+// namedItem returns the mapping in a sequence whose key has value, or nil
+func namedItem(s *yaml.Node, key, value string) *yaml.Node {
+	if s == nil || s.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, item := range s.Content {
+		if n := mappingNode(item, key); n != nil && n.Value == value {
+			return item
+		}
+	}
+	return nil
+}
+
+// podNode returns the mapping of the pod named name, or nil
+func podNode(root *yaml.Node, name string) *yaml.Node {
+	return namedItem(mappingNode(mappingNode(root, "application"), "pods"), "name", name)
+}
+
+// setString sets key to a string in a mapping, adding it when missing
+func setString(m *yaml.Node, key, value string) {
+	if n := mappingNode(m, key); n != nil {
+		n.Kind, n.Tag, n.Value, n.Content = yaml.ScalarNode, "!!str", value, nil
+		return
+	}
+	m.Content = append(m.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+}
+
+// dropDuplicates removes all but the last of the vars entries for key and
+// reports whether any was removed
+func dropDuplicates(vars *yaml.Node, key string) bool {
+	if vars == nil || vars.Kind != yaml.SequenceNode {
+		return false
+	}
+	last := -1
+	for i, item := range vars.Content {
+		if n := mappingNode(item, "key"); n != nil && n.Value == key {
+			last = i
+		}
+	}
+	kept := vars.Content[:0]
+	removed := false
+	for i, item := range vars.Content {
+		if n := mappingNode(item, "key"); n != nil && n.Value == key && i != last {
+			removed = true
+			continue
+		}
+		kept = append(kept, item)
+	}
+	vars.Content = kept
+	return removed
+}
+
+// lineOf returns the line of the deepest node a field path such as
+// pods[0].servicePorts[1].name leads to, or 0 when the path does not start
+// in the document. Paths may omit the leading application.
+func lineOf(root *yaml.Node, field string) int {
+	if field == "" {
+		return 0
+	}
+	node := root
+	if !strings.HasPrefix(field, "application") && mappingNode(root, firstKey(field)) == nil {
+		node = mappingNode(root, "application")
+	}
+	if node == nil || mappingNode(node, firstKey(field)) == nil {
+		return 0
+	}
+	line := node.Line
+	for _, part := range strings.Split(field, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		next := mappingNode(node, key)
+		if next == nil {
+			break
+		}
+		node, line = next, keyLine(node, key)
+		for rest != "" {
+			idx, after, _ := strings.Cut(rest, "]")
+			i, err := strconv.Atoi(idx)
+			item := sequenceItem(node, i)
+			if err != nil || item == nil {
+				return line
+			}
+			node, line = item, item.Line
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return line
+}
+
+// firstKey returns the first key of a field path
+func firstKey(field string) string {
+	key, _, _ := strings.Cut(field, ".")
+	key, _, _ = strings.Cut(key, "[")
+	return key
+}
+
+// keyLine returns the line of key in a mapping
+func keyLine(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i].Line
+		}
+	}
+	return m.Line
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/scanner"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule IDs reported for validation errors, old schema versions and, as
+// prefix of the scanner rule ID, security findings
+const (
+	RuleSchema        = "schema"
+	RuleSchemaVersion = "schema-version"
+	securityPrefix    = "security/"
+)
+
+// defaultVolumeSize is the size --fix gives volumes without one
+const defaultVolumeSize = "1Gi"
+
+// Rule describes a check
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+
+	check func(config *schema.NexlayerYAML) []Issue
+}
+
+// rules are the checks run on top of validation and the security scanner
+var rules = []Rule{
+	{
+		ID:          "port-name",
+		Description: "Service ports must be named",
+		Severity:    SeverityError,
+		check:       checkPortNames,
+	},
+	{
+		ID:          "volume-size",
+		Description: "Volumes must have a size",
+		Severity:    SeverityError,
+		check:       checkVolumeSizes,
+	},
+	{
+		ID:          "duplicate-var",
+		Description: "A variable must be set once per pod",
+		Severity:    SeverityError,
+		check:       checkDuplicateVars,
+	},
+	{
+		ID:          "image-tag",
+		Description: "Images should be pinned to a tag or digest other than latest",
+		Severity:    SeverityWarning,
+		check:       checkImageTags,
+	},
+	{
+		ID:          "probe-dependency",
+		Description: "Pods other pods depend on should have a probe",
+		Severity:    SeverityInfo,
+		check:       checkDependencyProbes,
+	},
+}
+
+// Rules returns every rule lint reports, including validation, schema
+// versions and one per security scanner rule
+func Rules() []Rule {
+	all := []Rule{
+		{ID: RuleSchema, Description: "The file must be valid for the Nexlayer schema", Severity: SeverityError},
+		{ID: RuleSchemaVersion, Description: "The file should use the current schema version", Severity: SeverityWarning},
+	}
+	all = append(all, rules...)
+	for _, r := range scanner.DefaultRules() {
+		all = append(all, Rule{ID: securityPrefix + r.ID(), Description: "Security: " + r.ID(), Severity: SeverityWarning})
+	}
+	return all
+}
+
+// schemaVersionIssue reports the migration steps a file needs
+func schemaVersionIssue(applied []string) Issue {
+	return Issue{
+		RuleID:     RuleSchemaVersion,
+		Severity:   SeverityWarning,
+		Field:      schema.SchemaVersionKey,
+		Message:    fmt.Sprintf("file uses an older schema; migrate %s", strings.Join(applied, ", ")),
+		Suggestion: fmt.Sprintf("Upgrade the file to schema version %d", schema.CurrentSchemaVersion),
+		Fixable:    true,
+		fix: func(root *yaml.Node) bool {
+			applied, err := schema.Migrate(root)
+			return err == nil && len(applied) > 0
+		},
+	}
+}
+
+// checkPortNames names unnamed service ports after their number
+func checkPortNames(config *schema.NexlayerYAML) []Issue {
+	var issues []Issue
+	for i, pod := range config.Application.Pods {
+		for j, port := range pod.ServicePorts {
+			if port.Name != "" || port.Port < 1 {
+				continue
+			}
+			name := fmt.Sprintf("port-%d", port.Port)
+			if schema.NormalizeProtocol(port.Protocol) != schema.ProtocolTCP {
+				name += "-" + strings.ToLower(schema.NormalizeProtocol(port.Protocol))
+			}
+			podName, index := pod.Name, j
+			issues = append(issues, Issue{
+				RuleID:     "port-name",
+				Severity:   SeverityError,
+				Field:      fmt.Sprintf("pods[%d].servicePorts[%d].name", i, j),
+				Message:    fmt.Sprintf("port %d of pod %s has no name", port.Port, pod.Name),
+				Suggestion: fmt.Sprintf("Name it, e.g. %s", name),
+				Fixable:    true,
+				fix: func(root *yaml.Node) bool {
+					item := sequenceItem(mappingNode(podNode(root, podName), "servicePorts"), index)
+					if item == nil || item.Kind != yaml.MappingNode {
+						return false
+					}
+					setString(item, "name", name)
+					return true
+				},
+				replaces: []validate.ValidationError{{
+					Field:   fmt.Sprintf("pod.servicePorts[%d].name", j),
+					Message: "port name is required",
+				}},
+			})
+		}
+	}
+	return issues
+}
+
+// checkVolumeSizes gives volumes without a size the default one
+func checkVolumeSizes(config *schema.NexlayerYAML) []Issue {
+	var issues []Issue
+	for i, pod := range config.Application.Pods {
+		for j, vol := range pod.Volumes {
+			if vol.Size != "" {
+				continue
+			}
+			podName, volName := pod.Name, vol.Name
+			issues = append(issues, Issue{
+				RuleID:     "volume-size",
+				Severity:   SeverityError,
+				Field:      fmt.Sprintf("pods[%d].volumes[%d].size", i, j),
+				Message:    fmt.Sprintf("volume %s of pod %s has no size", vol.Name, pod.Name),
+				Suggestion: fmt.Sprintf("Set a size such as %s", defaultVolumeSize),
+				Fixable:    volName != "",
+				fix: func(root *yaml.Node) bool {
+					item := namedItem(mappingNode(podNode(root, podName), "volumes"), "name", volName)
+					if volName == "" || item == nil {
+						return false
+					}
+					setString(item, "size", defaultVolumeSize)
+					return true
+				},
+				replaces: []validate.ValidationError{{
+					Field:   fmt.Sprintf("pods[%d].volumes.size", i),
+					Message: "volume size is required",
+				}},
+			})
+		}
+	}
+	return issues
+}
+
+// checkDuplicateVars keeps the last value of variables set more than once
+func checkDuplicateVars(config *schema.NexlayerYAML) []Issue {
+	var issues []Issue
+	for i, pod := range config.Application.Pods {
+		first := make(map[string]int)
+		var keys []string
+		for j, v := range pod.Vars {
+			if v.Key == "" {
+				continue
+			}
+			if _, ok := first[v.Key]; !ok {
+				first[v.Key] = j
+				keys = append(keys, v.Key)
+			}
+		}
+		for _, key := range keys {
+			var replaces []validate.ValidationError
+			for j, v := range pod.Vars {
+				if v.Key == key && j != first[key] {
+					replaces = append(replaces, validate.ValidationError{
+						Field:   fmt.Sprintf("pod.vars[%d].key", j),
+						Message: fmt.Sprintf("duplicate environment variable: %s", key),
+					})
+				}
+			}
+			if len(replaces) == 0 {
+				continue
+			}
+			podName, key := pod.Name, key
+			issues = append(issues, Issue{
+				RuleID:     "duplicate-var",
+				Severity:   SeverityError,
+				Field:      fmt.Sprintf("pods[%d].vars[%d]", i, first[key]),
+				Message:    fmt.Sprintf("variable %s is set %d times in pod %s", key, len(replaces)+1, pod.Name),
+				Suggestion: "Keep one value; --fix keeps the last",
+				Fixable:    true,
+				fix: func(root *yaml.Node) bool {
+					return dropDuplicates(mappingNode(podNode(root, podName), "vars"), key)
+				},
+				replaces: replaces,
+			})
+		}
+	}
+	return issues
+}
+
+// checkImageTags flags images pinned to no tag or to latest. Images built from
+// source are tagged by the build and not reported.
+func checkImageTags(config *schema.NexlayerYAML) []Issue {
+	var issues []Issue
+	for i, pod := range config.Application.Pods {
+		if pod.Image == "" || pod.Build != nil || strings.Contains(pod.Image, "@") {
+			continue
+		}
+		name := path.Base(pod.Image)
+		tag := ""
+		if idx := strings.LastIndex(name, ":"); idx >= 0 {
+			tag = name[idx+1:]
+		}
+		if tag != "" && tag != "latest" {
+			continue
+		}
+		issues = append(issues, Issue{
+			RuleID:     "image-tag",
+			Severity:   SeverityWarning,
+			Field:      fmt.Sprintf("pods[%d].image", i),
+			Message:    fmt.Sprintf("image %s of pod %s is not pinned to a version", pod.Image, pod.Name),
+			Suggestion: "Use a version tag or digest so that redeploys run the same image",
+		})
+	}
+	return issues
+}
+
+// checkDependencyProbes flags pods others wait for that have no probe: the
+// dependent pods start once they are up rather than ready. Jobs are waited
+// for until they complete.
+func checkDependencyProbes(config *schema.NexlayerYAML) []Issue {
+	var issues []Issue
+	dependents := make(map[string][]string)
+	for _, pod := range config.Application.Pods {
+		for _, dep := range pod.Dependencies() {
+			dependents[dep] = append(dependents[dep], pod.Name)
+		}
+	}
+	for i, pod := range config.Application.Pods {
+		if pod.ReadinessProbe() != nil || pod.IsJob() || len(dependents[pod.Name]) == 0 {
+			continue
+		}
+		issues = append(issues, Issue{
+			RuleID:     "probe-dependency",
+			Severity:   SeverityInfo,
+			Field:      fmt.Sprintf("pods[%d]", i),
+			Message:    fmt.Sprintf("pod %s has no probe but %s depend on it", pod.Name, strings.Join(dependents[pod.Name], ", ")),
+			Suggestion: "Add a readiness probe so that dependent pods wait until it is ready",
+		})
+	}
+	return issues
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package lint
+
+import "path/filepath"
+
+// SARIF 2.1.0, as read by GitHub code scanning and other CI annotators
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolURI      = "https://github.com/Nexlayer/nexlayer-cli"
+)
+
+// SARIFLog is a SARIF report with a single run of nexlayer lint
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *sarifRegion `json:"region,omitempty"`
+	} `json:"physicalLocation"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifLevel maps a severity to a SARIF level
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// SARIF converts reports into a SARIF log. toolVersion is the CLI version.
+func SARIF(reports []*Report, toolVersion string) SARIFLog {
+	driver := sarifDriver{Name: "nexlayer lint", Version: toolVersion, InformationURI: toolURI}
+	for _, rule := range Rules() {
+		r := sarifRule{ID: rule.ID, ShortDescription: sarifMessage{Text: rule.Description}}
+		r.DefaultConfiguration.Level = sarifLevel(rule.Severity)
+		driver.Rules = append(driver.Rules, r)
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, report := range reports {
+		for _, issue := range report.Issues {
+			text := issue.Message
+			if issue.Suggestion != "" {
+				text += ". " + issue.Suggestion
+			}
+			var loc sarifLocation
+			loc.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(report.File)
+			if issue.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{StartLine: issue.Line}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    issue.RuleID,
+				Level:     sarifLevel(issue.Severity),
+				Message:   sarifMessage{Text: text},
+				Locations: []sarifLocation{loc},
+			})
+		}
+	}
+	return SARIFLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// anthropicVersion is the version of the Messages API spoken
+const anthropicVersion = "2023-06-01"
+
+// anthropic streams messages from the Anthropic API
+type anthropic struct {
+	*base
+}
+
+// anthropicEvent is an event of a streamed message
+type anthropicEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Model string `json:"model"`
+		Usage struct {
+			InputTokens int `json:"input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+	Delta struct {
+		Text string `json:"text"`
+	} `json:"delta"`
+	Usage struct {
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Complete implements Provider
+func (p *anthropic) Complete(ctx context.Context, req Request, stream func(string)) (*Response, error) {
+	req, err := p.prepare(req)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	body := map[string]interface{}{
+		"model":      p.config.Model,
+		"max_tokens": p.config.MaxOutputTokens,
+		"messages":   []chatMessage{{Role: "user", Content: req.Prompt}},
+		"stream":     true,
+	}
+	if req.System != "" {
+		body["system"] = req.System
+	}
+	r, err := p.post(ctx, p.config.BaseURL+"/v1/messages", map[string]string{
+		"x-api-key":         p.config.APIKey,
+		"anthropic-version": anthropicVersion,
+	}, body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	resp := &Response{Model: p.config.Model}
+	var text strings.Builder
+	err = readEvents(r, func(data []byte) (bool, error) {
+		var ev anthropicEvent
+		if err := json.Unmarshal(data, &ev); err != nil {
+			return false, fmt.Errorf("invalid event from %s: %w", p.Name(), err)
+		}
+		switch ev.Type {
+		case "message_start":
+			resp.Model = firstNonEmpty(ev.Message.Model, resp.Model)
+			resp.InputTokens = ev.Message.Usage.InputTokens
+		case "content_block_delta":
+			text.WriteString(ev.Delta.Text)
+			if stream != nil && ev.Delta.Text != "" {
+				stream(ev.Delta.Text)
+			}
+		case "message_delta":
+			resp.OutputTokens = ev.Usage.OutputTokens
+		case "message_stop":
+			return false, nil
+		case "error":
+			return false, fmt.Errorf("%s failed: %s", p.Name(), ev.Error.Message)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Text = text.String()
+	p.record(req, resp)
+	return resp, nil
+}
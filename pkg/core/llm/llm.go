@@ -0,0 +1,278 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package llm sends prompts to large language models: OpenAI, Anthropic,
+// Azure OpenAI or a local Ollama server, selected in a profile of
+// ~/.nexlayer/config.yaml. Responses are streamed, and prompts and responses
+// are kept within token budgets.
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+)
+
+// LLM providers
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderOllama    = "ollama" // or any local server speaking its API
+	ProviderAzure     = "azure"  // Azure OpenAI
+)
+
+// Environment variables overriding the configuration
+const (
+	EnvProvider = "NEXLAYER_LLM_PROVIDER"
+	EnvModel    = "NEXLAYER_LLM_MODEL"
+)
+
+// Default budgets, in tokens
+const (
+	DefaultMaxInputTokens  = 8000
+	DefaultMaxOutputTokens = 1024
+)
+
+// ErrNotConfigured is returned by NewProvider when no provider is selected
+var ErrNotConfigured = errors.New("no LLM provider configured")
+
+// ErrBudgetExceeded is returned once the requests of a provider used up its
+// budget
+var ErrBudgetExceeded = errors.New("LLM token budget exceeded")
+
+// requestTimeout bounds a request, streaming included
+const requestTimeout = 2 * time.Minute
+
+// Config selects an LLM provider, its model and credentials, as kept in a
+// profile of ~/.nexlayer/config.yaml. Credentials left out are read from the
+// environment variables the provider's own tools use.
+type Config struct {
+	Provider string `yaml:"provider" json:"provider"`
+	Model    string `yaml:"model,omitempty" json:"model,omitempty"`
+
+	// API key (OPENAI_API_KEY, ANTHROPIC_API_KEY or AZURE_OPENAI_API_KEY);
+	// Ollama needs none
+	APIKey string `yaml:"apiKey,omitempty" json:"-"`
+
+	// Base URL of the API, for proxies and compatible servers; the Azure
+	// OpenAI resource endpoint (AZURE_OPENAI_ENDPOINT), or the Ollama server
+	// (OLLAMA_HOST, default http://localhost:11434)
+	BaseURL string `yaml:"baseURL,omitempty" json:"baseURL,omitempty"`
+
+	// Azure OpenAI deployment, which defaults to the model, and API version
+	Deployment string `yaml:"deployment,omitempty" json:"deployment,omitempty"`
+	APIVersion string `yaml:"apiVersion,omitempty" json:"apiVersion,omitempty"`
+
+	// Budgets, in tokens: prompts are cut to MaxInputTokens, responses to
+	// MaxOutputTokens, and requests stop once a command used Budget, if set
+	MaxInputTokens  int `yaml:"maxInputTokens,omitempty" json:"maxInputTokens,omitempty"`
+	MaxOutputTokens int `yaml:"maxOutputTokens,omitempty" json:"maxOutputTokens,omitempty"`
+	Budget          int `yaml:"budget,omitempty" json:"budget,omitempty"`
+}
+
+// Request is a prompt for a model
+type Request struct {
+	System string // instructions, kept whole
+	Prompt string // cut to the input budget
+}
+
+// Response is the answer of a model
+type Response struct {
+	Text         string
+	Model        string
+	InputTokens  int
+	OutputTokens int
+}
+
+// Provider sends prompts to a model
+type Provider interface {
+	// Name returns the name of the provider and model, for messages
+	Name() string
+
+	// Complete sends a request, calling stream, when not nil, with each part
+	// of the answer as it arrives
+	Complete(ctx context.Context, req Request, stream func(text string)) (*Response, error)
+}
+
+// NewProvider returns the provider a configuration selects, or
+// ErrNotConfigured. NEXLAYER_LLM_PROVIDER and NEXLAYER_LLM_MODEL override the
+// configuration.
+func NewProvider(c Config) (Provider, error) {
+	if v := os.Getenv(EnvProvider); v != "" {
+		c.Provider = v
+	}
+	if v := os.Getenv(EnvModel); v != "" {
+		c.Model = v
+	}
+	if c.MaxInputTokens <= 0 {
+		c.MaxInputTokens = DefaultMaxInputTokens
+	}
+	if c.MaxOutputTokens <= 0 {
+		c.MaxOutputTokens = DefaultMaxOutputTokens
+	}
+
+	b := &base{config: c, http: &http.Client{Timeout: requestTimeout, Transport: &offline.Transport{}}}
+	switch strings.ToLower(c.Provider) {
+	case ProviderOpenAI:
+		b.config.APIKey = firstNonEmpty(c.APIKey, os.Getenv("OPENAI_API_KEY"))
+		if b.config.APIKey == "" {
+			return nil, fmt.Errorf("no OpenAI API key; set llm.apiKey in the profile or OPENAI_API_KEY")
+		}
+		b.config.Model = firstNonEmpty(c.Model, "gpt-4o-mini")
+		b.config.BaseURL = strings.TrimSuffix(firstNonEmpty(c.BaseURL, "https://api.openai.com/v1"), "/")
+		return &openAI{base: b}, nil
+	case ProviderAzure, "azure-openai":
+		b.config.APIKey = firstNonEmpty(c.APIKey, os.Getenv("AZURE_OPENAI_API_KEY"))
+		b.config.BaseURL = strings.TrimSuffix(firstNonEmpty(c.BaseURL, os.Getenv("AZURE_OPENAI_ENDPOINT")), "/")
+		if b.config.APIKey == "" || b.config.BaseURL == "" {
+			return nil, fmt.Errorf("no Azure OpenAI credentials; set llm.apiKey and llm.baseURL in the profile or AZURE_OPENAI_API_KEY and AZURE_OPENAI_ENDPOINT")
+		}
+		b.config.Deployment = firstNonEmpty(c.Deployment, c.Model)
+		if b.config.Deployment == "" {
+			return nil, fmt.Errorf("no Azure OpenAI deployment; set llm.deployment in the profile")
+		}
+		b.config.Model = b.config.Deployment
+		b.config.APIVersion = firstNonEmpty(c.APIVersion, "2024-10-21")
+		return &openAI{base: b, azure: true}, nil
+	case ProviderAnthropic:
+		b.config.APIKey = firstNonEmpty(c.APIKey, os.Getenv("ANTHROPIC_API_KEY"))
+		if b.config.APIKey == "" {
+			return nil, fmt.Errorf("no Anthropic API key; set llm.apiKey in the profile or ANTHROPIC_API_KEY")
+		}
+		b.config.Model = firstNonEmpty(c.Model, "claude-3-5-haiku-latest")
+		b.config.BaseURL = strings.TrimSuffix(firstNonEmpty(c.BaseURL, "https://api.anthropic.com"), "/")
+		return &anthropic{base: b}, nil
+	case ProviderOllama, "local":
+		b.config.Model = firstNonEmpty(c.Model, "llama3.1")
+		b.config.BaseURL = strings.TrimSuffix(firstNonEmpty(c.BaseURL, os.Getenv("OLLAMA_HOST"), "http://localhost:11434"), "/")
+		if !strings.Contains(b.config.BaseURL, "://") {
+			b.config.BaseURL = "http://" + b.config.BaseURL
+		}
+		// A server on this machine keeps working in offline mode
+		if isLoopback(b.config.BaseURL) {
+			b.http.Transport = http.DefaultTransport
+		}
+		return &ollama{base: b}, nil
+	case "":
+		return nil, ErrNotConfigured
+	}
+	return nil, fmt.Errorf("unknown LLM provider %q; use %s, %s, %s or %s", c.Provider, ProviderOpenAI, ProviderAnthropic, ProviderOllama, ProviderAzure)
+}
+
+var (
+	activeMu sync.Mutex
+	active   Config
+)
+
+// Configure sets the configuration Default uses, the one of the selected
+// profile
+func Configure(c Config) {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	active = c
+}
+
+// Default returns the provider of the selected profile, or ErrNotConfigured
+func Default() (Provider, error) {
+	activeMu.Lock()
+	c := active
+	activeMu.Unlock()
+	return NewProvider(c)
+}
+
+// KnownProvider reports whether NewProvider supports a provider
+func KnownProvider(name string) bool {
+	switch strings.ToLower(name) {
+	case ProviderOpenAI, ProviderAnthropic, ProviderOllama, ProviderAzure, "azure-openai", "local":
+		return true
+	}
+	return false
+}
+
+// EstimateTokens estimates the number of tokens of a text, about four
+// characters each for English and code
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// base holds what every provider shares: the configuration, the HTTP client
+// and the tokens used so far
+type base struct {
+	config Config
+	http   *http.Client
+
+	mu   sync.Mutex
+	used int
+}
+
+// Name implements Provider
+func (b *base) Name() string {
+	return strings.ToLower(b.config.Provider) + "/" + b.config.Model
+}
+
+// prepare checks the budget and cuts the prompt to the input budget, minus
+// the instructions
+func (b *base) prepare(req Request) (Request, error) {
+	b.mu.Lock()
+	used := b.used
+	b.mu.Unlock()
+	if b.config.Budget > 0 && used >= b.config.Budget {
+		return req, fmt.Errorf("%w: %d of %d tokens used", ErrBudgetExceeded, used, b.config.Budget)
+	}
+
+	limit := b.config.MaxInputTokens - EstimateTokens(req.System)
+	if b.config.Budget > 0 {
+		limit = min(limit, b.config.Budget-used-b.config.MaxOutputTokens)
+	}
+	if limit <= 0 {
+		return req, fmt.Errorf("%w: no tokens left for the prompt", ErrBudgetExceeded)
+	}
+	if EstimateTokens(req.Prompt) > limit {
+		const marker = "\n[truncated to fit the token budget]"
+		req.Prompt = req.Prompt[:max(0, 4*limit-len(marker))] + marker
+	}
+	return req, nil
+}
+
+// record counts the tokens of a response against the budget, estimating
+// them when the provider did not report them
+func (b *base) record(req Request, resp *Response) {
+	if resp.InputTokens == 0 {
+		resp.InputTokens = EstimateTokens(req.System) + EstimateTokens(req.Prompt)
+	}
+	if resp.OutputTokens == 0 {
+		resp.OutputTokens = EstimateTokens(resp.Text)
+	}
+	b.mu.Lock()
+	b.used += resp.InputTokens + resp.OutputTokens
+	b.mu.Unlock()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// isLoopback reports whether a URL points at this machine
+func isLoopback(url string) bool {
+	host := strings.TrimPrefix(strings.TrimPrefix(url, "http://"), "https://")
+	host, _, _ = strings.Cut(host, "/")
+	if h, _, ok := strings.Cut(host, "]"); ok {
+		host = strings.TrimPrefix(h, "[")
+	} else {
+		host, _, _ = strings.Cut(host, ":")
+	}
+	return host == "localhost" || host == "::1" || strings.HasPrefix(host, "127.")
+}
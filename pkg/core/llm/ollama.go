@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package llm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ollama streams chats from an Ollama server, which answers with a JSON
+// object per line
+type ollama struct {
+	*base
+}
+
+// ollamaChunk is a line of a streamed chat
+type ollamaChunk struct {
+	Model   string `json:"model"`
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done            bool   `json:"done"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+	Error           string `json:"error"`
+}
+
+// Complete implements Provider
+func (p *ollama) Complete(ctx context.Context, req Request, stream func(string)) (*Response, error) {
+	req, err := p.prepare(req)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var messages []chatMessage
+	if req.System != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: req.System})
+	}
+	messages = append(messages, chatMessage{Role: "user", Content: req.Prompt})
+	r, err := p.post(ctx, p.config.BaseURL+"/api/chat", nil, map[string]interface{}{
+		"model":    p.config.Model,
+		"messages": messages,
+		"stream":   true,
+		"options": map[string]int{
+			"num_predict": p.config.MaxOutputTokens,
+			"num_ctx":     p.config.MaxInputTokens + p.config.MaxOutputTokens,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	resp := &Response{Model: p.config.Model}
+	var text strings.Builder
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var chunk ollamaChunk
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			return nil, fmt.Errorf("invalid response from %s: %w", p.Name(), err)
+		}
+		if chunk.Error != "" {
+			return nil, fmt.Errorf("%s failed: %s", p.Name(), chunk.Error)
+		}
+		text.WriteString(chunk.Message.Content)
+		if stream != nil && chunk.Message.Content != "" {
+			stream(chunk.Message.Content)
+		}
+		if chunk.Done {
+			resp.Model = firstNonEmpty(chunk.Model, resp.Model)
+			resp.InputTokens, resp.OutputTokens = chunk.PromptEvalCount, chunk.EvalCount
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", p.Name(), err)
+	}
+	resp.Text = text.String()
+	p.record(req, resp)
+	return resp, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// openAI streams chat completions from OpenAI, or from an Azure OpenAI
+// deployment, which speaks the same API
+type openAI struct {
+	*base
+	azure bool
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatChunk is an event of a streamed chat completion
+type chatChunk struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+// Complete implements Provider
+func (p *openAI) Complete(ctx context.Context, req Request, stream func(string)) (*Response, error) {
+	req, err := p.prepare(req)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var messages []chatMessage
+	if req.System != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: req.System})
+	}
+	messages = append(messages, chatMessage{Role: "user", Content: req.Prompt})
+	body := map[string]interface{}{
+		"messages":       messages,
+		"max_tokens":     p.config.MaxOutputTokens,
+		"stream":         true,
+		"stream_options": map[string]bool{"include_usage": true},
+	}
+
+	endpoint := p.config.BaseURL + "/chat/completions"
+	headers := map[string]string{"Authorization": "Bearer " + p.config.APIKey}
+	if p.azure {
+		endpoint = fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+			p.config.BaseURL, url.PathEscape(p.config.Deployment), url.QueryEscape(p.config.APIVersion))
+		headers = map[string]string{"api-key": p.config.APIKey}
+	} else {
+		body["model"] = p.config.Model
+	}
+
+	r, err := p.post(ctx, endpoint, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	resp := &Response{Model: p.config.Model}
+	var text strings.Builder
+	err = readEvents(r, func(data []byte) (bool, error) {
+		if string(data) == "[DONE]" {
+			return false, nil
+		}
+		var chunk chatChunk
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return false, fmt.Errorf("invalid event from %s: %w", p.Name(), err)
+		}
+		if chunk.Model != "" {
+			resp.Model = chunk.Model
+		}
+		for _, c := range chunk.Choices {
+			text.WriteString(c.Delta.Content)
+			if stream != nil && c.Delta.Content != "" {
+				stream(c.Delta.Content)
+			}
+		}
+		if chunk.Usage != nil {
+			resp.InputTokens, resp.OutputTokens = chunk.Usage.PromptTokens, chunk.Usage.CompletionTokens
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Text = text.String()
+	p.record(req, resp)
+	return resp, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// post sends a JSON request and returns the response body, which the caller
+// closes, or the API's error
+func (b *base) post(ctx context.Context, url string, headers map[string]string, body interface{}) (io.ReadCloser, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := b.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", b.Name(), err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("%s returned status %d: %s", b.Name(), resp.StatusCode, apiError(msg))
+	}
+	return resp.Body, nil
+}
+
+// apiError extracts the message of an error response
+func apiError(body []byte) string {
+	var e struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(body, &e) == nil && len(e.Error) > 0 {
+		var detail struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(e.Error, &detail) == nil && detail.Message != "" {
+			return detail.Message
+		}
+		var s string
+		if json.Unmarshal(e.Error, &s) == nil && s != "" {
+			return s
+		}
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// readEvents calls handle with the data of each server-sent event until the
+// stream ends or handle returns false
+func readEvents(r io.Reader, handle func(data []byte) (bool, error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		more, err := handle([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))))
+		if err != nil || !more {
+			return err
+		}
+	}
+	return scanner.Err()
+}
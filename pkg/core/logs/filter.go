@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+)
+
+// Filter selects log lines by pod, time and message. Lines a server sends
+// without a pod or time are not filtered by them.
+type Filter struct {
+	Pod   string
+	Since time.Time
+	Grep  *regexp.Regexp
+}
+
+// Match reports whether line passes the filter
+func (f Filter) Match(line apischema.LogLine) bool {
+	if f.Pod != "" && line.Pod != "" && line.Pod != f.Pod {
+		return false
+	}
+	if !f.Since.IsZero() && !line.Timestamp.IsZero() && line.Timestamp.Before(f.Since) {
+		return false
+	}
+	return f.Grep == nil || f.Grep.MatchString(line.Message)
+}
+
+// ParseSince parses how far back lines are shown: a duration counted back
+// from now, such as 10m, 2h or 1d, or an RFC 3339 time
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if n, ok := strings.CutSuffix(s, "d"); ok {
+		if d, err := time.ParseDuration(n + "h"); err == nil && d > 0 {
+			return now.Add(-24 * d), nil
+		}
+	} else if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q; use a duration such as 10m, 2h or 1d, or a time such as 2025-01-02T15:04:05Z", s)
+}
+
+// ParseLine turns a line returned by GetLogs into a log line: a LogLine as
+// JSON, like those streamed, or the bare line
+func ParseLine(raw string) apischema.LogLine {
+	line := apischema.LogLine{Message: raw}
+	if strings.HasPrefix(raw, "{") {
+		if err := json.Unmarshal([]byte(raw), &line); err != nil || line.Message == "" {
+			line = apischema.LogLine{Message: raw}
+		}
+	}
+	return line
+}
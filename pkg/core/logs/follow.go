@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package logs follows the logs of a deployment, reconnecting when the
+// stream drops, and filters them.
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+)
+
+// Backoff between reconnections, doubling from MinBackoff up to MaxBackoff
+const (
+	MinBackoff = time.Second
+	MaxBackoff = 30 * time.Second
+)
+
+// MaxAttempts is how many reconnections in a row may fail to receive a line
+// before Follow gives up
+const MaxAttempts = 10
+
+// Follow streams the logs of a deployment to onLine until ctx is done. When
+// the stream drops or the platform closes it, onReconnect is told and the
+// stream is reopened after a backoff, resuming after the last line received
+// so no line is repeated or lost. Against a server that does not stream, the
+// lines it has are passed on and api.ErrNotStreaming returned.
+func Follow(ctx context.Context, client api.APIClient, namespace, appID string, tail int, onLine func(apischema.LogLine), onReconnect func(err error, wait time.Duration)) error {
+	var lastID string
+	backoff := MinBackoff
+	attempts := 0
+	for {
+		received := false
+		err := client.StreamLogs(ctx, namespace, appID, tail, lastID, func(line apischema.LogLine) {
+			received = true
+			if line.ID != "" {
+				lastID = line.ID
+			}
+			onLine(line)
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		if errors.Is(err, api.ErrNotStreaming) {
+			return err
+		}
+		if received {
+			backoff, attempts = MinBackoff, 0
+		}
+		attempts++
+		if attempts > MaxAttempts {
+			return fmt.Errorf("gave up following logs after %d attempts: %w", MaxAttempts, err)
+		}
+		if lastID == "" && received {
+			// Without ids the stream cannot resume; skip the lines already shown
+			tail = 0
+		}
+
+		if onReconnect != nil {
+			onReconnect(err, backoff)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > MaxBackoff {
+			backoff = MaxBackoff
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package migrate runs the migrations declared in a nexlayer.yaml on the
+// platform and waits for them to finish.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Migration statuses reported by the platform
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped" // the release already succeeded
+)
+
+// LogDir holds the logs of migration runs started from this directory
+var LogDir = filepath.Join(".nexlayer", "migrations")
+
+// DefaultTimeout bounds how long a run is waited for
+const DefaultTimeout = 10 * time.Minute
+
+// pollInterval is how often the status of a run is checked
+const pollInterval = 3 * time.Second
+
+// tailLines is how much of the log of a failed run is printed
+const tailLines = 20
+
+// NewRequest builds the request running the migrations of config. Vars of
+// the migrations pod, with envFrom imports resolved, are passed along.
+func NewRequest(config *schema.NexlayerYAML) (apischema.MigrationRequest, error) {
+	m := config.Application.Migrations
+	if m == nil {
+		return apischema.MigrationRequest{}, fmt.Errorf("no migrations in application %s", config.Application.Name)
+	}
+	req := apischema.MigrationRequest{
+		Release: schema.MigrationRelease(config),
+		Pod:     m.Pod,
+		Image:   schema.MigrationImage(config),
+		Command: m.Command,
+	}
+	if req.Image == "" {
+		return req, fmt.Errorf("migrations need an image or the name of a pod to take it from")
+	}
+	for _, pod := range config.Application.Pods {
+		if pod.Name == m.Pod {
+			for _, v := range pod.Vars {
+				req.Vars = append(req.Vars, apischema.EnvVar{Key: v.Key, Value: v.Value})
+			}
+		}
+	}
+	return req, nil
+}
+
+// Finished reports whether a status is final
+func Finished(status string) bool {
+	switch strings.ToLower(status) {
+	case StatusSucceeded, StatusFailed, StatusSkipped:
+		return true
+	}
+	return false
+}
+
+// Run starts the migrations and polls every interval until they finish or
+// ctx is done. A failed run is returned together with an error.
+func Run(ctx context.Context, client api.APIClient, app string, req apischema.MigrationRequest, interval time.Duration) (*apischema.Migration, error) {
+	resp, err := client.RunMigration(ctx, app, req)
+	if err != nil {
+		return nil, err
+	}
+	m := resp.Data
+	for !Finished(m.Status) {
+		select {
+		case <-ctx.Done():
+			return &m, fmt.Errorf("migration %s still %s: %w", m.ID, m.Status, ctx.Err())
+		case <-time.After(interval):
+		}
+		resp, err := client.GetMigration(ctx, app, m.ID)
+		if err != nil {
+			return &m, err
+		}
+		m = resp.Data
+	}
+	if strings.EqualFold(m.Status, StatusFailed) {
+		return &m, fmt.Errorf("migration %s failed with exit code %d", m.ID, m.ExitCode)
+	}
+	return &m, nil
+}
+
+// SaveLogs writes the logs of a run under LogDir and returns the file
+func SaveLogs(m *apischema.Migration) (string, error) {
+	if err := os.MkdirAll(LogDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", LogDir, err)
+	}
+	file := filepath.Join(LogDir, fmt.Sprintf("%s-%s.log", m.Release, m.ID))
+	data := strings.Join(m.Logs, "\n")
+	if data != "" {
+		data += "\n"
+	}
+	if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return file, nil
+}
+
+// PrintTail prints the end of the logs of a run
+func PrintTail(out io.Writer, m *apischema.Migration) {
+	logs := m.Logs
+	if len(logs) > tailLines {
+		logs = logs[len(logs)-tailLines:]
+	}
+	for _, line := range logs {
+		fmt.Fprintf(out, "  | %s\n", line)
+	}
+}
+
+// Apply runs the migrations of config on the platform, waits for them and
+// reports progress to out. The logs are saved under LogDir and their tail is
+// printed when the run fails; force reruns a release that already succeeded.
+func Apply(ctx context.Context, client api.APIClient, config *schema.NexlayerYAML, force bool, out io.Writer) (*apischema.Migration, error) {
+	req, err := NewRequest(config)
+	if err != nil {
+		return nil, err
+	}
+	req.Force = force
+
+	fmt.Fprintf(out, "Running migrations %s (release %s)\n", schema.Command(req.Command), req.Release)
+	m, runErr := Run(ctx, client, config.Application.Name, req, pollInterval)
+	if m == nil {
+		return nil, runErr
+	}
+
+	logFile, err := SaveLogs(m)
+	if err != nil {
+		fmt.Fprintf(out, "Could not save the migration logs: %v\n", err)
+	}
+	switch {
+	case runErr != nil:
+		PrintTail(out, m)
+	case strings.EqualFold(m.Status, StatusSkipped):
+		fmt.Fprintf(out, "Migrations of release %s already applied; run 'nexlayer migrate --force' to run them again\n", m.Release)
+	default:
+		fmt.Fprintf(out, "Migrations succeeded in %s\n", m.FinishedAt.Sub(m.StartedAt).Round(time.Second))
+	}
+	if logFile != "" && len(m.Logs) > 0 {
+		fmt.Fprintf(out, "Logs: %s\n", logFile)
+	}
+	return m, runErr
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package minisign verifies detached minisign signatures, as made by
+// minisign -S, with legacy or prehashed (BLAKE2b-512) signatures.
+package minisign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ErrOtherKey is returned by Verify for a signature made with another key
+var ErrOtherKey = errors.New("signed with another key")
+
+// ParseKey decodes a minisign public key, the .pub file or its base64 line
+func ParseKey(text string) (id []byte, pub ed25519.PublicKey, err error) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil || len(raw) != 42 || string(raw[:2]) != "Ed" {
+		return nil, nil, fmt.Errorf("not a minisign public key or a PEM cosign public key")
+	}
+	return raw[2:10], ed25519.PublicKey(raw[10:]), nil
+}
+
+// KeyID formats a key ID the way minisign prints it
+func KeyID(id []byte) string {
+	var b strings.Builder
+	for i := len(id) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "%02X", id[i])
+	}
+	return b.String()
+}
+
+// Verify checks a minisign signature file: the signature of the data, or of
+// its BLAKE2b-512 hash for prehashed signatures, and the global signature
+// covering the trusted comment
+func Verify(publicKey string, data, sigFile []byte) error {
+	id, pub, err := ParseKey(publicKey)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(string(sigFile), "\r\n", "\n")), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 74 {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return fmt.Errorf("malformed minisign signature")
+	}
+	if !bytes.Equal(sig[2:10], id) {
+		return ErrOtherKey
+	}
+
+	message := data
+	switch string(sig[:2]) {
+	case "Ed":
+	case "ED":
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	default:
+		return fmt.Errorf("unsupported minisign algorithm %q", sig[:2])
+	}
+	if !ed25519.Verify(pub, message, sig[10:]) {
+		return fmt.Errorf("invalid signature")
+	}
+	comment := strings.TrimPrefix(lines[2], "trusted comment: ")
+	if !ed25519.Verify(pub, append(append([]byte{}, sig[10:]...), comment...), global) {
+		return fmt.Errorf("invalid signature of the trusted comment")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package minisign
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// sign writes a minisign public key and signature file of data the way
+// minisign -S does, prehashed when alg is "ED"
+func sign(t *testing.T, id []byte, alg string, data []byte) (string, []byte) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := data
+	if alg == "ED" {
+		sum := blake2b.Sum512(data)
+		message = sum[:]
+	}
+	sig := append(append([]byte(alg), id...), ed25519.Sign(priv, message)...)
+	comment := "timestamp:1700000000"
+	global := ed25519.Sign(priv, append(append([]byte{}, sig[10:]...), comment...))
+	key := "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), id...), pub...))
+	file := "untrusted comment: signature\n" + base64.StdEncoding.EncodeToString(sig) +
+		"\ntrusted comment: " + comment + "\n" + base64.StdEncoding.EncodeToString(global) + "\n"
+	return key, []byte(file)
+}
+
+func TestVerify(t *testing.T) {
+	id := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	data := []byte("abc  nexlayer_linux_amd64\n")
+	for _, alg := range []string{"Ed", "ED"} {
+		key, sig := sign(t, id, alg, data)
+		if err := Verify(key, data, sig); err != nil {
+			t.Errorf("%s: %v", alg, err)
+		}
+		if err := Verify(key, []byte("tampered"), sig); err == nil {
+			t.Errorf("%s: tampered data verified", alg)
+		}
+	}
+
+	key, _ := sign(t, id, "ED", data)
+	_, other := sign(t, []byte{8, 7, 6, 5, 4, 3, 2, 1}, "ED", data)
+	if err := Verify(key, data, other); !errors.Is(err, ErrOtherKey) {
+		t.Errorf("signature of another key: got %v, want ErrOtherKey", err)
+	}
+	if got := KeyID(id); got != "0807060504030201" {
+		t.Errorf("KeyID = %s", got)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Notifier types
+const (
+	TypeWebhook = "webhook" // POSTs the message as JSON
+	TypeSlack   = "slack"   // POSTs the message to a Slack incoming webhook
+)
+
+// NotifierConfig configures a notifier
+type NotifierConfig struct {
+	Type    string            `yaml:"type"`
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// Message is an event together with the rule that matched it
+type Message struct {
+	Rule  string `json:"rule"`
+	Event Event  `json:"event"`
+}
+
+// Text renders a message for chat
+func (m Message) Text() string {
+	return fmt.Sprintf("[%s] %s: %s", m.Rule, m.Event.App, m.Event.Message)
+}
+
+// Notifier delivers messages
+type Notifier interface {
+	Notify(ctx context.Context, m Message) error
+}
+
+// httpClient sends notifications
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// newNotifier creates the notifier for a configuration
+func newNotifier(c NotifierConfig) (Notifier, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid url %q", c.URL)
+	}
+	switch strings.ToLower(c.Type) {
+	case TypeWebhook, "":
+		return &webhook{url: c.URL, headers: c.Headers}, nil
+	case TypeSlack:
+		return &slack{url: c.URL}, nil
+	}
+	return nil, fmt.Errorf("unknown notifier type %q; use %s or %s", c.Type, TypeWebhook, TypeSlack)
+}
+
+// webhook POSTs messages as JSON
+type webhook struct {
+	url     string
+	headers map[string]string
+}
+
+func (w *webhook) Notify(ctx context.Context, m Message) error {
+	return post(ctx, w.url, w.headers, m)
+}
+
+// slack POSTs messages to a Slack incoming webhook
+type slack struct {
+	url string
+}
+
+func (s *slack) Notify(ctx context.Context, m Message) error {
+	return post(ctx, s.url, nil, map[string]string{"text": m.Text()})
+}
+
+// post sends body as JSON and fails on non-2xx responses
+func post(ctx context.Context, url string, headers map[string]string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
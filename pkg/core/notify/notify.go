@@ -0,0 +1,265 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package notify routes deployment events, such as failures, expiring
+// certificates and quota usage, to the notifiers named by the rules in the
+// CLI configuration:
+//
+//	notifications:
+//	  notifiers:
+//	    ops: {type: slack, url: https://hooks.slack.com/services/...}
+//	    pager: {type: webhook, url: https://example.com/hook, headers: {Authorization: Bearer ...}}
+//	  rules:
+//	    - on: failure
+//	      notify: [ops, pager]
+//	    - on: cert-expiry
+//	      within: 14d
+//	      notify: [ops]
+//	    - on: quota
+//	      above: 80
+//	      notify: [ops]
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigKey is the key of the notification settings in the CLI configuration
+const ConfigKey = "notifications"
+
+// Event kinds rules can match
+const (
+	EventFailure    = "failure"     // a deployment failed
+	EventCertExpiry = "cert-expiry" // DaysLeft until a TLS certificate expires
+	EventQuota      = "quota"       // Percent of a plan limit in use
+)
+
+// EventKinds lists the kinds accepted in rules
+var EventKinds = []string{EventFailure, EventCertExpiry, EventQuota}
+
+// Config holds the notifiers and the rules routing events to them
+type Config struct {
+	Notifiers map[string]NotifierConfig `yaml:"notifiers"`
+	Rules     []Rule                    `yaml:"rules"`
+}
+
+// Rule sends events of kind On to the notifiers in Notify. Within limits
+// cert-expiry events to certificates expiring that soon, e.g. 14d; Above
+// limits quota events to usage above that percent. Apps, when set, limits
+// the rule to those applications.
+type Rule struct {
+	Name   string   `yaml:"name,omitempty"`
+	On     string   `yaml:"on"`
+	Within string   `yaml:"within,omitempty"`
+	Above  float64  `yaml:"above,omitempty"`
+	Apps   []string `yaml:"apps,omitempty"`
+	Notify []string `yaml:"notify"`
+}
+
+// Event is something that happened to an application
+type Event struct {
+	Kind     string    `json:"kind"`
+	App      string    `json:"app"`
+	Message  string    `json:"message"`
+	Subject  string    `json:"subject,omitempty"` // domain or quota resource
+	DaysLeft float64   `json:"daysLeft,omitempty"`
+	Percent  float64   `json:"percent,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// Load reads the notification settings from the CLI configuration. It
+// returns an empty configuration when there are none.
+func Load() (*Config, error) {
+	cfg := &Config{}
+	raw := config.GetConfigProvider().Get(ConfigKey)
+	if raw == nil {
+		return cfg, nil
+	}
+	data, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s settings: %w", ConfigKey, err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s settings: %w", ConfigKey, err)
+	}
+	// The configuration lower-cases keys, and with them notifier names
+	for i := range cfg.Rules {
+		for j, name := range cfg.Rules[i].Notify {
+			cfg.Rules[i].Notify[j] = strings.ToLower(name)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks the notifiers and that rules match known events and name
+// existing notifiers
+func (c *Config) Validate() error {
+	var errs []error
+	for name, n := range c.Notifiers {
+		if _, err := newNotifier(n); err != nil {
+			errs = append(errs, fmt.Errorf("notifiers.%s: %w", name, err))
+		}
+	}
+	for i, r := range c.Rules {
+		field := fmt.Sprintf("rules[%d]", i)
+		known := false
+		for _, k := range EventKinds {
+			known = known || r.On == k
+		}
+		if !known {
+			errs = append(errs, fmt.Errorf("%s: unknown event %q; use one of %s", field, r.On, strings.Join(EventKinds, ", ")))
+		}
+		if r.Within != "" {
+			if _, err := parseDays(r.Within); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", field, err))
+			}
+		}
+		if len(r.Notify) == 0 {
+			errs = append(errs, fmt.Errorf("%s: notify names no notifier", field))
+		}
+		for _, name := range r.Notify {
+			if _, ok := c.Notifiers[name]; !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown notifier %q", field, name))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid %s settings: %w", ConfigKey, errors.Join(errs...))
+	}
+	return nil
+}
+
+// matches reports whether an event satisfies the rule
+func (r Rule) matches(ev Event) bool {
+	if r.On != ev.Kind {
+		return false
+	}
+	if len(r.Apps) > 0 {
+		found := false
+		for _, app := range r.Apps {
+			found = found || app == ev.App
+		}
+		if !found {
+			return false
+		}
+	}
+	switch ev.Kind {
+	case EventCertExpiry:
+		within := 14.0
+		if r.Within != "" {
+			within, _ = parseDays(r.Within)
+		}
+		return ev.DaysLeft <= within
+	case EventQuota:
+		above := 80.0
+		if r.Above > 0 {
+			above = r.Above
+		}
+		return ev.Percent >= above
+	}
+	return true
+}
+
+// Engine evaluates events against the rules and sends them on. An event
+// that keeps matching a rule, such as a certificate that stays close to
+// expiry, is only sent again once it stopped matching in between.
+type Engine struct {
+	rules     []Rule
+	notifiers map[string]Notifier
+
+	mu    sync.Mutex
+	fired map[string]bool
+}
+
+// NewEngine creates an engine from validated settings
+func NewEngine(cfg *Config) (*Engine, error) {
+	e := &Engine{rules: cfg.Rules, notifiers: make(map[string]Notifier), fired: make(map[string]bool)}
+	for name, nc := range cfg.Notifiers {
+		n, err := newNotifier(nc)
+		if err != nil {
+			return nil, fmt.Errorf("notifiers.%s: %w", name, err)
+		}
+		e.notifiers[name] = n
+	}
+	return e, nil
+}
+
+// Empty reports whether the engine has no rules, so that events need not be
+// collected
+func (e *Engine) Empty() bool {
+	return len(e.rules) == 0
+}
+
+// Evaluate sends ev to the notifiers of every rule it matches and reports
+// which notifiers were used. Each notifier gets the event at most once.
+func (e *Engine) Evaluate(ctx context.Context, ev Event) ([]string, error) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+
+	e.mu.Lock()
+	targets := make(map[string]string) // notifier -> rule
+	var order []string
+	for i, r := range e.rules {
+		key := fmt.Sprintf("%d/%s/%s/%s", i, ev.Kind, ev.App, ev.Subject)
+		if !r.matches(ev) {
+			delete(e.fired, key)
+			continue
+		}
+		if e.fired[key] {
+			continue
+		}
+		e.fired[key] = true
+		name := r.Name
+		if name == "" {
+			name = r.On
+		}
+		for _, n := range r.Notify {
+			if _, ok := targets[n]; !ok {
+				targets[n] = name
+				order = append(order, n)
+			}
+		}
+	}
+	e.mu.Unlock()
+
+	var errs []error
+	for _, n := range order {
+		if err := e.notifiers[n].Notify(ctx, Message{Rule: targets[n], Event: ev}); err != nil {
+			errs = append(errs, fmt.Errorf("notifier %s: %w", n, err))
+		}
+	}
+	return order, errors.Join(errs...)
+}
+
+// parseDays parses a period such as 14d, 2w or 72h into days
+func parseDays(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	for suffix, days := range map[string]float64{"d": 1, "w": 7} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.ParseFloat(n, 64)
+			if err != nil || v <= 0 {
+				return 0, fmt.Errorf("invalid period %q; use e.g. 14d, 2w or 72h", s)
+			}
+			return v * days, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid period %q; use e.g. 14d, 2w or 72h", s)
+	}
+	return d.Hours() / 24, nil
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package offline implements offline mode, for machines without internet
+// access. Validation, templates from local registries, cost estimates from
+// the cached or built-in pricing table and the local AI features keep
+// working; requests to the Nexlayer API, template catalogs, the pricing
+// endpoint and the release feed fail at once instead of timing out.
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// EnvVar turns offline mode on when set to 1 or true
+const EnvVar = "NEXLAYER_OFFLINE"
+
+// ErrOffline is wrapped by the errors of requests refused in offline mode
+var ErrOffline = errors.New("offline mode is on")
+
+var enabled atomic.Bool
+
+// Enable turns offline mode on for the rest of the process
+func Enable() {
+	enabled.Store(true)
+}
+
+// Enabled reports whether offline mode is on, by Enable or the environment
+func Enabled() bool {
+	if enabled.Load() {
+		return true
+	}
+	v := strings.ToLower(os.Getenv(EnvVar))
+	return v == "1" || v == "true"
+}
+
+// Check returns an error when offline mode is on, naming what needs the
+// network
+func Check(what string) error {
+	if !Enabled() {
+		return nil
+	}
+	return fmt.Errorf("%s needs network access: %w (unset %s or drop --offline)", what, ErrOffline, EnvVar)
+}
+
+// Transport refuses requests while offline mode is on and sends them with
+// Base, or http.DefaultTransport, otherwise
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := Check("request to " + req.URL.Host); err != nil {
+		return nil, err
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
@@ -0,0 +1,312 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package plan computes what deploying a configuration would change in the
+// live deployment: the pods added and removed, and the images, ports, env
+// vars and volumes that change in the pods that stay.
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/compare"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/vars"
+)
+
+// Kinds of change
+const (
+	Add    = "add"
+	Remove = "remove"
+	Update = "update"
+)
+
+// Change is a single difference between the live deployment and the
+// configuration. Pod is empty for application-wide fields such as the
+// domain; Field is "pod" when a whole pod is added or removed. From is the
+// live value and To the value after deploying.
+type Change struct {
+	Action string `json:"action"`
+	Pod    string `json:"pod,omitempty"`
+	Field  string `json:"field"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+}
+
+// String formats the change as a line of the plan
+func (c Change) String() string {
+	target := c.Field
+	if c.Pod != "" && c.Field != "pod" {
+		target = c.Pod + " " + c.Field
+	} else if c.Pod != "" {
+		target = "pod " + c.Pod
+	}
+	switch c.Action {
+	case Add:
+		return fmt.Sprintf("+ %s: %s", target, c.To)
+	case Remove:
+		return fmt.Sprintf("- %s: %s", target, c.From)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", target, c.From, c.To)
+	}
+}
+
+// state is the comparable state of a pod. A nil map is a part of the pod
+// whose live state is unknown, which is then not compared.
+type state struct {
+	image   string
+	ports   map[string]string
+	env     map[string]string
+	volumes map[string]string
+}
+
+// Compute returns the changes deploying config makes to the live deployment.
+// The API does not report volumes, nor vars for every pod, so those are taken
+// from last, the configuration deployed last, when known. An empty live
+// deployment, such as before the first deploy, adds every pod.
+func Compute(config *schema.NexlayerYAML, live apischema.Deployment, last *schema.NexlayerYAML) []Change {
+	var changes []Change
+	add := func(action, pod, field, from, to string) {
+		changes = append(changes, Change{Action: action, Pod: pod, Field: field, From: from, To: to})
+	}
+
+	from := liveState(live, last)
+	to := configState(config, live)
+	if len(live.PodStatuses) > 0 && config.Application.URL != live.CustomDomain {
+		add(action(live.CustomDomain, config.Application.URL), "", "domain", live.CustomDomain, config.Application.URL)
+	}
+
+	for _, name := range unionKeys(from, to) {
+		l, lok := from[name]
+		r, rok := to[name]
+		switch {
+		case !lok:
+			add(Add, name, "pod", "", r.image)
+			continue
+		case !rok:
+			add(Remove, name, "pod", l.image, "")
+			continue
+		}
+		if l.image != r.image {
+			add(Update, name, "image", orMissing(l.image), orMissing(r.image))
+		}
+		for _, part := range []struct {
+			prefix   string
+			from, to map[string]string
+			redact   bool
+		}{
+			{"port.", l.ports, r.ports, false},
+			{"env.", l.env, r.env, true},
+			{"volume.", l.volumes, r.volumes, false},
+		} {
+			if part.from == nil {
+				continue
+			}
+			for _, key := range unionKeys(part.from, part.to) {
+				lv, lok := part.from[key]
+				rv, rok := part.to[key]
+				if lok && rok && lv == rv {
+					continue
+				}
+				// The live value of a stored secret reference is resolved
+				if lok && rok && part.redact && schema.HasSecretRef(rv) {
+					continue
+				}
+				if part.redact && schema.IsSensitiveKey(key) {
+					lv, rv = redact(lv), redact(rv)
+				}
+				switch {
+				case !lok:
+					add(Add, name, part.prefix+key, "", rv)
+				case !rok:
+					add(Remove, name, part.prefix+key, lv, "")
+				default:
+					add(Update, name, part.prefix+key, lv, rv)
+				}
+			}
+		}
+	}
+	return changes
+}
+
+// liveState collects the state of the live pods; each status is one replica
+func liveState(live apischema.Deployment, last *schema.NexlayerYAML) map[string]*state {
+	known := make(map[string]schema.Pod)
+	if last != nil {
+		for _, p := range last.Application.Pods {
+			known[p.Name] = p
+		}
+	}
+
+	pods := make(map[string]*state)
+	for _, status := range live.PodStatuses {
+		if _, ok := pods[status.Name]; ok {
+			continue
+		}
+		s := &state{image: status.Image}
+		prev, isKnown := known[status.Name]
+		switch {
+		case len(status.Ports) > 0:
+			s.ports = make(map[string]string)
+			for _, p := range status.Ports {
+				port := p.ServicePort
+				if port == 0 {
+					port = p.ContainerPort
+				}
+				s.ports[orName(p.Name, status.Name)] = formatPort(port, p.ContainerPort, p.Protocol)
+			}
+		case isKnown:
+			s.ports = ports(prev)
+		}
+		switch {
+		case len(status.Vars) > 0:
+			s.env = make(map[string]string)
+			for _, v := range status.Vars {
+				s.env[v.Key] = v.Value
+			}
+		case isKnown:
+			s.env = env(prev)
+		}
+		if isKnown {
+			s.volumes = volumes(prev)
+		}
+		pods[status.Name] = s
+	}
+	return pods
+}
+
+// configState collects the state of the pods of config
+func configState(config *schema.NexlayerYAML, live apischema.Deployment) map[string]*state {
+	ctx := vars.NewVariableContext()
+	if login := config.Application.RegistryLogin; login != nil {
+		ctx.SetRegistry(login.Registry)
+	}
+	liveImages := make(map[string]string)
+	for _, status := range live.PodStatuses {
+		liveImages[status.Name] = status.Image
+	}
+
+	pods := make(map[string]*state)
+	for _, pod := range config.Application.Pods {
+		image, err := vars.SubstituteVariables(pod.Image, ctx)
+		if err != nil {
+			image = pod.Image
+		}
+		if pod.Static != nil {
+			// The platform picks the image serving a static site
+			image = liveImages[pod.Name]
+			if image == "" {
+				image = "static site from " + pod.Static.Dir
+			}
+		}
+		pods[pod.Name] = &state{image: image, ports: ports(pod), env: env(pod), volumes: volumes(pod)}
+	}
+	return pods
+}
+
+func ports(pod schema.Pod) map[string]string {
+	m := make(map[string]string)
+	for _, p := range pod.ServicePorts {
+		m[orName(p.Name, pod.Name)] = formatPort(p.Port, p.TargetPort, p.Protocol)
+	}
+	return m
+}
+
+func env(pod schema.Pod) map[string]string {
+	m := make(map[string]string)
+	for _, v := range pod.Vars {
+		m[v.Key] = v.Value
+	}
+	return m
+}
+
+func volumes(pod schema.Pod) map[string]string {
+	m := make(map[string]string)
+	for _, v := range pod.Volumes {
+		desc := v.Path
+		if v.Size != "" {
+			desc += " (" + v.Size + ")"
+		}
+		if v.ReadOnly {
+			desc += " read-only"
+		}
+		m[v.Name] = desc
+	}
+	return m
+}
+
+func formatPort(port, target int, protocol string) string {
+	s := fmt.Sprintf("%d", port)
+	if target != 0 && target != port {
+		s += fmt.Sprintf("->%d", target)
+	}
+	if p := schema.NormalizeProtocol(protocol); p != schema.ProtocolTCP {
+		s += "/" + p
+	}
+	return s
+}
+
+func action(from, to string) string {
+	switch {
+	case from == "":
+		return Add
+	case to == "":
+		return Remove
+	}
+	return Update
+}
+
+func redact(value string) string {
+	if value == "" {
+		return value
+	}
+	return schema.Redacted
+}
+
+func orName(name, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+func orMissing(s string) string {
+	if s == "" {
+		return compare.Missing
+	}
+	return s
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Summary counts the changes by kind, e.g. "1 to add, 2 to update"
+func Summary(changes []Change) string {
+	counts := make(map[string]int)
+	for _, c := range changes {
+		counts[c.Action]++
+	}
+	var parts []string
+	for _, a := range []string{Add, Update, Remove} {
+		if counts[a] > 0 {
+			parts = append(parts, fmt.Sprintf("%d to %s", counts[a], a))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
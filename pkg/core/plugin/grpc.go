@@ -0,0 +1,150 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	goplugin "github.com/hashicorp/go-plugin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+)
+
+// Methods of the Plugin service
+const (
+	methodRun  = "Run"
+	methodHook = "Hook"
+)
+
+// codecName is the content subtype of the calls to the Plugin service
+const codecName = "nexlayer-json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// jsonCodec encodes the messages of the Plugin service as JSON. The other
+// services go-plugin serves keep their protobuf encoding.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return codecName
+}
+
+// serviceDesc describes the Plugin service: Run and Hook each take a
+// request and stream back messages
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "nexlayer.plugin.v1.Plugin",
+	HandlerType: (*Handler)(nil),
+	Streams: []grpc.StreamDesc{
+		{StreamName: methodRun, Handler: serveRun, ServerStreams: true},
+		{StreamName: methodHook, Handler: serveHook, ServerStreams: true},
+	},
+}
+
+// grpcPlugin plugs the Plugin service into go-plugin: it serves handler in
+// the plugin and returns a *grpcClient in the CLI
+type grpcPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+	handler Handler
+}
+
+func (p *grpcPlugin) GRPCServer(_ *goplugin.GRPCBroker, s *grpc.Server) error {
+	s.RegisterService(&serviceDesc, p.handler)
+	return nil
+}
+
+func (p *grpcPlugin) GRPCClient(_ context.Context, _ *goplugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return &grpcClient{conn: conn}, nil
+}
+
+func serveRun(srv interface{}, stream grpc.ServerStream) error {
+	var req RunRequest
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+	return respond(stream, func(progress func(Progress)) (*Result, error) {
+		return srv.(Handler).Run(stream.Context(), &req, progress)
+	})
+}
+
+func serveHook(srv interface{}, stream grpc.ServerStream) error {
+	var req HookRequest
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+	return respond(stream, func(progress func(Progress)) (*Result, error) {
+		return srv.(Handler).Hook(stream.Context(), &req, progress)
+	})
+}
+
+// respond streams the progress of handle, then its result. An error of
+// handle becomes the status of the call.
+func respond(stream grpc.ServerStream, handle func(progress func(Progress)) (*Result, error)) error {
+	var mu sync.Mutex
+	send := func(m *message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.SendMsg(m)
+	}
+	result, err := handle(func(p Progress) { send(&message{Progress: &p}) })
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		result = &Result{}
+	}
+	return send(&message{Result: result})
+}
+
+// grpcClient calls the Plugin service of a running plugin
+type grpcClient struct {
+	conn *grpc.ClientConn
+}
+
+// call sends req to method and relays progress until the result
+func (c *grpcClient) call(ctx context.Context, method string, req interface{}, progress func(Progress)) (*Result, error) {
+	desc := &serviceDesc.Streams[0]
+	if method == methodHook {
+		desc = &serviceDesc.Streams[1]
+	}
+	stream, err := c.conn.NewStream(ctx, desc, "/"+serviceDesc.ServiceName+"/"+method, grpc.CallContentSubtype(codecName))
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	for {
+		var m message
+		err := stream.RecvMsg(&m)
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil, errors.New("plugin exited without a result")
+		case err != nil:
+			return nil, errors.New(status.Convert(err).Message())
+		case m.Result != nil:
+			return m.Result, nil
+		case m.Progress != nil && progress != nil:
+			progress(*m.Progress)
+		}
+	}
+}
@@ -0,0 +1,468 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/update"
+)
+
+// githubAPI is the base URL of the GitHub API, where the releases of plugins
+// hosted on GitHub are looked up
+var githubAPI = "https://api.github.com"
+
+// checksumsAsset is the release asset holding "<sha256>  <file>" lines
+const checksumsAsset = "checksums.txt"
+
+// errNotFound is returned by get for a 404 response
+var errNotFound = errors.New("not found")
+
+// Installer fetches plugins and installs them
+type Installer struct {
+	HTTPClient *http.Client
+
+	// Reserved reports whether a name is taken by a command of the CLI
+	Reserved func(name string) bool
+
+	// AllowUnsigned installs plugins no trusted key signed
+	AllowUnsigned bool
+}
+
+// artifact is what a source holds: an executable or an archive holding it,
+// with its detached signatures
+type artifact struct {
+	name       string
+	data       []byte
+	signatures []signature
+}
+
+// NewInstaller creates an installer refusing plugins named like a reserved
+// command
+func NewInstaller(reserved func(name string) bool) *Installer {
+	return &Installer{
+		HTTPClient: &http.Client{Timeout: 5 * time.Minute, Transport: &offline.Transport{}},
+		Reserved:   reserved,
+	}
+}
+
+// Install fetches a plugin from source and installs it, replacing any plugin
+// of the same name. The source is one of:
+//
+//	./nexlayer-foo                          an executable or archive on disk
+//	https://example.com/nexlayer-foo.tgz    an executable or archive to download
+//	github.com/org/nexlayer-plugin-foo@v1.2 a Go module; the binary of a GitHub
+//	                                        release for this platform, or else
+//	                                        built with go install
+//
+// Archives are .tar.gz, .tgz or .zip files holding the executable.
+//
+// The executable, or the archive, must be signed by a key of the trust store
+// allowed for the source, see Trust, unless AllowUnsigned is set: a minisign
+// signature in <file>.minisig or a cosign one in <file>.sig, next to the file
+// or as a release asset. Plugins built with go install are unsigned.
+func (i *Installer) Install(ctx context.Context, source string) (*Plugin, error) {
+	if isLocal(source) {
+		abs, err := filepath.Abs(source)
+		if err != nil {
+			return nil, err
+		}
+		source = abs
+	}
+	return i.install(ctx, source, "")
+}
+
+// Update installs the plugin called name again from its source. Plugins
+// installed from a Go module move to its latest version; the others are
+// fetched again from the same file or URL.
+func (i *Installer) Update(ctx context.Context, name string) (*Plugin, error) {
+	old, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	source := old.Source
+	if !isURL(source) && !filepath.IsAbs(source) {
+		source, _, _ = strings.Cut(source, "@")
+	}
+	return i.install(ctx, source, name)
+}
+
+// install fetches source and installs the plugin it holds, which must be
+// called want when want is set
+func (i *Installer) install(ctx context.Context, source, want string) (*Plugin, error) {
+	a, err := i.fetch(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := i.verify(source, a)
+	if err != nil {
+		return nil, err
+	}
+	data, err := unpack(a.name, a.data)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, ".install-*"+filepath.Ext(executableName("x")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to write to %s: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0755)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the plugin: %w", err)
+	}
+
+	meta, err := Describe(ctx, tmp.Name())
+	if err != nil {
+		return nil, err
+	}
+	if want != "" && meta.Name != want {
+		return nil, fmt.Errorf("%s now holds plugin %s instead of %s", source, meta.Name, want)
+	}
+	if i.Reserved != nil && i.Reserved(meta.Name) {
+		return nil, fmt.Errorf("plugin name %s is taken by a nexlayer command", meta.Name)
+	}
+
+	p := Plugin{
+		Metadata:    *meta,
+		Source:      source,
+		Path:        filepath.Join(dir, executableName(meta.Name)),
+		Signer:      signer,
+		InstalledAt: time.Now().UTC(),
+	}
+	sum := sha256.Sum256(data)
+	p.SHA256 = hex.EncodeToString(sum[:])
+	if err := os.Rename(tmp.Name(), p.Path); err != nil {
+		return nil, fmt.Errorf("failed to install %s: %w", p.Path, err)
+	}
+	if err := record(p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// verify checks the signatures of an artifact, returning the name of the
+// trusted key that signed it, or "" for an unsigned plugin allowed by
+// AllowUnsigned
+func (i *Installer) verify(source string, a *artifact) (string, error) {
+	keys, err := TrustedKeys()
+	if err != nil {
+		return "", err
+	}
+	signer, err := verifySignatures(keys, source, a.data, a.signatures)
+	switch {
+	case err == nil:
+		return signer, nil
+	case i.AllowUnsigned:
+		return "", nil
+	case len(keys) == 0:
+		return "", fmt.Errorf("%w\nAdd the publisher's key with 'nexlayer plugin keys add', or install anyway with --allow-unsigned", err)
+	}
+	return "", fmt.Errorf("%w\nInstall anyway with --allow-unsigned", err)
+}
+
+// fetch returns the artifact a source holds
+func (i *Installer) fetch(ctx context.Context, source string) (*artifact, error) {
+	switch {
+	case isURL(source):
+		data, err := i.get(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		a := &artifact{name: source, data: data}
+		for _, ext := range signatureExts {
+			sig, err := i.get(ctx, source+ext)
+			if errors.Is(err, errNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			a.signatures = append(a.signatures, signature{name: path.Base(source) + ext, data: sig})
+		}
+		return a, nil
+	case isLocal(source):
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", source, err)
+		}
+		a := &artifact{name: source, data: data}
+		for _, ext := range signatureExts {
+			sig, err := os.ReadFile(source + ext)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", source+ext, err)
+			}
+			a.signatures = append(a.signatures, signature{name: filepath.Base(source) + ext, data: sig})
+		}
+		return a, nil
+	}
+
+	module, version, _ := strings.Cut(source, "@")
+	parts := strings.Split(module, "/")
+	if len(parts) < 2 || !strings.Contains(parts[0], ".") {
+		return nil, fmt.Errorf("%s is not a file, a URL or a Go module path such as github.com/org/nexlayer-plugin-foo", source)
+	}
+	if parts[0] == "github.com" && len(parts) == 3 {
+		a, err := i.fetchRelease(ctx, parts[1], parts[2], version)
+		if !errors.Is(err, errNotFound) {
+			return a, err
+		}
+	}
+	data, err := goInstall(ctx, module, version)
+	if err != nil {
+		return nil, err
+	}
+	return &artifact{name: module, data: data}, nil
+}
+
+// fetchRelease downloads the binary for this platform from a GitHub release,
+// the latest one when version is empty, with its signatures. It returns
+// errNotFound when there is no such release or binary, and verifies the
+// binary when the release has checksums.
+func (i *Installer) fetchRelease(ctx context.Context, owner, repo, version string) (*artifact, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", githubAPI, owner, repo)
+	if version != "" && version != "latest" {
+		url = fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", githubAPI, owner, repo, version)
+	}
+	body, err := i.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	var release update.Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("failed to decode the release of %s/%s: %w", owner, repo, err)
+	}
+
+	var binary, sums *update.Asset
+	for k := range release.Assets {
+		a := &release.Assets[k]
+		if a.Name == checksumsAsset {
+			sums = a
+		} else if binary == nil && forPlatform(a.Name) {
+			binary = a
+		}
+	}
+	if binary == nil {
+		return nil, errNotFound
+	}
+	data, err := i.get(ctx, binary.URL)
+	if err != nil {
+		return nil, err
+	}
+	if sums != nil {
+		checksums, err := i.get(ctx, sums.URL)
+		if err != nil {
+			return nil, err
+		}
+		if err := verify(checksums, binary.Name, data); err != nil {
+			return nil, err
+		}
+	}
+
+	a := &artifact{name: binary.Name, data: data}
+	for _, ext := range signatureExts {
+		for k := range release.Assets {
+			if release.Assets[k].Name != binary.Name+ext {
+				continue
+			}
+			sig, err := i.get(ctx, release.Assets[k].URL)
+			if err != nil {
+				return nil, err
+			}
+			a.signatures = append(a.signatures, signature{name: binary.Name + ext, data: sig})
+		}
+	}
+	return a, nil
+}
+
+// forPlatform reports whether a release asset is built for this platform,
+// named like nexlayer-plugin-foo_linux_amd64, optionally archived
+func forPlatform(name string) bool {
+	name = strings.ToLower(name)
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip", ".exe"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	for _, sep := range []string{"_", "-"} {
+		if strings.HasSuffix(name, sep+runtime.GOOS+sep+runtime.GOARCH) {
+			return true
+		}
+	}
+	return false
+}
+
+// verify checks data against its line in a sha256sum-style file
+func verify(checksums []byte, name string, data []byte) error {
+	sum := sha256.Sum256(data)
+	got := hex.EncodeToString(sum[:])
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			if !strings.EqualFold(fields[0], got) {
+				return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, fields[0], got)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%s does not list %s", checksumsAsset, name)
+}
+
+// goInstall builds a Go module with go install, at its latest version when
+// version is empty
+func goInstall(ctx context.Context, module, version string) ([]byte, error) {
+	if _, err := exec.LookPath("go"); err != nil {
+		return nil, fmt.Errorf("%s has no release binary for %s/%s, and Go is not installed to build it", module, runtime.GOOS, runtime.GOARCH)
+	}
+	if err := offline.Check("building " + module); err != nil {
+		return nil, err
+	}
+	if version == "" {
+		version = "latest"
+	}
+	dir, err := os.MkdirTemp("", "nexlayer-plugin-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := exec.CommandContext(ctx, "go", "install", module+"@"+version)
+	cmd.Env = append(os.Environ(), "GOBIN="+dir, "GOOS="+runtime.GOOS, "GOARCH="+runtime.GOARCH)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("go install %s@%s failed: %w\n%s", module, version, err, strings.TrimSpace(string(out)))
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) != 1 {
+		return nil, fmt.Errorf("go install %s@%s built %d executables; a plugin is a single one", module, version, len(entries))
+	}
+	return os.ReadFile(filepath.Join(dir, entries[0].Name()))
+}
+
+// unpack returns the executable of an archive, or data itself when name is
+// not an archive
+func unpack(name string, data []byte) ([]byte, error) {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		tr := tar.NewReader(gz)
+		for {
+			h, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			if h.Typeflag == tar.TypeReg && isExecutable(h.Name, h.FileInfo().Mode()) {
+				return io.ReadAll(tr)
+			}
+		}
+	case strings.HasSuffix(lower, ".zip"):
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		for _, f := range zr.File {
+			if f.Mode().IsRegular() && isExecutable(f.Name, f.Mode()) {
+				rc, err := f.Open()
+				if err != nil {
+					return nil, fmt.Errorf("failed to read %s: %w", name, err)
+				}
+				defer rc.Close()
+				return io.ReadAll(rc)
+			}
+		}
+	default:
+		return data, nil
+	}
+	return nil, fmt.Errorf("%s holds no executable", name)
+}
+
+// isExecutable reports whether an archived file is the plugin executable
+func isExecutable(name string, mode os.FileMode) bool {
+	if runtime.GOOS == "windows" {
+		return strings.HasSuffix(strings.ToLower(name), ".exe")
+	}
+	return mode&0111 != 0 && !strings.HasPrefix(path.Base(name), ".")
+}
+
+// get downloads a URL
+func (i *Installer) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json, application/octet-stream")
+	resp, err := i.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", url, errNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status %d for %s", resp.StatusCode, url)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return data, nil
+}
+
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "https://") || strings.HasPrefix(source, "http://")
+}
+
+// isLocal reports whether source is a file on disk rather than a module path
+func isLocal(source string) bool {
+	if isURL(source) {
+		return false
+	}
+	info, err := os.Stat(source)
+	return err == nil && !info.IsDir()
+}
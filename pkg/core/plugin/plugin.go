@@ -0,0 +1,237 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package plugin installs external plugins, executables that add commands to
+// the CLI, under ~/.nexlayer/plugins. A plugin describes itself when run with
+// --describe, printing its Metadata as JSON. Plugins speaking the typed
+// protocol, see Serve, receive requests with the parsed deployment file and
+// answer with progress and structured results; the others are run with the
+// arguments that follow their name on the command line.
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+)
+
+// DescribeFlag makes a plugin print its Metadata and exit
+const DescribeFlag = "--describe"
+
+// describeTimeout bounds how long a plugin may take to describe itself
+const describeTimeout = 10 * time.Second
+
+// namePattern matches plugin names, which become command names
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,39}$`)
+
+// Metadata is what a plugin prints when run with --describe, e.g.
+//
+//	{"name": "foo", "version": "1.2.0", "description": "Do foo with deployments"}
+type Metadata struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Usage       string `json:"usage,omitempty"` // e.g. "foo <namespace> [flags]"
+
+	// Set by plugins speaking the typed protocol, which can subscribe to
+	// deployment hooks
+	Protocol int      `json:"protocol,omitempty"`
+	Hooks    []string `json:"hooks,omitempty"`
+}
+
+// Plugin is an installed plugin
+type Plugin struct {
+	Metadata
+	Source      string    `json:"source"` // what it was installed from
+	Path        string    `json:"path"`
+	SHA256      string    `json:"sha256"`
+	Signer      string    `json:"signer,omitempty"` // trusted key that signed it, empty when installed unsigned
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+// Dir returns the directory holding the plugin executables
+func Dir() (string, error) {
+	home, err := system.HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".nexlayer", "plugins"), nil
+}
+
+// indexPath returns the file listing the installed plugins, next to the
+// plugin directory so that it only holds executables
+func indexPath() (string, error) {
+	home, err := system.HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".nexlayer", "plugins.json"), nil
+}
+
+// List returns the installed plugins, sorted by name
+func List() ([]Plugin, error) {
+	path, err := indexPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var plugins []Plugin
+	if err := json.Unmarshal(data, &plugins); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, nil
+}
+
+// Get returns the installed plugin called name
+func Get(name string) (*Plugin, error) {
+	plugins, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range plugins {
+		if plugins[i].Name == name {
+			return &plugins[i], nil
+		}
+	}
+	return nil, fmt.Errorf("plugin %s is not installed; see nexlayer plugin list", name)
+}
+
+// Check makes sure the executable of a plugin is the one installed, so that
+// a replaced binary never runs
+func (p *Plugin) Check() error {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read plugin %s: %w (reinstall it with 'nexlayer plugin install %s')", p.Name, err, p.Source)
+	}
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != p.SHA256 {
+		return fmt.Errorf("%s changed since plugin %s was installed; reinstall it with 'nexlayer plugin install %s'", p.Path, p.Name, p.Source)
+	}
+	return nil
+}
+
+// Remove deletes an installed plugin
+func Remove(name string) error {
+	p, err := Get(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p.Path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", p.Path, err)
+	}
+	return rewrite(func(plugins []Plugin) []Plugin { return without(plugins, name) })
+}
+
+// record adds or replaces an installed plugin in the index
+func record(p Plugin) error {
+	return rewrite(func(plugins []Plugin) []Plugin { return append(without(plugins, p.Name), p) })
+}
+
+// rewrite rewrites the index with the plugins change returns
+func rewrite(change func([]Plugin) []Plugin) error {
+	plugins, err := List()
+	if err != nil {
+		return err
+	}
+	path, err := indexPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(change(plugins), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func without(plugins []Plugin, name string) []Plugin {
+	kept := []Plugin{}
+	for _, p := range plugins {
+		if p.Name != name {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// Describe runs an executable with --describe and checks the metadata it
+// prints
+func Describe(ctx context.Context, path string) (*Metadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, describeTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, DescribeFlag).Output()
+	if err != nil {
+		return nil, fmt.Errorf("not a Nexlayer plugin: %s %s failed: %w", filepath.Base(path), DescribeFlag, err)
+	}
+	var m Metadata
+	if err := json.Unmarshal(out, &m); err != nil {
+		return nil, fmt.Errorf("not a Nexlayer plugin: %s %s did not print its metadata as JSON", filepath.Base(path), DescribeFlag)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Validate checks the metadata of a plugin
+func (m *Metadata) Validate() error {
+	if !namePattern.MatchString(m.Name) {
+		return fmt.Errorf("invalid plugin name %q: use lower-case letters, digits and hyphens, starting with a letter", m.Name)
+	}
+	if strings.TrimSpace(m.Description) == "" {
+		return fmt.Errorf("plugin %s has no description", m.Name)
+	}
+	if fields := strings.Fields(m.Usage); len(fields) > 0 && fields[0] != m.Name {
+		return fmt.Errorf("usage of plugin %s must start with its name, e.g. %q", m.Name, m.Name+" [flags]")
+	}
+	if len(m.Hooks) > 0 && m.Protocol == 0 {
+		return fmt.Errorf("plugin %s subscribes to hooks without speaking the typed protocol", m.Name)
+	}
+	for _, h := range m.Hooks {
+		if !knownHook(h) {
+			return fmt.Errorf("plugin %s subscribes to unknown hook %q; use %s", m.Name, h, strings.Join(schema.HookEvents, ", "))
+		}
+	}
+	return nil
+}
+
+func knownHook(event string) bool {
+	for _, e := range schema.HookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// executableName returns the file name of the executable of a plugin
+func executableName(name string) string {
+	if runtime.GOOS == "windows" {
+		return "nexlayer-" + name + ".exe"
+	}
+	return "nexlayer-" + name
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/hashicorp/go-hclog"
+	goplugin "github.com/hashicorp/go-plugin"
+)
+
+// ProtocolVersion is the version of the typed plugin protocol the CLI speaks.
+// Plugins built with Serve declare it in their metadata; plugins that do not
+// are run as plain executables.
+//
+// Typed plugins are served with hashicorp/go-plugin over gRPC. The CLI starts
+// the plugin, which answers the handshake, and calls the Run or Hook method
+// of the Plugin service; the plugin streams back any number of progress
+// messages, then a result. A failure is returned as the status of the call.
+// Messages are encoded as JSON, which carries schema.NexlayerYAML and the
+// data of results as they are, without a protobuf schema to keep in step.
+// The stderr and stdout of the plugin are the user's terminal.
+const ProtocolVersion = 1
+
+// Handshake is the go-plugin handshake of typed plugins. The cookie tells a
+// plugin it was started by the CLI rather than by hand.
+var Handshake = goplugin.HandshakeConfig{
+	ProtocolVersion:  ProtocolVersion,
+	MagicCookieKey:   MagicCookieKey,
+	MagicCookieValue: MagicCookieValue,
+}
+
+// The handshake cookie
+const (
+	MagicCookieKey   = "NEXLAYER_PLUGIN_MAGIC_COOKIE"
+	MagicCookieValue = "7c1f0d7e6a5b4e2f9a3c8b1d0e4f6a2b"
+)
+
+// dispenseName is the name the CLI dispenses the plugin under
+const dispenseName = "plugin"
+
+// Hook events a plugin can subscribe to in its metadata; an error of a
+// pre- hook aborts the command
+const (
+	HookPreInit    = schema.HookPreInit
+	HookPostInit   = schema.HookPostInit
+	HookPreDeploy  = schema.HookPreDeploy
+	HookPostDeploy = schema.HookPostDeploy
+	HookOnFailure  = schema.HookOnFailure
+)
+
+// Handler is implemented by typed plugins and served with Serve
+type Handler interface {
+	// Metadata describes the plugin; Serve fills in the protocol version
+	Metadata() Metadata
+
+	// Run runs the plugin's command
+	Run(ctx context.Context, req *RunRequest, progress func(Progress)) (*Result, error)
+
+	// Hook handles an event listed in the metadata's hooks
+	Hook(ctx context.Context, req *HookRequest, progress func(Progress)) (*Result, error)
+}
+
+// Environment is what a plugin needs to call the Nexlayer API as the user
+type Environment struct {
+	APIURL string `json:"apiURL"`
+	Token  string `json:"token,omitempty"`
+}
+
+// RunRequest runs the command of a plugin
+type RunRequest struct {
+	Environment
+	Args []string `json:"args"`
+
+	// Deployment file of the working directory, parsed, when there is one
+	File   string               `json:"file,omitempty"`
+	Config *schema.NexlayerYAML `json:"config,omitempty"`
+}
+
+// HookRequest tells a plugin about an event of the lifecycle of an
+// application
+type HookRequest struct {
+	Environment
+	Event  string               `json:"event"`
+	File   string               `json:"file"`
+	Config *schema.NexlayerYAML `json:"config,omitempty"` // nil before init wrote the file
+
+	// Set for post-deploy, and for on-failure once the deployment started
+	Namespace string `json:"namespace,omitempty"`
+	URL       string `json:"url,omitempty"`
+
+	// Why the deployment failed, for on-failure
+	Error string `json:"error,omitempty"`
+}
+
+// Progress reports how a running request is doing
+type Progress struct {
+	Message string `json:"message"`
+	Percent int    `json:"percent,omitempty"` // 1-100, or 0 when unknown
+}
+
+// Result is the outcome of a request: a message for people and data for
+// --output json and yaml
+type Result struct {
+	Message string          `json:"message,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// message is what a plugin streams back: progress, then a result
+type message struct {
+	Progress *Progress `json:"progress,omitempty"`
+	Result   *Result   `json:"result,omitempty"`
+}
+
+// Typed reports whether a plugin speaks the typed protocol
+func (p *Plugin) Typed() bool {
+	return p.Protocol > 0
+}
+
+// HasHook reports whether a plugin subscribes to an event
+func (p *Plugin) HasHook(event string) bool {
+	for _, h := range p.Hooks {
+		if h == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs the command of a typed plugin
+func (p *Plugin) Run(ctx context.Context, req *RunRequest, progress func(Progress)) (*Result, error) {
+	return p.call(ctx, methodRun, req, progress)
+}
+
+// Hook tells a typed plugin about an event
+func (p *Plugin) Hook(ctx context.Context, req *HookRequest, progress func(Progress)) (*Result, error) {
+	return p.call(ctx, methodHook, req, progress)
+}
+
+// call starts the plugin, sends it a request and relays its progress until
+// the result
+func (p *Plugin) call(ctx context.Context, method string, req interface{}, progress func(Progress)) (*Result, error) {
+	if p.Protocol > ProtocolVersion {
+		return nil, fmt.Errorf("plugin %s needs protocol %d, but this CLI speaks %d; upgrade the CLI with nexlayer upgrade", p.Name, p.Protocol, ProtocolVersion)
+	}
+	if err := p.Check(); err != nil {
+		return nil, err
+	}
+	sum, err := hex.DecodeString(p.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checksum recorded for plugin %s: %w", p.Name, err)
+	}
+
+	client := goplugin.NewClient(&goplugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		Plugins:          goplugin.PluginSet{dispenseName: &grpcPlugin{}},
+		Cmd:              exec.Command(p.Path),
+		SecureConfig:     &goplugin.SecureConfig{Checksum: sum, Hash: sha256.New()},
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		Stderr:           os.Stderr,
+		SyncStdout:       os.Stdout,
+		SyncStderr:       os.Stderr,
+		Logger:           hclog.NewNullLogger(),
+	})
+	defer client.Kill()
+	conn, err := client.Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start plugin %s: %w", p.Name, err)
+	}
+	raw, err := conn.Dispense(dispenseName)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", p.Name, err)
+	}
+	return raw.(*grpcClient).call(ctx, method, req, progress)
+}
+
+// Serve runs a typed plugin; it is the main function of plugins written in
+// Go:
+//
+//	func main() {
+//		plugin.Serve(&myPlugin{})
+//	}
+//
+// It answers --describe with the metadata, and requests from the CLI with
+// the handler. It does not return.
+func Serve(h Handler) {
+	if len(os.Args) > 1 && os.Args[1] == DescribeFlag {
+		m := h.Metadata()
+		m.Protocol = ProtocolVersion
+		if err := json.NewEncoder(os.Stdout).Encode(m); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if os.Getenv(MagicCookieKey) != MagicCookieValue {
+		fmt.Fprintf(os.Stderr, "This is a plugin of the Nexlayer CLI. Install it with 'nexlayer plugin install' and run it with 'nexlayer %s'.\n", h.Metadata().Name)
+		os.Exit(1)
+	}
+
+	goplugin.Serve(&goplugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         goplugin.PluginSet{dispenseName: &grpcPlugin{handler: h}},
+		GRPCServer:      goplugin.DefaultGRPCServer,
+		Logger:          hclog.NewNullLogger(),
+	})
+	os.Exit(0)
+}
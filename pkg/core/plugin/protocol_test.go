@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// servePluginEnv makes the test binary serve testHandler, so that tests can
+// start it as a plugin
+const servePluginEnv = "NEXLAYER_TEST_SERVE_PLUGIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(servePluginEnv) != "" {
+		Serve(testHandler{})
+	}
+	os.Exit(m.Run())
+}
+
+// testHandler reports the application it receives, and fails hooks
+type testHandler struct{}
+
+func (testHandler) Metadata() Metadata {
+	return Metadata{Name: "test", Version: "1.0.0", Description: "Test plugin", Hooks: []string{HookPreDeploy}}
+}
+
+func (testHandler) Run(ctx context.Context, req *RunRequest, progress func(Progress)) (*Result, error) {
+	progress(Progress{Message: "reading " + req.File, Percent: 50})
+	data, err := json.Marshal(map[string]string{"application": req.Config.Application.Name})
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Message: strings.Join(req.Args, " "), Data: data}, nil
+}
+
+func (testHandler) Hook(ctx context.Context, req *HookRequest, progress func(Progress)) (*Result, error) {
+	return nil, errors.New("refusing " + req.Event)
+}
+
+// testPlugin returns the test binary installed as a plugin
+func testPlugin(t *testing.T) *Plugin {
+	t.Helper()
+	t.Setenv(servePluginEnv, "1")
+	path, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(data)
+	m := testHandler{}.Metadata()
+	m.Protocol = ProtocolVersion
+	return &Plugin{Metadata: m, Path: path, SHA256: hex.EncodeToString(sum[:])}
+}
+
+func TestRun(t *testing.T) {
+	p := testPlugin(t)
+	req := &RunRequest{
+		Args:   []string{"--all"},
+		File:   "nexlayer.yaml",
+		Config: &schema.NexlayerYAML{Application: schema.Application{Name: "shop"}},
+	}
+	var progress []Progress
+	result, err := p.Run(context.Background(), req, func(pr Progress) { progress = append(progress, pr) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progress) != 1 || progress[0].Message != "reading nexlayer.yaml" || progress[0].Percent != 50 {
+		t.Errorf("progress = %+v", progress)
+	}
+	if result.Message != "--all" || string(result.Data) != `{"application":"shop"}` {
+		t.Errorf("result = %q %s", result.Message, result.Data)
+	}
+}
+
+func TestHookError(t *testing.T) {
+	p := testPlugin(t)
+	_, err := p.Hook(context.Background(), &HookRequest{Event: HookPreDeploy, File: "nexlayer.yaml"}, nil)
+	if err == nil || err.Error() != "refusing pre-deploy" {
+		t.Fatalf("err = %v, want the error of the plugin", err)
+	}
+}
+
+func TestRunModified(t *testing.T) {
+	p := testPlugin(t)
+	p.SHA256 = strings.Repeat("0", 64)
+	if _, err := p.Run(context.Background(), &RunRequest{}, nil); err == nil || !strings.Contains(err.Error(), "changed since plugin") {
+		t.Fatalf("err = %v, want a checksum mismatch", err)
+	}
+}
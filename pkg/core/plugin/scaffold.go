@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed scaffold/*.tmpl
+var scaffoldFS embed.FS
+
+// scaffoldFiles maps the templates of a new plugin to the files they become
+var scaffoldFiles = []struct{ template, file string }{
+	{"go.mod.tmpl", "go.mod"},
+	{"main.go.tmpl", "main.go"},
+	{"handler.go.tmpl", "handler.go"},
+	{"command.go.tmpl", "command.go"},
+	{"handler_test.go.tmpl", "handler_test.go"},
+	{"Makefile.tmpl", "Makefile"},
+	{"README.md.tmpl", "README.md"},
+	{"gitignore.tmpl", ".gitignore"},
+}
+
+// Skeleton describes a new plugin
+type Skeleton struct {
+	Name        string
+	Module      string // defaults to Executable
+	Description string
+}
+
+// Executable returns the conventional name of the plugin's module and
+// release binaries
+func (s *Skeleton) Executable() string {
+	return "nexlayer-plugin-" + s.Name
+}
+
+// Scaffold writes the Go module of a new typed plugin into dir: a handler
+// served with Serve, a cobra command, tests and a Makefile. dir must not
+// exist or be empty. It returns the files written.
+func Scaffold(dir string, s Skeleton) ([]string, error) {
+	if !namePattern.MatchString(s.Name) {
+		return nil, fmt.Errorf("invalid plugin name %q: use lower-case letters, digits and hyphens, starting with a letter", s.Name)
+	}
+	if s.Module == "" {
+		s.Module = s.Executable()
+	}
+	if s.Description == "" {
+		s.Description = "Describe what " + s.Name + " does"
+	}
+	// The description ends up in Go string literals
+	s.Description = strings.TrimSuffix(strings.NewReplacer(`"`, `'`, `\`, `/`).Replace(s.Description), ".")
+
+	rendered := make([][]byte, len(scaffoldFiles))
+	for i, f := range scaffoldFiles {
+		tmpl, err := template.ParseFS(scaffoldFS, "scaffold/"+f.template)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, &s); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", f.file, err)
+		}
+		rendered[i] = buf.Bytes()
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	if len(entries) > 0 {
+		return nil, fmt.Errorf("%s is not empty; choose another directory with --dir", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	var written []string
+	for i, f := range scaffoldFiles {
+		path := filepath.Join(dir, f.file)
+		if err := os.WriteFile(path, rendered[i], 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
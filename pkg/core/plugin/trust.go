@@ -0,0 +1,261 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/minisign"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+)
+
+// Kinds of signing keys
+const (
+	KeyMinisign = "minisign"
+	KeyCosign   = "cosign"
+)
+
+// signatureExts are the extensions of the detached signatures of a plugin,
+// looked up next to it: minisign's, then cosign sign-blob's
+var signatureExts = []string{".minisig", ".sig"}
+
+// errUntrusted is returned when no trusted key verifies a plugin
+var errUntrusted = errors.New("not signed by a trusted key")
+
+// Key is a public key plugins may be signed with, in the trust store at
+// ~/.nexlayer/plugin-keys.json
+type Key struct {
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`      // KeyMinisign or KeyCosign
+	ID        string    `json:"id"`        // minisign key ID, or the start of the SHA-256 of a cosign key
+	PublicKey string    `json:"publicKey"` // as added, a minisign .pub file or a PEM block
+	Sources   []string  `json:"sources,omitempty"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
+// signature is a detached signature of a plugin
+type signature struct {
+	name string
+	data []byte
+}
+
+// keysPath returns the trust store
+func keysPath() (string, error) {
+	home, err := system.HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".nexlayer", "plugin-keys.json"), nil
+}
+
+// TrustedKeys returns the keys of the trust store, sorted by name
+func TrustedKeys() ([]Key, error) {
+	path, err := keysPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var keys []Key
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys, nil
+}
+
+// Trust adds a public key to the trust store, replacing any key of the same
+// name. Plugins verified with it must come from a source starting with one
+// of sources, when given, e.g. github.com/org/.
+func Trust(name string, publicKey []byte, sources []string) (*Key, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid key name %q: use lower-case letters, digits and hyphens, starting with a letter", name)
+	}
+	key := Key{Name: name, PublicKey: strings.TrimSpace(string(publicKey)), Sources: sources, AddedAt: time.Now().UTC()}
+	if strings.Contains(key.PublicKey, "-----BEGIN") {
+		pub, err := parseCosignKey(key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		der, _ := x509.MarshalPKIXPublicKey(pub)
+		sum := sha256.Sum256(der)
+		key.Kind, key.ID = KeyCosign, hex.EncodeToString(sum[:8])
+	} else {
+		id, _, err := minisign.ParseKey(key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		key.Kind, key.ID = KeyMinisign, minisign.KeyID(id)
+	}
+
+	keys, err := TrustedKeys()
+	if err != nil {
+		return nil, err
+	}
+	kept := []Key{}
+	for _, k := range keys {
+		if k.Name != name {
+			kept = append(kept, k)
+		}
+	}
+	if err := writeKeys(append(kept, key)); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Distrust removes a key from the trust store
+func Distrust(name string) error {
+	keys, err := TrustedKeys()
+	if err != nil {
+		return err
+	}
+	kept := []Key{}
+	for _, k := range keys {
+		if k.Name != name {
+			kept = append(kept, k)
+		}
+	}
+	if len(kept) == len(keys) {
+		return fmt.Errorf("key %s is not trusted; see nexlayer plugin keys list", name)
+	}
+	return writeKeys(kept)
+}
+
+func writeKeys(keys []Key) error {
+	path, err := keysPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// allows reports whether the key may verify plugins from source. A source
+// prefix matches whole path segments, so github.com/org does not allow
+// github.com/orgevil.
+func (k *Key) allows(source string) bool {
+	if len(k.Sources) == 0 {
+		return true
+	}
+	for _, prefix := range k.Sources {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if source == prefix || strings.HasPrefix(source, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// verifySignatures checks data against one of its signatures with the
+// trusted keys allowed for source, returning the name of the key that signed
+// it
+func verifySignatures(keys []Key, source string, data []byte, sigs []signature) (string, error) {
+	if len(sigs) == 0 {
+		return "", fmt.Errorf("%s has no signature: %w", source, errUntrusted)
+	}
+	var errs []error
+	for _, sig := range sigs {
+		for i := range keys {
+			k := &keys[i]
+			if !k.allows(source) {
+				continue
+			}
+			var err error
+			switch {
+			case k.Kind == KeyMinisign && strings.HasSuffix(sig.name, ".minisig"):
+				err = minisign.Verify(k.PublicKey, data, sig.data)
+			case k.Kind == KeyCosign && strings.HasSuffix(sig.name, ".sig"):
+				err = verifyCosign(k.PublicKey, data, sig.data)
+			default:
+				continue
+			}
+			if err == nil {
+				return k.Name, nil
+			}
+			if !errors.Is(err, minisign.ErrOtherKey) {
+				errs = append(errs, fmt.Errorf("%s with key %s: %w", sig.name, k.Name, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return "", fmt.Errorf("%s: %w: %w", source, errUntrusted, errors.Join(errs...))
+	}
+	return "", fmt.Errorf("%s: %w", source, errUntrusted)
+}
+
+// parseCosignKey decodes a PEM public key as written by cosign generate-key-pair
+func parseCosignKey(text string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(text))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("not a PEM public key")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the public key: %w", err)
+	}
+	switch pub.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return pub, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type %T", pub)
+}
+
+// verifyCosign checks a signature made with cosign sign-blob --key, base64
+// encoded as cosign writes it, over the SHA-256 of the data
+func verifyCosign(publicKey string, data, sigFile []byte) error {
+	pub, err := parseCosignKey(publicKey)
+	if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sigFile)))
+	if err != nil {
+		sig = sigFile
+	}
+	digest := sha256.Sum256(data)
+	var ok bool
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		ok = ecdsa.VerifyASN1(pub, digest[:], sig)
+	case ed25519.PublicKey:
+		ok = ed25519.Verify(pub, data, sig)
+	case *rsa.PublicKey:
+		ok = rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil
+	}
+	if !ok {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
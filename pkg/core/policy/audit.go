@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// AuditLog is the file, relative to the project, that records deploys made
+// against the deploy policy, one JSON entry per line
+var AuditLog = filepath.Join(".nexlayer", "audit.log")
+
+// Actions checked against the deploy policy
+const (
+	ActionDeploy   = "deploy"
+	ActionRollback = "rollback"
+	ActionPromote  = "promote"
+)
+
+// actionWords are how errors phrase an action: the verb and the noun
+var actionWords = map[string][2]string{
+	ActionDeploy:   {"deploy", "deployment"},
+	ActionRollback: {"roll back", "rollback"},
+	ActionPromote:  {"promote", "promotion"},
+}
+
+// AuditEntry records a deploy that overrode the deploy policy
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	User   string    `json:"user"`
+	Action string    `json:"action"`
+	App    string    `json:"app"`
+	Rule   string    `json:"rule"`
+	Reason string    `json:"reason"`
+}
+
+// Record appends an entry to the audit log, filling in the time and user when
+// they are unset
+func Record(e AuditEntry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.User == "" {
+		e.User = CurrentUser()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(AuditLog), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(AuditLog), err)
+	}
+	f, err := os.OpenFile(AuditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", AuditLog, err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", AuditLog, err)
+	}
+	return f.Close()
+}
+
+// CurrentUser names the person running the CLI
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+// Enforce stops an action the deploy policy of config forbids now, unless
+// overridden with a reason, which is then recorded in the audit log. It
+// returns the violation that was overridden, if any. The action must not go
+// ahead when the reason cannot be recorded.
+func Enforce(action string, config *schema.NexlayerYAML, reason string) (*Violation, error) {
+	v, err := Check(config.Application.DeployPolicy, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("invalid deploy policy: %w", err)
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	words, ok := actionWords[action]
+	if !ok {
+		words = [2]string{action, action}
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%s\nTo %s anyway, rerun with --override-freeze \"<reason>\"; the reason is recorded in %s", v, words[0], AuditLog)
+	}
+	err = Record(AuditEntry{
+		Action: action,
+		App:    config.Application.Name,
+		Rule:   v.Rule,
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not record the freeze override, %s aborted: %w", words[1], err)
+	}
+	return v, nil
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package policy decides whether a deployment may start now, from the deploy
+// windows and freezes declared in nexlayer.yaml, and records overrides in the
+// audit log.
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // timezones on hosts without zoneinfo
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+// Violation explains why a deployment may not start now
+type Violation struct {
+	Rule    string    `json:"rule"`    // freeze name, or "windows"
+	Message string    `json:"message"` // human readable
+	Until   time.Time `json:"until"`   // when deploys are allowed again, if known
+}
+
+// Error implements error
+func (v *Violation) Error() string {
+	if v.Until.IsZero() {
+		return v.Message
+	}
+	return fmt.Sprintf("%s until %s", v.Message, v.Until.Format("Mon 2006-01-02 15:04 MST"))
+}
+
+// Check returns the first rule of p that blocks a deployment at now, or nil
+// when it may start. Freezes are checked before windows.
+func Check(p *schema.DeployPolicy, now time.Time) (*Violation, error) {
+	if p == nil {
+		return nil, nil
+	}
+	loc, err := Location(p.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	now = now.In(loc).Truncate(time.Minute)
+
+	for i, f := range p.Freezes {
+		until, active, err := freezeActive(f, now, loc)
+		if err != nil {
+			return nil, fmt.Errorf("freezes[%d]: %w", i, err)
+		}
+		if !active {
+			continue
+		}
+		name := f.Name
+		if name == "" {
+			name = fmt.Sprintf("freezes[%d]", i)
+		}
+		msg := fmt.Sprintf("deploys are frozen (%s)", name)
+		if f.Reason != "" {
+			msg = fmt.Sprintf("deploys are frozen (%s: %s)", name, f.Reason)
+		}
+		return &Violation{Rule: name, Message: msg, Until: until}, nil
+	}
+
+	if len(p.Windows) == 0 {
+		return nil, nil
+	}
+	windows := make([][]weekRange, len(p.Windows))
+	for i, w := range p.Windows {
+		ranges, err := windowRanges(w)
+		if err != nil {
+			return nil, fmt.Errorf("windows[%d]: %w", i, err)
+		}
+		windows[i] = ranges
+	}
+	open := func(t time.Time) bool {
+		m := minuteOfWeek(t)
+		for _, ranges := range windows {
+			for _, r := range ranges {
+				if r.contains(m) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if open(now) {
+		return nil, nil
+	}
+	v := &Violation{Rule: "windows", Message: "deploys are only allowed inside the deploy windows"}
+	for t := now.Add(time.Minute); t.Before(now.Add(8 * 24 * time.Hour)); t = t.Add(time.Minute) {
+		if open(t) {
+			v.Until = t
+			break
+		}
+	}
+	return v, nil
+}
+
+// Location returns the timezone of a policy, the local zone when tz is empty
+func Location(tz string) (*time.Location, error) {
+	if tz == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", tz)
+	}
+	return loc, nil
+}
+
+// ValidateWindow reports whether a window's days and times are well formed
+func ValidateWindow(w schema.DeployWindow) error {
+	_, err := windowRanges(w)
+	return err
+}
+
+// ValidateFreeze reports whether a freeze's bounds are well formed and of the
+// same kind
+func ValidateFreeze(f schema.Freeze) error {
+	_, _, err := freezeActive(f, time.Now(), time.UTC)
+	return err
+}
+
+// weekRange is a half-open range of minutes in a week starting on Sunday; it
+// wraps around the end of the week when from > to
+type weekRange struct{ from, to int }
+
+func (r weekRange) contains(m int) bool {
+	if r.from <= r.to {
+		return m >= r.from && m < r.to
+	}
+	return m >= r.from || m < r.to
+}
+
+// windowRanges returns the week ranges a window is open
+func windowRanges(w schema.DeployWindow) ([]weekRange, error) {
+	from, err := parseClock(w.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseClock(w.To)
+	if err != nil {
+		return nil, err
+	}
+	days := []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+	if len(w.Days) > 0 {
+		days = days[:0]
+		for _, d := range w.Days {
+			day, err := parseWeekday(d)
+			if err != nil {
+				return nil, err
+			}
+			days = append(days, day)
+		}
+	}
+	length := to - from
+	if length <= 0 {
+		length += minutesPerDay
+	}
+	ranges := make([]weekRange, 0, len(days))
+	for _, day := range days {
+		start := int(day)*minutesPerDay + from
+		ranges = append(ranges, weekRange{from: start, to: (start + length) % minutesPerWeek})
+	}
+	return ranges, nil
+}
+
+// freezeActive reports whether a freeze is in effect at now and when it ends
+func freezeActive(f schema.Freeze, now time.Time, loc *time.Location) (time.Time, bool, error) {
+	fromDay, fromMin, weeklyFrom := parseWeekly(f.From)
+	toDay, toMin, weeklyTo := parseWeekly(f.To)
+	if weeklyFrom != weeklyTo {
+		return time.Time{}, false, fmt.Errorf("from %q and to %q must both be weekly (e.g. fri 18:00) or both dates", f.From, f.To)
+	}
+
+	if weeklyFrom {
+		if fromDay < 0 || toDay < 0 {
+			return time.Time{}, false, fmt.Errorf("invalid weekly freeze %q to %q; use a day and time such as fri 18:00", f.From, f.To)
+		}
+		r := weekRange{from: int(fromDay)*minutesPerDay + fromMin, to: int(toDay)*minutesPerDay + toMin}
+		if r.from == r.to {
+			return time.Time{}, false, fmt.Errorf("freeze ends when it starts (%s)", f.From)
+		}
+		m := minuteOfWeek(now)
+		if !r.contains(m) {
+			return time.Time{}, false, nil
+		}
+		left := (r.to - m + minutesPerWeek) % minutesPerWeek
+		return now.Add(time.Duration(left) * time.Minute), true, nil
+	}
+
+	start, _, err := parseDate(f.From, loc)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	end, dateOnly, err := parseDate(f.To, loc)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if dateOnly {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		return time.Time{}, false, fmt.Errorf("freeze ends (%s) before it starts (%s)", f.To, f.From)
+	}
+	if now.Before(start) || !now.Before(end) {
+		return time.Time{}, false, nil
+	}
+	return end, true, nil
+}
+
+// parseWeekly parses "fri 18:00". ok reports whether s looks weekly at all;
+// the day is -1 when it does but is malformed.
+func parseWeekly(s string) (day time.Weekday, minute int, ok bool) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 || fields[0] == "" || fields[0][0] < 'A' {
+		return 0, 0, false
+	}
+	d, err := parseWeekday(fields[0])
+	if err != nil {
+		return -1, 0, true
+	}
+	m, err := parseClock(fields[1])
+	if err != nil {
+		return -1, 0, true
+	}
+	return d, m, true
+}
+
+// parseDate parses a date, optionally with a time, in loc
+func parseDate(s string, loc *time.Location) (t time.Time, dateOnly bool, err error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, false, nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q; use YYYY-MM-DD or YYYY-MM-DD HH:MM", s)
+}
+
+// parseClock parses HH:MM into minutes since midnight
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+	hour, err1 := strconv.Atoi(h)
+	minute, err2 := strconv.Atoi(m)
+	if !ok || err1 != nil || err2 != nil || hour < 0 || hour > 24 || minute < 0 || minute > 59 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time %q; use HH:MM", s)
+	}
+	return hour*60 + minute, nil
+}
+
+// parseWeekday parses a day name, e.g. fri or Friday
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if len(s) >= 3 && strings.HasPrefix(name, s) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day %q; use mon, tue, wed, thu, fri, sat or sun", s)
+}
+
+// minuteOfWeek returns the minutes since Sunday midnight
+func minuteOfWeek(t time.Time) int {
+	return int(t.Weekday())*minutesPerDay + t.Hour()*60 + t.Minute()
+}
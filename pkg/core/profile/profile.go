@@ -0,0 +1,179 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package profile keeps named environments in ~/.nexlayer/config.yaml, each
+// with the API URL, token and default application ID to use, so the CLI can
+// switch between staging, production and self-hosted installations.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/auth"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/dns"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvVar selects the profile for one invocation, like --profile
+const EnvVar = "NEXLAYER_PROFILE"
+
+// FileName is the name of the profiles file in ~/.nexlayer
+const FileName = "config.yaml"
+
+// Built-in profiles, used until the file defines its own
+const (
+	Staging    = "staging"
+	Production = "production"
+)
+
+// DefaultProfile is the profile used when none is selected
+const DefaultProfile = Staging
+
+// Profile is a named environment
+type Profile struct {
+	Name  string `yaml:"-" json:"name"`
+	URL   string `yaml:"url" json:"url"`
+	Token string `yaml:"token,omitempty" json:"-"`
+	AppID string `yaml:"appID,omitempty" json:"appID,omitempty"`
+
+	// TLS settings, for installations with a private certificate authority
+	CACert   string `yaml:"caCert,omitempty" json:"caCert,omitempty"`
+	Insecure bool   `yaml:"insecure,omitempty" json:"insecure,omitempty"`
+
+	// DNS provider creating the records of custom domains, if any
+	DNS *dns.ProviderConfig `yaml:"dns,omitempty" json:"dns,omitempty"`
+
+	// LLM answering the AI analysis, if any; the built-in heuristics answer
+	// otherwise
+	LLM *llm.Config `yaml:"llm,omitempty" json:"llm,omitempty"`
+}
+
+// ResolveToken returns the token to use with the profile: a token in the
+// environment, else the profile's own, else the one nexlayer login saved for
+// the profile
+func (p Profile) ResolveToken() string {
+	if token := auth.FromEnv(); token != "" {
+		return token
+	}
+	if p.Token != "" {
+		return p.Token
+	}
+	return auth.Token(p.Name)
+}
+
+// Config is the profiles file
+type Config struct {
+	Current  string             `yaml:"current,omitempty"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// Defaults returns the built-in profiles
+func Defaults() *Config {
+	return &Config{
+		Current: DefaultProfile,
+		Profiles: map[string]Profile{
+			Staging:    {URL: "https://app.staging.nexlayer.io"},
+			Production: {URL: "https://app.nexlayer.io"},
+		},
+	}
+}
+
+// Path returns the path of the profiles file
+func Path() (string, error) {
+	home, err := system.HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".nexlayer", FileName), nil
+}
+
+// Load reads the profiles file, or returns the built-in profiles when there
+// is none
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Defaults(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if c.Profiles == nil {
+		c.Profiles = make(map[string]Profile)
+	}
+	return &c, nil
+}
+
+// Save writes the profiles file, readable only by the user as it may hold
+// tokens
+func Save(c *Config) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save profiles: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file
+	return os.Chmod(path, 0o600)
+}
+
+// Names returns the names of the profiles, sorted
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named profile
+func (c *Config) Get(name string) (Profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("no profile named %q; available: %s", name, strings.Join(c.Names(), ", "))
+	}
+	p.Name = name
+	if p.URL == "" {
+		return Profile{}, fmt.Errorf("profile %s has no url", name)
+	}
+	return p, nil
+}
+
+// Active returns the profile to use: name when not empty, else the one
+// NEXLAYER_PROFILE selects, else the current one
+func (c *Config) Active(name string) (Profile, error) {
+	if name == "" {
+		name = os.Getenv(EnvVar)
+	}
+	if name == "" {
+		name = c.Current
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	return c.Get(name)
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package promote promotes a release from one environment to another: the
+// configuration revision running in the source environment, with its images
+// pinned to the digests that run there, is deployed to the target with the
+// target's own vars. Promotions are recorded in the project's history.
+package promote
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/policy"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+)
+
+// HistoryFile records promotions made from the project, one JSON entry per
+// line
+var HistoryFile = filepath.Join(".nexlayer", "promotions.log")
+
+// Plan is a promotion ready to be deployed
+type Plan struct {
+	From, To string
+	Target   schema.Environment
+	Config   *schema.NexlayerYAML // what is deployed to the target
+	Images   []ImageChange
+	Unpinned []string // pods whose source image has no known digest
+}
+
+// ImageChange is the image a pod of the target runs before and after
+type ImageChange struct {
+	Pod     string `json:"pod"`
+	Current string `json:"current,omitempty"`
+	Image   string `json:"image"`
+}
+
+// Changed reports whether the pod gets a different image
+func (c ImageChange) Changed() bool {
+	return c.Current != c.Image
+}
+
+// Prepare builds the promotion of the release running in environment from to
+// environment to. base is the configuration declaring the environments, which
+// also supplies what the platform does not report, such as ports and vars.
+func Prepare(ctx context.Context, client api.APIClient, base *schema.NexlayerYAML, from, to string) (*Plan, error) {
+	if from == to {
+		return nil, fmt.Errorf("cannot promote %s to itself", from)
+	}
+	source, err := schema.LookupEnvironment(base, from)
+	if err != nil {
+		return nil, err
+	}
+	target, err := schema.LookupEnvironment(base, to)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.GetDeploymentInfo(ctx, source.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment of %s: %w", from, err)
+	}
+	if len(info.Data.PodStatuses) == 0 {
+		return nil, fmt.Errorf("%s (%s) has no pods to promote", from, source.Namespace)
+	}
+
+	plan := &Plan{From: from, To: to, Target: target, Config: tmpl.Snapshot(info.Data, base)}
+	app := &plan.Config.Application
+	app.Name = base.Application.Name
+	if target.Name != "" {
+		app.Name = target.Name
+	}
+	app.URL = ""
+	app.Migrations = base.Application.Migrations
+	app.Checks = base.Application.Checks
+	app.Environments = base.Application.Environments
+	app.DeployPolicy = base.Application.DeployPolicy
+
+	digests := make(map[string]string)
+	for _, s := range info.Data.PodStatuses {
+		digests[s.Name] = s.ImageDigest
+	}
+	current := make(map[string]string)
+	if live, err := client.GetDeploymentInfo(ctx, target.Namespace); err == nil {
+		for _, s := range live.Data.PodStatuses {
+			current[s.Name] = s.Image
+		}
+	}
+
+	for i := range app.Pods {
+		pod := &app.Pods[i]
+		if d := digests[pod.Name]; d != "" {
+			pod.Image = PinImage(pod.Image, d)
+		} else if !strings.Contains(pod.Image, "@") {
+			plan.Unpinned = append(plan.Unpinned, pod.Name)
+		}
+		for j := range pod.Vars {
+			if v, ok := target.Vars[pod.Vars[j].Key]; ok {
+				pod.Vars[j].Value = v
+			}
+		}
+		plan.Images = append(plan.Images, ImageChange{Pod: pod.Name, Current: current[pod.Name], Image: pod.Image})
+	}
+	return plan, nil
+}
+
+// PinImage replaces the tag of image with digest, e.g. app:1.2 and sha256:ab
+// give app@sha256:ab
+func PinImage(image, digest string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + "@" + digest
+}
+
+// Entry records a promotion
+type Entry struct {
+	Time      time.Time         `json:"time"`
+	User      string            `json:"user"`
+	App       string            `json:"app"`
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	Namespace string            `json:"namespace"`
+	Images    map[string]string `json:"images"`
+	Status    string            `json:"status"`
+	Reason    string            `json:"reason,omitempty"` // of a deploy policy override
+}
+
+// Record appends an entry to the history, filling in the time and user when
+// they are unset
+func Record(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.User == "" {
+		e.User = policy.CurrentUser()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(HistoryFile), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(HistoryFile), err)
+	}
+	f, err := os.OpenFile(HistoryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", HistoryFile, err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", HistoryFile, err)
+	}
+	return f.Close()
+}
+
+// History returns the recorded promotions, oldest first
+func History() ([]Entry, error) {
+	f, err := os.Open(HistoryFile)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", HistoryFile, err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", HistoryFile, n, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
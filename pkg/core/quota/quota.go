@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package quota compares deployments against the account's plan limits.
+package quota
+
+import (
+	"fmt"
+
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/cost"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Resource names
+const (
+	ResourceApps      = "apps"
+	ResourcePods      = "pods"
+	ResourceStorage   = "storage"
+	ResourceBandwidth = "bandwidth"
+)
+
+// Line is the usage of one limited resource
+type Line struct {
+	Resource string  `json:"resource"`
+	Used     float64 `json:"used"`
+	Limit    float64 `json:"limit"` // zero means unlimited
+	Unit     string  `json:"unit,omitempty"`
+}
+
+// Lines lists every resource of a quota in display order
+func Lines(q apischema.Quota) []Line {
+	return []Line{
+		{Resource: ResourceApps, Used: float64(q.Usage.Apps), Limit: float64(q.Limits.Apps)},
+		{Resource: ResourcePods, Used: float64(q.Usage.Pods), Limit: float64(q.Limits.Pods)},
+		{Resource: ResourceStorage, Used: q.Usage.StorageGB, Limit: q.Limits.StorageGB, Unit: "GB"},
+		{Resource: ResourceBandwidth, Used: q.Usage.BandwidthGB, Limit: q.Limits.BandwidthGB, Unit: "GB"},
+	}
+}
+
+// Percent returns how much of the limit is used, or -1 when unlimited
+func (l Line) Percent() float64 {
+	if l.Limit <= 0 {
+		return -1
+	}
+	return l.Used / l.Limit * 100
+}
+
+// Format renders an amount of the line's resource, e.g. "12" or "4.5 GB"
+func (l Line) Format(v float64) string {
+	if l.Unit == "" {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f %s", v, l.Unit)
+}
+
+// Check returns a warning for each limit the deployment of config would
+// exceed. A new application adds to the current usage; a redeploy of an
+// existing one replaces resources the CLI cannot see, so only the
+// configuration itself is compared against the limits.
+func Check(q apischema.Quota, config *schema.NexlayerYAML, newApp bool) []string {
+	var storage float64
+	for _, pod := range config.Application.Pods {
+		for _, v := range pod.Volumes {
+			if gb, err := cost.ParseSizeGB(v.Size); err == nil {
+				storage += gb
+			}
+		}
+	}
+	requested := map[string]float64{
+		ResourceApps:    1,
+		ResourcePods:    float64(len(config.Application.Pods)),
+		ResourceStorage: storage,
+	}
+
+	var warnings []string
+	for _, l := range Lines(q) {
+		if l.Limit <= 0 {
+			continue
+		}
+		if l.Resource == ResourceBandwidth {
+			if l.Used >= l.Limit {
+				warnings = append(warnings, fmt.Sprintf("bandwidth limit reached (%s of %s this period)", l.Format(l.Used), l.Format(l.Limit)))
+			}
+			continue
+		}
+		if l.Resource == ResourceApps && !newApp {
+			continue
+		}
+		total := requested[l.Resource]
+		if newApp {
+			total += l.Used
+		}
+		if total > l.Limit {
+			warnings = append(warnings, fmt.Sprintf("%s would exceed the %s plan limit (%s of %s)", l.Resource, q.Plan, l.Format(total), l.Format(l.Limit)))
+		}
+	}
+	return warnings
+}
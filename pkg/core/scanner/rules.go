@@ -0,0 +1,259 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// DefaultRules returns the built-in rule set
+func DefaultRules() []Rule {
+	return []Rule{
+		privilegedRule{},
+		publicDatabaseRule{},
+		weakCredentialRule{},
+		missingTLSRule{},
+		registryTokenRule{},
+	}
+}
+
+// ruleFunc adapts a function into a Rule
+type ruleFunc struct {
+	id    string
+	check func(config *schema.NexlayerYAML) []Finding
+}
+
+// NewRule creates a rule from a function
+func NewRule(id string, check func(config *schema.NexlayerYAML) []Finding) Rule {
+	return ruleFunc{id: id, check: check}
+}
+
+func (r ruleFunc) ID() string                                  { return r.id }
+func (r ruleFunc) Check(config *schema.NexlayerYAML) []Finding { return r.check(config) }
+
+// privilegedRule flags pods that request privileged access to the host
+type privilegedRule struct{}
+
+func (privilegedRule) ID() string { return "privileged-pod" }
+
+func (r privilegedRule) Check(config *schema.NexlayerYAML) []Finding {
+	var findings []Finding
+	for i, pod := range config.Application.Pods {
+		for k, v := range pod.Annotations {
+			if strings.Contains(strings.ToLower(k), "privileged") && strings.EqualFold(v, "true") {
+				findings = append(findings, Finding{
+					RuleID:      r.ID(),
+					Severity:    SeverityCritical,
+					Field:       fmt.Sprintf("pods[%d].annotations.%s", i, k),
+					Message:     fmt.Sprintf("pod %s requests privileged mode", pod.Name),
+					Remediation: "Remove the privileged annotation; run the container as an unprivileged user",
+				})
+			}
+		}
+		for j, vol := range pod.Volumes {
+			if vol.Path == "/var/run/docker.sock" || strings.HasPrefix(vol.Path, "/proc") || strings.HasPrefix(vol.Path, "/sys") {
+				findings = append(findings, Finding{
+					RuleID:      r.ID(),
+					Severity:    SeverityHigh,
+					Field:       fmt.Sprintf("pods[%d].volumes[%d].path", i, j),
+					Message:     fmt.Sprintf("pod %s mounts a host system path (%s)", pod.Name, vol.Path),
+					Remediation: "Mount application data under a dedicated directory such as /data",
+				})
+			}
+		}
+		if usesSudo(pod.Command) || usesSudo(pod.Entrypoint) {
+			findings = append(findings, Finding{
+				RuleID:      r.ID(),
+				Severity:    SeverityMedium,
+				Field:       fmt.Sprintf("pods[%d].command", i),
+				Message:     fmt.Sprintf("pod %s escalates privileges with sudo", pod.Name),
+				Remediation: "Perform privileged setup in the image build instead of at runtime",
+			})
+		}
+	}
+	return findings
+}
+
+// publicDatabaseRule flags database pods that are exposed through a public path
+type publicDatabaseRule struct{}
+
+func (publicDatabaseRule) ID() string { return "public-database" }
+
+func (r publicDatabaseRule) Check(config *schema.NexlayerYAML) []Finding {
+	var findings []Finding
+	for i, pod := range config.Application.Pods {
+		if pod.Path != "" && pod.IsDatabase() {
+			findings = append(findings, Finding{
+				RuleID:      r.ID(),
+				Severity:    SeverityCritical,
+				Field:       fmt.Sprintf("pods[%d].path", i),
+				Message:     fmt.Sprintf("database pod %s is exposed publicly at %s", pod.Name, pod.Path),
+				Remediation: "Remove the path so the database is only reachable from other pods via <pod>.pod",
+			})
+		}
+	}
+	return findings
+}
+
+// weakCredentialRule flags default, short or hardcoded secrets in vars
+type weakCredentialRule struct{}
+
+var weakValues = map[string]bool{
+	"password": true, "passw0rd": true, "admin": true, "root": true, "secret": true,
+	"changeme": true, "default": true, "test": true, "123456": true, "12345678": true,
+	"postgres": true, "mysql": true, "example": true, "qwerty": true, "letmein": true,
+}
+
+func (weakCredentialRule) ID() string { return "weak-credential" }
+
+func (r weakCredentialRule) Check(config *schema.NexlayerYAML) []Finding {
+	var findings []Finding
+	for i, pod := range config.Application.Pods {
+		for j, v := range pod.Vars {
+			if !isSecretKey(v.Key) || isReference(v.Value) {
+				continue
+			}
+			field := fmt.Sprintf("pods[%d].vars[%d]", i, j)
+			switch {
+			case weakValues[strings.ToLower(v.Value)]:
+				findings = append(findings, Finding{
+					RuleID:      r.ID(),
+					Severity:    SeverityHigh,
+					Field:       field,
+					Message:     fmt.Sprintf("%s in pod %s uses a default credential", v.Key, pod.Name),
+					Remediation: "Use a randomly generated value of at least 16 characters",
+				})
+			case len(v.Value) < 12:
+				findings = append(findings, Finding{
+					RuleID:      r.ID(),
+					Severity:    SeverityMedium,
+					Field:       field,
+					Message:     fmt.Sprintf("%s in pod %s is shorter than 12 characters", v.Key, pod.Name),
+					Remediation: "Use a randomly generated value of at least 16 characters",
+				})
+			default:
+				findings = append(findings, Finding{
+					RuleID:      r.ID(),
+					Severity:    SeverityLow,
+					Field:       field,
+					Message:     fmt.Sprintf("%s in pod %s is hardcoded in the configuration", v.Key, pod.Name),
+					Remediation: "Inject the value with a template variable instead of committing it",
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// missingTLSRule flags plaintext URLs and disabled TLS in connection strings
+type missingTLSRule struct{}
+
+func (missingTLSRule) ID() string { return "missing-tls" }
+
+func (r missingTLSRule) Check(config *schema.NexlayerYAML) []Finding {
+	var findings []Finding
+	if strings.HasPrefix(config.Application.URL, "http://") {
+		findings = append(findings, Finding{
+			RuleID:      r.ID(),
+			Severity:    SeverityHigh,
+			Field:       "application.url",
+			Message:     "application URL does not use HTTPS",
+			Remediation: "Use an https:// URL",
+		})
+	}
+	for i, pod := range config.Application.Pods {
+		for j, v := range pod.Vars {
+			value := strings.ToLower(v.Value)
+			field := fmt.Sprintf("pods[%d].vars[%d]", i, j)
+			switch {
+			case (strings.Contains(value, "sslmode=disable") || strings.Contains(value, "ssl=false") || strings.Contains(value, "tls=false")) &&
+				!strings.Contains(value, ".pod"):
+				findings = append(findings, Finding{
+					RuleID:      r.ID(),
+					Severity:    SeverityMedium,
+					Field:       field,
+					Message:     fmt.Sprintf("%s in pod %s disables TLS", v.Key, pod.Name),
+					Remediation: "Enable TLS for connections that leave the cluster",
+				})
+			case strings.HasPrefix(value, "http://") && !isInternalHost(value):
+				findings = append(findings, Finding{
+					RuleID:      r.ID(),
+					Severity:    SeverityLow,
+					Field:       field,
+					Message:     fmt.Sprintf("%s in pod %s points to a plaintext HTTP endpoint", v.Key, pod.Name),
+					Remediation: "Use https:// for external endpoints",
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// registryTokenRule flags hardcoded or broadly scoped registry tokens
+type registryTokenRule struct{}
+
+func (registryTokenRule) ID() string { return "registry-token" }
+
+func (r registryTokenRule) Check(config *schema.NexlayerYAML) []Finding {
+	login := config.Application.RegistryLogin
+	if login == nil || login.PersonalAccessToken == "" || isReference(login.PersonalAccessToken) {
+		return nil
+	}
+	token := login.PersonalAccessToken
+	if strings.HasPrefix(token, "ghp_") {
+		return []Finding{{
+			RuleID:      r.ID(),
+			Severity:    SeverityHigh,
+			Field:       "application.registryLogin.personalAccessToken",
+			Message:     "registry login uses a classic GitHub token, which grants access to every repository",
+			Remediation: "Use a fine-grained token limited to read:packages",
+		}}
+	}
+	return []Finding{{
+		RuleID:      r.ID(),
+		Severity:    SeverityMedium,
+		Field:       "application.registryLogin.personalAccessToken",
+		Message:     "registry token is hardcoded in the configuration",
+		Remediation: "Inject the token with a template variable and scope it to pulling images only",
+	}}
+}
+
+// usesSudo reports whether a command runs sudo, directly or through a shell
+func usesSudo(cmd schema.Command) bool {
+	for _, arg := range cmd {
+		if arg == "sudo" || strings.HasPrefix(arg, "sudo ") || strings.Contains(arg, " sudo ") {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecretKey reports whether an environment variable name holds a secret
+func isSecretKey(key string) bool {
+	k := strings.ToUpper(key)
+	for _, s := range []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "API_KEY", "APIKEY", "PRIVATE_KEY"} {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReference reports whether a value is filled in at deploy time rather than hardcoded
+func isReference(value string) bool {
+	return value == "" || strings.Contains(value, "<%") || strings.Contains(value, "${") || strings.Contains(value, "{{")
+}
+
+// isInternalHost reports whether a URL targets another pod in the deployment
+func isInternalHost(url string) bool {
+	host := strings.TrimPrefix(url, "http://")
+	if i := strings.IndexAny(host, ":/"); i >= 0 {
+		host = host[:i]
+	}
+	return strings.HasSuffix(host, ".pod") || host == "localhost" || host == "127.0.0.1"
+}
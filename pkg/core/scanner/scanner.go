@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package scanner checks Nexlayer configurations for insecure settings.
+package scanner
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Severity ranks how serious a finding is
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = []string{"info", "low", "medium", "high", "critical"}
+
+// String returns the lowercase severity name
+func (s Severity) String() string {
+	if s < SeverityInfo || s > SeverityCritical {
+		return "unknown"
+	}
+	return severityNames[s]
+}
+
+// MarshalText encodes the severity by name
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ParseSeverity converts a severity name into a Severity
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(n, name) {
+			return Severity(i), nil
+		}
+	}
+	return SeverityInfo, fmt.Errorf("unknown severity %q (use %s)", name, strings.Join(severityNames, ", "))
+}
+
+// Finding is a single security issue
+type Finding struct {
+	RuleID      string   `json:"ruleId"`
+	Severity    Severity `json:"severity"`
+	Field       string   `json:"field"`
+	Message     string   `json:"message"`
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// Rule inspects a configuration and reports findings
+type Rule interface {
+	ID() string
+	Check(config *schema.NexlayerYAML) []Finding
+}
+
+// SecurityScanner runs a set of rules against configurations
+type SecurityScanner struct {
+	rules []Rule
+}
+
+// NewSecurityScanner creates a scanner with the built-in rules
+func NewSecurityScanner() *SecurityScanner {
+	return &SecurityScanner{rules: DefaultRules()}
+}
+
+// AddRule registers an additional rule
+func (s *SecurityScanner) AddRule(rule Rule) {
+	s.rules = append(s.rules, rule)
+}
+
+// Scan runs every rule and returns findings ordered by severity, most severe first
+func (s *SecurityScanner) Scan(config *schema.NexlayerYAML) []Finding {
+	var findings []Finding
+	for _, rule := range s.rules {
+		findings = append(findings, rule.Check(config)...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+	return findings
+}
+
+// MaxSeverity returns the highest severity among findings, or -1 when there are none
+func MaxSeverity(findings []Finding) Severity {
+	max := Severity(-1)
+	for _, f := range findings {
+		if f.Severity > max {
+			max = f.Severity
+		}
+	}
+	return max
+}
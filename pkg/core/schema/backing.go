@@ -0,0 +1,186 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BackingService is the shorthand for a service in application.services,
+// keyed by kind, e.g.
+//
+//	services:
+//	  postgres: {version: 16, size: 10Gi}
+//
+// It expands into a pod with a volume and credentials, and the other pods get
+// a connection variable such as DATABASE_URL.
+type BackingService struct {
+	Name    string `yaml:"name,omitempty"`    // pod name, defaults to the kind
+	Version string `yaml:"version,omitempty"` // image tag
+	Size    string `yaml:"size,omitempty" validate:"omitempty,volumesize"`
+	Class   string `yaml:"class,omitempty" validate:"omitempty,oneof=standard ssd"`
+	Seed    *Seed  `yaml:"seed,omitempty"` // initial data, see Seed
+}
+
+// serviceKind describes how a kind of service expands. Credentials are
+// placeholders such as <% POSTGRES_PASSWORD %> that the platform fills in.
+type serviceKind struct {
+	image, version string
+	port           int
+	dataPath       string
+	env            []EnvVar // of the service pod
+	command        []string // of the service pod
+	urlKey, url    string   // connection variable; {name} is the pod name
+}
+
+// serviceKinds are the kinds accepted in application.services
+var serviceKinds = map[string]serviceKind{
+	PodTypePostgres: {
+		image: "postgres", version: "16", port: 5432,
+		dataPath: "/var/lib/postgresql/data",
+		env: []EnvVar{
+			{Key: "POSTGRES_USER", Value: "postgres"},
+			{Key: "POSTGRES_PASSWORD", Value: "<% POSTGRES_PASSWORD %>"},
+			{Key: "POSTGRES_DB", Value: "app"},
+			{Key: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+		},
+		urlKey: "DATABASE_URL", url: "postgresql://postgres:<% POSTGRES_PASSWORD %>@{name}.pod:5432/app",
+	},
+	PodTypeMySQL: {
+		image: "mysql", version: "8.4", port: 3306,
+		dataPath: "/var/lib/mysql",
+		env: []EnvVar{
+			{Key: "MYSQL_ROOT_PASSWORD", Value: "<% MYSQL_ROOT_PASSWORD %>"},
+			{Key: "MYSQL_DATABASE", Value: "app"},
+		},
+		urlKey: "MYSQL_URL", url: "mysql://root:<% MYSQL_ROOT_PASSWORD %>@{name}.pod:3306/app",
+	},
+	PodTypeMongoDB: {
+		image: "mongo", version: "7", port: 27017,
+		dataPath: "/data/db",
+		env: []EnvVar{
+			{Key: "MONGO_INITDB_ROOT_USERNAME", Value: "root"},
+			{Key: "MONGO_INITDB_ROOT_PASSWORD", Value: "<% MONGO_ROOT_PASSWORD %>"},
+		},
+		urlKey: "MONGODB_URI", url: "mongodb://root:<% MONGO_ROOT_PASSWORD %>@{name}.pod:27017/app?authSource=admin",
+	},
+	PodTypeRedis: {
+		image: "redis", version: "7", port: 6379,
+		dataPath: "/data",
+		env: []EnvVar{
+			{Key: "REDIS_PASSWORD", Value: "<% REDIS_PASSWORD %>"},
+		},
+		command: []string{"sh", "-c", `exec redis-server --appendonly yes --requirepass "$REDIS_PASSWORD"`},
+		urlKey:  "REDIS_URL", url: "redis://:<% REDIS_PASSWORD %>@{name}.pod:6379",
+	},
+}
+
+// DefaultServiceSize is the volume size of a service without size
+const DefaultServiceSize = "10Gi"
+
+// ServiceKinds returns the kinds accepted in application.services, sorted
+func ServiceKinds() []string {
+	kinds := make([]string, 0, len(serviceKinds))
+	for kind := range serviceKinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// ExpandServices replaces application.services with the pods they stand for,
+// in kind order, and adds each service's connection variable to the other
+// pods unless they set it already. It reports whether there was anything to
+// expand.
+func ExpandServices(config *NexlayerYAML) (bool, error) {
+	services := config.Application.Services
+	if len(services) == 0 {
+		return false, nil
+	}
+
+	taken := make(map[string]bool)
+	for _, pod := range config.Application.Pods {
+		for _, name := range pod.HostNames() {
+			taken[name] = true
+		}
+	}
+
+	kinds := make([]string, 0, len(services))
+	for kind := range services {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var pods []Pod
+	var wired []EnvVar
+	for _, kind := range kinds {
+		k, ok := serviceKinds[strings.ToLower(kind)]
+		if !ok {
+			return false, fmt.Errorf("services.%s: unsupported service; supported: %s", kind, strings.Join(ServiceKinds(), ", "))
+		}
+		svc := services[kind]
+		pod := k.pod(strings.ToLower(kind), svc)
+		if taken[pod.Name] {
+			return false, fmt.Errorf("services.%s: a pod named %s already exists; set a different name", kind, pod.Name)
+		}
+		taken[pod.Name] = true
+		pods = append(pods, pod)
+		wired = append(wired, EnvVar{Key: k.urlKey, Value: strings.ReplaceAll(k.url, "{name}", pod.Name)})
+	}
+
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		if pod.Static != nil {
+			continue
+		}
+		set := make(map[string]bool, len(pod.Vars))
+		for _, v := range pod.Vars {
+			set[v.Key] = true
+		}
+		for _, v := range wired {
+			if !set[v.Key] {
+				pod.Vars = append(pod.Vars, v)
+			}
+		}
+	}
+
+	config.Application.Pods = append(config.Application.Pods, pods...)
+	config.Application.Services = nil
+	return true, nil
+}
+
+// pod returns the fully specified pod of a service
+func (k serviceKind) pod(kind string, svc BackingService) Pod {
+	name := svc.Name
+	if name == "" {
+		name = kind
+	}
+	version := svc.Version
+	if version == "" {
+		version = k.version
+	}
+	size := svc.Size
+	if size == "" {
+		size = DefaultServiceSize
+	}
+
+	return Pod{
+		Name:    name,
+		Type:    kind,
+		Image:   k.image + ":" + version,
+		Command: append(Command(nil), k.command...),
+		Vars:    append([]EnvVar(nil), k.env...),
+		Volumes: []Volume{{
+			Name:  name + "-data",
+			Path:  k.dataPath,
+			Size:  size,
+			Class: svc.Class,
+		}},
+		ServicePorts: []ServicePort{{Name: kind, Port: k.port, TargetPort: k.port}},
+		Seed:         svc.Seed,
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDockerfile is the Dockerfile of a build context when none is set
+const DefaultDockerfile = "Dockerfile"
+
+// ImageBuild is how the image of a pod is built from source, used by
+// nexlayer deploy --watch-files to rebuild and push it on changes, e.g.
+//
+//	build:
+//	  context: ./api
+//	  dockerfile: Dockerfile.dev
+type ImageBuild struct {
+	Context    string            `yaml:"context" validate:"required"` // relative to nexlayer.yaml
+	Dockerfile string            `yaml:"dockerfile,omitempty"`        // relative to context
+	Args       map[string]string `yaml:"args,omitempty"`              // build arguments
+}
+
+// Dir returns the build context, relative to baseDir
+func (b ImageBuild) Dir(baseDir string) string {
+	if filepath.IsAbs(b.Context) {
+		return b.Context
+	}
+	return filepath.Join(baseDir, b.Context)
+}
+
+// DockerfilePath returns the Dockerfile of the build, relative to baseDir
+func (b ImageBuild) DockerfilePath(baseDir string) string {
+	name := b.Dockerfile
+	if name == "" {
+		name = DefaultDockerfile
+	}
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(b.Dir(baseDir), name)
+}
+
+// ResolveRegistry replaces the registry placeholder of an image with the
+// registry of registryLogin, if the application has one
+func ResolveRegistry(config *NexlayerYAML, image string) string {
+	if config.Application.RegistryLogin == nil || !strings.Contains(image, RegistryPlaceholder) {
+		return image
+	}
+	registry := strings.TrimSuffix(config.Application.RegistryLogin.Registry, "/")
+	return strings.ReplaceAll(image, RegistryPlaceholder, registry)
+}
+
+// SetPodBuild points a pod of a deployment file at an image built from
+// source, keeping the comments of the file
+func SetPodBuild(data []byte, pod, image string, build ImageBuild) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("the configuration is empty")
+	}
+	var target *yaml.Node
+	for _, n := range podNodes(doc.Content[0]) {
+		if mappingValue(n, "name") == pod {
+			target = n
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("pod %s not found", pod)
+	}
+
+	var buildNode yaml.Node
+	if err := buildNode.Encode(build); err != nil {
+		return nil, fmt.Errorf("failed to encode build: %w", err)
+	}
+	setValue(target, "image", scalarNode(image))
+	setValue(target, "build", &buildNode)
+	out, err := encodeDocument(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	return out, nil
+}
+
+// setValue sets key in a mapping, adding it last if missing
+func setValue(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, scalarNode(key), value)
+}
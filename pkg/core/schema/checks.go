@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Check is a synthetic HTTP check run against the deployed application by
+// nexlayer test, e.g.
+//
+//	checks:
+//	  - name: health
+//	    path: /api/health
+//	    status: 200
+//	    latency: 300ms
+//	    body: '"ok"'
+type Check struct {
+	Name      string            `yaml:"name,omitempty"`
+	Path      string            `yaml:"path" validate:"required,startswith=/"`
+	Method    string            `yaml:"method,omitempty"`
+	Headers   map[string]string `yaml:"headers,omitempty"`
+	Status    int               `yaml:"status,omitempty" validate:"omitempty,min=100,max=599"`
+	Latency   string            `yaml:"latency,omitempty"`   // budget, e.g. 500ms
+	Body      string            `yaml:"body,omitempty"`      // substring the body must contain
+	BodyRegex string            `yaml:"bodyRegex,omitempty"` // pattern the body must match
+}
+
+// DefaultCheckStatus is the status a check expects when it sets none
+const DefaultCheckStatus = http.StatusOK
+
+// Label names the check in reports, defaulting to its method and path
+func (c Check) Label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.HTTPMethod() + " " + c.Path
+}
+
+// HTTPMethod returns the upper-cased method, defaulting to GET
+func (c Check) HTTPMethod() string {
+	if c.Method == "" {
+		return http.MethodGet
+	}
+	return strings.ToUpper(c.Method)
+}
+
+// ExpectedStatus returns the expected status, defaulting to 200
+func (c Check) ExpectedStatus() int {
+	if c.Status == 0 {
+		return DefaultCheckStatus
+	}
+	return c.Status
+}
+
+// LatencyBudget parses the latency budget; zero means none
+func (c Check) LatencyBudget() (time.Duration, error) {
+	if c.Latency == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(c.Latency)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid latency budget %q; use a duration such as 500ms or 2s", c.Latency)
+	}
+	return d, nil
+}
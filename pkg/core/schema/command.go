@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Command is a pod's command or entrypoint as an argument list. In YAML it is
+// either an array, passed to the container as is, or a string, which is split
+// into arguments with shell-style quoting for compatibility with older files.
+type Command []string
+
+// ParseCommand splits a command line into arguments. Single and double quotes
+// group words and a backslash escapes the next character outside single quotes.
+func ParseCommand(line string) (Command, error) {
+	var args Command
+	var cur strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %q", quote, line)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash in %q", line)
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
+// String joins the arguments into a command line, quoting those that need it
+func (c Command) String() string {
+	parts := make([]string, len(c))
+	for i, arg := range c {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`") {
+			parts[i] = arg
+		} else {
+			parts[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// UnmarshalYAML accepts both the array and the string form
+func (c *Command) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.SequenceNode:
+		var args []string
+		if err := value.Decode(&args); err != nil {
+			return err
+		}
+		*c = args
+		return nil
+	case yaml.ScalarNode:
+		args, err := ParseCommand(value.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", value.Line, err)
+		}
+		*c = args
+		return nil
+	}
+	return fmt.Errorf("line %d: command must be a string or an array of strings", value.Line)
+}
+
+// MarshalYAML writes the string form when it splits back into the same
+// arguments, so existing files keep their shape, and the array form otherwise
+func (c Command) MarshalYAML() (interface{}, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	for _, arg := range c {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"\\") {
+			return []string(c), nil
+		}
+	}
+	return strings.Join(c, " "), nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ConfigChecksumAnnotation records a digest of a pod's config files. Any change
+// to the files changes the pod spec, so the platform restarts the pod even when
+// nothing else was edited.
+const ConfigChecksumAnnotation = "nexlayer.io/config-checksum"
+
+// HasConfigFiles reports whether any pod declares config files
+func HasConfigFiles(config *NexlayerYAML) bool {
+	for _, pod := range config.Application.Pods {
+		if len(pod.ConfigFiles) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveConfigFiles inlines the content of config files that name a Source,
+// relative to baseDir, defaults FileName to the source's base name and stamps
+// each pod with ConfigChecksumAnnotation
+func ResolveConfigFiles(config *NexlayerYAML, baseDir string) error {
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		if len(pod.ConfigFiles) == 0 {
+			continue
+		}
+		for j := range pod.ConfigFiles {
+			cf := &pod.ConfigFiles[j]
+			if cf.Source != "" {
+				src := cf.Source
+				if !filepath.IsAbs(src) {
+					src = filepath.Join(baseDir, src)
+				}
+				data, err := os.ReadFile(src)
+				if err != nil {
+					return fmt.Errorf("pod %s: config file %s: %w", pod.Name, cf.Name, err)
+				}
+				if cf.FileName == "" {
+					cf.FileName = filepath.Base(cf.Source)
+				}
+				cf.Content = string(data)
+				cf.Source = ""
+			}
+			if cf.FileName == "" {
+				cf.FileName = cf.Name
+			}
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[ConfigChecksumAnnotation] = ConfigChecksum(*pod)
+	}
+	return nil
+}
+
+// ConfigChecksum returns a digest of a pod's resolved config files that does
+// not depend on their order
+func ConfigChecksum(pod Pod) string {
+	files := append([]ConfigFile(nil), pod.ConfigFiles...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	h := sha256.New()
+	for _, cf := range files {
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00%d\x00", cf.Name, cf.Path, cf.FileName, len(cf.Content))
+		h.Write([]byte(cf.Content))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
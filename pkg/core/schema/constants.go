@@ -11,6 +11,7 @@ const (
 	PodTypeReact    = "react"
 	PodTypeNextJS   = "nextjs"
 	PodTypeVue      = "vue"
+	PodTypeStatic   = "static"
 
 	// Backend pod types
 	PodTypeBackend = "backend"
@@ -48,6 +49,11 @@ const (
 	PodTypeHFModel  = "huggingface"
 	PodTypeVertexAI = "vertexai"
 	PodTypeJupyter  = "jupyter"
+
+	// Task pod types, which run to completion: a job once per deployment and
+	// a cron job on its schedule
+	PodTypeJob     = "job"
+	PodTypeCronJob = "cronjob"
 )
 
 // Protocol types
@@ -56,12 +62,36 @@ const (
 	ProtocolUDP = "UDP"
 )
 
+// Protocols lists the servicePorts protocols accepted by the platform
+var Protocols = []string{ProtocolTCP, ProtocolUDP}
+
+// GPU types accepted in resources.gpu.type
+const (
+	GPUTypeT4   = "nvidia-t4"
+	GPUTypeL4   = "nvidia-l4"
+	GPUTypeA10G = "nvidia-a10g"
+	GPUTypeA100 = "nvidia-a100"
+	GPUTypeH100 = "nvidia-h100"
+
+	// DefaultGPUType is used when resources.gpu.type is omitted
+	DefaultGPUType = GPUTypeT4
+)
+
+// GPUTypes lists every supported GPU type
+var GPUTypes = []string{GPUTypeT4, GPUTypeL4, GPUTypeA10G, GPUTypeA100, GPUTypeH100}
+
 // Volume types
 const (
 	VolumeTypePersistent = "persistent"
 	VolumeTypeEphemeral  = "ephemeral"
 )
 
+// Volume storage classes
+const (
+	VolumeClassStandard = "standard"
+	VolumeClassSSD      = "ssd"
+)
+
 // Registry and image defaults
 const (
 	DefaultRegistry = "ghcr.io/nexlayer"
@@ -89,6 +119,7 @@ var DefaultPorts = map[string]int{
 	PodTypeMySQL:    3306,
 	PodTypeOllama:   11434,
 	PodTypeJupyter:  8888,
+	PodTypeStatic:   StaticPort,
 }
 
 // Default environment variables for different pod types
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+// ContainerOfLabel marks a compose service as an init container or sidecar
+// of the pod it names, since compose has no notion of either
+const ContainerOfLabel = "nexlayer.io/container-of"
+
+// Container is an extra container of a pod, sharing its network, so that
+// they reach each other on localhost, e.g.
+//
+//	initContainers:
+//	  - name: migrate
+//	    image: ghcr.io/acme/api:v1
+//	    command: [npm, run, migrate]
+//	sidecars:
+//	  - name: logs
+//	    image: fluent/fluent-bit:3.0
+//
+// Init containers run one after the other before the pod starts, each until
+// it exits; the pod does not start when one fails. Sidecars run next to the
+// pod for as long as it runs, e.g. to ship logs or proxy traffic.
+type Container struct {
+	Name       string   `yaml:"name" validate:"required,podname"`
+	Image      string   `yaml:"image" validate:"required,image"`
+	Entrypoint Command  `yaml:"entrypoint,omitempty" validate:"omitempty"`
+	Command    Command  `yaml:"command,omitempty" validate:"omitempty"`
+	Vars       []EnvVar `yaml:"vars,omitempty" validate:"omitempty,dive"`
+}
+
+// Containers returns the init containers and sidecars of a pod
+func (p Pod) Containers() []Container {
+	containers := make([]Container, 0, len(p.InitContainers)+len(p.Sidecars))
+	containers = append(containers, p.InitContainers...)
+	return append(containers, p.Sidecars...)
+}
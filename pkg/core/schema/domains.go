@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// domainLabelPattern matches a label of a domain name
+var domainLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ValidateDomain checks that name is a domain an application can be served
+// at: lower-case labels of letters, digits and hyphens, under a top-level
+// domain. A wildcard domain, *.example.com, covers the subdomains of its
+// parent.
+func ValidateDomain(name string) error {
+	if name == "" {
+		return fmt.Errorf("domain cannot be empty")
+	}
+	if strings.Contains(name, "://") {
+		return fmt.Errorf("%s is a URL; use the domain alone, e.g. example.com", name)
+	}
+	if len(name) > 253 {
+		return fmt.Errorf("%s is longer than 253 characters", name)
+	}
+
+	labels := strings.Split(strings.TrimPrefix(name, "*."), ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("%s has no top-level domain, e.g. example.com", name)
+	}
+	for _, label := range labels {
+		if !domainLabelPattern.MatchString(label) {
+			return fmt.Errorf("%s is not a valid domain: labels use lower-case letters, digits and hyphens, not at either end", name)
+		}
+	}
+	if tld := labels[len(labels)-1]; strings.Trim(tld, "0123456789") == "" {
+		return fmt.Errorf("%s is an IP address, not a domain", name)
+	}
+	return nil
+}
+
+// IsWildcardDomain reports whether name covers the subdomains of its parent
+func IsWildcardDomain(name string) bool {
+	return strings.HasPrefix(name, "*.")
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaURL is where the JSON Schema of nexlayer.yaml is published. The file
+// is schemas/nexlayer.schema.json in the CLI repository, regenerated with
+// nexlayer schema export --out-file schemas/nexlayer.schema.json.
+const SchemaURL = "https://raw.githubusercontent.com/Nexlayer/nexlayer-cli/main/schemas/nexlayer.schema.json"
+
+// LanguageServerHeader is the comment that points YAML editor plugins, such
+// as those of VS Code and IntelliJ, at the schema of nexlayer.yaml
+const LanguageServerHeader = "# yaml-language-server: $schema=" + SchemaURL + "\n"
+
+// JSONSchema returns the JSON Schema of nexlayer.yaml, as editors read it
+func JSONSchema() ([]byte, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(SchemaV2), &doc); err != nil {
+		return nil, fmt.Errorf("invalid built-in schema: %w", err)
+	}
+	doc["$id"] = SchemaURL
+	doc["title"] = "Nexlayer deployment configuration"
+	doc["description"] = "nexlayer.yaml describes an application deployed with Nexlayer"
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode schema: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// WithSchemaHeader prepends LanguageServerHeader to the contents of a
+// deployment file unless it already declares a schema
+func WithSchemaHeader(content []byte) []byte {
+	if bytes.Contains(content, []byte("yaml-language-server: $schema=")) {
+		return content
+	}
+	return append([]byte(LanguageServerHeader), content...)
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnvFrom imports a set of environment variables into a pod from one source
+// in KEY=VALUE form. Keys set in vars take precedence over imported ones;
+// otherwise a key imported from two sources is an error.
+type EnvFrom struct {
+	SecretRef string `yaml:"secretRef,omitempty"` // name of one of the pod's secrets
+	ConfigRef string `yaml:"configRef,omitempty"` // name of one of the pod's configFiles
+	File      string `yaml:"file,omitempty"`      // local file, relative to nexlayer.yaml
+	Prefix    string `yaml:"prefix,omitempty"`    // prepended to every imported key
+}
+
+// Source describes the entry for messages, e.g. "secret db-credentials"
+func (e EnvFrom) Source() string {
+	switch {
+	case e.SecretRef != "":
+		return "secret " + e.SecretRef
+	case e.ConfigRef != "":
+		return "config " + e.ConfigRef
+	case e.File != "":
+		return "file " + e.File
+	}
+	return "empty envFrom entry"
+}
+
+// EnvConflictError reports a variable imported by two envFrom entries
+type EnvConflictError struct {
+	Pod, Key      string
+	First, Second string // sources, as returned by EnvFrom.Source
+}
+
+func (e *EnvConflictError) Error() string {
+	return fmt.Sprintf("pod %s: %s is imported from both %s and %s", e.Pod, e.Key, e.First, e.Second)
+}
+
+// HasEnvFrom reports whether any pod imports environment variables
+func HasEnvFrom(config *NexlayerYAML) bool {
+	for _, pod := range config.Application.Pods {
+		if len(pod.EnvFrom) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseEnv parses KEY=VALUE lines, ignoring blanks and # comments. A leading
+// "export " is dropped and values may be wrapped in single or double quotes.
+func ParseEnv(data string) ([]EnvVar, error) {
+	var vars []EnvVar
+	for n, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n+1)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		vars = append(vars, EnvVar{Key: strings.TrimSpace(key), Value: value})
+	}
+	return vars, nil
+}
+
+// EnvFromVars returns the variables a pod imports with envFrom, in order,
+// leaving out those set in vars. Files are read relative to baseDir.
+func EnvFromVars(pod Pod, baseDir string) ([]EnvVar, error) {
+	explicit := make(map[string]bool, len(pod.Vars))
+	for _, v := range pod.Vars {
+		explicit[v.Key] = true
+	}
+
+	var vars []EnvVar
+	from := make(map[string]string) // key -> source
+	for _, e := range pod.EnvFrom {
+		data, err := envFromData(pod, e, baseDir)
+		if err != nil {
+			return nil, err
+		}
+		imported, err := ParseEnv(data)
+		if err != nil {
+			return nil, fmt.Errorf("pod %s: %s: %w", pod.Name, e.Source(), err)
+		}
+		for _, v := range imported {
+			v.Key = e.Prefix + v.Key
+			if explicit[v.Key] {
+				continue
+			}
+			if prev, ok := from[v.Key]; ok {
+				return nil, &EnvConflictError{Pod: pod.Name, Key: v.Key, First: prev, Second: e.Source()}
+			}
+			from[v.Key] = e.Source()
+			vars = append(vars, v)
+		}
+	}
+	return vars, nil
+}
+
+func envFromData(pod Pod, e EnvFrom, baseDir string) (string, error) {
+	file := e.File
+	switch {
+	case e.SecretRef != "":
+		for _, s := range pod.Secrets {
+			if s.Name == e.SecretRef {
+				return s.Data, nil
+			}
+		}
+		return "", fmt.Errorf("pod %s: envFrom references unknown %s", pod.Name, e.Source())
+	case e.ConfigRef != "":
+		for _, cf := range pod.ConfigFiles {
+			if cf.Name == e.ConfigRef {
+				if cf.Source == "" {
+					return cf.Content, nil
+				}
+				file = cf.Source
+			}
+		}
+		if file == "" {
+			return "", fmt.Errorf("pod %s: envFrom references unknown %s", pod.Name, e.Source())
+		}
+	case file == "":
+		return "", fmt.Errorf("pod %s: envFrom entry needs secretRef, configRef or file", pod.Name)
+	}
+
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(baseDir, file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("pod %s: %s: %w", pod.Name, e.Source(), err)
+	}
+	return string(data), nil
+}
+
+// ResolveEnvFrom expands envFrom into each pod's vars, relative to baseDir.
+// Imported variables come first, in order, followed by the pod's own vars.
+func ResolveEnvFrom(config *NexlayerYAML, baseDir string) error {
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		if len(pod.EnvFrom) == 0 {
+			continue
+		}
+		imported, err := EnvFromVars(*pod, baseDir)
+		if err != nil {
+			return err
+		}
+		pod.Vars = append(imported, pod.Vars...)
+		pod.EnvFrom = nil
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Environment is a deployment of the application that releases are promoted
+// through, keyed by name in application.environments, e.g.
+//
+//	environments:
+//	  staging: {namespace: shop-staging}
+//	  prod:
+//	    namespace: shop
+//	    vars: {LOG_LEVEL: warn}
+type Environment struct {
+	Name      string            `yaml:"name,omitempty"`  // application name, defaults to application.name
+	Namespace string            `yaml:"namespace"`       // namespace of the deployment
+	AppID     string            `yaml:"appId,omitempty"` // application to deploy to
+	Vars      map[string]string `yaml:"vars,omitempty"`  // values of vars that differ in this environment
+}
+
+// LookupEnvironment returns the environment called name
+func LookupEnvironment(config *NexlayerYAML, name string) (Environment, error) {
+	env, ok := config.Application.Environments[name]
+	if ok {
+		return env, nil
+	}
+	names := make([]string, 0, len(config.Application.Environments))
+	for n := range config.Application.Environments {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return env, fmt.Errorf("no environments in application.environments")
+	}
+	return env, fmt.Errorf("unknown environment %s; declared: %s", name, strings.Join(names, ", "))
+}
@@ -68,6 +68,8 @@ func (g *Generator) createPodForType(name, podType string, port int) (Pod, error
 		pod.Path = "/"
 	case "express", "fastapi":
 		pod.Path = "/api"
+	case PodTypeLLM, PodTypeOllama, PodTypeHFModel:
+		pod.Resources = &Resources{GPU: &GPU{Count: 1, Type: DefaultGPUType}}
 	}
 
 	return pod, nil
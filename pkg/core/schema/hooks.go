@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"strings"
+	"time"
+)
+
+// Hook events, the points of the lifecycle of an application hooks run at
+const (
+	HookPreInit    = "pre-init"    // before nexlayer init writes nexlayer.yaml
+	HookPostInit   = "post-init"   // after nexlayer init wrote nexlayer.yaml
+	HookPreDeploy  = "pre-deploy"  // before nexlayer deploy starts the deployment
+	HookPostDeploy = "post-deploy" // once the deployment is healthy
+	HookOnFailure  = "on-failure"  // when nexlayer deploy fails
+)
+
+// HookEvents lists the hook events in lifecycle order
+var HookEvents = []string{HookPreInit, HookPostInit, HookPreDeploy, HookPostDeploy, HookOnFailure}
+
+// DefaultHookTimeout bounds a hook step without a timeout
+const DefaultHookTimeout = 10 * time.Minute
+
+// Hooks run shell commands and plugins around the lifecycle of the
+// application, e.g.
+//
+//	hooks:
+//	  preDeploy:
+//	    - run: npm run db:migrate
+//	  postDeploy:
+//	    - name: warm cache
+//	      run: curl -fsS "$NEXLAYER_URL/api/warm"
+//	      continueOnError: true
+//	    - plugin: slack
+//	  onFailure:
+//	    - run: ./scripts/page-oncall.sh
+//
+// A failing pre-init or pre-deploy step aborts the command; failures of the
+// other steps are reported.
+type Hooks struct {
+	PreInit    []HookStep `yaml:"preInit,omitempty" validate:"omitempty,dive"`
+	PostInit   []HookStep `yaml:"postInit,omitempty" validate:"omitempty,dive"`
+	PreDeploy  []HookStep `yaml:"preDeploy,omitempty" validate:"omitempty,dive"`
+	PostDeploy []HookStep `yaml:"postDeploy,omitempty" validate:"omitempty,dive"`
+	OnFailure  []HookStep `yaml:"onFailure,omitempty" validate:"omitempty,dive"`
+}
+
+// HookStep is a shell command, run with sh -c or cmd /C in the directory of
+// the deployment file, or an installed plugin speaking the typed protocol.
+// Exactly one of Run and Plugin is set.
+type HookStep struct {
+	Name            string `yaml:"name,omitempty"`
+	Run             string `yaml:"run,omitempty"`
+	Plugin          string `yaml:"plugin,omitempty"`
+	Timeout         string `yaml:"timeout,omitempty"` // e.g. 5m, defaults to DefaultHookTimeout
+	ContinueOnError bool   `yaml:"continueOnError,omitempty"`
+}
+
+// Steps returns the steps of an event
+func (h *Hooks) Steps(event string) []HookStep {
+	if h == nil {
+		return nil
+	}
+	switch event {
+	case HookPreInit:
+		return h.PreInit
+	case HookPostInit:
+		return h.PostInit
+	case HookPreDeploy:
+		return h.PreDeploy
+	case HookPostDeploy:
+		return h.PostDeploy
+	case HookOnFailure:
+		return h.OnFailure
+	}
+	return nil
+}
+
+// HookAborts reports whether a failing hook of an event aborts the command
+func HookAborts(event string) bool {
+	return strings.HasPrefix(event, "pre-")
+}
+
+// Label returns the name of a step in messages
+func (s HookStep) Label() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.Plugin != "":
+		return "plugin " + s.Plugin
+	}
+	return s.Run
+}
+
+// TimeoutDuration returns how long the step may run
+func (s HookStep) TimeoutDuration() (time.Duration, error) {
+	if s.Timeout == "" {
+		return DefaultHookTimeout, nil
+	}
+	return time.ParseDuration(s.Timeout)
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+// Compose labels marking a service as a job or cron job, since compose has no
+// notion of either, e.g.
+//
+//	labels:
+//	  nexlayer.io/type: cronjob
+//	  nexlayer.io/schedule: "0 3 * * *"
+const (
+	TypeLabel     = "nexlayer.io/type"
+	ScheduleLabel = "nexlayer.io/schedule"
+)
+
+// IsJob reports whether the pod runs to completion, once per deployment or
+// on a schedule, instead of serving
+func (p Pod) IsJob() bool {
+	return p.Type == PodTypeJob || p.Type == PodTypeCronJob
+}
+
+// IsCronJob reports whether the pod runs on its schedule
+func (p Pod) IsCronJob() bool {
+	return p.Type == PodTypeCronJob
+}
@@ -10,9 +10,18 @@ const SchemaV2 = `{
   "type": "object",
   "required": ["application"],
   "properties": {
+    "schemaVersion": {
+      "type": "integer",
+      "minimum": 1,
+      "description": "OPTIONAL: Version of this schema the file is written for; defaults to the newest"
+    },
     "application": {
       "type": "object",
-      "required": ["name", "pods"],
+      "required": ["name"],
+      "anyOf": [
+        {"required": ["pods"]},
+        {"required": ["services"]}
+      ],
       "properties": {
         "name": {
           "type": "string",
@@ -40,24 +49,535 @@ const SchemaV2 = `{
             }
           }
         },
+        "labels": {
+          "type": "object",
+          "additionalProperties": {"type": "string", "maxLength": 63},
+          "propertyNames": {"pattern": "^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$"},
+          "description": "OPTIONAL: Labels for selecting the application, e.g. team: payments"
+        },
+        "annotations": {
+          "type": "object",
+          "additionalProperties": {"type": "string"},
+          "propertyNames": {"pattern": "^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$"},
+          "description": "OPTIONAL: Free-form metadata for integrations, e.g. nexlayer.ai/llm-provider"
+        },
+        "services": {
+          "type": "object",
+          "propertyNames": {"enum": ["postgres", "mysql", "mongodb", "redis"]},
+          "additionalProperties": {
+            "type": "object",
+            "properties": {
+              "name": {
+                "type": "string",
+                "pattern": "^[a-z][a-z0-9\\-]*$",
+                "description": "OPTIONAL: Pod name, defaults to the service kind"
+              },
+              "version": {
+                "type": ["string", "number"],
+                "description": "OPTIONAL: Image tag (e.g., 16 for postgres:16)"
+              },
+              "size": {
+                "type": "string",
+                "pattern": "^[0-9]+(\\.[0-9]+)?(Mi|Gi|Ti|M|G|T)$",
+                "description": "OPTIONAL: Volume size, defaults to 10Gi"
+              },
+              "class": {
+                "type": "string",
+                "enum": ["standard", "ssd"],
+                "description": "OPTIONAL: Volume storage class"
+              },
+              "seed": {
+                "type": "object",
+                "anyOf": [
+                  {"required": ["files"]},
+                  {"required": ["command"]}
+                ],
+                "description": "OPTIONAL: Initial data loaded once; see 'nexlayer seed'",
+                "properties": {
+                  "files": {
+                    "type": "array",
+                    "items": {"type": "string"},
+                    "description": "OPTIONAL: SQL, JavaScript or Redis command files relative to nexlayer.yaml, loaded in order with the database client"
+                  },
+                  "command": {
+                    "oneOf": [
+                      {"type": "string"},
+                      {"type": "array", "items": {"type": "string"}}
+                    ],
+                    "description": "OPTIONAL: Command run instead, with the files under /seed"
+                  },
+                  "image": {
+                    "type": "string",
+                    "description": "OPTIONAL: Image to run instead of the pod's"
+                  },
+                  "marker": {
+                    "type": "string",
+                    "description": "OPTIONAL: Idempotency marker; a seed runs once per marker, change it to seed again"
+                  },
+                  "runPolicy": {
+                    "type": "string",
+                    "enum": ["first-boot", "manual"],
+                    "description": "OPTIONAL: 'first-boot' (default) runs it on deploy once the pod is up; 'manual' only with 'nexlayer seed'"
+                  }
+                }
+              }
+            }
+          },
+          "description": "OPTIONAL: Backing services expanded into pods with a volume and credentials; other pods get DATABASE_URL, MYSQL_URL, MONGODB_URI or REDIS_URL"
+        },
+        "migrations": {
+          "type": "object",
+          "required": ["command"],
+          "anyOf": [
+            {"required": ["pod"]},
+            {"required": ["image"]}
+          ],
+          "description": "OPTIONAL: Schema migrations run once per release; see 'nexlayer migrate'",
+          "properties": {
+            "pod": {
+              "type": "string",
+              "description": "Pod whose image and vars the migrations run with"
+            },
+            "image": {
+              "type": "string",
+              "description": "OPTIONAL: Image to run instead of the pod's"
+            },
+            "command": {
+              "oneOf": [
+                {"type": "string"},
+                {"type": "array", "items": {"type": "string"}}
+              ],
+              "description": "REQUIRED: Migration command (e.g., ['npx', 'prisma', 'migrate', 'deploy'])"
+            },
+            "runPolicy": {
+              "type": "string",
+              "enum": ["before-deploy", "manual"],
+              "description": "OPTIONAL: 'before-deploy' (default) runs them on deploy and blocks the rollout on failure; 'manual' only with 'nexlayer migrate'"
+            }
+          }
+        },
+        "regions": {
+          "type": "object",
+          "required": ["primary"],
+          "description": "OPTIONAL: Deploy to a primary region and replica regions; see 'nexlayer regions'",
+          "properties": {
+            "primary": {
+              "type": "string",
+              "description": "REQUIRED: Primary region (e.g., us-east)"
+            },
+            "replicas": {
+              "type": "array",
+              "items": {"type": "string"},
+              "description": "OPTIONAL: Further regions the application runs in"
+            },
+            "overrides": {
+              "type": "object",
+              "description": "OPTIONAL: Changes per region, keyed by region",
+              "additionalProperties": {
+                "type": "object",
+                "properties": {
+                  "url": {
+                    "type": "string",
+                    "description": "OPTIONAL: URL of the application in this region"
+                  },
+                  "pods": {
+                    "type": "object",
+                    "description": "Changes per pod, keyed by pod name",
+                    "additionalProperties": {
+                      "type": "object",
+                      "properties": {
+                        "image": {"type": "string"},
+                        "vars": {
+                          "type": "array",
+                          "items": {
+                            "type": "object",
+                            "required": ["key", "value"],
+                            "properties": {
+                              "key": {"type": "string"},
+                              "value": {"type": "string"}
+                            }
+                          },
+                          "description": "Vars added to or replacing the pod's vars"
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          }
+        },
+        "environments": {
+          "type": "object",
+          "description": "OPTIONAL: Environments releases are promoted through, by name; see 'nexlayer promote'",
+          "additionalProperties": {
+            "type": "object",
+            "required": ["namespace"],
+            "properties": {
+              "name": {
+                "type": "string",
+                "pattern": "^[a-z][a-z0-9\\-]*$",
+                "description": "OPTIONAL: Application name in this environment, defaults to application.name"
+              },
+              "namespace": {
+                "type": "string",
+                "description": "REQUIRED: Namespace of the environment's deployment"
+              },
+              "appId": {
+                "type": "string",
+                "description": "OPTIONAL: Application to deploy to"
+              },
+              "vars": {
+                "type": "object",
+                "additionalProperties": {"type": "string"},
+                "description": "OPTIONAL: Values of vars that differ in this environment"
+              }
+            }
+          }
+        },
+        "checks": {
+          "type": "array",
+          "description": "OPTIONAL: Synthetic HTTP checks run against the deployed URL; see 'nexlayer test'",
+          "items": {
+            "type": "object",
+            "required": ["path"],
+            "properties": {
+              "name": {
+                "type": "string",
+                "description": "OPTIONAL: Name in reports, defaults to the method and path"
+              },
+              "path": {
+                "type": "string",
+                "pattern": "^/",
+                "description": "REQUIRED: Path requested on the application URL (e.g., '/api/health')"
+              },
+              "method": {
+                "type": "string",
+                "description": "OPTIONAL: HTTP method, defaults to GET"
+              },
+              "headers": {
+                "type": "object",
+                "additionalProperties": {"type": "string"},
+                "description": "OPTIONAL: Request headers"
+              },
+              "status": {
+                "type": "integer",
+                "minimum": 100,
+                "maximum": 599,
+                "description": "OPTIONAL: Expected status, defaults to 200"
+              },
+              "latency": {
+                "type": "string",
+                "pattern": "^[0-9]+(\\.[0-9]+)?(ms|s|m)$",
+                "description": "OPTIONAL: Latency budget (e.g., '500ms')"
+              },
+              "body": {
+                "type": "string",
+                "description": "OPTIONAL: Text the response body must contain"
+              },
+              "bodyRegex": {
+                "type": "string",
+                "description": "OPTIONAL: Regular expression the response body must match"
+              }
+            }
+          }
+        },
+        "deployPolicy": {
+          "type": "object",
+          "description": "OPTIONAL: When deploys are allowed; 'nexlayer deploy --override-freeze <reason>' overrides it",
+          "properties": {
+            "timezone": {
+              "type": "string",
+              "description": "OPTIONAL: IANA timezone of the windows and freezes (default: local)"
+            },
+            "windows": {
+              "type": "array",
+              "description": "OPTIONAL: Deploys are only allowed inside these windows",
+              "items": {
+                "type": "object",
+                "required": ["from", "to"],
+                "properties": {
+                  "days": {
+                    "type": "array",
+                    "items": {"type": "string", "enum": ["mon", "tue", "wed", "thu", "fri", "sat", "sun"]},
+                    "description": "OPTIONAL: Days the window opens (default: every day)"
+                  },
+                  "from": {"type": "string", "pattern": "^[0-9]{1,2}:[0-9]{2}$"},
+                  "to": {"type": "string", "pattern": "^[0-9]{1,2}:[0-9]{2}$"}
+                }
+              }
+            },
+            "freezes": {
+              "type": "array",
+              "description": "OPTIONAL: Periods without deploys, weekly ('fri 18:00' to 'mon 08:00') or dated ('2025-12-20' to '2026-01-04')",
+              "items": {
+                "type": "object",
+                "required": ["from", "to"],
+                "properties": {
+                  "name": {"type": "string"},
+                  "from": {"type": "string"},
+                  "to": {"type": "string"},
+                  "reason": {"type": "string"}
+                }
+              }
+            }
+          }
+        },
+        "hooks": {
+          "type": "object",
+          "description": "OPTIONAL: Shell commands and plugins run around init and deploy; a failing pre-init or pre-deploy step aborts the command",
+          "properties": {
+            "preInit": {"$ref": "#/definitions/hookSteps", "description": "OPTIONAL: Before 'nexlayer init' writes nexlayer.yaml"},
+            "postInit": {"$ref": "#/definitions/hookSteps", "description": "OPTIONAL: After 'nexlayer init' wrote nexlayer.yaml"},
+            "preDeploy": {"$ref": "#/definitions/hookSteps", "description": "OPTIONAL: Before the deployment starts"},
+            "postDeploy": {"$ref": "#/definitions/hookSteps", "description": "OPTIONAL: Once the deployment is healthy"},
+            "onFailure": {"$ref": "#/definitions/hookSteps", "description": "OPTIONAL: When the deployment fails"}
+          }
+        },
         "pods": {
           "type": "array",
           "items": {
             "type": "object",
-            "required": ["name", "image", "servicePorts"],
+            "required": ["name"],
+            "anyOf": [
+              {"required": ["image", "servicePorts"]},
+              {"required": ["static"]},
+              {
+                "required": ["image", "type"],
+                "properties": {"type": {"enum": ["job", "cronjob"]}}
+              }
+            ],
             "properties": {
               "name": {
                 "type": "string",
                 "pattern": "^[a-z][a-z0-9\\.\\-]*$",
                 "description": "REQUIRED: Pod name (must start with a lowercase letter, only alphanumeric, '-', or '.')"
               },
+              "type": {
+                "type": "string",
+                "description": "OPTIONAL: Kind of pod (e.g., 'frontend', 'database'); 'static' serves a built site, 'job' runs once per deployment and 'cronjob' on its schedule"
+              },
+              "schedule": {
+                "type": "string",
+                "description": "REQUIRED for type 'cronjob': @hourly, @daily, @weekly, @monthly or a cron expression (e.g., '0 3 * * *')"
+              },
               "path": {
                 "type": "string",
                 "description": "OPTIONAL: Route path for frontend (e.g., '/' for web apps)"
               },
+              "aliases": {
+                "type": "array",
+                "items": {
+                  "type": "string",
+                  "pattern": "^[a-z][a-z0-9\\-]*$"
+                },
+                "description": "OPTIONAL: Extra internal names, each reachable from other pods as <alias>.pod"
+              },
               "image": {
                 "type": "string",
-                "description": "REQUIRED: Docker image path (supports '<% REGISTRY %>' for private images)"
+                "description": "REQUIRED: Docker image path (supports '<% REGISTRY %>' for private images); omitted for static pods"
+              },
+              "canary": {
+                "type": "object",
+                "required": ["image"],
+                "description": "OPTIONAL: Run a new version next to this pod and send it part of the traffic; see 'nexlayer traffic'",
+                "properties": {
+                  "image": {
+                    "type": "string",
+                    "description": "REQUIRED: Image of the new version"
+                  },
+                  "weight": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "maximum": 100,
+                    "description": "OPTIONAL: Percent of traffic sent to the canary (default 0)"
+                  }
+                }
+              },
+              "seed": {
+                "type": "object",
+                "anyOf": [
+                  {"required": ["files"]},
+                  {"required": ["command"]}
+                ],
+                "description": "OPTIONAL: Initial data loaded once; see 'nexlayer seed'",
+                "properties": {
+                  "files": {
+                    "type": "array",
+                    "items": {"type": "string"},
+                    "description": "OPTIONAL: SQL, JavaScript or Redis command files relative to nexlayer.yaml, loaded in order with the database client"
+                  },
+                  "command": {
+                    "oneOf": [
+                      {"type": "string"},
+                      {"type": "array", "items": {"type": "string"}}
+                    ],
+                    "description": "OPTIONAL: Command run instead, with the files under /seed"
+                  },
+                  "image": {
+                    "type": "string",
+                    "description": "OPTIONAL: Image to run instead of the pod's"
+                  },
+                  "marker": {
+                    "type": "string",
+                    "description": "OPTIONAL: Idempotency marker; a seed runs once per marker, change it to seed again"
+                  },
+                  "runPolicy": {
+                    "type": "string",
+                    "enum": ["first-boot", "manual"],
+                    "description": "OPTIONAL: 'first-boot' (default) runs it on deploy once the pod is up; 'manual' only with 'nexlayer seed'"
+                  }
+                }
+              },
+              "build": {
+                "type": "object",
+                "required": ["context"],
+                "description": "OPTIONAL: Build the image from source; 'nexlayer deploy --watch-files' rebuilds and pushes it when the context changes",
+                "properties": {
+                  "context": {
+                    "type": "string",
+                    "description": "REQUIRED: Build context directory, relative to nexlayer.yaml (e.g., './api')"
+                  },
+                  "dockerfile": {
+                    "type": "string",
+                    "description": "OPTIONAL: Dockerfile relative to the context (default 'Dockerfile')"
+                  },
+                  "args": {
+                    "type": "object",
+                    "additionalProperties": {"type": "string"},
+                    "description": "OPTIONAL: Build arguments"
+                  }
+                }
+              },
+              "replicas": {
+                "type": "integer",
+                "minimum": 1,
+                "description": "OPTIONAL: Number of copies of the pod to run (default 1)"
+              },
+              "autoscale": {
+                "type": "object",
+                "required": ["min", "max"],
+                "description": "OPTIONAL: Scale the pod between min and max replicas on CPU use; replaces replicas",
+                "properties": {
+                  "min": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "description": "REQUIRED: Fewest replicas, which the pod starts with"
+                  },
+                  "max": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "description": "REQUIRED: Most replicas"
+                  },
+                  "targetCPU": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "maximum": 100,
+                    "description": "OPTIONAL: Average CPU use to keep replicas at, in percent of the pod's cpu (default 80)"
+                  }
+                }
+              },
+              "probe": {
+                "type": "object",
+                "required": ["command"],
+                "description": "OPTIONAL: Health check run in the pod; pods depending on it (annotation nexlayer.io/depends-on) wait until it passes",
+                "properties": {
+                  "command": {
+                    "oneOf": [
+                      {"type": "string"},
+                      {"type": "array", "items": {"type": "string"}}
+                    ],
+                    "description": "REQUIRED: Command exiting 0 when the pod is healthy (e.g., ['pg_isready', '-U', 'postgres'])"
+                  },
+                  "interval": {
+                    "type": "string",
+                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+                    "description": "OPTIONAL: Time between checks (e.g., '10s')"
+                  },
+                  "timeout": {
+                    "type": "string",
+                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+                    "description": "OPTIONAL: Time a check may take"
+                  },
+                  "retries": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "description": "OPTIONAL: Consecutive failures before the pod is unhealthy"
+                  },
+                  "startPeriod": {
+                    "type": "string",
+                    "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+                    "description": "OPTIONAL: Time after start during which failures do not count"
+                  }
+                }
+              },
+              "probes": {
+                "type": "object",
+                "description": "OPTIONAL: Health checks of the pod; each sets one of httpGet, tcpSocket and exec",
+                "properties": {
+                  "liveness": {
+                    "$ref": "#/definitions/healthProbe",
+                    "description": "OPTIONAL: The pod is restarted when this check fails"
+                  },
+                  "readiness": {
+                    "$ref": "#/definitions/healthProbe",
+                    "description": "OPTIONAL: The pod receives traffic, and dependent pods start, once this check passes"
+                  },
+                  "startup": {
+                    "$ref": "#/definitions/healthProbe",
+                    "description": "OPTIONAL: The other checks wait until this one passes, for slow starting pods"
+                  }
+                }
+              },
+              "static": {
+                "type": "object",
+                "required": ["dir"],
+                "description": "OPTIONAL: Serve a built frontend (e.g., Vite or React) without an image; servicePorts defaults to port 80",
+                "properties": {
+                  "dir": {
+                    "type": "string",
+                    "description": "REQUIRED: Build output directory with an index.html, relative to nexlayer.yaml (e.g., 'dist')"
+                  },
+                  "build": {
+                    "type": "string",
+                    "description": "OPTIONAL: Command run before upload to produce dir (e.g., 'npm run build')"
+                  },
+                  "spa": {
+                    "type": "boolean",
+                    "description": "OPTIONAL: Serve index.html for paths without a file, for client-side routing"
+                  },
+                  "cacheControl": {
+                    "type": "object",
+                    "additionalProperties": {"type": "string"},
+                    "description": "OPTIONAL: Cache-Control header by path pattern (e.g., '/assets/*' or '*.html'); hashed /assets are cached for a year and HTML is revalidated by default"
+                  }
+                }
+              },
+              "entrypoint": {
+                "oneOf": [
+                  {"type": "string"},
+                  {"type": "array", "items": {"type": "string"}}
+                ],
+                "description": "OPTIONAL: Overrides the image entrypoint; an array of arguments (e.g., ['python', '-m', 'app']) or a command line"
+              },
+              "command": {
+                "oneOf": [
+                  {"type": "string"},
+                  {"type": "array", "items": {"type": "string"}}
+                ],
+                "description": "OPTIONAL: Overrides the image command; use the array form for arguments containing spaces or quotes"
+              },
+              "labels": {
+                "type": "object",
+                "additionalProperties": {"type": "string", "maxLength": 63},
+                "propertyNames": {"pattern": "^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$"},
+                "description": "OPTIONAL: Labels for selecting the pod, e.g. team: payments"
+              },
+              "annotations": {
+                "type": "object",
+                "additionalProperties": {"type": "string"},
+                "propertyNames": {"pattern": "^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$"},
+                "description": "OPTIONAL: Free-form metadata for integrations, e.g. nexlayer.ai/llm-provider"
               },
               "volumes": {
                 "type": "array",
@@ -77,6 +597,26 @@ const SchemaV2 = `{
                     "mountPath": {
                       "type": "string",
                       "description": "REQUIRED: Path inside the container"
+                    },
+                    "class": {
+                      "type": "string",
+                      "enum": ["standard", "ssd"],
+                      "description": "OPTIONAL: Storage class, 'standard' (default) or 'ssd'"
+                    },
+                    "snapshot": {
+                      "type": "object",
+                      "required": ["schedule", "retention"],
+                      "properties": {
+                        "schedule": {
+                          "type": "string",
+                          "description": "REQUIRED: Cron expression or @hourly, @daily, @weekly, @monthly"
+                        },
+                        "retention": {
+                          "type": "integer",
+                          "minimum": 1,
+                          "description": "REQUIRED: Number of snapshots kept"
+                        }
+                      }
                     }
                   }
                 }
@@ -106,6 +646,66 @@ const SchemaV2 = `{
                   }
                 }
               },
+              "resources": {
+                "type": "object",
+                "properties": {
+                  "cpu": {
+                    "type": ["string", "number"],
+                    "pattern": "^[0-9]+(\\.[0-9]+)?m?$",
+                    "description": "OPTIONAL: CPU cores the pod is guaranteed and limited to, e.g. 500m or 2"
+                  },
+                  "memory": {
+                    "type": "string",
+                    "pattern": "^[0-9]+(\\.[0-9]+)?(Ki|Mi|Gi|Ti)$",
+                    "description": "OPTIONAL: Memory the pod is guaranteed and limited to, e.g. 512Mi or 2Gi"
+                  },
+                  "gpu": {
+                    "type": "object",
+                    "required": ["count"],
+                    "properties": {
+                      "count": {
+                        "type": "integer",
+                        "minimum": 1,
+                        "description": "REQUIRED: Number of GPUs"
+                      },
+                      "type": {
+                        "type": "string",
+                        "enum": ["nvidia-t4", "nvidia-l4", "nvidia-a10g", "nvidia-a100", "nvidia-h100"],
+                        "description": "GPU model (default: nvidia-t4)"
+                      }
+                    }
+                  }
+                }
+              },
+              "configFiles": {
+                "type": "array",
+                "items": {
+                  "type": "object",
+                  "required": ["name", "path"],
+                  "properties": {
+                    "name": {
+                      "type": "string",
+                      "description": "REQUIRED: Config file name"
+                    },
+                    "path": {
+                      "type": "string",
+                      "description": "REQUIRED: Directory where the file is mounted"
+                    },
+                    "fileName": {
+                      "type": "string",
+                      "description": "File name inside path (defaults to the source file name)"
+                    },
+                    "content": {
+                      "type": "string",
+                      "description": "Literal file content (use either content or source)"
+                    },
+                    "source": {
+                      "type": "string",
+                      "description": "Local file read at deploy time, relative to nexlayer.yaml"
+                    }
+                  }
+                }
+              },
               "vars": {
                 "type": "array",
                 "items": {
@@ -123,13 +723,85 @@ const SchemaV2 = `{
                   }
                 }
               },
+              "initContainers": {
+                "type": "array",
+                "items": {"$ref": "#/definitions/container"},
+                "description": "OPTIONAL: Containers run one after the other to completion before the pod starts (e.g., migrations)"
+              },
+              "sidecars": {
+                "type": "array",
+                "items": {"$ref": "#/definitions/container"},
+                "description": "OPTIONAL: Containers run next to the pod, sharing its network (e.g., log shippers or proxies)"
+              },
+              "envFrom": {
+                "type": "array",
+                "description": "OPTIONAL: Import KEY=VALUE sets; keys in vars override imported keys",
+                "items": {
+                  "type": "object",
+                  "oneOf": [
+                    {"required": ["secretRef"]},
+                    {"required": ["configRef"]},
+                    {"required": ["file"]}
+                  ],
+                  "properties": {
+                    "secretRef": {
+                      "type": "string",
+                      "description": "Name of one of the pod's secrets"
+                    },
+                    "configRef": {
+                      "type": "string",
+                      "description": "Name of one of the pod's configFiles"
+                    },
+                    "file": {
+                      "type": "string",
+                      "description": "Local file read at deploy time, relative to nexlayer.yaml"
+                    },
+                    "prefix": {
+                      "type": "string",
+                      "pattern": "^[A-Za-z_][A-Za-z0-9_]*$",
+                      "description": "OPTIONAL: Prepended to every imported key, e.g. DB_"
+                    }
+                  }
+                }
+              },
               "servicePorts": {
                 "type": "array",
                 "items": {
-                  "type": "integer",
-                  "minimum": 1,
-                  "maximum": 65535,
-                  "description": "REQUIRED: Port to expose (e.g., 3000)"
+                  "oneOf": [
+                    {
+                      "type": "integer",
+                      "minimum": 1,
+                      "maximum": 65535,
+                      "description": "REQUIRED: Port to expose (e.g., 3000)"
+                    },
+                    {
+                      "type": "object",
+                      "required": ["name", "port", "targetPort"],
+                      "properties": {
+                        "name": {
+                          "type": "string",
+                          "description": "REQUIRED: Name of the port"
+                        },
+                        "port": {
+                          "type": "integer",
+                          "minimum": 1,
+                          "maximum": 65535,
+                          "description": "REQUIRED: Port exposed by the pod"
+                        },
+                        "targetPort": {
+                          "type": "integer",
+                          "minimum": 1,
+                          "maximum": 65535,
+                          "description": "REQUIRED: Port the container listens on"
+                        },
+                        "protocol": {
+                          "type": "string",
+                          "enum": ["TCP", "UDP"],
+                          "description": "OPTIONAL: 'TCP' (default) or 'UDP'; a port may be listed once per protocol"
+                        }
+                      }
+                    }
+                  ]
                 },
                 "minItems": 1
               }
@@ -139,5 +811,155 @@ const SchemaV2 = `{
         }
       }
     }
+  },
+  "definitions": {
+    "hookSteps": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "oneOf": [
+          {"required": ["run"]},
+          {"required": ["plugin"]}
+        ],
+        "properties": {
+          "name": {
+            "type": "string",
+            "description": "OPTIONAL: Name in messages"
+          },
+          "run": {
+            "type": "string",
+            "description": "Shell command, run in the directory of nexlayer.yaml with NEXLAYER_HOOK, NEXLAYER_APP, NEXLAYER_NAMESPACE, NEXLAYER_URL and NEXLAYER_ERROR set"
+          },
+          "plugin": {
+            "type": "string",
+            "description": "Installed plugin to run; see 'nexlayer plugin'"
+          },
+          "timeout": {
+            "type": "string",
+            "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+            "description": "OPTIONAL: Time the step may take (default: 10m)"
+          },
+          "continueOnError": {
+            "type": "boolean",
+            "description": "OPTIONAL: Run the next steps even when this one fails"
+          }
+        }
+      }
+    },
+    "container": {
+      "type": "object",
+      "required": ["name", "image"],
+      "properties": {
+        "name": {
+          "type": "string",
+          "pattern": "^[a-z][a-z0-9\\.\\-]*$",
+          "description": "REQUIRED: Container name, distinct from the pod and its other containers"
+        },
+        "image": {
+          "type": "string",
+          "description": "REQUIRED: Docker image path (supports '<% REGISTRY %>' for private images)"
+        },
+        "entrypoint": {
+          "oneOf": [
+            {"type": "string"},
+            {"type": "array", "items": {"type": "string"}}
+          ],
+          "description": "OPTIONAL: Overrides the image's entrypoint"
+        },
+        "command": {
+          "oneOf": [
+            {"type": "string"},
+            {"type": "array", "items": {"type": "string"}}
+          ],
+          "description": "OPTIONAL: Overrides the image's command"
+        },
+        "vars": {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": ["key", "value"],
+            "properties": {
+              "key": {"type": "string"},
+              "value": {"type": "string"}
+            }
+          },
+          "description": "OPTIONAL: Environment variables of the container; it does not inherit the pod's"
+        }
+      }
+    },
+    "healthProbe": {
+      "type": "object",
+      "oneOf": [
+        {"required": ["httpGet"]},
+        {"required": ["tcpSocket"]},
+        {"required": ["exec"]}
+      ],
+      "properties": {
+        "httpGet": {
+          "type": "object",
+          "required": ["path"],
+          "description": "Passes when a GET of path answers with a 2xx or 3xx status",
+          "properties": {
+            "path": {
+              "type": "string",
+              "pattern": "^/",
+              "description": "REQUIRED: Path to request (e.g., '/health')"
+            },
+            "port": {
+              "type": "integer",
+              "minimum": 1,
+              "maximum": 65535,
+              "description": "OPTIONAL: Port to request; defaults to the targetPort of the first service port"
+            }
+          }
+        },
+        "tcpSocket": {
+          "type": "object",
+          "description": "Passes when a connection to port can be opened",
+          "properties": {
+            "port": {
+              "type": "integer",
+              "minimum": 1,
+              "maximum": 65535,
+              "description": "OPTIONAL: Port to connect to; defaults to the targetPort of the first service port"
+            }
+          }
+        },
+        "exec": {
+          "type": "object",
+          "required": ["command"],
+          "description": "Passes when command exits 0",
+          "properties": {
+            "command": {
+              "oneOf": [
+                {"type": "string"},
+                {"type": "array", "items": {"type": "string"}}
+              ],
+              "description": "REQUIRED: Command run in the pod (e.g., ['pg_isready', '-U', 'postgres'])"
+            }
+          }
+        },
+        "interval": {
+          "type": "string",
+          "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+          "description": "OPTIONAL: Time between checks (e.g., '10s')"
+        },
+        "timeout": {
+          "type": "string",
+          "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+          "description": "OPTIONAL: Time a check may take"
+        },
+        "retries": {
+          "type": "integer",
+          "minimum": 0,
+          "description": "OPTIONAL: Consecutive failures before the check fails"
+        },
+        "startPeriod": {
+          "type": "string",
+          "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+          "description": "OPTIONAL: Time after start during which failures do not count"
+        }
+      }
+    }
   }
 }`
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxAnnotationsSize is the total size in bytes the platform accepts for the
+// annotations of an application or pod
+const MaxAnnotationsSize = 256 * 1024
+
+var (
+	metadataNameRegex   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	metadataPrefixRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// CheckMetadataKey validates an annotation or label key: an optional DNS
+// subdomain prefix and a slash, followed by a name of at most 63 characters,
+// e.g. "nexlayer.ai/llm-provider" or "team"
+func CheckMetadataKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if prefix == "" || len(prefix) > 253 || !metadataPrefixRegex.MatchString(prefix) {
+			return fmt.Errorf("prefix of %q must be a lowercase DNS subdomain such as example.com", key)
+		}
+	}
+	if name == "" || len(name) > 63 || !metadataNameRegex.MatchString(name) {
+		return fmt.Errorf("name of %q must be 1-63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric", key)
+	}
+	return nil
+}
+
+// CheckLabelValue validates a label value, which is empty or follows the
+// rules of a key name
+func CheckLabelValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > 63 || !metadataNameRegex.MatchString(value) {
+		return fmt.Errorf("label value %q must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric", value)
+	}
+	return nil
+}
+
+// AnnotationsSize returns the size of annotations as counted by the platform
+func AnnotationsSize(annotations map[string]string) int {
+	size := 0
+	for k, v := range annotations {
+		size += len(k) + len(v)
+	}
+	return size
+}
@@ -0,0 +1,280 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchemaVersion is the newest configuration schema this CLI understands.
+// Documents without a schemaVersion are assumed to be current, unless they use
+// the template format of version 0.
+const CurrentSchemaVersion = 2
+
+// SchemaVersionKey is the top-level key declaring a document's schema version
+const SchemaVersionKey = "schemaVersion"
+
+// Migration upgrades a configuration document by one schema version
+type Migration struct {
+	From        int
+	Description string
+	Apply       func(root *yaml.Node) error
+}
+
+// migrations lists every upgrade step, ordered by From
+var migrations = []Migration{
+	{
+		From:        0,
+		Description: "move application.template into application and rename pod tag to image",
+		Apply:       migrateV0,
+	},
+	{
+		From:        1,
+		Description: "convert servicePorts to port objects and rename mountPath to path",
+		Apply:       migrateV1,
+	},
+}
+
+// SchemaTooNewError is returned for documents written for a newer CLI
+type SchemaTooNewError struct {
+	Version int
+}
+
+func (e *SchemaTooNewError) Error() string {
+	return fmt.Sprintf("configuration uses schema version %d but this CLI supports up to version %d; upgrade the Nexlayer CLI to use it",
+		e.Version, CurrentSchemaVersion)
+}
+
+// SchemaVersionOf returns the schema version declared by a document's root
+// mapping. Without a declared version it is 0 for the template format, where
+// pods were declared under application.template, and CurrentSchemaVersion
+// otherwise.
+func SchemaVersionOf(root *yaml.Node) (int, error) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != SchemaVersionKey {
+			continue
+		}
+		v, err := strconv.Atoi(root.Content[i+1].Value)
+		if err != nil || v < 1 {
+			return 0, fmt.Errorf("invalid schemaVersion %q: must be a positive integer", root.Content[i+1].Value)
+		}
+		return v, nil
+	}
+	if t := mappingNode(mappingNode(root, "application"), "template"); t != nil && t.Kind == yaml.MappingNode {
+		return 0, nil
+	}
+	return CurrentSchemaVersion, nil
+}
+
+// MigrateDocument upgrades the contents of a deployment file to
+// CurrentSchemaVersion, keeping its comments, and returns the steps applied.
+// Contents already current, or that are not a YAML mapping, are returned as
+// they are.
+func MigrateDocument(data []byte) ([]byte, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil, nil
+	}
+	applied, err := Migrate(doc.Content[0])
+	if err != nil || len(applied) == 0 {
+		return data, nil, err
+	}
+	out, err := encodeDocument(&doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode migrated configuration: %w", err)
+	}
+	return out, applied, nil
+}
+
+// encodeDocument writes a YAML document with the indentation of nexlayer.yaml
+func encodeDocument(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Migrate upgrades a document's root mapping in place to CurrentSchemaVersion
+// and returns a description of every step applied. Documents declaring a newer
+// version are refused with a *SchemaTooNewError.
+func Migrate(root *yaml.Node) ([]string, error) {
+	version, err := SchemaVersionOf(root)
+	if err != nil {
+		return nil, err
+	}
+	if version > CurrentSchemaVersion {
+		return nil, &SchemaTooNewError{Version: version}
+	}
+
+	var applied []string
+	for _, m := range migrations {
+		if m.From < version {
+			continue
+		}
+		if err := m.Apply(root); err != nil {
+			return nil, fmt.Errorf("failed to migrate from schema version %d: %w", m.From, err)
+		}
+		applied = append(applied, fmt.Sprintf("v%d -> v%d: %s", m.From, m.From+1, m.Description))
+		version = m.From + 1
+	}
+	if len(applied) > 0 {
+		setScalar(root, SchemaVersionKey, strconv.Itoa(version))
+	}
+	return applied, nil
+}
+
+// migrateV0 upgrades the template format, where the application was declared
+// under application.template, pods named their image with tag and were
+// exposed with exposeHttp. The first exposed pod is served at /.
+func migrateV0(root *yaml.Node) error {
+	app := mappingNode(root, "application")
+	tmpl := mappingNode(app, "template")
+	if tmpl == nil || tmpl.Kind != yaml.MappingNode {
+		return nil
+	}
+	removeKey(app, "template")
+	for i := 0; i+1 < len(tmpl.Content); i += 2 {
+		key := tmpl.Content[i].Value
+		if key == "deploymentName" || mappingNode(app, key) != nil {
+			continue
+		}
+		app.Content = append(app.Content, tmpl.Content[i], tmpl.Content[i+1])
+	}
+
+	rootTaken := false
+	for _, pod := range podNodes(root) {
+		rootTaken = rootTaken || mappingValue(pod, "path") == "/"
+	}
+	for _, pod := range podNodes(root) {
+		renameKey(pod, "tag", "image")
+		removeKey(pod, "privateTag")
+		if mappingValue(pod, "exposeHttp") == "true" && mappingNode(pod, "path") == nil && !rootTaken {
+			pod.Content = append(pod.Content, scalarNode("path"), scalarNode("/"))
+			rootTaken = true
+		}
+		removeKey(pod, "exposeHttp")
+	}
+	return nil
+}
+
+// migrateV1 upgrades the original format, where servicePorts were plain
+// numbers and volumes and secrets used mountPath
+func migrateV1(root *yaml.Node) error {
+	for _, pod := range podNodes(root) {
+		podName := mappingValue(pod, "name")
+		if ports := mappingNode(pod, "servicePorts"); ports != nil && ports.Kind == yaml.SequenceNode {
+			for i, port := range ports.Content {
+				if port.Kind != yaml.ScalarNode {
+					continue
+				}
+				name := podName
+				if len(ports.Content) > 1 {
+					name = fmt.Sprintf("%s-%s", podName, port.Value)
+				}
+				ports.Content[i] = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+					scalarNode("name"), scalarNode(name),
+					scalarNode("port"), {Kind: yaml.ScalarNode, Tag: port.Tag, Value: port.Value},
+					scalarNode("targetPort"), {Kind: yaml.ScalarNode, Tag: port.Tag, Value: port.Value},
+				}}
+			}
+		}
+		for _, key := range []string{"volumes", "secrets"} {
+			list := mappingNode(pod, key)
+			if list == nil || list.Kind != yaml.SequenceNode {
+				continue
+			}
+			for _, item := range list.Content {
+				renameKey(item, "mountPath", "path")
+			}
+		}
+	}
+	return nil
+}
+
+// podNodes returns the pod mappings under application.pods
+func podNodes(root *yaml.Node) []*yaml.Node {
+	app := mappingNode(root, "application")
+	if app == nil {
+		return nil
+	}
+	pods := mappingNode(app, "pods")
+	if pods == nil || pods.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var out []*yaml.Node
+	for _, p := range pods.Content {
+		if p.Kind == yaml.MappingNode {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// mappingNode returns the value node for key in a mapping, or nil
+func mappingNode(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the scalar value for key in a mapping, or ""
+func mappingValue(m *yaml.Node, key string) string {
+	if n := mappingNode(m, key); n != nil && n.Kind == yaml.ScalarNode {
+		return n.Value
+	}
+	return ""
+}
+
+// renameKey renames a mapping key unless the new key is already present
+func renameKey(m *yaml.Node, from, to string) {
+	if m.Kind != yaml.MappingNode || mappingNode(m, to) != nil {
+		return
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == from {
+			m.Content[i].Value = to
+		}
+	}
+}
+
+// removeKey removes a key from a mapping
+func removeKey(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// setScalar sets key to a scalar value in a mapping, adding it first if missing
+func setScalar(m *yaml.Node, key, value string) {
+	if n := mappingNode(m, key); n != nil {
+		n.Kind, n.Tag, n.Value, n.Content = yaml.ScalarNode, "!!int", value, nil
+		return
+	}
+	m.Content = append([]*yaml.Node{scalarNode(key), {Kind: yaml.ScalarNode, Tag: "!!int", Value: value}}, m.Content...)
+}
+
+// scalarNode creates a plain string scalar
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
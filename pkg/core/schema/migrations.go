@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Migration run policies
+const (
+	MigrationsBeforeDeploy = "before-deploy" // run by nexlayer deploy before the rollout
+	MigrationsManual       = "manual"        // run only by nexlayer migrate
+)
+
+// MigrationPolicies lists the accepted runPolicy values
+var MigrationPolicies = []string{MigrationsBeforeDeploy, MigrationsManual}
+
+// Migrations is a one-off command, typically database schema migrations, run
+// once per release. It runs with the image and vars of Pod unless Image is set.
+type Migrations struct {
+	Pod       string  `yaml:"pod,omitempty"`
+	Image     string  `yaml:"image,omitempty"`
+	Command   Command `yaml:"command" validate:"required"`
+	RunPolicy string  `yaml:"runPolicy,omitempty" validate:"omitempty,oneof=before-deploy manual"`
+}
+
+// Policy returns the run policy, defaulting to before-deploy
+func (m Migrations) Policy() string {
+	if m.RunPolicy == "" {
+		return MigrationsBeforeDeploy
+	}
+	return strings.ToLower(m.RunPolicy)
+}
+
+// MigrationImage returns the image the migrations of config run with, or ""
+// when neither the migrations nor their pod name one
+func MigrationImage(config *NexlayerYAML) string {
+	m := config.Application.Migrations
+	if m == nil {
+		return ""
+	}
+	if m.Image != "" {
+		return m.Image
+	}
+	for _, pod := range config.Application.Pods {
+		if pod.Name == m.Pod {
+			return pod.Image
+		}
+	}
+	return ""
+}
+
+// MigrationRelease identifies a release for the purpose of running migrations
+// once: a digest of the image and command. Pin image tags so that each release
+// gets its own digest.
+func MigrationRelease(config *NexlayerYAML) string {
+	m := config.Application.Migrations
+	if m == nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(MigrationImage(config) + "\x00" + m.Command.String()))
+	return hex.EncodeToString(sum[:])[:12]
+}
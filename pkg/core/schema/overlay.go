@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PatchDirective is the key of an overlay map naming what to do with its
+// base: "delete" removes a list item, e.g. {name: worker, $patch: delete},
+// and "replace" replaces a map or list item instead of merging into it
+const PatchDirective = "$patch"
+
+// mergeKeys identify the items of lists merged item by item: pods, volumes,
+// ports, secrets by name and vars by key
+var mergeKeys = []string{"name", "key"}
+
+// OverlayFile returns the overlay of file for an environment, e.g.
+// nexlayer.prod.yaml for nexlayer.yaml and prod
+func OverlayFile(file, env string) string {
+	ext := filepath.Ext(file)
+	return strings.TrimSuffix(file, ext) + "." + env + ext
+}
+
+// ReadWithOverlay reads file and, when env is set, merges the overlay of
+// that environment into it with MergeOverlay. The overlay must exist.
+func ReadWithOverlay(file, env string) ([]byte, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deployment file: %w", err)
+	}
+	if env == "" {
+		return data, nil
+	}
+	overlayFile := OverlayFile(file, env)
+	overlay, err := os.ReadFile(overlayFile)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no overlay for environment %s: %s does not exist", env, overlayFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay: %w", err)
+	}
+	merged, err := MergeOverlay(data, overlay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply %s: %w", overlayFile, err)
+	}
+	return merged, nil
+}
+
+// MergeOverlay merges an overlay into a base configuration with strategic
+// merge semantics:
+//
+//   - maps are merged key by key, a null value removes the key and
+//     "$patch: replace" replaces the base map
+//   - lists whose items all have a name, or all a key, such as pods, ports,
+//     volumes and vars, are merged item by item; items only in the overlay
+//     are appended and an item with "$patch: delete" removes its namesake
+//   - any other value of the overlay, lists included, replaces the base one
+//
+// Keys keep the order of the base, with keys only in the overlay appended.
+func MergeOverlay(base, overlay []byte) ([]byte, error) {
+	var b, o yaml.Node
+	if err := yaml.Unmarshal(base, &b); err != nil {
+		return nil, fmt.Errorf("invalid base: %w", err)
+	}
+	if err := yaml.Unmarshal(overlay, &o); err != nil {
+		return nil, fmt.Errorf("invalid overlay: %w", err)
+	}
+	if len(o.Content) == 0 || isNull(o.Content[0]) {
+		return base, nil
+	}
+	if len(b.Content) == 0 {
+		b = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{withoutDirectives(o.Content[0])}}
+	} else {
+		b.Content[0] = MergeNodes(b.Content[0], o.Content[0])
+	}
+	return encodeDocument(&b)
+}
+
+// MergeNodes merges overlay into base with the rules of MergeOverlay and
+// returns the result. base is updated in place.
+func MergeNodes(base, overlay *yaml.Node) *yaml.Node {
+	switch overlay.Kind {
+	case yaml.MappingNode:
+		if base == nil || base.Kind != yaml.MappingNode || mappingValue(overlay, PatchDirective) == "replace" {
+			return withoutDirectives(overlay)
+		}
+		for i := 0; i+1 < len(overlay.Content); i += 2 {
+			key, value := overlay.Content[i], overlay.Content[i+1]
+			if key.Value == PatchDirective {
+				continue
+			}
+			j := keyIndex(base, key.Value)
+			switch {
+			case isNull(value):
+				if j >= 0 {
+					base.Content = append(base.Content[:j], base.Content[j+2:]...)
+				}
+			case j >= 0:
+				base.Content[j+1] = MergeNodes(base.Content[j+1], value)
+			default:
+				base.Content = append(base.Content, key, withoutDirectives(value))
+			}
+		}
+		return base
+	case yaml.SequenceNode:
+		if base == nil || base.Kind != yaml.SequenceNode {
+			return withoutDirectives(overlay)
+		}
+		key := mergeKey(base, overlay)
+		if key == "" {
+			return withoutDirectives(overlay)
+		}
+		return mergeList(base, overlay, key)
+	}
+	return overlay
+}
+
+// mergeList merges the items of two lists identified by key, keeping the
+// order of the base
+func mergeList(base, overlay *yaml.Node, key string) *yaml.Node {
+	index := make(map[string]int, len(base.Content))
+	for i, item := range base.Content {
+		index[mappingValue(item, key)] = i
+	}
+
+	deleted := make(map[int]bool)
+	for _, item := range overlay.Content {
+		i, ok := index[mappingValue(item, key)]
+		if mappingValue(item, PatchDirective) == "delete" {
+			if ok {
+				deleted[i] = true
+			}
+			continue
+		}
+		if !ok {
+			index[mappingValue(item, key)] = len(base.Content)
+			base.Content = append(base.Content, withoutDirectives(item))
+			continue
+		}
+		base.Content[i] = MergeNodes(base.Content[i], item)
+	}
+
+	items := base.Content[:0]
+	for i, item := range base.Content {
+		if !deleted[i] {
+			items = append(items, item)
+		}
+	}
+	base.Content = items
+	return base
+}
+
+// mergeKey returns the key identifying the items of both lists, or "" when
+// the lists are replaced rather than merged
+func mergeKey(base, overlay *yaml.Node) string {
+	for _, key := range mergeKeys {
+		if hasKey(base, key) && hasKey(overlay, key) {
+			return key
+		}
+	}
+	return ""
+}
+
+func hasKey(list *yaml.Node, key string) bool {
+	for _, item := range list.Content {
+		if mappingValue(item, key) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// keyIndex returns the index of key in a mapping, or -1
+func keyIndex(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// isNull reports whether a node is an explicit null
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+// withoutDirectives returns a copy of an overlay value that has no base to
+// apply to, without its $patch directives and null values
+func withoutDirectives(n *yaml.Node) *yaml.Node {
+	out := *n
+	switch n.Kind {
+	case yaml.MappingNode:
+		out.Content = nil
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if key.Value != PatchDirective && !isNull(value) {
+				out.Content = append(out.Content, key, withoutDirectives(value))
+			}
+		}
+	case yaml.SequenceNode:
+		out.Content = nil
+		for _, item := range n.Content {
+			if mappingValue(item, PatchDirective) != "delete" {
+				out.Content = append(out.Content, withoutDirectives(item))
+			}
+		}
+	}
+	return &out
+}
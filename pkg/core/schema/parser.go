@@ -119,10 +119,10 @@ func mergePod(base, detected Pod) Pod {
 	if detected.Image != "" {
 		merged.Image = detected.Image
 	}
-	if detected.Command != "" {
+	if len(detected.Command) > 0 {
 		merged.Command = detected.Command
 	}
-	if detected.Entrypoint != "" {
+	if len(detected.Entrypoint) > 0 {
 		merged.Entrypoint = detected.Entrypoint
 	}
 
@@ -156,6 +156,16 @@ func mergePod(base, detected Pod) Pod {
 		}
 	}
 
+	// Merge labels
+	if len(detected.Labels) > 0 {
+		if merged.Labels == nil {
+			merged.Labels = make(map[string]string)
+		}
+		for k, v := range detected.Labels {
+			merged.Labels[k] = v
+		}
+	}
+
 	return merged
 }
 
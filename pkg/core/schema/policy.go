@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+// DeployPolicy restricts when the application may be deployed, e.g.
+//
+//	deployPolicy:
+//	  timezone: Europe/Berlin
+//	  windows:
+//	    - days: [mon, tue, wed, thu, fri]
+//	      from: "08:00"
+//	      to: "18:00"
+//	  freezes:
+//	    - name: weekend
+//	      from: fri 18:00
+//	      to: mon 08:00
+//	    - name: holidays
+//	      from: 2025-12-20
+//	      to: 2026-01-04
+//
+// nexlayer deploy refuses to deploy outside the windows or during a freeze
+// unless --override-freeze is given with a reason.
+type DeployPolicy struct {
+	Timezone string         `yaml:"timezone,omitempty"` // IANA name, defaults to the local zone
+	Windows  []DeployWindow `yaml:"windows,omitempty" validate:"omitempty,dive"`
+	Freezes  []Freeze       `yaml:"freezes,omitempty" validate:"omitempty,dive"`
+}
+
+// DeployWindow allows deploys on Days between From and To, given as HH:MM. A
+// window whose To is before its From runs past midnight.
+type DeployWindow struct {
+	Days []string `yaml:"days,omitempty"` // mon to sun, every day when empty
+	From string   `yaml:"from" validate:"required"`
+	To   string   `yaml:"to" validate:"required"`
+}
+
+// Freeze blocks deploys from From to To, either every week, given as a day
+// and time such as "fri 18:00", or once, given as a date or date and time
+// such as "2025-12-20" or "2025-12-20 18:00". A To date without a time ends
+// the freeze at the end of that day.
+type Freeze struct {
+	Name   string `yaml:"name,omitempty"`
+	From   string `yaml:"from" validate:"required"`
+	To     string `yaml:"to" validate:"required"`
+	Reason string `yaml:"reason,omitempty"`
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+// Probes configures the health checks of a pod, e.g.
+//
+//	probes:
+//	  readiness:
+//	    httpGet: {path: /health}
+//	    interval: 10s
+//	  liveness:
+//	    tcpSocket: {}
+//	    retries: 3
+//
+// A pod receives traffic once its readiness probe passes and is restarted
+// when its liveness probe fails. Until its startup probe passes, the other
+// two are not run, which gives slow starting pods time to come up.
+type Probes struct {
+	Liveness  *HealthProbe `yaml:"liveness,omitempty" validate:"omitempty"`
+	Readiness *HealthProbe `yaml:"readiness,omitempty" validate:"omitempty"`
+	Startup   *HealthProbe `yaml:"startup,omitempty" validate:"omitempty"`
+}
+
+// HealthProbe checks a pod with exactly one of an HTTP request, a TCP
+// connection or a command. Timings are as for Probe.
+type HealthProbe struct {
+	HTTPGet     *HTTPGetAction   `yaml:"httpGet,omitempty" validate:"omitempty"`
+	TCPSocket   *TCPSocketAction `yaml:"tcpSocket,omitempty" validate:"omitempty"`
+	Exec        *ExecAction      `yaml:"exec,omitempty" validate:"omitempty"`
+	Interval    string           `yaml:"interval,omitempty"`
+	Timeout     string           `yaml:"timeout,omitempty"`
+	Retries     int              `yaml:"retries,omitempty"`
+	StartPeriod string           `yaml:"startPeriod,omitempty"`
+}
+
+// HTTPGetAction passes when a GET of Path answers with a 2xx or 3xx status.
+// Port defaults to the target port of the first service port.
+type HTTPGetAction struct {
+	Path string `yaml:"path,omitempty"`
+	Port int    `yaml:"port,omitempty"`
+}
+
+// TCPSocketAction passes when a connection to Port can be opened. Port
+// defaults to the target port of the first service port.
+type TCPSocketAction struct {
+	Port int `yaml:"port,omitempty"`
+}
+
+// ExecAction passes when Command exits 0
+type ExecAction struct {
+	Command Command `yaml:"command"`
+}
+
+// NamedProbe is a health probe with its kind
+type NamedProbe struct {
+	Name  string
+	Probe *HealthProbe
+}
+
+// Named returns the probes by name, in the order liveness, readiness, startup,
+// skipping those not set
+func (p *Probes) Named() []NamedProbe {
+	if p == nil {
+		return nil
+	}
+	var named []NamedProbe
+	for _, n := range []NamedProbe{{"liveness", p.Liveness}, {"readiness", p.Readiness}, {"startup", p.Startup}} {
+		if n.Probe != nil {
+			named = append(named, n)
+		}
+	}
+	return named
+}
+
+// ReadinessProbe returns the probe deciding when a pod is ready: its readiness
+// probe, or else its probe as a command
+func (p Pod) ReadinessProbe() *HealthProbe {
+	if p.Probes != nil && p.Probes.Readiness != nil {
+		return p.Probes.Readiness
+	}
+	if p.Probe != nil {
+		return &HealthProbe{
+			Exec:        &ExecAction{Command: p.Probe.Command},
+			Interval:    p.Probe.Interval,
+			Timeout:     p.Probe.Timeout,
+			Retries:     p.Probe.Retries,
+			StartPeriod: p.Probe.StartPeriod,
+		}
+	}
+	return nil
+}
+
+// HasProbes reports whether a pod has any health check
+func (p Pod) HasProbes() bool {
+	return p.Probe != nil || len(p.Probes.Named()) > 0
+}
+
+// SetDefaultProbePorts points HTTP and TCP probes without a port at the target
+// port of the first service port, and reports whether any was changed
+func (p *Pod) SetDefaultProbePorts() bool {
+	if len(p.ServicePorts) == 0 {
+		return false
+	}
+	port := p.ServicePorts[0].TargetPort
+	if port == 0 {
+		port = p.ServicePorts[0].Port
+	}
+	changed := false
+	for _, n := range p.Probes.Named() {
+		if a := n.Probe.HTTPGet; a != nil && a.Port == 0 {
+			a.Port, changed = port, true
+		}
+		if a := n.Probe.TCPSocket; a != nil && a.Port == 0 {
+			a.Port, changed = port, true
+		}
+	}
+	return changed
+}
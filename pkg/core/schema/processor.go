@@ -348,7 +348,9 @@ func NewVariableContextFromConfig(config *NexlayerYAML) *DefaultVariableContext
 
 	// Add pods
 	for _, pod := range config.Application.Pods {
-		ctx.AddPod(pod.Name, fmt.Sprintf("%s.pod", pod.Name))
+		for _, name := range pod.HostNames() {
+			ctx.AddPod(name, fmt.Sprintf("%s.pod", name))
+		}
 	}
 
 	return ctx
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secret values in configurations shared outside the project
+const Redacted = "[REDACTED]"
+
+// sensitiveKeyMarkers are substrings of environment variable names that
+// usually hold credentials
+var sensitiveKeyMarkers = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "KEY", "CREDENTIAL", "DATABASE_URL", "DSN"}
+
+// urlCredentials matches the user information of URLs, such as user:pass@
+// in postgres://user:pass@db:5432/app
+var urlCredentials = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/?#\s]+@`)
+
+// IsSensitiveKey reports whether an environment variable is likely to hold a secret
+func IsSensitiveKey(key string) bool {
+	k := strings.ToUpper(key)
+	for _, s := range sensitiveKeyMarkers {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactURLCredentials replaces the credentials embedded in the URLs of s
+// with Redacted
+func RedactURLCredentials(s string) string {
+	return urlCredentials.ReplaceAllString(s, "${1}"+Redacted+"@")
+}
+
+// Redact replaces registry credentials, secret data, the values of sensitive
+// vars and the credentials of URLs in other vars and commands with Redacted,
+// in place
+func Redact(config *NexlayerYAML) {
+	if login := config.Application.RegistryLogin; login != nil {
+		login.Username = Redacted
+		login.PersonalAccessToken = Redacted
+	}
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		redactVars(pod.Vars)
+		redactCommand(pod.Entrypoint)
+		redactCommand(pod.Command)
+		for j := range pod.Secrets {
+			pod.Secrets[j].Data = Redacted
+		}
+		for _, containers := range [][]Container{pod.InitContainers, pod.Sidecars} {
+			for j := range containers {
+				redactVars(containers[j].Vars)
+				redactCommand(containers[j].Entrypoint)
+				redactCommand(containers[j].Command)
+			}
+		}
+	}
+}
+
+func redactVars(vars []EnvVar) {
+	for i := range vars {
+		if IsSensitiveKey(vars[i].Key) {
+			vars[i].Value = Redacted
+		} else {
+			vars[i].Value = RedactURLCredentials(vars[i].Value)
+		}
+	}
+}
+
+func redactCommand(command Command) {
+	for i := range command {
+		command[i] = RedactURLCredentials(command[i])
+	}
+}
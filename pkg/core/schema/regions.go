@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import "fmt"
+
+// Regions deploys the application to a primary region and to replica regions
+// from the same nexlayer.yaml, e.g.
+//
+//	regions:
+//	  primary: us-east
+//	  replicas: [eu-west, ap-south]
+//	  overrides:
+//	    eu-west:
+//	      pods:
+//	        api: {vars: [{key: STORAGE_BUCKET, value: assets-eu}]}
+//
+// Overrides change pods in a single region; every other field is shared.
+type Regions struct {
+	Primary   string                    `yaml:"primary" validate:"required"`
+	Replicas  []string                  `yaml:"replicas,omitempty"`
+	Overrides map[string]RegionOverride `yaml:"overrides,omitempty" validate:"omitempty,dive"`
+}
+
+// RegionOverride changes the application in one region
+type RegionOverride struct {
+	URL  string                 `yaml:"url,omitempty" validate:"omitempty,url"`
+	Pods map[string]PodOverride `yaml:"pods,omitempty" validate:"omitempty,dive"`
+}
+
+// PodOverride changes a pod in one region. Vars are merged by key into the
+// pod's vars.
+type PodOverride struct {
+	Image string   `yaml:"image,omitempty" validate:"omitempty,image"`
+	Vars  []EnvVar `yaml:"vars,omitempty" validate:"omitempty,dive"`
+}
+
+// Names returns the regions the application runs in, the primary first
+func (r Regions) Names() []string {
+	return append([]string{r.Primary}, r.Replicas...)
+}
+
+// RegionCount returns the number of regions config deploys to, one when it
+// declares no regions
+func RegionCount(config *NexlayerYAML) int {
+	if config.Application.Regions == nil {
+		return 1
+	}
+	return len(config.Application.Regions.Names())
+}
+
+// ForRegion returns config as deployed in region, with the region's overrides
+// applied. config itself is left unchanged.
+func ForRegion(config *NexlayerYAML, region string) (*NexlayerYAML, error) {
+	regions := config.Application.Regions
+	if regions == nil {
+		return nil, fmt.Errorf("application %s declares no regions", config.Application.Name)
+	}
+	known := false
+	for _, name := range regions.Names() {
+		known = known || name == region
+	}
+	if !known {
+		return nil, fmt.Errorf("application %s does not run in region %s", config.Application.Name, region)
+	}
+
+	out := *config
+	out.Application.Pods = append([]Pod(nil), config.Application.Pods...)
+	override, ok := regions.Overrides[region]
+	if !ok {
+		return &out, nil
+	}
+	if override.URL != "" {
+		out.Application.URL = override.URL
+	}
+	for name, po := range override.Pods {
+		found := false
+		for i := range out.Application.Pods {
+			pod := &out.Application.Pods[i]
+			if pod.Name != name {
+				continue
+			}
+			found = true
+			if po.Image != "" {
+				pod.Image = po.Image
+			}
+			pod.Vars = mergeVars(pod.Vars, po.Vars)
+		}
+		if !found {
+			return nil, fmt.Errorf("regions.overrides.%s: unknown pod %s", region, name)
+		}
+	}
+	return &out, nil
+}
+
+// mergeVars returns base with the vars of over replacing or added to it
+func mergeVars(base, over []EnvVar) []EnvVar {
+	merged := append([]EnvVar(nil), base...)
+	for _, v := range over {
+		replaced := false
+		for i := range merged {
+			if merged[i].Key == v.Key {
+				merged[i].Value = v.Value
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, v)
+		}
+	}
+	return merged
+}
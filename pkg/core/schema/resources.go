@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Resource profiles, the sizing classes pods fall in by type
+const (
+	ProfileDefault  = "default"
+	ProfileFrontend = "frontend"
+	ProfileBackend  = "backend"
+	ProfileDatabase = "database"
+	ProfileCache    = "cache"
+	ProfileQueue    = "queue"
+	ProfileLLM      = "llm"
+)
+
+// defaultResources are the resources init gives pods of each profile
+var defaultResources = map[string]Resources{
+	ProfileDefault:  {CPU: "250m", Memory: "512Mi"},
+	ProfileFrontend: {CPU: "250m", Memory: "512Mi"},
+	ProfileBackend:  {CPU: "500m", Memory: "1Gi"},
+	ProfileDatabase: {CPU: "500m", Memory: "1Gi"},
+	ProfileCache:    {CPU: "250m", Memory: "512Mi"},
+	ProfileQueue:    {CPU: "500m", Memory: "1Gi"},
+	ProfileLLM:      {CPU: "2", Memory: "8Gi"},
+}
+
+// Profile returns the resource profile of a pod's type
+func (p Pod) Profile() string {
+	switch strings.ToLower(p.Type) {
+	case PodTypeFrontend, PodTypeReact, PodTypeNextJS, PodTypeVue,
+		PodTypeNginx, PodTypeTraefik:
+		return ProfileFrontend
+	case PodTypeBackend, PodTypeExpress, PodTypeDjango, PodTypeFastAPI,
+		PodTypeNode, PodTypePython, PodTypeGolang, PodTypeJava:
+		return ProfileBackend
+	case PodTypeDatabase, PodTypePostgres, PodTypeMySQL, PodTypeMongoDB,
+		PodTypeClickhouse, PodTypeElastic, PodTypeMinio:
+		return ProfileDatabase
+	case PodTypeRedis:
+		return ProfileCache
+	case PodTypeRabbitMQ, PodTypeKafka:
+		return ProfileQueue
+	case PodTypeLLM, PodTypeOllama, PodTypeHFModel:
+		return ProfileLLM
+	}
+	return ProfileDefault
+}
+
+// SetDefaultResources gives a pod the CPU and memory of its profile unless it
+// already requests them
+func (p *Pod) SetDefaultResources() {
+	defaults := defaultResources[p.Profile()]
+	if p.Resources == nil {
+		p.Resources = &Resources{}
+	}
+	if p.Resources.CPU == "" {
+		p.Resources.CPU = defaults.CPU
+	}
+	if p.Resources.Memory == "" {
+		p.Resources.Memory = defaults.Memory
+	}
+}
+
+// ParseCPU converts a CPU quantity such as "500m", "0.5" or "2" to cores
+func ParseCPU(v string) (float64, error) {
+	v = strings.TrimSpace(v)
+	if strings.HasSuffix(v, "m") {
+		n, err := strconv.ParseFloat(strings.TrimSuffix(v, "m"), 64)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid cpu %q", v)
+		}
+		return n / 1000, nil
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid cpu %q", v)
+	}
+	return n, nil
+}
+
+// ParseMemory converts a memory quantity such as "512Mi" or "2Gi" to GiB
+func ParseMemory(v string) (float64, error) {
+	units := []struct {
+		suffix string
+		factor float64
+	}{
+		{"Ki", 1.0 / (1024 * 1024)}, {"Mi", 1.0 / 1024}, {"Gi", 1}, {"Ti", 1024},
+	}
+	v = strings.TrimSpace(v)
+	for _, u := range units {
+		if strings.HasSuffix(v, u.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(v, u.suffix), 64)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid memory %q", v)
+			}
+			return n * u.factor, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid memory %q, expected a unit such as Mi or Gi", v)
+}
+
+// NormalizeMemory rewrites a memory quantity of Kubernetes or Docker, such as
+// 512M, 1g, 256mb or a byte count, in the Ki, Mi or Gi of the schema. Decimal
+// units are read as their binary counterpart. ok is false when v is not a
+// memory quantity.
+func NormalizeMemory(v string) (string, bool) {
+	v = strings.TrimSpace(v)
+	i := 0
+	for i < len(v) && (v[i] >= '0' && v[i] <= '9' || v[i] == '.') {
+		i++
+	}
+	n, err := strconv.ParseFloat(v[:i], 64)
+	if err != nil || n <= 0 {
+		return "", false
+	}
+	number := v[:i]
+	switch strings.TrimSuffix(strings.ToLower(v[i:]), "b") {
+	case "":
+		mi := n / (1024 * 1024)
+		if mi != float64(int64(mi)) {
+			mi = float64(int64(mi) + 1)
+		}
+		return fmt.Sprintf("%dMi", int64(mi)), true
+	case "k", "ki":
+		return number + "Ki", true
+	case "m", "mi":
+		return number + "Mi", true
+	case "g", "gi":
+		return number + "Gi", true
+	case "t", "ti":
+		return number + "Ti", true
+	}
+	return "", false
+}
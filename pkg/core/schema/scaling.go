@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+// DefaultTargetCPU is the CPU utilization, in percent of the pod's CPU,
+// autoscaling keeps pods at when no target is set
+const DefaultTargetCPU = 80
+
+// Autoscale lets the platform run between Min and Max replicas of a pod,
+// adding replicas when their average CPU use exceeds TargetCPU percent of
+// the pod's CPU and removing them when it falls below, e.g.
+//
+//	autoscale:
+//	  min: 2
+//	  max: 10
+//	  targetCPU: 70
+type Autoscale struct {
+	Min       int `yaml:"min" validate:"required,min=1"`
+	Max       int `yaml:"max" validate:"required"`
+	TargetCPU int `yaml:"targetCPU,omitempty"`
+}
+
+// Target returns the CPU utilization to scale at, defaulted
+func (a Autoscale) Target() int {
+	if a.TargetCPU == 0 {
+		return DefaultTargetCPU
+	}
+	return a.TargetCPU
+}
+
+// ReplicaCount returns the number of replicas a pod starts with: its
+// replicas, the minimum of its autoscaling, or 1
+func (p Pod) ReplicaCount() int {
+	switch {
+	case p.Autoscale != nil && p.Autoscale.Min > 0:
+		return p.Autoscale.Min
+	case p.Replicas > 0:
+		return p.Replicas
+	default:
+		return 1
+	}
+}
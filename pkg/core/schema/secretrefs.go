@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"regexp"
+	"sort"
+)
+
+// secretNamePattern matches the names of secrets stored for an application
+var secretNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// secretRefPattern matches references to stored secrets in vars,
+// <% SECRET:NAME %>, which the platform resolves when a deployment starts
+var secretRefPattern = regexp.MustCompile(`<%\s*SECRET:([A-Za-z_][A-Za-z0-9_]*)\s*%>`)
+
+// ValidSecretName reports whether name can name a stored secret
+func ValidSecretName(name string) bool {
+	return secretNamePattern.MatchString(name)
+}
+
+// SecretRef returns the reference to the stored secret name, for use in vars
+func SecretRef(name string) string {
+	return "<% SECRET:" + name + " %>"
+}
+
+// HasSecretRef reports whether value refers to a stored secret
+func HasSecretRef(value string) bool {
+	return secretRefPattern.MatchString(value)
+}
+
+// SecretRefs returns the sorted names of the stored secrets the vars of
+// config refer to
+func SecretRefs(config *NexlayerYAML) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, pod := range config.Application.Pods {
+		for _, v := range pod.Vars {
+			for _, m := range secretRefPattern.FindAllStringSubmatch(v.Value, -1) {
+				if !seen[m[1]] {
+					seen[m[1]] = true
+					names = append(names, m[1])
+				}
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
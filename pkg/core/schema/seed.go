@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Seed run policies
+const (
+	SeedFirstBoot = "first-boot" // run by nexlayer deploy once the pod is up
+	SeedManual    = "manual"     // run only by nexlayer seed
+)
+
+// SeedPolicies lists the accepted runPolicy values
+var SeedPolicies = []string{SeedFirstBoot, SeedManual}
+
+// SeedDir is where seed files are placed in the seed container
+const SeedDir = "/seed"
+
+// Seed loads initial data into a pod, typically a database, e.g.
+//
+//	seed:
+//	  files: [db/schema.sql, db/fixtures.sql]
+//
+// Files are run in order by the client of the database, or Command runs with
+// the files under /seed. A seed runs once: the platform records its marker and
+// skips it afterwards, so redeploys never seed production data again.
+type Seed struct {
+	Files     []string `yaml:"files,omitempty"`
+	Command   Command  `yaml:"command,omitempty"`
+	Image     string   `yaml:"image,omitempty"`
+	Marker    string   `yaml:"marker,omitempty"` // change to seed again
+	RunPolicy string   `yaml:"runPolicy,omitempty" validate:"omitempty,oneof=first-boot manual"`
+}
+
+// Policy returns the run policy, defaulting to first-boot
+func (s Seed) Policy() string {
+	if s.RunPolicy == "" {
+		return SeedFirstBoot
+	}
+	return strings.ToLower(s.RunPolicy)
+}
+
+// SeedMarker is the idempotency marker of the seed of a pod: the platform
+// runs a seed only while its marker has not succeeded
+func SeedMarker(pod Pod) string {
+	if pod.Seed == nil || pod.Seed.Marker == "" {
+		return "seed-" + pod.Name
+	}
+	return "seed-" + pod.Name + "-" + pod.Seed.Marker
+}
+
+// SeedPath is where the i-th seed file is placed in the seed container. The
+// index keeps the files in declared order.
+func SeedPath(i int, file string) string {
+	return path.Join(SeedDir, fmt.Sprintf("%02d-%s", i+1, filepath.Base(file)))
+}
+
+// seedClients loads a file into each kind of database; {host} is the pod
+const (
+	seedPostgres = `PGPASSWORD="$POSTGRES_PASSWORD" psql -v ON_ERROR_STOP=1 -h {host} -U "${POSTGRES_USER:-postgres}" -d "${POSTGRES_DB:-postgres}" -f`
+	seedMySQL    = `mysql -h {host} -uroot -p"$MYSQL_ROOT_PASSWORD" "$MYSQL_DATABASE" <`
+	seedMongoDB  = `mongosh --quiet "mongodb://$MONGO_INITDB_ROOT_USERNAME:$MONGO_INITDB_ROOT_PASSWORD@{host}:27017/app?authSource=admin"`
+	seedRedis    = `redis-cli -h {host} -a "$REDIS_PASSWORD" --no-auth-warning <`
+)
+
+// SeedCommand returns the command seeding a pod: Command when set, else one
+// loading the files with the client of the database the pod runs
+func SeedCommand(pod Pod) (Command, error) {
+	s := pod.Seed
+	if s == nil {
+		return nil, fmt.Errorf("pod %s has no seed", pod.Name)
+	}
+	if len(s.Command) > 0 {
+		return s.Command, nil
+	}
+	if len(s.Files) == 0 {
+		return nil, fmt.Errorf("seed of pod %s needs files or a command", pod.Name)
+	}
+
+	var client string
+	switch seedKind(pod) {
+	case PodTypePostgres:
+		client = seedPostgres
+	case PodTypeMySQL:
+		client = seedMySQL
+	case PodTypeMongoDB:
+		client = seedMongoDB
+	case PodTypeRedis:
+		client = seedRedis
+	default:
+		return nil, fmt.Errorf("seed of pod %s needs a command; files are only loaded into postgres, mysql, mongodb and redis pods", pod.Name)
+	}
+	client = strings.ReplaceAll(client, "{host}", pod.Name+".pod")
+
+	steps := make([]string, len(s.Files))
+	for i, file := range s.Files {
+		steps[i] = client + " " + shellQuote(SeedPath(i, file))
+	}
+	return Command{"sh", "-c", "set -e; " + strings.Join(steps, "; ")}, nil
+}
+
+// seedKind returns the kind of database a pod runs, by type or image
+func seedKind(pod Pod) string {
+	switch t := strings.ToLower(pod.Type); t {
+	case PodTypePostgres, PodTypeMySQL, PodTypeMongoDB, PodTypeRedis:
+		return t
+	}
+	image := strings.ToLower(pod.Image)
+	for _, k := range []struct{ match, kind string }{
+		{"postgres", PodTypePostgres},
+		{"mysql", PodTypeMySQL},
+		{"mariadb", PodTypeMySQL},
+		{"mongo", PodTypeMongoDB},
+		{"redis", PodTypeRedis},
+	} {
+		if strings.Contains(image, k.match) {
+			return k.kind
+		}
+	}
+	return ""
+}
+
+// shellQuote quotes s for sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -116,6 +116,15 @@ func (s *Service) ProcessConfig(config *NexlayerYAML) error {
 			}
 			config.Application.Pods[i].Annotations = processedAnnotations
 		}
+
+		// Process labels if present
+		if len(pod.Labels) > 0 {
+			processedLabels, err := s.processor.ProcessMap(pod.Labels, ctx)
+			if err != nil {
+				return fmt.Errorf("failed to process pod %s labels: %w", pod.Name, err)
+			}
+			config.Application.Pods[i].Labels = processedLabels
+		}
 	}
 
 	return nil
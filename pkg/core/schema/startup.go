@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"sort"
+	"strings"
+)
+
+// DependsOnAnnotation lists, comma separated, the pods a pod waits for on
+// start. The platform starts a pod once the pods it depends on are up, or
+// healthy when they have a probe, and once the jobs it depends on completed.
+const DependsOnAnnotation = "nexlayer.io/depends-on"
+
+// Probe checks the health of a pod by running a command in it, e.g.
+//
+//	probe:
+//	  command: [pg_isready, -U, postgres]
+//	  interval: 10s
+//	  retries: 5
+//
+// The pod is unhealthy after Retries consecutive failures. Failures during
+// StartPeriod do not count. Durations are written as 30s or 1m30s.
+type Probe struct {
+	Command     Command `yaml:"command" validate:"required"`
+	Interval    string  `yaml:"interval,omitempty"`
+	Timeout     string  `yaml:"timeout,omitempty"`
+	Retries     int     `yaml:"retries,omitempty"`
+	StartPeriod string  `yaml:"startPeriod,omitempty"`
+}
+
+// Dependencies returns the pods a pod depends on, from DependsOnAnnotation
+func (p Pod) Dependencies() []string {
+	var deps []string
+	for _, name := range strings.Split(p.Annotations[DependsOnAnnotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			deps = append(deps, name)
+		}
+	}
+	return deps
+}
+
+// SetDependencies records the pods a pod depends on in DependsOnAnnotation
+func (p *Pod) SetDependencies(deps []string) {
+	if len(deps) == 0 {
+		delete(p.Annotations, DependsOnAnnotation)
+		return
+	}
+	deps = append([]string(nil), deps...)
+	sort.Strings(deps)
+	if p.Annotations == nil {
+		p.Annotations = make(map[string]string)
+	}
+	p.Annotations[DependsOnAnnotation] = strings.Join(deps, ",")
+}
+
+// OrderByDependencies reorders pods so that each comes after the pods it
+// depends on, keeping the order of the others. Cycles are left as they are.
+func OrderByDependencies(pods []Pod) []Pod {
+	index := make(map[string]int, len(pods))
+	for i, pod := range pods {
+		index[pod.Name] = i
+	}
+	ordered := make([]Pod, 0, len(pods))
+	state := make([]int, len(pods)) // 0 unvisited, 1 visiting, 2 done
+	var visit func(i int)
+	visit = func(i int) {
+		if state[i] != 0 {
+			return
+		}
+		state[i] = 1
+		for _, dep := range pods[i].Dependencies() {
+			if j, ok := index[dep]; ok {
+				visit(j)
+			}
+		}
+		state[i] = 2
+		ordered = append(ordered, pods[i])
+	}
+	for i := range pods {
+		visit(i)
+	}
+	return ordered
+}
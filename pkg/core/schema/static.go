@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+import (
+	"path"
+	"strings"
+)
+
+// StaticAssetsAnnotation records the ID of the asset bundle uploaded for a
+// static pod. The platform serves the bundle from its own image.
+const StaticAssetsAnnotation = "nexlayer.io/static-assets"
+
+// StaticPort is the port static pods serve on when servicePorts is omitted
+const StaticPort = 80
+
+// StaticSite is the build output of a frontend, e.g. a Vite or React app,
+// served by the platform without a web server image of its own
+type StaticSite struct {
+	Dir          string            `yaml:"dir" validate:"required"` // build output, relative to nexlayer.yaml
+	Build        string            `yaml:"build,omitempty"`         // shell command producing Dir, run before upload
+	SPA          bool              `yaml:"spa,omitempty"`           // serve index.html for paths without a file
+	CacheControl map[string]string `yaml:"cacheControl,omitempty"`  // Cache-Control header by path pattern
+}
+
+// DefaultCacheControl applies to files no cacheControl pattern matches:
+// hashed build assets are cached for good and HTML is always revalidated
+var DefaultCacheControl = map[string]string{
+	"/assets/*": "public, max-age=31536000, immutable",
+	"*.html":    "no-cache",
+	"*":         "public, max-age=3600",
+}
+
+// IsStatic reports whether the pod serves a static site
+func (p Pod) IsStatic() bool {
+	return p.Static != nil || strings.EqualFold(p.Type, PodTypeStatic)
+}
+
+// HasStaticSites reports whether any pod serves a static site
+func HasStaticSites(config *NexlayerYAML) bool {
+	for _, pod := range config.Application.Pods {
+		if pod.IsStatic() {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheControlFor returns the Cache-Control header for a file of the site,
+// given as a path from the site root such as /assets/index-4f2a9c.js.
+// Patterns starting with "/" match the whole path, others the file name; the
+// longest matching pattern wins, and the defaults apply when none matches.
+func (s StaticSite) CacheControlFor(file string) string {
+	for _, patterns := range []map[string]string{s.CacheControl, DefaultCacheControl} {
+		best := ""
+		for pattern := range patterns {
+			if len(pattern) > len(best) && MatchStaticPath(pattern, file) {
+				best = pattern
+			}
+		}
+		if best != "" {
+			return patterns[best]
+		}
+	}
+	return ""
+}
+
+// MatchStaticPath reports whether a cacheControl pattern matches a file path
+func MatchStaticPath(pattern, file string) bool {
+	target := path.Base(file)
+	if strings.HasPrefix(pattern, "/") {
+		target = file
+	}
+	ok, err := path.Match(pattern, target)
+	return err == nil && ok
+}
@@ -0,0 +1,18 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package schema
+
+// Canary runs a second version of a pod next to the stable one and sends it
+// Weight percent of the pod's traffic. Weights can be changed after the
+// deployment with nexlayer traffic.
+type Canary struct {
+	Image  string `yaml:"image" validate:"required,image"`
+	Weight int    `yaml:"weight" validate:"min=0,max=100"` // percent of traffic
+}
+
+// StableWeight returns the percent of traffic left to the stable version
+func (c Canary) StableWeight() int {
+	return 100 - c.Weight
+}
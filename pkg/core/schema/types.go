@@ -7,22 +7,32 @@ package schema
 
 import (
 	"fmt"
+	"strings"
 )
 
 // NexlayerYAML represents the top-level structure of a Nexlayer YAML configuration
 type NexlayerYAML struct {
-	Version     string            `yaml:"version,omitempty" json:"version,omitempty"`
-	Application Application       `yaml:"application" json:"application"`
-	Comments    map[string]string `yaml:"comments,omitempty" json:"comments,omitempty"`
+	Version       string            `yaml:"version,omitempty" json:"version,omitempty"`
+	SchemaVersion int               `yaml:"schemaVersion,omitempty" json:"schemaVersion,omitempty"`
+	Application   Application       `yaml:"application" json:"application"`
+	Comments      map[string]string `yaml:"comments,omitempty" json:"comments,omitempty"`
 }
 
 // Application represents a Nexlayer application configuration
 type Application struct {
-	Name          string            `yaml:"name" validate:"required,podname"`
-	URL           string            `yaml:"url,omitempty" validate:"omitempty,url"`
-	RegistryLogin *RegistryLogin    `yaml:"registryLogin,omitempty" validate:"omitempty"`
-	Pods          []Pod             `yaml:"pods" validate:"required,min=1,dive"`
-	Annotations   map[string]string `yaml:"annotations,omitempty" validate:"omitempty"`
+	Name          string                    `yaml:"name" validate:"required,podname"`
+	URL           string                    `yaml:"url,omitempty" validate:"omitempty,url"`
+	RegistryLogin *RegistryLogin            `yaml:"registryLogin,omitempty" validate:"omitempty"`
+	Pods          []Pod                     `yaml:"pods" validate:"required,min=1,dive"`
+	Services      map[string]BackingService `yaml:"services,omitempty" validate:"omitempty,dive"`
+	Migrations    *Migrations               `yaml:"migrations,omitempty" validate:"omitempty"`
+	Regions       *Regions                  `yaml:"regions,omitempty" validate:"omitempty"`
+	DeployPolicy  *DeployPolicy             `yaml:"deployPolicy,omitempty" validate:"omitempty"`
+	Hooks         *Hooks                    `yaml:"hooks,omitempty" validate:"omitempty"`
+	Checks        []Check                   `yaml:"checks,omitempty" validate:"omitempty,dive"`
+	Environments  map[string]Environment    `yaml:"environments,omitempty" validate:"omitempty,dive"`
+	Labels        map[string]string         `yaml:"labels,omitempty" validate:"omitempty"`
+	Annotations   map[string]string         `yaml:"annotations,omitempty" validate:"omitempty"`
 }
 
 // RegistryLogin represents private registry authentication
@@ -34,17 +44,33 @@ type RegistryLogin struct {
 
 // Pod represents a container in the deployment
 type Pod struct {
-	Name         string            `yaml:"name" validate:"required,podname"`
-	Type         string            `yaml:"type,omitempty" validate:"omitempty"`
-	Path         string            `yaml:"path,omitempty" validate:"omitempty,startswith=/"`
-	Image        string            `yaml:"image" validate:"required,image"`
-	Entrypoint   string            `yaml:"entrypoint,omitempty" validate:"omitempty"`
-	Command      string            `yaml:"command,omitempty" validate:"omitempty"`
-	Volumes      []Volume          `yaml:"volumes,omitempty" validate:"omitempty,dive"`
-	Secrets      []Secret          `yaml:"secrets,omitempty" validate:"omitempty,dive"`
-	Vars         []EnvVar          `yaml:"vars,omitempty" validate:"omitempty,dive"`
-	ServicePorts []ServicePort     `yaml:"servicePorts" validate:"required,min=1,dive"`
-	Annotations  map[string]string `yaml:"annotations,omitempty" validate:"omitempty"`
+	Name           string            `yaml:"name" validate:"required,podname"`
+	Type           string            `yaml:"type,omitempty" validate:"omitempty"`
+	Schedule       string            `yaml:"schedule,omitempty" validate:"omitempty"`
+	Aliases        []string          `yaml:"aliases,omitempty" validate:"omitempty,dive,podname"`
+	Path           string            `yaml:"path,omitempty" validate:"omitempty,startswith=/"`
+	Image          string            `yaml:"image,omitempty" validate:"required_without=Static,omitempty,image"`
+	Entrypoint     Command           `yaml:"entrypoint,omitempty" validate:"omitempty"`
+	Command        Command           `yaml:"command,omitempty" validate:"omitempty"`
+	InitContainers []Container       `yaml:"initContainers,omitempty" validate:"omitempty,dive"`
+	Sidecars       []Container       `yaml:"sidecars,omitempty" validate:"omitempty,dive"`
+	Volumes        []Volume          `yaml:"volumes,omitempty" validate:"omitempty,dive"`
+	Secrets        []Secret          `yaml:"secrets,omitempty" validate:"omitempty,dive"`
+	ConfigFiles    []ConfigFile      `yaml:"configFiles,omitempty" validate:"omitempty,dive"`
+	Vars           []EnvVar          `yaml:"vars,omitempty" validate:"omitempty,dive"`
+	EnvFrom        []EnvFrom         `yaml:"envFrom,omitempty" validate:"omitempty,dive"`
+	ServicePorts   []ServicePort     `yaml:"servicePorts" validate:"required,min=1,dive"`
+	Replicas       int               `yaml:"replicas,omitempty" validate:"omitempty,min=1"`
+	Autoscale      *Autoscale        `yaml:"autoscale,omitempty" validate:"omitempty"`
+	Resources      *Resources        `yaml:"resources,omitempty" validate:"omitempty"`
+	Static         *StaticSite       `yaml:"static,omitempty" validate:"omitempty"`
+	Build          *ImageBuild       `yaml:"build,omitempty" validate:"omitempty"`
+	Canary         *Canary           `yaml:"canary,omitempty" validate:"omitempty"`
+	Seed           *Seed             `yaml:"seed,omitempty" validate:"omitempty"`
+	Probe          *Probe            `yaml:"probe,omitempty" validate:"omitempty"`
+	Probes         *Probes           `yaml:"probes,omitempty" validate:"omitempty"`
+	Labels         map[string]string `yaml:"labels,omitempty" validate:"omitempty"`
+	Annotations    map[string]string `yaml:"annotations,omitempty" validate:"omitempty"`
 }
 
 // UnmarshalYAML implements custom unmarshaling for Pod to handle environment variables
@@ -87,6 +113,56 @@ func (p *Pod) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// Resources are the compute resources of a pod. CPU is in cores, such as 500m
+// or 2, and Memory in Ki, Mi or Gi, such as 512Mi; the pod is guaranteed and
+// limited to them. Unset, the platform defaults apply.
+type Resources struct {
+	CPU    string `yaml:"cpu,omitempty"`
+	Memory string `yaml:"memory,omitempty"`
+	GPU    *GPU   `yaml:"gpu,omitempty"`
+}
+
+// GPU requests accelerators for a pod, e.g. to serve a model
+type GPU struct {
+	Count int    `yaml:"count" validate:"required,min=1"`
+	Type  string `yaml:"type,omitempty"`
+}
+
+// GPURequest returns the pod's GPU count and type, with the type defaulted,
+// or zero and "" when the pod requests none
+func (p Pod) GPURequest() (int, string) {
+	if p.Resources == nil || p.Resources.GPU == nil || p.Resources.GPU.Count == 0 {
+		return 0, ""
+	}
+	t := p.Resources.GPU.Type
+	if t == "" {
+		t = DefaultGPUType
+	}
+	return p.Resources.GPU.Count, t
+}
+
+// HostNames returns the names other pods reach the pod by, each resolvable as
+// <name>.pod: the pod name followed by its aliases
+func (p Pod) HostNames() []string {
+	return append([]string{p.Name}, p.Aliases...)
+}
+
+// IsDatabase reports whether the pod runs a database, by type or image
+func (p Pod) IsDatabase() bool {
+	switch strings.ToLower(p.Type) {
+	case PodTypeDatabase, PodTypePostgres, PodTypeMySQL, PodTypeMongoDB,
+		PodTypeRedis, PodTypeClickhouse, PodTypeElastic:
+		return true
+	}
+	image := strings.ToLower(p.Image)
+	for _, db := range []string{"postgres", "mysql", "mariadb", "mongo", "redis", "clickhouse", "elasticsearch"} {
+		if strings.Contains(image, db) {
+			return true
+		}
+	}
+	return false
+}
+
 // ServicePort represents a service port configuration
 type ServicePort struct {
 	Name       string `yaml:"name" validate:"required"`
@@ -95,13 +171,40 @@ type ServicePort struct {
 	Protocol   string `yaml:"protocol,omitempty" validate:"omitempty,oneof=TCP UDP"`
 }
 
+// NormalizeProtocol upper-cases a servicePorts protocol and defaults it to TCP
+func NormalizeProtocol(protocol string) string {
+	if protocol == "" {
+		return ProtocolTCP
+	}
+	return strings.ToUpper(strings.TrimSpace(protocol))
+}
+
+// IsValidProtocol reports whether protocol, in any case, is one of Protocols
+func IsValidProtocol(protocol string) bool {
+	protocol = NormalizeProtocol(protocol)
+	for _, p := range Protocols {
+		if protocol == p {
+			return true
+		}
+	}
+	return false
+}
+
 // Volume represents a persistent storage volume
 type Volume struct {
-	Name     string `yaml:"name" validate:"required,alphanum"`
-	Path     string `yaml:"path" validate:"required,startswith=/"`
-	Size     string `yaml:"size,omitempty" validate:"omitempty,volumesize"`
-	Type     string `yaml:"type,omitempty" validate:"omitempty"`
-	ReadOnly bool   `yaml:"readOnly,omitempty"`
+	Name     string          `yaml:"name" validate:"required,alphanum"`
+	Path     string          `yaml:"path" validate:"required,startswith=/"`
+	Size     string          `yaml:"size,omitempty" validate:"omitempty,volumesize"`
+	Type     string          `yaml:"type,omitempty" validate:"omitempty"`
+	ReadOnly bool            `yaml:"readOnly,omitempty"`
+	Class    string          `yaml:"class,omitempty" validate:"omitempty,oneof=standard ssd"`
+	Snapshot *SnapshotPolicy `yaml:"snapshot,omitempty"`
+}
+
+// SnapshotPolicy schedules automatic snapshots of a volume
+type SnapshotPolicy struct {
+	Schedule  string `yaml:"schedule" validate:"required"`        // cron expression or @hourly, @daily, @weekly, @monthly
+	Retention int    `yaml:"retention" validate:"required,min=1"` // snapshots kept
 }
 
 // Secret represents encrypted credentials or config files
@@ -112,6 +215,17 @@ type Secret struct {
 	FileName string `yaml:"fileName" validate:"required,filename"`
 }
 
+// ConfigFile is a plain configuration file mounted into a pod at Path/FileName.
+// Its content is either given inline or read from a local Source file at
+// deploy time. Unlike secrets, config files are not treated as sensitive.
+type ConfigFile struct {
+	Name     string `yaml:"name" validate:"required"`
+	Path     string `yaml:"path" validate:"required,startswith=/"`
+	FileName string `yaml:"fileName,omitempty" validate:"omitempty,filename"`
+	Content  string `yaml:"content,omitempty"`
+	Source   string `yaml:"source,omitempty"`
+}
+
 // EnvVar represents an environment variable
 type EnvVar struct {
 	Key   string `yaml:"key" validate:"required,envvar"`
@@ -355,8 +355,8 @@ func (v *Validator) autoCorrectPod(pod *Pod) []ValidationError {
 		})
 	}
 
-	// Auto-correct service ports
-	if len(pod.ServicePorts) == 0 {
+	// Auto-correct service ports; jobs need none
+	if len(pod.ServicePorts) == 0 && !pod.IsJob() {
 		// Add default port based on common patterns
 		defaultPort := getDefaultPortForImage(pod.Image)
 		pod.ServicePorts = []ServicePort{{
@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package seed loads the seed data declared on pods of a nexlayer.yaml. Seeds
+// run on the platform as one-off commands, like migrations, under a marker
+// the platform records, so each seed runs once unless forced.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/migrate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// pollInterval is how often the status of a run is checked
+const pollInterval = 3 * time.Second
+
+// Pods returns the pods of config with a seed, limited to those with the
+// given run policy unless policy is empty
+func Pods(config *schema.NexlayerYAML, policy string) []schema.Pod {
+	var pods []schema.Pod
+	for _, pod := range config.Application.Pods {
+		if pod.Seed != nil && (policy == "" || pod.Seed.Policy() == policy) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// NewRequest builds the request seeding a pod. Seed files, relative to
+// baseDir, are sent along and the command runs with the vars of the pod.
+func NewRequest(pod schema.Pod, baseDir string) (apischema.MigrationRequest, error) {
+	command, err := schema.SeedCommand(pod)
+	if err != nil {
+		return apischema.MigrationRequest{}, err
+	}
+	req := apischema.MigrationRequest{
+		Release: schema.SeedMarker(pod),
+		Pod:     pod.Name,
+		Image:   pod.Seed.Image,
+		Command: command,
+	}
+	if req.Image == "" {
+		req.Image = pod.Image
+	}
+	if req.Image == "" {
+		return req, fmt.Errorf("seed of pod %s needs an image", pod.Name)
+	}
+	for _, v := range pod.Vars {
+		req.Vars = append(req.Vars, apischema.EnvVar{Key: v.Key, Value: v.Value})
+	}
+	for i, file := range pod.Seed.Files {
+		data, err := os.ReadFile(filepath.Join(baseDir, file))
+		if err != nil {
+			return req, fmt.Errorf("failed to read seed file of pod %s: %w", pod.Name, err)
+		}
+		if req.Files == nil {
+			req.Files = make(map[string]string)
+		}
+		req.Files[schema.SeedPath(i, file)] = string(data)
+	}
+	return req, nil
+}
+
+// Apply seeds a pod of config, waits for it and reports progress to out. A
+// pod already seeded under its marker is skipped unless force is set.
+func Apply(ctx context.Context, client api.APIClient, config *schema.NexlayerYAML, pod schema.Pod, baseDir string, force bool, out io.Writer) (*apischema.Migration, error) {
+	req, err := NewRequest(pod, baseDir)
+	if err != nil {
+		return nil, err
+	}
+	req.Force = force
+
+	if len(pod.Seed.Files) > 0 {
+		fmt.Fprintf(out, "Seeding %s from %s (marker %s)\n", pod.Name, strings.Join(pod.Seed.Files, ", "), req.Release)
+	} else {
+		fmt.Fprintf(out, "Seeding %s with %s (marker %s)\n", pod.Name, pod.Seed.Command, req.Release)
+	}
+	m, runErr := migrate.Run(ctx, client, config.Application.Name, req, pollInterval)
+	if m == nil {
+		return nil, runErr
+	}
+
+	logFile, err := migrate.SaveLogs(m)
+	if err != nil {
+		fmt.Fprintf(out, "Could not save the seed logs: %v\n", err)
+	}
+	switch {
+	case runErr != nil:
+		migrate.PrintTail(out, m)
+	case strings.EqualFold(m.Status, migrate.StatusSkipped):
+		fmt.Fprintf(out, "%s is already seeded; run 'nexlayer seed --pod %s --force' or change seed.marker to seed it again\n", pod.Name, pod.Name)
+	default:
+		fmt.Fprintf(out, "Seeded %s in %s\n", pod.Name, m.FinishedAt.Sub(m.StartedAt).Round(time.Second))
+	}
+	if logFile != "" && len(m.Logs) > 0 {
+		fmt.Fprintf(out, "Logs: %s\n", logFile)
+	}
+	return m, runErr
+}
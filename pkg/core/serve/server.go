@@ -0,0 +1,335 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package serve exposes validation, conversion, deployment and status over a
+// local REST API, for playgrounds, editors and internal tools:
+//
+//	GET  /v1/health               liveness, without authentication
+//	POST /v1/validate             body: nexlayer.yaml
+//	POST /v1/convert?name=<app>   body: docker-compose.yml; returns nexlayer.yaml
+//	POST /v1/deploy?appId=<id>    body: nexlayer.yaml
+//	GET  /v1/status/{namespace}
+//
+// Every other endpoint requires the header "Authorization: Bearer <token>".
+// Errors are returned as {"error": "..."}. Config files, envFrom files and
+// static sites must lie under the server's base directory, and compose files
+// may not name env files, so callers cannot read other files of the machine.
+package serve
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/validate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// MaxBodySize limits the size of request bodies
+const MaxBodySize = 1 << 20
+
+// DefaultAppName names converted applications when the request names none
+const DefaultAppName = "app"
+
+// Server handles the REST API
+type Server struct {
+	// Client runs the requests against the platform
+	Client *sdk.Client
+	// Token authenticates requests
+	Token string
+	// BaseDir resolves files referenced by deployed configurations; files
+	// outside of it are refused
+	BaseDir string
+	// AllowOrigins lists the browser origins allowed to call the API; "*"
+	// allows any
+	AllowOrigins []string
+	// OnRequest, when set, sees every request with the status returned
+	OnRequest func(r *http.Request, status int)
+}
+
+// ValidateResponse is returned by /v1/validate
+type ValidateResponse struct {
+	Valid  bool                       `json:"valid"`
+	Errors []validate.ValidationError `json:"errors"`
+}
+
+// NewToken generates a random token
+func NewToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Handler returns the handler serving the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.Handle("POST /v1/validate", s.auth(s.validate))
+	mux.Handle("POST /v1/convert", s.auth(s.convert))
+	mux.Handle("POST /v1/deploy", s.auth(s.deploy))
+	mux.Handle("GET /v1/status/{namespace}", s.auth(s.status))
+	return s.logged(s.cors(mux))
+}
+
+// validate checks the configuration in the body
+func (s *Server) validate(w http.ResponseWriter, r *http.Request) {
+	config, err := s.parse(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	problems := sdk.Validate(config, s.BaseDir)
+	if problems == nil {
+		problems = []validate.ValidationError{}
+	}
+	writeJSON(w, http.StatusOK, ValidateResponse{Valid: len(problems) == 0, Errors: problems})
+}
+
+// convert turns the Docker Compose file in the body into a nexlayer.yaml
+func (s *Server) convert(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := checkEnvFiles(data); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		name = DefaultAppName
+	}
+
+	dir, err := os.MkdirTemp("", "nexlayer-serve-*")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "docker-compose.yml")
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	config, err := sdk.Convert(file, name)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(out)
+}
+
+// deploy starts a deployment of the configuration in the body
+func (s *Server) deploy(w http.ResponseWriter, r *http.Request) {
+	config, err := s.parse(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// Build commands would run anything a caller sends on this machine
+	for _, pod := range config.Application.Pods {
+		if pod.Static != nil && pod.Static.Build != "" {
+			writeError(w, http.StatusUnprocessableEntity, fmt.Errorf("pod %s: static site builds are not run by the server; build locally and deploy the output directory", pod.Name))
+			return
+		}
+	}
+	resp, err := s.Client.Deploy(r.Context(), config, sdk.DeployOptions{
+		AppID:   r.URL.Query().Get("appId"),
+		BaseDir: s.BaseDir,
+	})
+	var invalid sdk.ValidationErrors
+	switch {
+	case errors.As(err, &invalid):
+		writeJSON(w, http.StatusUnprocessableEntity, ValidateResponse{Errors: invalid})
+	case err != nil:
+		writeError(w, http.StatusBadGateway, err)
+	default:
+		writeJSON(w, http.StatusAccepted, resp)
+	}
+}
+
+// status returns the state of a deployment
+func (s *Server) status(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	d, err := s.Client.Status(r.Context(), namespace)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, d)
+}
+
+// parse reads the configuration in the body of a request and refuses files
+// it refers to outside of BaseDir
+func (s *Server) parse(w http.ResponseWriter, r *http.Request) (*schema.NexlayerYAML, error) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil, errors.New("request body must be a nexlayer.yaml")
+	}
+	config, err := sdk.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFiles(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// checkFiles rejects config files, envFrom files and static sites that are
+// absolute or climb out of the base directory
+func checkFiles(config *schema.NexlayerYAML) error {
+	for _, pod := range config.Application.Pods {
+		for _, cf := range pod.ConfigFiles {
+			if err := checkPath(cf.Source); err != nil {
+				return fmt.Errorf("pod %s: config file %s: %w", pod.Name, cf.Name, err)
+			}
+		}
+		for _, e := range pod.EnvFrom {
+			if err := checkPath(e.File); err != nil {
+				return fmt.Errorf("pod %s: envFrom %s: %w", pod.Name, e.Source(), err)
+			}
+		}
+		if pod.Static != nil {
+			if err := checkPath(pod.Static.Dir); err != nil {
+				return fmt.Errorf("pod %s: static site: %w", pod.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// checkPath rejects a path that does not stay under the base directory
+func checkPath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "/") || strings.HasPrefix(path, `\`) || filepath.VolumeName(path) != "" {
+		return fmt.Errorf("%s: absolute paths are not allowed by the server", path)
+	}
+	if clean := filepath.Clean(filepath.FromSlash(path)); clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%s: paths outside of the server's base directory are not allowed", path)
+	}
+	return nil
+}
+
+// checkEnvFiles rejects compose files whose services read env files: the
+// converter would read them from this machine
+func checkEnvFiles(data []byte) error {
+	var doc struct {
+		Services map[string]struct {
+			EnvFile interface{} `yaml:"env_file"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse Docker Compose file: %w", err)
+	}
+	for name, service := range doc.Services {
+		if service.EnvFile != nil {
+			return fmt.Errorf("service %s: env_file is not read by the server; list the variables under environment", name)
+		}
+	}
+	return nil
+}
+
+// auth rejects requests without the server token
+func (s *Server) auth(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+			return
+		}
+		next(w, r)
+	})
+}
+
+// cors lets the allowed browser origins call the API
+func (s *Server) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && s.allowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Add("Vary", "Origin")
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowed reports whether a browser origin may call the API
+func (s *Server) allowed(origin string) bool {
+	for _, o := range s.AllowOrigins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// logged reports requests to OnRequest
+func (s *Server) logged(next http.Handler) http.Handler {
+	if s.OnRequest == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.OnRequest(r, rec.status)
+	})
+}
+
+// statusRecorder remembers the status written to a response
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// writeJSON writes v as the JSON body of a response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes err as the JSON body of a response
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package serve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestFilesOutsideBaseDir checks that requests cannot make the server read
+// files outside of its base directory
+func TestFilesOutsideBaseDir(t *testing.T) {
+	s := &Server{Token: "secret", BaseDir: t.TempDir()}
+	handler := s.Handler()
+
+	pod := `application:
+  name: app
+  pods:
+    - name: web
+      image: nginx:latest
+      path: /
+      servicePorts:
+        - {name: http, port: 80, targetPort: 80}
+`
+	tests := []struct {
+		name, path, body string
+		rejected         bool
+	}{
+		{"config file inside", "/v1/validate", pod + "      configFiles:\n        - name: conf\n          path: /etc/app\n          source: conf/app.ini\n", false},
+		{"absolute config file", "/v1/validate", pod + "      configFiles:\n        - name: conf\n          path: /etc/app\n          source: /etc/passwd\n", true},
+		{"escaping envFrom file", "/v1/deploy", pod + "      envFrom:\n        - file: ../../.env\n", true},
+		{"escaping static site", "/v1/deploy", pod + "      static:\n        dir: conf/../../dist\n", true},
+		{"compose env_file", "/v1/convert", "services:\n  web:\n    image: nginx\n    env_file: /root/.env\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer secret")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			refused := strings.Contains(rec.Body.String(), "not allowed") || strings.Contains(rec.Body.String(), "not read by the server")
+			if refused != tt.rejected {
+				t.Errorf("status %d, body %s; want rejected %v", rec.Code, rec.Body.String(), tt.rejected)
+			}
+		})
+	}
+}
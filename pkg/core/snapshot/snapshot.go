@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package snapshot records point-in-time snapshots of a deployment: the
+// configuration revision that is running together with a snapshot of every
+// volume, so both can be restored in one step after a bad release or a data
+// incident.
+//
+// Volume data stays on the platform; the snapshot record, which holds the
+// configuration and the ids of the volume snapshots, is kept in the project
+// under .nexlayer/snapshots/<namespace>.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"gopkg.in/yaml.v3"
+)
+
+// Dir holds the snapshot records of the current project
+var Dir = filepath.Join(".nexlayer", "snapshots")
+
+// Snapshot is a configuration revision with the volume snapshots taken with it
+type Snapshot struct {
+	ID          string    `json:"id"`
+	Namespace   string    `json:"namespace"`
+	Application string    `json:"application"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Config      string    `json:"config"` // nexlayer.yaml of the running revision
+	Volumes     []Volume  `json:"volumes,omitempty"`
+}
+
+// Volume is a volume snapshot that is part of a deployment snapshot
+type Volume struct {
+	Pod        string `json:"pod"`
+	Volume     string `json:"volume"`
+	SnapshotID string `json:"snapshotId"`
+}
+
+// Create captures the running revision of a deployment and snapshots each of
+// its volumes. base is the configuration the deployment was created from,
+// which supplies what the platform does not report, such as ports and vars.
+func Create(ctx context.Context, client api.APIClient, namespace string, base *schema.NexlayerYAML, description string) (*Snapshot, error) {
+	info, err := client.GetDeploymentInfo(ctx, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment info: %w", err)
+	}
+	if len(info.Data.PodStatuses) == 0 {
+		return nil, fmt.Errorf("deployment %s has no pods to snapshot", namespace)
+	}
+
+	config := tmpl.Snapshot(info.Data, base)
+	if config.Application.Name == "" {
+		config.Application.Name = namespace
+	}
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	now := time.Now().UTC()
+	s := &Snapshot{
+		ID:          now.Format("20060102-150405"),
+		Namespace:   namespace,
+		Application: config.Application.Name,
+		Description: description,
+		CreatedAt:   now,
+		Config:      string(content),
+	}
+	for _, pod := range config.Application.Pods {
+		for _, v := range pod.Volumes {
+			resp, err := client.CreateVolumeSnapshot(ctx, namespace, pod.Name, v.Name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to snapshot volume %s of pod %s: %w", v.Name, pod.Name, err)
+			}
+			s.Volumes = append(s.Volumes, Volume{Pod: pod.Name, Volume: v.Name, SnapshotID: resp.Data.ID})
+		}
+	}
+	return s, nil
+}
+
+// Parse returns the configuration revision of the snapshot
+func (s *Snapshot) Parse() (*schema.NexlayerYAML, error) {
+	var config schema.NexlayerYAML
+	if err := yaml.Unmarshal([]byte(s.Config), &config); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration of snapshot %s: %w", s.ID, err)
+	}
+	return &config, nil
+}
+
+// Save writes the snapshot record. Records may hold secret values, so they
+// are only readable by the current user.
+func Save(s *Snapshot) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(Dir, s.Namespace)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	file := filepath.Join(dir, s.ID+".json")
+	if err := os.WriteFile(file, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
+
+// Load reads a snapshot record. "latest" names the newest snapshot.
+func Load(namespace, id string) (*Snapshot, error) {
+	if id == "latest" {
+		all, err := List(namespace)
+		if err != nil {
+			return nil, err
+		}
+		if len(all) == 0 {
+			return nil, fmt.Errorf("no snapshots of %s", namespace)
+		}
+		return &all[0], nil
+	}
+	if strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid snapshot id %q", id)
+	}
+
+	file := filepath.Join(Dir, namespace, id+".json")
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("snapshot %s of %s not found; run 'nexlayer snapshot list %s'", id, namespace, namespace)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return &s, nil
+}
+
+// List returns the snapshots of a namespace, newest first
+func List(namespace string) ([]Snapshot, error) {
+	files, err := filepath.Glob(filepath.Join(Dir, namespace, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var all []Snapshot
+	for _, file := range files {
+		s, err := Load(namespace, strings.TrimSuffix(filepath.Base(file), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, *s)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.After(all[j].CreatedAt) })
+	return all, nil
+}
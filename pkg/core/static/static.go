@@ -0,0 +1,187 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package static builds and packages the assets of static site pods.
+//
+// A package is a gzipped tarball of the build output with a manifest telling
+// the platform how to serve it: the Cache-Control header of every file and
+// whether unknown paths fall back to index.html.
+package static
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// ManifestFile is the name of the manifest inside a package
+const ManifestFile = ".nexlayer-static.json"
+
+// IndexFile is served for "/" and, with spa, for paths without a file
+const IndexFile = "index.html"
+
+// Manifest tells the platform how to serve a package
+type Manifest struct {
+	SPA      bool              `json:"spa"`
+	Fallback string            `json:"fallback,omitempty"`
+	Headers  map[string]Header `json:"headers"` // by file path, e.g. /assets/app.js
+}
+
+// Header holds the response headers of one file
+type Header struct {
+	CacheControl string `json:"cacheControl,omitempty"`
+}
+
+// Package is a packed static site ready for upload
+type Package struct {
+	Data   []byte
+	Files  int
+	Digest string // sha256 of the files and manifest, stable across builds with the same output
+}
+
+// Dir returns the build output directory of a site, relative to baseDir
+func Dir(site schema.StaticSite, baseDir string) string {
+	if filepath.IsAbs(site.Dir) {
+		return site.Dir
+	}
+	return filepath.Join(baseDir, site.Dir)
+}
+
+// Build runs the site's build command in baseDir, if it has one
+func Build(ctx context.Context, site schema.StaticSite, baseDir string, stdout, stderr io.Writer) error {
+	if site.Build == "" {
+		return nil
+	}
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, site.Build)
+	cmd.Dir = baseDir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("build %q failed: %w", site.Build, err)
+	}
+	return nil
+}
+
+// Pack packages the build output of a site. The output must contain an
+// index.html at its root.
+func Pack(site schema.StaticSite, baseDir string) (*Package, error) {
+	dir := Dir(site, baseDir)
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s is empty; run the build first", dir)
+	}
+
+	manifest := Manifest{SPA: site.SPA, Headers: make(map[string]Header, len(files))}
+	if site.SPA {
+		manifest.Fallback = "/" + IndexFile
+	}
+	hasIndex := false
+	for _, f := range files {
+		manifest.Headers[f] = Header{CacheControl: site.CacheControlFor(f)}
+		hasIndex = hasIndex || f == "/"+IndexFile
+	}
+	if !hasIndex {
+		return nil, fmt.Errorf("%s has no %s", dir, IndexFile)
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	digest := sha256.New()
+
+	// A fixed modification time keeps the package, and its digest, the same
+	// when the build output is
+	add := func(name string, data []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Unix(0, 0)}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		fmt.Fprintf(digest, "%s\x00%d\x00", name, len(data))
+		digest.Write(data)
+		return nil
+	}
+
+	if err := add(ManifestFile, manifestData); err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f, err)
+		}
+		if err := add(f[1:], data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write package: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write package: %w", err)
+	}
+
+	return &Package{Data: buf.Bytes(), Files: len(files), Digest: hex.EncodeToString(digest.Sum(nil))}, nil
+}
+
+// listFiles returns the regular files under dir as sorted paths from the site
+// root, e.g. /assets/app.js
+func listFiles(dir string) ([]string, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("build output %s not found: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("build output %s is not a directory", dir)
+	}
+
+	var files []string
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, "/"+filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
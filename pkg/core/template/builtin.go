@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"context"
+	"embed"
+	"fmt"
+)
+
+//go:embed builtin/*.yaml
+var builtinFS embed.FS
+
+// BuiltinVersion is the version of every built-in template; they change
+// with the CLI
+const BuiltinVersion = "builtin"
+
+// Param is a variable of a built-in template, with the value used when the
+// user gives none
+type Param struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Default     string `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// Builtin is a starter template shipped in the binary
+type Builtin struct {
+	Metadata Metadata `json:"metadata" yaml:"metadata"`
+	Params   []Param  `json:"params" yaml:"params"`
+}
+
+var (
+	appNameParam       = Param{Name: "appName", Description: "Application name"}
+	dbPasswordVarParam = Param{Name: "dbPasswordVar", Description: "Secret holding the database password", Default: "DB_PASSWORD"}
+)
+
+// builtins is the gallery of starter templates, each in builtin/<name>.yaml
+var builtins = []Builtin{
+	{
+		Metadata: Metadata{Name: "mern", Stack: "node", Description: "MongoDB, Express, React and Node.js", Keywords: []string{"mongodb", "express", "react"}},
+		Params:   []Param{appNameParam, dbPasswordVarParam},
+	},
+	{
+		Metadata: Metadata{Name: "pern", Stack: "node", Description: "PostgreSQL, Express, React and Node.js", Keywords: []string{"postgres", "express", "react"}},
+		Params:   []Param{appNameParam, dbPasswordVarParam},
+	},
+	{
+		Metadata: Metadata{Name: "fastapi-postgres", Stack: "python", Description: "FastAPI with PostgreSQL", Keywords: []string{"fastapi", "postgres"}},
+		Params:   []Param{appNameParam, dbPasswordVarParam},
+	},
+	{
+		Metadata: Metadata{Name: "nextjs-supabase", Stack: "nextjs", Description: "Next.js on a hosted Supabase project", Keywords: []string{"nextjs", "supabase"}},
+		Params: []Param{appNameParam,
+			{Name: "supabaseUrl", Description: "URL of the Supabase project", Default: "https://example.supabase.co"}},
+	},
+	{
+		Metadata: Metadata{Name: "llm-rag", Stack: "python", Description: "Chat UI, Python RAG API and Qdrant vector database", Keywords: []string{"llm", "rag", "qdrant", "ai"}},
+		Params: []Param{appNameParam,
+			{Name: "llmKeyVar", Description: "Secret holding the LLM provider API key", Default: "LLM_API_KEY"},
+			{Name: "llmModel", Description: "Model the API asks for", Default: "gpt-4o-mini"}},
+	},
+}
+
+// Builtins returns the built-in templates
+func Builtins() []Builtin {
+	list := make([]Builtin, len(builtins))
+	for i, b := range builtins {
+		b.Metadata.Version = BuiltinVersion
+		list[i] = b
+	}
+	return list
+}
+
+// FindBuiltin returns the built-in template with this name
+func FindBuiltin(name string) (Builtin, bool) {
+	for _, b := range Builtins() {
+		if b.Metadata.Name == name {
+			return b, true
+		}
+	}
+	return Builtin{}, false
+}
+
+// BuiltinRegistry serves the built-in templates. It is read-only.
+type BuiltinRegistry struct{}
+
+// NewBuiltinRegistry returns the registry of the built-in templates
+func NewBuiltinRegistry() *BuiltinRegistry {
+	return &BuiltinRegistry{}
+}
+
+// Push refuses to publish: built-in templates ship with the CLI
+func (r *BuiltinRegistry) Push(ctx context.Context, tmpl *Template) error {
+	return fmt.Errorf("built-in templates are read-only; push to a registry with --registry")
+}
+
+// Pull reads a built-in template. The version must be empty or BuiltinVersion.
+func (r *BuiltinRegistry) Pull(ctx context.Context, name, version string) (*Template, error) {
+	b, ok := FindBuiltin(name)
+	if !ok || (version != "" && version != BuiltinVersion) {
+		return nil, fmt.Errorf("built-in template %s not found", name)
+	}
+	content, err := builtinFS.ReadFile("builtin/" + name + ".yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in template %s: %w", name, err)
+	}
+	return &Template{Metadata: b.Metadata, Content: content}, nil
+}
+
+// Search lists the built-in templates matching the query
+func (r *BuiltinRegistry) Search(ctx context.Context, query SearchQuery) ([]Metadata, error) {
+	var results []Metadata
+	for _, b := range Builtins() {
+		if query.Matches(b.Metadata) {
+			results = append(results, b.Metadata)
+		}
+	}
+	return results, nil
+}
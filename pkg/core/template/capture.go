@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// Capture builds a template from a running deployment. Pods and images come
+// from the deployment; ports, vars and volumes are taken from base when it
+// defines a pod with the same name, and otherwise from what the API reports
+// for the pod. The result is parameterized with Parameterize.
+func Capture(dep apischema.Deployment, base *schema.NexlayerYAML) (*schema.NexlayerYAML, []string) {
+	config := Snapshot(dep, base)
+	return config, Parameterize(config)
+}
+
+// Snapshot builds the configuration of a running deployment like Capture, but
+// keeps the application name, URL and all values as they are.
+func Snapshot(dep apischema.Deployment, base *schema.NexlayerYAML) *schema.NexlayerYAML {
+	known := make(map[string]schema.Pod)
+	config := &schema.NexlayerYAML{}
+	if base != nil {
+		for _, p := range base.Application.Pods {
+			known[p.Name] = p
+		}
+		config.Application.Name = base.Application.Name
+		config.Application.URL = base.Application.URL
+		config.Application.RegistryLogin = base.Application.RegistryLogin
+	}
+
+	for _, status := range dep.PodStatuses {
+		pod, ok := known[status.Name]
+		if !ok {
+			pod = schema.Pod{Name: status.Name, Type: status.Type}
+			for _, p := range status.Ports {
+				name := p.Name
+				if name == "" {
+					name = status.Name
+				}
+				port := p.ServicePort
+				if port == 0 {
+					port = p.ContainerPort
+				}
+				sp := schema.ServicePort{Name: name, Port: port, TargetPort: p.ContainerPort}
+				if protocol := schema.NormalizeProtocol(p.Protocol); protocol != schema.ProtocolTCP {
+					sp.Protocol = protocol
+				}
+				pod.ServicePorts = append(pod.ServicePorts, sp)
+			}
+			if port, ok := schema.DefaultPorts[status.Type]; ok && len(pod.ServicePorts) == 0 {
+				pod.ServicePorts = []schema.ServicePort{{Name: status.Name, Port: port, TargetPort: port}}
+			}
+			for _, v := range status.Vars {
+				pod.Vars = append(pod.Vars, schema.EnvVar{Key: v.Key, Value: v.Value})
+			}
+			pod.Labels = status.Labels
+			pod.Annotations = status.Annotations
+		}
+		if status.Image != "" {
+			pod.Image = status.Image
+		}
+		config.Application.Pods = append(config.Application.Pods, pod)
+	}
+	return config
+}
+
+// Parameterize replaces deployment-specific values with template variables and
+// strips secrets. It returns the names of the variables it introduced.
+func Parameterize(config *schema.NexlayerYAML) []string {
+	vars := map[string]bool{"appName": true}
+	config.Application.Name = "{{ .appName }}"
+	config.Application.URL = ""
+
+	if login := config.Application.RegistryLogin; login != nil {
+		login.Username = "{{ .registryUsername }}"
+		login.PersonalAccessToken = "{{ .registryToken }}"
+		vars["registryUsername"] = true
+		vars["registryToken"] = true
+	}
+
+	for i := range config.Application.Pods {
+		pod := &config.Application.Pods[i]
+		for j := range pod.Vars {
+			if schema.IsSensitiveKey(pod.Vars[j].Key) {
+				name := variableName(pod.Name, pod.Vars[j].Key)
+				pod.Vars[j].Value = fmt.Sprintf("{{ .%s }}", name)
+				vars[name] = true
+			}
+		}
+		for j := range pod.Secrets {
+			name := variableName(pod.Name, pod.Secrets[j].Name)
+			pod.Secrets[j].Data = fmt.Sprintf("{{ .%s }}", name)
+			vars[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// variableName builds a camelCase template variable from a pod name and key,
+// e.g. ("db", "POSTGRES_PASSWORD") -> "dbPostgresPassword"
+func variableName(parts ...string) string {
+	var b strings.Builder
+	for _, part := range parts {
+		for _, word := range strings.FieldsFunc(part, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			word = strings.ToLower(word)
+			if b.Len() == 0 {
+				b.WriteString(word)
+				continue
+			}
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
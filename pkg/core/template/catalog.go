@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/config"
+	"gopkg.in/yaml.v3"
+)
+
+const catalogsFile = "catalogs.yaml"
+
+// Visibility values for published templates. The registry enforces them;
+// the CLI only records the publisher's intent.
+const (
+	VisibilityPublic  = "public"
+	VisibilityOrg     = "org"
+	VisibilityPrivate = "private"
+)
+
+// Catalog is an organization-scoped template registry. Templates named
+// "<org>/<name>" are resolved against the catalog configured for <org>.
+type Catalog struct {
+	Org   string `yaml:"org"`
+	URL   string `yaml:"url"`
+	Token string `yaml:"token,omitempty"`
+}
+
+// CatalogsPath returns the file that stores configured catalogs
+func CatalogsPath() (string, error) {
+	dir, err := DefaultRegistryDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(dir), catalogsFile), nil
+}
+
+// LoadCatalogs reads the configured catalogs. A missing file yields no catalogs.
+func LoadCatalogs() ([]Catalog, error) {
+	path, err := CatalogsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalogs: %w", err)
+	}
+	var catalogs []Catalog
+	if err := yaml.Unmarshal(data, &catalogs); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return catalogs, nil
+}
+
+// SaveCatalogs writes the catalogs file. It is only readable by the current
+// user because it may contain access tokens.
+func SaveCatalogs(catalogs []Catalog) error {
+	path, err := CatalogsPath()
+	if err != nil {
+		return err
+	}
+	sort.Slice(catalogs, func(i, j int) bool { return catalogs[i].Org < catalogs[j].Org })
+	data, err := yaml.Marshal(catalogs)
+	if err != nil {
+		return fmt.Errorf("failed to encode catalogs: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write catalogs: %w", err)
+	}
+	return nil
+}
+
+// ValidateOrg checks that an organization name is a single path segment
+func ValidateOrg(org string) error {
+	if org == "" || strings.Contains(org, "/") {
+		return fmt.Errorf("invalid organization name: %q", org)
+	}
+	return ValidateName(org)
+}
+
+// ValidateCatalogURL checks that a catalog is reached over https, as its
+// token is sent with every request. Plain http is only allowed to this machine.
+func ValidateCatalogURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid catalog URL: %q", rawURL)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("catalog URL must start with https:// (http is only allowed to localhost): %s", rawURL)
+}
+
+// FindCatalog returns the catalog serving a template name, if any
+func FindCatalog(catalogs []Catalog, name string) (Catalog, bool) {
+	org, _, ok := strings.Cut(name, "/")
+	if !ok {
+		return Catalog{}, false
+	}
+	for _, c := range catalogs {
+		if c.Org == org {
+			return c, true
+		}
+	}
+	return Catalog{}, false
+}
+
+// OpenRegistryFor returns the registry that serves a template name. An explicit
+// location always wins; otherwise built-in templates are served from the
+// binary, an "<org>/" prefix selects that org's catalog, and everything else
+// falls back to the default registry.
+func OpenRegistryFor(location, name string) (Registry, error) {
+	if location != "" {
+		return OpenRegistry(location)
+	}
+	if _, ok := FindBuiltin(name); ok {
+		return NewBuiltinRegistry(), nil
+	}
+	catalogs, err := LoadCatalogs()
+	if err != nil {
+		return nil, err
+	}
+	c, ok := FindCatalog(catalogs, name)
+	if !ok {
+		return OpenRegistry("")
+	}
+	if err := ValidateCatalogURL(c.URL); err != nil {
+		return nil, fmt.Errorf("catalog of %s: %w", c.Org, err)
+	}
+	reg := NewHTTPRegistry(c.URL)
+	token := c.Token
+	if token == "" {
+		token = config.GetToken()
+	}
+	reg.SetToken(token)
+	return reg, nil
+}
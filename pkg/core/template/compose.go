@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// extendsKey is the top-level key naming a template's base
+const extendsKey = "extends"
+
+// maxExtendsDepth bounds the length of an extends chain
+const maxExtendsDepth = 8
+
+var (
+	actionPattern      = regexp.MustCompile(`{{-?\s*(.*?)\s*-?}}`)
+	placeholderPattern = regexp.MustCompile(`__nexlayer_var_(\d+)__`)
+)
+
+// Resolve prepares a pulled template for use. It composes the extends chain
+// and migrates every layer from its declared schemaVersion to the current
+// schema. Templates that need neither are returned unchanged. Templates that
+// target a newer CLI are refused with a *schema.SchemaTooNewError. The
+// returned notes describe the migrations that were applied.
+//
+// A child is merged over its base like an environment overlay, with
+// schema.MergeNodes: child values win, lists of pods, ports, volumes, secrets
+// and vars are merged item by item, and $patch and null values remove or
+// replace what the base defines.
+//
+// Variables such as {{ .appName }} are carried through untouched, so bases and
+// children share one set of values. Control actions (if, range, ...) cannot
+// be used in templates that extend, are extended or need migrating.
+func Resolve(ctx context.Context, reg Registry, content []byte) ([]byte, []string, error) {
+	if !hasKey(content, extendsKey) && !hasKey(content, schema.SchemaVersionKey) {
+		return content, nil, nil
+	}
+	r := &resolver{reg: reg}
+	root, err := r.resolve(ctx, content, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !r.changed {
+		return content, nil, nil
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode template: %w", err)
+	}
+	return r.restore(buf.Bytes()), r.notes, nil
+}
+
+// resolver carries state across the layers of an extends chain
+type resolver struct {
+	protector
+	reg     Registry
+	notes   []string
+	changed bool
+}
+
+// resolve decodes and migrates content and, when it extends a base, merges it
+// over the resolved base. chain holds the references already visited.
+func (r *resolver) resolve(ctx context.Context, content []byte, chain []string) (*yaml.Node, error) {
+	protected, err := r.protect(content)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(protected, &doc); err != nil {
+		return nil, fmt.Errorf("template is not valid YAML: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("template must be a YAML mapping")
+	}
+	root := doc.Content[0]
+
+	applied, err := schema.Migrate(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range applied {
+		name := "template"
+		if len(chain) > 0 {
+			name = chain[len(chain)-1]
+		}
+		r.notes = append(r.notes, fmt.Sprintf("%s: migrated %s", name, step))
+		r.changed = true
+	}
+
+	ref, ok, err := takeExtends(root)
+	if err != nil || !ok {
+		return root, err
+	}
+	r.changed = true
+	for _, seen := range chain {
+		if seen == ref {
+			return nil, fmt.Errorf("template extends cycle: %s -> %s", strings.Join(chain, " -> "), ref)
+		}
+	}
+	if len(chain) >= maxExtendsDepth {
+		return nil, fmt.Errorf("template extends chain is deeper than %d levels", maxExtendsDepth)
+	}
+
+	name, version, err := ParseRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	base, err := r.reg.Pull(ctx, name, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull base template %s: %w", ref, err)
+	}
+	baseRoot, err := r.resolve(ctx, base.Content, append(chain, ref))
+	if err != nil {
+		return nil, err
+	}
+	return schema.MergeNodes(baseRoot, root), nil
+}
+
+// hasKey is a cheap check for a top-level key that avoids decoding templates
+// that cannot need resolving
+func hasKey(content []byte, key string) bool {
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, key+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// takeExtends removes the extends key from a mapping and returns its value
+func takeExtends(root *yaml.Node) (string, bool, error) {
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != extendsKey {
+			continue
+		}
+		value := root.Content[i+1]
+		if value.Kind != yaml.ScalarNode || value.Value == "" {
+			return "", false, fmt.Errorf("extends must be a template reference such as base@1.0.0")
+		}
+		root.Content = append(root.Content[:i], root.Content[i+2:]...)
+		return value.Value, true, nil
+	}
+	return "", false, nil
+}
+
+// protector swaps template actions for plain placeholders so templates can be
+// handled as YAML, and swaps them back afterwards
+type protector struct {
+	actions []string
+}
+
+// protect replaces every {{ ... }} action with a placeholder
+func (p *protector) protect(content []byte) ([]byte, error) {
+	var err error
+	out := actionPattern.ReplaceAllFunc(content, func(action []byte) []byte {
+		body := actionPattern.FindSubmatch(action)[1]
+		if fields := strings.Fields(string(body)); len(fields) > 0 {
+			switch fields[0] {
+			case "if", "else", "end", "range", "with", "define", "template", "block":
+				err = fmt.Errorf("templates using extends cannot contain {{ %s }} actions", fields[0])
+			}
+		}
+		p.actions = append(p.actions, string(action))
+		return []byte(fmt.Sprintf("__nexlayer_var_%d__", len(p.actions)-1))
+	})
+	return out, err
+}
+
+// restore puts the original actions back in place of their placeholders
+func (p *protector) restore(content []byte) []byte {
+	return placeholderPattern.ReplaceAllFunc(content, func(placeholder []byte) []byte {
+		i, err := strconv.Atoi(string(placeholderPattern.FindSubmatch(placeholder)[1]))
+		if err != nil || i >= len(p.actions) {
+			return placeholder
+		}
+		return []byte(p.actions[i])
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+)
+
+// ChangeKind describes how an element differs between two templates
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is a single structural difference between two templates
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	Path string     `json:"path"`
+	Old  string     `json:"old,omitempty"`
+	New  string     `json:"new,omitempty"`
+}
+
+// Diff compares two configurations pod by pod and returns the differences
+func Diff(oldCfg, newCfg *schema.NexlayerYAML) []Change {
+	var changes []Change
+	add := func(kind ChangeKind, path, o, n string) {
+		changes = append(changes, Change{Kind: kind, Path: path, Old: o, New: n})
+	}
+
+	if oldCfg.Application.Name != newCfg.Application.Name {
+		add(ChangeChanged, "application.name", oldCfg.Application.Name, newCfg.Application.Name)
+	}
+	if oldCfg.Application.URL != newCfg.Application.URL {
+		add(ChangeChanged, "application.url", oldCfg.Application.URL, newCfg.Application.URL)
+	}
+
+	oldPods := podsByName(oldCfg.Application.Pods)
+	newPods := podsByName(newCfg.Application.Pods)
+	for _, name := range unionKeys(oldPods, newPods) {
+		path := "pods." + name
+		o, inOld := oldPods[name]
+		n, inNew := newPods[name]
+		switch {
+		case !inOld:
+			add(ChangeAdded, path, "", n.Image)
+		case !inNew:
+			add(ChangeRemoved, path, o.Image, "")
+		default:
+			changes = append(changes, diffPod(path, o, n)...)
+		}
+	}
+	return changes
+}
+
+// diffPod compares two pods with the same name
+func diffPod(path string, o, n schema.Pod) []Change {
+	var changes []Change
+	scalar := func(field, a, b string) {
+		if a != b {
+			changes = append(changes, Change{Kind: ChangeChanged, Path: path + "." + field, Old: a, New: b})
+		}
+	}
+	scalar("image", o.Image, n.Image)
+	scalar("type", o.Type, n.Type)
+	scalar("path", o.Path, n.Path)
+	scalar("entrypoint", o.Entrypoint.String(), n.Entrypoint.String())
+	scalar("command", o.Command.String(), n.Command.String())
+
+	changes = append(changes, diffMaps(path+".servicePorts", portsByName(o.ServicePorts), portsByName(n.ServicePorts))...)
+	changes = append(changes, diffMaps(path+".vars", varsByKey(o.Vars), varsByKey(n.Vars))...)
+	changes = append(changes, diffMaps(path+".volumes", volumesByName(o.Volumes), volumesByName(n.Volumes))...)
+	return changes
+}
+
+// diffMaps compares keyed string representations of list elements
+func diffMaps(path string, o, n map[string]string) []Change {
+	var changes []Change
+	for _, key := range unionKeys(o, n) {
+		a, inOld := o[key]
+		b, inNew := n[key]
+		switch {
+		case !inOld:
+			changes = append(changes, Change{Kind: ChangeAdded, Path: path + "." + key, New: b})
+		case !inNew:
+			changes = append(changes, Change{Kind: ChangeRemoved, Path: path + "." + key, Old: a})
+		case a != b:
+			changes = append(changes, Change{Kind: ChangeChanged, Path: path + "." + key, Old: a, New: b})
+		}
+	}
+	return changes
+}
+
+func podsByName(pods []schema.Pod) map[string]schema.Pod {
+	m := make(map[string]schema.Pod, len(pods))
+	for _, p := range pods {
+		m[p.Name] = p
+	}
+	return m
+}
+
+func portsByName(ports []schema.ServicePort) map[string]string {
+	m := make(map[string]string, len(ports))
+	for _, p := range ports {
+		desc := fmt.Sprintf("%d->%d", p.Port, p.TargetPort)
+		if protocol := schema.NormalizeProtocol(p.Protocol); protocol != schema.ProtocolTCP {
+			desc += "/" + protocol
+		}
+		m[p.Name] = desc
+	}
+	return m
+}
+
+func varsByKey(vars []schema.EnvVar) map[string]string {
+	m := make(map[string]string, len(vars))
+	for _, v := range vars {
+		m[v.Key] = v.Value
+	}
+	return m
+}
+
+func volumesByName(volumes []schema.Volume) map[string]string {
+	m := make(map[string]string, len(volumes))
+	for _, v := range volumes {
+		parts := []string{v.Path}
+		if v.Size != "" {
+			parts = append(parts, v.Size)
+		}
+		if v.ReadOnly {
+			parts = append(parts, "ro")
+		}
+		m[v.Name] = strings.Join(parts, " ")
+	}
+	return m
+}
+
+// unionKeys returns the sorted keys present in either map
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		seen[k] = true
+	}
+	for k := range b {
+		seen[k] = true
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
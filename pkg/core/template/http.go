@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+)
+
+// HTTPRegistry talks to a remote template registry over HTTP.
+//
+//	GET  /templates?q=&stack=          -> []Metadata
+//	GET  /templates/{name}?version=    -> {metadata, content}
+//	POST /templates                    <- {metadata, content}
+type HTTPRegistry struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+}
+
+// templatePayload is the wire format for a single template
+type templatePayload struct {
+	Metadata Metadata `json:"metadata"`
+	Content  string   `json:"content"`
+}
+
+// NewHTTPRegistry creates a registry client for baseURL
+func NewHTTPRegistry(baseURL string) *HTTPRegistry {
+	return &HTTPRegistry{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: &offline.Transport{}},
+	}
+}
+
+// SetToken sets the bearer token sent with every request
+func (r *HTTPRegistry) SetToken(token string) {
+	r.token = token
+}
+
+// Push uploads a template version
+func (r *HTTPRegistry) Push(ctx context.Context, tmpl *Template) error {
+	if err := ValidateName(tmpl.Metadata.Name); err != nil {
+		return err
+	}
+	if err := ValidateVersion(tmpl.Metadata.Version); err != nil {
+		return err
+	}
+	body, err := json.Marshal(templatePayload{Metadata: tmpl.Metadata, Content: string(tmpl.Content)})
+	if err != nil {
+		return fmt.Errorf("failed to encode template: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+"/templates", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Pull downloads a template version
+func (r *HTTPRegistry) Pull(ctx context.Context, name, version string) (*Template, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	u := fmt.Sprintf("%s/templates/%s", r.baseURL, name)
+	if version != "" {
+		if err := ValidateVersion(version); err != nil {
+			return nil, err
+		}
+		u += "?version=" + url.QueryEscape(version)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := r.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var payload templatePayload
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode template: %w", err)
+	}
+	return &Template{Metadata: payload.Metadata, Content: []byte(payload.Content)}, nil
+}
+
+// Search queries the registry index
+func (r *HTTPRegistry) Search(ctx context.Context, query SearchQuery) ([]Metadata, error) {
+	params := url.Values{}
+	if query.Keyword != "" {
+		params.Set("q", query.Keyword)
+	}
+	if query.Stack != "" {
+		params.Set("stack", query.Stack)
+	}
+	u := r.baseURL + "/templates"
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := r.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var results []Metadata
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode search results: %w", err)
+	}
+	return results, nil
+}
+
+// do sends a request and converts error statuses into errors
+func (r *HTTPRegistry) do(req *http.Request) (*http.Response, error) {
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach template registry: %w", err)
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		resp.Body.Close()
+		return nil, fmt.Errorf("template registry requires authentication: set a catalog token or NEXLAYER_TOKEN")
+	case http.StatusForbidden:
+		resp.Body.Close()
+		return nil, fmt.Errorf("access denied by template registry: your account is not allowed to access this template")
+	}
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("template registry error (status %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
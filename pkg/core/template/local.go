@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	templateFile = "template.yaml"
+	metadataFile = "metadata.json"
+)
+
+// LocalRegistry stores templates on disk as <root>/<name>/<version>/template.yaml
+type LocalRegistry struct {
+	root string
+}
+
+// NewLocalRegistry creates a registry rooted at dir
+func NewLocalRegistry(dir string) *LocalRegistry {
+	return &LocalRegistry{root: dir}
+}
+
+// Push writes a new template version to disk
+func (r *LocalRegistry) Push(ctx context.Context, tmpl *Template) error {
+	if err := ValidateName(tmpl.Metadata.Name); err != nil {
+		return err
+	}
+	if err := ValidateVersion(tmpl.Metadata.Version); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(r.root, filepath.FromSlash(tmpl.Metadata.Name), tmpl.Metadata.Version)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("template %s@%s already exists", tmpl.Metadata.Name, tmpl.Metadata.Version)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create template directory: %w", err)
+	}
+
+	meta, err := json.MarshalIndent(tmpl.Metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, metadataFile), meta, 0644); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, templateFile), tmpl.Content, 0644); err != nil {
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+	return nil
+}
+
+// Pull reads a template version from disk
+func (r *LocalRegistry) Pull(ctx context.Context, name, version string) (*Template, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	if version == "" {
+		versions, err := r.versions(name)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			return nil, fmt.Errorf("template %s not found", name)
+		}
+		version = latest(versions).Version
+	}
+	if err := ValidateVersion(version); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(r.root, filepath.FromSlash(name), version)
+	meta, err := readMetadata(dir)
+	if err != nil {
+		return nil, fmt.Errorf("template %s@%s not found", name, version)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, templateFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+	return &Template{Metadata: meta, Content: content}, nil
+}
+
+// Search walks the registry and returns the latest version of each matching template
+func (r *LocalRegistry) Search(ctx context.Context, query SearchQuery) ([]Metadata, error) {
+	byName := make(map[string][]Metadata)
+	err := filepath.WalkDir(r.root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != metadataFile {
+			return nil
+		}
+		meta, err := readMetadata(filepath.Dir(path))
+		if err != nil {
+			return nil
+		}
+		byName[meta.Name] = append(byName[meta.Name], meta)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read registry: %w", err)
+	}
+
+	var results []Metadata
+	for _, versions := range byName {
+		if m := latest(versions); query.Matches(m) {
+			results = append(results, m)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results, nil
+}
+
+// versions lists every published version of a template
+func (r *LocalRegistry) versions(name string) ([]Metadata, error) {
+	entries, err := os.ReadDir(filepath.Join(r.root, filepath.FromSlash(name)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read template versions: %w", err)
+	}
+	var versions []Metadata
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if meta, err := readMetadata(filepath.Join(r.root, filepath.FromSlash(name), e.Name())); err == nil {
+			versions = append(versions, meta)
+		}
+	}
+	return versions, nil
+}
+
+// readMetadata loads metadata.json from a version directory
+func readMetadata(dir string) (Metadata, error) {
+	var meta Metadata
+	data, err := os.ReadFile(filepath.Join(dir, metadataFile))
+	if err != nil {
+		return meta, err
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("invalid metadata in %s: %w", dir, err)
+	}
+	return meta, nil
+}
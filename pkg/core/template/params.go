@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Values holds the variables used to instantiate a parameterized template
+type Values map[string]interface{}
+
+// LoadValues reads a values.yaml file
+func LoadValues(path string) (Values, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values file: %w", err)
+	}
+	values := Values{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse values file: %w", err)
+	}
+	return values, nil
+}
+
+// ParseSetFlags converts "key=value" pairs into values, overriding base
+func ParseSetFlags(base Values, pairs []string) (Values, error) {
+	if base == nil {
+		base = Values{}
+	}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --set value %q, expected key=value", pair)
+		}
+		base[key] = value
+	}
+	return base, nil
+}
+
+// Variables returns the sorted names of the top-level variables a template references
+func Variables(content []byte) ([]string, error) {
+	t, err := template.New("template").Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Nodes {
+				walk(c)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, c := range n.Cmds {
+				walk(c)
+			}
+		case *parse.CommandNode:
+			for _, a := range n.Args {
+				walk(a)
+			}
+		case *parse.FieldNode:
+			seen[n.Ident[0]] = true
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	walk(t.Tree.Root)
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Missing returns the template variables that have no value
+func Missing(content []byte, values Values) ([]string, error) {
+	names, err := Variables(content)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, name := range names {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// Render instantiates a template with values. Every referenced variable must be set.
+func Render(content []byte, values Values) ([]byte, error) {
+	t, err := template.New("template").Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, map[string]interface{}(values)); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderPlaceholder renders a template with a neutral value for every variable,
+// so the structure can be checked before any real values are known
+func RenderPlaceholder(content []byte) ([]byte, error) {
+	return renderEach(content, func(string) interface{} { return "1" })
+}
+
+// RenderSymbolic renders a template with "${name}" in place of every variable,
+// which keeps variable names visible in string fields
+func RenderSymbolic(content []byte) ([]byte, error) {
+	return renderEach(content, func(name string) interface{} { return "${" + name + "}" })
+}
+
+// renderEach renders a template using value(name) for every variable
+func renderEach(content []byte, value func(name string) interface{}) ([]byte, error) {
+	names, err := Variables(content)
+	if err != nil {
+		return nil, err
+	}
+	values := Values{}
+	for _, name := range names {
+		values[name] = value(name)
+	}
+	return Render(content, values)
+}
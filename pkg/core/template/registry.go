@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package template provides storage and discovery of reusable Nexlayer templates.
+package template
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+)
+
+// Metadata describes a published template version
+type Metadata struct {
+	Name        string    `json:"name" yaml:"name"`
+	Version     string    `json:"version" yaml:"version"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Stack       string    `json:"stack,omitempty" yaml:"stack,omitempty"`
+	Keywords    []string  `json:"keywords,omitempty" yaml:"keywords,omitempty"`
+	Changelog   string    `json:"changelog,omitempty" yaml:"changelog,omitempty"`
+	Visibility  string    `json:"visibility,omitempty" yaml:"visibility,omitempty"`
+	PublishedAt time.Time `json:"publishedAt" yaml:"publishedAt"`
+}
+
+// Template is a template body together with its metadata
+type Template struct {
+	Metadata Metadata
+	Content  []byte
+}
+
+// SearchQuery filters templates in a registry
+type SearchQuery struct {
+	Keyword string
+	Stack   string
+}
+
+// Registry stores and retrieves templates
+type Registry interface {
+	// Push publishes a template version. Existing versions are never overwritten.
+	Push(ctx context.Context, tmpl *Template) error
+	// Pull fetches a template. An empty version resolves to the latest one.
+	Pull(ctx context.Context, name, version string) (*Template, error)
+	// Search lists the latest version of each template matching the query.
+	Search(ctx context.Context, query SearchQuery) ([]Metadata, error)
+}
+
+// DefaultRegistryDir returns the local registry location under the user config directory
+func DefaultRegistryDir() (string, error) {
+	home, err := system.HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "nexlayer", "templates"), nil
+}
+
+// OpenRegistry returns a registry for a location, which is either an
+// http(s) URL or a directory on disk
+func OpenRegistry(location string) (Registry, error) {
+	if location == "" {
+		dir, err := DefaultRegistryDir()
+		if err != nil {
+			return nil, err
+		}
+		location = dir
+	}
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return NewHTTPRegistry(location), nil
+	}
+	dir := system.HostPath(location)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create registry directory: %w", err)
+	}
+	return NewLocalRegistry(dir), nil
+}
+
+// ParseRef splits a "name@version" reference
+func ParseRef(ref string) (string, string, error) {
+	i := strings.LastIndex(ref, "@")
+	if i <= 0 {
+		return ref, "", nil
+	}
+	name, version := ref[:i], ref[i+1:]
+	if err := ValidateVersion(version); err != nil {
+		return "", "", err
+	}
+	return name, version, nil
+}
+
+// ValidateName checks that a template name is safe to use as a path segment
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("template name is required")
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == "" || part == "." || part == ".." || strings.ContainsAny(part, `\:@`) {
+			return fmt.Errorf("invalid template name: %s", name)
+		}
+	}
+	return nil
+}
+
+// ValidateVersion checks that a template version is safe to use as a path
+// segment: a semantic version, or a single segment without dots
+func ValidateVersion(version string) error {
+	if version == "" {
+		return fmt.Errorf("template version is required")
+	}
+	if strings.ContainsAny(version, `/\:@`) {
+		return fmt.Errorf("invalid template version: %s", version)
+	}
+	if _, err := ParseSemver(version); err != nil && strings.Contains(version, ".") {
+		return fmt.Errorf("invalid template version: %s", version)
+	}
+	return nil
+}
+
+// Matches reports whether the metadata satisfies the query
+func (q SearchQuery) Matches(m Metadata) bool {
+	if q.Stack != "" && !strings.EqualFold(q.Stack, m.Stack) {
+		return false
+	}
+	if q.Keyword == "" {
+		return true
+	}
+	kw := strings.ToLower(q.Keyword)
+	if strings.Contains(strings.ToLower(m.Name), kw) || strings.Contains(strings.ToLower(m.Description), kw) {
+		return true
+	}
+	for _, k := range m.Keywords {
+		if strings.EqualFold(k, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// latest returns the highest semantic version, falling back to publish time
+// for versions that are not semver
+func latest(versions []Metadata) Metadata {
+	sort.Slice(versions, func(i, j int) bool {
+		a, errA := ParseSemver(versions[i].Version)
+		b, errB := ParseSemver(versions[j].Version)
+		if errA == nil && errB == nil {
+			if c := a.Compare(b); c != 0 {
+				return c > 0
+			}
+		}
+		return versions[i].PublishedAt.After(versions[j].PublishedAt)
+	})
+	return versions[0]
+}
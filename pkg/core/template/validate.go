@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Parse decodes a template for inspection. Variables are rendered as "${name}"
+// so they stay visible, or as neutral values when used in numeric fields.
+func Parse(content []byte) (*schema.NexlayerYAML, error) {
+	if rendered, err := RenderSymbolic(content); err == nil {
+		var config schema.NexlayerYAML
+		if yaml.Unmarshal(rendered, &config) == nil {
+			if _, err := schema.ExpandServices(&config); err != nil {
+				return nil, err
+			}
+			return &config, nil
+		}
+	}
+	return parsePlaceholder(content)
+}
+
+// parsePlaceholder renders a template with neutral values and decodes it
+func parsePlaceholder(content []byte) (*schema.NexlayerYAML, error) {
+	rendered, err := RenderPlaceholder(content)
+	if err != nil {
+		return nil, err
+	}
+	var config schema.NexlayerYAML
+	if err := yaml.Unmarshal(rendered, &config); err != nil {
+		return nil, fmt.Errorf("template is not valid YAML: %w", err)
+	}
+	if _, err := schema.ExpandServices(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// CheckCompatibility validates a template against the current schema and
+// returns an error listing every blocking problem
+func CheckCompatibility(content []byte) error {
+	config, err := parsePlaceholder(content)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for _, e := range schema.Validate(config) {
+		if e.Severity == schema.ValidationErrorSeverityError {
+			problems = append(problems, fmt.Sprintf("  - %s: %s", e.Field, e.Message))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("template is not compatible with the current schema:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// ChangelogStub drafts a changelog entry for a new version from a diff
+func ChangelogStub(version string, changes []Change) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s\n", version)
+	if len(changes) == 0 {
+		b.WriteString("- No structural changes\n")
+		return b.String()
+	}
+	for _, c := range changes {
+		switch c.Kind {
+		case ChangeAdded:
+			fmt.Fprintf(&b, "- Added %s\n", c.Path)
+		case ChangeRemoved:
+			fmt.Fprintf(&b, "- Removed %s\n", c.Path)
+		default:
+			fmt.Fprintf(&b, "- Changed %s: %s -> %s\n", c.Path, c.Old, c.New)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package template
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver bump levels
+const (
+	BumpPatch = "patch"
+	BumpMinor = "minor"
+	BumpMajor = "major"
+)
+
+// Semver is a parsed MAJOR.MINOR.PATCH version
+type Semver struct {
+	Major, Minor, Patch int
+	Prerelease          string
+}
+
+// ParseSemver parses versions such as "1.2.3", "v1.2.3" or "1.2.3-beta.1"
+func ParseSemver(v string) (Semver, error) {
+	var s Semver
+	core := strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		if core[i] == '-' {
+			s.Prerelease = strings.SplitN(core[i+1:], "+", 2)[0]
+		}
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return s, fmt.Errorf("invalid semantic version %q, expected MAJOR.MINOR.PATCH", v)
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return s, fmt.Errorf("invalid semantic version %q, expected MAJOR.MINOR.PATCH", v)
+		}
+		nums[i] = n
+	}
+	s.Major, s.Minor, s.Patch = nums[0], nums[1], nums[2]
+	return s, nil
+}
+
+// String formats the version without a "v" prefix
+func (s Semver) String() string {
+	v := fmt.Sprintf("%d.%d.%d", s.Major, s.Minor, s.Patch)
+	if s.Prerelease != "" {
+		v += "-" + s.Prerelease
+	}
+	return v
+}
+
+// Compare returns -1, 0 or 1 when s is lower, equal or higher than o.
+// A prerelease sorts before the release it precedes.
+func (s Semver) Compare(o Semver) int {
+	for _, d := range []int{s.Major - o.Major, s.Minor - o.Minor, s.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	switch {
+	case s.Prerelease == o.Prerelease:
+		return 0
+	case s.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	default:
+		return comparePrerelease(s.Prerelease, o.Prerelease)
+	}
+}
+
+// comparePrerelease orders dot-separated prerelease identifiers. Numeric
+// identifiers compare numerically so "alpha.10" follows "alpha.9".
+func comparePrerelease(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aerr := strconv.Atoi(as[i])
+		bn, berr := strconv.Atoi(bs[i])
+		switch {
+		case aerr == nil && berr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		case aerr == nil:
+			return -1
+		case berr == nil:
+			return 1
+		case as[i] != bs[i]:
+			if as[i] < bs[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(as) < len(bs):
+		return -1
+	case len(as) > len(bs):
+		return 1
+	}
+	return 0
+}
+
+// BumpVersion increments a version at the given level and drops any prerelease.
+// Bumping the patch level of a prerelease releases it ("1.2.3-rc.1" -> "1.2.3").
+func BumpVersion(v, level string) (string, error) {
+	s, err := ParseSemver(v)
+	if err != nil {
+		return "", err
+	}
+	pre := s.Prerelease
+	s.Prerelease = ""
+	switch level {
+	case BumpPatch:
+		if pre == "" {
+			s.Patch++
+		}
+	case BumpMinor:
+		s.Minor++
+		s.Patch = 0
+	case BumpMajor:
+		s.Major++
+		s.Minor, s.Patch = 0, 0
+	default:
+		return "", fmt.Errorf("unknown version bump %q (use patch, minor or major)", level)
+	}
+	return s.String(), nil
+}
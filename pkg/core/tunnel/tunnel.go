@@ -0,0 +1,195 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package tunnel forwards connections from a deployed pod to a local process.
+//
+// The CLI keeps a control connection open to the tunnel endpoint. For every
+// connection the pod receives, the endpoint sends "CONNECT <conn-id>" and the
+// CLI dials back with "DATA <tunnel-id> <conn-id> <token>", then copies bytes
+// between that connection and the local service. All connections use TLS.
+package tunnel
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// dialTimeout bounds each connection attempt
+const dialTimeout = 10 * time.Second
+
+// Event describes forwarded traffic for display
+type Event struct {
+	ConnID string
+	Bytes  int64
+	Err    error
+}
+
+// Forwarder relays a tunnel to a local address
+type Forwarder struct {
+	Endpoint string // host:port of the tunnel endpoint
+	ID       string
+	Token    string
+	Local    string // host:port of the local service
+
+	// TLS holds the TLS settings to reach the endpoint with, such as those of
+	// the API client; nil verifies it against the system roots
+	TLS *tls.Config
+
+	// OnConn is called when a forwarded connection closes, if set
+	OnConn func(Event)
+
+	active int64
+}
+
+// Active returns the number of connections being forwarded
+func (f *Forwarder) Active() int64 {
+	return atomic.LoadInt64(&f.active)
+}
+
+// Run holds the control connection open and forwards connections until ctx is
+// cancelled or the endpoint closes the tunnel
+func (f *Forwarder) Run(ctx context.Context) error {
+	conn, err := f.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reach tunnel endpoint %s: %w", f.Endpoint, err)
+	}
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	if _, err := fmt.Fprintf(conn, "CONTROL %s %s\n", f.ID, f.Token); err != nil {
+		return fmt.Errorf("failed to open control connection: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err == io.EOF {
+				return fmt.Errorf("tunnel closed by the server")
+			}
+			return fmt.Errorf("control connection lost: %w", err)
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "PING":
+			fmt.Fprint(conn, "PONG\n")
+		case "CONNECT":
+			if len(fields) != 2 {
+				continue
+			}
+			wg.Add(1)
+			go func(connID string) {
+				defer wg.Done()
+				f.forward(ctx, connID)
+			}(fields[1])
+		case "ERROR":
+			return fmt.Errorf("tunnel endpoint: %s", strings.TrimSpace(strings.TrimPrefix(line, "ERROR")))
+		}
+	}
+}
+
+// forward connects one remote connection to the local service
+func (f *Forwarder) forward(ctx context.Context, connID string) {
+	atomic.AddInt64(&f.active, 1)
+	defer atomic.AddInt64(&f.active, -1)
+
+	ev := Event{ConnID: connID}
+	defer func() {
+		if f.OnConn != nil {
+			f.OnConn(ev)
+		}
+	}()
+
+	remote, err := f.dial(ctx)
+	if err != nil {
+		ev.Err = err
+		return
+	}
+	defer remote.Close()
+	if _, err := fmt.Fprintf(remote, "DATA %s %s %s\n", f.ID, connID, f.Token); err != nil {
+		ev.Err = err
+		return
+	}
+
+	var d net.Dialer
+	dctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	local, err := d.DialContext(dctx, "tcp", f.Local)
+	cancel()
+	if err != nil {
+		ev.Err = fmt.Errorf("nothing is listening on %s: %w", f.Local, err)
+		return
+	}
+	defer local.Close()
+
+	var n int64
+	done := make(chan struct{}, 2)
+	go func() {
+		c, _ := io.Copy(local, remote)
+		atomic.AddInt64(&n, c)
+		done <- struct{}{}
+	}()
+	go func() {
+		c, _ := io.Copy(remote, local)
+		atomic.AddInt64(&n, c)
+		done <- struct{}{}
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+	ev.Bytes = atomic.LoadInt64(&n)
+}
+
+func (f *Forwarder) dial(ctx context.Context) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(f.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", f.Endpoint, err)
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if f.TLS != nil {
+		config = f.TLS.Clone()
+	}
+	config.ServerName = host
+	d := tls.Dialer{NetDialer: &net.Dialer{Timeout: dialTimeout}, Config: config}
+	return d.DialContext(ctx, "tcp", f.Endpoint)
+}
+
+// LocalAddress accepts a port ("3000") or host:port and returns host:port
+func LocalAddress(local string) (string, error) {
+	if local == "" {
+		return "", fmt.Errorf("a local port is required")
+	}
+	if !strings.Contains(local, ":") {
+		local = "127.0.0.1:" + local
+	}
+	host, port, err := net.SplitHostPort(local)
+	if err != nil {
+		return "", fmt.Errorf("invalid local address %q: %w", local, err)
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port), nil
+}
@@ -16,6 +16,7 @@ const (
 	TypePython    ProjectType = "python"
 	TypeGo        ProjectType = "go"
 	TypeDockerRaw ProjectType = "docker"
+	TypeHeroku    ProjectType = "heroku" // Procfile, optionally with app.json
 
 	// AI/LLM project types
 	TypeLangchainNextjs ProjectType = "langchain-nextjs"
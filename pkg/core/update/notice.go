@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+)
+
+// CheckInterval is how often the passive notice queries the release feed
+const CheckInterval = 24 * time.Hour
+
+// noticeTimeout bounds the passive check so it never slows a command down noticeably
+const noticeTimeout = 2 * time.Second
+
+// checkState is the cached result of the last passive check
+type checkState struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Channel   string    `json:"channel"`
+	Latest    string    `json:"latest"`
+}
+
+// DefaultChannel returns the channel matching the running version: prerelease
+// builds follow beta, everything else follows stable.
+func DefaultChannel(current string) string {
+	if v, err := tmpl.ParseSemver(current); err == nil && v.Prerelease != "" {
+		return ChannelBeta
+	}
+	return ChannelStable
+}
+
+// Notice returns the newer version available on channel, or "" when the CLI is
+// up to date. The release feed is queried at most once per CheckInterval; in
+// between the cached answer is used. Errors are swallowed since the notice is
+// advisory. Set NEXLAYER_NO_UPDATE_NOTIFIER to disable it.
+func (u *Updater) Notice(ctx context.Context, current, channel string) string {
+	if os.Getenv("NEXLAYER_NO_UPDATE_NOTIFIER") != "" {
+		return ""
+	}
+	path, err := statePath()
+	if err != nil {
+		return ""
+	}
+
+	state := loadState(path)
+	if state == nil || state.Channel != channel || time.Since(state.CheckedAt) > CheckInterval {
+		ctx, cancel := context.WithTimeout(ctx, noticeTimeout)
+		defer cancel()
+		state = &checkState{CheckedAt: time.Now(), Channel: channel}
+		if release, err := u.Latest(ctx, channel); err == nil {
+			state.Latest = release.Version
+		}
+		saveState(path, state)
+	}
+
+	if state.Latest != "" && IsNewer(state.Latest, current) {
+		return state.Latest
+	}
+	return ""
+}
+
+// statePath returns the file caching the last passive check
+func statePath() (string, error) {
+	home, err := system.HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "nexlayer", "update-check.json"), nil
+}
+
+func loadState(path string) *checkState {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var state checkState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	return &state
+}
+
+func saveState(path string, state *checkState) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package update finds, verifies and installs new releases of the CLI.
+package update
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/minisign"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/offline"
+	tmpl "github.com/Nexlayer/nexlayer-cli/pkg/core/template"
+)
+
+// Release channels
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+)
+
+// DefaultReleasesURL lists published releases, newest first
+const DefaultReleasesURL = "https://api.github.com/repos/Nexlayer/nexlayer-cli/releases"
+
+// checksumsAsset is the release asset holding "<sha256>  <file>" lines, and
+// signatureAsset its minisign signature
+const (
+	checksumsAsset = "checksums.txt"
+	signatureAsset = checksumsAsset + ".minisig"
+)
+
+// ReleaseKey is the minisign public key releases are signed with. It is set
+// when building releases with
+// -ldflags "-X github.com/Nexlayer/nexlayer-cli/pkg/core/update.ReleaseKey=...";
+// builds without it cannot verify, and so do not install, releases.
+var ReleaseKey string
+
+// Release is a published CLI release
+type Release struct {
+	Version    string  `json:"tag_name"`
+	Prerelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset is a downloadable file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Updater checks for and installs releases
+type Updater struct {
+	ReleasesURL string
+	HTTPClient  *http.Client
+}
+
+// NewUpdater creates an updater for the official release feed. The feed can be
+// overridden with NEXLAYER_RELEASES_URL, e.g. for mirrors.
+func NewUpdater() *Updater {
+	url := os.Getenv("NEXLAYER_RELEASES_URL")
+	if url == "" {
+		url = DefaultReleasesURL
+	}
+	return &Updater{ReleasesURL: url, HTTPClient: &http.Client{Timeout: 60 * time.Second, Transport: &offline.Transport{}}}
+}
+
+// ValidateChannel checks a channel name
+func ValidateChannel(channel string) error {
+	if channel != ChannelStable && channel != ChannelBeta {
+		return fmt.Errorf("unknown channel %q (use stable or beta)", channel)
+	}
+	return nil
+}
+
+// Latest returns the newest release on a channel. The beta channel includes
+// prereleases; stable does not.
+func (u *Updater) Latest(ctx context.Context, channel string) (*Release, error) {
+	if err := ValidateChannel(channel); err != nil {
+		return nil, err
+	}
+	body, err := u.get(ctx, u.ReleasesURL)
+	if err != nil {
+		return nil, err
+	}
+	var releases []Release
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to decode releases: %w", err)
+	}
+
+	var best *Release
+	var bestVersion tmpl.Semver
+	for i := range releases {
+		r := &releases[i]
+		if r.Draft || (r.Prerelease && channel != ChannelBeta) {
+			continue
+		}
+		v, err := tmpl.ParseSemver(r.Version)
+		if err != nil {
+			continue
+		}
+		if best == nil || v.Compare(bestVersion) > 0 {
+			best, bestVersion = r, v
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no %s release found", channel)
+	}
+	return best, nil
+}
+
+// IsNewer reports whether release is newer than the running version. Versions
+// that cannot be parsed, such as development builds, are never upgraded
+// implicitly.
+func IsNewer(release, current string) bool {
+	r, err := tmpl.ParseSemver(release)
+	if err != nil {
+		return false
+	}
+	c, err := tmpl.ParseSemver(current)
+	if err != nil {
+		return false
+	}
+	return r.Compare(c) > 0
+}
+
+// AssetName returns the binary asset name for a platform
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("nexlayer_%s_%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Download fetches the binary for the current platform and verifies it against
+// the release checksums, whose signature is checked with ReleaseKey. It
+// returns the verified binary.
+func (u *Updater) Download(ctx context.Context, release *Release) ([]byte, error) {
+	if ReleaseKey == "" {
+		return nil, fmt.Errorf("this build has no release signing key, so it cannot verify releases; download %s from the release page instead", release.Version)
+	}
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	binary, sums, sig := findAsset(release, name), findAsset(release, checksumsAsset), findAsset(release, signatureAsset)
+	if binary == nil {
+		return nil, fmt.Errorf("release %s has no binary for %s/%s", release.Version, runtime.GOOS, runtime.GOARCH)
+	}
+	if sums == nil || sig == nil {
+		return nil, fmt.Errorf("release %s has no signed %s; refusing to install an unverified binary", release.Version, checksumsAsset)
+	}
+
+	checksums, err := u.get(ctx, sums.URL)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := u.get(ctx, sig.URL)
+	if err != nil {
+		return nil, err
+	}
+	if err := minisign.Verify(ReleaseKey, checksums, signature); err != nil {
+		return nil, fmt.Errorf("%s of release %s is not signed by the release key: %w", checksumsAsset, release.Version, err)
+	}
+	want, err := lookupChecksum(checksums, name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := u.get(ctx, binary.URL)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, want, got)
+	}
+	return data, nil
+}
+
+// Install replaces the binary at path with data. The new binary is written
+// next to the old one and renamed over it so a failure never leaves a
+// half-written executable behind.
+func Install(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".nexlayer-update-*")
+	if err != nil {
+		return fmt.Errorf("cannot write to %s (try running with elevated permissions): %w", filepath.Dir(path), err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0111); err != nil {
+		return fmt.Errorf("failed to make new binary executable: %w", err)
+	}
+
+	// Windows cannot replace a running executable, but it can rename it.
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			return fmt.Errorf("failed to move the current binary aside: %w", err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		if runtime.GOOS == "windows" {
+			// Put the current binary back rather than leave no CLI at path
+			if restoreErr := os.Rename(path+".old", path); restoreErr != nil {
+				return fmt.Errorf("failed to replace %s: %w; the previous binary is at %s.old", path, err, path)
+			}
+		}
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
+
+// RemoveReplaced removes the binary a Windows upgrade moved aside to
+// path.old, which could not be removed while it was still running
+func RemoveReplaced() {
+	if runtime.GOOS != "windows" {
+		return
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	os.Remove(exe + ".old")
+}
+
+// findAsset returns the named asset of a release, or nil
+func findAsset(release *Release, name string) *Asset {
+	for i := range release.Assets {
+		if release.Assets[i].Name == name {
+			return &release.Assets[i]
+		}
+	}
+	return nil
+}
+
+// lookupChecksum finds the checksum for name in a sha256sum-style file
+func lookupChecksum(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("%s does not list %s", checksumsAsset, name)
+}
+
+// get downloads a URL
+func (u *Updater) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := u.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach release server: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("release server returned status %d for %s", resp.StatusCode, url)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,1907 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package validate checks a nexlayer.yaml against the rules of the platform
+// and reports every problem found with the field at fault and suggestions.
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/policy"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/static"
+	"github.com/Nexlayer/nexlayer-cli/pkg/system"
+)
+
+// ValidationError represents a single validation error with field path and suggestions
+type ValidationError struct {
+	Field       string   `json:"field"`
+	Message     string   `json:"message"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// Error implements error
+func (e ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// Validator holds the configuration and collects validation errors
+type Validator struct {
+	config  *schema.NexlayerYAML
+	baseDir string // directory envFrom files and static sites are relative to
+	errors  []ValidationError
+}
+
+// NewValidator creates a new Validator instance
+func NewValidator(config *schema.NexlayerYAML) *Validator {
+	return &Validator{config: config}
+}
+
+// WithBaseDir sets the directory of the deployment file, which envFrom files
+// and static site directories are relative to
+func (v *Validator) WithBaseDir(dir string) *Validator {
+	v.baseDir = dir
+	return v
+}
+
+// Errors returns the problems found by the last call to Validate
+func (v *Validator) Errors() []ValidationError {
+	return v.errors
+}
+
+// Validate performs the full validation of the NexlayerYAML configuration
+func (v *Validator) Validate() error {
+	v.errors = nil
+	if v.config == nil {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "",
+			Message: "deployment configuration is required",
+		})
+		return v.formatErrors()
+	}
+
+	v.validateApplication()
+	v.validateRegistryLogin()
+	v.validatePods()
+	v.validateMigrations()
+	v.validateRegions()
+	v.validateDeployPolicy()
+	v.validateChecks()
+	v.validateEnvironments()
+	v.validateHooks()
+
+	if len(v.errors) > 0 {
+		return v.formatErrors()
+	}
+	return nil
+}
+
+// validateApplication checks the application-level fields
+func (v *Validator) validateApplication() {
+	if v.config.Application.Name == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "application.name",
+			Message: "application name is required",
+			Suggestions: []string{
+				"Add 'name' field under 'application' in nexlayer.yaml",
+				"Run 'nexlayer init' to generate a valid configuration",
+			},
+		})
+	} else if !isValidName(v.config.Application.Name) {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "application.name",
+			Message: "application name must follow Nexlayer platform naming conventions",
+			Suggestions: []string{
+				"Use lowercase letters, numbers, and hyphens",
+				"Must start with a letter",
+				"Example: my-app-v1, web-service, api-backend",
+			},
+		})
+	}
+
+	// Validate URL if provided
+	if v.config.Application.URL != "" && !isValidURL(v.config.Application.URL) {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "application.url",
+			Message: "invalid URL format",
+			Suggestions: []string{
+				"Use a valid domain name (e.g., example.com)",
+				"Only alphanumeric characters, dots, and hyphens are allowed",
+			},
+		})
+	}
+
+	v.validateMetadata("application", v.config.Application.Labels, v.config.Application.Annotations)
+}
+
+// validateRegistryLogin ensures registry login is correctly configured if present
+func (v *Validator) validateRegistryLogin() {
+	rl := v.config.Application.RegistryLogin
+	if rl != nil {
+		if rl.Registry == "" {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "application.registryLogin.registry",
+				Message: "registry hostname is required when registryLogin is present",
+				Suggestions: []string{
+					"Add 'registry' field with the hostname",
+					"Example: docker.io, ghcr.io",
+				},
+			})
+		} else if !isValidRegistryHost(rl.Registry) {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "application.registryLogin.registry",
+				Message: "invalid registry hostname format",
+				Suggestions: []string{
+					"Use a valid hostname (e.g., docker.io, ghcr.io)",
+					"Only alphanumeric characters, dots, and hyphens are allowed",
+				},
+			})
+		}
+
+		if rl.Username == "" {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "application.registryLogin.username",
+				Message: "registry username is required when registryLogin is present",
+			})
+		}
+
+		if rl.PersonalAccessToken == "" {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "application.registryLogin.personalAccessToken",
+				Message: "registry personal access token is required when registryLogin is present",
+			})
+		}
+	}
+}
+
+// validatePods checks all pod configurations
+func (v *Validator) validatePods() {
+	if len(v.config.Application.Pods) == 0 {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "application.pods",
+			Message: "at least one pod is required",
+			Suggestions: []string{
+				"Add at least one pod configuration",
+				"Run 'nexlayer init' to generate a valid configuration",
+			},
+		})
+		return
+	}
+
+	// Check for duplicate pod names
+	podNames := make(map[string]bool)
+	for i, pod := range v.config.Application.Pods {
+		if podNames[pod.Name] {
+			v.errors = append(v.errors, ValidationError{
+				Field:   fmt.Sprintf("pods[%d].name", i),
+				Message: fmt.Sprintf("duplicate pod name: %s", pod.Name),
+				Suggestions: []string{
+					"Each pod must have a unique name",
+					fmt.Sprintf("Rename one of the pods with name '%s'", pod.Name),
+				},
+			})
+		}
+		podNames[pod.Name] = true
+
+		// Validate pod directly
+		v.validatePod(pod)
+	}
+
+	v.validateHostNames()
+	v.validateDependencies(podNames)
+}
+
+// validateDependencies checks that pods depend only on other existing pods
+func (v *Validator) validateDependencies(podNames map[string]bool) {
+	for _, pod := range v.config.Application.Pods {
+		for _, dep := range pod.Dependencies() {
+			switch {
+			case dep == pod.Name:
+				v.errors = append(v.errors, ValidationError{
+					Field:   "pod.annotations." + schema.DependsOnAnnotation,
+					Message: fmt.Sprintf("pod %s depends on itself", pod.Name),
+				})
+			case !podNames[dep]:
+				suggestions := []string{"Available pods: " + strings.Join(getAvailablePods(podNames), ", ")}
+				if closest := findClosestPodName(dep, podNames); closest != "" {
+					suggestions = append([]string{fmt.Sprintf("Did you mean '%s'?", closest)}, suggestions...)
+				}
+				v.errors = append(v.errors, ValidationError{
+					Field:       "pod.annotations." + schema.DependsOnAnnotation,
+					Message:     fmt.Sprintf("pod %s depends on unknown pod %s", pod.Name, dep),
+					Suggestions: suggestions,
+				})
+			}
+		}
+	}
+}
+
+// validateMigrations checks the migrations block: a command, a known run
+// policy and an image, given directly or taken from an existing pod
+func (v *Validator) validateMigrations() {
+	m := v.config.Application.Migrations
+	if m == nil {
+		return
+	}
+
+	if len(m.Command) == 0 {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "application.migrations.command",
+			Message: "migrations command is required",
+			Suggestions: []string{
+				"Example: command: [npx, prisma, migrate, deploy]",
+			},
+		})
+	}
+
+	policy := m.Policy()
+	valid := false
+	for _, p := range schema.MigrationPolicies {
+		valid = valid || policy == p
+	}
+	if !valid {
+		v.errors = append(v.errors, ValidationError{
+			Field:       "application.migrations.runPolicy",
+			Message:     fmt.Sprintf("unsupported run policy: %s", m.RunPolicy),
+			Suggestions: []string{"Supported policies: " + strings.Join(schema.MigrationPolicies, ", ")},
+		})
+	}
+
+	if m.Pod != "" {
+		var pod *schema.Pod
+		podNames := make(map[string]bool)
+		for i := range v.config.Application.Pods {
+			p := &v.config.Application.Pods[i]
+			podNames[p.Name] = true
+			if p.Name == m.Pod {
+				pod = p
+			}
+		}
+		switch {
+		case pod == nil:
+			verr := ValidationError{
+				Field:   "application.migrations.pod",
+				Message: fmt.Sprintf("migrations reference unknown pod %s", m.Pod),
+			}
+			if closest := findClosestPodName(m.Pod, podNames); closest != "" {
+				verr.Suggestions = []string{fmt.Sprintf("Did you mean %s?", closest)}
+			}
+			v.errors = append(v.errors, verr)
+		case pod.Static != nil && m.Image == "":
+			v.errors = append(v.errors, ValidationError{
+				Field:   "application.migrations.pod",
+				Message: fmt.Sprintf("migrations cannot run in static pod %s", m.Pod),
+				Suggestions: []string{
+					"Name the pod of your backend, or set migrations.image",
+				},
+			})
+		}
+	} else if m.Image == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "application.migrations",
+			Message: "migrations need an image or a pod to take the image and vars from",
+			Suggestions: []string{
+				"Example: pod: api, to run with the image and vars of the api pod",
+			},
+		})
+	}
+}
+
+// validateRegions checks the regions block: valid, distinct region names and
+// overrides that name a declared region and existing pods
+func (v *Validator) validateRegions() {
+	r := v.config.Application.Regions
+	if r == nil {
+		return
+	}
+
+	if r.Primary == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "application.regions.primary",
+			Message: "primary region is required",
+			Suggestions: []string{
+				"Example: primary: us-east",
+			},
+		})
+	}
+	regions := make(map[string]bool)
+	for i, name := range r.Names() {
+		field := "application.regions.primary"
+		if i > 0 {
+			field = fmt.Sprintf("application.regions.replicas[%d]", i-1)
+		}
+		switch {
+		case name == "" && i == 0:
+			// reported above
+		case !isValidName(name):
+			v.errors = append(v.errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("invalid region name: %s", name),
+				Suggestions: []string{
+					"Use lowercase letters, numbers, and hyphens, e.g. eu-west",
+				},
+			})
+		case regions[name]:
+			v.errors = append(v.errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("region %s is listed more than once", name),
+				Suggestions: []string{
+					"List each region once; the primary region is not repeated in replicas",
+				},
+			})
+		}
+		regions[name] = true
+	}
+
+	podNames := make(map[string]bool)
+	for _, pod := range v.config.Application.Pods {
+		podNames[pod.Name] = true
+	}
+	names := make([]string, 0, len(r.Overrides))
+	for name := range r.Overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, region := range names {
+		field := "application.regions.overrides." + region
+		if !regions[region] {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("overrides for undeclared region %s", region),
+				Suggestions: []string{
+					"Add the region to regions.replicas, or remove its overrides",
+				},
+			})
+		}
+		override := r.Overrides[region]
+		if override.URL != "" && !isValidURL(override.URL) {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".url",
+				Message: "invalid URL format",
+			})
+		}
+		pods := make([]string, 0, len(override.Pods))
+		for name := range override.Pods {
+			pods = append(pods, name)
+		}
+		sort.Strings(pods)
+		for _, name := range pods {
+			if podNames[name] {
+				continue
+			}
+			verr := ValidationError{
+				Field:   field + ".pods." + name,
+				Message: fmt.Sprintf("override for unknown pod %s", name),
+			}
+			if closest := findClosestPodName(name, podNames); closest != "" {
+				verr.Suggestions = []string{fmt.Sprintf("Did you mean %s?", closest)}
+			}
+			v.errors = append(v.errors, verr)
+		}
+	}
+}
+
+// validateChecks checks that synthetic checks have a path, a valid status,
+// latency budget and body pattern, and distinct names
+func (v *Validator) validateChecks() {
+	names := make(map[string]bool)
+	for i, c := range v.config.Application.Checks {
+		field := fmt.Sprintf("application.checks[%d]", i)
+		if !strings.HasPrefix(c.Path, "/") {
+			v.errors = append(v.errors, ValidationError{
+				Field:       field + ".path",
+				Message:     fmt.Sprintf("check path must start with /: %q", c.Path),
+				Suggestions: []string{"Example: path: /api/health"},
+			})
+		}
+		if c.Status != 0 && (c.Status < 100 || c.Status > 599) {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".status",
+				Message: fmt.Sprintf("invalid expected status: %d", c.Status),
+			})
+		}
+		if _, err := c.LatencyBudget(); err != nil {
+			v.errors = append(v.errors, ValidationError{Field: field + ".latency", Message: err.Error()})
+		}
+		if c.BodyRegex != "" {
+			if _, err := regexp.Compile(c.BodyRegex); err != nil {
+				v.errors = append(v.errors, ValidationError{
+					Field:   field + ".bodyRegex",
+					Message: fmt.Sprintf("invalid body pattern: %v", err),
+				})
+			}
+		}
+		if label := c.Label(); names[label] {
+			v.errors = append(v.errors, ValidationError{
+				Field:       field + ".name",
+				Message:     fmt.Sprintf("duplicate check %s", label),
+				Suggestions: []string{"Give each check a distinct name"},
+			})
+		} else {
+			names[label] = true
+		}
+	}
+}
+
+// validateHooks checks that each hook step runs either a command or a plugin
+func (v *Validator) validateHooks() {
+	hooks := v.config.Application.Hooks
+	for _, event := range schema.HookEvents {
+		for i, step := range hooks.Steps(event) {
+			field := fmt.Sprintf("application.hooks.%s[%d]", hookKey(event), i)
+			if (step.Run == "") == (step.Plugin == "") {
+				v.errors = append(v.errors, ValidationError{
+					Field:       field,
+					Message:     "hook step must set either run or plugin",
+					Suggestions: []string{"Example: run: npm run db:migrate", "Example: plugin: slack"},
+				})
+			}
+			if d, err := step.TimeoutDuration(); err != nil || d <= 0 {
+				v.errors = append(v.errors, ValidationError{
+					Field:       field + ".timeout",
+					Message:     fmt.Sprintf("invalid timeout: %q", step.Timeout),
+					Suggestions: []string{"Use a duration such as 30s or 5m"},
+				})
+			}
+		}
+	}
+}
+
+// hookKey returns the key of an event in the hooks block, e.g. preDeploy
+func hookKey(event string) string {
+	parts := strings.Split(event, "-")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// validateEnvironments checks that each environment names its namespace and
+// that no two environments share one
+func (v *Validator) validateEnvironments() {
+	names := make([]string, 0, len(v.config.Application.Environments))
+	for name := range v.config.Application.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]string)
+	for _, name := range names {
+		env := v.config.Application.Environments[name]
+		field := "application.environments." + name
+		switch {
+		case env.Namespace == "":
+			v.errors = append(v.errors, ValidationError{
+				Field:       field + ".namespace",
+				Message:     fmt.Sprintf("environment %s needs the namespace of its deployment", name),
+				Suggestions: []string{"Find it with 'nexlayer list'"},
+			})
+		case seen[env.Namespace] != "":
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".namespace",
+				Message: fmt.Sprintf("environments %s and %s share namespace %s", seen[env.Namespace], name, env.Namespace),
+			})
+		default:
+			seen[env.Namespace] = name
+		}
+		if env.Name != "" && !isValidName(env.Name) {
+			v.errors = append(v.errors, ValidationError{
+				Field:       field + ".name",
+				Message:     fmt.Sprintf("invalid application name: %s", env.Name),
+				Suggestions: []string{"Use lowercase letters, numbers, and hyphens"},
+			})
+		}
+	}
+}
+
+// validateDeployPolicy checks the timezone, windows and freezes of the deploy
+// policy
+func (v *Validator) validateDeployPolicy() {
+	p := v.config.Application.DeployPolicy
+	if p == nil {
+		return
+	}
+
+	if _, err := policy.Location(p.Timezone); err != nil {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "application.deployPolicy.timezone",
+			Message: err.Error(),
+			Suggestions: []string{
+				"Use an IANA timezone name, e.g. Europe/Berlin or America/New_York",
+			},
+		})
+	}
+	for i, w := range p.Windows {
+		if err := policy.ValidateWindow(w); err != nil {
+			v.errors = append(v.errors, ValidationError{
+				Field:   fmt.Sprintf("application.deployPolicy.windows[%d]", i),
+				Message: err.Error(),
+				Suggestions: []string{
+					"Example: {days: [mon, tue, wed, thu, fri], from: \"08:00\", to: \"18:00\"}",
+				},
+			})
+		}
+	}
+	for i, f := range p.Freezes {
+		if err := policy.ValidateFreeze(f); err != nil {
+			v.errors = append(v.errors, ValidationError{
+				Field:   fmt.Sprintf("application.deployPolicy.freezes[%d]", i),
+				Message: err.Error(),
+				Suggestions: []string{
+					"Weekly: {from: fri 18:00, to: mon 08:00}",
+					"Once: {from: 2025-12-20, to: 2026-01-04}",
+				},
+			})
+		}
+	}
+}
+
+// validateHostNames checks pod aliases and that every <name>.pod reference in
+// vars names a pod or an alias
+func (v *Validator) validateHostNames() {
+	hosts := make(map[string]string) // pod name or alias -> pod
+	for _, pod := range v.config.Application.Pods {
+		hosts[pod.Name] = pod.Name
+	}
+	for i, pod := range v.config.Application.Pods {
+		for _, alias := range pod.Aliases {
+			field := fmt.Sprintf("pods[%d].aliases", i)
+			if !isValidPodName(alias) {
+				v.errors = append(v.errors, ValidationError{
+					Field:   field,
+					Message: fmt.Sprintf("invalid alias: %s", alias),
+					Suggestions: []string{
+						"Aliases follow the pod naming rules: lowercase letters, numbers and hyphens, starting with a letter",
+					},
+				})
+			} else if owner, ok := hosts[alias]; ok {
+				v.errors = append(v.errors, ValidationError{
+					Field:   field,
+					Message: fmt.Sprintf("alias %s of pod %s is already used by pod %s", alias, pod.Name, owner),
+					Suggestions: []string{
+						"Pod names and aliases share one namespace and must be unique",
+					},
+				})
+			} else {
+				hosts[alias] = pod.Name
+			}
+		}
+	}
+
+	names := make(map[string]bool, len(hosts))
+	for name := range hosts {
+		names[name] = true
+	}
+	for i, pod := range v.config.Application.Pods {
+		for j, env := range pod.Vars {
+			for _, ref := range extractPodReferences(env.Value) {
+				if names[ref] {
+					continue
+				}
+				verr := ValidationError{
+					Field:   fmt.Sprintf("pods[%d].vars[%d].value", i, j),
+					Message: fmt.Sprintf("%s references unknown pod %s.pod", env.Key, ref),
+				}
+				if closest := findClosestPodName(ref, names); closest != "" {
+					verr.Suggestions = []string{fmt.Sprintf("Did you mean %s.pod?", closest)}
+				} else {
+					verr.Suggestions = []string{"Add the name to the aliases of the pod it should resolve to"}
+				}
+				v.errors = append(v.errors, verr)
+			}
+		}
+	}
+}
+
+// validatePod validates a pod configuration
+func (v *Validator) validatePod(pod schema.Pod) {
+	// Validate pod name
+	if pod.Name == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.name",
+			Message: "pod name is required",
+		})
+	} else if !isValidPodName(pod.Name) {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.name",
+			Message: fmt.Sprintf("invalid pod name: %s", pod.Name),
+			Suggestions: []string{
+				"Pod names must start with a lowercase letter",
+				"Use only lowercase letters, numbers, and hyphens",
+			},
+		})
+	}
+
+	// Validate image
+	if pod.IsStatic() {
+		v.validateStatic(pod)
+	} else if pod.Image == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.image",
+			Message: "pod image is required",
+		})
+	} else if strings.Contains(pod.Image, "<% REGISTRY %>") {
+		if !strings.HasPrefix(pod.Image, "<% REGISTRY %>/") {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "pod.image",
+				Message: "private images must start with '<% REGISTRY %>/'",
+				Suggestions: []string{
+					"Example: <% REGISTRY %>/myapp/backend:v1.0.0",
+				},
+			})
+		}
+	}
+
+	// Validate service ports; jobs need none as they do not serve
+	if len(pod.ServicePorts) == 0 && !pod.IsJob() {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.servicePorts",
+			Message: "at least one service port is required",
+		})
+	} else {
+		portNames := make(map[string]bool)
+		// target port of each service port, by protocol
+		portTargets := make(map[int]map[string]int)
+
+		for i, port := range pod.ServicePorts {
+			if port.Name == "" {
+				v.errors = append(v.errors, ValidationError{
+					Field:   fmt.Sprintf("pod.servicePorts[%d].name", i),
+					Message: "port name is required",
+					Suggestions: []string{
+						"Use descriptive names like 'http', 'api', or 'metrics'",
+					},
+				})
+			} else if !isValidName(port.Name) {
+				v.errors = append(v.errors, ValidationError{
+					Field:   fmt.Sprintf("pod.servicePorts[%d].name", i),
+					Message: "port name must be lowercase alphanumeric with hyphens",
+				})
+			} else if portNames[port.Name] {
+				v.errors = append(v.errors, ValidationError{
+					Field:   fmt.Sprintf("pod.servicePorts[%d].name", i),
+					Message: fmt.Sprintf("duplicate port name: %s", port.Name),
+				})
+			}
+
+			protocol := schema.NormalizeProtocol(port.Protocol)
+			if !schema.IsValidProtocol(protocol) {
+				v.errors = append(v.errors, ValidationError{
+					Field:   fmt.Sprintf("pod.servicePorts[%d].protocol", i),
+					Message: fmt.Sprintf("unsupported protocol: %s", port.Protocol),
+					Suggestions: []string{
+						"Supported protocols: " + strings.Join(schema.Protocols, ", ") + " (default TCP)",
+					},
+				})
+			}
+
+			if port.Port < 1 || port.Port > 65535 {
+				v.errors = append(v.errors, ValidationError{
+					Field:   fmt.Sprintf("pod.servicePorts[%d].port", i),
+					Message: "port must be between 1 and 65535",
+				})
+			} else if targets := portTargets[port.Port]; targets != nil {
+				if _, ok := targets[protocol]; ok {
+					v.errors = append(v.errors, ValidationError{
+						Field:   fmt.Sprintf("pod.servicePorts[%d].port", i),
+						Message: fmt.Sprintf("duplicate port number: %d/%s", port.Port, protocol),
+					})
+				} else {
+					for other, target := range targets {
+						if target != port.TargetPort {
+							v.errors = append(v.errors, ValidationError{
+								Field:   fmt.Sprintf("pod.servicePorts[%d].targetPort", i),
+								Message: fmt.Sprintf("port %d forwards %s to %d but %s to %d", port.Port, other, target, protocol, port.TargetPort),
+								Suggestions: []string{
+									"A port exposed over both TCP and UDP must use the same targetPort",
+								},
+							})
+						}
+					}
+				}
+			}
+
+			portNames[port.Name] = true
+			if portTargets[port.Port] == nil {
+				portTargets[port.Port] = make(map[string]int)
+			}
+			portTargets[port.Port][protocol] = port.TargetPort
+		}
+	}
+
+	v.validateMetadata("pod", pod.Labels, pod.Annotations)
+
+	// Validate volumes
+	if len(pod.Volumes) > 0 {
+		volumeNames := make(map[string]bool)
+		for i, volume := range pod.Volumes {
+			v.validateVolume(i, volume, volumeNames)
+		}
+	}
+
+	if pod.Canary != nil {
+		v.validateCanary(pod)
+	}
+
+	// Validate CPU and memory
+	if r := pod.Resources; r != nil {
+		if r.CPU != "" {
+			if _, err := schema.ParseCPU(r.CPU); err != nil {
+				v.errors = append(v.errors, ValidationError{
+					Field:   "pod.resources.cpu",
+					Message: fmt.Sprintf("invalid cpu: %s", r.CPU),
+					Suggestions: []string{
+						"Use cores or millicores, e.g. 2, 0.5 or 500m",
+					},
+				})
+			}
+		}
+		if r.Memory != "" {
+			if _, err := schema.ParseMemory(r.Memory); err != nil {
+				v.errors = append(v.errors, ValidationError{
+					Field:   "pod.resources.memory",
+					Message: fmt.Sprintf("invalid memory: %s", r.Memory),
+					Suggestions: []string{
+						"Use Ki, Mi or Gi, e.g. 512Mi or 2Gi",
+					},
+				})
+			}
+		}
+	}
+
+	// Validate GPU requests
+	if pod.Resources != nil && pod.Resources.GPU != nil {
+		gpu := pod.Resources.GPU
+		if gpu.Count < 1 {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "pod.resources.gpu.count",
+				Message: fmt.Sprintf("gpu count must be at least 1, got %d", gpu.Count),
+				Suggestions: []string{
+					"Remove resources.gpu if the pod does not need a GPU",
+				},
+			})
+		}
+		if gpu.Type != "" && !isValidGPUType(gpu.Type) {
+			v.errors = append(v.errors, ValidationError{
+				Field:       "pod.resources.gpu.type",
+				Message:     fmt.Sprintf("unsupported gpu type: %s", gpu.Type),
+				Suggestions: []string{"Supported types: " + strings.Join(schema.GPUTypes, ", ")},
+			})
+		}
+	}
+
+	// Validate config files
+	if len(pod.ConfigFiles) > 0 {
+		configNames := make(map[string]bool)
+		for i, cf := range pod.ConfigFiles {
+			v.validateConfigFile(i, cf, configNames)
+		}
+	}
+
+	// Validate environment variables
+	if len(pod.Vars) > 0 {
+		envVarNames := make(map[string]bool)
+		for i, env := range pod.Vars {
+			if env.Key == "" {
+				v.errors = append(v.errors, ValidationError{
+					Field:   fmt.Sprintf("pod.vars[%d].key", i),
+					Message: "environment variable key is required",
+				})
+			} else if envVarNames[env.Key] {
+				v.errors = append(v.errors, ValidationError{
+					Field:   fmt.Sprintf("pod.vars[%d].key", i),
+					Message: fmt.Sprintf("duplicate environment variable: %s", env.Key),
+				})
+			}
+			envVarNames[env.Key] = true
+		}
+	}
+
+	// Validate environment imports
+	if len(pod.EnvFrom) > 0 {
+		v.validateEnvFrom(pod)
+	}
+
+	if pod.Seed != nil {
+		v.validateSeed(pod)
+	}
+
+	if pod.Probe != nil {
+		v.validateProbe(pod)
+	}
+
+	if pod.Probes != nil {
+		v.validateProbes(pod)
+	}
+
+	v.validateScaling(pod)
+	v.validateJob(pod)
+	v.validateContainers(pod)
+
+	if pod.Build != nil {
+		v.validateBuild(pod)
+	}
+}
+
+// validateBuild checks that a pod built from source has an image to push to
+// and a build context with a Dockerfile
+func (v *Validator) validateBuild(pod schema.Pod) {
+	b := pod.Build
+	if pod.IsStatic() {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.build",
+			Message: fmt.Sprintf("static pod %s cannot set build", pod.Name),
+			Suggestions: []string{
+				"Use static.build to build the site instead",
+			},
+		})
+		return
+	}
+	if pod.Image == "" || strings.Contains(pod.Image, "@") {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.build",
+			Message: fmt.Sprintf("pod %s is built from source but has no image tag to push to", pod.Name),
+			Suggestions: []string{
+				"Example: image: <% REGISTRY %>/api:dev",
+			},
+		})
+	}
+	if b.Context == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.build.context",
+			Message: fmt.Sprintf("build context of pod %s is required", pod.Name),
+		})
+		return
+	}
+	if info, err := os.Stat(b.Dir(v.baseDir)); err != nil || !info.IsDir() {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.build.context",
+			Message: fmt.Sprintf("build context %s of pod %s is not a directory", b.Context, pod.Name),
+			Suggestions: []string{
+				"Build contexts are relative to the directory of nexlayer.yaml",
+			},
+		})
+		return
+	}
+	if _, err := os.Stat(b.DockerfilePath(v.baseDir)); err != nil {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.build.dockerfile",
+			Message: fmt.Sprintf("%s of pod %s not found", b.DockerfilePath(v.baseDir), pod.Name),
+		})
+	}
+}
+
+// validateSeed checks that a seed has a known run policy and either a command
+// or files that exist and that the pod's database can load
+func (v *Validator) validateSeed(pod schema.Pod) {
+	s := pod.Seed
+	policy := s.Policy()
+	valid := false
+	for _, p := range schema.SeedPolicies {
+		valid = valid || policy == p
+	}
+	if !valid {
+		v.errors = append(v.errors, ValidationError{
+			Field:       "pod.seed.runPolicy",
+			Message:     fmt.Sprintf("unsupported seed run policy of pod %s: %s", pod.Name, s.RunPolicy),
+			Suggestions: []string{"Supported policies: " + strings.Join(schema.SeedPolicies, ", ")},
+		})
+	}
+
+	if pod.IsStatic() && s.Image == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.seed",
+			Message: fmt.Sprintf("static pod %s cannot be seeded", pod.Name),
+			Suggestions: []string{
+				"Declare the seed on the database pod, or set seed.image",
+			},
+		})
+		return
+	}
+
+	if _, err := schema.SeedCommand(pod); err != nil {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.seed",
+			Message: err.Error(),
+			Suggestions: []string{
+				"Example: files: [db/schema.sql, db/fixtures.sql]",
+				"Example: command: [npm, run, seed]",
+			},
+		})
+	}
+
+	for i, file := range s.Files {
+		if _, err := os.Stat(filepath.Join(v.baseDir, file)); err != nil {
+			v.errors = append(v.errors, ValidationError{
+				Field:   fmt.Sprintf("pod.seed.files[%d]", i),
+				Message: fmt.Sprintf("seed file %s of pod %s not found", file, pod.Name),
+				Suggestions: []string{
+					"Seed files are relative to the directory of nexlayer.yaml",
+				},
+			})
+		}
+	}
+}
+
+// validateProbe checks that a probe has a command, durations and a number of
+// retries that is not negative
+func (v *Validator) validateProbe(pod schema.Pod) {
+	p := pod.Probe
+	if len(p.Command) == 0 {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.probe.command",
+			Message: fmt.Sprintf("probe of pod %s needs a command", pod.Name),
+			Suggestions: []string{
+				"Example: command: [pg_isready, -U, postgres]",
+				"Example: command: curl -f http://localhost:3000/health",
+			},
+		})
+	}
+	for _, d := range []struct{ name, value string }{
+		{"interval", p.Interval},
+		{"timeout", p.Timeout},
+		{"startPeriod", p.StartPeriod},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if duration, err := time.ParseDuration(d.value); err != nil || duration < 0 {
+			v.errors = append(v.errors, ValidationError{
+				Field:       "pod.probe." + d.name,
+				Message:     fmt.Sprintf("invalid probe %s of pod %s: %s", d.name, pod.Name, d.value),
+				Suggestions: []string{"Write durations as 10s, 500ms or 1m30s"},
+			})
+		}
+	}
+	if p.Retries < 0 {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.probe.retries",
+			Message: fmt.Sprintf("probe retries must not be negative, got %d", p.Retries),
+		})
+	}
+}
+
+// validateJob checks the schedule of a cron job, and that jobs set nothing
+// that only applies to pods serving traffic
+func (v *Validator) validateJob(pod schema.Pod) {
+	switch {
+	case pod.IsCronJob() && pod.Schedule == "":
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.schedule",
+			Message: fmt.Sprintf("cron job %s needs a schedule", pod.Name),
+			Suggestions: []string{
+				"Use @hourly, @daily, @weekly, @monthly or a cron expression such as '0 3 * * *'",
+			},
+		})
+	case pod.IsCronJob() && !isValidSchedule(pod.Schedule):
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.schedule",
+			Message: fmt.Sprintf("invalid schedule of cron job %s: %q", pod.Name, pod.Schedule),
+			Suggestions: []string{
+				"Use @hourly, @daily, @weekly, @monthly or a cron expression such as '0 3 * * *'",
+			},
+		})
+	case !pod.IsCronJob() && pod.Schedule != "":
+		v.errors = append(v.errors, ValidationError{
+			Field:       "pod.schedule",
+			Message:     fmt.Sprintf("pod %s has a schedule but is not a cron job", pod.Name),
+			Suggestions: []string{"Set type: cronjob to run the pod on its schedule"},
+		})
+	}
+	if !pod.IsJob() {
+		return
+	}
+
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"path", pod.Path != ""},
+		{"replicas", pod.Replicas > 0},
+		{"autoscale", pod.Autoscale != nil},
+		{"canary", pod.Canary != nil},
+	} {
+		if f.set {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "pod." + f.name,
+				Message: fmt.Sprintf("%s %s cannot set %s", pod.Type, pod.Name, f.name),
+				Suggestions: []string{
+					"Jobs run to completion and serve no traffic; remove " + f.name,
+				},
+			})
+		}
+	}
+}
+
+// validateContainers checks the init containers and sidecars of a pod like
+// the pod itself: each needs a unique name and an image, and unique vars
+func (v *Validator) validateContainers(pod schema.Pod) {
+	names := map[string]bool{pod.Name: true}
+	check := func(kind string, i int, c schema.Container) {
+		field := fmt.Sprintf("pod.%s[%d]", kind, i)
+		switch {
+		case c.Name == "":
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".name",
+				Message: "container name is required",
+			})
+		case !isValidPodName(c.Name):
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".name",
+				Message: fmt.Sprintf("invalid container name: %s", c.Name),
+				Suggestions: []string{
+					"Container names must start with a lowercase letter",
+					"Use only lowercase letters, numbers, and hyphens",
+				},
+			})
+		case names[c.Name]:
+			v.errors = append(v.errors, ValidationError{
+				Field:       field + ".name",
+				Message:     fmt.Sprintf("duplicate container name in pod %s: %s", pod.Name, c.Name),
+				Suggestions: []string{"Give each container a name distinct from the pod and its other containers"},
+			})
+		}
+		names[c.Name] = true
+
+		if c.Image == "" {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".image",
+				Message: fmt.Sprintf("image of container %s is required", c.Name),
+			})
+		} else if strings.Contains(c.Image, "<% REGISTRY %>") && !strings.HasPrefix(c.Image, "<% REGISTRY %>/") {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".image",
+				Message: "private images must start with '<% REGISTRY %>/'",
+				Suggestions: []string{
+					"Example: <% REGISTRY %>/myapp/migrate:v1.0.0",
+				},
+			})
+		}
+
+		keys := make(map[string]bool)
+		for j, env := range c.Vars {
+			if env.Key == "" {
+				v.errors = append(v.errors, ValidationError{
+					Field:   fmt.Sprintf("%s.vars[%d].key", field, j),
+					Message: "environment variable key is required",
+				})
+			} else if keys[env.Key] {
+				v.errors = append(v.errors, ValidationError{
+					Field:   fmt.Sprintf("%s.vars[%d].key", field, j),
+					Message: fmt.Sprintf("duplicate environment variable: %s", env.Key),
+				})
+			}
+			keys[env.Key] = true
+		}
+	}
+	for i, c := range pod.InitContainers {
+		check("initContainers", i, c)
+	}
+	for i, c := range pod.Sidecars {
+		check("sidecars", i, c)
+	}
+}
+
+// validateScaling checks the replicas of a pod and the bounds and target of
+// its autoscaling
+func (v *Validator) validateScaling(pod schema.Pod) {
+	if pod.Replicas < 0 {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.replicas",
+			Message: fmt.Sprintf("replicas of pod %s must be at least 1, got %d", pod.Name, pod.Replicas),
+		})
+	}
+	a := pod.Autoscale
+	if a == nil {
+		return
+	}
+	if pod.Replicas > 0 {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.replicas",
+			Message: fmt.Sprintf("pod %s sets both replicas and autoscale", pod.Name),
+			Suggestions: []string{
+				"Remove replicas; an autoscaled pod starts with autoscale.min replicas",
+			},
+		})
+	}
+	if a.Min < 1 {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.autoscale.min",
+			Message: fmt.Sprintf("autoscale min of pod %s must be at least 1, got %d", pod.Name, a.Min),
+		})
+	}
+	if a.Max < a.Min || a.Max < 1 {
+		v.errors = append(v.errors, ValidationError{
+			Field:       "pod.autoscale.max",
+			Message:     fmt.Sprintf("autoscale max of pod %s must be at least its min, got %d", pod.Name, a.Max),
+			Suggestions: []string{"Example: autoscale: {min: 2, max: 10}"},
+		})
+	}
+	if a.TargetCPU < 0 || a.TargetCPU > 100 {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.autoscale.targetCPU",
+			Message: fmt.Sprintf("autoscale targetCPU of pod %s must be a percent between 1 and 100, got %d", pod.Name, a.TargetCPU),
+			Suggestions: []string{
+				fmt.Sprintf("Leave it unset to scale at %d%% of the pod's CPU", schema.DefaultTargetCPU),
+			},
+		})
+	}
+}
+
+// validateProbes checks that each health probe has one action, a path and
+// port that can be reached, durations and a number of retries that is not
+// negative
+func (v *Validator) validateProbes(pod schema.Pod) {
+	if pod.Probe != nil && pod.Probes.Readiness != nil {
+		v.errors = append(v.errors, ValidationError{
+			Field:       "pod.probe",
+			Message:     fmt.Sprintf("pod %s sets both probe and probes.readiness", pod.Name),
+			Suggestions: []string{"Move the command of probe to probes.readiness.exec"},
+		})
+	}
+	for _, n := range pod.Probes.Named() {
+		field := "pod.probes." + n.Name
+		p := n.Probe
+
+		actions := 0
+		for _, set := range []bool{p.HTTPGet != nil, p.TCPSocket != nil, p.Exec != nil} {
+			if set {
+				actions++
+			}
+		}
+		if actions != 1 {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("%s probe of pod %s must set exactly one of httpGet, tcpSocket and exec", n.Name, pod.Name),
+				Suggestions: []string{
+					"Example: httpGet: {path: /health}",
+					"Example: tcpSocket: {port: 5432}",
+					"Example: exec: {command: [pg_isready, -U, postgres]}",
+				},
+			})
+		}
+
+		if a := p.HTTPGet; a != nil {
+			if !strings.HasPrefix(a.Path, "/") {
+				v.errors = append(v.errors, ValidationError{
+					Field:       field + ".httpGet.path",
+					Message:     fmt.Sprintf("%s probe path of pod %s must start with /, got %q", n.Name, pod.Name, a.Path),
+					Suggestions: []string{"Example: path: /health"},
+				})
+			}
+			v.validateProbePort(pod, field+".httpGet.port", a.Port)
+		}
+		if a := p.TCPSocket; a != nil {
+			v.validateProbePort(pod, field+".tcpSocket.port", a.Port)
+		}
+		if a := p.Exec; a != nil && len(a.Command) == 0 {
+			v.errors = append(v.errors, ValidationError{
+				Field:       field + ".exec.command",
+				Message:     fmt.Sprintf("%s probe of pod %s needs a command", n.Name, pod.Name),
+				Suggestions: []string{"Example: command: [pg_isready, -U, postgres]"},
+			})
+		}
+
+		for _, d := range []struct{ name, value string }{
+			{"interval", p.Interval},
+			{"timeout", p.Timeout},
+			{"startPeriod", p.StartPeriod},
+		} {
+			if d.value == "" {
+				continue
+			}
+			if duration, err := time.ParseDuration(d.value); err != nil || duration < 0 {
+				v.errors = append(v.errors, ValidationError{
+					Field:       field + "." + d.name,
+					Message:     fmt.Sprintf("invalid %s probe %s of pod %s: %s", n.Name, d.name, pod.Name, d.value),
+					Suggestions: []string{"Write durations as 10s, 500ms or 1m30s"},
+				})
+			}
+		}
+		if p.Retries < 0 {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".retries",
+				Message: fmt.Sprintf("probe retries must not be negative, got %d", p.Retries),
+			})
+		}
+	}
+}
+
+// validateProbePort checks the port of an HTTP or TCP probe. Unset ports
+// default to the first service port, which the pod must then have.
+func (v *Validator) validateProbePort(pod schema.Pod, field string, port int) {
+	if port == 0 && len(pod.ServicePorts) > 0 {
+		return
+	}
+	if port < 1 || port > 65535 {
+		v.errors = append(v.errors, ValidationError{
+			Field:       field,
+			Message:     fmt.Sprintf("invalid probe port for pod %s: %d", pod.Name, port),
+			Suggestions: []string{"Use a port between 1 and 65535, usually a targetPort of the pod"},
+		})
+	}
+}
+
+// validateStatic checks a static site pod. Its image is provided by the
+// platform at deploy time, so neither it nor a command may be set.
+func (v *Validator) validateStatic(pod schema.Pod) {
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{{"image", pod.Image != ""}, {"entrypoint", len(pod.Entrypoint) > 0}, {"command", len(pod.Command) > 0}} {
+		if f.set {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "pod." + f.name,
+				Message: fmt.Sprintf("static pod %s cannot set %s", pod.Name, f.name),
+				Suggestions: []string{
+					"Static sites are served by the platform; remove " + f.name,
+					"To run your own web server image, remove static and type: static",
+				},
+			})
+		}
+	}
+
+	site := pod.Static
+	if site == nil || site.Dir == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.static.dir",
+			Message: fmt.Sprintf("static pod %s needs the directory of its build output", pod.Name),
+			Suggestions: []string{
+				"Example: static: {dir: dist, build: npm run build, spa: true}",
+			},
+		})
+		return
+	}
+
+	for _, pattern := range sortedKeys(site.CacheControl) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "pod.static.cacheControl",
+				Message: fmt.Sprintf("invalid path pattern: %s", pattern),
+				Suggestions: []string{
+					"Patterns starting with / match the path, e.g. /assets/*; others the file name, e.g. *.html",
+				},
+			})
+		} else if strings.TrimSpace(site.CacheControl[pattern]) == "" {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "pod.static.cacheControl",
+				Message: fmt.Sprintf("empty Cache-Control value for %s", pattern),
+				Suggestions: []string{
+					"Example: public, max-age=31536000, immutable",
+				},
+			})
+		}
+	}
+
+	// Without a build command the output must already exist
+	if site.Build == "" {
+		dir := static.Dir(*site, v.baseDir)
+		if _, err := os.Stat(filepath.Join(dir, static.IndexFile)); err != nil {
+			v.errors = append(v.errors, ValidationError{
+				Field:   "pod.static.dir",
+				Message: fmt.Sprintf("%s of static pod %s has no %s", dir, pod.Name, static.IndexFile),
+				Suggestions: []string{
+					"Build the site first, or set static.build to build it on deploy, e.g. npm run build",
+				},
+			})
+		}
+	}
+}
+
+// validateCanary checks that a canary runs a different image than its pod and
+// gets a weight between 0 and 100
+func (v *Validator) validateCanary(pod schema.Pod) {
+	c := pod.Canary
+	switch {
+	case pod.Static != nil:
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.canary",
+			Message: fmt.Sprintf("static pod %s cannot have a canary", pod.Name),
+		})
+	case c.Image == "":
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.canary.image",
+			Message: fmt.Sprintf("canary of pod %s needs an image", pod.Name),
+			Suggestions: []string{
+				"Set the image of the new version, e.g. ghcr.io/acme/api:v2",
+			},
+		})
+	case c.Image == pod.Image:
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.canary.image",
+			Message: fmt.Sprintf("canary of pod %s runs the same image as the pod", pod.Name),
+			Suggestions: []string{
+				"Promote the canary by setting it as the pod's image and removing canary",
+			},
+		})
+	}
+	if c.Weight < 0 || c.Weight > 100 {
+		v.errors = append(v.errors, ValidationError{
+			Field:   "pod.canary.weight",
+			Message: fmt.Sprintf("canary weight must be between 0 and 100, got %d", c.Weight),
+			Suggestions: []string{
+				"The weight is the percent of traffic sent to the canary, e.g. 10",
+			},
+		})
+	}
+}
+
+// validateEnvFrom checks that each envFrom entry names exactly one source and
+// that no variable is imported twice. Variables in vars override imports.
+func (v *Validator) validateEnvFrom(pod schema.Pod) {
+	valid := true
+	for i, e := range pod.EnvFrom {
+		sources := 0
+		for _, s := range []string{e.SecretRef, e.ConfigRef, e.File} {
+			if s != "" {
+				sources++
+			}
+		}
+		if sources != 1 {
+			valid = false
+			v.errors = append(v.errors, ValidationError{
+				Field:   fmt.Sprintf("pod.envFrom[%d]", i),
+				Message: fmt.Sprintf("envFrom entry of pod %s must set exactly one of secretRef, configRef or file", pod.Name),
+				Suggestions: []string{
+					"Split the entry into one entry per source",
+				},
+			})
+		}
+		if e.Prefix != "" && !envPrefixRegex.MatchString(e.Prefix) {
+			valid = false
+			v.errors = append(v.errors, ValidationError{
+				Field:   fmt.Sprintf("pod.envFrom[%d].prefix", i),
+				Message: fmt.Sprintf("invalid prefix: %s", e.Prefix),
+				Suggestions: []string{
+					"Use letters, digits and underscores, e.g. DB_",
+				},
+			})
+		}
+	}
+	if !valid {
+		return
+	}
+
+	if _, err := schema.EnvFromVars(pod, v.baseDir); err != nil {
+		verr := ValidationError{Field: "pod.envFrom", Message: err.Error()}
+		var conflict *schema.EnvConflictError
+		if errors.As(err, &conflict) {
+			verr.Suggestions = []string{
+				"Set a prefix on one of the sources, or remove the key from one of them",
+				"To choose a value, set " + conflict.Key + " in vars; vars override imported keys",
+			}
+		}
+		v.errors = append(v.errors, verr)
+	}
+}
+
+// validateVolume validates a volume configuration
+func (v *Validator) validateVolume(podIndex int, volume schema.Volume, volumeNames map[string]bool) {
+	if volume.Name == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pods[%d].volumes.name", podIndex),
+			Message: "volume name is required",
+		})
+	} else if !isValidName(volume.Name) {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pods[%d].volumes.name", podIndex),
+			Message: "volume name must be lowercase alphanumeric with hyphens",
+		})
+	} else if volumeNames[volume.Name] {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pods[%d].volumes.name", podIndex),
+			Message: fmt.Sprintf("duplicate volume name: %s", volume.Name),
+		})
+	}
+
+	if volume.Path == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pods[%d].volumes.path", podIndex),
+			Message: "volume path is required",
+			Suggestions: []string{
+				"Volume paths must be absolute paths starting with '/'",
+			},
+		})
+	} else if !system.IsContainerAbs(volume.Path) {
+		suggestions := []string{
+			"Volume paths must be absolute paths starting with '/'",
+		}
+		if hasDriveLetter(volume.Path) {
+			suggestions = append(suggestions, "Volume paths refer to the container filesystem, not a Windows drive; use a path like /data")
+		}
+		v.errors = append(v.errors, ValidationError{
+			Field:       fmt.Sprintf("pods[%d].volumes.path", podIndex),
+			Message:     fmt.Sprintf("volume path must start with '/': %s", volume.Path),
+			Suggestions: suggestions,
+		})
+	}
+
+	if volume.Size == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pods[%d].volumes.size", podIndex),
+			Message: "volume size is required",
+			Suggestions: []string{
+				"Specify size in Ki, Mi, or Gi (e.g., '1Gi', '500Mi')",
+			},
+		})
+	} else if !isValidVolumeSize(volume.Size) {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pods[%d].volumes.size", podIndex),
+			Message: fmt.Sprintf("invalid volume size format: %s", volume.Size),
+			Suggestions: []string{
+				"Use format: <number><unit> where unit is Ki, Mi, or Gi",
+				"Examples: 1Gi, 500Mi, 2048Ki",
+			},
+		})
+	}
+
+	if volume.Class != "" && volume.Class != schema.VolumeClassStandard && volume.Class != schema.VolumeClassSSD {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pods[%d].volumes.class", podIndex),
+			Message: fmt.Sprintf("invalid volume class: %s", volume.Class),
+			Suggestions: []string{
+				"Supported classes: " + schema.VolumeClassStandard + ", " + schema.VolumeClassSSD,
+			},
+		})
+	}
+
+	if snap := volume.Snapshot; snap != nil {
+		if !isValidSchedule(snap.Schedule) {
+			v.errors = append(v.errors, ValidationError{
+				Field:   fmt.Sprintf("pods[%d].volumes.snapshot.schedule", podIndex),
+				Message: fmt.Sprintf("invalid snapshot schedule: %q", snap.Schedule),
+				Suggestions: []string{
+					"Use @hourly, @daily, @weekly, @monthly or a cron expression such as '0 3 * * *'",
+				},
+			})
+		}
+		if snap.Retention < 1 {
+			v.errors = append(v.errors, ValidationError{
+				Field:   fmt.Sprintf("pods[%d].volumes.snapshot.retention", podIndex),
+				Message: "snapshot retention must be at least 1",
+				Suggestions: []string{
+					"Retention is the number of snapshots kept, e.g. 7",
+				},
+			})
+		}
+		if volume.ReadOnly {
+			v.errors = append(v.errors, ValidationError{
+				Field:   fmt.Sprintf("pods[%d].volumes.snapshot", podIndex),
+				Message: fmt.Sprintf("volume %s is read-only and cannot be snapshotted", volume.Name),
+			})
+		}
+	}
+
+	volumeNames[volume.Name] = true
+}
+
+// validateMetadata checks the keys and values of labels and annotations
+func (v *Validator) validateMetadata(field string, labels, annotations map[string]string) {
+	for _, key := range sortedKeys(labels) {
+		if err := schema.CheckMetadataKey(key); err != nil {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".labels",
+				Message: fmt.Sprintf("invalid label key: %v", err),
+			})
+		} else if err := schema.CheckLabelValue(labels[key]); err != nil {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".labels." + key,
+				Message: err.Error(),
+				Suggestions: []string{
+					"Move free-form values to annotations, which accept any string",
+				},
+			})
+		}
+	}
+
+	for _, key := range sortedKeys(annotations) {
+		if err := schema.CheckMetadataKey(key); err != nil {
+			v.errors = append(v.errors, ValidationError{
+				Field:   field + ".annotations",
+				Message: fmt.Sprintf("invalid annotation key: %v", err),
+			})
+		}
+	}
+	if size := schema.AnnotationsSize(annotations); size > schema.MaxAnnotationsSize {
+		v.errors = append(v.errors, ValidationError{
+			Field:   field + ".annotations",
+			Message: fmt.Sprintf("annotations total %d bytes, more than the %d allowed", size, schema.MaxAnnotationsSize),
+			Suggestions: []string{
+				"Mount large values with configFiles instead",
+			},
+		})
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateConfigFile validates a config file mount
+func (v *Validator) validateConfigFile(index int, cf schema.ConfigFile, configNames map[string]bool) {
+	if cf.Name == "" {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pod.configFiles[%d].name", index),
+			Message: "config file name is required",
+		})
+	} else if configNames[cf.Name] {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pod.configFiles[%d].name", index),
+			Message: fmt.Sprintf("duplicate config file name: %s", cf.Name),
+		})
+	}
+	configNames[cf.Name] = true
+
+	if !system.IsContainerAbs(cf.Path) {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pod.configFiles[%d].path", index),
+			Message: fmt.Sprintf("config file path must start with '/': %s", cf.Path),
+			Suggestions: []string{
+				"Path is the directory the file is mounted into, e.g. /etc/nginx/conf.d",
+			},
+		})
+	}
+
+	if (cf.Content == "") == (cf.Source == "") {
+		v.errors = append(v.errors, ValidationError{
+			Field:   fmt.Sprintf("pod.configFiles[%d]", index),
+			Message: "exactly one of content or source is required",
+			Suggestions: []string{
+				"Use 'content' for inline text or 'source' for a local file, e.g. source: ./nginx.conf",
+			},
+		})
+	}
+}
+
+// Helper functions for validation
+
+var envPrefixRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func isValidName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	if name[0] < 'a' || name[0] > 'z' {
+		return false
+	}
+	for _, c := range name {
+		if !((c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidPodName(name string) bool {
+	return isValidName(name)
+}
+
+// isValidURL reports whether url is a domain an application can be served at
+func isValidURL(url string) bool {
+	return schema.ValidateDomain(url) == nil && !schema.IsWildcardDomain(url)
+}
+
+func isValidRegistryHost(host string) bool {
+	// More comprehensive registry host validation
+	if strings.ContainsAny(host, " \t\n\r") {
+		return false
+	}
+
+	// Must contain at least one dot and no consecutive dots
+	if !strings.Contains(host, ".") || strings.Contains(host, "..") {
+		return false
+	}
+
+	// Split into parts
+	parts := strings.Split(host, ".")
+	for _, part := range parts {
+		if len(part) == 0 || len(part) > 63 {
+			return false
+		}
+		// Each part must be alphanumeric or hyphen
+		for _, c := range part {
+			if !((c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-') {
+				return false
+			}
+		}
+		// Cannot start or end with hyphen
+		if part[0] == '-' || part[len(part)-1] == '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidSchedule accepts the @hourly, @daily, @weekly and @monthly shorthands and
+// five-field cron expressions
+func isValidSchedule(schedule string) bool {
+	switch schedule {
+	case "@hourly", "@daily", "@weekly", "@monthly":
+		return true
+	}
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return false
+	}
+	for _, f := range fields {
+		if strings.Trim(f, "0123456789*/,-") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidGPUType(t string) bool {
+	for _, known := range schema.GPUTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidVolumeSize(size string) bool {
+	// More comprehensive volume size validation
+	re := regexp.MustCompile(`^([1-9][0-9]*(?:\.[0-9]+)?|0\.[0-9]*[1-9][0-9]*)[KMGT]i$`)
+	if !re.MatchString(size) {
+		return false
+	}
+
+	// Extract numeric part and unit
+	numStr := size[:len(size)-2]
+	unit := size[len(size)-2:]
+
+	// Parse numeric part
+	num, err := parseFloat(numStr)
+	if err != nil {
+		return false
+	}
+
+	// Check size limits based on unit
+	switch unit[0] {
+	case 'K':
+		return num <= 1024*1024 // Max 1024Ki
+	case 'M':
+		return num <= 1024*1024 // Max 1024Mi
+	case 'G':
+		return num <= 1024 // Max 1024Gi
+	case 'T':
+		return num <= 64 // Max 64Ti
+	default:
+		return false
+	}
+}
+
+// parseFloat parses a string to float64, handling scientific notation
+func parseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+var podRefRegex = regexp.MustCompile(`\b([a-z][a-z0-9-]*)\.pod\b`)
+
+func extractPodReferences(value string) []string {
+	matches := podRefRegex.FindAllStringSubmatch(value, -1)
+	refs := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if len(match) > 1 {
+			refs = append(refs, match[1])
+		}
+	}
+	return refs
+}
+
+func findClosestPodName(ref string, podNames map[string]bool) string {
+	minDist := len(ref)
+	var closest string
+	for name := range podNames {
+		dist := levenshteinDistance(ref, name)
+		if dist < minDist {
+			minDist = dist
+			closest = name
+		}
+	}
+	if minDist <= len(ref)/2 {
+		return closest
+	}
+	return ""
+}
+
+func levenshteinDistance(s1, s2 string) int {
+	if len(s1) == 0 {
+		return len(s2)
+	}
+	if len(s2) == 0 {
+		return len(s1)
+	}
+
+	matrix := make([][]int, len(s1)+1)
+	for i := range matrix {
+		matrix[i] = make([]int, len(s2)+1)
+		matrix[i][0] = i
+	}
+	for j := range matrix[0] {
+		matrix[0][j] = j
+	}
+
+	for i := 1; i <= len(s1); i++ {
+		for j := 1; j <= len(s2); j++ {
+			cost := 1
+			if s1[i-1] == s2[j-1] {
+				cost = 0
+			}
+			matrix[i][j] = min(
+				matrix[i-1][j]+1,
+				matrix[i][j-1]+1,
+				matrix[i-1][j-1]+cost,
+			)
+		}
+	}
+
+	return matrix[len(s1)][len(s2)]
+}
+
+func min(a, b, c int) int {
+	if a < b {
+		if a < c {
+			return a
+		}
+		return c
+	}
+	if b < c {
+		return b
+	}
+	return c
+}
+
+func getAvailablePods(podNames map[string]bool) []string {
+	pods := make([]string, 0, len(podNames))
+	for name := range podNames {
+		pods = append(pods, name)
+	}
+	sort.Strings(pods)
+	return pods
+}
+
+// formatErrors formats all validation errors into a single error message
+func (v *Validator) formatErrors() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	// Pre-allocate buffer with estimated size
+	var errMsg strings.Builder
+	errMsg.Grow(len(v.errors) * 100) // Estimate 100 bytes per error
+
+	errMsg.WriteString("Validation failed:\n")
+
+	// Use map to group errors by category
+	categories := map[string][]ValidationError{
+		"application": make([]ValidationError, 0),
+		"pods":        make([]ValidationError, 0),
+		"volumes":     make([]ValidationError, 0),
+		"vars":        make([]ValidationError, 0),
+	}
+
+	// Group errors by category
+	for _, err := range v.errors {
+		category := strings.Split(err.Field, ".")[0]
+		if i := strings.Index(category, "["); i >= 0 {
+			category = category[:i]
+		}
+		if _, ok := categories[category]; !ok {
+			category = "pods"
+		}
+		categories[category] = append(categories[category], err)
+	}
+
+	// Print errors by category in a consistent order
+	for _, category := range []string{"application", "pods", "volumes", "vars"} {
+		if errors := categories[category]; len(errors) > 0 {
+			errMsg.WriteString(fmt.Sprintf("\n%s:\n", strings.Title(category)))
+			for _, err := range errors {
+				errMsg.WriteString(fmt.Sprintf("  - %s: %s\n", err.Field, err.Message))
+				for _, suggestion := range err.Suggestions {
+					errMsg.WriteString(fmt.Sprintf("    • %s\n", suggestion))
+				}
+			}
+		}
+	}
+
+	return fmt.Errorf(errMsg.String())
+}
+
+// ValidatePod validates a single pod configuration
+func ValidatePod(pod schema.Pod) error {
+	validator := NewValidator(&schema.NexlayerYAML{
+		Application: schema.Application{
+			Name: "temp",
+			Pods: []schema.Pod{pod},
+		},
+	})
+	return validator.Validate()
+}
+
+// validateTemplate validates a template configuration
+func validateTemplate(tmpl *schema.NexlayerYAML) error {
+	if tmpl == nil {
+		return fmt.Errorf("template is nil")
+	}
+
+	// Validate application
+	if tmpl.Application.Name == "" {
+		return fmt.Errorf("application name is required")
+	}
+
+	// Validate pods
+	if len(tmpl.Application.Pods) == 0 {
+		return fmt.Errorf("at least one pod is required")
+	}
+
+	for _, pod := range tmpl.Application.Pods {
+		if err := validatePod(pod); err != nil {
+			return fmt.Errorf("invalid pod %s: %w", pod.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validatePod validates a pod configuration
+func validatePod(pod schema.Pod) error {
+	// Validate pod name
+	if pod.Name == "" {
+		return fmt.Errorf("pod name is required")
+	}
+
+	// Validate image
+	if pod.Image == "" && !pod.IsStatic() {
+		return fmt.Errorf("image is required for pod %s", pod.Name)
+	}
+
+	// Validate service ports; jobs need none as they do not serve
+	if len(pod.ServicePorts) == 0 && !pod.IsJob() {
+		return fmt.Errorf("at least one service port is required for pod %s", pod.Name)
+	}
+
+	// Validate service ports
+	for _, port := range pod.ServicePorts {
+		if port.Name == "" {
+			return fmt.Errorf("port name is required for pod %s", pod.Name)
+		}
+		if port.Port < 1 || port.Port > 65535 {
+			return fmt.Errorf("invalid port number %d for pod %s (must be between 1 and 65535)", port.Port, pod.Name)
+		}
+		if port.TargetPort < 1 || port.TargetPort > 65535 {
+			return fmt.Errorf("invalid target port number %d for pod %s (must be between 1 and 65535)", port.TargetPort, pod.Name)
+		}
+		if !schema.IsValidProtocol(port.Protocol) {
+			return fmt.Errorf("unsupported protocol %s on port %s of pod %s (use TCP or UDP)", port.Protocol, port.Name, pod.Name)
+		}
+	}
+
+	return nil
+}
+
+// hasDriveLetter reports whether a path starts with a Windows drive letter such as "C:"
+func hasDriveLetter(p string) bool {
+	return len(p) >= 2 && p[1] == ':' && ((p[0] >= 'a' && p[0] <= 'z') || (p[0] >= 'A' && p[0] <= 'Z'))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package detection
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/types"
+)
+
+// AddDependencies completes info with the dependencies declared in the
+// package.json, requirements.txt and go.mod of dir, and with the npm scripts.
+// Detectors report the type of a project; this reports what it uses, such as
+// its database clients. Versions already in info are kept.
+func AddDependencies(info *types.ProjectInfo, dir string) {
+	if info.Dependencies == nil {
+		info.Dependencies = make(map[string]string)
+	}
+	add := func(name, version string) {
+		if _, ok := info.Dependencies[name]; !ok && name != "" {
+			info.Dependencies[name] = version
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+		var pkg struct {
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+			Scripts         map[string]string `json:"scripts"`
+		}
+		if json.Unmarshal(data, &pkg) == nil {
+			for name, version := range pkg.Dependencies {
+				add(name, version)
+			}
+			for name, version := range pkg.DevDependencies {
+				add(name, version)
+			}
+			if info.Scripts == nil {
+				info.Scripts = make(map[string]string)
+			}
+			for name, script := range pkg.Scripts {
+				if _, ok := info.Scripts[name]; !ok {
+					info.Scripts[name] = script
+				}
+			}
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "requirements.txt")); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+				continue
+			}
+			name, version := line, "latest"
+			if i := strings.IndexAny(line, "[<>=~!; "); i > 0 {
+				name = line[:i]
+				if _, v, ok := strings.Cut(line, "=="); ok {
+					version = strings.TrimSpace(v)
+				}
+			}
+			add(strings.ToLower(name), version)
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+		inRequire := false
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line, _, _ = strings.Cut(line, "//"); line == "" {
+				continue
+			}
+			switch {
+			case line == "require (":
+				inRequire = true
+				continue
+			case inRequire && line == ")":
+				inRequire = false
+				continue
+			case strings.HasPrefix(line, "require "):
+				line = strings.TrimPrefix(line, "require ")
+			case !inRequire:
+				continue
+			}
+			if fields := strings.Fields(line); len(fields) >= 2 {
+				add(fields[0], fields[1])
+			}
+		}
+	}
+}
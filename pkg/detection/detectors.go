@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/heroku"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/types"
 	"gopkg.in/yaml.v3"
 )
@@ -82,6 +83,7 @@ func NewDetectorRegistry() *DetectorRegistry {
 			&MERNDetector{},
 			&PERNDetector{},
 			&MEANDetector{},
+			&HerokuDetector{},
 
 			// Base Detectors
 			&NextjsDetector{},
@@ -696,6 +698,23 @@ func (d *MEANDetector) Detect(dir string) (*types.ProjectInfo, error) {
 	}, nil
 }
 
+// HerokuDetector detects Heroku projects, described by a Procfile
+type HerokuDetector struct{}
+
+func (d *HerokuDetector) Priority() int { return 120 }
+
+func (d *HerokuDetector) Detect(dir string) (*types.ProjectInfo, error) {
+	if !heroku.Detect(dir) {
+		return nil, nil
+	}
+	return &types.ProjectInfo{
+		Type:         types.TypeHeroku,
+		Name:         filepath.Base(dir),
+		Dependencies: make(map[string]string),
+		Port:         heroku.DefaultPort,
+	}, nil
+}
+
 // LLMDetector detects AI-powered IDEs or LLM-based coding assistants
 type LLMDetector struct{}
 
@@ -78,14 +78,6 @@ type fileReadCache struct {
 	content string
 }
 
-// NewBaseDetector creates a new base detector with the given name and confidence
-func NewBaseDetector(name string, confidence float64) *BaseDetector {
-	return &BaseDetector{
-		name:       name,
-		confidence: confidence,
-	}
-}
-
 // NewStackDetector creates a new detector for common technology stacks
 func NewStackDetector() *StackDetector {
 	detector := &StackDetector{
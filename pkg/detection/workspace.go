@@ -0,0 +1,342 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package detection
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Workspace is a monorepo: a root directory whose workspace definitions,
+// such as pnpm-workspace.yaml or go.work, list several projects
+type Workspace struct {
+	Root     string             // directory holding the workspace definitions
+	Tools    []string           // tools defining the workspace, such as pnpm and turbo
+	Projects []WorkspaceProject // deployable projects, in directory order
+}
+
+// WorkspaceProject is a deployable project of a workspace
+type WorkspaceProject struct {
+	Dir        string             // relative to the workspace root, with forward slashes
+	Info       *types.ProjectInfo // detected type, port and dependencies
+	Dockerfile string             // Dockerfile in Dir, or "" when it has none
+}
+
+// workspaceDetectors detect the projects of a workspace, in priority order.
+// The full-stack and LLM detectors describe a whole repository, so they are
+// left out.
+var workspaceDetectors = []ProjectDetector{
+	&NextjsDetector{},
+	&ReactDetector{},
+	&NodeDetector{},
+	&PythonDetector{},
+	&GoDetector{},
+}
+
+// exposeRegex matches the first port of an EXPOSE instruction
+var exposeRegex = regexp.MustCompile(`(?im)^\s*EXPOSE\s+(\d+)`)
+
+// DetectWorkspace reads the workspace definitions of dir, pnpm-workspace.yaml,
+// the workspaces of package.json, lerna.json, turbo.json, nx.json and go.work,
+// and detects the deployable projects they list. It returns nil when dir
+// defines no workspace.
+func DetectWorkspace(dir string) (*Workspace, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	ws := &Workspace{Root: root}
+	var patterns []string
+	add := func(tool string, globs []string) {
+		ws.Tools = append(ws.Tools, tool)
+		patterns = append(patterns, globs...)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(root, "pnpm-workspace.yaml")); err == nil {
+		var def struct {
+			Packages []string `yaml:"packages"`
+		}
+		if err := yaml.Unmarshal(data, &def); err != nil {
+			return nil, err
+		}
+		add("pnpm", def.Packages)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(root, "package.json")); err == nil {
+		var pkg struct {
+			Workspaces json.RawMessage `json:"workspaces"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && len(pkg.Workspaces) > 0 {
+			// npm and yarn take a list, or yarn's object with packages
+			var globs []string
+			if json.Unmarshal(pkg.Workspaces, &globs) != nil {
+				var def struct {
+					Packages []string `json:"packages"`
+				}
+				json.Unmarshal(pkg.Workspaces, &def)
+				globs = def.Packages
+			}
+			add("npm", globs)
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(root, "lerna.json")); err == nil {
+		var def struct {
+			Packages []string `json:"packages"`
+		}
+		json.Unmarshal(data, &def)
+		if len(def.Packages) == 0 {
+			def.Packages = []string{"packages/*"}
+		}
+		add("lerna", def.Packages)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "turbo.json")); err == nil {
+		// Turborepo runs on the package manager's workspaces
+		if len(patterns) == 0 {
+			add("turbo", []string{"apps/*", "packages/*"})
+		} else {
+			ws.Tools = append(ws.Tools, "turbo")
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(root, "nx.json")); err == nil {
+		var def struct {
+			WorkspaceLayout struct {
+				AppsDir string `json:"appsDir"`
+				LibsDir string `json:"libsDir"`
+			} `json:"workspaceLayout"`
+		}
+		json.Unmarshal(data, &def)
+		apps, libs := def.WorkspaceLayout.AppsDir, def.WorkspaceLayout.LibsDir
+		if apps == "" {
+			apps = "apps"
+		}
+		if libs == "" {
+			libs = "libs"
+		}
+		add("nx", []string{apps + "/*", libs + "/*"})
+	}
+
+	if data, err := os.ReadFile(filepath.Join(root, "go.work")); err == nil {
+		add("go", goWorkUses(data))
+	}
+
+	if len(ws.Tools) == 0 {
+		return nil, nil
+	}
+
+	for _, rel := range expandWorkspaceGlobs(root, patterns) {
+		if project, ok := detectWorkspaceProject(root, rel); ok {
+			ws.Projects = append(ws.Projects, project)
+		}
+	}
+	return ws, nil
+}
+
+// goWorkUses returns the directories of the use directives of a go.work file
+func goWorkUses(data []byte) []string {
+	var dirs []string
+	inUse := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line, _, _ = strings.Cut(line, "//"); strings.TrimSpace(line) == "" {
+			continue
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "use (":
+			inUse = true
+		case inUse && line == ")":
+			inUse = false
+		case inUse:
+			dirs = append(dirs, strings.Trim(line, `"`))
+		case strings.HasPrefix(line, "use "):
+			dirs = append(dirs, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "use ")), `"`))
+		}
+	}
+	return dirs
+}
+
+// expandWorkspaceGlobs returns the directories matched by patterns, relative
+// to root and sorted. Patterns starting with ! exclude directories, and **
+// matches any depth outside node_modules and hidden directories.
+func expandWorkspaceGlobs(root string, patterns []string) []string {
+	matched := make(map[string]bool)
+	var excludes []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(filepath.ToSlash(strings.TrimSpace(pattern)), "/")
+		if strings.HasPrefix(pattern, "!") {
+			excludes = append(excludes, cleanWorkspaceGlob(strings.TrimPrefix(pattern, "!")))
+			continue
+		}
+		pattern = cleanWorkspaceGlob(pattern)
+		if pattern == "" || pattern == "." {
+			continue
+		}
+		for _, dir := range globDirs(root, pattern) {
+			matched[dir] = true
+		}
+	}
+
+	var dirs []string
+	for dir := range matched {
+		excluded := false
+		for _, exclude := range excludes {
+			if ok, _ := matchWorkspaceGlob(exclude, dir); ok {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// cleanWorkspaceGlob cleans a workspace glob into a slash-separated path
+// relative to the workspace root
+func cleanWorkspaceGlob(pattern string) string {
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(pattern)), "./")
+}
+
+// globDirs returns the directories under root matching pattern
+func globDirs(root, pattern string) []string {
+	var dirs []string
+	if !strings.Contains(pattern, "**") {
+		matches, _ := filepath.Glob(filepath.Join(root, filepath.FromSlash(pattern)))
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && info.IsDir() {
+				rel, _ := filepath.Rel(root, match)
+				dirs = append(dirs, filepath.ToSlash(rel))
+			}
+		}
+		return dirs
+	}
+
+	base, _, _ := strings.Cut(pattern, "**")
+	start := filepath.Join(root, filepath.FromSlash(base))
+	filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if name := d.Name(); p != start && (name == "node_modules" || strings.HasPrefix(name, ".")) {
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(root, p)
+		rel = filepath.ToSlash(rel)
+		if ok, _ := matchWorkspaceGlob(pattern, rel); ok {
+			dirs = append(dirs, rel)
+		}
+		return nil
+	})
+	return dirs
+}
+
+// matchWorkspaceGlob matches a slash-separated path against a glob in which
+// ** matches any number of path elements
+func matchWorkspaceGlob(pattern, name string) (bool, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Match(pattern, name)
+	}
+	patternParts, nameParts := strings.Split(pattern, "/"), strings.Split(name, "/")
+	var match func(p, n []string) (bool, error)
+	match = func(p, n []string) (bool, error) {
+		if len(p) == 0 {
+			return len(n) == 0, nil
+		}
+		if p[0] == "**" {
+			for i := 0; i <= len(n); i++ {
+				if ok, err := match(p[1:], n[i:]); ok || err != nil {
+					return ok, err
+				}
+			}
+			return false, nil
+		}
+		if len(n) == 0 {
+			return false, nil
+		}
+		ok, err := filepath.Match(p[0], n[0])
+		if !ok || err != nil {
+			return false, err
+		}
+		return match(p[1:], n[1:])
+	}
+	return match(patternParts, nameParts)
+}
+
+// detectWorkspaceProject detects the project in root/rel, and reports whether
+// it is deployable: it has a Dockerfile, is a frontend, or has an entry point
+// such as a start script, main.go or app.py. Libraries are left out.
+func detectWorkspaceProject(root, rel string) (WorkspaceProject, bool) {
+	dir := filepath.Join(root, filepath.FromSlash(rel))
+	project := WorkspaceProject{Dir: rel}
+	if _, err := os.Stat(filepath.Join(dir, "Dockerfile")); err == nil {
+		project.Dockerfile = "Dockerfile"
+	}
+
+	for _, detector := range workspaceDetectors {
+		if info, err := detector.Detect(dir); err == nil && info != nil {
+			project.Info = info
+			break
+		}
+	}
+	if project.Info == nil {
+		if project.Dockerfile == "" {
+			return project, false
+		}
+		project.Info = &types.ProjectInfo{Type: types.TypeDockerRaw, Port: 8080}
+	}
+	project.Info.Name = filepath.Base(dir)
+	project.Info.HasDocker = project.Dockerfile != ""
+	AddDependencies(project.Info, dir)
+
+	if project.Dockerfile != "" {
+		if data, err := os.ReadFile(filepath.Join(dir, project.Dockerfile)); err == nil {
+			if m := exposeRegex.FindSubmatch(data); m != nil {
+				if port, err := strconv.Atoi(string(m[1])); err == nil {
+					project.Info.Port = port
+				}
+			}
+		}
+		return project, true
+	}
+
+	switch project.Info.Type {
+	case types.TypePython, types.TypeLlamaPython:
+		return project, true
+	case types.TypeNextjs, types.TypeLangchainNextjs, types.TypeReact:
+		// Component libraries have no build of their own
+		_, start := project.Info.Scripts["start"]
+		_, build := project.Info.Scripts["build"]
+		return project, start || build
+	case types.TypeNode, types.TypeOpenAINode:
+		_, ok := project.Info.Scripts["start"]
+		return project, ok
+	case types.TypeGo:
+		for _, main := range []string{"main.go", filepath.Join("cmd", "main.go"), filepath.Join("cmd", "server.go")} {
+			if _, err := os.Stat(filepath.Join(dir, main)); err == nil {
+				return project, true
+			}
+		}
+		matches, _ := filepath.Glob(filepath.Join(dir, "cmd", "*", "main.go"))
+		return project, len(matches) > 0
+	}
+	return project, false
+}
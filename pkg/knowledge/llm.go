@@ -6,8 +6,6 @@ package knowledge
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,9 +14,25 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/cache"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/llm"
 	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
 )
 
+// Sources of LLM results
+const (
+	SourceCache     = "cache"
+	SourceAPI       = "api"
+	SourceHeuristic = "heuristic" // the built-in analysis, without a model
+)
+
+// systemPrompt tells the model how to answer, in the bullet lists the AI
+// enhancer parses
+const systemPrompt = `You review deployment configurations (nexlayer.yaml) of the Nexlayer cloud platform.
+Answer with a short list of findings, one per line, each starting with "- ".
+Start a finding with the pod or field it is about followed by a colon when there is one.
+Only report actionable findings; answer "- No issues found" when there are none.`
+
 // LLMContext represents the enriched context for LLM interactions
 type LLMContext struct {
 	ProjectStructure map[string]interface{} `json:"project_structure"`
@@ -37,7 +51,9 @@ type LLMContext struct {
 type LLMResult struct {
 	Result    string    `json:"result"`
 	Timestamp time.Time `json:"timestamp"`
-	Source    string    `json:"source"` // "cache" or "api"
+	Source    string    `json:"source"`             // SourceCache, SourceAPI or SourceHeuristic
+	Model     string    `json:"model,omitempty"`    // provider and model that answered
+	Fallback  string    `json:"fallback,omitempty"` // why the heuristic answered instead of the model
 }
 
 // LLMEnricher enriches the knowledge graph with LLM metadata
@@ -46,10 +62,12 @@ type LLMEnricher struct {
 	metadata       map[string]interface{}
 	metadataMu     sync.RWMutex
 	metadataDir    string
-	cache          sync.Map // Cache for LLM query results
+	cache          sync.Map     // Cache for LLM query results
+	store          *cache.Store // keeps the answers of models across runs, nil when unavailable
 	cacheTTL       time.Duration
 	processingChan chan *processingTask
 	wg             sync.WaitGroup
+	provider       llm.Provider // nil to only use the heuristic analysis
 }
 
 type processingTask struct {
@@ -62,8 +80,9 @@ type processingTask struct {
 
 // NewLLMEnricher creates a new LLM metadata enricher with optimized caching
 func NewLLMEnricher(graph *Graph, metadataDir string) *LLMEnricher {
-	// Default cache TTL of 30 minutes, can be customized if needed
-	cacheTTL := 30 * time.Minute
+	// Answers are kept for a day by default; their keys cover the model, the
+	// prompt and the whole configuration, so any change asks again
+	cacheTTL := 24 * time.Hour
 	if ttlEnv := os.Getenv("NEXLAYER_LLM_CACHE_TTL"); ttlEnv != "" {
 		if duration, err := time.ParseDuration(ttlEnv); err == nil {
 			cacheTTL = duration
@@ -80,6 +99,9 @@ func NewLLMEnricher(graph *Graph, metadataDir string) *LLMEnricher {
 		cacheTTL:       cacheTTL,
 		processingChan: processingChan,
 	}
+	if store, err := cache.Open(cache.KindLLM); err == nil {
+		enricher.store = store
+	}
 
 	// Start background workers
 	numWorkers := 2 // Default to 2 workers
@@ -96,28 +118,51 @@ func NewLLMEnricher(graph *Graph, metadataDir string) *LLMEnricher {
 	return enricher
 }
 
-// generateCacheKey creates a deterministic cache key from a prompt and context
+// WithProvider makes the enricher answer queries with an LLM, falling back
+// to the heuristic analysis when it fails, e.g. offline or over budget
+func (e *LLMEnricher) WithProvider(provider llm.Provider) *LLMEnricher {
+	e.provider = provider
+	return e
+}
+
+// generateCacheKey creates a deterministic cache key from the model, a prompt
+// and the whole configuration
 func (e *LLMEnricher) generateCacheKey(prompt string, config *schema.NexlayerYAML) string {
-	// Create a composite key from the prompt and relevant config data
-	var builder strings.Builder
-	builder.WriteString(prompt)
-
-	// Add key config elements if available
-	if config != nil {
-		builder.WriteString(config.Application.Name)
-		for _, pod := range config.Application.Pods {
-			builder.WriteString(pod.Name)
-			builder.WriteString(pod.Image)
-			for _, port := range pod.ServicePorts {
-				builder.WriteString(fmt.Sprintf("%d", port.Port))
-			}
+	model := ""
+	if e.provider != nil {
+		model = e.provider.Name()
+	}
+	// Configurations encode the same way every time, map keys sorted
+	encoded, _ := json.Marshal(config)
+	return cache.Key(model, prompt, string(encoded))
+}
+
+// cached returns the unexpired answer cached under key, from this run or,
+// for answers of a model, an earlier one
+func (e *LLMEnricher) cached(key string) (*LLMResult, bool) {
+	if value, found := e.cache.Load(key); found {
+		result := value.(*LLMResult)
+		if time.Since(result.Timestamp) < e.cacheTTL {
+			return result, true
 		}
+		e.cache.Delete(key)
+	}
+	var result LLMResult
+	if e.store != nil && e.store.Get(key, e.cacheTTL, &result) {
+		e.cache.Store(key, &result)
+		return &result, true
 	}
+	return nil, false
+}
 
-	// Create a SHA-256 hash of the combined string
-	hasher := sha256.New()
-	hasher.Write([]byte(builder.String()))
-	return hex.EncodeToString(hasher.Sum(nil))
+// remember caches an answer for this run and, when a model gave it, on disk
+// for the next ones. The heuristic analysis is cheap and changes with the CLI.
+func (e *LLMEnricher) remember(key string, result *LLMResult) {
+	e.cache.Store(key, result)
+	if e.store != nil && result.Source == SourceAPI {
+		// A cache that cannot be written only costs the next run a query
+		_ = e.store.Put(key, result)
+	}
 }
 
 // processTasksWorker handles background processing of LLM tasks
@@ -130,7 +175,7 @@ func (e *LLMEnricher) processTasksWorker() {
 			continue
 		default:
 			// Process the task
-			result, err := e.performLLMQuery(task.ctx, task.prompt, task.config)
+			result, err := e.performLLMQuery(task.ctx, task.prompt, task.config, nil)
 			if err != nil {
 				task.errCh <- err
 			} else {
@@ -142,21 +187,24 @@ func (e *LLMEnricher) processTasksWorker() {
 
 // QueryLLM performs an LLM query with caching and optimized performance
 func (e *LLMEnricher) QueryLLM(ctx context.Context, prompt string, config *schema.NexlayerYAML) (*LLMResult, error) {
-	cacheKey := e.generateCacheKey(prompt, config)
+	return e.QueryLLMStream(ctx, prompt, config, nil)
+}
 
+// QueryLLMStream performs an LLM query like QueryLLM, calling stream with
+// each part of the answer as it arrives
+func (e *LLMEnricher) QueryLLMStream(ctx context.Context, prompt string, config *schema.NexlayerYAML, stream func(text string)) (*LLMResult, error) {
 	// Check cache first
-	if cachedValue, found := e.cache.Load(cacheKey); found {
-		result := cachedValue.(*LLMResult)
-		// Check if cache entry is still valid
-		if time.Since(result.Timestamp) < e.cacheTTL {
-			return result, nil
+	if result, found := e.cached(e.generateCacheKey(prompt, config)); found {
+		cached := *result
+		cached.Source = SourceCache
+		if stream != nil {
+			stream(cached.Result)
 		}
-		// Cache expired, remove it
-		e.cache.Delete(cacheKey)
+		return &cached, nil
 	}
 
 	// Perform actual LLM query
-	return e.performLLMQuery(ctx, prompt, config)
+	return e.performLLMQuery(ctx, prompt, config, stream)
 }
 
 // QueryLLMAsync performs an asynchronous LLM query
@@ -165,20 +213,13 @@ func (e *LLMEnricher) QueryLLMAsync(ctx context.Context, prompt string, config *
 	errCh := make(chan error, 1)
 
 	// Check cache first for immediate response
-	cacheKey := e.generateCacheKey(prompt, config)
-	if cachedValue, found := e.cache.Load(cacheKey); found {
-		result := cachedValue.(*LLMResult)
-		// Check if cache entry is still valid
-		if time.Since(result.Timestamp) < e.cacheTTL {
-			go func() {
-				resultCh <- result
-				close(resultCh)
-				close(errCh)
-			}()
-			return resultCh, errCh
-		}
-		// Cache expired, remove it
-		e.cache.Delete(cacheKey)
+	if result, found := e.cached(e.generateCacheKey(prompt, config)); found {
+		go func() {
+			resultCh <- result
+			close(resultCh)
+			close(errCh)
+		}()
+		return resultCh, errCh
 	}
 
 	// Queue the task for background processing
@@ -205,58 +246,71 @@ func (e *LLMEnricher) QueryLLMAsync(ctx context.Context, prompt string, config *
 	return resultCh, errCh
 }
 
-// performLLMQuery is the actual implementation of the LLM query
-func (e *LLMEnricher) performLLMQuery(ctx context.Context, prompt string, config *schema.NexlayerYAML) (*LLMResult, error) {
-	// TODO: Implement actual LLM API call here
-	// For now, this is a placeholder that simulates an LLM response
+// performLLMQuery asks the provider, or the heuristic analysis when there is
+// none or it fails
+func (e *LLMEnricher) performLLMQuery(ctx context.Context, prompt string, config *schema.NexlayerYAML, stream func(string)) (*LLMResult, error) {
+	var fallback string
+	if e.provider != nil {
+		fullPrompt, err := e.GeneratePrompt(ctx, prompt, config)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := e.provider.Complete(ctx, llm.Request{System: systemPrompt, Prompt: fullPrompt}, stream)
+		if err == nil {
+			result := &LLMResult{Result: resp.Text, Timestamp: time.Now(), Source: SourceAPI, Model: e.provider.Name()}
+			e.remember(e.generateCacheKey(prompt, config), result)
+			return result, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		fallback = err.Error()
+	}
 
-	// Create an enriched context for better LLM understanding
 	enriched, err := e.EnrichContext(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to enrich context: %w", err)
 	}
-
-	// Simulate LLM processing time (remove in production)
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case <-time.After(100 * time.Millisecond):
-		// Continue processing
-	}
-
-	// Generate a simulated response based on the prompt and context
-	var response string
-	if strings.Contains(prompt, "deployment issues") {
-		response = e.simulateDeploymentIssueCheck(config, enriched)
-	} else if strings.Contains(prompt, "volume recommendations") {
-		response = e.simulateVolumeRecommendations(config, enriched)
-	} else if strings.Contains(prompt, "port configuration") {
-		response = e.simulatePortConfigurationCheck(config, enriched)
-	} else {
-		response = "LLM analysis complete. No issues detected."
-	}
-
-	// Create result
 	result := &LLMResult{
-		Result:    response,
+		Result:    e.heuristicQuery(prompt, config, enriched),
 		Timestamp: time.Now(),
-		Source:    "api", // This would be "api" in a real implementation
+		Source:    SourceHeuristic,
+		Fallback:  fallback,
+	}
+	if stream != nil {
+		stream(result.Result)
 	}
 
-	// Cache the result for future queries
-	cacheKey := e.generateCacheKey(prompt, config)
-	e.cache.Store(cacheKey, result)
-
+	// A failed request is tried again on the next query rather than cached
+	if fallback == "" {
+		e.remember(e.generateCacheKey(prompt, config), result)
+	}
 	return result, nil
 }
 
-// simulateDeploymentIssueCheck creates a simulated response for deployment issues
-func (e *LLMEnricher) simulateDeploymentIssueCheck(config *schema.NexlayerYAML, context *LLMContext) string {
+// heuristicQuery answers the prompts of the AI enhancer without a model
+func (e *LLMEnricher) heuristicQuery(prompt string, config *schema.NexlayerYAML, enriched *LLMContext) string {
+	if config == nil {
+		return "No configuration to analyze."
+	}
+	switch {
+	case strings.Contains(prompt, "deployment issues"):
+		return e.heuristicDeploymentIssueCheck(config, enriched)
+	case strings.Contains(prompt, "volume"):
+		return e.heuristicVolumeRecommendations(config, enriched)
+	case strings.Contains(prompt, "port configuration"):
+		return e.heuristicPortConfigurationCheck(config, enriched)
+	}
+	return "LLM analysis complete. No issues detected."
+}
+
+// heuristicDeploymentIssueCheck finds deployment issues without a model
+func (e *LLMEnricher) heuristicDeploymentIssueCheck(config *schema.NexlayerYAML, context *LLMContext) string {
 	var issues []string
 
 	// Check for missing ports
 	for _, pod := range config.Application.Pods {
-		if len(pod.ServicePorts) == 0 {
+		if len(pod.ServicePorts) == 0 && !pod.IsJob() {
 			issues = append(issues, fmt.Sprintf("- Pod '%s' has no service ports defined", pod.Name))
 		}
 	}
@@ -274,8 +328,8 @@ func (e *LLMEnricher) simulateDeploymentIssueCheck(config *schema.NexlayerYAML,
 	return "No deployment issues detected."
 }
 
-// simulateVolumeRecommendations creates a simulated response for volume recommendations
-func (e *LLMEnricher) simulateVolumeRecommendations(config *schema.NexlayerYAML, context *LLMContext) string {
+// heuristicVolumeRecommendations recommends volume sizes without a model
+func (e *LLMEnricher) heuristicVolumeRecommendations(config *schema.NexlayerYAML, context *LLMContext) string {
 	var recommendations []string
 
 	// Generate volume size recommendations based on pod type
@@ -296,8 +350,8 @@ func (e *LLMEnricher) simulateVolumeRecommendations(config *schema.NexlayerYAML,
 	return "No volume recommendations needed."
 }
 
-// simulatePortConfigurationCheck creates a simulated response for port configuration checks
-func (e *LLMEnricher) simulatePortConfigurationCheck(config *schema.NexlayerYAML, context *LLMContext) string {
+// heuristicPortConfigurationCheck checks the ports of databases without a model
+func (e *LLMEnricher) heuristicPortConfigurationCheck(config *schema.NexlayerYAML, context *LLMContext) string {
 	var recommendations []string
 
 	// Check for common port misconfigurations
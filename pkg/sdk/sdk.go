@@ -0,0 +1,222 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+// Package sdk deploys to Nexlayer from Go programs, without running the CLI.
+// It loads, validates and converts nexlayer.yaml files, starts deployments
+// and follows their status, doing what 'nexlayer deploy', 'nexlayer validate'
+// and 'nexlayer info' do. Nothing is printed: progress goes to the writer set
+// in DeployOptions, and problems are returned as errors.
+//
+//	client := sdk.New("https://app.nexlayer.io", token)
+//	config, err := sdk.Load("nexlayer.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	if problems := sdk.Validate(config, "."); len(problems) > 0 {
+//		return problems[0]
+//	}
+//	started, err := client.Deploy(ctx, config, sdk.DeployOptions{BaseDir: "."})
+//	if err != nil {
+//		return err
+//	}
+//	status, err := client.Wait(ctx, started.Namespace)
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/api"
+	apischema "github.com/Nexlayer/nexlayer-cli/pkg/core/api/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/compose"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/deployment"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/migrate"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/schema"
+	"github.com/Nexlayer/nexlayer-cli/pkg/core/validate"
+)
+
+// Intervals at which Wait polls a deployment
+const (
+	PollInterval    = 2 * time.Second
+	MaxPollInterval = 10 * time.Second
+)
+
+// Client talks to the Nexlayer API
+type Client struct {
+	api api.APIClient
+}
+
+// New creates a client for the API at apiURL, authenticating with token when
+// it is not empty
+func New(apiURL, token string) *Client {
+	c := api.NewClient(apiURL)
+	c.SetDebugOutput(nil)
+	if token != "" {
+		c.SetToken(token)
+	}
+	return &Client{api: c}
+}
+
+// NewWithAPI creates a client on top of an existing API client, such as one
+// shared with the rest of a program or a fake in tests
+func NewWithAPI(client api.APIClient) *Client {
+	return &Client{api: client}
+}
+
+// API returns the underlying API client, for endpoints the SDK does not wrap
+func (c *Client) API() api.APIClient {
+	return c.api
+}
+
+// Load reads a nexlayer.yaml, expands the services shorthand and normalizes
+// paths and port protocols the way 'nexlayer deploy' does
+func Load(file string) (*schema.NexlayerYAML, error) {
+	config, _, err := deployment.Load(file)
+	return config, err
+}
+
+// LoadEnv reads a nexlayer.yaml like Load, with the overlay of env, such as
+// nexlayer.prod.yaml, merged into it the way 'nexlayer deploy --env' does
+func LoadEnv(file, env string) (*schema.NexlayerYAML, error) {
+	config, _, err := deployment.LoadEnv(file, env)
+	return config, err
+}
+
+// Parse parses the contents of a nexlayer.yaml like Load
+func Parse(data []byte) (*schema.NexlayerYAML, error) {
+	config, _, err := deployment.Parse(data)
+	return config, err
+}
+
+// Validate checks a configuration and returns every problem found. Files the
+// configuration refers to are resolved against baseDir, the directory of the
+// nexlayer.yaml.
+func Validate(config *schema.NexlayerYAML, baseDir string) []validate.ValidationError {
+	v := validate.NewValidator(config).WithBaseDir(baseDir)
+	_ = v.Validate()
+	return v.Errors()
+}
+
+// Convert turns a Docker Compose file into a configuration named appName,
+// without AI enrichment. Env files and the project are read relative to the
+// directory of the compose file; warnings are dropped.
+func Convert(composeFile, appName string) (*schema.NexlayerYAML, error) {
+	return compose.Convert(composeFile, compose.ConvertOptions{
+		ProjectDir:      filepath.Dir(composeFile),
+		ApplicationName: appName,
+		Log:             io.Discard,
+	})
+}
+
+// DeployOptions tune Deploy
+type DeployOptions struct {
+	// AppID deploys to a registered application; empty deploys anonymously
+	AppID string
+	// BaseDir is the directory of the nexlayer.yaml, against which config
+	// files, envFrom files and static sites are resolved
+	BaseDir string
+	// Output receives build output and progress; nil discards it
+	Output io.Writer
+	// SkipValidation deploys configurations that failed Validate
+	SkipValidation bool
+	// ForceMigrations reruns migrations of a release that already succeeded
+	ForceMigrations bool
+}
+
+// ValidationErrors is returned by Deploy for invalid configurations
+type ValidationErrors []validate.ValidationError
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more problems)", e[0].Error(), len(e)-1)
+}
+
+// Deploy validates a configuration, uploads its static sites, runs its
+// migrations when they run before deploying and starts the deployment. It
+// returns once the platform accepted the deployment; use Wait to follow it.
+// Static sites set the image of their pods in config.
+func (c *Client) Deploy(ctx context.Context, config *schema.NexlayerYAML, opts DeployOptions) (*apischema.DeploymentResponse, error) {
+	if config == nil {
+		return nil, errors.New("no configuration to deploy")
+	}
+	out := opts.Output
+	if out == nil {
+		out = io.Discard
+	}
+	baseDir := opts.BaseDir
+	if baseDir == "" {
+		baseDir = "."
+	}
+
+	deployment.Normalize(config)
+	if !opts.SkipValidation {
+		if problems := Validate(config, baseDir); len(problems) > 0 {
+			return nil, ValidationErrors(problems)
+		}
+	}
+
+	if err := deployment.UploadStaticSites(ctx, c.api, config, baseDir, out); err != nil {
+		return nil, err
+	}
+	if err := schema.ResolveConfigFiles(config, baseDir); err != nil {
+		return nil, err
+	}
+	if err := schema.ResolveEnvFrom(config, baseDir); err != nil {
+		return nil, err
+	}
+
+	if m := config.Application.Migrations; m != nil && m.Policy() == schema.MigrationsBeforeDeploy {
+		if _, err := migrate.Apply(ctx, c.api, config, opts.ForceMigrations, out); err != nil {
+			return nil, fmt.Errorf("deployment aborted: %w", err)
+		}
+	}
+
+	file, cleanup, err := deployment.WriteTemp(config)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	resp, err := c.api.StartDeployment(ctx, opts.AppID, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start deployment: %w", err)
+	}
+	if resp.Data.Namespace == "" {
+		return nil, errors.New("deployment started but no namespace was returned from the API")
+	}
+	return &resp.Data, nil
+}
+
+// Status returns the current state of a deployment
+func (c *Client) Status(ctx context.Context, namespace string) (*apischema.Deployment, error) {
+	resp, err := c.api.GetDeploymentInfo(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// ErrDeploymentFailed is returned by Wait for deployments that settled
+// without running
+var ErrDeploymentFailed = errors.New("deployment failed")
+
+// Wait polls a deployment until it settles or ctx is done and returns its
+// last known state. A deployment that settled without running is returned
+// along with ErrDeploymentFailed.
+func (c *Client) Wait(ctx context.Context, namespace string) (*apischema.Deployment, error) {
+	d, err := deployment.Wait(ctx, c.api, namespace, PollInterval, MaxPollInterval, nil)
+	if err != nil {
+		return d, err
+	}
+	if !deployment.Succeeded(*d) {
+		return d, fmt.Errorf("%w: %s is %s", ErrDeploymentFailed, namespace, d.Status)
+	}
+	return d, nil
+}
@@ -37,7 +37,7 @@ func CheckPathSetup() error {
 	for _, gp := range gopaths {
 		gopathBin = filepath.Join(gp, "bin")
 		for _, dir := range pathDirs {
-			if dir == gopathBin && filepath.Dir(exePath) == gopathBin {
+			if SamePath(dir, gopathBin) && SamePath(filepath.Dir(exePath), gopathBin) {
 				binaryInPath = true
 				break
 			}
@@ -106,17 +106,22 @@ Set-ItemProperty -Path 'HKCU:\Environment' -Name Path -Value $env:Path`, gopathB
 
 // appendToShellConfig appends PATH update to shell config file
 func appendToShellConfig(shell, gopathBin string) error {
+	homeDir, err := HomeDir()
+	if err != nil {
+		return err
+	}
+
 	var configFile string
 	var command string
 	switch {
 	case strings.Contains(shell, "zsh"):
-		configFile = filepath.Join(os.Getenv("HOME"), ".zshrc")
+		configFile = filepath.Join(homeDir, ".zshrc")
 		command = fmt.Sprintf("\nexport PATH=\"$PATH:%s\"\n", gopathBin)
 	case strings.Contains(shell, "bash"), shell == "gitbash":
-		configFile = filepath.Join(os.Getenv("HOME"), ".bashrc")
+		configFile = filepath.Join(homeDir, ".bashrc")
 		command = fmt.Sprintf("\nexport PATH=\"$PATH:%s\"\n", gopathBin)
 	case strings.Contains(shell, "fish"):
-		configFile = filepath.Join(os.Getenv("HOME"), ".config", "fish", "config.fish")
+		configFile = filepath.Join(homeDir, ".config", "fish", "config.fish")
 		command = fmt.Sprintf("\nset -gx PATH $PATH %s\n", gopathBin)
 	default:
 		return fmt.Errorf("automatic PATH update not supported for %s", shell)
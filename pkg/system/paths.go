@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ContainerPath normalizes a path that will be used inside a (Linux) container.
+// Backslashes are converted to forward slashes and, on Windows, prefixes added by
+// MSYS/Git Bash path conversion are stripped so "/data" typed in Git Bash does not
+// arrive as "C:/Program Files/Git/data".
+func ContainerPath(p string) string {
+	if p == "" {
+		return p
+	}
+	p = strings.ReplaceAll(p, `\`, "/")
+	p = stripShellPathPrefix(p)
+	if !strings.HasPrefix(p, "/") {
+		return p
+	}
+	return path.Clean(p)
+}
+
+// IsContainerAbs reports whether p is an absolute container path once normalized.
+func IsContainerAbs(p string) bool {
+	return strings.HasPrefix(ContainerPath(p), "/")
+}
+
+// HostPath normalizes a path on the local machine, expanding a leading "~" to the
+// user's home directory and converting separators to the platform convention.
+func HostPath(p string) string {
+	if p == "" {
+		return p
+	}
+	if p == "~" || strings.HasPrefix(p, "~/") || strings.HasPrefix(p, `~\`) {
+		if home, err := os.UserHomeDir(); err == nil {
+			p = filepath.Join(home, p[1:])
+		}
+	}
+	return filepath.Clean(normalizeHostSeparators(p))
+}
+
+// HomeDir returns the current user's home directory. Unlike $HOME it is
+// populated on Windows, where the variable is usually unset.
+func HomeDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return home, nil
+}
+
+// SplitVolumeSpec splits a Docker volume specification ("src:dst[:mode]") into its
+// parts without breaking Windows drive letters such as "C:\data:/var/lib/data:ro".
+func SplitVolumeSpec(spec string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(spec); i++ {
+		if spec[i] != ':' {
+			continue
+		}
+		if isDriveColon(spec, start, i) {
+			continue
+		}
+		parts = append(parts, spec[start:i])
+		start = i + 1
+	}
+	return append(parts, spec[start:])
+}
+
+// isDriveColon reports whether the colon at index i terminates a drive letter
+// ("C:") that begins the segment starting at start.
+func isDriveColon(spec string, start, i int) bool {
+	if i != start+1 {
+		return false
+	}
+	c := spec[start]
+	if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')) {
+		return false
+	}
+	return i+1 < len(spec) && (spec[i+1] == '\\' || spec[i+1] == '/')
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package system
+
+import "path/filepath"
+
+// stripShellPathPrefix is a no-op outside Windows; POSIX shells do not rewrite paths.
+func stripShellPathPrefix(p string) string {
+	return p
+}
+
+// normalizeHostSeparators is a no-op on platforms that use "/" natively.
+func normalizeHostSeparators(p string) string {
+	return p
+}
+
+// SamePath reports whether two host paths refer to the same location.
+func SamePath(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+
+package system
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// msysRootPattern matches the install root that MSYS/Git Bash prepends when it
+// converts POSIX-looking arguments into Windows paths.
+var msysRootPattern = regexp.MustCompile(`(?i)^[a-z]:/(program files( \(x86\))?/git|msys64|msys32|cygwin64|cygwin)(/|$)`)
+
+// stripShellPathPrefix undoes MSYS path conversion for container paths.
+func stripShellPathPrefix(p string) string {
+	if loc := msysRootPattern.FindStringIndex(p); loc != nil {
+		return "/" + strings.TrimPrefix(p[loc[1]:], "/")
+	}
+	return p
+}
+
+// normalizeHostSeparators converts forward slashes to backslashes.
+func normalizeHostSeparators(p string) string {
+	return strings.ReplaceAll(p, "/", `\`)
+}
+
+// SamePath reports whether two host paths refer to the same location. Windows
+// file systems are case-insensitive, so the comparison ignores case.
+func SamePath(a, b string) bool {
+	return strings.EqualFold(filepath.Clean(a), filepath.Clean(b))
+}
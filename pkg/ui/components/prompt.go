@@ -12,6 +12,7 @@ import (
 // Prompt represents a user input prompt
 type Prompt struct {
 	label     string
+	defValue  string
 	validator func(string) error
 }
 
@@ -28,11 +29,19 @@ func (p *Prompt) WithValidator(validator func(string) error) *Prompt {
 	return p
 }
 
+// WithDefault sets the value the prompt starts with
+func (p *Prompt) WithDefault(value string) *Prompt {
+	p.defValue = value
+	return p
+}
+
 // Run runs the prompt and returns the user's input
 func (p *Prompt) Run() (string, error) {
 	prompt := promptui.Prompt{
-		Label:    p.label,
-		Validate: p.validator,
+		Label:     p.label,
+		Default:   p.defValue,
+		AllowEdit: p.defValue != "",
+		Validate:  p.validator,
 	}
 
 	result, err := prompt.Run()
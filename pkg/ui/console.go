@@ -0,0 +1,86 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package ui
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/muesli/termenv"
+)
+
+// Glyphs holds the status symbols used in CLI output
+type Glyphs struct {
+	Success   string
+	Error     string
+	Warning   string
+	Info      string
+	Highlight string
+	Bullet    string
+}
+
+var (
+	unicodeGlyphs = Glyphs{Success: "✅", Error: "❌", Warning: "⚠️ ", Info: "ℹ️", Highlight: "✨", Bullet: "•"}
+	asciiGlyphs   = Glyphs{Success: "[ok]", Error: "[x]", Warning: "[!]", Info: "[i]", Highlight: "*", Bullet: "-"}
+
+	consoleOnce    sync.Once
+	consoleUnicode bool
+	consoleANSI    bool
+)
+
+// detectConsole inspects the environment once and caches the result.
+// NEXLAYER_ASCII=1 forces plain output; NO_COLOR disables escape sequences.
+func detectConsole() {
+	consoleOnce.Do(func() {
+		dumb := os.Getenv("TERM") == "dumb"
+		consoleUnicode = !dumb && platformSupportsUnicode()
+		consoleANSI = !dumb && platformSupportsANSI()
+		if v := strings.ToLower(os.Getenv("NEXLAYER_ASCII")); v == "1" || v == "true" {
+			consoleUnicode = false
+		}
+		if _, ok := os.LookupEnv("NO_COLOR"); ok {
+			consoleANSI = false
+		}
+	})
+}
+
+// SupportsUnicode reports whether the console can render emoji and box symbols
+func SupportsUnicode() bool {
+	detectConsole()
+	return consoleUnicode
+}
+
+// SupportsANSI reports whether the console understands ANSI escape sequences
+func SupportsANSI() bool {
+	detectConsole()
+	return consoleANSI
+}
+
+// Symbols returns the glyph set appropriate for the current console
+func Symbols() Glyphs {
+	if SupportsUnicode() {
+		return unicodeGlyphs
+	}
+	return asciiGlyphs
+}
+
+// ClearLine returns the sequence that rewinds and clears the current line
+func ClearLine() string {
+	if SupportsANSI() {
+		return "\r\033[K"
+	}
+	return "\r"
+}
+
+// ConfigureConsole disables colored output when the console cannot render it
+func ConfigureConsole() {
+	if !SupportsANSI() {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		color.NoColor = true
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package ui
+
+// platformSupportsUnicode assumes a UTF-8 capable terminal on POSIX systems.
+func platformSupportsUnicode() bool {
+	return true
+}
+
+// platformSupportsANSI assumes an ANSI capable terminal on POSIX systems.
+func platformSupportsANSI() bool {
+	return true
+}
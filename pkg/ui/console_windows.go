@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+
+package ui
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// modernTerminal reports whether we are running inside a terminal that renders
+// UTF-8 and emoji (Windows Terminal, VS Code, ConEmu, mintty).
+func modernTerminal() bool {
+	return os.Getenv("WT_SESSION") != "" ||
+		os.Getenv("TERM_PROGRAM") != "" ||
+		os.Getenv("ConEmuANSI") == "ON" ||
+		os.Getenv("TERM") != ""
+}
+
+// platformSupportsUnicode is false for the legacy conhost used by cmd.exe.
+func platformSupportsUnicode() bool {
+	return modernTerminal()
+}
+
+// platformSupportsANSI enables virtual terminal processing on the console and
+// reports whether it succeeded.
+func platformSupportsANSI() bool {
+	if modernTerminal() {
+		return true
+	}
+	handle := windows.Handle(os.Stdout.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	return windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Nexlayer. All rights reserved.
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of command results
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+var (
+	outputFormat           = OutputText
+	results      io.Writer = os.Stdout
+)
+
+// SetOutputFormat selects how commands write their results. With json or
+// yaml, results are the only thing written to standard output: everything
+// else commands print goes to standard error, so that the output can be
+// piped to tools such as jq.
+func SetOutputFormat(format string) error {
+	switch format {
+	case "", OutputText:
+		return nil
+	case OutputJSON, OutputYAML:
+	default:
+		return fmt.Errorf("invalid output format %q: use %s, %s or %s", format, OutputText, OutputJSON, OutputYAML)
+	}
+	if outputFormat == OutputText {
+		results = os.Stdout
+		os.Stdout = os.Stderr
+	}
+	outputFormat = format
+	return nil
+}
+
+// OutputFormat returns the format of command results
+func OutputFormat() string {
+	return outputFormat
+}
+
+// Structured reports whether results are written as json or yaml
+func Structured() bool {
+	return outputFormat != OutputText
+}
+
+// WriteOutput writes the result of a command in the selected format, json
+// when it is text. YAML uses the JSON field names, in the same order.
+func WriteOutput(v interface{}) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	if outputFormat != OutputYAML {
+		_, err := results.Write(buf.Bytes())
+		return err
+	}
+
+	// JSON is YAML, so decoding it keeps the order of the fields
+	var node yaml.Node
+	if err := yaml.Unmarshal(buf.Bytes(), &node); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	blockStyle(&node)
+	out := yaml.NewEncoder(results)
+	out.SetIndent(2)
+	if err := out.Encode(&node); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return out.Close()
+}
+
+// WriteStream writes one of a stream of results, such as followed log lines:
+// a line of JSON, so that the stream is JSON Lines, or a YAML document
+func WriteStream(v interface{}) error {
+	if outputFormat == OutputYAML {
+		if _, err := io.WriteString(results, "---\n"); err != nil {
+			return err
+		}
+		return WriteOutput(v)
+	}
+	enc := json.NewEncoder(results)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	return nil
+}
+
+// blockStyle drops the flow style and quotes of JSON; the encoder quotes the
+// strings that need it
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
@@ -51,13 +51,13 @@ func (p *progressBar) Update(progress float64, msg string) {
 	}
 
 	// Clear the current line and print the progress bar.
-	fmt.Printf("\r\033[K[%s] %.1f%% %s (%s)", bar, progress, p.message, elapsed)
+	fmt.Printf("%s[%s] %.1f%% %s (%s)", ClearLine(), bar, progress, p.message, elapsed)
 }
 
 // Complete finalizes the progress bar, clears the line, and prints a completion message.
 func (p *progressBar) Complete() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	fmt.Print("\r\033[K")
-	color.Green("✓ %s (%.2fs)", p.message, time.Since(p.started).Seconds())
+	fmt.Print(ClearLine())
+	color.Green("%s %s (%.2fs)", Symbols().Success, p.message, time.Since(p.started).Seconds())
 }
@@ -101,22 +101,22 @@ func RenderWelcome(text string) {
 
 // RenderHighlight renders highlighted text
 func RenderHighlight(text string) {
-	fmt.Printf("\n✨ %s\n", text)
+	fmt.Printf("\n%s %s\n", Symbols().Highlight, text)
 }
 
 // RenderSuccess renders a success message
 func RenderSuccess(text string) {
-	fmt.Printf("\n✅ %s\n", text)
+	fmt.Printf("\n%s %s\n", Symbols().Success, text)
 }
 
 // RenderError renders an error message
 func RenderError(text string) {
-	fmt.Printf("\n❌ %s\n", text)
+	fmt.Printf("\n%s %s\n", Symbols().Error, text)
 }
 
 // RenderWarning renders a warning message
 func RenderWarning(text string) {
-	fmt.Printf("\n⚠️  %s\n", text)
+	fmt.Printf("\n%s %s\n", Symbols().Warning, text)
 }
 
 // Spinner represents a CLI progress spinner